@@ -0,0 +1,90 @@
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type Action string
+
+const (
+	ActionAllow Action = "Allow"
+)
+
+type PolicyType string
+
+const (
+	PolicyTypeIngress PolicyType = "Ingress"
+	PolicyTypeEgress  PolicyType = "Egress"
+)
+
+// NetworkPolicySpec is the specification of a Calico NetworkPolicy
+type NetworkPolicySpec struct {
+	Selector string       `json:"selector,omitempty"`
+	Types    []PolicyType `json:"types,omitempty"`
+	Ingress  []Rule       `json:"ingress,omitempty"`
+	Egress   []Rule       `json:"egress,omitempty"`
+}
+
+type Rule struct {
+	Action      Action     `json:"action"`
+	Protocol    string     `json:"protocol,omitempty"`
+	Source      EntityRule `json:"source,omitempty"`
+	Destination EntityRule `json:"destination,omitempty"`
+}
+
+type EntityRule struct {
+	Nets              []string             `json:"nets,omitempty"`
+	NotNets           []string             `json:"notNets,omitempty"`
+	Selector          string               `json:"selector,omitempty"`
+	NamespaceSelector string               `json:"namespaceSelector,omitempty"`
+	Ports             []intstr.IntOrString `json:"ports,omitempty"`
+}
+
+// NetworkSetSpec is the specification of a Calico NetworkSet
+type NetworkSetSpec struct {
+	Nets                 []string `json:"nets,omitempty"`
+	AllowedEgressDomains []string `json:"allowedEgressDomains,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NetworkPolicy is the Schema for the Calico NetworkPolicy API
+type NetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NetworkPolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=networkpolicies
+//+kubebuilder:skip
+
+type NetworkPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NetworkPolicy `json:"items"`
+}
+
+//+kubebuilder:object:root=true
+
+// NetworkSet is the Schema for the Calico NetworkSet API
+type NetworkSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NetworkSetSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+type NetworkSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NetworkSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NetworkPolicy{}, &NetworkPolicyList{}, &NetworkSet{}, &NetworkSetList{})
+}
@@ -0,0 +1,85 @@
+package v2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Entity is a Cilium entity, i.e. "world" or "all"
+type Entity string
+
+const (
+	EntityAll   Entity = "all"
+	EntityWorld Entity = "world"
+)
+
+// Rule is the specification of a CiliumNetworkPolicy
+type Rule struct {
+	EndpointSelector metav1.LabelSelector `json:"endpointSelector"`
+	Ingress          []IngressRule        `json:"ingress,omitempty"`
+	Egress           []EgressRule         `json:"egress,omitempty"`
+	Description      string               `json:"description,omitempty"`
+}
+
+type IngressRule struct {
+	FromEndpoints []metav1.LabelSelector `json:"fromEndpoints,omitempty"`
+	FromCIDRSet   []CIDRRule             `json:"fromCIDRSet,omitempty"`
+	FromEntities  []Entity               `json:"fromEntities,omitempty"`
+	ToPorts       []PortRule             `json:"toPorts,omitempty"`
+}
+
+type EgressRule struct {
+	ToEndpoints []metav1.LabelSelector `json:"toEndpoints,omitempty"`
+	ToCIDRSet   []CIDRRule             `json:"toCIDRSet,omitempty"`
+	ToEntities  []Entity               `json:"toEntities,omitempty"`
+	ToFQDNs     []FQDNSelector         `json:"toFQDNs,omitempty"`
+	ToPorts     []PortRule             `json:"toPorts,omitempty"`
+}
+
+type CIDRRule struct {
+	Cidr        string   `json:"cidr"`
+	ExceptCIDRs []string `json:"except,omitempty"`
+}
+
+type FQDNSelector struct {
+	MatchName    string `json:"matchName,omitempty"`
+	MatchPattern string `json:"matchPattern,omitempty"`
+}
+
+type PortRule struct {
+	Ports []PortProtocol `json:"ports,omitempty"`
+	Rules *L7Rules       `json:"rules,omitempty"`
+}
+
+type PortProtocol struct {
+	Port     string `json:"port"`
+	EndPort  int32  `json:"endPort,omitempty"`
+	Protocol string `json:"protocol,omitempty"`
+}
+
+type L7Rules struct {
+	DNS []FQDNSelector `json:"dns,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CiliumNetworkPolicy is the Schema for the CiliumNetworkPolicy API
+type CiliumNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec *Rule `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=ciliumnetworkpolicies
+//+kubebuilder:skip
+
+type CiliumNetworkPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CiliumNetworkPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CiliumNetworkPolicy{}, &CiliumNetworkPolicyList{})
+}
@@ -0,0 +1,14 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Resource(t *testing.T) {
+	grp := Resource("test")
+
+	assert.Equal(t, "test", grp.Resource)
+	assert.Equal(t, "cilium.io", grp.Group)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/posit-dev/team-operator/api/product"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -21,11 +22,33 @@ type ChronicleSpec struct {
 
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 
+	// PodSchedulingConfig sets the affinity, tolerations, topology spread constraints, priority class and extra
+	// metadata of the server pods
+	PodSchedulingConfig `json:",inline"`
+
+	// SecurityProfile hardens the pods to comply with a Pod Security Standard
+	// +optional
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+
+	// Overrides patch the objects that the operator generates for the product. Overrides that fail to apply are
+	// listed in the status
+	// +optional
+	Overrides []Override `json:"overrides,omitempty"`
+
 	// AddEnv adds arbitrary environment variables to the container env
 	AddEnv map[string]string `json:"addEnv,omitempty"`
 
 	Image string `json:"image,omitempty"`
 
+	// Resources are the resource requests and limits of the Chronicle container. Defaults to DefaultChronicleResources
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// AgentResources are the resource requests and limits of the Chronicle agent sidecar of each product. Defaults
+	// to DefaultChronicleAgentResources
+	// +optional
+	AgentResources *corev1.ResourceRequirements `json:"agentResources,omitempty"`
+
 	// AwsAccountId is the account Id for this AWS Account. It is used to create EKS-to-IAM annotations
 	AwsAccountId string `json:"awsAccountId,omitempty"`
 
@@ -39,6 +62,10 @@ type ChronicleSpec struct {
 // ChronicleStatus defines the observed state of Chronicle
 type ChronicleStatus struct {
 	Ready bool `json:"ready"`
+
+	// FailedOverrides lists the overrides that failed to apply, i.e. "Deployment/connect: <error>"
+	// +optional
+	FailedOverrides []string `json:"failedOverrides,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -148,3 +175,12 @@ func (c *Chronicle) GetChronicleAgentImage() string {
 	//   - does chronicle care what version the agents are?
 	return fmt.Sprintf("ghcr.io/rstudio/chronicle:2023.10.4")
 }
+
+func (c *Chronicle) GetChronicleAgentResources() corev1.ResourceRequirements {
+	return resourcesOrDefault(c.Spec.AgentResources, DefaultChronicleAgentResources)
+}
+
+// GetResources returns the resources of the Chronicle server container, defaulting to DefaultChronicleResources
+func (c *Chronicle) GetResources() corev1.ResourceRequirements {
+	return resourcesOrDefault(c.Spec.Resources, DefaultChronicleResources)
+}
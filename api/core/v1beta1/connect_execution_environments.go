@@ -0,0 +1,165 @@
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/posit-dev/team-operator/api/product"
+)
+
+// ConnectExecutionEnvironment is an image that Connect runs content in, with the R, Python and Quarto installations
+// that it provides. It becomes an image of runtime.yaml. An environment without installations has them discovered
+// from the co.posit.r.versions, co.posit.python.versions and co.posit.quarto.versions labels of the image, at the
+// paths that its co.posit.r.path, co.posit.python.path and co.posit.quarto.path labels list
+type ConnectExecutionEnvironment struct {
+	// Image is the image that content runs in, i.e. ghcr.io/rstudio/content-pro:r4.4.1-py3.12.4-ubuntu2204
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+
+	// Tag replaces the tag of Image
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// R are the installations of R in the image
+	// +optional
+	R []ConnectRuntimeInstallation `json:"r,omitempty"`
+
+	// Python are the installations of Python in the image
+	// +optional
+	Python []ConnectRuntimeInstallation `json:"python,omitempty"`
+
+	// Quarto are the installations of Quarto in the image
+	// +optional
+	Quarto []ConnectRuntimeInstallation `json:"quarto,omitempty"`
+}
+
+// ConnectRuntimeInstallation is an installation of R, Python or Quarto in an execution environment
+type ConnectRuntimeInstallation struct {
+	// Version is the version of the installation, i.e. 4.4.1
+	// +kubebuilder:validation:MinLength=1
+	Version string `json:"version"`
+
+	// Path is the absolute path of the executable, i.e. /opt/R/4.4.1/bin/R
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path"`
+}
+
+// DiscoversInstallations reports whether the installations of the environment are discovered from the labels of its
+// image
+func (e *ConnectExecutionEnvironment) DiscoversInstallations() bool {
+	return len(e.R) == 0 && len(e.Python) == 0 && len(e.Quarto) == 0
+}
+
+// ImageName is the Image with its Tag, as content refers to it
+func (e *ConnectExecutionEnvironment) ImageName() string {
+	if e.Tag == "" {
+		return e.Image
+	}
+	name, _, _ := strings.Cut(e.Image, "@")
+	// the tag is after the last colon of the image name, which may include a registry port
+	if i := strings.LastIndex(name, ":"); i >= 0 && !strings.Contains(name[i:], "/") {
+		name = name[:i]
+	}
+	return name + ":" + e.Tag
+}
+
+// RuntimeYAMLImageEntry returns the runtime.yaml image of the execution environment, or an error if Connect could not
+// run content in it
+func (e *ConnectExecutionEnvironment) RuntimeYAMLImageEntry() (product.RuntimeYAMLImageEntry, error) {
+	name := e.ImageName()
+	if name == "" || strings.ContainsAny(name, " \t\n") {
+		return product.RuntimeYAMLImageEntry{}, fmt.Errorf("%w: image %q is not an image reference", product.InvalidConnectRuntimeImageDefinitionError, name)
+	}
+	if strings.Contains(e.Tag, ":") || strings.Contains(e.Tag, "/") {
+		return product.RuntimeYAMLImageEntry{}, fmt.Errorf("%w: tag %q of image %s is not a tag", product.InvalidConnectRuntimeImageDefinitionError, e.Tag, e.Image)
+	}
+	if e.DiscoversInstallations() {
+		return product.RuntimeYAMLImageEntry{}, fmt.Errorf("%w: image %s has no R, Python or Quarto installation", product.InvalidConnectRuntimeImageDefinitionError, name)
+	}
+
+	entry := product.RuntimeYAMLImageEntry{Name: name}
+	var errs []error
+	for _, s := range []struct {
+		runtime       string
+		installations []ConnectRuntimeInstallation
+		section       *product.RuntimeYAMLSection
+	}{
+		{"R", e.R, &entry.R},
+		{"Python", e.Python, &entry.Python},
+		{"Quarto", e.Quarto, &entry.Quarto},
+	} {
+		versions := map[string]bool{}
+		for _, i := range s.installations {
+			switch {
+			case i.Version == "":
+				errs = append(errs, fmt.Errorf("an %s installation of image %s has no version", s.runtime, name))
+			case versions[i.Version]:
+				errs = append(errs, fmt.Errorf("image %s has more than one %s %s installation", name, s.runtime, i.Version))
+			case !path.IsAbs(i.Path):
+				errs = append(errs, fmt.Errorf("the path %q of %s %s in image %s is not absolute", i.Path, s.runtime, i.Version, name))
+			}
+			versions[i.Version] = true
+			s.section.Installations = append(s.section.Installations, product.RuntimeYAMLInstallation{
+				Path:    i.Path,
+				Version: i.Version,
+			})
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return product.RuntimeYAMLImageEntry{}, fmt.Errorf("%w: %w", product.InvalidConnectRuntimeImageDefinitionError, err)
+	}
+	return entry, nil
+}
+
+// ValidateExecutionEnvironments returns an error describing the execution environments that Connect could not run
+// content in. Environments whose installations are discovered are validated once they are
+func (c *ConnectSpec) ValidateExecutionEnvironments() error {
+	_, err := c.runtimeYAMLImages()
+	return err
+}
+
+// runtimeYAMLImages returns the images of runtime.yaml: the default images and the ExecutionEnvironments, or only
+// the ExecutionEnvironments with ReplaceDefaultExecutionEnvironments. Environments whose installations have not been
+// discovered are left out
+func (c *ConnectSpec) runtimeYAMLImages() ([]product.RuntimeYAMLImageEntry, error) {
+	var images []product.RuntimeYAMLImageEntry
+	if !c.ReplaceDefaultExecutionEnvironments {
+		for _, img := range defaultConnectRuntimeImages {
+			entry, err := img.GenerateImageEntry()
+			if err != nil {
+				return nil, err
+			}
+			images = append(images, entry)
+		}
+	} else if len(c.ExecutionEnvironments) == 0 {
+		return nil, fmt.Errorf("%w: replaceDefaultExecutionEnvironments requires executionEnvironments", product.InvalidConnectRuntimeImageDefinitionError)
+	}
+
+	seen := map[string]bool{}
+	for _, img := range images {
+		seen[img.Name] = true
+	}
+	var errs []error
+	for i := range c.ExecutionEnvironments {
+		if c.ExecutionEnvironments[i].DiscoversInstallations() {
+			continue
+		}
+		entry, err := c.ExecutionEnvironments[i].RuntimeYAMLImageEntry()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if seen[entry.Name] {
+			errs = append(errs, fmt.Errorf("%w: image %s is defined more than once", product.InvalidConnectRuntimeImageDefinitionError, entry.Name))
+			continue
+		}
+		seen[entry.Name] = true
+		images = append(images, entry)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return images, nil
+}
@@ -17,6 +17,8 @@ import (
 )
 
 // ConnectSpec defines the desired state of Connect
+// +kubebuilder:validation:XValidation:rule="!has(self.securityProfile) || self.securityProfile == ” || (has(self.offHostExecution) && self.offHostExecution)",message="a securityProfile requires offHostExecution"
+// +kubebuilder:validation:XValidation:rule="!has(self.replaceDefaultExecutionEnvironments) || !self.replaceDefaultExecutionEnvironments || (has(self.executionEnvironments) && size(self.executionEnvironments) > 0)",message="replaceDefaultExecutionEnvironments requires executionEnvironments"
 type ConnectSpec struct {
 	License       product.LicenseSpec    `json:"license,omitempty"`
 	Config        ConnectConfig          `json:"config,omitempty"`
@@ -27,6 +29,20 @@ type ConnectSpec struct {
 
 	Url string `json:"url,omitempty"`
 
+	// RootPath is the path the product is served under on Url (i.e. "/connect"). Empty serves the product at the
+	// root of Url
+	// +optional
+	RootPath string `json:"rootPath,omitempty"`
+
+	// Aliases are additional hosts that the product is served on
+	// +optional
+	Aliases []string `json:"aliases,omitempty"`
+
+	// TLSSecretName is the TLS Secret for the Ingress. When set, the URL and aliases are listed in the Ingress
+	// TLS section
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
 	DatabaseConfig PostgresDatabaseConfig `json:"databaseConfig,omitempty"`
 
 	// IngressClass is the ingress class to be used when creating ingress routes
@@ -35,12 +51,33 @@ type ConnectSpec struct {
 	// IngressAnnotations is a set of annotations to be applied to all ingress routes
 	IngressAnnotations map[string]string `json:"ingressAnnotations,omitempty"`
 
+	// Routing selects between Ingress and Gateway API HTTPRoute resources
+	// +optional
+	Routing *RoutingConfig `json:"routing,omitempty"`
+
+	// IngressPolicy adds rate limiting, an IP allow-list, a maximum body size and retries to the Ingress
+	// +optional
+	IngressPolicy *IngressPolicy `json:"ingressPolicy,omitempty"`
+
 	// ImagePullSecrets is a set of image pull secrets to use for all image pulls. These names / secrets
 	// must already exist in the namespace in question.
 	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
 
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 
+	// PodSchedulingConfig sets the affinity, tolerations, topology spread constraints, priority class and extra
+	// metadata of the server pods
+	PodSchedulingConfig `json:",inline"`
+
+	// SecurityProfile hardens the pods to comply with a Pod Security Standard
+	// +optional
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+
+	// Overrides patch the objects that the operator generates for the product. Overrides that fail to apply are
+	// listed in the status
+	// +optional
+	Overrides []Override `json:"overrides,omitempty"`
+
 	// AddEnv adds arbitrary environment variables to the container env
 	AddEnv map[string]string `json:"addEnv,omitempty"`
 
@@ -85,6 +122,34 @@ type ConnectSpec struct {
 
 	Replicas int `json:"replicas,omitempty"`
 
+	// Autoscaling creates a HorizontalPodAutoscaler for the Deployment, which then owns the replica count
+	// +optional
+	Autoscaling *AutoscalingConfig `json:"autoscaling,omitempty"`
+
+	// SessionQuota caps the resources of the content pods with a ResourceQuota and a LimitRange
+	// +optional
+	SessionQuota *SessionQuota `json:"sessionQuota,omitempty"`
+
+	// ExecutionEnvironments are images that content can run in with off-host execution. They are added to the
+	// default images of runtime.yaml. Environments without installations have them discovered from the labels of
+	// their image
+	// +optional
+	ExecutionEnvironments []ConnectExecutionEnvironment `json:"executionEnvironments,omitempty"`
+
+	// ReplaceDefaultExecutionEnvironments runs content only in the ExecutionEnvironments, instead of also in the
+	// default images
+	// +optional
+	ReplaceDefaultExecutionEnvironments bool `json:"replaceDefaultExecutionEnvironments,omitempty"`
+
+	// Resources are the resource requests and limits of the Connect container. Defaults to DefaultConnectResources
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ChronicleAgentResources are the resource requests and limits of the Chronicle agent sidecar. Defaults to
+	// DefaultChronicleAgentResources
+	// +optional
+	ChronicleAgentResources *corev1.ResourceRequirements `json:"chronicleAgentResources,omitempty"`
+
 	// DsnSecret is the name of the secret that contains the DSN to include with all Connect sessions
 	DsnSecret string `json:"dsnSecret,omitempty"`
 
@@ -101,6 +166,24 @@ type ConnectSpec struct {
 type ConnectStatus struct {
 	KeySecretRef corev1.SecretReference `json:"keySecretRef,omitempty"`
 	Ready        bool                   `json:"ready"`
+
+	// FailedOverrides lists the overrides that failed to apply, i.e. "Deployment/connect: <error>"
+	// +optional
+	FailedOverrides []string `json:"failedOverrides,omitempty"`
+
+	// SessionQuota is the usage of the content pods against their quota
+	// +optional
+	SessionQuota *SessionQuotaStatus `json:"sessionQuota,omitempty"`
+
+	// ExecutionEnvironmentWarnings lists the execution environments whose installations could not be discovered and
+	// that runtime.yaml leaves out, i.e. "image has no runtime version labels: ghcr.io/acme/content:1"
+	// +optional
+	ExecutionEnvironmentWarnings []string `json:"executionEnvironmentWarnings,omitempty"`
+
+	// DiscoveredExecutionEnvironments are the execution environments whose installations were discovered, by image
+	// name. An environment whose registry cannot be read keeps the installations that were last discovered
+	// +optional
+	DiscoveredExecutionEnvironments []ConnectExecutionEnvironment `json:"discoveredExecutionEnvironments,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -148,6 +231,15 @@ func (c *Connect) GetChronicleAgentImage() string {
 	return c.Spec.ChronicleAgentImage
 }
 
+func (c *Connect) GetChronicleAgentResources() corev1.ResourceRequirements {
+	return resourcesOrDefault(c.Spec.ChronicleAgentResources, DefaultChronicleAgentResources)
+}
+
+// GetResources returns the resources of the Connect container, defaulting to DefaultConnectResources
+func (c *Connect) GetResources() corev1.ResourceRequirements {
+	return resourcesOrDefault(c.Spec.Resources, DefaultConnectResources)
+}
+
 func (c *Connect) KeySecretName() string {
 	return fmt.Sprintf("%s-key", c.ComponentName())
 }
@@ -224,36 +316,37 @@ func (c *Connect) OwnerReferencesForChildren() []metav1.OwnerReference {
 	}
 }
 
+// defaultConnectRuntimeImages are the images of runtime.yaml unless ReplaceDefaultExecutionEnvironments is set
+var defaultConnectRuntimeImages = []product.ConnectRuntimeImageDefinition{
+	{
+		PyVersion:     "3.12.4",
+		RVersion:      "4.4.1",
+		OSVersion:     "ubuntu2204",
+		QuartoVersion: "1.4.557",
+		Repo:          "ghcr.io/rstudio/content-pro",
+	},
+	{
+		PyVersion:     "3.11.3",
+		RVersion:      "4.2.2",
+		OSVersion:     "ubuntu2204",
+		QuartoVersion: "1.3.340",
+		Repo:          "ghcr.io/rstudio/content-pro",
+	},
+}
+
+// DefaultRuntimeYAML returns the runtime.yaml of the default images and the ExecutionEnvironments
 func (c *Connect) DefaultRuntimeYAML() (string, error) {
-	def := product.ConnectRuntimeDefinition{
-		Images: []product.RuntimeYAMLImageEntry{},
+	images, err := c.Spec.runtimeYAMLImages()
+	if err != nil {
+		return "", err
 	}
-
-	for _, img := range []product.ConnectRuntimeImageDefinition{
-		{
-			PyVersion:     "3.12.4",
-			RVersion:      "4.4.1",
-			OSVersion:     "ubuntu2204",
-			QuartoVersion: "1.4.557",
-			Repo:          "ghcr.io/rstudio/content-pro",
-		},
-		{
-			PyVersion:     "3.11.3",
-			RVersion:      "4.2.2",
-			OSVersion:     "ubuntu2204",
-			QuartoVersion: "1.3.340",
-			Repo:          "ghcr.io/rstudio/content-pro",
-		},
-	} {
-
-		imgEntry, err := img.GenerateImageEntry()
-		if err != nil {
-			return "", err
-		}
-
-		def.Images = append(def.Images, imgEntry)
+	if len(images) == 0 {
+		return "", fmt.Errorf("%w: no execution environment has R, Python or Quarto installations", product.InvalidConnectRuntimeImageDefinitionError)
 	}
 
+	def := product.ConnectRuntimeDefinition{
+		Images: images,
+	}
 	return def.BuildDefaultRuntimeYAML()
 }
 
@@ -801,6 +894,17 @@ func (c *Connect) SessionConfigTemplateData(ctx context.Context) string {
 		sess = defaultSessionConfig
 	}
 
+	// the session quota only counts the pods of its PriorityClass, which is the default for them
+	if q := c.Spec.SessionQuota; q != nil {
+		if sess.Pod.PriorityClassName == "" {
+			sess.Pod.PriorityClassName = q.PriorityClassName
+		} else if sess.Pod.PriorityClassName != q.PriorityClassName {
+			l.Info("the session quota does not count the session pods, because the session config sets another priorityClassName", "priorityClassName", sess.Pod.PriorityClassName)
+		}
+	}
+
+	c.Spec.SecurityProfile.HardenSession(sess.Pod)
+
 	if str, err := sess.GenerateSessionConfigTemplate(); err != nil {
 		l.Error(err, "Error generating session config template")
 		return ""
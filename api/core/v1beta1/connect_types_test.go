@@ -307,6 +307,94 @@ func TestConnect_DefaultRuntimeYAML(t *testing.T) {
 	r.Equal(repo, "ghcr.io/rstudio/content-pro")
 }
 
+func TestConnect_DefaultRuntimeYAML_ExecutionEnvironments(t *testing.T) {
+	r := require.New(t)
+
+	hardened := ConnectExecutionEnvironment{
+		Image: "registry.example.com:5000/hardened/content:2024.10",
+		Tag:   "2025.01",
+		R:     []ConnectRuntimeInstallation{{Version: "4.4.2", Path: "/usr/lib/R/bin/R"}},
+		Python: []ConnectRuntimeInstallation{
+			{Version: "3.12.8", Path: "/usr/local/bin/python3.12"},
+			{Version: "3.11.11", Path: "/usr/local/bin/python3.11"},
+		},
+	}
+	con := &Connect{
+		Spec: ConnectSpec{
+			ExecutionEnvironments: []ConnectExecutionEnvironment{hardened},
+		},
+	}
+	parse := func() *product.ConnectRuntimeDefinition {
+		runtimeYaml, err := con.DefaultRuntimeYAML()
+		r.NoError(err)
+		parsed := &product.ConnectRuntimeDefinition{}
+		r.NoError(yaml.Unmarshal([]byte(runtimeYaml), parsed))
+		return parsed
+	}
+
+	// the execution environments extend the default images
+	parsed := parse()
+	r.Len(parsed.Images, 3)
+	r.Equal(product.RuntimeYAMLImageEntry{
+		Name: "registry.example.com:5000/hardened/content:2025.01",
+		R:    product.RuntimeYAMLSection{Installations: []product.RuntimeYAMLInstallation{{Path: "/usr/lib/R/bin/R", Version: "4.4.2"}}},
+		Python: product.RuntimeYAMLSection{Installations: []product.RuntimeYAMLInstallation{
+			{Path: "/usr/local/bin/python3.12", Version: "3.12.8"},
+			{Path: "/usr/local/bin/python3.11", Version: "3.11.11"},
+		}},
+	}, parsed.Images[2])
+
+	// or replace them
+	con.Spec.ReplaceDefaultExecutionEnvironments = true
+	parsed = parse()
+	r.Len(parsed.Images, 1)
+	r.Equal("registry.example.com:5000/hardened/content:2025.01", parsed.Images[0].Name)
+
+	// which requires execution environments
+	con.Spec.ExecutionEnvironments = nil
+	r.ErrorIs(con.Spec.ValidateExecutionEnvironments(), product.InvalidConnectRuntimeImageDefinitionError)
+
+	// an execution environment without installations is left out until they are discovered
+	con.Spec.ExecutionEnvironments = []ConnectExecutionEnvironment{{Image: "content:1"}}
+	r.NoError(con.Spec.ValidateExecutionEnvironments())
+	_, err := con.DefaultRuntimeYAML()
+	r.ErrorIs(err, product.InvalidConnectRuntimeImageDefinitionError)
+	con.Spec.ExecutionEnvironments[0].R = []ConnectRuntimeInstallation{{Version: "4.4.2", Path: "/opt/R/4.4.2/bin/R"}}
+	con.Spec.ExecutionEnvironments[0].Quarto = []ConnectRuntimeInstallation{{Version: "1.6.39", Path: "/opt/quarto/1.6.39/bin/quarto"}}
+	parsed = parse()
+	r.Equal([]product.RuntimeYAMLImageEntry{{
+		Name:   "content:1",
+		R:      product.RuntimeYAMLSection{Installations: []product.RuntimeYAMLInstallation{{Path: "/opt/R/4.4.2/bin/R", Version: "4.4.2"}}},
+		Quarto: product.RuntimeYAMLSection{Installations: []product.RuntimeYAMLInstallation{{Path: "/opt/quarto/1.6.39/bin/quarto", Version: "1.6.39"}}},
+	}}, parsed.Images)
+
+	// every execution environment must be usable
+	for name, env := range map[string]ConnectExecutionEnvironment{
+		"relative path": {Image: "content:1", Quarto: []ConnectRuntimeInstallation{{Version: "1.6.39", Path: "quarto"}}},
+		"no version":    {Image: "content:1", R: []ConnectRuntimeInstallation{{Path: "/opt/R/bin/R"}}},
+		"bad tag":       {Image: "content", Tag: "a:b", R: []ConnectRuntimeInstallation{{Version: "4.4.2", Path: "/opt/R/bin/R"}}},
+		"duplicate version": {Image: "content:1", R: []ConnectRuntimeInstallation{
+			{Version: "4.4.2", Path: "/opt/R/a/bin/R"},
+			{Version: "4.4.2", Path: "/opt/R/b/bin/R"},
+		}},
+	} {
+		con.Spec.ExecutionEnvironments = []ConnectExecutionEnvironment{env}
+		r.ErrorIs(con.Spec.ValidateExecutionEnvironments(), product.InvalidConnectRuntimeImageDefinitionError, name)
+		_, err := con.DefaultRuntimeYAML()
+		r.Error(err, name)
+	}
+
+	// and defined once
+	con.Spec.ExecutionEnvironments = []ConnectExecutionEnvironment{hardened, hardened}
+	r.ErrorContains(con.Spec.ValidateExecutionEnvironments(), "defined more than once")
+	con.Spec.ReplaceDefaultExecutionEnvironments = false
+	con.Spec.ExecutionEnvironments = []ConnectExecutionEnvironment{{
+		Image: "ghcr.io/rstudio/content-pro:r4.4.1-py3.12.4-ubuntu2204",
+		R:     []ConnectRuntimeInstallation{{Version: "4.4.1", Path: "/opt/R/4.4.1/bin/R"}},
+	}}
+	r.ErrorContains(con.Spec.ValidateExecutionEnvironments(), "defined more than once")
+}
+
 func TestConnect_CreateSessionVolumeFactory(t *testing.T) {
 	con := &Connect{
 		ObjectMeta: v1.ObjectMeta{
@@ -22,6 +22,23 @@ type FlightdeckSpec struct {
 	// ImagePullPolicy controls when the kubelet pulls the image
 	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
 
+	// NodeSelector is the node selector of the Flightdeck pods
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// PodSchedulingConfig sets the affinity, tolerations, topology spread constraints, priority class and extra
+	// metadata of the server pods
+	PodSchedulingConfig `json:",inline"`
+
+	// SecurityProfile hardens the pods to comply with a Pod Security Standard
+	// +optional
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+
+	// Overrides patch the objects that the operator generates for the product. Overrides that fail to apply are
+	// listed in the status
+	// +optional
+	Overrides []Override `json:"overrides,omitempty"`
+
 	// Port is the port that the container will listen on
 	// +kubebuilder:default=8080
 	Port int32 `json:"port,omitempty"`
@@ -30,18 +47,39 @@ type FlightdeckSpec struct {
 	// +kubebuilder:default=1
 	Replicas int `json:"replicas,omitempty"`
 
+	// Autoscaling creates a HorizontalPodAutoscaler for the Deployment, which then owns the replica count
+	// +optional
+	Autoscaling *AutoscalingConfig `json:"autoscaling,omitempty"`
+
+	// Resources are the resource requests and limits of the Flightdeck container. Defaults to DefaultFlightdeckResources
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
 	// FeatureEnabler controls which features are enabled in Flightdeck
 	FeatureEnabler FeatureEnablerConfig `json:"featureEnabler,omitempty"`
 
 	// Domain is the domain name for Flightdeck ingress
 	Domain string `json:"domain,omitempty"`
 
+	// Aliases are additional hosts that the product is served on
+	// +optional
+	Aliases []string `json:"aliases,omitempty"`
+
+	// TLSSecretName is the TLS Secret for the Ingress. When set, the domain and aliases are listed in the Ingress
+	// TLS section
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
 	// IngressClass is the ingress class to use
 	IngressClass string `json:"ingressClass,omitempty"`
 
 	// IngressAnnotations are annotations to apply to the ingress
 	IngressAnnotations map[string]string `json:"ingressAnnotations,omitempty"`
 
+	// Routing selects between an Ingress and a Gateway API HTTPRoute
+	// +optional
+	Routing *RoutingConfig `json:"routing,omitempty"`
+
 	// ImagePullSecrets are references to secrets for pulling images
 	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
 
@@ -67,6 +105,10 @@ type FlightdeckSpec struct {
 type FlightdeckStatus struct {
 	// Ready indicates whether the Flightdeck deployment is ready
 	Ready bool `json:"ready"`
+
+	// FailedOverrides lists the overrides that failed to apply, i.e. "Deployment/connect: <error>"
+	// +optional
+	FailedOverrides []string `json:"failedOverrides,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -121,3 +163,8 @@ func (f *Flightdeck) KubernetesLabels() map[string]string {
 func (f *Flightdeck) ComponentName() string {
 	return fmt.Sprintf("%s-flightdeck", f.Name)
 }
+
+// GetResources returns the resources of the Flightdeck container, defaulting to DefaultFlightdeckResources
+func (f *Flightdeck) GetResources() corev1.ResourceRequirements {
+	return resourcesOrDefault(f.Spec.Resources, DefaultFlightdeckResources)
+}
@@ -0,0 +1,87 @@
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// GetPeriod returns the period that Average applies to, defaulting to a second
+func (r *IngressRateLimit) GetPeriod() time.Duration {
+	if r.Period == nil || r.Period.Duration <= 0 {
+		return time.Second
+	}
+	return r.Period.Duration
+}
+
+// RequestsPer returns the limit as a whole number of requests per unit (i.e. time.Minute), or false when it is not a
+// whole number
+func (r *IngressRateLimit) RequestsPer(unit time.Duration) (int64, bool) {
+	rate := big.NewRat(r.Average, int64(r.GetPeriod()))
+	rate.Mul(rate, big.NewRat(int64(unit), 1))
+	if !rate.IsInt() || !rate.Num().IsInt64() || rate.Num().Int64() < 1 {
+		return 0, false
+	}
+	return rate.Num().Int64(), true
+}
+
+// ValidateIngressPolicy returns an error describing the settings of policy that the routing mode and ingress
+// provider cannot enforce. Traefik enforces all of them
+func (r *RoutingConfig) ValidateIngressPolicy(policy *IngressPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if r.UseGateway() {
+		return fmt.Errorf("ingressPolicy is not supported in %q routing mode", RoutingModeGateway)
+	}
+
+	provider := r.GetProvider()
+	var errs []error
+	switch provider {
+	case IngressProviderNginx:
+		if policy.IPAllowList != nil && policy.IPAllowList.Depth > 0 {
+			errs = append(errs, fmt.Errorf("ingressPolicy ipAllowList.depth is not supported by the %q provider; configure the real client IP on ingress-nginx instead", provider))
+		}
+		if policy.RateLimit != nil {
+			if _, ok := policy.RateLimit.RequestsPer(time.Second); !ok {
+				if _, ok := policy.RateLimit.RequestsPer(time.Minute); !ok {
+					errs = append(errs, fmt.Errorf("ingressPolicy rateLimit must be a whole number of requests per second or per minute for the %q provider", provider))
+				}
+			}
+		}
+		if policy.Retry != nil && policy.Retry.InitialInterval != nil {
+			errs = append(errs, fmt.Errorf("ingressPolicy retry.initialInterval is not supported by the %q provider, which retries immediately", provider))
+		}
+	case IngressProviderAlb:
+		if policy.IPAllowList != nil && policy.IPAllowList.Depth > 0 {
+			errs = append(errs, fmt.Errorf("ingressPolicy ipAllowList.depth is not supported by the %q provider", provider))
+		}
+		if policy.RateLimit != nil {
+			errs = append(errs, fmt.Errorf("ingressPolicy rateLimit is not supported by the %q provider", provider))
+		}
+		if policy.MaxBodySize != nil {
+			errs = append(errs, fmt.Errorf("ingressPolicy maxBodySize is not supported by the %q provider", provider))
+		}
+		if policy.Retry != nil {
+			errs = append(errs, fmt.Errorf("ingressPolicy retry is not supported by the %q provider", provider))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateIngressPolicies returns an error describing the product ingress policies that the Site's routing cannot
+// enforce
+func (s *SiteSpec) ValidateIngressPolicies() error {
+	var errs []error
+	if err := s.Routing.ValidateIngressPolicy(s.Connect.IngressPolicy); err != nil {
+		errs = append(errs, fmt.Errorf("connect: %w", err))
+	}
+	if err := s.Routing.ValidateIngressPolicy(s.Workbench.IngressPolicy); err != nil {
+		errs = append(errs, fmt.Errorf("workbench: %w", err))
+	}
+	if err := s.Routing.ValidateIngressPolicy(s.PackageManager.IngressPolicy); err != nil {
+		errs = append(errs, fmt.Errorf("packageManager: %w", err))
+	}
+	return errors.Join(errs...)
+}
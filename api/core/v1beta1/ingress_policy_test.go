@@ -0,0 +1,80 @@
+package v1beta1_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIngressRateLimitRequestsPer(t *testing.T) {
+	r := require.New(t)
+
+	perSecond := &v1beta1.IngressRateLimit{Average: 10}
+	n, ok := perSecond.RequestsPer(time.Second)
+	r.True(ok)
+	r.Equal(int64(10), n)
+	n, ok = perSecond.RequestsPer(time.Minute)
+	r.True(ok)
+	r.Equal(int64(600), n)
+
+	perMinute := &v1beta1.IngressRateLimit{Average: 30, Period: &metav1.Duration{Duration: time.Minute}}
+	_, ok = perMinute.RequestsPer(time.Second)
+	r.False(ok)
+	n, ok = perMinute.RequestsPer(time.Minute)
+	r.True(ok)
+	r.Equal(int64(30), n)
+
+	odd := &v1beta1.IngressRateLimit{Average: 7, Period: &metav1.Duration{Duration: 11 * time.Second}}
+	_, ok = odd.RequestsPer(time.Minute)
+	r.False(ok)
+}
+
+func TestRoutingConfigValidateIngressPolicy(t *testing.T) {
+	r := require.New(t)
+
+	maxBodySize := resource.MustParse("10Mi")
+	policy := &v1beta1.IngressPolicy{
+		RateLimit:   &v1beta1.IngressRateLimit{Average: 7, Period: &metav1.Duration{Duration: 11 * time.Second}},
+		IPAllowList: &v1beta1.IngressIPAllowList{SourceRanges: []string{"203.0.113.0/24"}, Depth: 1},
+		MaxBodySize: &maxBodySize,
+		Retry:       &v1beta1.IngressRetry{Attempts: 3, InitialInterval: &metav1.Duration{Duration: time.Second}},
+	}
+
+	// traefik enforces everything, and no policy is always valid
+	r.NoError((*v1beta1.RoutingConfig)(nil).ValidateIngressPolicy(policy))
+	r.NoError((&v1beta1.RoutingConfig{Mode: v1beta1.RoutingModeGateway}).ValidateIngressPolicy(nil))
+
+	r.ErrorContains((&v1beta1.RoutingConfig{Mode: v1beta1.RoutingModeGateway}).ValidateIngressPolicy(policy), "gateway")
+
+	nginx := &v1beta1.RoutingConfig{Provider: v1beta1.IngressProviderNginx}
+	err := nginx.ValidateIngressPolicy(policy)
+	r.ErrorContains(err, "ipAllowList.depth")
+	r.ErrorContains(err, "rateLimit")
+	r.ErrorContains(err, "retry.initialInterval")
+	r.NotContains(err.Error(), "maxBodySize")
+	r.NoError(nginx.ValidateIngressPolicy(&v1beta1.IngressPolicy{
+		RateLimit:   &v1beta1.IngressRateLimit{Average: 30, Period: &metav1.Duration{Duration: time.Minute}},
+		IPAllowList: &v1beta1.IngressIPAllowList{SourceRanges: []string{"203.0.113.0/24"}},
+		MaxBodySize: &maxBodySize,
+		Retry:       &v1beta1.IngressRetry{Attempts: 3},
+	}))
+
+	alb := &v1beta1.RoutingConfig{Provider: v1beta1.IngressProviderAlb}
+	err = alb.ValidateIngressPolicy(policy)
+	r.ErrorContains(err, "ipAllowList.depth")
+	r.ErrorContains(err, "rateLimit")
+	r.ErrorContains(err, "maxBodySize")
+	r.ErrorContains(err, "retry")
+	r.NoError(alb.ValidateIngressPolicy(&v1beta1.IngressPolicy{
+		IPAllowList: &v1beta1.IngressIPAllowList{SourceRanges: []string{"203.0.113.0/24"}},
+	}))
+
+	// the site names the product whose policy is rejected
+	site := v1beta1.SiteSpec{Routing: alb}
+	site.Workbench.IngressPolicy = &v1beta1.IngressPolicy{MaxBodySize: &maxBodySize}
+	r.ErrorContains(site.ValidateIngressPolicies(), "workbench: ingressPolicy maxBodySize")
+}
@@ -74,6 +74,11 @@ func (k *Keycloak) SecretProviderClass(request controllerruntime.Request) (*v1.S
 	)
 }
 
+// ServiceName is the name of the Service created by the keycloak operator for this instance
+func (k *Keycloak) ServiceName() string {
+	return fmt.Sprintf("%s-service", k.ComponentName())
+}
+
 func (k *Keycloak) MiddlewareForwardName() string {
 	return fmt.Sprintf("%s-keycloak-forward", k.Site.Name)
 }
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+package v1beta1
+
+// OverridePatchType is the format of an Override's patch
+// +kubebuilder:validation:Enum=strategic-merge;json6902
+type OverridePatchType string
+
+const (
+	// OverridePatchTypeStrategicMerge is a Kubernetes strategic merge patch, i.e. a partial object whose lists of
+	// containers, volumes, etc. are merged by name
+	OverridePatchTypeStrategicMerge OverridePatchType = "strategic-merge"
+
+	// OverridePatchTypeJSON6902 is a list of RFC 6902 JSON patch operations
+	OverridePatchTypeJSON6902 OverridePatchType = "json6902"
+)
+
+// Override patches an object that the operator generates. It fills the gap until a spec field exists for the
+// setting, i.e. a hostAliases entry on the Connect Deployment or an extra label on an Ingress
+type Override struct {
+	// Kind of the generated object, i.e. Deployment, Service or Ingress
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+
+	// Name of the generated object. Empty targets every object of the kind
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Type of the patch. Defaults to strategic-merge
+	// +kubebuilder:default=strategic-merge
+	// +optional
+	Type OverridePatchType `json:"type,omitempty"`
+
+	// Patch is the patch as YAML or JSON
+	// +kubebuilder:validation:MinLength=1
+	Patch string `json:"patch"`
+}
+
+// Targets reports whether the override patches the object of kind with name
+func (o *Override) Targets(kind, name string) bool {
+	return o.Kind == kind && (o.Name == "" || o.Name == name)
+}
+
+func (s *Site) GetOverrides() []Override {
+	return s.Spec.Overrides
+}
+
+func (s *Site) RecordOverrideFailure(failure string) {
+	s.Status.FailedOverrides = append(s.Status.FailedOverrides, failure)
+}
+
+func (c *Connect) GetOverrides() []Override {
+	return c.Spec.Overrides
+}
+
+func (c *Connect) RecordOverrideFailure(failure string) {
+	c.Status.FailedOverrides = append(c.Status.FailedOverrides, failure)
+}
+
+func (w *Workbench) GetOverrides() []Override {
+	return w.Spec.Overrides
+}
+
+func (w *Workbench) RecordOverrideFailure(failure string) {
+	w.Status.FailedOverrides = append(w.Status.FailedOverrides, failure)
+}
+
+func (pm *PackageManager) GetOverrides() []Override {
+	return pm.Spec.Overrides
+}
+
+func (pm *PackageManager) RecordOverrideFailure(failure string) {
+	pm.Status.FailedOverrides = append(pm.Status.FailedOverrides, failure)
+}
+
+func (c *Chronicle) GetOverrides() []Override {
+	return c.Spec.Overrides
+}
+
+func (c *Chronicle) RecordOverrideFailure(failure string) {
+	c.Status.FailedOverrides = append(c.Status.FailedOverrides, failure)
+}
+
+func (f *Flightdeck) GetOverrides() []Override {
+	return f.Spec.Overrides
+}
+
+func (f *Flightdeck) RecordOverrideFailure(failure string) {
+	f.Status.FailedOverrides = append(f.Status.FailedOverrides, failure)
+}
@@ -23,6 +23,20 @@ type PackageManagerSpec struct {
 
 	Url string `json:"url,omitempty"`
 
+	// RootPath is the path the product is served under on Url (i.e. "/packagemanager"). Empty serves the product at the
+	// root of Url
+	// +optional
+	RootPath string `json:"rootPath,omitempty"`
+
+	// Aliases are additional hosts that the product is served on
+	// +optional
+	Aliases []string `json:"aliases,omitempty"`
+
+	// TLSSecretName is the TLS Secret for the Ingress. When set, the URL and aliases are listed in the Ingress
+	// TLS section
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
 	DatabaseConfig PostgresDatabaseConfig `json:"databaseConfig,omitempty"`
 
 	// IngressClass is the ingress class to be used when creating ingress routes
@@ -31,12 +45,33 @@ type PackageManagerSpec struct {
 	// IngressAnnotations is a set of annotations to be applied to all ingress routes
 	IngressAnnotations map[string]string `json:"ingressAnnotations,omitempty"`
 
+	// Routing selects between Ingress and Gateway API HTTPRoute resources
+	// +optional
+	Routing *RoutingConfig `json:"routing,omitempty"`
+
+	// IngressPolicy adds rate limiting, an IP allow-list, a maximum body size and retries to the Ingress
+	// +optional
+	IngressPolicy *IngressPolicy `json:"ingressPolicy,omitempty"`
+
 	// ImagePullSecrets is a set of image pull secrets to use for all image pulls. These names / secrets
 	// must already exist in the namespace in question.
 	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
 
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 
+	// PodSchedulingConfig sets the affinity, tolerations, topology spread constraints, priority class and extra
+	// metadata of the server pods
+	PodSchedulingConfig `json:",inline"`
+
+	// SecurityProfile hardens the pods to comply with a Pod Security Standard
+	// +optional
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+
+	// Overrides patch the objects that the operator generates for the product. Overrides that fail to apply are
+	// listed in the status
+	// +optional
+	Overrides []Override `json:"overrides,omitempty"`
+
 	// AddEnv adds arbitrary environment variables to the container env
 	AddEnv map[string]string `json:"addEnv,omitempty"`
 
@@ -71,6 +106,19 @@ type PackageManagerSpec struct {
 
 	Replicas int `json:"replicas,omitempty"`
 
+	// Autoscaling creates a HorizontalPodAutoscaler for the Deployment, which then owns the replica count
+	// +optional
+	Autoscaling *AutoscalingConfig `json:"autoscaling,omitempty"`
+
+	// Resources are the resource requests and limits of the Package Manager container. Defaults to DefaultPackageManagerResources
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ChronicleAgentResources are the resource requests and limits of the Chronicle agent sidecar. Defaults to
+	// DefaultChronicleAgentResources
+	// +optional
+	ChronicleAgentResources *v1.ResourceRequirements `json:"chronicleAgentResources,omitempty"`
+
 	// GitSSHKeys defines SSH key configurations for Git authentication
 	// This is used for mounting SSH keys but not included in the .gcfg file
 	// +optional
@@ -85,6 +133,10 @@ type PackageManagerSpec struct {
 type PackageManagerStatus struct {
 	KeySecretRef v1.SecretReference `json:"keySecretRef,omitempty"`
 	Ready        bool               `json:"ready"`
+
+	// FailedOverrides lists the overrides that failed to apply, i.e. "Deployment/connect: <error>"
+	// +optional
+	FailedOverrides []string `json:"failedOverrides,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -171,6 +223,15 @@ func (pm *PackageManager) GetChronicleAgentImage() string {
 	return pm.Spec.ChronicleAgentImage
 }
 
+func (pm *PackageManager) GetChronicleAgentResources() v1.ResourceRequirements {
+	return resourcesOrDefault(pm.Spec.ChronicleAgentResources, DefaultChronicleAgentResources)
+}
+
+// GetResources returns the resources of the Package Manager container, defaulting to DefaultPackageManagerResources
+func (pm *PackageManager) GetResources() v1.ResourceRequirements {
+	return resourcesOrDefault(pm.Spec.Resources, DefaultPackageManagerResources)
+}
+
 // SelectorLabels are immutable!
 func (pm *PackageManager) SelectorLabels() map[string]string {
 	return map[string]string{
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/rstudio/goex/ptr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SecurityProfile is a Pod Security Standard that the product pods comply with
+// +kubebuilder:validation:Enum="";baseline;restricted
+type SecurityProfile string
+
+const (
+	// SecurityProfileNone leaves the products' security contexts as they are. On-host execution in Connect needs a
+	// privileged container, for example
+	SecurityProfileNone SecurityProfile = ""
+
+	// SecurityProfileBaseline complies with the "baseline" Pod Security Standard: no privileged containers and the
+	// RuntimeDefault seccomp profile
+	SecurityProfileBaseline SecurityProfile = "baseline"
+
+	// SecurityProfileRestricted complies with the "restricted" Pod Security Standard: in addition to baseline, the
+	// containers run as non-root users without privilege escalation and with all capabilities dropped
+	SecurityProfileRestricted SecurityProfile = "restricted"
+)
+
+// DefaultNonRootUID is the user that product containers run as under the restricted profile, unless they already
+// run as a non-root user. It is the service user of the Posit product images
+const DefaultNonRootUID int64 = 999
+
+const (
+	PodSecurityEnforceLabelKey        = "pod-security.kubernetes.io/enforce"
+	PodSecurityEnforceVersionLabelKey = "pod-security.kubernetes.io/enforce-version"
+	PodSecurityWarnLabelKey           = "pod-security.kubernetes.io/warn"
+	PodSecurityAuditLabelKey          = "pod-security.kubernetes.io/audit"
+
+	// PodSecurityProfileAnnotationKey records the profile that the operator labeled a namespace with, so that the
+	// labels are only removed again when the operator set them
+	PodSecurityProfileAnnotationKey = "posit.team/security-profile"
+)
+
+// Enabled reports whether the profile hardens the product pods
+func (p SecurityProfile) Enabled() bool {
+	return p != SecurityProfileNone
+}
+
+// PodSecurityLabels returns the Pod Security Admission labels of a namespace that enforce the profile
+func (p SecurityProfile) PodSecurityLabels() map[string]string {
+	if !p.Enabled() {
+		return nil
+	}
+	return map[string]string{
+		PodSecurityEnforceLabelKey:        string(p),
+		PodSecurityEnforceVersionLabelKey: "latest",
+		PodSecurityWarnLabelKey:           string(p),
+		PodSecurityAuditLabelKey:          string(p),
+	}
+}
+
+// HardenContainer returns a copy of sc that complies with the profile. A container that would run as root runs as
+// DefaultNonRootUID under the restricted profile
+func (p SecurityProfile) HardenContainer(sc *corev1.SecurityContext) *corev1.SecurityContext {
+	if !p.Enabled() {
+		return sc
+	}
+	out := &corev1.SecurityContext{}
+	if sc != nil {
+		out = sc.DeepCopy()
+	}
+	out.Privileged = ptr.To(false)
+	out.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	if p == SecurityProfileRestricted {
+		out.AllowPrivilegeEscalation = ptr.To(false)
+		out.RunAsNonRoot = ptr.To(true)
+		if out.RunAsUser != nil && *out.RunAsUser == 0 {
+			out.RunAsUser = ptr.To(DefaultNonRootUID)
+		}
+		out.Capabilities = &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}}
+	}
+	return out
+}
+
+// HardenPod makes every container of spec comply with the profile. Under the restricted profile, containers that
+// do not set a user run as DefaultNonRootUID
+func (p SecurityProfile) HardenPod(spec *corev1.PodSpec) {
+	if !p.Enabled() {
+		return
+	}
+	if spec.SecurityContext == nil {
+		spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	spec.SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	if p == SecurityProfileRestricted {
+		spec.SecurityContext.RunAsNonRoot = ptr.To(true)
+		if spec.SecurityContext.RunAsUser == nil || *spec.SecurityContext.RunAsUser == 0 {
+			spec.SecurityContext.RunAsUser = ptr.To(DefaultNonRootUID)
+		}
+	}
+	for i := range spec.InitContainers {
+		spec.InitContainers[i].SecurityContext = p.HardenContainer(spec.InitContainers[i].SecurityContext)
+	}
+	for i := range spec.Containers {
+		spec.Containers[i].SecurityContext = p.HardenContainer(spec.Containers[i].SecurityContext)
+	}
+}
+
+// HardenSession makes the containers of the session pods that the launcher creates from pod comply with the profile
+func (p SecurityProfile) HardenSession(pod *product.PodConfig) {
+	if !p.Enabled() || pod == nil {
+		return
+	}
+	pod.ContainerSecurityContext = *p.HardenContainer(&pod.ContainerSecurityContext)
+	for i := range pod.InitContainers {
+		pod.InitContainers[i].SecurityContext = p.HardenContainer(pod.InitContainers[i].SecurityContext)
+	}
+	for i := range pod.ExtraContainers {
+		pod.ExtraContainers[i].SecurityContext = p.HardenContainer(pod.ExtraContainers[i].SecurityContext)
+	}
+}
+
+// ValidateSecurityProfile returns an error describing the features of the Site that conflict with its
+// SecurityProfile
+func (s *SiteSpec) ValidateSecurityProfile() error {
+	if !s.SecurityProfile.Enabled() {
+		return nil
+	}
+	var errs []error
+	if s.Workbench.ExperimentalFeatures != nil && s.Workbench.ExperimentalFeatures.PrivilegedSessions {
+		errs = append(errs, fmt.Errorf("workbench privilegedSessions conflicts with the %q security profile", s.SecurityProfile))
+	}
+	if s.SecurityProfile == SecurityProfileRestricted && s.runsSubdirJob() {
+		errs = append(errs, fmt.Errorf("the volume subdirectory job runs as root, which conflicts with the %q security profile; set volumeSubdirJobOff", s.SecurityProfile))
+	}
+	return errors.Join(errs...)
+}
+
+// runsSubdirJob reports whether a Job provisions the volume subdirectories
+func (s *SiteSpec) runsSubdirJob() bool {
+	return !s.VolumeSubdirJobOff && (s.VolumeSource.Type == VolumeSourceTypeFsxZfs || s.VolumeSource.Type == VolumeSourceTypeNfs)
+}
+
+// ValidateSecurityProfile returns an error when Connect's configuration conflicts with its SecurityProfile
+func (c *ConnectSpec) ValidateSecurityProfile() error {
+	if c.SecurityProfile.Enabled() && !c.OffHostExecution {
+		return fmt.Errorf("on-host execution needs a privileged container, which conflicts with the %q security profile; enable offHostExecution", c.SecurityProfile)
+	}
+	return nil
+}
+
+// ValidateSecurityProfile returns an error when Workbench's configuration conflicts with its SecurityProfile
+func (w *WorkbenchSpec) ValidateSecurityProfile() error {
+	if !w.SecurityProfile.Enabled() {
+		return nil
+	}
+	var errs []error
+	if !w.OffHostExecution {
+		errs = append(errs, fmt.Errorf("on-host sessions conflict with the %q security profile; enable offHostExecution", w.SecurityProfile))
+	}
+	if w.SecurityProfile == SecurityProfileRestricted && !w.NonRoot {
+		errs = append(errs, fmt.Errorf("the %q security profile requires nonRoot", w.SecurityProfile))
+	}
+	if w.SessionConfig != nil && w.SessionConfig.Pod != nil {
+		if privileged := w.SessionConfig.Pod.ContainerSecurityContext.Privileged; privileged != nil && *privileged {
+			errs = append(errs, fmt.Errorf("privileged sessions conflict with the %q security profile", w.SecurityProfile))
+		}
+	}
+	return errors.Join(errs...)
+}
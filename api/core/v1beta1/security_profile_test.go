@@ -0,0 +1,133 @@
+package v1beta1_test
+
+import (
+	"testing"
+
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/rstudio/goex/ptr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSecurityProfileHardenPod(t *testing.T) {
+	r := require.New(t)
+
+	podSpec := func() *corev1.PodSpec {
+		return &corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init"}},
+			Containers: []corev1.Container{{
+				Name: "server",
+				SecurityContext: &corev1.SecurityContext{
+					RunAsUser:                ptr.To(int64(0)),
+					AllowPrivilegeEscalation: ptr.To(true),
+					Privileged:               ptr.To(true),
+				},
+			}},
+		}
+	}
+
+	// no profile leaves the pod alone
+	spec := podSpec()
+	v1beta1.SecurityProfileNone.HardenPod(spec)
+	r.Equal(podSpec(), spec)
+
+	spec = podSpec()
+	v1beta1.SecurityProfileBaseline.HardenPod(spec)
+	r.Equal(corev1.SeccompProfileTypeRuntimeDefault, spec.SecurityContext.SeccompProfile.Type)
+	r.Nil(spec.SecurityContext.RunAsNonRoot)
+	server := spec.Containers[0].SecurityContext
+	r.False(*server.Privileged)
+	r.Equal(int64(0), *server.RunAsUser)
+	r.True(*server.AllowPrivilegeEscalation)
+	r.Equal(corev1.SeccompProfileTypeRuntimeDefault, spec.InitContainers[0].SecurityContext.SeccompProfile.Type)
+
+	spec = podSpec()
+	v1beta1.SecurityProfileRestricted.HardenPod(spec)
+	r.True(*spec.SecurityContext.RunAsNonRoot)
+	r.Equal(v1beta1.DefaultNonRootUID, *spec.SecurityContext.RunAsUser)
+	for _, c := range append(spec.InitContainers, spec.Containers...) {
+		r.False(*c.SecurityContext.Privileged, c.Name)
+		r.False(*c.SecurityContext.AllowPrivilegeEscalation, c.Name)
+		r.True(*c.SecurityContext.RunAsNonRoot, c.Name)
+		r.Equal([]corev1.Capability{"ALL"}, c.SecurityContext.Capabilities.Drop, c.Name)
+	}
+	r.Equal(v1beta1.DefaultNonRootUID, *spec.Containers[0].SecurityContext.RunAsUser)
+
+	// a non-root user is kept
+	spec = podSpec()
+	spec.SecurityContext = &corev1.PodSecurityContext{RunAsUser: ptr.To(int64(1000))}
+	v1beta1.SecurityProfileRestricted.HardenPod(spec)
+	r.Equal(int64(1000), *spec.SecurityContext.RunAsUser)
+}
+
+func TestSecurityProfileHardenSession(t *testing.T) {
+	r := require.New(t)
+
+	pod := &product.PodConfig{
+		InitContainers:  []corev1.Container{{Name: "init"}},
+		ExtraContainers: []corev1.Container{{Name: "extra"}},
+	}
+	v1beta1.SecurityProfileRestricted.HardenSession(pod)
+	r.True(*pod.ContainerSecurityContext.RunAsNonRoot)
+	r.False(*pod.ContainerSecurityContext.AllowPrivilegeEscalation)
+	r.True(*pod.InitContainers[0].SecurityContext.RunAsNonRoot)
+	r.True(*pod.ExtraContainers[0].SecurityContext.RunAsNonRoot)
+
+	// a nil pod is fine
+	v1beta1.SecurityProfileRestricted.HardenSession(nil)
+}
+
+func TestSecurityProfilePodSecurityLabels(t *testing.T) {
+	r := require.New(t)
+
+	r.Nil(v1beta1.SecurityProfileNone.PodSecurityLabels())
+	r.Equal(map[string]string{
+		v1beta1.PodSecurityEnforceLabelKey:        "restricted",
+		v1beta1.PodSecurityEnforceVersionLabelKey: "latest",
+		v1beta1.PodSecurityWarnLabelKey:           "restricted",
+		v1beta1.PodSecurityAuditLabelKey:          "restricted",
+	}, v1beta1.SecurityProfileRestricted.PodSecurityLabels())
+}
+
+func TestSiteSpecValidateSecurityProfile(t *testing.T) {
+	r := require.New(t)
+
+	spec := v1beta1.SiteSpec{
+		Workbench: v1beta1.InternalWorkbenchSpec{
+			ExperimentalFeatures: &v1beta1.InternalWorkbenchExperimentalFeatures{PrivilegedSessions: true},
+		},
+		VolumeSource: v1beta1.VolumeSource{Type: v1beta1.VolumeSourceTypeNfs},
+	}
+	r.NoError(spec.ValidateSecurityProfile())
+
+	spec.SecurityProfile = v1beta1.SecurityProfileBaseline
+	r.ErrorContains(spec.ValidateSecurityProfile(), "privilegedSessions")
+
+	spec.Workbench.ExperimentalFeatures.PrivilegedSessions = false
+	r.NoError(spec.ValidateSecurityProfile())
+
+	spec.SecurityProfile = v1beta1.SecurityProfileRestricted
+	r.ErrorContains(spec.ValidateSecurityProfile(), "volumeSubdirJobOff")
+
+	spec.VolumeSubdirJobOff = true
+	r.NoError(spec.ValidateSecurityProfile())
+}
+
+func TestProductSpecValidateSecurityProfile(t *testing.T) {
+	r := require.New(t)
+
+	connect := v1beta1.ConnectSpec{SecurityProfile: v1beta1.SecurityProfileBaseline}
+	r.ErrorContains(connect.ValidateSecurityProfile(), "offHostExecution")
+	connect.OffHostExecution = true
+	r.NoError(connect.ValidateSecurityProfile())
+
+	workbench := v1beta1.WorkbenchSpec{SecurityProfile: v1beta1.SecurityProfileRestricted, OffHostExecution: true}
+	r.ErrorContains(workbench.ValidateSecurityProfile(), "nonRoot")
+	workbench.NonRoot = true
+	r.NoError(workbench.ValidateSecurityProfile())
+	workbench.SessionConfig = &product.SessionConfig{
+		Pod: &product.PodConfig{ContainerSecurityContext: corev1.SecurityContext{Privileged: ptr.To(true)}},
+	}
+	r.ErrorContains(workbench.ValidateSecurityProfile(), "privileged sessions")
+}
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+//+k8s:openapi-gen=true
+
+package v1beta1
+
+import (
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SessionIDE is an IDE that Workbench sessions run
+// +kubebuilder:validation:Enum=RStudio;Jupyter;VSCode;Positron
+type SessionIDE string
+
+const (
+	SessionIDERStudio  SessionIDE = "RStudio"
+	SessionIDEJupyter  SessionIDE = "Jupyter"
+	SessionIDEVSCode   SessionIDE = "VSCode"
+	SessionIDEPositron SessionIDE = "Positron"
+)
+
+// SessionImageDeprecationDateFormat is the format of SessionImageSpec.DeprecationDate
+const SessionImageDeprecationDateFormat = time.DateOnly
+
+// SessionImageSpec defines the desired state of SessionImage
+type SessionImageSpec struct {
+	// Image is the image reference, i.e. ghcr.io/rstudio/workbench-session:ubuntu2204-r4.4.1_py3.12.4
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+
+	// DisplayName is a human-readable name for the image
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// IDEs are the IDEs that the image supports. Defaults to all of them
+	// +optional
+	IDEs []SessionIDE `json:"ides,omitempty"`
+
+	// RVersions are the versions of R in the image
+	// +optional
+	RVersions []string `json:"rVersions,omitempty"`
+
+	// PythonVersions are the versions of Python in the image
+	// +optional
+	PythonVersions []string `json:"pythonVersions,omitempty"`
+
+	// DeprecationDate is the day, i.e. 2026-06-30, from which the image is no longer offered for new sessions.
+	// Running sessions are not affected
+	// +kubebuilder:validation:Format=date
+	// +optional
+	DeprecationDate string `json:"deprecationDate,omitempty"`
+
+	// Default makes the image the default session image of the IDEs that it supports. When several images are
+	// the default, the first by name wins
+	// +optional
+	Default bool `json:"default,omitempty"`
+}
+
+// SessionImageStatus defines the observed state of SessionImage
+type SessionImageStatus struct {
+	// Sites whose Workbench offers the image
+	// +optional
+	Sites []string `json:"sites,omitempty"`
+
+	// Digest is the digest that the image resolved to when its runtime versions were discovered
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
+	// RVersions are the versions of R that the co.posit.r.versions label of the image lists. They are discovered
+	// when the spec lists neither R nor Python versions
+	// +optional
+	RVersions []string `json:"rVersions,omitempty"`
+
+	// PythonVersions are the versions of Python that the co.posit.python.versions label of the image lists
+	// +optional
+	PythonVersions []string `json:"pythonVersions,omitempty"`
+
+	// QuartoVersions are the versions of Quarto that the co.posit.quarto.versions label of the image lists
+	// +optional
+	QuartoVersions []string `json:"quartoVersions,omitempty"`
+
+	// RuntimeVersionsWarning explains why the runtime versions of the image could not be discovered, i.e. because
+	// the registry could not be read or the image has no labels
+	// +optional
+	RuntimeVersionsWarning string `json:"runtimeVersionsWarning,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName={si,sis},path=sessionimages,singular=sessionimage
+//+kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.image`
+//+kubebuilder:printcolumn:name="Default",type=boolean,JSONPath=`.spec.default`
+//+kubebuilder:printcolumn:name="Deprecation",type=string,JSONPath=`.spec.deprecationDate`
+//+kubebuilder:printcolumn:name="Sites",type=string,JSONPath=`.status.sites`
+//+genclient
+//+k8s:openapi-gen=true
+
+// SessionImage is a Workbench session image in the catalog of a namespace. Workbenches offer the SessionImages
+// that their sessionImageSelector matches
+type SessionImage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SessionImageSpec   `json:"spec,omitempty"`
+	Status SessionImageStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SessionImageList contains a list of SessionImage
+type SessionImageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SessionImage `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SessionImage{}, &SessionImageList{})
+}
+
+// DiscoversRuntimeVersions reports whether the runtime versions of the image are discovered from its labels
+func (si *SessionImage) DiscoversRuntimeVersions() bool {
+	return len(si.Spec.RVersions) == 0 && len(si.Spec.PythonVersions) == 0
+}
+
+// Supports reports whether the image supports ide
+func (si *SessionImage) Supports(ide SessionIDE) bool {
+	return len(si.Spec.IDEs) == 0 || slices.Contains(si.Spec.IDEs, ide)
+}
+
+// Deprecation returns the start of the deprecation date in UTC, or false when the image has none
+func (si *SessionImage) Deprecation() (time.Time, bool) {
+	if si.Spec.DeprecationDate == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(SessionImageDeprecationDateFormat, si.Spec.DeprecationDate)
+	if err != nil {
+		// the CRD validates the format, so this only happens for objects that were never validated
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// Retired reports whether the deprecation date of the image has passed at now
+func (si *SessionImage) Retired(now time.Time) bool {
+	t, ok := si.Deprecation()
+	return ok && !now.Before(t)
+}
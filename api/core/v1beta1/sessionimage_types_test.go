@@ -0,0 +1,35 @@
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionImageSupports(t *testing.T) {
+	si := SessionImage{}
+	for _, ide := range []SessionIDE{SessionIDERStudio, SessionIDEJupyter, SessionIDEVSCode, SessionIDEPositron} {
+		require.True(t, si.Supports(ide), ide)
+	}
+
+	si.Spec.IDEs = []SessionIDE{SessionIDEPositron}
+	require.True(t, si.Supports(SessionIDEPositron))
+	require.False(t, si.Supports(SessionIDERStudio))
+}
+
+func TestSessionImageRetired(t *testing.T) {
+	si := SessionImage{}
+	require.False(t, si.Retired(time.Now()))
+
+	si.Spec.DeprecationDate = "2026-06-30"
+	deprecation, ok := si.Deprecation()
+	require.True(t, ok)
+	require.Equal(t, time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC), deprecation)
+	require.False(t, si.Retired(deprecation.Add(-time.Second)))
+	require.True(t, si.Retired(deprecation))
+
+	// an invalid date never retires the image
+	si.Spec.DeprecationDate = "june"
+	require.False(t, si.Retired(time.Now()))
+}
@@ -1,8 +1,13 @@
 package v1beta1
 
 import (
+	"errors"
+
 	"github.com/posit-dev/team-operator/api/product"
+	"github.com/rstudio/goex/ptr"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -38,8 +43,37 @@ const (
 	NetworkTrustZero     NetworkTrust = 0
 )
 
+// NetworkTrust is how far a Site's workloads trust each other on the network, from 0 (zero trust) to 100 (full
+// trust). Values fall into three levels: above NetworkTrustSameSite no NetworkPolicies are created, down to 1 the
+// Site's components can reach each other, and at NetworkTrustZero all traffic is denied except the flows each
+// component needs
 type NetworkTrust uint8
 
+// Level returns the level that the trust value falls into: NetworkTrustFull, NetworkTrustSameSite or
+// NetworkTrustZero
+func (n NetworkTrust) Level() NetworkTrust {
+	switch {
+	case n > NetworkTrustSameSite:
+		return NetworkTrustFull
+	case n > NetworkTrustZero:
+		return NetworkTrustSameSite
+	default:
+		return NetworkTrustZero
+	}
+}
+
+// LevelName returns the name of the level that the trust value falls into
+func (n NetworkTrust) LevelName() string {
+	switch n.Level() {
+	case NetworkTrustFull:
+		return "full"
+	case NetworkTrustSameSite:
+		return "same-site"
+	default:
+		return "zero"
+	}
+}
+
 type AuthSpec struct {
 	Type               AuthType `json:"type,omitempty"`
 	ClientId           string   `json:"clientId,omitempty"`
@@ -68,6 +102,519 @@ type SecretConfig struct {
 	Type      product.SiteSecretType `json:"type,omitempty"`
 }
 
+type RoutingMode string
+
+const (
+	RoutingModeIngress RoutingMode = "ingress"
+	RoutingModeGateway RoutingMode = "gateway"
+)
+
+// IngressProvider is the ingress controller that Ingresses are configured for
+type IngressProvider string
+
+const (
+	IngressProviderTraefik IngressProvider = "traefik"
+	IngressProviderNginx   IngressProvider = "nginx"
+	IngressProviderAlb     IngressProvider = "alb"
+)
+
+// RoutingConfig controls how products are exposed outside of the cluster
+type RoutingConfig struct {
+	// Mode selects the kind of routing resources that are created. "ingress" (the default) creates
+	// networking.k8s.io/v1 Ingresses configured for Provider. "gateway" creates Gateway API HTTPRoutes
+	// attached to the Gateway referenced in Gateway.
+	// +kubebuilder:validation:Enum=ingress;gateway
+	// +optional
+	Mode RoutingMode `json:"mode,omitempty"`
+
+	// Gateway is the parent Gateway that HTTPRoutes attach to. Required when Mode is "gateway"
+	// +optional
+	Gateway *GatewayParentRef `json:"gateway,omitempty"`
+
+	// Provider is the ingress controller that Ingresses are configured for when Mode is "ingress". Sticky
+	// sessions, forwarded headers, CSP headers and timeouts are translated into that controller's annotations
+	// (or CRDs). Defaults to "traefik"
+	// +kubebuilder:validation:Enum=traefik;nginx;alb
+	// +optional
+	Provider IngressProvider `json:"provider,omitempty"`
+
+	// RequestTimeout is how long the proxy waits for a product to respond. The proxy default is used when unset
+	// +optional
+	RequestTimeout *metav1.Duration `json:"requestTimeout,omitempty"`
+
+	// Layout is how product URLs are derived from the Site domain. "subdomain" (the default) serves each product
+	// on <domainPrefix>.<domain>, "dash" on <domainPrefix>-<domain> and "path" serves every product on <domain>
+	// under /<domainPrefix>. Only used on a Site
+	// +kubebuilder:validation:Enum=subdomain;dash;path
+	// +optional
+	Layout SiteDomainType `json:"layout,omitempty"`
+
+	// ExternalDNS adds external-dns annotations to every Ingress and HTTPRoute so that DNS records are created
+	// for the product hostnames
+	// +optional
+	ExternalDNS *ExternalDNSConfig `json:"externalDns,omitempty"`
+}
+
+// ExternalDNSConfig configures the external-dns annotations added to routing resources
+type ExternalDNSConfig struct {
+	// Target is the target of the DNS records (i.e. a load balancer hostname or IP). external-dns uses the
+	// addresses in the Ingress status when unset
+	// +optional
+	Target string `json:"target,omitempty"`
+
+	// TTL is the TTL of the DNS records, in seconds
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+}
+
+// IngressPolicy protects a product's Ingress. Traefik enforces every setting with middlewares, ingress-nginx with
+// annotations and the ALB only the IP allow-list. Settings that the provider cannot enforce, and any policy in
+// gateway mode, fail validation
+type IngressPolicy struct {
+	// RateLimit limits the rate of requests from each client IP
+	// +optional
+	RateLimit *IngressRateLimit `json:"rateLimit,omitempty"`
+
+	// IPAllowList only accepts requests from the given source ranges
+	// +optional
+	IPAllowList *IngressIPAllowList `json:"ipAllowList,omitempty"`
+
+	// MaxBodySize rejects requests with a larger body (i.e. "100Mi")
+	// +optional
+	MaxBodySize *resource.Quantity `json:"maxBodySize,omitempty"`
+
+	// Retry retries requests that fail with a network error
+	// +optional
+	Retry *IngressRetry `json:"retry,omitempty"`
+}
+
+// IngressRateLimit configures a Traefik RateLimit middleware, or the ingress-nginx limit-rps (or limit-rpm) annotation
+type IngressRateLimit struct {
+	// Average is the number of requests allowed per Period
+	// +kubebuilder:validation:Minimum=1
+	Average int64 `json:"average"`
+
+	// Burst is the number of requests allowed to arrive at once. Defaults to 1
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Burst int64 `json:"burst,omitempty"`
+
+	// Period is the period that Average applies to. Defaults to a second
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty"`
+}
+
+// IngressIPAllowList configures a Traefik IPAllowList middleware, the ingress-nginx whitelist-source-range annotation
+// or the ALB inbound CIDRs
+type IngressIPAllowList struct {
+	// SourceRanges are the allowed IPs or CIDR ranges
+	// +kubebuilder:validation:MinItems=1
+	SourceRanges []string `json:"sourceRanges"`
+
+	// Depth selects the client IP from the X-Forwarded-For header, counting from the right, for when a load
+	// balancer in front of Traefik does not preserve the client IP. The remote address is used when unset
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Depth int `json:"depth,omitempty"`
+}
+
+// IngressRetry configures a Traefik Retry middleware, or the ingress-nginx proxy-next-upstream-tries annotation
+type IngressRetry struct {
+	// Attempts is the number of times a request is tried
+	// +kubebuilder:validation:Minimum=1
+	Attempts int `json:"attempts"`
+
+	// InitialInterval is the first wait in the exponential backoff between attempts. Attempts are retried
+	// immediately when unset
+	// +optional
+	InitialInterval *metav1.Duration `json:"initialInterval,omitempty"`
+}
+
+// DefaultTargetCPUUtilizationPercentage is the CPU target of an HPA without any other target
+const DefaultTargetCPUUtilizationPercentage int32 = 80
+
+// SessionQuota caps the resources of the pods that a product's launcher creates, with a ResourceQuota that only
+// counts pods of PriorityClassName, and a LimitRange for their containers
+type SessionQuota struct {
+	// PriorityClassName is set on the launched pods, so that the ResourceQuota counts them. The PriorityClass must
+	// exist, and should not be used by the other pods of the namespace
+	// +kubebuilder:validation:MinLength=1
+	PriorityClassName string `json:"priorityClassName"`
+
+	// CPU caps the total CPU requests of the launched pods
+	// +optional
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+
+	// Memory caps the total memory requests of the launched pods
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+
+	// NvidiaGPUs caps the total NVIDIA GPUs of the launched pods
+	// +optional
+	NvidiaGPUs *resource.Quantity `json:"nvidiaGpus,omitempty"`
+
+	// Pods caps the number of launched pods
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Pods *int32 `json:"pods,omitempty"`
+
+	// LimitRange sets the default and maximum sizes of containers. Kubernetes cannot scope a LimitRange, so it
+	// needs the sessions to run in a session namespace, away from the product's own pods
+	// +optional
+	LimitRange *SessionLimitRange `json:"limitRange,omitempty"`
+}
+
+// ValidateSessionQuotas returns an error when a session quota has a LimitRange that would apply to the product's own
+// pods. Only Workbench sessions can run in a namespace of their own
+func (s *SiteSpec) ValidateSessionQuotas() error {
+	var errs []error
+	if q := s.Connect.SessionQuota; q != nil && q.LimitRange != nil {
+		errs = append(errs, errors.New("connect: sessionQuota.limitRange needs a session namespace, which Connect does not have"))
+	}
+	if q := s.Workbench.SessionQuota; q != nil && q.LimitRange != nil && s.Workbench.SessionNamespace == "" {
+		errs = append(errs, errors.New("workbench: sessionQuota.limitRange needs a sessionNamespace"))
+	}
+	return errors.Join(errs...)
+}
+
+// SessionLimitRange is the container limits of a LimitRange
+type SessionLimitRange struct {
+	// DefaultRequest is the requests of containers that do not set their own
+	// +optional
+	DefaultRequest corev1.ResourceList `json:"defaultRequest,omitempty"`
+
+	// Default is the limits of containers that do not set their own
+	// +optional
+	Default corev1.ResourceList `json:"default,omitempty"`
+
+	// Max is the largest limits of a container
+	// +optional
+	Max corev1.ResourceList `json:"max,omitempty"`
+}
+
+// Hard returns the hard limits of the ResourceQuota
+func (q *SessionQuota) Hard() corev1.ResourceList {
+	hard := corev1.ResourceList{}
+	if q.CPU != nil {
+		hard[corev1.ResourceRequestsCPU] = *q.CPU
+	}
+	if q.Memory != nil {
+		hard[corev1.ResourceRequestsMemory] = *q.Memory
+	}
+	if q.NvidiaGPUs != nil {
+		hard[corev1.DefaultResourceRequestsPrefix+"nvidia.com/gpu"] = *q.NvidiaGPUs
+	}
+	if q.Pods != nil {
+		hard[corev1.ResourcePods] = *resource.NewQuantity(int64(*q.Pods), resource.DecimalSI)
+	}
+	return hard
+}
+
+// SessionQuotaStatus is the usage of a SessionQuota, as reported by its ResourceQuota
+type SessionQuotaStatus struct {
+	// Hard is the enforced limits
+	// +optional
+	Hard corev1.ResourceList `json:"hard,omitempty"`
+
+	// Used is the current usage of the launched pods
+	// +optional
+	Used corev1.ResourceList `json:"used,omitempty"`
+}
+
+// AutoscalingConfig configures a HorizontalPodAutoscaler for a product's Deployment. The HPA owns the replica
+// count, so Replicas is ignored while it is set
+type AutoscalingConfig struct {
+	// MinReplicas is the lowest number of replicas. Defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the highest number of replicas
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilizationPercentage is the average CPU utilization, relative to the CPU request, to scale to.
+	// Defaults to 80 when no other target is set
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// TargetMemoryUtilizationPercentage is the average memory utilization, relative to the memory request, to
+	// scale to
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+
+	// Metrics are additional metrics to scale on, i.e. Pods metrics served by a custom metrics adapter from the
+	// product's Prometheus metrics
+	// +optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+
+	// Behavior configures the scaling speed in each direction
+	// +optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// GetMinReplicas returns the lowest number of replicas, defaulting to 1
+func (a *AutoscalingConfig) GetMinReplicas() int32 {
+	if a == nil || a.MinReplicas == nil {
+		return 1
+	}
+	return *a.MinReplicas
+}
+
+// GetMetrics returns the metrics to scale on: the resource utilization targets followed by Metrics
+func (a *AutoscalingConfig) GetMetrics() []autoscalingv2.MetricSpec {
+	if a == nil {
+		return nil
+	}
+
+	resourceMetric := func(name corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+		return autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: name,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: &utilization,
+				},
+			},
+		}
+	}
+
+	var metrics []autoscalingv2.MetricSpec
+	cpu := a.TargetCPUUtilizationPercentage
+	if cpu == nil && a.TargetMemoryUtilizationPercentage == nil && len(a.Metrics) == 0 {
+		cpu = ptr.To(DefaultTargetCPUUtilizationPercentage)
+	}
+	if cpu != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceCPU, *cpu))
+	}
+	if a.TargetMemoryUtilizationPercentage != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceMemory, *a.TargetMemoryUtilizationPercentage))
+	}
+	return append(metrics, a.Metrics...)
+}
+
+// The Default*Resources functions return the resources of the product containers when none are configured. They
+// set requests, which keep the product pods out of the BestEffort QoS class, so that they are not the first to be
+// evicted. Limits are left unset unless configured, so that existing deployments are not throttled or OOM-killed on
+// upgrade; only Package Manager and Flightdeck keep the limits they have always had
+
+// DefaultConnectResources requests 500m CPU and 2Gi memory
+func DefaultConnectResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:              resource.MustParse("500m"),
+			corev1.ResourceMemory:           resource.MustParse("2Gi"),
+			corev1.ResourceEphemeralStorage: resource.MustParse("500Mi"),
+		},
+	}
+}
+
+// DefaultWorkbenchResources requests 500m CPU and 2Gi memory. Sessions run in their own pods and are sized by the
+// launcher resource profiles instead
+func DefaultWorkbenchResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:              resource.MustParse("500m"),
+			corev1.ResourceMemory:           resource.MustParse("2Gi"),
+			corev1.ResourceEphemeralStorage: resource.MustParse("500Mi"),
+		},
+	}
+}
+
+// DefaultPackageManagerResources requests 100m CPU and 2Gi memory, limited to 2 CPU and 4Gi memory
+func DefaultPackageManagerResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:              resource.MustParse("100m"),
+			corev1.ResourceMemory:           resource.MustParse("2Gi"),
+			corev1.ResourceEphemeralStorage: resource.MustParse("500Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:              resource.MustParse("2"),
+			corev1.ResourceMemory:           resource.MustParse("4Gi"),
+			corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+		},
+	}
+}
+
+// DefaultChronicleResources requests 100m CPU and 256Mi memory
+func DefaultChronicleResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		},
+	}
+}
+
+// DefaultChronicleAgentResources requests 50m CPU and 64Mi memory for the Chronicle agent sidecar
+func DefaultChronicleAgentResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+	}
+}
+
+// DefaultFlightdeckResources requests 50m CPU and 64Mi memory, limited to 200m CPU and 256Mi memory
+func DefaultFlightdeckResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("200m"),
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		},
+	}
+}
+
+// resourcesOrDefault returns a copy of resources, or the default when resources is not set. Set resources replace the
+// default as a whole, so an unset request or limit stays unset
+func resourcesOrDefault(resources *corev1.ResourceRequirements, def func() corev1.ResourceRequirements) corev1.ResourceRequirements {
+	if resources == nil {
+		return def()
+	}
+	return *resources.DeepCopy()
+}
+
+// GatewayParentRef references a Gateway API Gateway (and optionally one of its listeners)
+type GatewayParentRef struct {
+	// Name is the name of the Gateway
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Gateway. Defaults to the namespace of the HTTPRoute
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of a listener on the Gateway to attach to
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// UseGateway reports whether Gateway API HTTPRoutes should be created instead of Ingresses
+func (r *RoutingConfig) UseGateway() bool {
+	return r != nil && r.Mode == RoutingModeGateway
+}
+
+// GetProvider returns the configured ingress provider, defaulting to Traefik
+func (r *RoutingConfig) GetProvider() IngressProvider {
+	if r == nil || r.Provider == "" {
+		return IngressProviderTraefik
+	}
+	return r.Provider
+}
+
+// SetsRequestHeaders reports whether the proxy in front of the products can add request headers. The ALB cannot
+func (r *RoutingConfig) SetsRequestHeaders() bool {
+	return r.UseGateway() || r.GetProvider() != IngressProviderAlb
+}
+
+// GetRequestTimeout returns the configured request timeout, or nil if the proxy default should be used
+func (r *RoutingConfig) GetRequestTimeout() *metav1.Duration {
+	if r == nil {
+		return nil
+	}
+	return r.RequestTimeout
+}
+
+// GetLayout returns the configured Site layout, defaulting to subdomains
+func (r *RoutingConfig) GetLayout() SiteDomainType {
+	if r == nil || r.Layout == "" {
+		return SiteSubDomain
+	}
+	return r.Layout
+}
+
+// GetExternalDNS returns the external-dns configuration, or nil if external-dns annotations are not wanted
+func (r *RoutingConfig) GetExternalDNS() *ExternalDNSConfig {
+	if r == nil {
+		return nil
+	}
+	return r.ExternalDNS
+}
+
+// PodSchedulingConfig configures where a product's server pods run, along with extra metadata for the pods
+type PodSchedulingConfig struct {
+	// Affinity of the server pods. A PodAntiAffinity replaces the default preference for spreading replicas
+	// across nodes, while NodeAffinity and PodAffinity are used alongside it
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Tolerations of the server pods
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// TopologySpreadConstraints of the server pods, i.e. to spread replicas across zones. A constraint without a
+	// labelSelector selects the product's own pods
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName of the server pods
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// PodAnnotations are added to the server pods. Annotations set by the operator take precedence
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// PodLabels are added to the server pods. Labels set by the operator take precedence
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+}
+
+// GetAffinity returns the affinity of a product's server pods. Unless a PodAntiAffinity is configured, replicas
+// prefer separate nodes (see ComponentSpecPodAntiAffinity)
+func (s *PodSchedulingConfig) GetAffinity(p product.KubernetesLabelser, namespace string) *corev1.Affinity {
+	affinity := &corev1.Affinity{}
+	if s != nil && s.Affinity != nil {
+		affinity = s.Affinity.DeepCopy()
+	}
+	if affinity.PodAntiAffinity == nil {
+		affinity.PodAntiAffinity = ComponentSpecPodAntiAffinity(p, namespace)
+	}
+	return affinity
+}
+
+// GetTopologySpreadConstraints returns the topology spread constraints of a product's server pods, with a
+// missing labelSelector defaulted to selectorLabels
+func (s *PodSchedulingConfig) GetTopologySpreadConstraints(selectorLabels map[string]string) []corev1.TopologySpreadConstraint {
+	if s == nil || len(s.TopologySpreadConstraints) == 0 {
+		return nil
+	}
+	constraints := make([]corev1.TopologySpreadConstraint, 0, len(s.TopologySpreadConstraints))
+	for _, c := range s.TopologySpreadConstraints {
+		c = *c.DeepCopy()
+		if c.LabelSelector == nil {
+			c.LabelSelector = &metav1.LabelSelector{MatchLabels: product.LabelMerge(nil, selectorLabels)}
+		}
+		constraints = append(constraints, c)
+	}
+	return constraints
+}
+
+// GetPodLabels returns the labels of a product's server pods: PodLabels overridden by labels
+func (s *PodSchedulingConfig) GetPodLabels(labels map[string]string) map[string]string {
+	if s == nil || len(s.PodLabels) == 0 {
+		return labels
+	}
+	return product.LabelMerge(product.LabelMerge(nil, s.PodLabels), labels)
+}
+
+// GetPodAnnotations returns the annotations of a product's server pods: PodAnnotations overridden by annotations
+func (s *PodSchedulingConfig) GetPodAnnotations(annotations map[string]string) map[string]string {
+	if s == nil || len(s.PodAnnotations) == 0 {
+		return annotations
+	}
+	return product.LabelMerge(product.LabelMerge(nil, s.PodAnnotations), annotations)
+}
+
 // ComponentSpecPodAntiAffinity generates a *corev1.PodAntiAffinity suitable for use in a
 // given component's deployment template spec to inform kubernetes to place pod replicas
 // on separate nodes when possible.
@@ -1,10 +1,17 @@
 package v1beta1_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/rstudio/goex/ptr"
 	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type fakeKubernetesLabeler struct{}
@@ -45,3 +52,158 @@ func TestComponentSpecPodAntiAffinity(t *testing.T) {
 	r.Contains(melsKeys, v1beta1.KubernetesInstanceLabelKey)
 	r.Contains(melsKeys, v1beta1.SiteLabelKey)
 }
+
+func TestNetworkTrustLevel(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal(v1beta1.NetworkTrustFull, v1beta1.NetworkTrust(100).Level())
+	r.Equal(v1beta1.NetworkTrustFull, v1beta1.NetworkTrust(51).Level())
+	r.Equal(v1beta1.NetworkTrustSameSite, v1beta1.NetworkTrust(50).Level())
+	r.Equal(v1beta1.NetworkTrustSameSite, v1beta1.NetworkTrust(1).Level())
+	r.Equal(v1beta1.NetworkTrustZero, v1beta1.NetworkTrust(0).Level())
+
+	r.Equal("full", v1beta1.NetworkTrustFull.LevelName())
+	r.Equal("same-site", v1beta1.NetworkTrust(20).LevelName())
+	r.Equal("zero", v1beta1.NetworkTrustZero.LevelName())
+
+	// an unset trust is full trust, but zero trust survives a round trip
+	r.Equal(v1beta1.NetworkTrustFull, (&v1beta1.SiteSpec{}).GetNetworkTrust())
+	spec := v1beta1.SiteSpec{}
+	r.NoError(json.Unmarshal([]byte(`{"networkTrust":0}`), &spec))
+	r.Equal(v1beta1.NetworkTrustZero, spec.GetNetworkTrust())
+	out, err := json.Marshal(spec)
+	r.NoError(err)
+	r.Contains(string(out), `"networkTrust":0`)
+}
+
+func TestAutoscalingConfig(t *testing.T) {
+	r := require.New(t)
+
+	var nilConfig *v1beta1.AutoscalingConfig
+	r.Equal(int32(1), nilConfig.GetMinReplicas())
+	r.Nil(nilConfig.GetMetrics())
+
+	// CPU is the default target
+	config := &v1beta1.AutoscalingConfig{MaxReplicas: 3}
+	metrics := config.GetMetrics()
+	r.Len(metrics, 1)
+	r.Equal(corev1.ResourceCPU, metrics[0].Resource.Name)
+	r.Equal(v1beta1.DefaultTargetCPUUtilizationPercentage, *metrics[0].Resource.Target.AverageUtilization)
+
+	// custom metrics replace the default
+	sessions := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: "workbench_active_sessions"},
+			Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType},
+		},
+	}
+	config.Metrics = []autoscalingv2.MetricSpec{sessions}
+	r.Equal([]autoscalingv2.MetricSpec{sessions}, config.GetMetrics())
+
+	config.MinReplicas = ptr.To(int32(2))
+	config.TargetMemoryUtilizationPercentage = ptr.To(int32(75))
+	metrics = config.GetMetrics()
+	r.Equal(int32(2), config.GetMinReplicas())
+	r.Len(metrics, 2)
+	r.Equal(corev1.ResourceMemory, metrics[0].Resource.Name)
+	r.Equal(sessions, metrics[1])
+}
+
+func TestProductResources(t *testing.T) {
+	r := require.New(t)
+
+	c := &v1beta1.Connect{}
+	r.Equal(v1beta1.DefaultConnectResources(), c.GetResources())
+	r.Equal(v1beta1.DefaultChronicleAgentResources(), c.GetChronicleAgentResources())
+
+	// the defaults only request resources, so upgrades do not add limits
+	r.Nil(c.GetResources().Limits)
+	r.Nil((&v1beta1.Workbench{}).GetResources().Limits)
+	r.Nil((&v1beta1.Chronicle{}).GetResources().Limits)
+	r.Nil(c.GetChronicleAgentResources().Limits)
+
+	// configured resources replace the defaults as a whole
+	c.Spec.Resources = &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+	}
+	resources := c.GetResources()
+	r.Equal(*c.Spec.Resources, resources)
+	r.Nil(resources.Limits)
+
+	// the returned resources are a copy
+	resources.Requests[corev1.ResourceCPU] = resource.MustParse("2")
+	r.Equal(resource.MustParse("1"), c.Spec.Resources.Requests[corev1.ResourceCPU])
+
+	// the Chronicle agent sidecar uses the agent resources
+	c.Spec.ChronicleAgentImage = "ghcr.io/rstudio/chronicle:latest"
+	c.Spec.ChronicleAgentResources = &corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+	}
+	sidecars := product.ChronicleSidecar(c, nil)
+	r.Len(sidecars, 1)
+	r.Equal(*c.Spec.ChronicleAgentResources, sidecars[0].Resources)
+
+	// as does Chronicle itself
+	chronicle := &v1beta1.Chronicle{}
+	r.Equal(v1beta1.DefaultChronicleAgentResources(), chronicle.GetChronicleAgentResources())
+	chronicle.Spec.AgentResources = c.Spec.ChronicleAgentResources
+	r.Equal(*c.Spec.ChronicleAgentResources, chronicle.GetChronicleAgentResources())
+}
+
+func TestPodSchedulingConfig(t *testing.T) {
+	r := require.New(t)
+	p := &v1beta1.Connect{ObjectMeta: metav1.ObjectMeta{Name: "fiona"}}
+
+	// replicas prefer separate nodes by default
+	config := &v1beta1.PodSchedulingConfig{}
+	r.Equal(&corev1.Affinity{PodAntiAffinity: v1beta1.ComponentSpecPodAntiAffinity(p, "posit-team")}, config.GetAffinity(p, "posit-team"))
+	r.Nil(config.GetTopologySpreadConstraints(p.SelectorLabels()))
+
+	// node affinity is used alongside the default anti-affinity
+	nodeAffinity := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"products"}},
+				},
+			}},
+		},
+	}
+	config.Affinity = &corev1.Affinity{NodeAffinity: nodeAffinity}
+	affinity := config.GetAffinity(p, "posit-team")
+	r.Equal(nodeAffinity, affinity.NodeAffinity)
+	r.Equal(v1beta1.ComponentSpecPodAntiAffinity(p, "posit-team"), affinity.PodAntiAffinity)
+	r.Nil(config.Affinity.PodAntiAffinity)
+
+	// a configured anti-affinity replaces the default
+	config.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	r.Equal(config.Affinity, config.GetAffinity(p, "posit-team"))
+
+	// constraints without a selector select the product's pods
+	config.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.ScheduleAnyway},
+		{
+			MaxSkew:           1,
+			TopologyKey:       "kubernetes.io/hostname",
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}},
+		},
+	}
+	constraints := config.GetTopologySpreadConstraints(p.SelectorLabels())
+	r.Len(constraints, 2)
+	r.Equal(p.SelectorLabels(), constraints[0].LabelSelector.MatchLabels)
+	r.Equal("topology.kubernetes.io/zone", constraints[0].TopologyKey)
+	r.Nil(config.TopologySpreadConstraints[0].LabelSelector)
+	r.Equal(config.TopologySpreadConstraints[1], constraints[1])
+
+	// the operator's labels and annotations take precedence
+	r.Equal(p.KubernetesLabels(), config.GetPodLabels(p.KubernetesLabels()))
+	config.PodLabels = map[string]string{"team": "data", v1beta1.KubernetesInstanceLabelKey: "other"}
+	config.PodAnnotations = map[string]string{"karpenter.sh/do-not-disrupt": "true", "sha": "old"}
+	labels := config.GetPodLabels(p.KubernetesLabels())
+	r.Equal("data", labels["team"])
+	r.Equal(p.ComponentName(), labels[v1beta1.KubernetesInstanceLabelKey])
+	r.Equal("other", config.PodLabels[v1beta1.KubernetesInstanceLabelKey])
+	r.Equal(map[string]string{"karpenter.sh/do-not-disrupt": "true", "sha": "new"}, config.GetPodAnnotations(map[string]string{"sha": "new"}))
+}
@@ -5,8 +5,11 @@
 package v1beta1
 
 import (
+	"fmt"
+
 	"github.com/posit-dev/team-operator/api/product"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -19,6 +22,7 @@ const (
 	ManagedByLabelKey                   = "app.kubernetes.io/managed-by"
 	ManagedByLabelValue                 = "team-operator"
 	SiteLabelKey                        = "posit.team/site"
+	OwnerNamespaceLabelKey              = "posit.team/owner-namespace"
 	ComponentLabelKey                   = "posit.team/component"
 	ComponentLabelValueConnect          = "connect"
 	ComponentLabelValueConnectSession   = "connect-session"
@@ -27,6 +31,9 @@ const (
 )
 
 // SiteSpec defines the desired state of Site
+// +kubebuilder:validation:XValidation:rule="!has(self.securityProfile) || self.securityProfile == ” || !has(self.workbench) || !has(self.workbench.experimentalFeatures) || !has(self.workbench.experimentalFeatures.privilegedSessions) || !self.workbench.experimentalFeatures.privilegedSessions",message="workbench privilegedSessions conflicts with the securityProfile"
+// +kubebuilder:validation:XValidation:rule="!has(self.securityProfile) || self.securityProfile != 'restricted' || !has(self.volumeSource) || !has(self.volumeSource.type) || !(self.volumeSource.type in ['fsx-zfs', 'nfs']) || (has(self.volumeSubdirJobOff) && self.volumeSubdirJobOff)",message="the volume subdirectory job runs as root, which conflicts with the restricted securityProfile; set volumeSubdirJobOff"
+// +kubebuilder:validation:XValidation:rule="!has(self.workbench) || !has(self.workbench.userSelector) || ((!has(self.securityProfile) || self.securityProfile != 'restricted') && (!has(self.workbench.experimentalFeatures) || !has(self.workbench.experimentalFeatures.nonRoot) || !self.workbench.experimentalFeatures.nonRoot))",message="workbench userSelector syncs the users as root, which conflicts with the restricted securityProfile and with nonRoot"
 type SiteSpec struct {
 	// AwsAccountId is the account Id for this AWS Account. It is used to create EKS-to-IAM annotations
 	AwsAccountId string `json:"awsAccountId,omitempty"`
@@ -69,6 +76,10 @@ type SiteSpec struct {
 	// IngressAnnotations is a set of annotations to be applied to all ingress routes
 	IngressAnnotations map[string]string `json:"ingressAnnotations,omitempty"`
 
+	// Routing selects between Ingress and Gateway API HTTPRoute resources for all products
+	// +optional
+	Routing *RoutingConfig `json:"routing,omitempty"`
+
 	// ImagePullSecrets is a set of image pull secrets to use for all image pulls. These names / secrets
 	// must already exist in the namespace in question.
 	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
@@ -103,11 +114,23 @@ type SiteSpec struct {
 	Debug     bool              `json:"debug,omitempty"`
 	LogFormat product.LogFormat `json:"logFormat,omitempty"`
 
+	// NetworkTrust is how far the Site's workloads trust each other on the network, from 0 (zero trust) to 100 (full
+	// trust). Unset is full trust
 	// +kubebuilder:validation:Maximum=100
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:default=100
 	// +kubebuilder:validation:Type=integer
-	NetworkTrust NetworkTrust `json:"networkTrust,omitempty"`
+	NetworkTrust *NetworkTrust `json:"networkTrust,omitempty"`
+
+	// SecurityProfile hardens every product pod to comply with a Pod Security Standard and labels the namespace to
+	// enforce it with Pod Security Admission. Features that conflict with the profile fail validation
+	// +optional
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+
+	// Overrides patch the objects that the operator generates, i.e. a Deployment, Service or Ingress. They are
+	// passed to every product as well. Overrides that fail to apply are listed in the status of the Site or product
+	// +optional
+	Overrides []Override `json:"overrides,omitempty"`
 
 	// PackageManagerUrl specifies the Package Manager URL for Workbench to use
 	// If empty, Workbench will use the local Package Manager URL by default
@@ -120,6 +143,10 @@ type SiteSpec struct {
 	// VPCCIDR is the CIDR block for the VPC, used for EFS network policies
 	VPCCIDR string `json:"vpcCIDR,omitempty"`
 
+	// NetworkPolicy customizes the NetworkPolicies that are created when NetworkTrust requires them
+	// +optional
+	NetworkPolicy *NetworkPolicyConfig `json:"networkPolicy,omitempty"`
+
 	// EnableFQDNHealthChecks controls whether Grafana Alloy generates FQDN-based health check targets
 	// for this site's products. When false, only internal cluster health checks are generated.
 	// Defaults to true.
@@ -132,11 +159,13 @@ type ServiceAccountConfig struct {
 	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
+// SiteDomainType is how product hostnames (and paths) are derived from the Site domain
 type SiteDomainType string
 
 const (
-	SiteSubDomain  SiteDomainType = ""
-	SiteDashDomain                = "dash"
+	SiteSubDomain  SiteDomainType = "subdomain"
+	SiteDashDomain SiteDomainType = "dash"
+	SitePathDomain SiteDomainType = "path"
 )
 
 type AzureFilesConfig struct {
@@ -163,9 +192,25 @@ type InternalFlightdeckSpec struct {
 	// ImagePullPolicy controls when the kubelet pulls the image
 	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
 
+	// NodeSelector is the node selector of the Flightdeck pods
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// PodSchedulingConfig sets the affinity, tolerations, topology spread constraints, priority class and extra
+	// metadata of the server pods
+	PodSchedulingConfig `json:",inline"`
+
 	// Replicas is the number of Flightdeck pods to run
 	Replicas int `json:"replicas,omitempty"`
 
+	// Autoscaling creates a HorizontalPodAutoscaler for the Deployment, which then owns the replica count
+	// +optional
+	Autoscaling *AutoscalingConfig `json:"autoscaling,omitempty"`
+
+	// Resources are the resource requests and limits of the Flightdeck container. Defaults to DefaultFlightdeckResources
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
 	// FeatureEnabler controls which features are enabled in Flightdeck
 	FeatureEnabler FeatureEnablerConfig `json:"featureEnabler,omitempty"`
 
@@ -176,6 +221,17 @@ type InternalFlightdeckSpec struct {
 	// LogFormat sets the log output format (text, json)
 	// +kubebuilder:default="text"
 	LogFormat string `json:"logFormat,omitempty"`
+
+	// Hostnames are the hosts that the product is served on. The first is the primary hostname, which the rest
+	// of the Site uses to reach the product; the others are aliases (i.e. during a domain migration). Defaults
+	// to the Site domain
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// TLSSecretName is the TLS Secret for the product's Ingress. When set, every hostname is listed in the
+	// Ingress TLS section
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
 }
 
 type FeatureEnablerConfig struct {
@@ -195,6 +251,10 @@ type InternalPackageManagerSpec struct {
 
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 
+	// PodSchedulingConfig sets the affinity, tolerations, topology spread constraints, priority class and extra
+	// metadata of the server pods
+	PodSchedulingConfig `json:",inline"`
+
 	AddEnv map[string]string `json:"addEnv,omitempty"`
 
 	Image string `json:"image,omitempty"`
@@ -205,9 +265,33 @@ type InternalPackageManagerSpec struct {
 
 	Replicas int `json:"replicas,omitempty"`
 
+	// Autoscaling creates a HorizontalPodAutoscaler for the Deployment, which then owns the replica count
+	// +optional
+	Autoscaling *AutoscalingConfig `json:"autoscaling,omitempty"`
+
+	// Resources are the resource requests and limits of the Package Manager container. Defaults to DefaultPackageManagerResources
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
 	// +kubebuilder:default=packagemanager
 	DomainPrefix string `json:"domainPrefix,omitempty"`
 
+	// Hostnames are the hosts that the product is served on. The first is the primary hostname, which the rest
+	// of the Site uses to reach the product; the others are aliases (i.e. during a domain migration). Defaults
+	// to the host derived from DomainPrefix and the Site domain
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// TLSSecretName is the TLS Secret for the product's Ingress. When set, every hostname is listed in the
+	// Ingress TLS section
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// IngressPolicy adds rate limiting, an IP allow-list, a maximum body size and retries to the product's
+	// Ingress
+	// +optional
+	IngressPolicy *IngressPolicy `json:"ingressPolicy,omitempty"`
+
 	// GitSSHKeys defines SSH key configurations for Git authentication in Package Manager
 	// These SSH keys will be made available to Package Manager for Git Builders
 	// +optional
@@ -218,6 +302,7 @@ type InternalPackageManagerSpec struct {
 	AzureFiles *AzureFilesConfig `json:"azureFiles,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.replaceDefaultExecutionEnvironments) || !self.replaceDefaultExecutionEnvironments || (has(self.executionEnvironments) && size(self.executionEnvironments) > 0)",message="replaceDefaultExecutionEnvironments requires executionEnvironments"
 type InternalConnectSpec struct {
 	License product.LicenseSpec `json:"license,omitempty"`
 
@@ -225,6 +310,10 @@ type InternalConnectSpec struct {
 
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 
+	// PodSchedulingConfig sets the affinity, tolerations, topology spread constraints, priority class and extra
+	// metadata of the server pods
+	PodSchedulingConfig `json:",inline"`
+
 	Auth AuthSpec `json:"auth,omitempty"`
 
 	AddEnv map[string]string `json:"addEnv,omitempty"`
@@ -243,11 +332,50 @@ type InternalConnectSpec struct {
 
 	Replicas int `json:"replicas,omitempty"`
 
+	// Autoscaling creates a HorizontalPodAutoscaler for the Deployment, which then owns the replica count
+	// +optional
+	Autoscaling *AutoscalingConfig `json:"autoscaling,omitempty"`
+
+	// SessionQuota caps the resources of the content pods with a ResourceQuota and a LimitRange
+	// +optional
+	SessionQuota *SessionQuota `json:"sessionQuota,omitempty"`
+
+	// ExecutionEnvironments are images that content can run in with off-host execution. They are added to the
+	// default images of runtime.yaml. Environments without installations have them discovered from the labels of
+	// their image
+	// +optional
+	ExecutionEnvironments []ConnectExecutionEnvironment `json:"executionEnvironments,omitempty"`
+
+	// ReplaceDefaultExecutionEnvironments runs content only in the ExecutionEnvironments, instead of also in the
+	// default images
+	// +optional
+	ReplaceDefaultExecutionEnvironments bool `json:"replaceDefaultExecutionEnvironments,omitempty"`
+
+	// Resources are the resource requests and limits of the Connect container. Defaults to DefaultConnectResources
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
 	ExperimentalFeatures *InternalConnectExperimentalFeatures `json:"experimentalFeatures,omitempty"`
 
 	// +kubebuilder:default=connect
 	DomainPrefix string `json:"domainPrefix,omitempty"`
 
+	// Hostnames are the hosts that the product is served on. The first is the primary hostname, which the rest
+	// of the Site uses to reach the product; the others are aliases (i.e. during a domain migration). Defaults
+	// to the host derived from DomainPrefix and the Site domain
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// TLSSecretName is the TLS Secret for the product's Ingress. When set, every hostname is listed in the
+	// Ingress TLS section
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// IngressPolicy adds rate limiting, an IP allow-list, a maximum body size and retries to the product's
+	// Ingress
+	// +optional
+	IngressPolicy *IngressPolicy `json:"ingressPolicy,omitempty"`
+
 	// GPUSettings allows configuring GPU resource requests and limits
 	GPUSettings *GPUSettings `json:"gpuSettings,omitempty"`
 
@@ -313,8 +441,9 @@ type InternalWorkbenchSpec struct {
 	// NodeSelector that is applied universally to server and sessions
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 
-	// Tolerations that are applied universally to server and sessions
-	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// PodSchedulingConfig sets the affinity, tolerations, topology spread constraints, priority class and extra
+	// metadata of the server pods. The tolerations also apply to the image pre-pull DaemonSet
+	PodSchedulingConfig `json:",inline"`
 
 	// SessionTolerations are tolerations applied only to session pods (not the main workbench server)
 	SessionTolerations []corev1.Toleration `json:"sessionTolerations,omitempty"`
@@ -342,6 +471,50 @@ type InternalWorkbenchSpec struct {
 	DefaultSessionImage string   `json:"defaultSessionImage,omitempty"`
 	ExtraSessionImages  []string `json:"extraSessionImages,omitempty"`
 
+	// Profiles are launcher profiles for specific users and groups, i.e. to give GPU resource profiles to a data
+	// science group only. They are written to launcher.kubernetes.profiles.conf in order, after the profile for all
+	// users. A profile that targets "*" changes the profile for all users
+	// +optional
+	Profiles []WorkbenchLauncherProfile `json:"profiles,omitempty"`
+
+	// SessionImageSelector selects the SessionImages in the namespace that sessions can use, on top of the
+	// default and extra session images. Nil selects none
+	// +optional
+	SessionImageSelector *metav1.LabelSelector `json:"sessionImageSelector,omitempty"`
+
+	// SessionLifecycle limits how long sessions can be idle and how old they can get
+	// +optional
+	SessionLifecycle *WorkbenchSessionLifecycle `json:"sessionLifecycle,omitempty"`
+
+	// UserSelector selects the WorkbenchUsers and WorkbenchGroups in the namespace that Workbench projects, so
+	// that they have the same UID and GID in every pod. Nil selects none
+	// +optional
+	UserSelector *metav1.LabelSelector `json:"userSelector,omitempty"`
+
+	// LauncherTemplates chooses the bundled launcher templates of sessions by version, or replaces them with
+	// templates from a ConfigMap. Defaults to the latest bundled version
+	// +optional
+	LauncherTemplates *WorkbenchLauncherTemplates `json:"launcherTemplates,omitempty"`
+
+	// Repositories are the package repositories of the sessions: CRAN repositories in repos.conf, a Bioconductor
+	// mirror in Rprofile.site, PyPI indexes in pip.conf and conda channels in condarc. The first PyPI repository
+	// is the index. Defaults to the CRAN, Bioconductor and PyPI repositories of the Site's Package Manager, or to
+	// packageManagerUrl as the only CRAN repository when it is set
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:XValidation:rule="self.filter(r, r.type == 'bioc').size() <= 1",message="only one bioc repository can be set"
+	// +optional
+	Repositories []WorkbenchRepository `json:"repositories,omitempty"`
+
+	// SessionNamespace runs the sessions in a namespace of their own, which the Site creates, labels and deletes.
+	// The session ServiceAccount, the launcher Role and the session NetworkPolicies are created there, along with
+	// copies of the ConfigMaps, Secrets and volumes that sessions mount. The operator needs the opt-in
+	// session-namespace-role for it
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	SessionNamespace string `json:"sessionNamespace,omitempty"`
+
 	// SessionInitContainerImageName specifies the init container image name for Workbench sessions
 	SessionInitContainerImageName string `json:"sessionInitContainerImageName,omitempty"`
 
@@ -350,11 +523,28 @@ type InternalWorkbenchSpec struct {
 
 	Replicas int `json:"replicas,omitempty"`
 
+	// Autoscaling creates a HorizontalPodAutoscaler for the Deployment, which then owns the replica count
+	// +optional
+	Autoscaling *AutoscalingConfig `json:"autoscaling,omitempty"`
+
+	// SessionQuota caps the resources of the sessions with a ResourceQuota and a LimitRange
+	// +optional
+	SessionQuota *SessionQuota `json:"sessionQuota,omitempty"`
+
+	// Resources are the resource requests and limits of the Workbench container. Defaults to DefaultWorkbenchResources
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
 	// ExperimentalFeatures allows enabling miscellaneous experimental features for workbench
 	ExperimentalFeatures *InternalWorkbenchExperimentalFeatures `json:"experimentalFeatures,omitempty"`
 
 	VsCodeExtensions []string `json:"vsCodeExtensions,omitempty"`
 
+	// ExtensionCache installs VsCodeExtensions and the Positron extensions into the shared directory once, instead
+	// of in every session. Needs sharedDirectory
+	// +optional
+	ExtensionCache *WorkbenchExtensionCacheConfig `json:"extensionCache,omitempty"`
+
 	VsCodeUserSettings map[string]*apiextensionsv1.JSON `json:"vsCodeUserSettings,omitempty"`
 
 	PositronSettings PositronConfig `json:"positronConfig,omitempty"`
@@ -366,6 +556,22 @@ type InternalWorkbenchSpec struct {
 	// +kubebuilder:default=workbench
 	DomainPrefix string `json:"domainPrefix,omitempty"`
 
+	// Hostnames are the hosts that the product is served on. The first is the primary hostname, which the rest
+	// of the Site uses to reach the product; the others are aliases (i.e. during a domain migration). Defaults
+	// to the host derived from DomainPrefix and the Site domain
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// TLSSecretName is the TLS Secret for the product's Ingress. When set, every hostname is listed in the
+	// Ingress TLS section
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// IngressPolicy adds rate limiting, an IP allow-list, a maximum body size and retries to the product's
+	// Ingress
+	// +optional
+	IngressPolicy *IngressPolicy `json:"ingressPolicy,omitempty"`
+
 	// Workbench Auth/Login Landing Page Customization HTML
 	AuthLoginPageHtml string `json:"authLoginPageHtml,omitempty"`
 
@@ -373,6 +579,43 @@ type InternalWorkbenchSpec struct {
 	JupyterConfig *WorkbenchJupyterConfig `json:"jupyterConfig,omitempty"`
 }
 
+// WorkbenchLauncherProfile is a section of launcher.kubernetes.profiles.conf. Settings that are not set fall back to
+// the profile for all users
+type WorkbenchLauncherProfile struct {
+	// Targets are the users and "@groups" that the profile applies to, or "*" for all users
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:MinLength=1
+	Targets []string `json:"targets"`
+
+	// Images are the session images that the targets can choose from
+	// +optional
+	Images []string `json:"images,omitempty"`
+
+	// DefaultImage is the default session image. Defaults to the first of Images
+	// +optional
+	DefaultImage string `json:"defaultImage,omitempty"`
+
+	// ResourceProfiles are the resource profiles that the targets can choose from. Each must be a key of
+	// experimentalFeatures.resourceProfiles, or of the default resource profiles when those are not set
+	// +optional
+	ResourceProfiles []string `json:"resourceProfiles,omitempty"`
+
+	// MaxCpus is the maximum number of CPUs for a session
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	MaxCpus string `json:"maxCpus,omitempty"`
+
+	// MaxMemMb is the maximum memory for a session in megabytes
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxMemMb *int32 `json:"maxMemMb,omitempty"`
+
+	// MaxNvidiaGpus is the maximum number of NVIDIA GPUs for a session
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxNvidiaGpus *int32 `json:"maxNvidiaGpus,omitempty"`
+}
+
 type InternalWorkbenchExperimentalFeatures struct {
 	EnableManagedCredentialJobs bool `json:"enableManagedCredentialJobs,omitempty"`
 
@@ -447,6 +690,10 @@ type InternalWorkbenchExperimentalFeatures struct {
 type InternalChronicleSpec struct {
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 
+	// PodSchedulingConfig sets the affinity, tolerations, topology spread constraints, priority class and extra
+	// metadata of the server pods
+	PodSchedulingConfig `json:",inline"`
+
 	Image string `json:"image,omitempty"`
 
 	AddEnv map[string]string `json:"addEnv,omitempty"`
@@ -456,6 +703,15 @@ type InternalChronicleSpec struct {
 	S3Bucket string `json:"s3Bucket,omitempty"`
 
 	AgentImage string `json:"agentImage,omitempty"`
+
+	// Resources are the resource requests and limits of the Chronicle server container. Defaults to DefaultChronicleResources
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// AgentResources are the resource requests and limits of the Chronicle agent sidecar of each product. Defaults
+	// to DefaultChronicleAgentResources
+	// +optional
+	AgentResources *corev1.ResourceRequirements `json:"agentResources,omitempty"`
 }
 
 type InternalKeycloakSpec struct {
@@ -463,6 +719,21 @@ type InternalKeycloakSpec struct {
 	Image           string            `json:"image,omitempty"`
 	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
 	NodeSelector    map[string]string `json:"nodeSelector,omitempty"`
+
+	// Hostnames are the hosts that the product is served on. The first is the primary hostname, which the rest
+	// of the Site uses to reach the product; the others are aliases (i.e. during a domain migration). Defaults
+	// to "key.<domain>" (or the Site domain in the path layout)
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// TLSSecretName is the TLS Secret for the product's Ingress. When set, every hostname is listed in the
+	// Ingress TLS section
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// PodSchedulingConfig sets the affinity, tolerations, topology spread constraints, priority class and extra
+	// metadata of the server pods
+	PodSchedulingConfig `json:",inline"`
 }
 
 type SnowflakeConfig struct {
@@ -508,10 +779,179 @@ type ApiSettingsConfig struct {
 	WorkbenchApiSuperAdminEnabled int `json:"workbenchApiSuperAdminEnabled,omitempty"`
 }
 
+// NetworkPolicyBackend is the policy engine that a Site's NetworkPolicies are rendered for
+type NetworkPolicyBackend string
+
+const (
+	NetworkPolicyBackendKubernetes NetworkPolicyBackend = "kubernetes"
+	NetworkPolicyBackendCilium     NetworkPolicyBackend = "cilium"
+	NetworkPolicyBackendCalico     NetworkPolicyBackend = "calico"
+)
+
+// NetworkPolicyConfig customizes the NetworkPolicies created for a Site
+type NetworkPolicyConfig struct {
+	// Backend selects the policy engine. "kubernetes" (the default) creates networking.k8s.io NetworkPolicies.
+	// "cilium" also creates CiliumNetworkPolicies and "calico" also creates Calico NetworkPolicies (and
+	// NetworkSets), which can limit egress to the domains in EgressFQDNs
+	// +kubebuilder:validation:Enum=kubernetes;cilium;calico
+	// +optional
+	Backend NetworkPolicyBackend `json:"backend,omitempty"`
+
+	// ReplaceKubernetesPolicies renders each component's whole policy for the Backend instead of creating
+	// networking.k8s.io NetworkPolicies alongside the Backend's policies. Ignored for the "kubernetes" Backend
+	// +optional
+	ReplaceKubernetesPolicies bool `json:"replaceKubernetesPolicies,omitempty"`
+
+	// EgressFQDNs are the internet domains that each component may reach, i.e. "pypi.org" or
+	// "*.snowflakecomputing.com". When a component has domains (and Backend is not "kubernetes"), its internet
+	// egress is limited to them. Private networks are still reachable wherever the policy allowed them before
+	// +optional
+	EgressFQDNs NetworkPolicyFQDNs `json:"egressFQDNs,omitempty"`
+
+	// PrivateCIDRs are the private address ranges that sessions are not allowed to reach. Products can reach
+	// them only where their policy allows it. Defaults to 192.168.0.0/16, 10.0.0.0/8 and 172.16.0.0/12
+	// +optional
+	PrivateCIDRs []string `json:"privateCIDRs,omitempty"`
+
+	// PodCIDRs are the address ranges of the cluster pod network, which products (but not sessions) can always
+	// reach. Defaults to 172.16.0.0/12, which assumes Calico's default pod network
+	// +optional
+	PodCIDRs []string `json:"podCIDRs,omitempty"`
+
+	// InfrastructureCIDRs are the address ranges that Connect and Keycloak reach their databases (and other
+	// private HTTPS services) on. Defaults to 10.0.0.0/8
+	// +optional
+	InfrastructureCIDRs []string `json:"infrastructureCIDRs,omitempty"`
+
+	// ExtraEgress are egress rules added to each component's NetworkPolicy, i.e. to allow sessions to reach a
+	// private data warehouse or Git server
+	// +optional
+	ExtraEgress NetworkPolicyEgressRules `json:"extraEgress,omitempty"`
+
+	// ExtraIngress are ingress rules added to each component's NetworkPolicy
+	// +optional
+	ExtraIngress NetworkPolicyIngressRules `json:"extraIngress,omitempty"`
+}
+
+// NetworkPolicyEgressRules are extra egress rules for each component of a Site
+type NetworkPolicyEgressRules struct {
+	Connect          []networkingv1.NetworkPolicyEgressRule `json:"connect,omitempty"`
+	ConnectSession   []networkingv1.NetworkPolicyEgressRule `json:"connectSession,omitempty"`
+	Workbench        []networkingv1.NetworkPolicyEgressRule `json:"workbench,omitempty"`
+	WorkbenchSession []networkingv1.NetworkPolicyEgressRule `json:"workbenchSession,omitempty"`
+	PackageManager   []networkingv1.NetworkPolicyEgressRule `json:"packageManager,omitempty"`
+	Chronicle        []networkingv1.NetworkPolicyEgressRule `json:"chronicle,omitempty"`
+	Keycloak         []networkingv1.NetworkPolicyEgressRule `json:"keycloak,omitempty"`
+}
+
+// NetworkPolicyIngressRules are extra ingress rules for each component of a Site
+type NetworkPolicyIngressRules struct {
+	Connect          []networkingv1.NetworkPolicyIngressRule `json:"connect,omitempty"`
+	ConnectSession   []networkingv1.NetworkPolicyIngressRule `json:"connectSession,omitempty"`
+	Workbench        []networkingv1.NetworkPolicyIngressRule `json:"workbench,omitempty"`
+	WorkbenchSession []networkingv1.NetworkPolicyIngressRule `json:"workbenchSession,omitempty"`
+	PackageManager   []networkingv1.NetworkPolicyIngressRule `json:"packageManager,omitempty"`
+	Chronicle        []networkingv1.NetworkPolicyIngressRule `json:"chronicle,omitempty"`
+	Keycloak         []networkingv1.NetworkPolicyIngressRule `json:"keycloak,omitempty"`
+}
+
+// NetworkPolicyFQDNs are the egress domain allow-lists for each component of a Site
+type NetworkPolicyFQDNs struct {
+	Connect          []string `json:"connect,omitempty"`
+	ConnectSession   []string `json:"connectSession,omitempty"`
+	Workbench        []string `json:"workbench,omitempty"`
+	WorkbenchSession []string `json:"workbenchSession,omitempty"`
+	PackageManager   []string `json:"packageManager,omitempty"`
+	Chronicle        []string `json:"chronicle,omitempty"`
+	Keycloak         []string `json:"keycloak,omitempty"`
+}
+
+// GetBackend returns the configured policy engine, defaulting to Kubernetes NetworkPolicies
+func (n *NetworkPolicyConfig) GetBackend() NetworkPolicyBackend {
+	if n == nil || n.Backend == "" {
+		return NetworkPolicyBackendKubernetes
+	}
+	return n.Backend
+}
+
+// UseKubernetesPolicies reports whether networking.k8s.io NetworkPolicies should be created
+func (n *NetworkPolicyConfig) UseKubernetesPolicies() bool {
+	return n.GetBackend() == NetworkPolicyBackendKubernetes || !n.ReplaceKubernetesPolicies
+}
+
+// GetEgressFQDNs returns the egress domain allow-lists for each component
+func (n *NetworkPolicyConfig) GetEgressFQDNs() NetworkPolicyFQDNs {
+	if n == nil {
+		return NetworkPolicyFQDNs{}
+	}
+	return n.EgressFQDNs
+}
+
+// GetPrivateCIDRs returns the private address ranges, defaulting to all RFC 1918 ranges
+func (n *NetworkPolicyConfig) GetPrivateCIDRs() []string {
+	if n == nil || len(n.PrivateCIDRs) == 0 {
+		return []string{"192.168.0.0/16", "10.0.0.0/8", "172.16.0.0/12"}
+	}
+	return n.PrivateCIDRs
+}
+
+// GetPodCIDRs returns the pod network address ranges, defaulting to the Calico pod network
+func (n *NetworkPolicyConfig) GetPodCIDRs() []string {
+	if n == nil || len(n.PodCIDRs) == 0 {
+		return []string{"172.16.0.0/12"}
+	}
+	return n.PodCIDRs
+}
+
+// GetInfrastructureCIDRs returns the address ranges of private infrastructure, defaulting to 10.0.0.0/8
+func (n *NetworkPolicyConfig) GetInfrastructureCIDRs() []string {
+	if n == nil || len(n.InfrastructureCIDRs) == 0 {
+		return []string{"10.0.0.0/8"}
+	}
+	return n.InfrastructureCIDRs
+}
+
+// GetExtraEgress returns the extra egress rules for each component
+func (n *NetworkPolicyConfig) GetExtraEgress() NetworkPolicyEgressRules {
+	if n == nil {
+		return NetworkPolicyEgressRules{}
+	}
+	return n.ExtraEgress
+}
+
+// GetExtraIngress returns the extra ingress rules for each component
+func (n *NetworkPolicyConfig) GetExtraIngress() NetworkPolicyIngressRules {
+	if n == nil {
+		return NetworkPolicyIngressRules{}
+	}
+	return n.ExtraIngress
+}
+
 // SiteStatus defines the observed state of Site
 type SiteStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// NetworkPolicy is the network trust level in effect and the rules of the Site's NetworkPolicies
+	// +optional
+	NetworkPolicy *NetworkPolicyStatus `json:"networkPolicy,omitempty"`
+
+	// FailedOverrides lists the overrides that failed to apply, i.e. "Deployment/connect: <error>"
+	// +optional
+	FailedOverrides []string `json:"failedOverrides,omitempty"`
+
+	// SessionNamespace is the Workbench session namespace that the Site created, which it deletes when it no longer
+	// uses it
+	// +optional
+	SessionNamespace string `json:"sessionNamespace,omitempty"`
+}
+
+// NetworkPolicyStatus describes the NetworkPolicies in effect for a Site
+type NetworkPolicyStatus struct {
+	// Level is the NetworkTrust level in effect: "full", "same-site" or "zero"
+	Level string `json:"level,omitempty"`
+
+	// Rules describe the traffic that each policy allows, i.e. "site-packagemanager: allow ingress from pods
+	// posit.team/component=workbench on 4242/TCP"
+	// +optional
+	Rules []string `json:"rules,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -542,6 +982,14 @@ func init() {
 	SchemeBuilder.Register(&Site{}, &SiteList{})
 }
 
+// GetNetworkTrust returns the configured network trust, defaulting to NetworkTrustFull
+func (s *SiteSpec) GetNetworkTrust() NetworkTrust {
+	if s.NetworkTrust == nil {
+		return NetworkTrustFull
+	}
+	return *s.NetworkTrust
+}
+
 func (s *Site) GetSecretType() product.SiteSecretType {
 	return s.Spec.Secret.Type
 }
@@ -572,3 +1020,49 @@ func (s *Site) KubernetesLabels() map[string]string {
 		ComponentLabelKey: "site",
 	})
 }
+
+// ProductHost returns the primary host of a product: the first of its configured hostnames, or the host
+// derived from its domain prefix and the Site layout
+func (s *Site) ProductHost(domainPrefix string, hostnames []string) string {
+	if len(hostnames) > 0 && hostnames[0] != "" {
+		return hostnames[0]
+	}
+	switch s.Spec.Routing.GetLayout() {
+	case SiteDashDomain:
+		return fmt.Sprintf("%s-%s", domainPrefix, s.Spec.Domain)
+	case SitePathDomain:
+		return s.Spec.Domain
+	default:
+		return fmt.Sprintf("%s.%s", domainPrefix, s.Spec.Domain)
+	}
+}
+
+// FlightdeckHost returns the primary host of Flightdeck, which is the Site domain unless hostnames are configured
+func (s *Site) FlightdeckHost() string {
+	if len(s.Spec.Flightdeck.Hostnames) > 0 && s.Spec.Flightdeck.Hostnames[0] != "" {
+		return s.Spec.Flightdeck.Hostnames[0]
+	}
+	return s.Spec.Domain
+}
+
+// ProductAliases returns the hosts that a product is served on in addition to its primary host
+func (s *Site) ProductAliases(hostnames []string) []string {
+	if len(hostnames) < 2 {
+		return nil
+	}
+	return hostnames[1:]
+}
+
+// ProductPath returns the path that the product with the given domain prefix is served under, or "" when the
+// product is served at the root of its host
+func (s *Site) ProductPath(domainPrefix string) string {
+	if s.Spec.Routing.GetLayout() == SitePathDomain {
+		return "/" + domainPrefix
+	}
+	return ""
+}
+
+// ProductUrl returns the external URL of a product (see ProductHost and ProductPath)
+func (s *Site) ProductUrl(domainPrefix string, hostnames []string) string {
+	return fmt.Sprintf("https://%s%s", s.ProductHost(domainPrefix, hostnames), s.ProductPath(domainPrefix))
+}
@@ -0,0 +1,41 @@
+package v1beta1_test
+
+import (
+	"testing"
+
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteProductUrl(t *testing.T) {
+	site := &v1beta1.Site{
+		Spec: v1beta1.SiteSpec{
+			Domain: "posit.example.com",
+		},
+	}
+
+	// subdomains are the default
+	assert.Equal(t, "connect.posit.example.com", site.ProductHost("connect", nil))
+	assert.Equal(t, "", site.ProductPath("connect"))
+	assert.Equal(t, "https://connect.posit.example.com", site.ProductUrl("connect", nil))
+
+	site.Spec.Routing = &v1beta1.RoutingConfig{Layout: v1beta1.SiteDashDomain}
+	assert.Equal(t, "connect-posit.example.com", site.ProductHost("connect", nil))
+	assert.Equal(t, "", site.ProductPath("connect"))
+	assert.Equal(t, "https://connect-posit.example.com", site.ProductUrl("connect", nil))
+
+	site.Spec.Routing = &v1beta1.RoutingConfig{Layout: v1beta1.SitePathDomain}
+	assert.Equal(t, "posit.example.com", site.ProductHost("connect", nil))
+	assert.Equal(t, "/connect", site.ProductPath("connect"))
+	assert.Equal(t, "https://posit.example.com/connect", site.ProductUrl("connect", nil))
+
+	// the first hostname overrides the layout, the rest are aliases
+	hostnames := []string{"rsc.example.com", "connect.old.example.com"}
+	assert.Equal(t, "rsc.example.com", site.ProductHost("connect", hostnames))
+	assert.Equal(t, []string{"connect.old.example.com"}, site.ProductAliases(hostnames))
+	assert.Nil(t, site.ProductAliases(hostnames[:1]))
+
+	assert.Equal(t, "posit.example.com", site.FlightdeckHost())
+	site.Spec.Flightdeck.Hostnames = []string{"home.example.com"}
+	assert.Equal(t, "home.example.com", site.FlightdeckHost())
+}
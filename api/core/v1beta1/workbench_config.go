@@ -6,11 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"unicode"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -134,6 +137,14 @@ func (w *WorkbenchSessionIniConfig) GenerateConfigMap() map[string]string {
 			sessionConfigName := sessionValues.Type().Field(j).Name
 			sessionConfigValue := sessionValues.Field(j)
 
+			// optional settings are pointers that are only written when set
+			if sessionConfigValue.Kind() == reflect.Pointer {
+				if sessionConfigValue.IsNil() {
+					continue
+				}
+				sessionConfigValue = sessionConfigValue.Elem()
+			}
+
 			if sessionConfigValue.String() != "" {
 
 				if sessionConfigValue.Kind() == reflect.Slice {
@@ -362,6 +373,10 @@ type WorkbenchRSessionConfig struct {
 	// SessionFirstProjectTemplatePath must be configured in the "project template," but _is_ a per-session-image setting
 	SessionFirstProjectTemplatePath string            `json:"session-first-project-template-path,omitempty"`
 	SessionSaveActionDefault        SessionSaveAction `json:"session-save-action-default,omitempty"`
+	// SessionTimeoutMinutes suspends sessions that are idle for longer. Nil leaves the Workbench default
+	SessionTimeoutMinutes *int `json:"session-timeout-minutes,omitempty"`
+	// SessionTimeoutKillHours stops sessions that are idle for longer. Nil leaves the Workbench default
+	SessionTimeoutKillHours *int `json:"session-timeout-kill-hours,omitempty"`
 }
 
 type SessionSaveAction string
@@ -382,6 +397,121 @@ type WorkbenchLauncherKubnernetesResourcesConfigSection struct {
 	NvidiaGpus           string `json:"nvidia-gpus,omitempty"`
 	AmdGpus              string `json:"amd-gpus,omitempty"`
 	PlacementConstraints string `json:"placement-constraints,omitempty"`
+	// Pod customizes the session pods that use the resource profile. It is not written to
+	// launcher.kubernetes.resources.conf
+	Pod *WorkbenchResourceProfilePodConfig `json:"pod,omitempty"`
+}
+
+// ResourceProfileAnnotationKey is the session Pod annotation that job.tpl records the resource profile of the
+// session in
+const ResourceProfileAnnotationKey = "posit.team/resource-profile"
+
+// WorkbenchResourceProfilePodConfig customizes the session pods of a resource profile. The NodeSelector is added to
+// the placement constraints of the resource profile, and the rest is rendered into the session Job by job.tpl
+type WorkbenchResourceProfilePodConfig struct {
+	Tolerations  []corev1.Toleration `json:"tolerations,omitempty"`
+	NodeSelector map[string]string   `json:"nodeSelector,omitempty"`
+	// Affinity replaces the affinity of the session config
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// PriorityClassName replaces the priorityClassName of the session config. It is ignored when a sessionQuota is
+	// set, because the quota only counts the pods of its own PriorityClass
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	RuntimeClassName  string `json:"runtimeClassName,omitempty"`
+	// Volumes and VolumeMounts are added to those of the session config
+	Volumes      []corev1.Volume      `json:"volumes,omitempty"`
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+}
+
+// templated reports whether the Pod has customizations that job.tpl renders, as opposed to the NodeSelector
+func (p *WorkbenchResourceProfilePodConfig) templated() bool {
+	return p != nil && (len(p.Tolerations) > 0 || p.Affinity != nil || p.PriorityClassName != "" ||
+		p.RuntimeClassName != "" || len(p.Volumes) > 0 || len(p.VolumeMounts) > 0)
+}
+
+// placementConstraints returns the placement constraints of the resource profile, with the NodeSelector of its Pod
+func (r *WorkbenchLauncherKubnernetesResourcesConfigSection) placementConstraints() []string {
+	var constraints []string
+	if r.Pod != nil {
+		keys := make([]string, 0, len(r.Pod.NodeSelector))
+		for k := range r.Pod.NodeSelector {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			constraints = append(constraints, k+":"+r.Pod.NodeSelector[k])
+		}
+	}
+	return mergeConstraints(parseConstraintsString(r.PlacementConstraints), constraints)
+}
+
+// ResourceLimits returns the resource limits that the launcher gives the sessions of the resource profile, by the
+// type that job.tpl sees them as. Sessions without GPUs have none, so they are 0 unless set
+func (r *WorkbenchLauncherKubnernetesResourcesConfigSection) ResourceLimits() map[string]string {
+	limits := map[string]string{"NVIDIA GPUs": "0", "AMD GPUs": "0"}
+	for t, v := range map[string]string{
+		"cpuCount":       r.Cpus,
+		"CPU Request":    r.CpusRequest,
+		"memory":         r.MemMb,
+		"Memory Request": r.MemMbRequest,
+		"NVIDIA GPUs":    r.NvidiaGpus,
+		"AMD GPUs":       r.AmdGpus,
+	} {
+		if v != "" {
+			limits[t] = v
+		}
+	}
+	return limits
+}
+
+// resourceLimitsMatch reports whether a session with the limits of other is taken for a session of a resource
+// profile with limits, i.e. whether other has the same value for each of them
+func resourceLimitsMatch(limits, other map[string]string) bool {
+	for t, v := range limits {
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return false
+		}
+		o, err := resource.ParseQuantity(other[t])
+		if err != nil || q.Cmp(o) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// resourceProfileTemplateData is the Pod of a resource profile in the template data of job.tpl, with the resource
+// limits that job.tpl recognizes the sessions of the profile by
+// +kubebuilder:object:generate=false
+type resourceProfileTemplateData struct {
+	*WorkbenchResourceProfilePodConfig
+	ResourceLimits map[string]string `json:"resourceLimits"`
+}
+
+// ResourceProfilePods returns the Pods of the resource profiles that job.tpl renders, by resource profile key.
+// job.tpl recognizes the sessions of a profile by their resource limits, so a profile whose limits match those of
+// another profile is left out and returned in ambiguous
+func (w *WorkbenchIniConfig) ResourceProfilePods() (pods map[string]*WorkbenchResourceProfilePodConfig, ambiguous []string) {
+	pods = map[string]*WorkbenchResourceProfilePodConfig{}
+	for key, r := range w.Resources {
+		if r == nil || !r.Pod.templated() {
+			continue
+		}
+		limits := r.ResourceLimits()
+		unique := true
+		for otherKey, other := range w.Resources {
+			if otherKey != key && other != nil && resourceLimitsMatch(limits, other.ResourceLimits()) {
+				unique = false
+				break
+			}
+		}
+		if unique {
+			pods[key] = r.Pod
+		} else {
+			ambiguous = append(ambiguous, key)
+		}
+	}
+	sort.Strings(ambiguous)
+	return pods, ambiguous
 }
 
 type WorkbenchLauncherEnvConfig struct {
@@ -414,64 +544,123 @@ type WorkbenchLauncherKubernetesProfilesConfigSection struct {
 
 type WorkbenchProfilesConfig struct {
 	LauncherKubernetesProfiles map[string]WorkbenchLauncherKubernetesProfilesConfigSection `json:"launcher.kubernetes.profiles.conf,omitempty"`
-}
-
-func (w *WorkbenchProfilesConfig) GenerateConfigMap() map[string]string {
 
-	configMap := make(map[string]string, countInitializedFields(*w))
+	// Profiles are written to launcher.kubernetes.profiles.conf in order, after the sections of
+	// LauncherKubernetesProfiles. A profile that targets one of those sections changes its settings instead
+	// +optional
+	Profiles []WorkbenchLauncherProfile `json:"profiles,omitempty"`
+}
 
-	configStructValsPtr := reflect.ValueOf(w)
-	configStructVals := reflect.Indirect(configStructValsPtr)
+// launcherProfileSection is a section of launcher.kubernetes.profiles.conf
+// +kubebuilder:object:generate=false
+type launcherProfileSection struct {
+	name    string
+	section WorkbenchLauncherKubernetesProfilesConfigSection
 
-	for i := 0; i < configStructVals.NumField(); i++ {
-		var builder strings.Builder
+	// generated sections come from Profiles alone, and only write the settings that their profiles set
+	generated bool
+}
 
-		fieldName := configStructVals.Type().Field(i).Name
-		field, _ := reflect.TypeOf(w).Elem().FieldByName(fieldName)
-		fieldTag := string(field.Tag)
-		fieldTag = strings.ReplaceAll(fieldTag, "json:\"", "")
-		fieldTag = strings.ReplaceAll(fieldTag, ",omitempty\"", "")
-		fieldValue := configStructVals.Field(i)
+// launcherProfileSections returns the sections in the order that they are written: "*" first, then the other
+// sections of LauncherKubernetesProfiles sorted, then the targets of Profiles in order
+func (w *WorkbenchProfilesConfig) launcherProfileSections() []launcherProfileSection {
+	names := make([]string, 0, len(w.LauncherKubernetesProfiles))
+	for name := range w.LauncherKubernetesProfiles {
+		if name != "*" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	_, all := w.LauncherKubernetesProfiles["*"]
+	for _, p := range w.Profiles {
+		all = all || slices.Contains(p.Targets, "*")
+	}
+	if all {
+		names = append([]string{"*"}, names...)
+	}
 
-		if fieldValue.IsNil() {
-			continue
+	sections := make([]launcherProfileSection, 0, len(names))
+	index := make(map[string]int, len(names))
+	for _, name := range names {
+		index[name] = len(sections)
+		sections = append(sections, launcherProfileSection{name: name, section: w.LauncherKubernetesProfiles[name]})
+	}
+	for _, p := range w.Profiles {
+		for _, target := range p.Targets {
+			i, ok := index[target]
+			if !ok {
+				i = len(sections)
+				index[target] = i
+				sections = append(sections, launcherProfileSection{name: target, generated: true})
+			}
+			sections[i].section = p.apply(sections[i].section)
 		}
+	}
+	return sections
+}
 
-		profiles := reflect.Indirect(fieldValue)
+// apply sets the settings of p that are set on section
+func (p *WorkbenchLauncherProfile) apply(section WorkbenchLauncherKubernetesProfilesConfigSection) WorkbenchLauncherKubernetesProfilesConfigSection {
+	if len(p.Images) > 0 {
+		section.ContainerImages = p.Images
+		section.DefaultContainerImage = p.Images[0]
+	}
+	if p.DefaultImage != "" {
+		section.DefaultContainerImage = p.DefaultImage
+	}
+	if len(p.ResourceProfiles) > 0 {
+		section.ResourceProfiles = p.ResourceProfiles
+	}
+	if p.MaxCpus != "" {
+		section.MaxCpus = p.MaxCpus
+	}
+	if p.MaxMemMb != nil {
+		section.MaxMemMb = strconv.Itoa(int(*p.MaxMemMb))
+	}
+	if p.MaxNvidiaGpus != nil {
+		section.MaxNvidiaGpus = strconv.Itoa(int(*p.MaxNvidiaGpus))
+	}
+	return section
+}
 
-		iter := profiles.MapRange()
+// GenerateConfigMap writes launcher.kubernetes.profiles.conf. Each section gets the placement constraints of the
+// resource profiles that it references
+func (w *WorkbenchProfilesConfig) GenerateConfigMap(resources map[string]*WorkbenchLauncherKubnernetesResourcesConfigSection) map[string]string {
+	var builder strings.Builder
 
-		for iter.Next() {
-			profileName := iter.Key()
-			profileValues := iter.Value()
+	for _, s := range w.launcherProfileSections() {
+		profileValues := reflect.ValueOf(withPlacementConstraints(s.name, s.section, resources))
 
-			builder.WriteString("\n[" + fmt.Sprintf("%v", profileName) + "]\n")
+		builder.WriteString("\n[" + s.name + "]\n")
 
-			for j := 0; j < profileValues.NumField(); j++ {
-				profileConfigName := profileValues.Type().Field(j).Name
-				profileConfigValue := profileValues.Field(j)
+		for j := 0; j < profileValues.NumField(); j++ {
+			profileConfigName := profileValues.Type().Field(j).Name
+			profileConfigValue := profileValues.Field(j)
 
-				if profileConfigValue.String() != "" {
-					if profileConfigValue.Kind() == reflect.Slice {
-						arrayString := sliceToString(profileConfigValue, ",")
-						if fmt.Sprintf("%v", arrayString) != "" {
-							builder.WriteString(toKebabCase(profileConfigName) + "=" + fmt.Sprintf("%v", arrayString) + "\n")
-						}
-					} else if fmt.Sprintf("%v", profileConfigValue) != "" {
-						builder.WriteString(toKebabCase(profileConfigName) + "=" + fmt.Sprintf("%v", profileConfigValue) + "\n")
+			// profiles do not set numbers, so generated sections leave them to "*"
+			if s.generated && profileConfigValue.Kind() == reflect.Int && profileConfigValue.Int() == 0 {
+				continue
+			}
+
+			if profileConfigValue.String() != "" {
+				if profileConfigValue.Kind() == reflect.Slice {
+					arrayString := sliceToString(profileConfigValue, ",")
+					if fmt.Sprintf("%v", arrayString) != "" {
+						builder.WriteString(toKebabCase(profileConfigName) + "=" + fmt.Sprintf("%v", arrayString) + "\n")
 					}
+				} else if fmt.Sprintf("%v", profileConfigValue) != "" {
+					builder.WriteString(toKebabCase(profileConfigName) + "=" + fmt.Sprintf("%v", profileConfigValue) + "\n")
 				}
 			}
 		}
-		finalString := builder.String()
-		// Ensure all config files have a trailing newline
-		if !strings.HasSuffix(finalString, "\n") {
-			finalString += "\n"
-		}
-		configMap[fieldTag] = finalString
+	}
+	finalString := builder.String()
+	// Ensure all config files have a trailing newline
+	if !strings.HasSuffix(finalString, "\n") {
+		finalString += "\n"
 	}
 
-	return configMap
+	return map[string]string{"launcher.kubernetes.profiles.conf": finalString}
 }
 
 // Supervisord //
@@ -574,8 +763,16 @@ func (w *WorkbenchIniConfig) GenerateConfigMap() map[string]string {
 						valueKey := value.Type().Field(j).Name
 						valueVal := value.Field(j)
 
-						if fmt.Sprintf("%v", valueVal) != "" {
-							builder.WriteString(fmt.Sprintf("%v", toKebabCase(valueKey)) + "=" + fmt.Sprintf("%v", valueVal) + "\n")
+						// the Pod is rendered by job.tpl instead
+						if valueVal.Kind() != reflect.String {
+							continue
+						}
+						str := valueVal.String()
+						if valueKey == "PlacementConstraints" {
+							str = strings.Join(profile.value.placementConstraints(), ",")
+						}
+						if str != "" {
+							builder.WriteString(fmt.Sprintf("%v", toKebabCase(valueKey)) + "=" + str + "\n")
 						}
 					}
 				}
@@ -791,11 +988,8 @@ func (w *WorkbenchConfig) GenerateConfigmap() (map[string]string, error) {
 		}
 	}
 
-	// Synchronize placement constraints from resources to profiles before generating profiles config
-	w.syncPlacementConstraints()
-
 	if countInitializedFields(w.WorkbenchProfilesConfig) != 0 {
-		for k, v := range w.WorkbenchProfilesConfig.GenerateConfigMap() {
+		for k, v := range w.WorkbenchProfilesConfig.GenerateConfigMap(w.Resources) {
 			m[k] = v
 		}
 	}
@@ -945,6 +1139,7 @@ type WorkbenchRServerConfig struct {
 	AdminGroup                             string   `json:"admin-group,omitempty"`
 	AdminSuperuserGroup                    string   `json:"admin-superuser-group,omitempty"`
 	WwwPort                                int      `json:"www-port,omitempty"`
+	WwwRootPath                            string   `json:"www-root-path,omitempty"`
 	ServerProjectSharing                   int      `json:"server-project-sharing,omitempty"`
 	LauncherAddress                        string   `json:"launcher-address,omitempty"`
 	LauncherPort                           int      `json:"launcher-port,omitempty"`
@@ -967,6 +1162,7 @@ type WorkbenchRServerConfig struct {
 	WwwThreadPoolSize                      int      `json:"www-thread-pool-size,omitempty"`
 	LauncherSessionsProxyTimeoutSeconds    int      `json:"launcher-sessions-proxy-timeout-seconds,omitempty"`
 	LauncherSessionsAutoUpdate             int      `json:"launcher-sessions-auto-update,omitempty"`
+	LauncherSessionsCreateContainerUser    int      `json:"launcher-sessions-create-container-user,omitempty"`
 	LauncherSessionsInitContainerImageName string   `json:"launcher-sessions-init-container-image-name,omitempty"`
 	LauncherSessionsInitContainerImageTag  string   `json:"launcher-sessions-init-container-image-tag,omitempty"`
 	DatabricksEnabled                      int      `json:"databricks-enabled,omitempty"`
@@ -1209,44 +1405,38 @@ func validateConstraintFormat(constraint string) bool {
 	return true
 }
 
-// syncPlacementConstraints synchronizes placement constraints from resource profiles
-// to the launcher profiles that reference them
-func (w *WorkbenchConfig) syncPlacementConstraints() {
-	if w.Resources == nil || w.LauncherKubernetesProfiles == nil {
-		return
+// withPlacementConstraints adds the placement constraints of the resource profiles that the launcher profile
+// references to its own
+func withPlacementConstraints(
+	profileName string,
+	profile WorkbenchLauncherKubernetesProfilesConfigSection,
+	resources map[string]*WorkbenchLauncherKubnernetesResourcesConfigSection,
+) WorkbenchLauncherKubernetesProfilesConfigSection {
+	if resources == nil || len(profile.ResourceProfiles) == 0 {
+		return profile
 	}
 
 	logger := ctrl.Log.WithName("workbench-config")
 
-	// Iterate through each launcher profile
-	for profileName, profile := range w.LauncherKubernetesProfiles {
-		if profile.ResourceProfiles == nil || len(profile.ResourceProfiles) == 0 {
+	// Collect constraints from referenced resource profiles
+	var newConstraints []string
+	for _, resourceName := range profile.ResourceProfiles {
+		resource, exists := resources[resourceName]
+		if !exists {
+			logger.Info("Resource profile not found", "profile", profileName, "resource", resourceName)
 			continue
 		}
 
-		// Collect constraints from referenced resource profiles
-		var newConstraints []string
-		for _, resourceName := range profile.ResourceProfiles {
-			resource, exists := w.Resources[resourceName]
-			if !exists {
-				logger.Info("Resource profile not found", "profile", profileName, "resource", resourceName)
-				continue
-			}
-
-			if resource.PlacementConstraints != "" {
-				constraints := parseConstraintsString(resource.PlacementConstraints)
-				for _, c := range constraints {
-					if validateConstraintFormat(c) {
-						newConstraints = append(newConstraints, c)
-					} else {
-						logger.Info("Invalid constraint format", "constraint", c, "resource", resourceName)
-					}
-				}
+		for _, c := range resource.placementConstraints() {
+			if validateConstraintFormat(c) {
+				newConstraints = append(newConstraints, c)
+			} else {
+				logger.Info("Invalid constraint format", "constraint", c, "resource", resourceName)
 			}
 		}
-
-		// Merge with existing constraints
-		profile.PlacementConstraints = mergeConstraints(profile.PlacementConstraints, newConstraints)
-		w.LauncherKubernetesProfiles[profileName] = profile
 	}
+
+	// Merge with existing constraints
+	profile.PlacementConstraints = mergeConstraints(profile.PlacementConstraints, newConstraints)
+	return profile
 }
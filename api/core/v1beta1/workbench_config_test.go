@@ -7,7 +7,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/rstudio/goex/ptr"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
@@ -127,7 +129,7 @@ func TestWorkbenchConfig_GenerateConfigmap(t *testing.T) {
 			},
 		},
 		WorkbenchProfilesConfig: WorkbenchProfilesConfig{
-			map[string]WorkbenchLauncherKubernetesProfilesConfigSection{
+			LauncherKubernetesProfiles: map[string]WorkbenchLauncherKubernetesProfilesConfigSection{
 				"*": WorkbenchLauncherKubernetesProfilesConfigSection{
 					ContainerImages:      []string{"one", "two"},
 					AllowCustomResources: 1,
@@ -244,6 +246,27 @@ func TestWorkbenchConfig_GenerateSessionConfigmap(t *testing.T) {
 	require.Contains(t, cm["positron.conf"], "exe=/some/path")
 }
 
+func TestWorkbenchConfig_GenerateSessionConfigmap_SessionTimeouts(t *testing.T) {
+	wbc := WorkbenchConfig{
+		WorkbenchSessionIniConfig: WorkbenchSessionIniConfig{
+			RSession: &WorkbenchRSessionConfig{
+				SessionTimeoutKillHours: ptr.To(0),
+			},
+		},
+	}
+
+	cm, err := wbc.GenerateSessionConfigmap()
+	require.Nil(t, err)
+	// unset timeouts keep the Workbench default, set ones are written even when they are 0
+	require.NotContains(t, cm["rsession.conf"], "session-timeout-minutes")
+	require.Contains(t, cm["rsession.conf"], "session-timeout-kill-hours=0\n")
+
+	wbc.RSession.SessionTimeoutMinutes = ptr.To(120)
+	cm, err = wbc.GenerateSessionConfigmap()
+	require.Nil(t, err)
+	require.Contains(t, cm["rsession.conf"], "session-timeout-minutes=120\n")
+}
+
 func TestWorkbenchConfig_GenerateDcfConfigmap(t *testing.T) {
 	wbc := WorkbenchConfig{
 		WorkbenchDcfConfig: WorkbenchDcfConfig{
@@ -539,6 +562,42 @@ func TestWorkbenchConfig_GenerateConfigmap_DefaultProfileFirst(t *testing.T) {
 	require.Less(t, tinyIdx, largeIdx, "tiny should come before large")
 }
 
+func TestWorkbenchConfig_GenerateConfigmap_Profiles(t *testing.T) {
+	wb := WorkbenchConfig{
+		WorkbenchProfilesConfig: WorkbenchProfilesConfig{
+			LauncherKubernetesProfiles: map[string]WorkbenchLauncherKubernetesProfilesConfigSection{
+				"bob": {MaxCpus: "1"},
+				"*":   {MaxCpus: "2"},
+			},
+			Profiles: []WorkbenchLauncherProfile{
+				{Targets: []string{"@engineering"}, MaxCpus: "4"},
+				{Targets: []string{"@data-sci", "alice"}, MaxNvidiaGpus: ptr.To(int32(1))},
+				{Targets: []string{"*", "bob"}, MaxMemMb: ptr.To(int32(4000))},
+			},
+		},
+	}
+
+	res, err := wb.GenerateConfigmap()
+	require.Nil(t, err)
+
+	profilesConfig := res["launcher.kubernetes.profiles.conf"]
+
+	// "*" first, then the other sections sorted, then the profiles in order
+	last := -1
+	for _, section := range []string{"[*]", "[bob]", "[@engineering]", "[@data-sci]", "[alice]"} {
+		idx := strings.Index(profilesConfig, section)
+		require.Greater(t, idx, last, section)
+		last = idx
+	}
+	require.Equal(t, 1, strings.Count(profilesConfig, "[*]"))
+	require.Contains(t, profilesConfig, "[*]\nallow-unknown-images=0\nmax-cpus=2\nmax-mem-mb=4000\nallow-custom-resources=0\n")
+	// the sections of the config keep their zero numbers, and the sections of profiles inherit those of "*"
+	require.Contains(t, profilesConfig, "[bob]\nallow-unknown-images=0\nmax-cpus=1\nmax-mem-mb=4000\nallow-custom-resources=0\n")
+	require.Contains(t, profilesConfig, "[@data-sci]\nmax-nvidia-gpus=1\n")
+	require.NotContains(t, profilesConfig, "[alice]\nallow-unknown-images")
+	require.NotContains(t, res, "profiles")
+}
+
 func TestWorkbenchConfig_GenerateConfigmap_MemoryUnits(t *testing.T) {
 	wb := WorkbenchConfig{
 		WorkbenchIniConfig: WorkbenchIniConfig{
@@ -1003,3 +1062,109 @@ func TestWorkbenchConfig_ForceAdminUiEnabled(t *testing.T) {
 	require.Nil(t, err)
 	require.Contains(t, res["rserver.conf"], "force-admin-ui-enabled=0\n")
 }
+
+func TestGenerateRepositoryConfig(t *testing.T) {
+	files := GenerateRepositoryConfig([]WorkbenchRepository{
+		{Name: "CRAN", Type: WorkbenchRepositoryTypeCRAN, URL: "https://ppm.example.com/cran/__linux__/jammy/latest"},
+		{Name: "Internal", Type: WorkbenchRepositoryTypeCRAN, URL: "https://ppm.example.com/internal/latest"},
+		{Name: "Bioconductor", Type: WorkbenchRepositoryTypeBioc, URL: "https://ppm.example.com/bioconductor"},
+		{Name: "PyPI", Type: WorkbenchRepositoryTypePyPI, URL: "https://ppm.example.com/pypi/latest/simple"},
+		{Name: "InternalPyPI", Type: WorkbenchRepositoryTypePyPI, URL: "https://ppm.example.com/python-internal/latest/simple"},
+		{Name: "conda-forge", Type: WorkbenchRepositoryTypeConda, URL: "https://conda.example.com/conda-forge"},
+	})
+
+	require.Equal(t, "CRAN=https://ppm.example.com/cran/__linux__/jammy/latest\n"+
+		"Internal=https://ppm.example.com/internal/latest\n", files["repos.conf"])
+	require.Contains(t, files["Rprofile.site"], `options(BioC_mirror = "https://ppm.example.com/bioconductor")`)
+	require.Contains(t, files["Rprofile.site"], `options(BIOCONDUCTOR_CONFIG_FILE = "https://ppm.example.com/bioconductor/config.yaml")`)
+	// the first PyPI repository is the index
+	require.Equal(t, "[global]\n"+
+		"index-url = https://ppm.example.com/pypi/latest/simple\n"+
+		"extra-index-url =\n"+
+		"    https://ppm.example.com/python-internal/latest/simple\n", files["pip.conf"])
+	require.Equal(t, "channels:\n  - https://conda.example.com/conda-forge\n", files["condarc"])
+
+	// only the files of the listed types are generated
+	files = GenerateRepositoryConfig([]WorkbenchRepository{
+		{Name: "PyPI", Type: WorkbenchRepositoryTypePyPI, URL: "https://pypi.org/simple"},
+	})
+	require.Equal(t, map[string]string{"pip.conf": "[global]\nindex-url = https://pypi.org/simple\n"}, files)
+	require.Empty(t, GenerateRepositoryConfig(nil))
+}
+
+func TestWorkbenchConfig_GenerateConfigmap_ResourceProfilePod(t *testing.T) {
+	wb := WorkbenchConfig{
+		WorkbenchIniConfig: WorkbenchIniConfig{
+			Resources: map[string]*WorkbenchLauncherKubnernetesResourcesConfigSection{
+				"default": {
+					Name:  "Small",
+					Cpus:  "1",
+					MemMb: "2000",
+				},
+				"gpu": {
+					Name:                 "GPU",
+					Cpus:                 "8",
+					MemMb:                "32000",
+					NvidiaGpus:           "1",
+					PlacementConstraints: "gpu-vendor:nvidia",
+					Pod: &WorkbenchResourceProfilePodConfig{
+						NodeSelector:     map[string]string{"node-pool": "gpu"},
+						Tolerations:      []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists}},
+						RuntimeClassName: "nvidia",
+					},
+				},
+				"spot": {
+					Name:  "Spot",
+					Cpus:  "2",
+					MemMb: "4000",
+					Pod: &WorkbenchResourceProfilePodConfig{
+						NodeSelector: map[string]string{"lifecycle": "spot"},
+					},
+				},
+			},
+		},
+		WorkbenchProfilesConfig: WorkbenchProfilesConfig{
+			LauncherKubernetesProfiles: map[string]WorkbenchLauncherKubernetesProfilesConfigSection{
+				"*": {ResourceProfiles: []string{"default", "gpu", "spot"}},
+			},
+		},
+	}
+
+	res, err := wb.GenerateConfigmap()
+	require.Nil(t, err)
+
+	// the nodeSelector becomes placement constraints; job.tpl recognizes the sessions of a profile by their
+	// resource limits, so no constraint that users could select names the profile
+	resources := res["launcher.kubernetes.resources.conf"]
+	require.Contains(t, resources, "[gpu]\nname=GPU\ncpus=8\nmem-mb=32000\nnvidia-gpus=1\nplacement-constraints=gpu-vendor:nvidia,node-pool:gpu\n")
+	require.Contains(t, resources, "[spot]\nname=Spot\ncpus=2\nmem-mb=4000\nplacement-constraints=lifecycle:spot\n")
+	require.Contains(t, resources, "[default]\nname=Small\ncpus=1\nmem-mb=2000\n")
+	require.NotContains(t, resources, "pod=")
+	require.NotContains(t, resources, "nvidia.com/gpu")
+	require.NotContains(t, resources, "posit.team/")
+
+	profiles := res["launcher.kubernetes.profiles.conf"]
+	require.Contains(t, profiles, "placement-constraints=gpu-vendor:nvidia,node-pool:gpu,lifecycle:spot\n")
+
+	pods, ambiguous := wb.ResourceProfilePods()
+	require.Equal(t, map[string]*WorkbenchResourceProfilePodConfig{"gpu": wb.Resources["gpu"].Pod}, pods)
+	require.Empty(t, ambiguous)
+}
+
+func TestWorkbenchIniConfig_ResourceProfilePods_Ambiguous(t *testing.T) {
+	pod := &WorkbenchResourceProfilePodConfig{RuntimeClassName: "nvidia"}
+	w := WorkbenchIniConfig{
+		Resources: map[string]*WorkbenchLauncherKubnernetesResourcesConfigSection{
+			"gpu":       {Cpus: "8", MemMb: "32000", NvidiaGpus: "1", Pod: pod},
+			"gpu-large": {Cpus: "8.0", MemMb: "32000", NvidiaGpus: "1", PlacementConstraints: "node-pool:large"},
+			"gpu-small": {Cpus: "2", NvidiaGpus: "1", Pod: pod},
+			"default":   {Cpus: "2", MemMb: "4000"},
+		},
+	}
+	require.Equal(t, map[string]string{"cpuCount": "8", "memory": "32000", "NVIDIA GPUs": "1", "AMD GPUs": "0"}, w.Resources["gpu"].ResourceLimits())
+
+	// the sessions of gpu-large would be taken for gpu sessions
+	pods, ambiguous := w.ResourceProfilePods()
+	require.Equal(t, map[string]*WorkbenchResourceProfilePodConfig{"gpu-small": pod}, pods)
+	require.Equal(t, []string{"gpu"}, ambiguous)
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+package v1beta1
+
+// WorkbenchExtensionCacheMountPath is where sessions mount the extension cache, read-only
+const WorkbenchExtensionCacheMountPath = "/mnt/extension-cache"
+
+// WorkbenchExtensionCacheConfig installs the VS Code and Positron extensions of the sessions into the shared
+// directory of the Site, so that sessions do not install them from the marketplace when they start
+type WorkbenchExtensionCacheConfig struct {
+	// Subdirectory of the shared directory that the extensions are installed into. Defaults to workbench-extensions
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._-]+$`
+	// +optional
+	Subdirectory string `json:"subdirectory,omitempty"`
+
+	// Image installs the extensions with the VS Code and Positron servers that it contains. Defaults to the
+	// Workbench image
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Source has .vsix files of the extensions. Extensions without a file are installed from the marketplace
+	// +optional
+	Source *WorkbenchExtensionSource `json:"source,omitempty"`
+}
+
+// WorkbenchExtensionCache installs the VS Code and Positron extensions of the config into a directory of a volume,
+// which sessions mount read-only
+type WorkbenchExtensionCache struct {
+	// ClaimName is the PersistentVolumeClaim that the extensions are installed into
+	// +kubebuilder:validation:MinLength=1
+	ClaimName string `json:"claimName"`
+
+	// SubPath is the directory of the volume that the extensions are installed into
+	// +kubebuilder:validation:MinLength=1
+	SubPath string `json:"subPath"`
+
+	// Image installs the extensions. Defaults to the Workbench image
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Source has .vsix files of the extensions
+	// +optional
+	Source *WorkbenchExtensionSource `json:"source,omitempty"`
+}
+
+// WorkbenchExtensionSource is where .vsix files are read from. A file named <publisher>.<name>-<version>.vsix
+// installs the extension, or the version of it that the extension list pins
+// +kubebuilder:validation:XValidation:rule="[has(self.image), has(self.configMapName), has(self.claimName)].filter(x, x).size() == 1",message="exactly one of image, configMapName and claimName must be set"
+type WorkbenchExtensionSource struct {
+	// Image is an OCI image with the .vsix files at its root. It is mounted as an image volume, which needs the
+	// ImageVolume feature gate of Kubernetes 1.31 or later on the API server and the kubelets
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ConfigMapName is a ConfigMap whose binaryData keys are .vsix files
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// ClaimName is a PersistentVolumeClaim with the .vsix files
+	// +optional
+	ClaimName string `json:"claimName,omitempty"`
+
+	// SubPath is the directory of the claim with the .vsix files
+	// +optional
+	SubPath string `json:"subPath,omitempty"`
+}
+
+// WorkbenchExtensionCachePhase is the state of the Job that installs the extensions
+type WorkbenchExtensionCachePhase string
+
+const (
+	WorkbenchExtensionCachePending   WorkbenchExtensionCachePhase = "Pending"
+	WorkbenchExtensionCacheRunning   WorkbenchExtensionCachePhase = "Running"
+	WorkbenchExtensionCacheSucceeded WorkbenchExtensionCachePhase = "Succeeded"
+	WorkbenchExtensionCacheFailed    WorkbenchExtensionCachePhase = "Failed"
+)
+
+// WorkbenchExtensionCacheStatus is the state of the extension cache
+type WorkbenchExtensionCacheStatus struct {
+	// Hash of the extension lists and source. The Job re-runs when it changes
+	Hash string `json:"hash"`
+
+	// Job that installs the extensions
+	Job string `json:"job"`
+
+	// Phase of the Job
+	Phase WorkbenchExtensionCachePhase `json:"phase"`
+}
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+package v1beta1
+
+// LauncherTemplateVersionAuto detects the launcher template version from the tag of the Workbench image
+const LauncherTemplateVersionAuto = "auto"
+
+// WorkbenchConditionLauncherTemplatesValid reports whether the launcher templates of the ConfigMap render a
+// sample session
+const WorkbenchConditionLauncherTemplatesValid = "LauncherTemplatesValid"
+
+// WorkbenchLauncherTemplates chooses the job.tpl and service.tpl that the launcher renders session Jobs and
+// Services with
+type WorkbenchLauncherTemplates struct {
+	// Version of the bundled templates, or auto to detect it from the tag of the Workbench image. Defaults to the
+	// latest version
+	// +kubebuilder:validation:Enum=auto;"2.3.1";"2.4.0";"2.5.0"
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// ConfigMapName is a ConfigMap with a job.tpl, a service.tpl or both, which replace the bundled templates.
+	// A template that does not render a sample session is not used, and the LauncherTemplatesValid condition
+	// says why
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
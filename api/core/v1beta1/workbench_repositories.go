@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+package v1beta1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// +kubebuilder:validation:Enum=cran;bioc;pypi;conda
+type WorkbenchRepositoryType string
+
+const (
+	WorkbenchRepositoryTypeCRAN  WorkbenchRepositoryType = "cran"
+	WorkbenchRepositoryTypeBioc  WorkbenchRepositoryType = "bioc"
+	WorkbenchRepositoryTypePyPI  WorkbenchRepositoryType = "pypi"
+	WorkbenchRepositoryTypeConda WorkbenchRepositoryType = "conda"
+)
+
+const (
+	// WorkbenchRProfileSitePath is where sessions read the Rprofile.site of the repositories from
+	WorkbenchRProfileSitePath = "/mnt/session/rstudio/Rprofile.site"
+	// WorkbenchPipConfPath is the site-wide pip configuration of the sessions
+	WorkbenchPipConfPath = "/etc/pip.conf"
+	// WorkbenchCondarcPath is the system-wide conda configuration of the sessions
+	WorkbenchCondarcPath = "/etc/conda/condarc"
+)
+
+// WorkbenchRepository is a package repository that sessions install packages from
+type WorkbenchRepository struct {
+	// Name of the repository. CRAN repositories are listed under their name in the R repositories
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._-]+$`
+	Name string `json:"name"`
+
+	// Type of the repository: cran, bioc (a Bioconductor mirror), pypi or conda (a channel)
+	Type WorkbenchRepositoryType `json:"type"`
+
+	// URL of the repository, i.e. the simple index of a PyPI repository
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+}
+
+// GenerateRepositoryConfig renders the repositories into the files that sessions read them from. Files are
+// only generated for the types of repositories that are listed
+func GenerateRepositoryConfig(repos []WorkbenchRepository) map[string]string {
+	var cran, pypi, conda []WorkbenchRepository
+	var bioc *WorkbenchRepository
+	for i, r := range repos {
+		switch r.Type {
+		case WorkbenchRepositoryTypeCRAN:
+			cran = append(cran, r)
+		case WorkbenchRepositoryTypeBioc:
+			if bioc == nil {
+				bioc = &repos[i]
+			}
+		case WorkbenchRepositoryTypePyPI:
+			pypi = append(pypi, r)
+		case WorkbenchRepositoryTypeConda:
+			conda = append(conda, r)
+		}
+	}
+
+	files := map[string]string{}
+
+	if len(cran) > 0 {
+		var b strings.Builder
+		for _, r := range cran {
+			b.WriteString(r.Name + "=" + r.URL + "\n")
+		}
+		files["repos.conf"] = b.String()
+	}
+
+	if bioc != nil {
+		// R_PROFILE replaces the Rprofile.site of the R installation, so it is loaded first
+		var b strings.Builder
+		b.WriteString("local({\n")
+		b.WriteString("  profile <- file.path(R.home(\"etc\"), \"Rprofile.site\")\n")
+		b.WriteString("  if (file.exists(profile)) sys.source(profile, envir = baseenv())\n")
+		b.WriteString("})\n")
+		b.WriteString(fmt.Sprintf("options(BioC_mirror = %s)\n", strconv.Quote(bioc.URL)))
+		b.WriteString(fmt.Sprintf("options(BIOCONDUCTOR_CONFIG_FILE = %s)\n", strconv.Quote(strings.TrimSuffix(bioc.URL, "/")+"/config.yaml")))
+		files["Rprofile.site"] = b.String()
+	}
+
+	if len(pypi) > 0 {
+		// the first repository is the index, the others are searched as well
+		var b strings.Builder
+		b.WriteString("[global]\n")
+		b.WriteString("index-url = " + pypi[0].URL + "\n")
+		if len(pypi) > 1 {
+			b.WriteString("extra-index-url =\n")
+			for _, r := range pypi[1:] {
+				b.WriteString("    " + r.URL + "\n")
+			}
+		}
+		files["pip.conf"] = b.String()
+	}
+
+	if len(conda) > 0 {
+		var b strings.Builder
+		b.WriteString("channels:\n")
+		for _, r := range conda {
+			b.WriteString("  - " + r.URL + "\n")
+		}
+		files["condarc"] = b.String()
+	}
+
+	return files
+}
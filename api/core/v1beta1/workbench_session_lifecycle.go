@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+package v1beta1
+
+import (
+	"slices"
+	"time"
+)
+
+// WorkbenchSessionLifecycle limits how long Workbench sessions live
+// +kubebuilder:validation:XValidation:rule="!has(self.jupyter) || !has(self.jupyter.idleKillHours)",message="jupyter sessions do not support idleKillHours"
+// +kubebuilder:validation:XValidation:rule="!has(self.vsCode) || !has(self.vsCode.idleSuspendMinutes)",message="vsCode sessions do not support idleSuspendMinutes"
+// +kubebuilder:validation:XValidation:rule="!has(self.positron) || !has(self.positron.idleSuspendMinutes)",message="positron sessions do not support idleSuspendMinutes"
+type WorkbenchSessionLifecycle struct {
+	// RStudio limits RStudio Pro sessions (rsession.conf)
+	// +optional
+	RStudio *WorkbenchSessionIdleLimits `json:"rstudio,omitempty"`
+
+	// Jupyter limits Jupyter sessions (jupyter.conf)
+	// +optional
+	Jupyter *WorkbenchSessionIdleLimits `json:"jupyter,omitempty"`
+
+	// VSCode limits VS Code sessions (vscode.conf)
+	// +optional
+	VSCode *WorkbenchSessionIdleLimits `json:"vsCode,omitempty"`
+
+	// Positron limits Positron sessions (positron.conf)
+	// +optional
+	Positron *WorkbenchSessionIdleLimits `json:"positron,omitempty"`
+
+	// JobExpiryHours is how long the launcher keeps the Jobs of finished sessions. Defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	JobExpiryHours *int32 `json:"jobExpiryHours,omitempty"`
+
+	// Reaper deletes the session Jobs that are older than their maximum age, whether they are idle or not
+	// +optional
+	Reaper *WorkbenchSessionReaper `json:"reaper,omitempty"`
+}
+
+// WorkbenchSessionIdleLimits limits how long the sessions of an IDE can be idle. 0 disables a limit
+type WorkbenchSessionIdleLimits struct {
+	// IdleSuspendMinutes is how long a session can be idle before it is suspended (RStudio, session-timeout-minutes)
+	// or its kernels are culled (Jupyter, session-cull-minutes)
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	IdleSuspendMinutes *int32 `json:"idleSuspendMinutes,omitempty"`
+
+	// IdleKillHours is how long a session can be idle before it is stopped (RStudio, VS Code and Positron,
+	// session-timeout-kill-hours)
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	IdleKillHours *int32 `json:"idleKillHours,omitempty"`
+}
+
+// WorkbenchSessionReaper deletes session Jobs that are older than their maximum age and records an Event on the
+// Workbench for each
+type WorkbenchSessionReaper struct {
+	// MaxAgeHours is the maximum age of a session
+	// +kubebuilder:validation:Minimum=1
+	MaxAgeHours int32 `json:"maxAgeHours"`
+
+	// Profiles set the maximum age of the sessions of specific users, IDEs or resource profiles. The first profile
+	// that matches a session wins
+	// +optional
+	Profiles []WorkbenchSessionReaperProfile `json:"profiles,omitempty"`
+}
+
+// WorkbenchSessionIDE is an IDE that Workbench sessions run
+// +kubebuilder:validation:Enum=rstudio;jupyter;vsCode;positron
+type WorkbenchSessionIDE string
+
+const (
+	WorkbenchSessionIDERStudio  WorkbenchSessionIDE = "rstudio"
+	WorkbenchSessionIDEJupyter  WorkbenchSessionIDE = "jupyter"
+	WorkbenchSessionIDEVSCode   WorkbenchSessionIDE = "vsCode"
+	WorkbenchSessionIDEPositron WorkbenchSessionIDE = "positron"
+)
+
+// WorkbenchSessionReaperProfile is the maximum age of the sessions that match all of its users, IDEs and resource
+// profiles. A list that is not set matches every session
+// +kubebuilder:validation:XValidation:rule="has(self.users) || has(self.ides) || has(self.resourceProfiles)",message="a reaper profile needs users, ides or resourceProfiles"
+type WorkbenchSessionReaperProfile struct {
+	// Users that the profile applies to
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// IDEs that the profile applies to
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	IDEs []WorkbenchSessionIDE `json:"ides,omitempty"`
+
+	// ResourceProfiles that the profile applies to, by their key in launcher.kubernetes.resources.conf
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	ResourceProfiles []string `json:"resourceProfiles,omitempty"`
+
+	// MaxAgeHours is the maximum age of the sessions
+	// +kubebuilder:validation:Minimum=1
+	MaxAgeHours int32 `json:"maxAgeHours"`
+}
+
+// WorkbenchSession identifies the user, IDE and resource profile of a session. IDE and ResourceProfile are empty
+// when they are not known
+type WorkbenchSession struct {
+	User            string
+	IDE             WorkbenchSessionIDE
+	ResourceProfile string
+}
+
+// matches returns true if the profile applies to session
+func (p *WorkbenchSessionReaperProfile) matches(session WorkbenchSession) bool {
+	if len(p.Users) > 0 && !slices.Contains(p.Users, session.User) {
+		return false
+	}
+	if len(p.IDEs) > 0 && !slices.Contains(p.IDEs, session.IDE) {
+		return false
+	}
+	if len(p.ResourceProfiles) > 0 && !slices.Contains(p.ResourceProfiles, session.ResourceProfile) {
+		return false
+	}
+	return true
+}
+
+// MaxAge returns the maximum age of session
+func (r *WorkbenchSessionReaper) MaxAge(session WorkbenchSession) time.Duration {
+	hours := r.MaxAgeHours
+	for i := range r.Profiles {
+		if r.Profiles[i].matches(session) {
+			hours = r.Profiles[i].MaxAgeHours
+			break
+		}
+	}
+	return time.Duration(hours) * time.Hour
+}
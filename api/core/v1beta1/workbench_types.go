@@ -24,6 +24,10 @@ import (
 const MaxLoginPageHtmlSize = 64 * 1024
 
 // WorkbenchSpec defines the desired state of Workbench
+// +kubebuilder:validation:XValidation:rule="!has(self.securityProfile) || self.securityProfile == ” || (has(self.offHostExecution) && self.offHostExecution)",message="a securityProfile requires offHostExecution"
+// +kubebuilder:validation:XValidation:rule="!has(self.securityProfile) || self.securityProfile != 'restricted' || (has(self.nonRoot) && self.nonRoot)",message="the restricted securityProfile requires nonRoot"
+// +kubebuilder:validation:XValidation:rule="!has(self.userSelector) || !has(self.nonRoot) || !self.nonRoot",message="userSelector syncs the users as root, which conflicts with nonRoot"
+// +kubebuilder:validation:XValidation:rule="!has(self.sessionNamespace) || self.sessionNamespace == ” || (has(self.offHostExecution) && self.offHostExecution)",message="a sessionNamespace requires offHostExecution"
 type WorkbenchSpec struct {
 	License       product.LicenseSpec    `json:"license,omitempty"`
 	Config        WorkbenchConfig        `json:"config,omitempty"`
@@ -36,6 +40,20 @@ type WorkbenchSpec struct {
 	Url       string `json:"url,omitempty"`
 	ParentUrl string `json:"parentUrl,omitempty"`
 
+	// RootPath is the path the product is served under on Url (i.e. "/workbench"). Empty serves the product at the
+	// root of Url
+	// +optional
+	RootPath string `json:"rootPath,omitempty"`
+
+	// Aliases are additional hosts that the product is served on
+	// +optional
+	Aliases []string `json:"aliases,omitempty"`
+
+	// TLSSecretName is the TLS Secret for the Ingress. When set, the URL and aliases are listed in the Ingress
+	// TLS section
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
 	// NonRoot is a flag that enables rootless execution for workbench (or as much as is currently possible...)
 	NonRoot bool `json:"nonRoot,omitempty"`
 
@@ -47,13 +65,75 @@ type WorkbenchSpec struct {
 	// IngressAnnotations is a set of annotations to be applied to all ingress routes
 	IngressAnnotations map[string]string `json:"ingressAnnotations,omitempty"`
 
+	// Routing selects between Ingress and Gateway API HTTPRoute resources
+	// +optional
+	Routing *RoutingConfig `json:"routing,omitempty"`
+
+	// IngressPolicy adds rate limiting, an IP allow-list, a maximum body size and retries to the Ingress
+	// +optional
+	IngressPolicy *IngressPolicy `json:"ingressPolicy,omitempty"`
+
 	// ImagePullSecrets is a set of image pull secrets to use for all image pulls. These names / secrets
 	// must already exist in the namespace in question.
 	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
 
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 
-	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// PodSchedulingConfig sets the affinity, tolerations, topology spread constraints, priority class and extra
+	// metadata of the server pods
+	PodSchedulingConfig `json:",inline"`
+
+	// SecurityProfile hardens the pods to comply with a Pod Security Standard
+	// +optional
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+
+	// Overrides patch the objects that the operator generates for the product. Overrides that fail to apply are
+	// listed in the status
+	// +optional
+	Overrides []Override `json:"overrides,omitempty"`
+
+	// SessionImageSelector selects the SessionImages in the namespace that sessions can use, on top of the images
+	// in the config. Nil selects none
+	// +optional
+	SessionImageSelector *metav1.LabelSelector `json:"sessionImageSelector,omitempty"`
+
+	// SessionReaper deletes session Jobs that are older than their maximum age
+	// +optional
+	SessionReaper *WorkbenchSessionReaper `json:"sessionReaper,omitempty"`
+
+	// UserSelector selects the WorkbenchUsers and WorkbenchGroups in the namespace that are synced into the
+	// passwd and group files of the server, which creates them in sessions. Nil selects none
+	// +optional
+	UserSelector *metav1.LabelSelector `json:"userSelector,omitempty"`
+
+	// ExtensionCache installs the VS Code and Positron extensions of the config with a Job, into a directory that
+	// sessions mount read-only
+	// +optional
+	ExtensionCache *WorkbenchExtensionCache `json:"extensionCache,omitempty"`
+
+	// LauncherTemplates chooses the launcher templates of sessions. Defaults to the latest bundled version
+	// +optional
+	LauncherTemplates *WorkbenchLauncherTemplates `json:"launcherTemplates,omitempty"`
+
+	// SessionQuota caps the resources of the sessions with a ResourceQuota and a LimitRange
+	// +optional
+	SessionQuota *SessionQuota `json:"sessionQuota,omitempty"`
+
+	// Repositories are the package repositories of the sessions, rendered into repos.conf, Rprofile.site, pip.conf
+	// and condarc. Nil leaves the repos.conf of the config
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:XValidation:rule="self.filter(r, r.type == 'bioc').size() <= 1",message="only one bioc repository can be set"
+	// +optional
+	Repositories []WorkbenchRepository `json:"repositories,omitempty"`
+
+	// SessionNamespace is the namespace that sessions run in, which must exist. The session ServiceAccount and the
+	// launcher Role are created there, along with copies of the ConfigMaps, Secrets and volumes that sessions
+	// mount. Defaults to the namespace of the Workbench
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	SessionNamespace string `json:"sessionNamespace,omitempty"`
 
 	// AddEnv adds arbitrary environment variables to the container env
 	AddEnv map[string]string `json:"addEnv,omitempty"`
@@ -96,6 +176,19 @@ type WorkbenchSpec struct {
 
 	Replicas int `json:"replicas,omitempty"`
 
+	// Autoscaling creates a HorizontalPodAutoscaler for the Deployment, which then owns the replica count
+	// +optional
+	Autoscaling *AutoscalingConfig `json:"autoscaling,omitempty"`
+
+	// Resources are the resource requests and limits of the Workbench container. Defaults to DefaultWorkbenchResources
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ChronicleAgentResources are the resource requests and limits of the Chronicle agent sidecar. Defaults to
+	// DefaultChronicleAgentResources
+	// +optional
+	ChronicleAgentResources *corev1.ResourceRequirements `json:"chronicleAgentResources,omitempty"`
+
 	// DsnSecret is the name of the secret that contains the DSN to include with all Workbench sessions
 	DsnSecret string `json:"dsnSecret,omitempty"`
 
@@ -117,6 +210,24 @@ type WorkbenchSpec struct {
 type WorkbenchStatus struct {
 	Ready        bool                   `json:"ready"`
 	KeySecretRef corev1.SecretReference `json:"keySecretRef,omitempty"`
+
+	// FailedOverrides lists the overrides that failed to apply, i.e. "Deployment/connect: <error>"
+	// +optional
+	FailedOverrides []string `json:"failedOverrides,omitempty"`
+
+	// ExtensionCache is the state of the Job that installs the extensions
+	// +optional
+	ExtensionCache *WorkbenchExtensionCacheStatus `json:"extensionCache,omitempty"`
+
+	// SessionQuota is the usage of the sessions against their quota
+	// +optional
+	SessionQuota *SessionQuotaStatus `json:"sessionQuota,omitempty"`
+
+	// Conditions of the Workbench, i.e. LauncherTemplatesValid and SessionNamespaceReady
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -170,6 +281,11 @@ func (w *Workbench) SupervisorConfigmapName() string {
 	return fmt.Sprintf("%s-supervisor", w.ComponentName())
 }
 
+// UsersConfigmapName is the ConfigMap with the passwd and group files of the projected WorkbenchUsers
+func (w *Workbench) UsersConfigmapName() string {
+	return fmt.Sprintf("%s-users", w.ComponentName())
+}
+
 func (w *Workbench) LoginConfigmapName() string {
 	return fmt.Sprintf("%s-login", w.ComponentName())
 }
@@ -192,6 +308,15 @@ func (w *Workbench) GetChronicleAgentImage() string {
 	return w.Spec.ChronicleAgentImage
 }
 
+func (w *Workbench) GetChronicleAgentResources() corev1.ResourceRequirements {
+	return resourcesOrDefault(w.Spec.ChronicleAgentResources, DefaultChronicleAgentResources)
+}
+
+// GetResources returns the resources of the Workbench container, defaulting to DefaultWorkbenchResources
+func (w *Workbench) GetResources() corev1.ResourceRequirements {
+	return resourcesOrDefault(w.Spec.Resources, DefaultWorkbenchResources)
+}
+
 func (w *Workbench) ServiceUrl(proto string, ns string) string {
 	return fmt.Sprintf("%s%s.%s.svc.cluster.local:80", proto, w.ComponentName(), ns)
 }
@@ -204,6 +329,18 @@ func (w *Workbench) SessionConfigMapName() string {
 	return fmt.Sprintf("%s-session", w.ComponentName())
 }
 
+// WorkbenchConditionSessionNamespaceReady reports whether everything that sessions mount was copied to the
+// session namespace
+const WorkbenchConditionSessionNamespaceReady = "SessionNamespaceReady"
+
+// SessionNamespace is the namespace that sessions run in
+func (w *Workbench) SessionNamespace() string {
+	if w.Spec.SessionNamespace != "" {
+		return w.Spec.SessionNamespace
+	}
+	return w.Namespace
+}
+
 func (w *Workbench) SessionServiceAccountName() string {
 	return fmt.Sprintf("%s-session", w.ComponentName())
 }
@@ -306,6 +443,23 @@ func (w *Workbench) CreateSessionVolumeFactory(cfg *WorkbenchConfig) *product.Vo
 	vols["etc-cover-volume"] = w.etcCoverVolumeDef()
 	vols["login-volume"] = w.loginVolumeDef()
 
+	// sessions do not mount the projected users: the server creates the session user with its UID and GID
+
+	// sessions load the cached extensions, but cannot change them
+	if c := w.Spec.ExtensionCache; c != nil {
+		vols["extension-cache-volume"] = &product.VolumeDef{
+			Source: &corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: c.ClaimName,
+					ReadOnly:  true,
+				},
+			},
+			Mounts: []*product.VolumeMountDef{
+				{MountPath: WorkbenchExtensionCacheMountPath, SubPath: c.SubPath, ReadOnly: true},
+			},
+		}
+	}
+
 	sessionMounts := []*product.VolumeMountDef{
 		{MountPath: "/mnt/session/rstudio/", ReadOnly: true},
 	}
@@ -326,6 +480,28 @@ func (w *Workbench) CreateSessionVolumeFactory(cfg *WorkbenchConfig) *product.Vo
 		})
 	}
 
+	// the repositories are rendered into the session configmap; pip and conda read them from /etc, and R from
+	// the R_PROFILE
+	var sessionEnv []corev1.EnvVar
+	repoFiles := GenerateRepositoryConfig(w.Spec.Repositories)
+	if _, ok := repoFiles["pip.conf"]; ok {
+		sessionMounts = append(sessionMounts, &product.VolumeMountDef{
+			MountPath: WorkbenchPipConfPath,
+			SubPath:   "pip.conf",
+			ReadOnly:  true,
+		})
+	}
+	if _, ok := repoFiles["condarc"]; ok {
+		sessionMounts = append(sessionMounts, &product.VolumeMountDef{
+			MountPath: WorkbenchCondarcPath,
+			SubPath:   "condarc",
+			ReadOnly:  true,
+		})
+	}
+	if _, ok := repoFiles["Rprofile.site"]; ok {
+		sessionEnv = append(sessionEnv, corev1.EnvVar{Name: "R_PROFILE", Value: WorkbenchRProfileSitePath})
+	}
+
 	vols["session-config-volume"] = &product.VolumeDef{
 		Source: &corev1.VolumeSource{
 			ConfigMap: &corev1.ConfigMapVolumeSource{
@@ -338,6 +514,7 @@ func (w *Workbench) CreateSessionVolumeFactory(cfg *WorkbenchConfig) *product.Vo
 			},
 		},
 		Mounts: sessionMounts,
+		Env:    sessionEnv,
 	}
 
 	if w.Spec.DsnSecret != "" {
@@ -521,6 +698,51 @@ func (w *Workbench) loginVolumeDef() *product.VolumeDef {
 	}
 }
 
+// WorkbenchUsersMountPath is where the server mounts the passwd and group files of the projected WorkbenchUsers
+const WorkbenchUsersMountPath = "/etc/workbench-users"
+
+// usersVolumeDef mounts the projected WorkbenchUsers
+func (w *Workbench) usersVolumeDef() *product.VolumeDef {
+	return &product.VolumeDef{
+		Source: &corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: w.UsersConfigmapName(),
+				},
+				DefaultMode: ptr.To(product.MustParseOctal("0644")),
+			},
+		},
+		Mounts: []*product.VolumeMountDef{
+			{MountPath: WorkbenchUsersMountPath, ReadOnly: true},
+		},
+	}
+}
+
+// usersSupervisorVolumeDef adds the supervisord program that syncs the projected WorkbenchUsers into the server's
+// /etc/passwd and /etc/group
+func (w *Workbench) usersSupervisorVolumeDef() *product.VolumeDef {
+	return &product.VolumeDef{
+		Source: &corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: w.UsersConfigmapName(),
+				},
+				Items: []corev1.KeyToPath{
+					{Key: "workbench-users.conf", Path: "workbench-users.conf"},
+				},
+				DefaultMode: ptr.To(product.MustParseOctal("0644")),
+			},
+		},
+		Mounts: []*product.VolumeMountDef{
+			{
+				MountPath: "/startup/user-provisioning/workbench-users.conf",
+				SubPath:   "workbench-users.conf",
+				ReadOnly:  true,
+			},
+		},
+	}
+}
+
 // AuthLoginPageHtmlVolumeDef returns a VolumeDef for mounting the custom login page HTML
 // at /etc/rstudio/login.html in the Workbench pod
 func (w *Workbench) AuthLoginPageHtmlVolumeDef() *product.VolumeDef {
@@ -659,6 +881,13 @@ func (w *Workbench) CreateVolumeFactory(cfg *WorkbenchConfig) *product.VolumeFac
 		vols["server-shared-storage-volume"] = w.serverSharedStorageVolumeDef()
 	}
 
+	// projected users volumes (conditional). Syncing the users needs root, so a userSelector fails validation with
+	// nonRoot
+	if w.Spec.UserSelector != nil {
+		vols["users-volume"] = w.usersVolumeDef()
+		vols["users-supervisor-volume"] = w.usersSupervisorVolumeDef()
+	}
+
 	// Auth login page HTML volume (conditional)
 	if w.Spec.AuthLoginPageHtml != "" {
 		vols["auth-login-page-html-volume"] = w.AuthLoginPageHtmlVolumeDef()
@@ -972,7 +1201,40 @@ func (w *Workbench) SessionConfigTemplateData(l logr.Logger, cfg *WorkbenchConfi
 		sess = defaultSessionConfig
 	}
 
-	if str, err := sess.GenerateSessionConfigTemplate(); err != nil {
+	// the session quota only counts the pods of its PriorityClass, which is the default for them
+	if q := w.Spec.SessionQuota; q != nil {
+		if sess.Pod.PriorityClassName == "" {
+			sess.Pod.PriorityClassName = q.PriorityClassName
+		} else if sess.Pod.PriorityClassName != q.PriorityClassName {
+			l.Info("the session quota does not count the session pods, because the session config sets another priorityClassName", "priorityClassName", sess.Pod.PriorityClassName)
+		}
+	}
+
+	w.Spec.SecurityProfile.HardenSession(sess.Pod)
+
+	resourceProfiles, ambiguous := cfg.ResourceProfilePods()
+	if len(ambiguous) > 0 {
+		l.Info("ignoring the pods of resource profiles whose sessions cannot be told from those of another profile by their resource limits", "resourceProfiles", ambiguous)
+	}
+	resourceProfileData := map[string]resourceProfileTemplateData{}
+	for key, pod := range resourceProfiles {
+		if w.Spec.SessionQuota != nil && pod.PriorityClassName != "" {
+			l.Info("ignoring the priorityClassName of a resource profile, because the session quota sets one", "resourceProfile", key)
+			pod = pod.DeepCopy()
+			pod.PriorityClassName = ""
+		}
+		resourceProfileData[key] = resourceProfileTemplateData{
+			WorkbenchResourceProfilePodConfig: pod,
+			ResourceLimits:                    cfg.Resources[key].ResourceLimits(),
+		}
+	}
+
+	var templateResourceProfiles any
+	if len(resourceProfileData) > 0 {
+		templateResourceProfiles = resourceProfileData
+	}
+
+	if str, err := sess.GenerateSessionConfigTemplateWithResourceProfiles(templateResourceProfiles); err != nil {
 		l.Error(err, "Error generating session config template")
 		return ""
 	} else {
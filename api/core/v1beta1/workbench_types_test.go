@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/posit-dev/team-operator/api/product"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -107,6 +108,97 @@ func TestWorkbench_CreateVolumeFactory_NonRoot(t *testing.T) {
 	assert.False(t, foundCoverVm2)
 }
 
+func TestWorkbench_CreateVolumeFactory_Users(t *testing.T) {
+	w := &Workbench{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "users",
+			Namespace: "ns",
+		},
+	}
+	cfg := &WorkbenchConfig{}
+
+	// without a user selector, nothing is projected
+	for _, vf := range []*product.VolumeFactory{w.CreateVolumeFactory(cfg), w.CreateSessionVolumeFactory(cfg)} {
+		assert.False(t, anyTrue(vf.Volumes(), func(v corev1.Volume) bool { return v.Name == "users-volume" }))
+	}
+
+	// the server mounts the projected users, and creates the session users from its own
+	w.Spec.UserSelector = &v1.LabelSelector{}
+	isUsersMount := func(vm corev1.VolumeMount) bool {
+		return vm.Name == "users-volume" && vm.MountPath == WorkbenchUsersMountPath
+	}
+	assert.True(t, anyTrue(w.CreateVolumeFactory(cfg).VolumeMounts(), isUsersMount))
+	assert.False(t, anyTrue(w.CreateSessionVolumeFactory(cfg).Volumes(), func(v corev1.Volume) bool { return v.Name == "users-volume" }))
+
+	// the server syncs them into its passwd and group files, which needs root
+	isSupervisorMount := func(vm corev1.VolumeMount) bool {
+		return vm.Name == "users-supervisor-volume" && vm.MountPath == "/startup/user-provisioning/workbench-users.conf"
+	}
+	assert.True(t, anyTrue(w.CreateVolumeFactory(cfg).VolumeMounts(), isSupervisorMount))
+	assert.False(t, anyTrue(w.CreateSessionVolumeFactory(cfg).VolumeMounts(), isSupervisorMount))
+}
+
+func TestWorkbench_CreateSessionVolumeFactory_ExtensionCache(t *testing.T) {
+	w := &Workbench{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "extensions",
+			Namespace: "ns",
+		},
+		Spec: WorkbenchSpec{
+			ExtensionCache: &WorkbenchExtensionCache{ClaimName: "main-shared", SubPath: "workbench-extensions"},
+		},
+	}
+	cfg := &WorkbenchConfig{}
+
+	// sessions mount the cache read-only, and the server does not mount it
+	vf := w.CreateSessionVolumeFactory(cfg)
+	assert.True(t, anyTrue(vf.VolumeMounts(), func(vm corev1.VolumeMount) bool {
+		return vm.Name == "extension-cache-volume" && vm.MountPath == WorkbenchExtensionCacheMountPath &&
+			vm.SubPath == "workbench-extensions" && vm.ReadOnly
+	}))
+	assert.True(t, anyTrue(vf.Volumes(), func(v corev1.Volume) bool {
+		return v.Name == "extension-cache-volume" && v.PersistentVolumeClaim.ClaimName == "main-shared" && v.PersistentVolumeClaim.ReadOnly
+	}))
+	for _, v := range w.CreateVolumeFactory(cfg).Volumes() {
+		assert.NotEqual(t, "extension-cache-volume", v.Name)
+	}
+}
+
+func TestWorkbench_CreateSessionVolumeFactory_Repositories(t *testing.T) {
+	w := &Workbench{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "repositories",
+			Namespace: "ns",
+		},
+		Spec: WorkbenchSpec{
+			Repositories: []WorkbenchRepository{
+				{Name: "CRAN", Type: WorkbenchRepositoryTypeCRAN, URL: "https://ppm.example.com/cran/latest"},
+				{Name: "Bioconductor", Type: WorkbenchRepositoryTypeBioc, URL: "https://ppm.example.com/bioconductor"},
+				{Name: "PyPI", Type: WorkbenchRepositoryTypePyPI, URL: "https://ppm.example.com/pypi/latest/simple"},
+			},
+		},
+	}
+	cfg := &WorkbenchConfig{}
+
+	// pip reads the session config from /etc, and R loads the Rprofile.site from the session config
+	vf := w.CreateSessionVolumeFactory(cfg)
+	assert.True(t, anyTrue(vf.VolumeMounts(), func(vm corev1.VolumeMount) bool {
+		return vm.Name == "session-config-volume" && vm.MountPath == WorkbenchPipConfPath && vm.SubPath == "pip.conf" && vm.ReadOnly
+	}))
+	assert.False(t, anyTrue(vf.VolumeMounts(), func(vm corev1.VolumeMount) bool {
+		return vm.MountPath == WorkbenchCondarcPath
+	}))
+	assert.Contains(t, vf.EnvVars(), corev1.EnvVar{Name: "R_PROFILE", Value: WorkbenchRProfileSitePath})
+
+	// without repositories nothing is mounted
+	w.Spec.Repositories = nil
+	vf = w.CreateSessionVolumeFactory(cfg)
+	assert.False(t, anyTrue(vf.VolumeMounts(), func(vm corev1.VolumeMount) bool {
+		return vm.MountPath == WorkbenchPipConfPath
+	}))
+	assert.Empty(t, vf.EnvVars())
+}
+
 func TestWorkbench_CreateSecretVolumeFactory_Kubernetes(t *testing.T) {
 
 	w := &Workbench{
@@ -239,3 +331,41 @@ func TestWorkbench_InitializeNonRootSupervisorConfig(t *testing.T) {
 	assert.Contains(t, cfgProg.SupervisordIniConfig.Programs["something.conf"], "some-launcher-program")
 	assert.NotContains(t, cfgProg.SupervisordIniConfig.Programs["something.conf"], "rstudio-launcher")
 }
+
+func TestWorkbench_SessionConfigTemplateData_ResourceProfiles(t *testing.T) {
+	w := &Workbench{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "profiles",
+			Namespace: "ns",
+		},
+	}
+	cfg := &WorkbenchConfig{
+		WorkbenchIniConfig: WorkbenchIniConfig{
+			Resources: map[string]*WorkbenchLauncherKubnernetesResourcesConfigSection{
+				"default": {Name: "Small", Cpus: "1", MemMb: "2000"},
+				"gpu": {
+					Name: "GPU",
+					Cpus: "8",
+					Pod: &WorkbenchResourceProfilePodConfig{
+						PriorityClassName: "gpu-sessions",
+						RuntimeClassName:  "nvidia",
+					},
+				},
+			},
+		},
+	}
+
+	// job.tpl finds the pods of the resource profiles in the template data, by the resource limits of the sessions
+	data := w.SessionConfigTemplateData(logr.Discard(), cfg)
+	assert.Contains(t, data, `"resourceProfiles":{"gpu":{"priorityClassName":"gpu-sessions","resourceLimits":{"AMD GPUs":"0","NVIDIA GPUs":"0","cpuCount":"8"},"runtimeClassName":"nvidia"}}`)
+
+	// the session quota only counts the pods of its PriorityClass
+	w.Spec.SessionQuota = &SessionQuota{PriorityClassName: "sessions"}
+	data = w.SessionConfigTemplateData(logr.Discard(), cfg)
+	assert.Contains(t, data, `"resourceProfiles":{"gpu":{"resourceLimits":{"AMD GPUs":"0","NVIDIA GPUs":"0","cpuCount":"8"},"runtimeClassName":"nvidia"}}`)
+	assert.Equal(t, "gpu-sessions", cfg.Resources["gpu"].Pod.PriorityClassName)
+
+	// without pods there is nothing to find
+	cfg.Resources["gpu"].Pod = nil
+	assert.NotContains(t, w.SessionConfigTemplateData(logr.Discard(), cfg), "resourceProfiles")
+}
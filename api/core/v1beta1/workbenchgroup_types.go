@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+//+k8s:openapi-gen=true
+
+package v1beta1
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkbenchGroupSpec defines the desired state of WorkbenchGroup
+type WorkbenchGroupSpec struct {
+	// GroupName is the POSIX group name. WorkbenchUsers list it in their groups
+	// +kubebuilder:validation:Pattern=`^[a-z_][a-z0-9_.-]{0,31}$`
+	GroupName string `json:"groupName"`
+
+	// GID is the fixed POSIX group id. Ids below 2000 are left to the groups of the images
+	// +kubebuilder:validation:Minimum=2000
+	GID int64 `json:"gid"`
+}
+
+// WorkbenchGroupStatus defines the observed state of WorkbenchGroup
+type WorkbenchGroupStatus struct {
+	// Sites whose Workbench projects the group
+	// +optional
+	Sites []string `json:"sites,omitempty"`
+
+	// Conflicts are the reasons why the group is not projected, i.e. "gid 3001 is also used by WorkbenchGroup data"
+	// +optional
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName={wbg,wbgs},path=workbenchgroups,singular=workbenchgroup
+//+kubebuilder:printcolumn:name="Group",type=string,JSONPath=`.spec.groupName`
+//+kubebuilder:printcolumn:name="GID",type=integer,JSONPath=`.spec.gid`
+//+kubebuilder:printcolumn:name="Sites",type=string,JSONPath=`.status.sites`
+//+kubebuilder:printcolumn:name="Conflicts",type=string,JSONPath=`.status.conflicts`
+//+genclient
+//+k8s:openapi-gen=true
+
+// WorkbenchGroup is a POSIX group with a fixed GID. Workbenches project the WorkbenchGroups that their userSelector
+// matches, with the projected WorkbenchUsers that are members of them
+type WorkbenchGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkbenchGroupSpec   `json:"spec,omitempty"`
+	Status WorkbenchGroupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// WorkbenchGroupList contains a list of WorkbenchGroup
+type WorkbenchGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkbenchGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkbenchGroup{}, &WorkbenchGroupList{})
+}
+
+// GroupEntry returns the line of the group in a group(5) file
+func (g *WorkbenchGroup) GroupEntry(members []string) string {
+	return fmt.Sprintf("%s:x:%d:%s", g.Spec.GroupName, g.Spec.GID, strings.Join(members, ","))
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+//+k8s:openapi-gen=true
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkbenchUserSpec defines the desired state of WorkbenchUser
+type WorkbenchUserSpec struct {
+	// Username is the POSIX user name
+	// +kubebuilder:validation:Pattern=`^[a-z_][a-z0-9_.-]{0,31}$`
+	Username string `json:"username"`
+
+	// UID is the fixed POSIX user id. Ids below 2000 are left to the users of the images, i.e. ubuntu (1000)
+	// +kubebuilder:validation:Minimum=2000
+	UID int64 `json:"uid"`
+
+	// GID is the id of the primary group. Defaults to the UID. When no WorkbenchGroup has the id, a group named
+	// after the user is added
+	// +kubebuilder:validation:Minimum=2000
+	// +optional
+	GID *int64 `json:"gid,omitempty"`
+
+	// Groups are the names of the WorkbenchGroups that the user is a member of. Unknown groups are ignored
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// FullName is the GECOS field of the user
+	// +kubebuilder:validation:Pattern=`^[^:\n]*$`
+	// +optional
+	FullName string `json:"fullName,omitempty"`
+
+	// Shell is the login shell. Defaults to /bin/bash
+	// +kubebuilder:validation:Pattern=`^/[^:\n]*$`
+	// +optional
+	Shell string `json:"shell,omitempty"`
+
+	// HomeSeed is a ConfigMap whose files are copied into the home directory of the user when it does not exist
+	// yet. Seeding needs the home directory volume of the Workbench
+	// +optional
+	HomeSeed *WorkbenchUserHomeSeed `json:"homeSeed,omitempty"`
+}
+
+// WorkbenchUserHomeSeed is the initial content of a home directory
+type WorkbenchUserHomeSeed struct {
+	// ConfigMapName is the name of a ConfigMap in the namespace of the WorkbenchUser. Each key is a file
+	ConfigMapName string `json:"configMapName"`
+}
+
+// WorkbenchUserStatus defines the observed state of WorkbenchUser
+type WorkbenchUserStatus struct {
+	// Sites whose Workbench projects the user
+	// +optional
+	Sites []string `json:"sites,omitempty"`
+
+	// Conflicts are the reasons why the user is not projected, i.e. "uid 2001 is also used by WorkbenchUser alice"
+	// +optional
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName={wbu,wbus},path=workbenchusers,singular=workbenchuser
+//+kubebuilder:printcolumn:name="Username",type=string,JSONPath=`.spec.username`
+//+kubebuilder:printcolumn:name="UID",type=integer,JSONPath=`.spec.uid`
+//+kubebuilder:printcolumn:name="Sites",type=string,JSONPath=`.status.sites`
+//+kubebuilder:printcolumn:name="Conflicts",type=string,JSONPath=`.status.conflicts`
+//+genclient
+//+k8s:openapi-gen=true
+
+// WorkbenchUser is a POSIX user with a fixed UID. Workbenches project the WorkbenchUsers that their userSelector
+// matches into the passwd and group files of their server, which creates them in sessions
+type WorkbenchUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkbenchUserSpec   `json:"spec,omitempty"`
+	Status WorkbenchUserStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// WorkbenchUserList contains a list of WorkbenchUser
+type WorkbenchUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkbenchUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkbenchUser{}, &WorkbenchUserList{})
+}
+
+// PrimaryGID returns the id of the primary group of the user
+func (u *WorkbenchUser) PrimaryGID() int64 {
+	if u.Spec.GID != nil {
+		return *u.Spec.GID
+	}
+	return u.Spec.UID
+}
+
+// HomeDirectory returns the home directory of the user
+func (u *WorkbenchUser) HomeDirectory() string {
+	return "/home/" + u.Spec.Username
+}
+
+// PasswdEntry returns the line of the user in a passwd(5) file
+func (u *WorkbenchUser) PasswdEntry() string {
+	shell := u.Spec.Shell
+	if shell == "" {
+		shell = "/bin/bash"
+	}
+	return fmt.Sprintf("%s:x:%d:%d:%s:%s:%s", u.Spec.Username, u.Spec.UID, u.PrimaryGID(), u.Spec.FullName, u.HomeDirectory(), shell)
+}
+
+// ValidateUserSelector returns an error when the Workbench selects users that it cannot sync. The server syncs them
+// into its passwd and group files as root, so a non-root server cannot
+func (w *WorkbenchSpec) ValidateUserSelector() error {
+	if w.UserSelector != nil && w.NonRoot {
+		return errors.New("userSelector syncs the users as root, which conflicts with nonRoot")
+	}
+	return nil
+}
+
+// ValidateWorkbenchUsers returns an error when the Site selects Workbench users and runs Workbench as non-root, as the
+// restricted security profile and the nonRoot experimental feature do
+func (s *SiteSpec) ValidateWorkbenchUsers() error {
+	if s.Workbench.UserSelector == nil {
+		return nil
+	}
+	if s.SecurityProfile == SecurityProfileRestricted {
+		return fmt.Errorf("workbench userSelector syncs the users as root, which conflicts with the %q security profile", s.SecurityProfile)
+	}
+	if f := s.Workbench.ExperimentalFeatures; f != nil && f.NonRoot {
+		return errors.New("workbench userSelector syncs the users as root, which conflicts with the nonRoot experimental feature")
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/rstudio/goex/ptr"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWorkbenchUserPasswdEntry(t *testing.T) {
+	u := WorkbenchUser{Spec: WorkbenchUserSpec{Username: "alice", UID: 2001, FullName: "Alice Smith"}}
+	require.Equal(t, int64(2001), u.PrimaryGID())
+	require.Equal(t, "alice:x:2001:2001:Alice Smith:/home/alice:/bin/bash", u.PasswdEntry())
+
+	u.Spec.GID = ptr.To(int64(3001))
+	u.Spec.Shell = "/bin/zsh"
+	require.Equal(t, "alice:x:2001:3001:Alice Smith:/home/alice:/bin/zsh", u.PasswdEntry())
+}
+
+func TestWorkbenchGroupEntry(t *testing.T) {
+	g := WorkbenchGroup{Spec: WorkbenchGroupSpec{GroupName: "data-science", GID: 3001}}
+	require.Equal(t, "data-science:x:3001:", g.GroupEntry(nil))
+	require.Equal(t, "data-science:x:3001:alice,bob", g.GroupEntry([]string{"alice", "bob"}))
+}
+
+func TestValidateUserSelector(t *testing.T) {
+	r := require.New(t)
+
+	w := WorkbenchSpec{NonRoot: true}
+	r.NoError(w.ValidateUserSelector())
+	w.UserSelector = &metav1.LabelSelector{}
+	r.ErrorContains(w.ValidateUserSelector(), "nonRoot")
+	w.NonRoot = false
+	r.NoError(w.ValidateUserSelector())
+
+	site := SiteSpec{SecurityProfile: SecurityProfileRestricted}
+	r.NoError(site.ValidateWorkbenchUsers())
+	site.Workbench.UserSelector = &metav1.LabelSelector{}
+	r.ErrorContains(site.ValidateWorkbenchUsers(), "restricted")
+	site.SecurityProfile = SecurityProfileBaseline
+	r.NoError(site.ValidateWorkbenchUsers())
+	site.Workbench.ExperimentalFeatures = &InternalWorkbenchExperimentalFeatures{NonRoot: true}
+	r.ErrorContains(site.ValidateWorkbenchUsers(), "nonRoot")
+}
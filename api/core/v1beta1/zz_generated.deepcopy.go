@@ -9,8 +9,11 @@ package v1beta1
 
 import (
 	"github.com/posit-dev/team-operator/api/product"
+	"k8s.io/api/autoscaling/v2"
 	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -64,6 +67,48 @@ func (in *AuthSpec) DeepCopy() *AuthSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingConfig) DeepCopyInto(out *AutoscalingConfig) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilizationPercentage != nil {
+		in, out := &in.TargetMemoryUtilizationPercentage, &out.TargetMemoryUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Behavior != nil {
+		in, out := &in.Behavior, &out.Behavior
+		*out = new(v2.HorizontalPodAutoscalerBehavior)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingConfig.
+func (in *AutoscalingConfig) DeepCopy() *AutoscalingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureFilesConfig) DeepCopyInto(out *AzureFilesConfig) {
 	*out = *in
@@ -85,7 +130,7 @@ func (in *Chronicle) DeepCopyInto(out *Chronicle) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Chronicle.
@@ -289,6 +334,12 @@ func (in *ChronicleSpec) DeepCopyInto(out *ChronicleSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.PodSchedulingConfig.DeepCopyInto(&out.PodSchedulingConfig)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]Override, len(*in))
+		copy(*out, *in)
+	}
 	if in.AddEnv != nil {
 		in, out := &in.AddEnv, &out.AddEnv
 		*out = make(map[string]string, len(*in))
@@ -296,6 +347,16 @@ func (in *ChronicleSpec) DeepCopyInto(out *ChronicleSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AgentResources != nil {
+		in, out := &in.AgentResources, &out.AgentResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChronicleSpec.
@@ -311,6 +372,11 @@ func (in *ChronicleSpec) DeepCopy() *ChronicleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChronicleStatus) DeepCopyInto(out *ChronicleStatus) {
 	*out = *in
+	if in.FailedOverrides != nil {
+		in, out := &in.FailedOverrides, &out.FailedOverrides
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChronicleStatus.
@@ -329,7 +395,7 @@ func (in *Connect) DeepCopyInto(out *Connect) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Connect.
@@ -532,6 +598,36 @@ func (in *ConnectDatabaseConfig) DeepCopy() *ConnectDatabaseConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectExecutionEnvironment) DeepCopyInto(out *ConnectExecutionEnvironment) {
+	*out = *in
+	if in.R != nil {
+		in, out := &in.R, &out.R
+		*out = make([]ConnectRuntimeInstallation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Python != nil {
+		in, out := &in.Python, &out.Python
+		*out = make([]ConnectRuntimeInstallation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Quarto != nil {
+		in, out := &in.Quarto, &out.Quarto
+		*out = make([]ConnectRuntimeInstallation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectExecutionEnvironment.
+func (in *ConnectExecutionEnvironment) DeepCopy() *ConnectExecutionEnvironment {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectExecutionEnvironment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectHttpConfig) DeepCopyInto(out *ConnectHttpConfig) {
 	*out = *in
@@ -714,6 +810,21 @@ func (in *ConnectRConfig) DeepCopy() *ConnectRConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectRuntimeInstallation) DeepCopyInto(out *ConnectRuntimeInstallation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectRuntimeInstallation.
+func (in *ConnectRuntimeInstallation) DeepCopy() *ConnectRuntimeInstallation {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectRuntimeInstallation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectSamlConfig) DeepCopyInto(out *ConnectSamlConfig) {
 	*out = *in
@@ -775,6 +886,11 @@ func (in *ConnectSpec) DeepCopyInto(out *ConnectSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Auth.DeepCopyInto(&out.Auth)
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.DatabaseConfig = in.DatabaseConfig
 	if in.IngressAnnotations != nil {
 		in, out := &in.IngressAnnotations, &out.IngressAnnotations
@@ -783,6 +899,16 @@ func (in *ConnectSpec) DeepCopyInto(out *ConnectSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Routing != nil {
+		in, out := &in.Routing, &out.Routing
+		*out = new(RoutingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IngressPolicy != nil {
+		in, out := &in.IngressPolicy, &out.IngressPolicy
+		*out = new(IngressPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]string, len(*in))
@@ -795,6 +921,12 @@ func (in *ConnectSpec) DeepCopyInto(out *ConnectSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.PodSchedulingConfig.DeepCopyInto(&out.PodSchedulingConfig)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]Override, len(*in))
+		copy(*out, *in)
+	}
 	if in.AddEnv != nil {
 		in, out := &in.AddEnv, &out.AddEnv
 		*out = make(map[string]string, len(*in))
@@ -812,6 +944,33 @@ func (in *ConnectSpec) DeepCopyInto(out *ConnectSpec) {
 	out.Secret = in.Secret
 	out.WorkloadSecret = in.WorkloadSecret
 	out.MainDatabaseCredentialSecret = in.MainDatabaseCredentialSecret
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SessionQuota != nil {
+		in, out := &in.SessionQuota, &out.SessionQuota
+		*out = new(SessionQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExecutionEnvironments != nil {
+		in, out := &in.ExecutionEnvironments, &out.ExecutionEnvironments
+		*out = make([]ConnectExecutionEnvironment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ChronicleAgentResources != nil {
+		in, out := &in.ChronicleAgentResources, &out.ChronicleAgentResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectSpec.
@@ -828,6 +987,28 @@ func (in *ConnectSpec) DeepCopy() *ConnectSpec {
 func (in *ConnectStatus) DeepCopyInto(out *ConnectStatus) {
 	*out = *in
 	out.KeySecretRef = in.KeySecretRef
+	if in.FailedOverrides != nil {
+		in, out := &in.FailedOverrides, &out.FailedOverrides
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SessionQuota != nil {
+		in, out := &in.SessionQuota, &out.SessionQuota
+		*out = new(SessionQuotaStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExecutionEnvironmentWarnings != nil {
+		in, out := &in.ExecutionEnvironmentWarnings, &out.ExecutionEnvironmentWarnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DiscoveredExecutionEnvironments != nil {
+		in, out := &in.DiscoveredExecutionEnvironments, &out.DiscoveredExecutionEnvironments
+		*out = make([]ConnectExecutionEnvironment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectStatus.
@@ -885,6 +1066,26 @@ func (in *DatabricksConfig) DeepCopy() *DatabricksConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSConfig) DeepCopyInto(out *ExternalDNSConfig) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSConfig.
+func (in *ExternalDNSConfig) DeepCopy() *ExternalDNSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureEnablerConfig) DeepCopyInto(out *FeatureEnablerConfig) {
 	*out = *in
@@ -906,7 +1107,7 @@ func (in *Flightdeck) DeepCopyInto(out *Flightdeck) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Flightdeck.
@@ -962,7 +1163,35 @@ func (in *FlightdeckList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlightdeckSpec) DeepCopyInto(out *FlightdeckSpec) {
 	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.PodSchedulingConfig.DeepCopyInto(&out.PodSchedulingConfig)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]Override, len(*in))
+		copy(*out, *in)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 	out.FeatureEnabler = in.FeatureEnabler
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.IngressAnnotations != nil {
 		in, out := &in.IngressAnnotations, &out.IngressAnnotations
 		*out = make(map[string]string, len(*in))
@@ -970,6 +1199,11 @@ func (in *FlightdeckSpec) DeepCopyInto(out *FlightdeckSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Routing != nil {
+		in, out := &in.Routing, &out.Routing
+		*out = new(RoutingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]string, len(*in))
@@ -990,6 +1224,11 @@ func (in *FlightdeckSpec) DeepCopy() *FlightdeckSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlightdeckStatus) DeepCopyInto(out *FlightdeckStatus) {
 	*out = *in
+	if in.FailedOverrides != nil {
+		in, out := &in.FailedOverrides, &out.FailedOverrides
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlightdeckStatus.
@@ -1017,6 +1256,116 @@ func (in *GPUSettings) DeepCopy() *GPUSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentRef) DeepCopyInto(out *GatewayParentRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentRef.
+func (in *GatewayParentRef) DeepCopy() *GatewayParentRef {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressIPAllowList) DeepCopyInto(out *IngressIPAllowList) {
+	*out = *in
+	if in.SourceRanges != nil {
+		in, out := &in.SourceRanges, &out.SourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressIPAllowList.
+func (in *IngressIPAllowList) DeepCopy() *IngressIPAllowList {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressIPAllowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressPolicy) DeepCopyInto(out *IngressPolicy) {
+	*out = *in
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(IngressRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPAllowList != nil {
+		in, out := &in.IPAllowList, &out.IPAllowList
+		*out = new(IngressIPAllowList)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxBodySize != nil {
+		in, out := &in.MaxBodySize, &out.MaxBodySize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(IngressRetry)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressPolicy.
+func (in *IngressPolicy) DeepCopy() *IngressPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressRateLimit) DeepCopyInto(out *IngressRateLimit) {
+	*out = *in
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressRateLimit.
+func (in *IngressRateLimit) DeepCopy() *IngressRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressRetry) DeepCopyInto(out *IngressRetry) {
+	*out = *in
+	if in.InitialInterval != nil {
+		in, out := &in.InitialInterval, &out.InitialInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressRetry.
+func (in *IngressRetry) DeepCopy() *IngressRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InternalChronicleSpec) DeepCopyInto(out *InternalChronicleSpec) {
 	*out = *in
@@ -1027,6 +1376,7 @@ func (in *InternalChronicleSpec) DeepCopyInto(out *InternalChronicleSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.PodSchedulingConfig.DeepCopyInto(&out.PodSchedulingConfig)
 	if in.AddEnv != nil {
 		in, out := &in.AddEnv, &out.AddEnv
 		*out = make(map[string]string, len(*in))
@@ -1034,6 +1384,16 @@ func (in *InternalChronicleSpec) DeepCopyInto(out *InternalChronicleSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AgentResources != nil {
+		in, out := &in.AgentResources, &out.AgentResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalChronicleSpec.
@@ -1084,6 +1444,7 @@ func (in *InternalConnectSpec) DeepCopyInto(out *InternalConnectSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.PodSchedulingConfig.DeepCopyInto(&out.PodSchedulingConfig)
 	in.Auth.DeepCopyInto(&out.Auth)
 	if in.AddEnv != nil {
 		in, out := &in.AddEnv, &out.AddEnv
@@ -1097,11 +1458,43 @@ func (in *InternalConnectSpec) DeepCopyInto(out *InternalConnectSpec) {
 		*out = new(DatabricksConfig)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SessionQuota != nil {
+		in, out := &in.SessionQuota, &out.SessionQuota
+		*out = new(SessionQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExecutionEnvironments != nil {
+		in, out := &in.ExecutionEnvironments, &out.ExecutionEnvironments
+		*out = make([]ConnectExecutionEnvironment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExperimentalFeatures != nil {
 		in, out := &in.ExperimentalFeatures, &out.ExperimentalFeatures
 		*out = new(InternalConnectExperimentalFeatures)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IngressPolicy != nil {
+		in, out := &in.IngressPolicy, &out.IngressPolicy
+		*out = new(IngressPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.GPUSettings != nil {
 		in, out := &in.GPUSettings, &out.GPUSettings
 		*out = new(GPUSettings)
@@ -1132,7 +1525,30 @@ func (in *InternalFlightdeckSpec) DeepCopyInto(out *InternalFlightdeckSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.PodSchedulingConfig.DeepCopyInto(&out.PodSchedulingConfig)
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 	out.FeatureEnabler = in.FeatureEnabler
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalFlightdeckSpec.
@@ -1155,6 +1571,12 @@ func (in *InternalKeycloakSpec) DeepCopyInto(out *InternalKeycloakSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.PodSchedulingConfig.DeepCopyInto(&out.PodSchedulingConfig)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalKeycloakSpec.
@@ -1183,6 +1605,7 @@ func (in *InternalPackageManagerSpec) DeepCopyInto(out *InternalPackageManagerSp
 			(*out)[key] = val
 		}
 	}
+	in.PodSchedulingConfig.DeepCopyInto(&out.PodSchedulingConfig)
 	if in.AddEnv != nil {
 		in, out := &in.AddEnv, &out.AddEnv
 		*out = make(map[string]string, len(*in))
@@ -1190,7 +1613,27 @@ func (in *InternalPackageManagerSpec) DeepCopyInto(out *InternalPackageManagerSp
 			(*out)[key] = val
 		}
 	}
-	if in.GitSSHKeys != nil {
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IngressPolicy != nil {
+		in, out := &in.IngressPolicy, &out.IngressPolicy
+		*out = new(IngressPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitSSHKeys != nil {
 		in, out := &in.GitSSHKeys, &out.GitSSHKeys
 		*out = make([]SSHKeyConfig, len(*in))
 		for i := range *in {
@@ -1245,7 +1688,7 @@ func (in *InternalWorkbenchExperimentalFeatures) DeepCopyInto(out *InternalWorkb
 				inVal := (*in)[key]
 				in, out := &inVal, &outVal
 				*out = new(WorkbenchLauncherKubnernetesResourcesConfigSection)
-				**out = **in
+				(*in).DeepCopyInto(*out)
 			}
 			(*out)[key] = outVal
 		}
@@ -1293,13 +1736,7 @@ func (in *InternalWorkbenchSpec) DeepCopyInto(out *InternalWorkbenchSpec) {
 			(*out)[key] = val
 		}
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.PodSchedulingConfig.DeepCopyInto(&out.PodSchedulingConfig)
 	if in.SessionTolerations != nil {
 		in, out := &in.SessionTolerations, &out.SessionTolerations
 		*out = make([]v1.Toleration, len(*in))
@@ -1330,6 +1767,53 @@ func (in *InternalWorkbenchSpec) DeepCopyInto(out *InternalWorkbenchSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]WorkbenchLauncherProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SessionImageSelector != nil {
+		in, out := &in.SessionImageSelector, &out.SessionImageSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SessionLifecycle != nil {
+		in, out := &in.SessionLifecycle, &out.SessionLifecycle
+		*out = new(WorkbenchSessionLifecycle)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UserSelector != nil {
+		in, out := &in.UserSelector, &out.UserSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LauncherTemplates != nil {
+		in, out := &in.LauncherTemplates, &out.LauncherTemplates
+		*out = new(WorkbenchLauncherTemplates)
+		**out = **in
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]WorkbenchRepository, len(*in))
+		copy(*out, *in)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SessionQuota != nil {
+		in, out := &in.SessionQuota, &out.SessionQuota
+		*out = new(SessionQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExperimentalFeatures != nil {
 		in, out := &in.ExperimentalFeatures, &out.ExperimentalFeatures
 		*out = new(InternalWorkbenchExperimentalFeatures)
@@ -1340,6 +1824,11 @@ func (in *InternalWorkbenchSpec) DeepCopyInto(out *InternalWorkbenchSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExtensionCache != nil {
+		in, out := &in.ExtensionCache, &out.ExtensionCache
+		*out = new(WorkbenchExtensionCacheConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.VsCodeUserSettings != nil {
 		in, out := &in.VsCodeUserSettings, &out.VsCodeUserSettings
 		*out = make(map[string]*apiextensionsv1.JSON, len(*in))
@@ -1359,6 +1848,16 @@ func (in *InternalWorkbenchSpec) DeepCopyInto(out *InternalWorkbenchSpec) {
 	in.PositronSettings.DeepCopyInto(&out.PositronSettings)
 	out.VSCodeSettings = in.VSCodeSettings
 	out.ApiSettings = in.ApiSettings
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IngressPolicy != nil {
+		in, out := &in.IngressPolicy, &out.IngressPolicy
+		*out = new(IngressPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.JupyterConfig != nil {
 		in, out := &in.JupyterConfig, &out.JupyterConfig
 		*out = new(WorkbenchJupyterConfig)
@@ -1396,13 +1895,259 @@ func (in *Keycloak) DeepCopy() *Keycloak {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyConfig) DeepCopyInto(out *NetworkPolicyConfig) {
+	*out = *in
+	in.EgressFQDNs.DeepCopyInto(&out.EgressFQDNs)
+	if in.PrivateCIDRs != nil {
+		in, out := &in.PrivateCIDRs, &out.PrivateCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodCIDRs != nil {
+		in, out := &in.PodCIDRs, &out.PodCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InfrastructureCIDRs != nil {
+		in, out := &in.InfrastructureCIDRs, &out.InfrastructureCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.ExtraEgress.DeepCopyInto(&out.ExtraEgress)
+	in.ExtraIngress.DeepCopyInto(&out.ExtraIngress)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyConfig.
+func (in *NetworkPolicyConfig) DeepCopy() *NetworkPolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyEgressRules) DeepCopyInto(out *NetworkPolicyEgressRules) {
+	*out = *in
+	if in.Connect != nil {
+		in, out := &in.Connect, &out.Connect
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConnectSession != nil {
+		in, out := &in.ConnectSession, &out.ConnectSession
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Workbench != nil {
+		in, out := &in.Workbench, &out.Workbench
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WorkbenchSession != nil {
+		in, out := &in.WorkbenchSession, &out.WorkbenchSession
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PackageManager != nil {
+		in, out := &in.PackageManager, &out.PackageManager
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Chronicle != nil {
+		in, out := &in.Chronicle, &out.Chronicle
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Keycloak != nil {
+		in, out := &in.Keycloak, &out.Keycloak
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyEgressRules.
+func (in *NetworkPolicyEgressRules) DeepCopy() *NetworkPolicyEgressRules {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyEgressRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyFQDNs) DeepCopyInto(out *NetworkPolicyFQDNs) {
+	*out = *in
+	if in.Connect != nil {
+		in, out := &in.Connect, &out.Connect
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectSession != nil {
+		in, out := &in.ConnectSession, &out.ConnectSession
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workbench != nil {
+		in, out := &in.Workbench, &out.Workbench
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WorkbenchSession != nil {
+		in, out := &in.WorkbenchSession, &out.WorkbenchSession
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PackageManager != nil {
+		in, out := &in.PackageManager, &out.PackageManager
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Chronicle != nil {
+		in, out := &in.Chronicle, &out.Chronicle
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Keycloak != nil {
+		in, out := &in.Keycloak, &out.Keycloak
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyFQDNs.
+func (in *NetworkPolicyFQDNs) DeepCopy() *NetworkPolicyFQDNs {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyFQDNs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyIngressRules) DeepCopyInto(out *NetworkPolicyIngressRules) {
+	*out = *in
+	if in.Connect != nil {
+		in, out := &in.Connect, &out.Connect
+		*out = make([]networkingv1.NetworkPolicyIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConnectSession != nil {
+		in, out := &in.ConnectSession, &out.ConnectSession
+		*out = make([]networkingv1.NetworkPolicyIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Workbench != nil {
+		in, out := &in.Workbench, &out.Workbench
+		*out = make([]networkingv1.NetworkPolicyIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WorkbenchSession != nil {
+		in, out := &in.WorkbenchSession, &out.WorkbenchSession
+		*out = make([]networkingv1.NetworkPolicyIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PackageManager != nil {
+		in, out := &in.PackageManager, &out.PackageManager
+		*out = make([]networkingv1.NetworkPolicyIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Chronicle != nil {
+		in, out := &in.Chronicle, &out.Chronicle
+		*out = make([]networkingv1.NetworkPolicyIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Keycloak != nil {
+		in, out := &in.Keycloak, &out.Keycloak
+		*out = make([]networkingv1.NetworkPolicyIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyIngressRules.
+func (in *NetworkPolicyIngressRules) DeepCopy() *NetworkPolicyIngressRules {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyIngressRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyStatus) DeepCopyInto(out *NetworkPolicyStatus) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyStatus.
+func (in *NetworkPolicyStatus) DeepCopy() *NetworkPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Override) DeepCopyInto(out *Override) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Override.
+func (in *Override) DeepCopy() *Override {
+	if in == nil {
+		return nil
+	}
+	out := new(Override)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageManager) DeepCopyInto(out *PackageManager) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageManager.
@@ -1694,6 +2439,11 @@ func (in *PackageManagerSpec) DeepCopyInto(out *PackageManagerSpec) {
 		*out = new(product.VolumeSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.DatabaseConfig = in.DatabaseConfig
 	if in.IngressAnnotations != nil {
 		in, out := &in.IngressAnnotations, &out.IngressAnnotations
@@ -1702,6 +2452,16 @@ func (in *PackageManagerSpec) DeepCopyInto(out *PackageManagerSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Routing != nil {
+		in, out := &in.Routing, &out.Routing
+		*out = new(RoutingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IngressPolicy != nil {
+		in, out := &in.IngressPolicy, &out.IngressPolicy
+		*out = new(IngressPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]string, len(*in))
@@ -1714,6 +2474,12 @@ func (in *PackageManagerSpec) DeepCopyInto(out *PackageManagerSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.PodSchedulingConfig.DeepCopyInto(&out.PodSchedulingConfig)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]Override, len(*in))
+		copy(*out, *in)
+	}
 	if in.AddEnv != nil {
 		in, out := &in.AddEnv, &out.AddEnv
 		*out = make(map[string]string, len(*in))
@@ -1724,6 +2490,21 @@ func (in *PackageManagerSpec) DeepCopyInto(out *PackageManagerSpec) {
 	out.Secret = in.Secret
 	out.WorkloadSecret = in.WorkloadSecret
 	out.MainDatabaseCredentialSecret = in.MainDatabaseCredentialSecret
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ChronicleAgentResources != nil {
+		in, out := &in.ChronicleAgentResources, &out.ChronicleAgentResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.GitSSHKeys != nil {
 		in, out := &in.GitSSHKeys, &out.GitSSHKeys
 		*out = make([]SSHKeyConfig, len(*in))
@@ -1752,6 +2533,11 @@ func (in *PackageManagerSpec) DeepCopy() *PackageManagerSpec {
 func (in *PackageManagerStatus) DeepCopyInto(out *PackageManagerStatus) {
 	*out = *in
 	out.KeySecretRef = in.KeySecretRef
+	if in.FailedOverrides != nil {
+		in, out := &in.FailedOverrides, &out.FailedOverrides
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageManagerStatus.
@@ -1780,32 +2566,80 @@ func (in *PackageManagerStorageConfig) DeepCopy() *PackageManagerStorageConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PositronConfig) DeepCopyInto(out *PositronConfig) {
+func (in *PodSchedulingConfig) DeepCopyInto(out *PodSchedulingConfig) {
 	*out = *in
-	if in.SessionContainerImages != nil {
-		in, out := &in.SessionContainerImages, &out.SessionContainerImages
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Extensions != nil {
-		in, out := &in.Extensions, &out.Extensions
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.UserSettings != nil {
-		in, out := &in.UserSettings, &out.UserSettings
-		*out = make(map[string]*apiextensionsv1.JSON, len(*in))
-		for key, val := range *in {
-			var outVal *apiextensionsv1.JSON
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				inVal := (*in)[key]
-				in, out := &inVal, &outVal
-				*out = new(apiextensionsv1.JSON)
-				(*in).DeepCopyInto(*out)
-			}
-			(*out)[key] = outVal
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSchedulingConfig.
+func (in *PodSchedulingConfig) DeepCopy() *PodSchedulingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSchedulingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PositronConfig) DeepCopyInto(out *PositronConfig) {
+	*out = *in
+	if in.SessionContainerImages != nil {
+		in, out := &in.SessionContainerImages, &out.SessionContainerImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserSettings != nil {
+		in, out := &in.UserSettings, &out.UserSettings
+		*out = make(map[string]*apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			var outVal *apiextensionsv1.JSON
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(apiextensionsv1.JSON)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
 		}
 	}
 }
@@ -1972,6 +2806,36 @@ func (in *RPackageRepositoryConfig) DeepCopy() *RPackageRepositoryConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingConfig) DeepCopyInto(out *RoutingConfig) {
+	*out = *in
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewayParentRef)
+		**out = **in
+	}
+	if in.RequestTimeout != nil {
+		in, out := &in.RequestTimeout, &out.RequestTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ExternalDNS != nil {
+		in, out := &in.ExternalDNS, &out.ExternalDNS
+		*out = new(ExternalDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingConfig.
+func (in *RoutingConfig) DeepCopy() *RoutingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSHKeyConfig) DeepCopyInto(out *SSHKeyConfig) {
 	*out = *in
@@ -2045,13 +2909,242 @@ func (in *ServiceAccountConfig) DeepCopy() *ServiceAccountConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionImage) DeepCopyInto(out *SessionImage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionImage.
+func (in *SessionImage) DeepCopy() *SessionImage {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SessionImage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionImageList) DeepCopyInto(out *SessionImageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SessionImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionImageList.
+func (in *SessionImageList) DeepCopy() *SessionImageList {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionImageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SessionImageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionImageSpec) DeepCopyInto(out *SessionImageSpec) {
+	*out = *in
+	if in.IDEs != nil {
+		in, out := &in.IDEs, &out.IDEs
+		*out = make([]SessionIDE, len(*in))
+		copy(*out, *in)
+	}
+	if in.RVersions != nil {
+		in, out := &in.RVersions, &out.RVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PythonVersions != nil {
+		in, out := &in.PythonVersions, &out.PythonVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionImageSpec.
+func (in *SessionImageSpec) DeepCopy() *SessionImageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionImageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionImageStatus) DeepCopyInto(out *SessionImageStatus) {
+	*out = *in
+	if in.Sites != nil {
+		in, out := &in.Sites, &out.Sites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RVersions != nil {
+		in, out := &in.RVersions, &out.RVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PythonVersions != nil {
+		in, out := &in.PythonVersions, &out.PythonVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.QuartoVersions != nil {
+		in, out := &in.QuartoVersions, &out.QuartoVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionImageStatus.
+func (in *SessionImageStatus) DeepCopy() *SessionImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionLimitRange) DeepCopyInto(out *SessionLimitRange) {
+	*out = *in
+	if in.DefaultRequest != nil {
+		in, out := &in.DefaultRequest, &out.DefaultRequest
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionLimitRange.
+func (in *SessionLimitRange) DeepCopy() *SessionLimitRange {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionLimitRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionQuota) DeepCopyInto(out *SessionQuota) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.NvidiaGPUs != nil {
+		in, out := &in.NvidiaGPUs, &out.NvidiaGPUs
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LimitRange != nil {
+		in, out := &in.LimitRange, &out.LimitRange
+		*out = new(SessionLimitRange)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionQuota.
+func (in *SessionQuota) DeepCopy() *SessionQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionQuotaStatus) DeepCopyInto(out *SessionQuotaStatus) {
+	*out = *in
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionQuotaStatus.
+func (in *SessionQuotaStatus) DeepCopy() *SessionQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Site) DeepCopyInto(out *Site) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Site.
@@ -2120,6 +3213,11 @@ func (in *SiteSpec) DeepCopyInto(out *SiteSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Routing != nil {
+		in, out := &in.Routing, &out.Routing
+		*out = new(RoutingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]string, len(*in))
@@ -2136,6 +3234,21 @@ func (in *SiteSpec) DeepCopyInto(out *SiteSpec) {
 	out.Secret = in.Secret
 	out.WorkloadSecret = in.WorkloadSecret
 	out.MainDatabaseCredentialSecret = in.MainDatabaseCredentialSecret
+	if in.NetworkTrust != nil {
+		in, out := &in.NetworkTrust, &out.NetworkTrust
+		*out = new(NetworkTrust)
+		**out = **in
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]Override, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicyConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EnableFQDNHealthChecks != nil {
 		in, out := &in.EnableFQDNHealthChecks, &out.EnableFQDNHealthChecks
 		*out = new(bool)
@@ -2156,6 +3269,16 @@ func (in *SiteSpec) DeepCopy() *SiteSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SiteStatus) DeepCopyInto(out *SiteStatus) {
 	*out = *in
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailedOverrides != nil {
+		in, out := &in.FailedOverrides, &out.FailedOverrides
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SiteStatus.
@@ -2276,7 +3399,7 @@ func (in *Workbench) DeepCopyInto(out *Workbench) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Workbench.
@@ -2370,33 +3493,202 @@ func (in *WorkbenchDcfConfig) DeepCopy() *WorkbenchDcfConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *WorkbenchIniConfig) DeepCopyInto(out *WorkbenchIniConfig) {
+func (in *WorkbenchExtensionCache) DeepCopyInto(out *WorkbenchExtensionCache) {
 	*out = *in
-	if in.Launcher != nil {
-		in, out := &in.Launcher, &out.Launcher
-		*out = new(WorkbenchLauncherConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VsCode != nil {
-		in, out := &in.VsCode, &out.VsCode
-		*out = new(WorkbenchVsCodeConfig)
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(WorkbenchExtensionSource)
 		**out = **in
 	}
-	if in.Logging != nil {
-		in, out := &in.Logging, &out.Logging
-		*out = new(WorkbenchLoggingConfig)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchExtensionCache.
+func (in *WorkbenchExtensionCache) DeepCopy() *WorkbenchExtensionCache {
+	if in == nil {
+		return nil
 	}
-	if in.Jupyter != nil {
-		in, out := &in.Jupyter, &out.Jupyter
-		*out = new(WorkbenchJupyterConfig)
+	out := new(WorkbenchExtensionCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchExtensionCacheConfig) DeepCopyInto(out *WorkbenchExtensionCacheConfig) {
+	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(WorkbenchExtensionSource)
 		**out = **in
 	}
-	if in.RServer != nil {
-		in, out := &in.RServer, &out.RServer
-		*out = new(WorkbenchRServerConfig)
-		(*in).DeepCopyInto(*out)
-	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchExtensionCacheConfig.
+func (in *WorkbenchExtensionCacheConfig) DeepCopy() *WorkbenchExtensionCacheConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchExtensionCacheConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchExtensionCacheStatus) DeepCopyInto(out *WorkbenchExtensionCacheStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchExtensionCacheStatus.
+func (in *WorkbenchExtensionCacheStatus) DeepCopy() *WorkbenchExtensionCacheStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchExtensionCacheStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchExtensionSource) DeepCopyInto(out *WorkbenchExtensionSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchExtensionSource.
+func (in *WorkbenchExtensionSource) DeepCopy() *WorkbenchExtensionSource {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchExtensionSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchGroup) DeepCopyInto(out *WorkbenchGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchGroup.
+func (in *WorkbenchGroup) DeepCopy() *WorkbenchGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkbenchGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchGroupList) DeepCopyInto(out *WorkbenchGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkbenchGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchGroupList.
+func (in *WorkbenchGroupList) DeepCopy() *WorkbenchGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkbenchGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchGroupSpec) DeepCopyInto(out *WorkbenchGroupSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchGroupSpec.
+func (in *WorkbenchGroupSpec) DeepCopy() *WorkbenchGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchGroupStatus) DeepCopyInto(out *WorkbenchGroupStatus) {
+	*out = *in
+	if in.Sites != nil {
+		in, out := &in.Sites, &out.Sites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchGroupStatus.
+func (in *WorkbenchGroupStatus) DeepCopy() *WorkbenchGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchIniConfig) DeepCopyInto(out *WorkbenchIniConfig) {
+	*out = *in
+	if in.Launcher != nil {
+		in, out := &in.Launcher, &out.Launcher
+		*out = new(WorkbenchLauncherConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VsCode != nil {
+		in, out := &in.VsCode, &out.VsCode
+		*out = new(WorkbenchVsCodeConfig)
+		**out = **in
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(WorkbenchLoggingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Jupyter != nil {
+		in, out := &in.Jupyter, &out.Jupyter
+		*out = new(WorkbenchJupyterConfig)
+		**out = **in
+	}
+	if in.RServer != nil {
+		in, out := &in.RServer, &out.RServer
+		*out = new(WorkbenchRServerConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LauncherKubernetes != nil {
 		in, out := &in.LauncherKubernetes, &out.LauncherKubernetes
 		*out = new(WorkbenchLauncherKubernetesConfig)
@@ -2434,7 +3726,7 @@ func (in *WorkbenchIniConfig) DeepCopyInto(out *WorkbenchIniConfig) {
 				inVal := (*in)[key]
 				in, out := &inVal, &outVal
 				*out = new(WorkbenchLauncherKubnernetesResourcesConfigSection)
-				**out = **in
+				(*in).DeepCopyInto(*out)
 			}
 			(*out)[key] = outVal
 		}
@@ -2576,6 +3868,11 @@ func (in *WorkbenchLauncherKubernetesProfilesConfigSection) DeepCopy() *Workbenc
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkbenchLauncherKubnernetesResourcesConfigSection) DeepCopyInto(out *WorkbenchLauncherKubnernetesResourcesConfigSection) {
 	*out = *in
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(WorkbenchResourceProfilePodConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchLauncherKubnernetesResourcesConfigSection.
@@ -2603,6 +3900,46 @@ func (in *WorkbenchLauncherLocalConfig) DeepCopy() *WorkbenchLauncherLocalConfig
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchLauncherProfile) DeepCopyInto(out *WorkbenchLauncherProfile) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceProfiles != nil {
+		in, out := &in.ResourceProfiles, &out.ResourceProfiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxMemMb != nil {
+		in, out := &in.MaxMemMb, &out.MaxMemMb
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxNvidiaGpus != nil {
+		in, out := &in.MaxNvidiaGpus, &out.MaxNvidiaGpus
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchLauncherProfile.
+func (in *WorkbenchLauncherProfile) DeepCopy() *WorkbenchLauncherProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchLauncherProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkbenchLauncherServerConfig) DeepCopyInto(out *WorkbenchLauncherServerConfig) {
 	*out = *in
@@ -2618,6 +3955,21 @@ func (in *WorkbenchLauncherServerConfig) DeepCopy() *WorkbenchLauncherServerConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchLauncherTemplates) DeepCopyInto(out *WorkbenchLauncherTemplates) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchLauncherTemplates.
+func (in *WorkbenchLauncherTemplates) DeepCopy() *WorkbenchLauncherTemplates {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchLauncherTemplates)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkbenchList) DeepCopyInto(out *WorkbenchList) {
 	*out = *in
@@ -2745,6 +4097,13 @@ func (in *WorkbenchProfilesConfig) DeepCopyInto(out *WorkbenchProfilesConfig) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]WorkbenchLauncherProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchProfilesConfig.
@@ -2780,6 +4139,16 @@ func (in *WorkbenchRServerConfig) DeepCopy() *WorkbenchRServerConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkbenchRSessionConfig) DeepCopyInto(out *WorkbenchRSessionConfig) {
 	*out = *in
+	if in.SessionTimeoutMinutes != nil {
+		in, out := &in.SessionTimeoutMinutes, &out.SessionTimeoutMinutes
+		*out = new(int)
+		**out = **in
+	}
+	if in.SessionTimeoutKillHours != nil {
+		in, out := &in.SessionTimeoutKillHours, &out.SessionTimeoutKillHours
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchRSessionConfig.
@@ -2807,6 +4176,69 @@ func (in *WorkbenchRepoConfig) DeepCopy() *WorkbenchRepoConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchRepository) DeepCopyInto(out *WorkbenchRepository) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchRepository.
+func (in *WorkbenchRepository) DeepCopy() *WorkbenchRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchResourceProfilePodConfig) DeepCopyInto(out *WorkbenchResourceProfilePodConfig) {
+	*out = *in
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchResourceProfilePodConfig.
+func (in *WorkbenchResourceProfilePodConfig) DeepCopy() *WorkbenchResourceProfilePodConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchResourceProfilePodConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkbenchSecretConfig) DeepCopyInto(out *WorkbenchSecretConfig) {
 	*out = *in
@@ -2864,13 +4296,53 @@ func (in *WorkbenchSecretIniConfig) DeepCopy() *WorkbenchSecretIniConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchSession) DeepCopyInto(out *WorkbenchSession) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchSession.
+func (in *WorkbenchSession) DeepCopy() *WorkbenchSession {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchSession)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchSessionIdleLimits) DeepCopyInto(out *WorkbenchSessionIdleLimits) {
+	*out = *in
+	if in.IdleSuspendMinutes != nil {
+		in, out := &in.IdleSuspendMinutes, &out.IdleSuspendMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdleKillHours != nil {
+		in, out := &in.IdleKillHours, &out.IdleKillHours
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchSessionIdleLimits.
+func (in *WorkbenchSessionIdleLimits) DeepCopy() *WorkbenchSessionIdleLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchSessionIdleLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkbenchSessionIniConfig) DeepCopyInto(out *WorkbenchSessionIniConfig) {
 	*out = *in
 	if in.RSession != nil {
 		in, out := &in.RSession, &out.RSession
 		*out = new(WorkbenchRSessionConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Repos != nil {
 		in, out := &in.Repos, &out.Repos
@@ -2946,6 +4418,51 @@ func (in *WorkbenchSessionJsonConfig) DeepCopy() *WorkbenchSessionJsonConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchSessionLifecycle) DeepCopyInto(out *WorkbenchSessionLifecycle) {
+	*out = *in
+	if in.RStudio != nil {
+		in, out := &in.RStudio, &out.RStudio
+		*out = new(WorkbenchSessionIdleLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Jupyter != nil {
+		in, out := &in.Jupyter, &out.Jupyter
+		*out = new(WorkbenchSessionIdleLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VSCode != nil {
+		in, out := &in.VSCode, &out.VSCode
+		*out = new(WorkbenchSessionIdleLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Positron != nil {
+		in, out := &in.Positron, &out.Positron
+		*out = new(WorkbenchSessionIdleLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobExpiryHours != nil {
+		in, out := &in.JobExpiryHours, &out.JobExpiryHours
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Reaper != nil {
+		in, out := &in.Reaper, &out.Reaper
+		*out = new(WorkbenchSessionReaper)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchSessionLifecycle.
+func (in *WorkbenchSessionLifecycle) DeepCopy() *WorkbenchSessionLifecycle {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchSessionLifecycle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkbenchSessionNewlineConfig) DeepCopyInto(out *WorkbenchSessionNewlineConfig) {
 	*out = *in
@@ -2971,6 +4488,58 @@ func (in *WorkbenchSessionNewlineConfig) DeepCopy() *WorkbenchSessionNewlineConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchSessionReaper) DeepCopyInto(out *WorkbenchSessionReaper) {
+	*out = *in
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]WorkbenchSessionReaperProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchSessionReaper.
+func (in *WorkbenchSessionReaper) DeepCopy() *WorkbenchSessionReaper {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchSessionReaper)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchSessionReaperProfile) DeepCopyInto(out *WorkbenchSessionReaperProfile) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IDEs != nil {
+		in, out := &in.IDEs, &out.IDEs
+		*out = make([]WorkbenchSessionIDE, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceProfiles != nil {
+		in, out := &in.ResourceProfiles, &out.ResourceProfiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchSessionReaperProfile.
+func (in *WorkbenchSessionReaperProfile) DeepCopy() *WorkbenchSessionReaperProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchSessionReaperProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkbenchSpec) DeepCopyInto(out *WorkbenchSpec) {
 	*out = *in
@@ -2988,6 +4557,11 @@ func (in *WorkbenchSpec) DeepCopyInto(out *WorkbenchSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Auth.DeepCopyInto(&out.Auth)
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.DatabaseConfig = in.DatabaseConfig
 	if in.IngressAnnotations != nil {
 		in, out := &in.IngressAnnotations, &out.IngressAnnotations
@@ -2996,6 +4570,16 @@ func (in *WorkbenchSpec) DeepCopyInto(out *WorkbenchSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Routing != nil {
+		in, out := &in.Routing, &out.Routing
+		*out = new(RoutingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IngressPolicy != nil {
+		in, out := &in.IngressPolicy, &out.IngressPolicy
+		*out = new(IngressPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]string, len(*in))
@@ -3008,12 +4592,46 @@ func (in *WorkbenchSpec) DeepCopyInto(out *WorkbenchSpec) {
 			(*out)[key] = val
 		}
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.PodSchedulingConfig.DeepCopyInto(&out.PodSchedulingConfig)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]Override, len(*in))
+		copy(*out, *in)
+	}
+	if in.SessionImageSelector != nil {
+		in, out := &in.SessionImageSelector, &out.SessionImageSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SessionReaper != nil {
+		in, out := &in.SessionReaper, &out.SessionReaper
+		*out = new(WorkbenchSessionReaper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UserSelector != nil {
+		in, out := &in.UserSelector, &out.UserSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtensionCache != nil {
+		in, out := &in.ExtensionCache, &out.ExtensionCache
+		*out = new(WorkbenchExtensionCache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LauncherTemplates != nil {
+		in, out := &in.LauncherTemplates, &out.LauncherTemplates
+		*out = new(WorkbenchLauncherTemplates)
+		**out = **in
+	}
+	if in.SessionQuota != nil {
+		in, out := &in.SessionQuota, &out.SessionQuota
+		*out = new(SessionQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]WorkbenchRepository, len(*in))
+		copy(*out, *in)
 	}
 	if in.AddEnv != nil {
 		in, out := &in.AddEnv, &out.AddEnv
@@ -3033,6 +4651,21 @@ func (in *WorkbenchSpec) DeepCopyInto(out *WorkbenchSpec) {
 	out.Secret = in.Secret
 	out.WorkloadSecret = in.WorkloadSecret
 	out.MainDatabaseCredentialSecret = in.MainDatabaseCredentialSecret
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ChronicleAgentResources != nil {
+		in, out := &in.ChronicleAgentResources, &out.ChronicleAgentResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchSpec.
@@ -3049,6 +4682,28 @@ func (in *WorkbenchSpec) DeepCopy() *WorkbenchSpec {
 func (in *WorkbenchStatus) DeepCopyInto(out *WorkbenchStatus) {
 	*out = *in
 	out.KeySecretRef = in.KeySecretRef
+	if in.FailedOverrides != nil {
+		in, out := &in.FailedOverrides, &out.FailedOverrides
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtensionCache != nil {
+		in, out := &in.ExtensionCache, &out.ExtensionCache
+		*out = new(WorkbenchExtensionCacheStatus)
+		**out = **in
+	}
+	if in.SessionQuota != nil {
+		in, out := &in.SessionQuota, &out.SessionQuota
+		*out = new(SessionQuotaStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchStatus.
@@ -3061,6 +4716,135 @@ func (in *WorkbenchStatus) DeepCopy() *WorkbenchStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchUser) DeepCopyInto(out *WorkbenchUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchUser.
+func (in *WorkbenchUser) DeepCopy() *WorkbenchUser {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkbenchUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchUserHomeSeed) DeepCopyInto(out *WorkbenchUserHomeSeed) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchUserHomeSeed.
+func (in *WorkbenchUserHomeSeed) DeepCopy() *WorkbenchUserHomeSeed {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchUserHomeSeed)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchUserList) DeepCopyInto(out *WorkbenchUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkbenchUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchUserList.
+func (in *WorkbenchUserList) DeepCopy() *WorkbenchUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkbenchUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchUserSpec) DeepCopyInto(out *WorkbenchUserSpec) {
+	*out = *in
+	if in.GID != nil {
+		in, out := &in.GID, &out.GID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HomeSeed != nil {
+		in, out := &in.HomeSeed, &out.HomeSeed
+		*out = new(WorkbenchUserHomeSeed)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchUserSpec.
+func (in *WorkbenchUserSpec) DeepCopy() *WorkbenchUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkbenchUserStatus) DeepCopyInto(out *WorkbenchUserStatus) {
+	*out = *in
+	if in.Sites != nil {
+		in, out := &in.Sites, &out.Sites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkbenchUserStatus.
+func (in *WorkbenchUserStatus) DeepCopy() *WorkbenchUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkbenchUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkbenchVsCodeConfig) DeepCopyInto(out *WorkbenchVsCodeConfig) {
 	*out = *in
@@ -14,8 +14,16 @@ type KeycloakSpec struct {
 	Transaction *KeycloakTransactionSpec `json:"transaction,omitempty"`
 	Unsupported *KeycloakUnsupportedSpec `json:"unsupported,omitempty"`
 	Ingress     *KeycloakIngressSpec     `json:"ingress,omitempty"`
+	Scheduling  *KeycloakSchedulingSpec  `json:"scheduling,omitempty"`
 	Instances   int                      `json:"instances,omitempty"`
 	Image       string                   `json:"image,omitempty"`
+
+	AdditionalOptions []KeycloakAdditionalOption `json:"additionalOptions,omitempty"`
+}
+
+type KeycloakAdditionalOption struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
 }
 
 type KeycloakDbSpec struct {
@@ -65,6 +73,13 @@ type KeycloakTransactionSpec struct {
 	XaEnabled bool `json:"xaEnabled,omitempty"`
 }
 
+type KeycloakSchedulingSpec struct {
+	Affinity                  *v1.Affinity                  `json:"affinity,omitempty"`
+	Tolerations               []v1.Toleration               `json:"tolerations,omitempty"`
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	PriorityClassName         string                        `json:"priorityClassName,omitempty"`
+}
+
 type KeycloakUnsupportedSpec struct {
 	PodTemplate *v1.PodTemplateSpec `json:"podTemplate,omitempty"`
 }
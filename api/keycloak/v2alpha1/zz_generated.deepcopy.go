@@ -39,6 +39,21 @@ func (in *Keycloak) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakAdditionalOption) DeepCopyInto(out *KeycloakAdditionalOption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAdditionalOption.
+func (in *KeycloakAdditionalOption) DeepCopy() *KeycloakAdditionalOption {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakAdditionalOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakDbSpec) DeepCopyInto(out *KeycloakDbSpec) {
 	*out = *in
@@ -173,6 +188,40 @@ func (in *KeycloakList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakSchedulingSpec) DeepCopyInto(out *KeycloakSchedulingSpec) {
+	*out = *in
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakSchedulingSpec.
+func (in *KeycloakSchedulingSpec) DeepCopy() *KeycloakSchedulingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakSchedulingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakSecretSpec) DeepCopyInto(out *KeycloakSecretSpec) {
 	*out = *in
@@ -226,6 +275,16 @@ func (in *KeycloakSpec) DeepCopyInto(out *KeycloakSpec) {
 		*out = new(KeycloakIngressSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(KeycloakSchedulingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalOptions != nil {
+		in, out := &in.AdditionalOptions, &out.AdditionalOptions
+		*out = make([]KeycloakAdditionalOption, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakSpec.
@@ -33,7 +33,7 @@ func ChronicleSidecar(p Product, env []v1.EnvVar) []v1.Container {
 				},
 			}, env),
 			VolumeMounts:    vm,
-			Resources:       v1.ResourceRequirements{},
+			Resources:       p.GetChronicleAgentResources(),
 			ImagePullPolicy: "",
 			SecurityContext: nil,
 		},
@@ -110,7 +110,7 @@ func CreateChronicleWorkbenchVolumeFactory(p Product, env []v1.EnvVar) MultiCont
 				},
 			}, env),
 			Mounts:          sidecarMountDefs,
-			Resources:       v1.ResourceRequirements{},
+			Resources:       p.GetChronicleAgentResources(),
 			ImagePullPolicy: "",
 			SecurityContext: nil,
 		}
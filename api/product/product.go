@@ -3,6 +3,7 @@ package product
 import (
 	"strconv"
 
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/strings/slices"
 )
@@ -53,6 +54,7 @@ type Product interface {
 	GetSecretVaultName() string
 	SecretProviderClassName() string
 	GetChronicleAgentImage() string
+	GetChronicleAgentResources() v1.ResourceRequirements
 	GetChronicleUrl() string
 }
 
@@ -61,15 +61,29 @@ type JobConfig struct {
 }
 
 type wrapperTemplateData struct {
-	Name  string         `json:"name"`
-	Value *SessionConfig `json:"value"`
+	Name  string               `json:"name"`
+	Value *sessionTemplateData `json:"value"`
+}
+
+type sessionTemplateData struct {
+	*SessionConfig
+	ResourceProfiles any `json:"resourceProfiles,omitempty"`
 }
 
 func (s *SessionConfig) GenerateSessionConfigTemplate() (string, error) {
+	return s.GenerateSessionConfigTemplateWithResourceProfiles(nil)
+}
+
+// GenerateSessionConfigTemplateWithResourceProfiles adds the pod customizations of resource profiles, by resource
+// profile name, to the template data. job.tpl applies those of the resource profile of a session
+func (s *SessionConfig) GenerateSessionConfigTemplateWithResourceProfiles(resourceProfiles any) (string, error) {
 	// build wrapper struct
 	w := wrapperTemplateData{
-		Name:  "rstudio-library.templates.data",
-		Value: s,
+		Name: "rstudio-library.templates.data",
+		Value: &sessionTemplateData{
+			SessionConfig:    s,
+			ResourceProfiles: resourceProfiles,
+		},
 	}
 
 	// remove struct information by serializing to JSON
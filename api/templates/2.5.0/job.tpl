@@ -2,6 +2,26 @@
 # DO NOT MODIFY the "Version: " key
 # Helm Version: v1
 {{- $templateData := include "rstudio-library.templates.data" nil | mustFromJson }}
+{{- $resourceProfile := dict }}
+{{- $resourceProfileName := "" }}
+{{- $resourceLimits := dict }}
+{{- range .Job.resourceLimits }}
+  {{- $_ := set $resourceLimits .type (float64 .value) }}
+{{- end }}
+{{- range $name, $profile := (default dict $templateData.resourceProfiles) }}
+  {{- $matches := true }}
+  {{- range $type, $value := $profile.resourceLimits }}
+    {{- if ne (float64 $value) (float64 (get $resourceLimits $type | default 0)) }}
+      {{- $matches = false }}
+    {{- end }}
+  {{- end }}
+  {{- if $matches }}
+    {{- $resourceProfileName = $name }}
+    {{- $resourceProfile = $profile }}
+  {{- end }}
+{{- end }}
+{{- $profileVolumes := $resourceProfile.volumes | default list }}
+{{- $profileVolumeMounts := $resourceProfile.volumeMounts | default list }}
 apiVersion: batch/v1
 kind: Job
 metadata:
@@ -51,6 +71,9 @@ spec:
         user: {{ toYaml .Job.user }}
         name: {{ toYaml .Job.name }}
         service_ports: {{ toYaml .Job.servicePortsJson }}
+        {{- with $resourceProfileName }}
+        posit.team/resource-profile: {{ toYaml . }}
+        {{- end }}
         {{- if .Job.metadata }}
         user_metadata: {{ toJson .Job.metadata | toYaml | indent 8 | trimPrefix (repeat 8 " ") }}
         {{- end }}
@@ -93,7 +116,7 @@ spec:
         {{- toYaml . | nindent 8 }}
       {{- end }}
       shareProcessNamespace: {{ .Job.shareProcessNamespace }}
-      {{- if or (ne (len .Job.volumes) 0) (ne (len $templateData.pod.volumes) 0) }}
+      {{- if or (ne (len .Job.volumes) 0) (ne (len $templateData.pod.volumes) 0) (ne (len $profileVolumes) 0) }}
       volumes:
         {{- range .Job.volumes }}
         - {{ nindent 10 (toYaml .) | trim -}}
@@ -101,12 +124,15 @@ spec:
         {{- range $templateData.pod.volumes }}
         - {{ nindent 10 (toYaml .) | trim -}}
         {{- end }}
+        {{- range $profileVolumes }}
+        - {{ nindent 10 (toYaml .) | trim -}}
+        {{- end }}
       {{- end }}
-      {{- with $templateData.pod.tolerations }}
+      {{- with concat ($templateData.pod.tolerations | default list) ($resourceProfile.tolerations | default list) }}
       tolerations:
         {{- toYaml . | nindent 8 }}
       {{- end }}
-      {{- with $templateData.pod.affinity }}
+      {{- with $resourceProfile.affinity | default $templateData.pod.affinity }}
       affinity:
         {{- toYaml . | nindent 8 }}
       {{- end }}
@@ -119,10 +145,13 @@ spec:
         {{ $key }}: {{- toYaml $val | nindent 10 }}
         {{- end }}
       {{- end }}
-      {{- with $templateData.pod.priorityClassName }}
+      {{- with $resourceProfile.priorityClassName | default $templateData.pod.priorityClassName }}
       priorityClassName:
         {{- toYaml . | nindent 8 }}
       {{- end }}
+      {{- with $resourceProfile.runtimeClassName }}
+      runtimeClassName: {{ toYaml . }}
+      {{- end }}
       {{- $securityContext := $templateData.pod.defaultSecurityContext }}
       {{- if .Job.container.runAsUserId }}
         {{- $_ := set $securityContext "runAsUser" .Job.container.runAsUserId }}
@@ -333,7 +362,7 @@ spec:
               {{- end }}
             {{- end }}
           {{- end }}
-          {{- if or (ne (len .Job.volumes) 0) (ne (len $templateData.pod.volumeMounts) 0) }}
+          {{- if or (ne (len .Job.volumes) 0) (ne (len $templateData.pod.volumeMounts) 0) (ne (len $profileVolumeMounts) 0) }}
           volumeMounts:
             {{- range .Job.volumeMounts }}
             - {{ nindent 14 (toYaml .) | trim -}}
@@ -341,6 +370,9 @@ spec:
             {{- range $templateData.pod.volumeMounts }}
             - {{ nindent 14 (toYaml .) | trim -}}
             {{- end }}
+            {{- range $profileVolumeMounts }}
+            - {{ nindent 14 (toYaml .) | trim -}}
+            {{- end }}
           {{- end }}
         {{- with $templateData.pod.extraContainers }}
           {{- toYaml . | nindent 8 }}
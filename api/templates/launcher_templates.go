@@ -0,0 +1,241 @@
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+//go:embed 2.3.1/*.tpl 2.4.0/*.tpl 2.5.0/*.tpl
+var launcherTemplates embed.FS
+
+// LatestLauncherTemplateVersion is the launcher template version that is used unless another one is chosen
+const LatestLauncherTemplateVersion = "2.5.0"
+
+// launcherTemplateReleases are the first Workbench release of each launcher template version, newest first
+var launcherTemplateReleases = []struct {
+	version string
+	release [3]int
+}{
+	{"2.5.0", [3]int{2024, 12, 0}},
+	{"2.4.0", [3]int{2023, 12, 0}},
+	{"2.3.1", [3]int{0, 0, 0}},
+}
+
+// LauncherTemplateVersions are the bundled launcher template versions, newest first
+func LauncherTemplateVersions() []string {
+	versions := make([]string, 0, len(launcherTemplateReleases))
+	for _, r := range launcherTemplateReleases {
+		versions = append(versions, r.version)
+	}
+	return versions
+}
+
+// LauncherTemplate returns the bundled job.tpl or service.tpl of a launcher template version
+func LauncherTemplate(version, name string) (string, error) {
+	b, err := launcherTemplates.ReadFile(version + "/" + name)
+	if err != nil {
+		return "", fmt.Errorf("launcher template %s is not bundled for version %q", name, version)
+	}
+	return string(b), nil
+}
+
+func mustLauncherTemplate(version, name string) string {
+	tpl, err := LauncherTemplate(version, name)
+	if err != nil {
+		panic(err)
+	}
+	return tpl
+}
+
+var workbenchReleaseRegexp = regexp.MustCompile(`(\d{4})\.(\d{2})\.(\d+)`)
+
+// LauncherTemplateVersionForImage returns the launcher template version of the Workbench release in the tag of
+// image, i.e. rstudio/rstudio-workbench:ubuntu2204-2024.12.1. Images without a release in their tag get the
+// latest version
+func LauncherTemplateVersionForImage(image string) string {
+	// the tag is after the last colon of the image name, which may include a registry port
+	name, _, _ := strings.Cut(image, "@")
+	i := strings.LastIndex(name, ":")
+	if i < 0 || strings.Contains(name[i:], "/") {
+		return LatestLauncherTemplateVersion
+	}
+	tag := name[i+1:]
+
+	m := workbenchReleaseRegexp.FindStringSubmatch(tag)
+	if m == nil {
+		return LatestLauncherTemplateVersion
+	}
+	var release [3]int
+	for i := range release {
+		release[i], _ = strconv.Atoi(m[i+1])
+	}
+	for _, r := range launcherTemplateReleases {
+		if !releaseBefore(release, r.release) {
+			return r.version
+		}
+	}
+	return LatestLauncherTemplateVersion
+}
+
+func releaseBefore(a, b [3]int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// sampleLauncherJob is a session as the launcher passes it to the templates
+func sampleLauncherJob() map[string]any {
+	return map[string]any{
+		"Job": map[string]any{
+			"id":               "sample-job",
+			"name":             "Sample Session",
+			"generateName":     "sample-session-",
+			"serviceName":      "sample-session",
+			"instanceId":       "sample-instance",
+			"user":             "sample-user",
+			"host":             "",
+			"stdin":            "",
+			"tags":             []any{"rstudio-r-session"},
+			"exe":              "/usr/lib/rstudio-server/bin/rsession-run",
+			"command":          "",
+			"args":             []any{"--standalone=1"},
+			"workingDirectory": "/home/sample-user",
+			"environment": []any{
+				map[string]any{"name": "USER", "value": "sample-user"},
+			},
+			"config": []any{},
+			"container": map[string]any{
+				"image":                "sample-image",
+				"runAsUserId":          1000,
+				"runAsGroupId":         1000,
+				"supplementalGroupIds": []any{},
+			},
+			"exposedPorts": []any{
+				map[string]any{"targetPort": 8787, "protocol": "TCP"},
+			},
+			"servicePortsJson": `{"8787":"TCP"}`,
+			"metadata": map[string]any{
+				"job":     map[string]any{},
+				"pod":     map[string]any{},
+				"service": map[string]any{},
+			},
+			"placementConstraints":  []any{},
+			"resourceLimits":        []any{map[string]any{"type": "cpuCount", "value": "1"}},
+			"initContainers":        []any{},
+			"volumes":               []any{},
+			"volumeMounts":          []any{},
+			"serviceAccountName":    "",
+			"shareProcessNamespace": false,
+		},
+	}
+}
+
+// resourceProfileAnnotation is the session Pod annotation that job.tpl records the resource profile of a session in
+const resourceProfileAnnotation = "posit.team/resource-profile"
+
+// ValidateLauncherTemplate renders a job.tpl or service.tpl for a sample session, with templateData as the
+// rstudio-library-templates-data.tpl that the launcher loads next to it, and checks that it renders a kind object.
+// A job.tpl must also apply the resource profiles of templateData to the sessions that have their resource limits
+func ValidateLauncherTemplate(tpl, templateData, kind string) error {
+	obj, err := renderLauncherTemplate(tpl, templateData, sampleLauncherJob())
+	if err != nil {
+		return err
+	}
+	if obj["kind"] != kind {
+		return fmt.Errorf("rendered template is a %v, not a %s", obj["kind"], kind)
+	}
+	if kind != "Job" {
+		return nil
+	}
+
+	profiles, err := templateResourceProfiles(templateData)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		profile, _ := profiles[name].(map[string]any)
+		limits, _ := profile["resourceLimits"].(map[string]any)
+		types := make([]string, 0, len(limits))
+		for t := range limits {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		resourceLimits := make([]any, 0, len(limits))
+		for _, t := range types {
+			resourceLimits = append(resourceLimits, map[string]any{"type": t, "value": limits[t]})
+		}
+
+		job := sampleLauncherJob()
+		job["Job"].(map[string]any)["resourceLimits"] = resourceLimits
+		obj, err := renderLauncherTemplate(tpl, templateData, job)
+		if err != nil {
+			return fmt.Errorf("resource profile %s: %w", name, err)
+		}
+		if recorded, _, _ := unstructured.NestedString(obj, "spec", "template", "metadata", "annotations", resourceProfileAnnotation); recorded != name {
+			return fmt.Errorf("rendered template does not apply resource profile %s, which it records in the %s annotation", name, resourceProfileAnnotation)
+		}
+	}
+	return nil
+}
+
+// templateResourceProfiles returns the resource profiles of templateData, by name
+func templateResourceProfiles(templateData string) (map[string]any, error) {
+	t := template.New("launcher")
+	t.Funcs(AddOnFuncMap(t, TemplateFuncMap(t)))
+	if _, err := t.Parse(templateData); err != nil {
+		return nil, fmt.Errorf("parsing template data: %w", err)
+	}
+	if _, err := t.New("data").Parse(`{{ include "rstudio-library.templates.data" nil }}`); err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	if err := t.ExecuteTemplate(buf, "data", nil); err != nil {
+		return nil, fmt.Errorf("rendering template data: %w", err)
+	}
+	data := struct {
+		ResourceProfiles map[string]any `json:"resourceProfiles"`
+	}{}
+	if err := json.Unmarshal(buf.Bytes(), &data); err != nil {
+		return nil, fmt.Errorf("template data is not JSON: %w", err)
+	}
+	return data.ResourceProfiles, nil
+}
+
+func renderLauncherTemplate(tpl, templateData string, job map[string]any) (map[string]any, error) {
+	t := template.New("launcher")
+	t.Funcs(AddOnFuncMap(t, TemplateFuncMap(t)))
+	if _, err := t.Parse(templateData); err != nil {
+		return nil, fmt.Errorf("parsing template data: %w", err)
+	}
+	if _, err := t.New("template").Parse(tpl); err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := t.ExecuteTemplate(buf, "template", job); err != nil {
+		return nil, fmt.Errorf("rendering template: %w", err)
+	}
+	obj := map[string]any{}
+	if err := yaml.Unmarshal(buf.Bytes(), &obj); err != nil {
+		return nil, fmt.Errorf("rendered template is not YAML: %w", err)
+	}
+	return obj, nil
+}
@@ -0,0 +1,168 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleTemplateData(t *testing.T) string {
+	data, err := RenderTemplateDataOutput(map[string]any{
+		"name": "rstudio-library.templates.data",
+		"value": map[string]any{
+			"service": map[string]any{"type": "ClusterIP"},
+			"pod": map[string]any{
+				"defaultSecurityContext": map[string]any{},
+				"volumes":                []any{map[string]any{"name": "home", "emptyDir": map[string]any{}}},
+				"volumeMounts":           []any{map[string]any{"name": "home", "mountPath": "/home"}},
+			},
+			"job": map[string]any{"labels": map[string]any{"team": "data"}},
+		},
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func TestValidateLauncherTemplate_Bundled(t *testing.T) {
+	data := sampleTemplateData(t)
+	for _, version := range LauncherTemplateVersions() {
+		job, err := LauncherTemplate(version, "job.tpl")
+		require.NoError(t, err)
+		assert.NoError(t, ValidateLauncherTemplate(job, data, "Job"), version)
+
+		service, err := LauncherTemplate(version, "service.tpl")
+		require.NoError(t, err)
+		assert.NoError(t, ValidateLauncherTemplate(service, data, "Service"), version)
+	}
+
+	_, err := LauncherTemplate("1.0.0", "job.tpl")
+	assert.Error(t, err)
+}
+
+func TestValidateLauncherTemplate_Errors(t *testing.T) {
+	data := sampleTemplateData(t)
+
+	err := ValidateLauncherTemplate("apiVersion: batch/v1\nkind: Job\nmetadata: {{ .Job.name", data, "Job")
+	assert.ErrorContains(t, err, "parsing template")
+
+	err = ValidateLauncherTemplate("kind: Job\nname: {{ fail \"no name\" }}", data, "Job")
+	assert.ErrorContains(t, err, "rendering template")
+
+	err = ValidateLauncherTemplate("kind: Job\n  name: {{ .Job.name }}", data, "Job")
+	assert.ErrorContains(t, err, "not YAML")
+
+	err = ValidateLauncherTemplate("apiVersion: v1\nkind: Service", data, "Job")
+	assert.ErrorContains(t, err, "is a Service, not a Job")
+}
+
+func TestLauncherTemplateVersionForImage(t *testing.T) {
+	for image, version := range map[string]string{
+		"rstudio/rstudio-workbench:ubuntu2204-2025.05.1":                    "2.5.0",
+		"rstudio/rstudio-workbench:ubuntu2204-2024.12.0":                    "2.5.0",
+		"rstudio/rstudio-workbench:jammy-2024.04.2":                         "2.4.0",
+		"registry.example.com:5000/workbench:2023.12.1-402.pro1":            "2.4.0",
+		"rstudio/rstudio-workbench:2023.09.1@sha256:0123456789abcdef":       "2.3.1",
+		"rstudio/rstudio-workbench:latest":                                  "2.5.0",
+		"registry.example.com:5000/rstudio-workbench":                       "2.5.0",
+		"rstudio/rstudio-workbench@sha256:2023.09.1f0123456789abcdef012345": "2.5.0",
+	} {
+		assert.Equal(t, version, LauncherTemplateVersionForImage(image), image)
+	}
+}
+
+func TestLauncherTemplate_ResourceProfile(t *testing.T) {
+	data, err := RenderTemplateDataOutput(map[string]any{
+		"name": "rstudio-library.templates.data",
+		"value": map[string]any{
+			"pod": map[string]any{
+				"defaultSecurityContext": map[string]any{},
+				"volumes":                []any{},
+				"volumeMounts":           []any{},
+				"tolerations":            []any{map[string]any{"key": "sessions", "operator": "Exists"}},
+				"priorityClassName":      "sessions",
+			},
+			"resourceProfiles": map[string]any{
+				"gpu": map[string]any{
+					"tolerations":       []any{map[string]any{"key": "nvidia.com/gpu", "operator": "Exists", "effect": "NoSchedule"}},
+					"priorityClassName": "gpu-sessions",
+					"runtimeClassName":  "nvidia",
+					"volumes":           []any{map[string]any{"name": "shm", "emptyDir": map[string]any{"medium": "Memory"}}},
+					"volumeMounts":      []any{map[string]any{"name": "shm", "mountPath": "/dev/shm"}},
+					"resourceLimits":    map[string]any{"cpuCount": "8", "memory": "32000", "NVIDIA GPUs": "1", "AMD GPUs": "0"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	for _, version := range LauncherTemplateVersions() {
+		tpl, err := LauncherTemplate(version, "job.tpl")
+		require.NoError(t, err)
+
+		// the bundled templates apply the resource profiles
+		require.NoError(t, ValidateLauncherTemplate(tpl, data, "Job"), version)
+
+		// a session of the gpu resource profile is recognized by its resource limits
+		job := sampleLauncherJob()
+		job["Job"].(map[string]any)["placementConstraints"] = []any{
+			map[string]any{"name": "node-pool", "value": "gpu"},
+		}
+		job["Job"].(map[string]any)["resourceLimits"] = []any{
+			map[string]any{"type": "cpuCount", "value": "8.0"},
+			map[string]any{"type": "memory", "value": "32000"},
+			map[string]any{"type": "NVIDIA GPUs", "value": "1"},
+		}
+		obj, err := renderLauncherTemplate(tpl, data, job)
+		require.NoError(t, err, version)
+		template := obj["spec"].(map[string]any)["template"].(map[string]any)
+		assert.Equal(t, "gpu", template["metadata"].(map[string]any)["annotations"].(map[string]any)["posit.team/resource-profile"], version)
+		spec := template["spec"].(map[string]any)
+		assert.Equal(t, map[string]any{"node-pool": "gpu"}, spec["nodeSelector"], version)
+		assert.Len(t, spec["tolerations"], 2, version)
+		assert.Equal(t, "gpu-sessions", spec["priorityClassName"], version)
+		assert.Equal(t, "nvidia", spec["runtimeClassName"], version)
+		assert.Equal(t, []any{map[string]any{"name": "shm", "emptyDir": map[string]any{"medium": "Memory"}}}, spec["volumes"], version)
+		container := spec["containers"].([]any)[0].(map[string]any)
+		assert.Equal(t, []any{map[string]any{"name": "shm", "mountPath": "/dev/shm"}}, container["volumeMounts"], version)
+
+		// sessions of other resource profiles are not customized, even if they share some of the resource limits
+		job = sampleLauncherJob()
+		job["Job"].(map[string]any)["resourceLimits"] = []any{
+			map[string]any{"type": "cpuCount", "value": "8"},
+			map[string]any{"type": "memory", "value": "32000"},
+		}
+		obj, err = renderLauncherTemplate(tpl, data, job)
+		require.NoError(t, err, version)
+		template = obj["spec"].(map[string]any)["template"].(map[string]any)
+		assert.NotContains(t, template["metadata"].(map[string]any)["annotations"], "posit.team/resource-profile", version)
+		spec = template["spec"].(map[string]any)
+		assert.NotContains(t, spec, "nodeSelector", version)
+		assert.Len(t, spec["tolerations"], 1, version)
+		assert.Equal(t, "sessions", spec["priorityClassName"], version)
+		assert.NotContains(t, spec, "runtimeClassName", version)
+		assert.NotContains(t, spec, "volumes", version)
+	}
+}
+
+func TestValidateLauncherTemplate_ResourceProfile(t *testing.T) {
+	data, err := RenderTemplateDataOutput(map[string]any{
+		"name": "rstudio-library.templates.data",
+		"value": map[string]any{
+			"resourceProfiles": map[string]any{
+				"gpu": map[string]any{
+					"runtimeClassName": "nvidia",
+					"resourceLimits":   map[string]any{"cpuCount": "8", "NVIDIA GPUs": "1", "AMD GPUs": "0"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// a custom template that ignores the resource profiles would drop their customizations
+	err = ValidateLauncherTemplate("apiVersion: batch/v1\nkind: Job\nmetadata:\n  name: {{ .Job.name }}", data, "Job")
+	assert.ErrorContains(t, err, "does not apply resource profile gpu")
+
+	// services have no resource profile
+	assert.NoError(t, ValidateLauncherTemplate("apiVersion: v1\nkind: Service", data, "Service"))
+}
@@ -26,21 +26,14 @@ var debugTpl string
 //go:embed _launcher-templates.tpl
 var launcherTemplatesTpl string
 
-// TODO: we will probably need to support more than one version eventually...
-//   (i.e. if Launcher version >= XYZ, then use this template, else older)
-
-//go:embed 2.5.0/job.tpl
-var jobTpl string
-
-//go:embed 2.5.0/service.tpl
-var serviceTpl string
-
+// DumpJobTpl returns the job.tpl of the latest launcher template version
 func DumpJobTpl() string {
-	return jobTpl
+	return mustLauncherTemplate(LatestLauncherTemplateVersion, "job.tpl")
 }
 
+// DumpServiceTpl returns the service.tpl of the latest launcher template version
 func DumpServiceTpl() string {
-	return serviceTpl
+	return mustLauncherTemplate(LatestLauncherTemplateVersion, "service.tpl")
 }
 
 const recursionMaxNums = 1000
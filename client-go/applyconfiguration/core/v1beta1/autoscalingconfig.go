@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v2 "k8s.io/api/autoscaling/v2"
+)
+
+// AutoscalingConfigApplyConfiguration represents a declarative configuration of the AutoscalingConfig type for use
+// with apply.
+type AutoscalingConfigApplyConfiguration struct {
+	MinReplicas                       *int32                              `json:"minReplicas,omitempty"`
+	MaxReplicas                       *int32                              `json:"maxReplicas,omitempty"`
+	TargetCPUUtilizationPercentage    *int32                              `json:"targetCPUUtilizationPercentage,omitempty"`
+	TargetMemoryUtilizationPercentage *int32                              `json:"targetMemoryUtilizationPercentage,omitempty"`
+	Metrics                           []v2.MetricSpec                     `json:"metrics,omitempty"`
+	Behavior                          *v2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// AutoscalingConfigApplyConfiguration constructs a declarative configuration of the AutoscalingConfig type for use with
+// apply.
+func AutoscalingConfig() *AutoscalingConfigApplyConfiguration {
+	return &AutoscalingConfigApplyConfiguration{}
+}
+
+// WithMinReplicas sets the MinReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinReplicas field is set to the value of the last call.
+func (b *AutoscalingConfigApplyConfiguration) WithMinReplicas(value int32) *AutoscalingConfigApplyConfiguration {
+	b.MinReplicas = &value
+	return b
+}
+
+// WithMaxReplicas sets the MaxReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxReplicas field is set to the value of the last call.
+func (b *AutoscalingConfigApplyConfiguration) WithMaxReplicas(value int32) *AutoscalingConfigApplyConfiguration {
+	b.MaxReplicas = &value
+	return b
+}
+
+// WithTargetCPUUtilizationPercentage sets the TargetCPUUtilizationPercentage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TargetCPUUtilizationPercentage field is set to the value of the last call.
+func (b *AutoscalingConfigApplyConfiguration) WithTargetCPUUtilizationPercentage(value int32) *AutoscalingConfigApplyConfiguration {
+	b.TargetCPUUtilizationPercentage = &value
+	return b
+}
+
+// WithTargetMemoryUtilizationPercentage sets the TargetMemoryUtilizationPercentage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TargetMemoryUtilizationPercentage field is set to the value of the last call.
+func (b *AutoscalingConfigApplyConfiguration) WithTargetMemoryUtilizationPercentage(value int32) *AutoscalingConfigApplyConfiguration {
+	b.TargetMemoryUtilizationPercentage = &value
+	return b
+}
+
+// WithMetrics adds the given value to the Metrics field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Metrics field.
+func (b *AutoscalingConfigApplyConfiguration) WithMetrics(values ...v2.MetricSpec) *AutoscalingConfigApplyConfiguration {
+	for i := range values {
+		b.Metrics = append(b.Metrics, values[i])
+	}
+	return b
+}
+
+// WithBehavior sets the Behavior field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Behavior field is set to the value of the last call.
+func (b *AutoscalingConfigApplyConfiguration) WithBehavior(value v2.HorizontalPodAutoscalerBehavior) *AutoscalingConfigApplyConfiguration {
+	b.Behavior = &value
+	return b
+}
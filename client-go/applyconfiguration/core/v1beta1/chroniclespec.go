@@ -5,17 +5,27 @@
 
 package v1beta1
 
+import (
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	v1 "k8s.io/api/core/v1"
+)
+
 // ChronicleSpecApplyConfiguration represents a declarative configuration of the ChronicleSpec type for use
 // with apply.
 type ChronicleSpecApplyConfiguration struct {
-	Config               *ChronicleConfigApplyConfiguration `json:"config,omitempty"`
-	ImagePullSecrets     []string                           `json:"imagePullSecrets,omitempty"`
-	NodeSelector         map[string]string                  `json:"nodeSelector,omitempty"`
-	AddEnv               map[string]string                  `json:"addEnv,omitempty"`
-	Image                *string                            `json:"image,omitempty"`
-	AwsAccountId         *string                            `json:"awsAccountId,omitempty"`
-	ClusterDate          *string                            `json:"clusterDate,omitempty"`
-	WorkloadCompoundName *string                            `json:"workloadCompoundName,omitempty"`
+	Config                                *ChronicleConfigApplyConfiguration `json:"config,omitempty"`
+	ImagePullSecrets                      []string                           `json:"imagePullSecrets,omitempty"`
+	NodeSelector                          map[string]string                  `json:"nodeSelector,omitempty"`
+	PodSchedulingConfigApplyConfiguration `json:",inline"`
+	SecurityProfile                       *corev1beta1.SecurityProfile `json:"securityProfile,omitempty"`
+	Overrides                             []OverrideApplyConfiguration `json:"overrides,omitempty"`
+	AddEnv                                map[string]string            `json:"addEnv,omitempty"`
+	Image                                 *string                      `json:"image,omitempty"`
+	Resources                             *v1.ResourceRequirements     `json:"resources,omitempty"`
+	AgentResources                        *v1.ResourceRequirements     `json:"agentResources,omitempty"`
+	AwsAccountId                          *string                      `json:"awsAccountId,omitempty"`
+	ClusterDate                           *string                      `json:"clusterDate,omitempty"`
+	WorkloadCompoundName                  *string                      `json:"workloadCompoundName,omitempty"`
 }
 
 // ChronicleSpecApplyConfiguration constructs a declarative configuration of the ChronicleSpec type for use with
@@ -56,6 +66,91 @@ func (b *ChronicleSpecApplyConfiguration) WithNodeSelector(entries map[string]st
 	return b
 }
 
+// WithAffinity sets the Affinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Affinity field is set to the value of the last call.
+func (b *ChronicleSpecApplyConfiguration) WithAffinity(value v1.Affinity) *ChronicleSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.Affinity = &value
+	return b
+}
+
+// WithTolerations adds the given value to the Tolerations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tolerations field.
+func (b *ChronicleSpecApplyConfiguration) WithTolerations(values ...v1.Toleration) *ChronicleSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.Tolerations = append(b.PodSchedulingConfigApplyConfiguration.Tolerations, values[i])
+	}
+	return b
+}
+
+// WithTopologySpreadConstraints adds the given value to the TopologySpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopologySpreadConstraints field.
+func (b *ChronicleSpecApplyConfiguration) WithTopologySpreadConstraints(values ...v1.TopologySpreadConstraint) *ChronicleSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints = append(b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints, values[i])
+	}
+	return b
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *ChronicleSpecApplyConfiguration) WithPriorityClassName(value string) *ChronicleSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.PriorityClassName = &value
+	return b
+}
+
+// WithPodAnnotations puts the entries into the PodAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodAnnotations field,
+// overwriting an existing map entries in PodAnnotations field with the same key.
+func (b *ChronicleSpecApplyConfiguration) WithPodAnnotations(entries map[string]string) *ChronicleSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodAnnotations == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations[k] = v
+	}
+	return b
+}
+
+// WithPodLabels puts the entries into the PodLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodLabels field,
+// overwriting an existing map entries in PodLabels field with the same key.
+func (b *ChronicleSpecApplyConfiguration) WithPodLabels(entries map[string]string) *ChronicleSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodLabels == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels[k] = v
+	}
+	return b
+}
+
+// WithSecurityProfile sets the SecurityProfile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecurityProfile field is set to the value of the last call.
+func (b *ChronicleSpecApplyConfiguration) WithSecurityProfile(value corev1beta1.SecurityProfile) *ChronicleSpecApplyConfiguration {
+	b.SecurityProfile = &value
+	return b
+}
+
+// WithOverrides adds the given value to the Overrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Overrides field.
+func (b *ChronicleSpecApplyConfiguration) WithOverrides(values ...*OverrideApplyConfiguration) *ChronicleSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOverrides")
+		}
+		b.Overrides = append(b.Overrides, *values[i])
+	}
+	return b
+}
+
 // WithAddEnv puts the entries into the AddEnv field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the AddEnv field,
@@ -78,6 +173,22 @@ func (b *ChronicleSpecApplyConfiguration) WithImage(value string) *ChronicleSpec
 	return b
 }
 
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *ChronicleSpecApplyConfiguration) WithResources(value v1.ResourceRequirements) *ChronicleSpecApplyConfiguration {
+	b.Resources = &value
+	return b
+}
+
+// WithAgentResources sets the AgentResources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AgentResources field is set to the value of the last call.
+func (b *ChronicleSpecApplyConfiguration) WithAgentResources(value v1.ResourceRequirements) *ChronicleSpecApplyConfiguration {
+	b.AgentResources = &value
+	return b
+}
+
 // WithAwsAccountId sets the AwsAccountId field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the AwsAccountId field is set to the value of the last call.
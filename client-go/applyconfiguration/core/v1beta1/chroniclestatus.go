@@ -8,7 +8,8 @@ package v1beta1
 // ChronicleStatusApplyConfiguration represents a declarative configuration of the ChronicleStatus type for use
 // with apply.
 type ChronicleStatusApplyConfiguration struct {
-	Ready *bool `json:"ready,omitempty"`
+	Ready           *bool    `json:"ready,omitempty"`
+	FailedOverrides []string `json:"failedOverrides,omitempty"`
 }
 
 // ChronicleStatusApplyConfiguration constructs a declarative configuration of the ChronicleStatus type for use with
@@ -24,3 +25,13 @@ func (b *ChronicleStatusApplyConfiguration) WithReady(value bool) *ChronicleStat
 	b.Ready = &value
 	return b
 }
+
+// WithFailedOverrides adds the given value to the FailedOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FailedOverrides field.
+func (b *ChronicleStatusApplyConfiguration) WithFailedOverrides(values ...string) *ChronicleStatusApplyConfiguration {
+	for i := range values {
+		b.FailedOverrides = append(b.FailedOverrides, values[i])
+	}
+	return b
+}
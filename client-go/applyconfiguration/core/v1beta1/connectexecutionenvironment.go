@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// ConnectExecutionEnvironmentApplyConfiguration represents a declarative configuration of the ConnectExecutionEnvironment type for use
+// with apply.
+type ConnectExecutionEnvironmentApplyConfiguration struct {
+	Image  *string                                        `json:"image,omitempty"`
+	Tag    *string                                        `json:"tag,omitempty"`
+	R      []ConnectRuntimeInstallationApplyConfiguration `json:"r,omitempty"`
+	Python []ConnectRuntimeInstallationApplyConfiguration `json:"python,omitempty"`
+	Quarto []ConnectRuntimeInstallationApplyConfiguration `json:"quarto,omitempty"`
+}
+
+// ConnectExecutionEnvironmentApplyConfiguration constructs a declarative configuration of the ConnectExecutionEnvironment type for use with
+// apply.
+func ConnectExecutionEnvironment() *ConnectExecutionEnvironmentApplyConfiguration {
+	return &ConnectExecutionEnvironmentApplyConfiguration{}
+}
+
+// WithImage sets the Image field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Image field is set to the value of the last call.
+func (b *ConnectExecutionEnvironmentApplyConfiguration) WithImage(value string) *ConnectExecutionEnvironmentApplyConfiguration {
+	b.Image = &value
+	return b
+}
+
+// WithTag sets the Tag field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Tag field is set to the value of the last call.
+func (b *ConnectExecutionEnvironmentApplyConfiguration) WithTag(value string) *ConnectExecutionEnvironmentApplyConfiguration {
+	b.Tag = &value
+	return b
+}
+
+// WithR adds the given value to the R field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the R field.
+func (b *ConnectExecutionEnvironmentApplyConfiguration) WithR(values ...*ConnectRuntimeInstallationApplyConfiguration) *ConnectExecutionEnvironmentApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithR")
+		}
+		b.R = append(b.R, *values[i])
+	}
+	return b
+}
+
+// WithPython adds the given value to the Python field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Python field.
+func (b *ConnectExecutionEnvironmentApplyConfiguration) WithPython(values ...*ConnectRuntimeInstallationApplyConfiguration) *ConnectExecutionEnvironmentApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPython")
+		}
+		b.Python = append(b.Python, *values[i])
+	}
+	return b
+}
+
+// WithQuarto adds the given value to the Quarto field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Quarto field.
+func (b *ConnectExecutionEnvironmentApplyConfiguration) WithQuarto(values ...*ConnectRuntimeInstallationApplyConfiguration) *ConnectExecutionEnvironmentApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithQuarto")
+		}
+		b.Quarto = append(b.Quarto, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// ConnectRuntimeInstallationApplyConfiguration represents a declarative configuration of the ConnectRuntimeInstallation type for use
+// with apply.
+type ConnectRuntimeInstallationApplyConfiguration struct {
+	Version *string `json:"version,omitempty"`
+	Path    *string `json:"path,omitempty"`
+}
+
+// ConnectRuntimeInstallationApplyConfiguration constructs a declarative configuration of the ConnectRuntimeInstallation type for use with
+// apply.
+func ConnectRuntimeInstallation() *ConnectRuntimeInstallationApplyConfiguration {
+	return &ConnectRuntimeInstallationApplyConfiguration{}
+}
+
+// WithVersion sets the Version field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Version field is set to the value of the last call.
+func (b *ConnectRuntimeInstallationApplyConfiguration) WithVersion(value string) *ConnectRuntimeInstallationApplyConfiguration {
+	b.Version = &value
+	return b
+}
+
+// WithPath sets the Path field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Path field is set to the value of the last call.
+func (b *ConnectRuntimeInstallationApplyConfiguration) WithPath(value string) *ConnectRuntimeInstallationApplyConfiguration {
+	b.Path = &value
+	return b
+}
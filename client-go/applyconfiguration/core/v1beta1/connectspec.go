@@ -6,6 +6,7 @@
 package v1beta1
 
 import (
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
 	product "github.com/posit-dev/team-operator/api/product"
 	v1 "k8s.io/api/core/v1"
 )
@@ -13,36 +14,50 @@ import (
 // ConnectSpecApplyConfiguration represents a declarative configuration of the ConnectSpec type for use
 // with apply.
 type ConnectSpecApplyConfiguration struct {
-	License                              *product.LicenseSpec                      `json:"license,omitempty"`
-	Config                               *ConnectConfigApplyConfiguration          `json:"config,omitempty"`
-	SessionConfig                        *product.SessionConfig                    `json:"sessionConfig,omitempty"`
-	Volume                               *product.VolumeSpec                       `json:"volume,omitempty"`
-	SecretType                           *product.SiteSecretType                   `json:"secretType,omitempty"`
-	Auth                                 *AuthSpecApplyConfiguration               `json:"auth,omitempty"`
-	Url                                  *string                                   `json:"url,omitempty"`
-	DatabaseConfig                       *PostgresDatabaseConfigApplyConfiguration `json:"databaseConfig,omitempty"`
-	IngressClass                         *string                                   `json:"ingressClass,omitempty"`
-	IngressAnnotations                   map[string]string                         `json:"ingressAnnotations,omitempty"`
-	ImagePullSecrets                     []string                                  `json:"imagePullSecrets,omitempty"`
-	NodeSelector                         map[string]string                         `json:"nodeSelector,omitempty"`
-	AddEnv                               map[string]string                         `json:"addEnv,omitempty"`
-	OffHostExecution                     *bool                                     `json:"offHostExecution,omitempty"`
-	Image                                *string                                   `json:"image,omitempty"`
-	ImagePullPolicy                      *v1.PullPolicy                            `json:"imagePullPolicy,omitempty"`
-	Sleep                                *bool                                     `json:"sleep,omitempty"`
-	SessionImage                         *string                                   `json:"sessionImage,omitempty"`
-	AwsAccountId                         *string                                   `json:"awsAccountId,omitempty"`
-	ClusterDate                          *string                                   `json:"clusterDate,omitempty"`
-	WorkloadCompoundName                 *string                                   `json:"workloadCompoundName,omitempty"`
-	ChronicleAgentImage                  *string                                   `json:"chronicleImage,omitempty"`
-	AdditionalVolumes                    []product.VolumeSpec                      `json:"additionalVolumes,omitempty"`
-	Secret                               *SecretConfigApplyConfiguration           `json:"secret,omitempty"`
-	WorkloadSecret                       *SecretConfigApplyConfiguration           `json:"workloadSecret,omitempty"`
-	MainDatabaseCredentialSecret         *SecretConfigApplyConfiguration           `json:"mainDatabaseCredentialSecret,omitempty"`
-	Debug                                *bool                                     `json:"debug,omitempty"`
-	Replicas                             *int                                      `json:"replicas,omitempty"`
-	DsnSecret                            *string                                   `json:"dsnSecret,omitempty"`
-	ChronicleSidecarProductApiKeyEnabled *bool                                     `json:"chronicleSidecarProductApiKeyEnabled,omitempty"`
+	License                               *product.LicenseSpec                      `json:"license,omitempty"`
+	Config                                *ConnectConfigApplyConfiguration          `json:"config,omitempty"`
+	SessionConfig                         *product.SessionConfig                    `json:"sessionConfig,omitempty"`
+	Volume                                *product.VolumeSpec                       `json:"volume,omitempty"`
+	SecretType                            *product.SiteSecretType                   `json:"secretType,omitempty"`
+	Auth                                  *AuthSpecApplyConfiguration               `json:"auth,omitempty"`
+	Url                                   *string                                   `json:"url,omitempty"`
+	RootPath                              *string                                   `json:"rootPath,omitempty"`
+	Aliases                               []string                                  `json:"aliases,omitempty"`
+	TLSSecretName                         *string                                   `json:"tlsSecretName,omitempty"`
+	DatabaseConfig                        *PostgresDatabaseConfigApplyConfiguration `json:"databaseConfig,omitempty"`
+	IngressClass                          *string                                   `json:"ingressClass,omitempty"`
+	IngressAnnotations                    map[string]string                         `json:"ingressAnnotations,omitempty"`
+	Routing                               *RoutingConfigApplyConfiguration          `json:"routing,omitempty"`
+	IngressPolicy                         *IngressPolicyApplyConfiguration          `json:"ingressPolicy,omitempty"`
+	ImagePullSecrets                      []string                                  `json:"imagePullSecrets,omitempty"`
+	NodeSelector                          map[string]string                         `json:"nodeSelector,omitempty"`
+	PodSchedulingConfigApplyConfiguration `json:",inline"`
+	SecurityProfile                       *corev1beta1.SecurityProfile                    `json:"securityProfile,omitempty"`
+	Overrides                             []OverrideApplyConfiguration                    `json:"overrides,omitempty"`
+	AddEnv                                map[string]string                               `json:"addEnv,omitempty"`
+	OffHostExecution                      *bool                                           `json:"offHostExecution,omitempty"`
+	Image                                 *string                                         `json:"image,omitempty"`
+	ImagePullPolicy                       *v1.PullPolicy                                  `json:"imagePullPolicy,omitempty"`
+	Sleep                                 *bool                                           `json:"sleep,omitempty"`
+	SessionImage                          *string                                         `json:"sessionImage,omitempty"`
+	AwsAccountId                          *string                                         `json:"awsAccountId,omitempty"`
+	ClusterDate                           *string                                         `json:"clusterDate,omitempty"`
+	WorkloadCompoundName                  *string                                         `json:"workloadCompoundName,omitempty"`
+	ChronicleAgentImage                   *string                                         `json:"chronicleImage,omitempty"`
+	AdditionalVolumes                     []product.VolumeSpec                            `json:"additionalVolumes,omitempty"`
+	Secret                                *SecretConfigApplyConfiguration                 `json:"secret,omitempty"`
+	WorkloadSecret                        *SecretConfigApplyConfiguration                 `json:"workloadSecret,omitempty"`
+	MainDatabaseCredentialSecret          *SecretConfigApplyConfiguration                 `json:"mainDatabaseCredentialSecret,omitempty"`
+	Debug                                 *bool                                           `json:"debug,omitempty"`
+	Replicas                              *int                                            `json:"replicas,omitempty"`
+	Autoscaling                           *AutoscalingConfigApplyConfiguration            `json:"autoscaling,omitempty"`
+	SessionQuota                          *SessionQuotaApplyConfiguration                 `json:"sessionQuota,omitempty"`
+	ExecutionEnvironments                 []ConnectExecutionEnvironmentApplyConfiguration `json:"executionEnvironments,omitempty"`
+	ReplaceDefaultExecutionEnvironments   *bool                                           `json:"replaceDefaultExecutionEnvironments,omitempty"`
+	Resources                             *v1.ResourceRequirements                        `json:"resources,omitempty"`
+	ChronicleAgentResources               *v1.ResourceRequirements                        `json:"chronicleAgentResources,omitempty"`
+	DsnSecret                             *string                                         `json:"dsnSecret,omitempty"`
+	ChronicleSidecarProductApiKeyEnabled  *bool                                           `json:"chronicleSidecarProductApiKeyEnabled,omitempty"`
 }
 
 // ConnectSpecApplyConfiguration constructs a declarative configuration of the ConnectSpec type for use with
@@ -107,6 +122,32 @@ func (b *ConnectSpecApplyConfiguration) WithUrl(value string) *ConnectSpecApplyC
 	return b
 }
 
+// WithRootPath sets the RootPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RootPath field is set to the value of the last call.
+func (b *ConnectSpecApplyConfiguration) WithRootPath(value string) *ConnectSpecApplyConfiguration {
+	b.RootPath = &value
+	return b
+}
+
+// WithAliases adds the given value to the Aliases field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Aliases field.
+func (b *ConnectSpecApplyConfiguration) WithAliases(values ...string) *ConnectSpecApplyConfiguration {
+	for i := range values {
+		b.Aliases = append(b.Aliases, values[i])
+	}
+	return b
+}
+
+// WithTLSSecretName sets the TLSSecretName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TLSSecretName field is set to the value of the last call.
+func (b *ConnectSpecApplyConfiguration) WithTLSSecretName(value string) *ConnectSpecApplyConfiguration {
+	b.TLSSecretName = &value
+	return b
+}
+
 // WithDatabaseConfig sets the DatabaseConfig field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the DatabaseConfig field is set to the value of the last call.
@@ -137,6 +178,22 @@ func (b *ConnectSpecApplyConfiguration) WithIngressAnnotations(entries map[strin
 	return b
 }
 
+// WithRouting sets the Routing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Routing field is set to the value of the last call.
+func (b *ConnectSpecApplyConfiguration) WithRouting(value *RoutingConfigApplyConfiguration) *ConnectSpecApplyConfiguration {
+	b.Routing = value
+	return b
+}
+
+// WithIngressPolicy sets the IngressPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IngressPolicy field is set to the value of the last call.
+func (b *ConnectSpecApplyConfiguration) WithIngressPolicy(value *IngressPolicyApplyConfiguration) *ConnectSpecApplyConfiguration {
+	b.IngressPolicy = value
+	return b
+}
+
 // WithImagePullSecrets adds the given value to the ImagePullSecrets field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the ImagePullSecrets field.
@@ -161,6 +218,91 @@ func (b *ConnectSpecApplyConfiguration) WithNodeSelector(entries map[string]stri
 	return b
 }
 
+// WithAffinity sets the Affinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Affinity field is set to the value of the last call.
+func (b *ConnectSpecApplyConfiguration) WithAffinity(value v1.Affinity) *ConnectSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.Affinity = &value
+	return b
+}
+
+// WithTolerations adds the given value to the Tolerations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tolerations field.
+func (b *ConnectSpecApplyConfiguration) WithTolerations(values ...v1.Toleration) *ConnectSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.Tolerations = append(b.PodSchedulingConfigApplyConfiguration.Tolerations, values[i])
+	}
+	return b
+}
+
+// WithTopologySpreadConstraints adds the given value to the TopologySpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopologySpreadConstraints field.
+func (b *ConnectSpecApplyConfiguration) WithTopologySpreadConstraints(values ...v1.TopologySpreadConstraint) *ConnectSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints = append(b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints, values[i])
+	}
+	return b
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *ConnectSpecApplyConfiguration) WithPriorityClassName(value string) *ConnectSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.PriorityClassName = &value
+	return b
+}
+
+// WithPodAnnotations puts the entries into the PodAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodAnnotations field,
+// overwriting an existing map entries in PodAnnotations field with the same key.
+func (b *ConnectSpecApplyConfiguration) WithPodAnnotations(entries map[string]string) *ConnectSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodAnnotations == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations[k] = v
+	}
+	return b
+}
+
+// WithPodLabels puts the entries into the PodLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodLabels field,
+// overwriting an existing map entries in PodLabels field with the same key.
+func (b *ConnectSpecApplyConfiguration) WithPodLabels(entries map[string]string) *ConnectSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodLabels == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels[k] = v
+	}
+	return b
+}
+
+// WithSecurityProfile sets the SecurityProfile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecurityProfile field is set to the value of the last call.
+func (b *ConnectSpecApplyConfiguration) WithSecurityProfile(value corev1beta1.SecurityProfile) *ConnectSpecApplyConfiguration {
+	b.SecurityProfile = &value
+	return b
+}
+
+// WithOverrides adds the given value to the Overrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Overrides field.
+func (b *ConnectSpecApplyConfiguration) WithOverrides(values ...*OverrideApplyConfiguration) *ConnectSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOverrides")
+		}
+		b.Overrides = append(b.Overrides, *values[i])
+	}
+	return b
+}
+
 // WithAddEnv puts the entries into the AddEnv field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the AddEnv field,
@@ -297,6 +439,59 @@ func (b *ConnectSpecApplyConfiguration) WithReplicas(value int) *ConnectSpecAppl
 	return b
 }
 
+// WithAutoscaling sets the Autoscaling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Autoscaling field is set to the value of the last call.
+func (b *ConnectSpecApplyConfiguration) WithAutoscaling(value *AutoscalingConfigApplyConfiguration) *ConnectSpecApplyConfiguration {
+	b.Autoscaling = value
+	return b
+}
+
+// WithSessionQuota sets the SessionQuota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionQuota field is set to the value of the last call.
+func (b *ConnectSpecApplyConfiguration) WithSessionQuota(value *SessionQuotaApplyConfiguration) *ConnectSpecApplyConfiguration {
+	b.SessionQuota = value
+	return b
+}
+
+// WithExecutionEnvironments adds the given value to the ExecutionEnvironments field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ExecutionEnvironments field.
+func (b *ConnectSpecApplyConfiguration) WithExecutionEnvironments(values ...*ConnectExecutionEnvironmentApplyConfiguration) *ConnectSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithExecutionEnvironments")
+		}
+		b.ExecutionEnvironments = append(b.ExecutionEnvironments, *values[i])
+	}
+	return b
+}
+
+// WithReplaceDefaultExecutionEnvironments sets the ReplaceDefaultExecutionEnvironments field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReplaceDefaultExecutionEnvironments field is set to the value of the last call.
+func (b *ConnectSpecApplyConfiguration) WithReplaceDefaultExecutionEnvironments(value bool) *ConnectSpecApplyConfiguration {
+	b.ReplaceDefaultExecutionEnvironments = &value
+	return b
+}
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *ConnectSpecApplyConfiguration) WithResources(value v1.ResourceRequirements) *ConnectSpecApplyConfiguration {
+	b.Resources = &value
+	return b
+}
+
+// WithChronicleAgentResources sets the ChronicleAgentResources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ChronicleAgentResources field is set to the value of the last call.
+func (b *ConnectSpecApplyConfiguration) WithChronicleAgentResources(value v1.ResourceRequirements) *ConnectSpecApplyConfiguration {
+	b.ChronicleAgentResources = &value
+	return b
+}
+
 // WithDsnSecret sets the DsnSecret field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the DsnSecret field is set to the value of the last call.
@@ -12,8 +12,12 @@ import (
 // ConnectStatusApplyConfiguration represents a declarative configuration of the ConnectStatus type for use
 // with apply.
 type ConnectStatusApplyConfiguration struct {
-	KeySecretRef *v1.SecretReference `json:"keySecretRef,omitempty"`
-	Ready        *bool               `json:"ready,omitempty"`
+	KeySecretRef                    *v1.SecretReference                             `json:"keySecretRef,omitempty"`
+	Ready                           *bool                                           `json:"ready,omitempty"`
+	FailedOverrides                 []string                                        `json:"failedOverrides,omitempty"`
+	SessionQuota                    *SessionQuotaStatusApplyConfiguration           `json:"sessionQuota,omitempty"`
+	ExecutionEnvironmentWarnings    []string                                        `json:"executionEnvironmentWarnings,omitempty"`
+	DiscoveredExecutionEnvironments []ConnectExecutionEnvironmentApplyConfiguration `json:"discoveredExecutionEnvironments,omitempty"`
 }
 
 // ConnectStatusApplyConfiguration constructs a declarative configuration of the ConnectStatus type for use with
@@ -37,3 +41,44 @@ func (b *ConnectStatusApplyConfiguration) WithReady(value bool) *ConnectStatusAp
 	b.Ready = &value
 	return b
 }
+
+// WithFailedOverrides adds the given value to the FailedOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FailedOverrides field.
+func (b *ConnectStatusApplyConfiguration) WithFailedOverrides(values ...string) *ConnectStatusApplyConfiguration {
+	for i := range values {
+		b.FailedOverrides = append(b.FailedOverrides, values[i])
+	}
+	return b
+}
+
+// WithSessionQuota sets the SessionQuota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionQuota field is set to the value of the last call.
+func (b *ConnectStatusApplyConfiguration) WithSessionQuota(value *SessionQuotaStatusApplyConfiguration) *ConnectStatusApplyConfiguration {
+	b.SessionQuota = value
+	return b
+}
+
+// WithExecutionEnvironmentWarnings adds the given value to the ExecutionEnvironmentWarnings field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ExecutionEnvironmentWarnings field.
+func (b *ConnectStatusApplyConfiguration) WithExecutionEnvironmentWarnings(values ...string) *ConnectStatusApplyConfiguration {
+	for i := range values {
+		b.ExecutionEnvironmentWarnings = append(b.ExecutionEnvironmentWarnings, values[i])
+	}
+	return b
+}
+
+// WithDiscoveredExecutionEnvironments adds the given value to the DiscoveredExecutionEnvironments field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DiscoveredExecutionEnvironments field.
+func (b *ConnectStatusApplyConfiguration) WithDiscoveredExecutionEnvironments(values ...*ConnectExecutionEnvironmentApplyConfiguration) *ConnectStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDiscoveredExecutionEnvironments")
+		}
+		b.DiscoveredExecutionEnvironments = append(b.DiscoveredExecutionEnvironments, *values[i])
+	}
+	return b
+}
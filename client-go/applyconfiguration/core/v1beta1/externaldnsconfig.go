@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// ExternalDNSConfigApplyConfiguration represents a declarative configuration of the ExternalDNSConfig type for use
+// with apply.
+type ExternalDNSConfigApplyConfiguration struct {
+	Target *string `json:"target,omitempty"`
+	TTL    *int64  `json:"ttl,omitempty"`
+}
+
+// ExternalDNSConfigApplyConfiguration constructs a declarative configuration of the ExternalDNSConfig type for use with
+// apply.
+func ExternalDNSConfig() *ExternalDNSConfigApplyConfiguration {
+	return &ExternalDNSConfigApplyConfiguration{}
+}
+
+// WithTarget sets the Target field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Target field is set to the value of the last call.
+func (b *ExternalDNSConfigApplyConfiguration) WithTarget(value string) *ExternalDNSConfigApplyConfiguration {
+	b.Target = &value
+	return b
+}
+
+// WithTTL sets the TTL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TTL field is set to the value of the last call.
+func (b *ExternalDNSConfigApplyConfiguration) WithTTL(value int64) *ExternalDNSConfigApplyConfiguration {
+	b.TTL = &value
+	return b
+}
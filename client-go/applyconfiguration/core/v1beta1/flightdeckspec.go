@@ -6,27 +6,37 @@
 package v1beta1
 
 import (
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
 	v1 "k8s.io/api/core/v1"
 )
 
 // FlightdeckSpecApplyConfiguration represents a declarative configuration of the FlightdeckSpec type for use
 // with apply.
 type FlightdeckSpecApplyConfiguration struct {
-	SiteName             *string                                 `json:"siteName,omitempty"`
-	Image                *string                                 `json:"image,omitempty"`
-	ImagePullPolicy      *v1.PullPolicy                          `json:"imagePullPolicy,omitempty"`
-	Port                 *int32                                  `json:"port,omitempty"`
-	Replicas             *int                                    `json:"replicas,omitempty"`
-	FeatureEnabler       *FeatureEnablerConfigApplyConfiguration `json:"featureEnabler,omitempty"`
-	Domain               *string                                 `json:"domain,omitempty"`
-	IngressClass         *string                                 `json:"ingressClass,omitempty"`
-	IngressAnnotations   map[string]string                       `json:"ingressAnnotations,omitempty"`
-	ImagePullSecrets     []string                                `json:"imagePullSecrets,omitempty"`
-	AwsAccountId         *string                                 `json:"awsAccountId,omitempty"`
-	ClusterDate          *string                                 `json:"clusterDate,omitempty"`
-	WorkloadCompoundName *string                                 `json:"workloadCompoundName,omitempty"`
-	LogLevel             *string                                 `json:"logLevel,omitempty"`
-	LogFormat            *string                                 `json:"logFormat,omitempty"`
+	SiteName                              *string           `json:"siteName,omitempty"`
+	Image                                 *string           `json:"image,omitempty"`
+	ImagePullPolicy                       *v1.PullPolicy    `json:"imagePullPolicy,omitempty"`
+	NodeSelector                          map[string]string `json:"nodeSelector,omitempty"`
+	PodSchedulingConfigApplyConfiguration `json:",inline"`
+	SecurityProfile                       *corev1beta1.SecurityProfile            `json:"securityProfile,omitempty"`
+	Overrides                             []OverrideApplyConfiguration            `json:"overrides,omitempty"`
+	Port                                  *int32                                  `json:"port,omitempty"`
+	Replicas                              *int                                    `json:"replicas,omitempty"`
+	Autoscaling                           *AutoscalingConfigApplyConfiguration    `json:"autoscaling,omitempty"`
+	Resources                             *v1.ResourceRequirements                `json:"resources,omitempty"`
+	FeatureEnabler                        *FeatureEnablerConfigApplyConfiguration `json:"featureEnabler,omitempty"`
+	Domain                                *string                                 `json:"domain,omitempty"`
+	Aliases                               []string                                `json:"aliases,omitempty"`
+	TLSSecretName                         *string                                 `json:"tlsSecretName,omitempty"`
+	IngressClass                          *string                                 `json:"ingressClass,omitempty"`
+	IngressAnnotations                    map[string]string                       `json:"ingressAnnotations,omitempty"`
+	Routing                               *RoutingConfigApplyConfiguration        `json:"routing,omitempty"`
+	ImagePullSecrets                      []string                                `json:"imagePullSecrets,omitempty"`
+	AwsAccountId                          *string                                 `json:"awsAccountId,omitempty"`
+	ClusterDate                           *string                                 `json:"clusterDate,omitempty"`
+	WorkloadCompoundName                  *string                                 `json:"workloadCompoundName,omitempty"`
+	LogLevel                              *string                                 `json:"logLevel,omitempty"`
+	LogFormat                             *string                                 `json:"logFormat,omitempty"`
 }
 
 // FlightdeckSpecApplyConfiguration constructs a declarative configuration of the FlightdeckSpec type for use with
@@ -59,6 +69,105 @@ func (b *FlightdeckSpecApplyConfiguration) WithImagePullPolicy(value v1.PullPoli
 	return b
 }
 
+// WithNodeSelector puts the entries into the NodeSelector field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the NodeSelector field,
+// overwriting an existing map entries in NodeSelector field with the same key.
+func (b *FlightdeckSpecApplyConfiguration) WithNodeSelector(entries map[string]string) *FlightdeckSpecApplyConfiguration {
+	if b.NodeSelector == nil && len(entries) > 0 {
+		b.NodeSelector = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.NodeSelector[k] = v
+	}
+	return b
+}
+
+// WithAffinity sets the Affinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Affinity field is set to the value of the last call.
+func (b *FlightdeckSpecApplyConfiguration) WithAffinity(value v1.Affinity) *FlightdeckSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.Affinity = &value
+	return b
+}
+
+// WithTolerations adds the given value to the Tolerations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tolerations field.
+func (b *FlightdeckSpecApplyConfiguration) WithTolerations(values ...v1.Toleration) *FlightdeckSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.Tolerations = append(b.PodSchedulingConfigApplyConfiguration.Tolerations, values[i])
+	}
+	return b
+}
+
+// WithTopologySpreadConstraints adds the given value to the TopologySpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopologySpreadConstraints field.
+func (b *FlightdeckSpecApplyConfiguration) WithTopologySpreadConstraints(values ...v1.TopologySpreadConstraint) *FlightdeckSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints = append(b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints, values[i])
+	}
+	return b
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *FlightdeckSpecApplyConfiguration) WithPriorityClassName(value string) *FlightdeckSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.PriorityClassName = &value
+	return b
+}
+
+// WithPodAnnotations puts the entries into the PodAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodAnnotations field,
+// overwriting an existing map entries in PodAnnotations field with the same key.
+func (b *FlightdeckSpecApplyConfiguration) WithPodAnnotations(entries map[string]string) *FlightdeckSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodAnnotations == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations[k] = v
+	}
+	return b
+}
+
+// WithPodLabels puts the entries into the PodLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodLabels field,
+// overwriting an existing map entries in PodLabels field with the same key.
+func (b *FlightdeckSpecApplyConfiguration) WithPodLabels(entries map[string]string) *FlightdeckSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodLabels == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels[k] = v
+	}
+	return b
+}
+
+// WithSecurityProfile sets the SecurityProfile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecurityProfile field is set to the value of the last call.
+func (b *FlightdeckSpecApplyConfiguration) WithSecurityProfile(value corev1beta1.SecurityProfile) *FlightdeckSpecApplyConfiguration {
+	b.SecurityProfile = &value
+	return b
+}
+
+// WithOverrides adds the given value to the Overrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Overrides field.
+func (b *FlightdeckSpecApplyConfiguration) WithOverrides(values ...*OverrideApplyConfiguration) *FlightdeckSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOverrides")
+		}
+		b.Overrides = append(b.Overrides, *values[i])
+	}
+	return b
+}
+
 // WithPort sets the Port field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Port field is set to the value of the last call.
@@ -75,6 +184,22 @@ func (b *FlightdeckSpecApplyConfiguration) WithReplicas(value int) *FlightdeckSp
 	return b
 }
 
+// WithAutoscaling sets the Autoscaling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Autoscaling field is set to the value of the last call.
+func (b *FlightdeckSpecApplyConfiguration) WithAutoscaling(value *AutoscalingConfigApplyConfiguration) *FlightdeckSpecApplyConfiguration {
+	b.Autoscaling = value
+	return b
+}
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *FlightdeckSpecApplyConfiguration) WithResources(value v1.ResourceRequirements) *FlightdeckSpecApplyConfiguration {
+	b.Resources = &value
+	return b
+}
+
 // WithFeatureEnabler sets the FeatureEnabler field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the FeatureEnabler field is set to the value of the last call.
@@ -91,6 +216,24 @@ func (b *FlightdeckSpecApplyConfiguration) WithDomain(value string) *FlightdeckS
 	return b
 }
 
+// WithAliases adds the given value to the Aliases field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Aliases field.
+func (b *FlightdeckSpecApplyConfiguration) WithAliases(values ...string) *FlightdeckSpecApplyConfiguration {
+	for i := range values {
+		b.Aliases = append(b.Aliases, values[i])
+	}
+	return b
+}
+
+// WithTLSSecretName sets the TLSSecretName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TLSSecretName field is set to the value of the last call.
+func (b *FlightdeckSpecApplyConfiguration) WithTLSSecretName(value string) *FlightdeckSpecApplyConfiguration {
+	b.TLSSecretName = &value
+	return b
+}
+
 // WithIngressClass sets the IngressClass field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the IngressClass field is set to the value of the last call.
@@ -113,6 +256,14 @@ func (b *FlightdeckSpecApplyConfiguration) WithIngressAnnotations(entries map[st
 	return b
 }
 
+// WithRouting sets the Routing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Routing field is set to the value of the last call.
+func (b *FlightdeckSpecApplyConfiguration) WithRouting(value *RoutingConfigApplyConfiguration) *FlightdeckSpecApplyConfiguration {
+	b.Routing = value
+	return b
+}
+
 // WithImagePullSecrets adds the given value to the ImagePullSecrets field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the ImagePullSecrets field.
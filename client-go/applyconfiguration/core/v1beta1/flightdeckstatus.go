@@ -8,7 +8,8 @@ package v1beta1
 // FlightdeckStatusApplyConfiguration represents a declarative configuration of the FlightdeckStatus type for use
 // with apply.
 type FlightdeckStatusApplyConfiguration struct {
-	Ready *bool `json:"ready,omitempty"`
+	Ready           *bool    `json:"ready,omitempty"`
+	FailedOverrides []string `json:"failedOverrides,omitempty"`
 }
 
 // FlightdeckStatusApplyConfiguration constructs a declarative configuration of the FlightdeckStatus type for use with
@@ -24,3 +25,13 @@ func (b *FlightdeckStatusApplyConfiguration) WithReady(value bool) *FlightdeckSt
 	b.Ready = &value
 	return b
 }
+
+// WithFailedOverrides adds the given value to the FailedOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FailedOverrides field.
+func (b *FlightdeckStatusApplyConfiguration) WithFailedOverrides(values ...string) *FlightdeckStatusApplyConfiguration {
+	for i := range values {
+		b.FailedOverrides = append(b.FailedOverrides, values[i])
+	}
+	return b
+}
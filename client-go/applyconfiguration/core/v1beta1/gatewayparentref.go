@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// GatewayParentRefApplyConfiguration represents a declarative configuration of the GatewayParentRef type for use
+// with apply.
+type GatewayParentRefApplyConfiguration struct {
+	Name        *string `json:"name,omitempty"`
+	Namespace   *string `json:"namespace,omitempty"`
+	SectionName *string `json:"sectionName,omitempty"`
+}
+
+// GatewayParentRefApplyConfiguration constructs a declarative configuration of the GatewayParentRef type for use with
+// apply.
+func GatewayParentRef() *GatewayParentRefApplyConfiguration {
+	return &GatewayParentRefApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *GatewayParentRefApplyConfiguration) WithName(value string) *GatewayParentRefApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *GatewayParentRefApplyConfiguration) WithNamespace(value string) *GatewayParentRefApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithSectionName sets the SectionName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SectionName field is set to the value of the last call.
+func (b *GatewayParentRefApplyConfiguration) WithSectionName(value string) *GatewayParentRefApplyConfiguration {
+	b.SectionName = &value
+	return b
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// IngressIPAllowListApplyConfiguration represents a declarative configuration of the IngressIPAllowList type for use
+// with apply.
+type IngressIPAllowListApplyConfiguration struct {
+	SourceRanges []string `json:"sourceRanges,omitempty"`
+	Depth        *int     `json:"depth,omitempty"`
+}
+
+// IngressIPAllowListApplyConfiguration constructs a declarative configuration of the IngressIPAllowList type for use with
+// apply.
+func IngressIPAllowList() *IngressIPAllowListApplyConfiguration {
+	return &IngressIPAllowListApplyConfiguration{}
+}
+
+// WithSourceRanges adds the given value to the SourceRanges field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the SourceRanges field.
+func (b *IngressIPAllowListApplyConfiguration) WithSourceRanges(values ...string) *IngressIPAllowListApplyConfiguration {
+	for i := range values {
+		b.SourceRanges = append(b.SourceRanges, values[i])
+	}
+	return b
+}
+
+// WithDepth sets the Depth field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Depth field is set to the value of the last call.
+func (b *IngressIPAllowListApplyConfiguration) WithDepth(value int) *IngressIPAllowListApplyConfiguration {
+	b.Depth = &value
+	return b
+}
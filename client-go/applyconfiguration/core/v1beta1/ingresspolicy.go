@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// IngressPolicyApplyConfiguration represents a declarative configuration of the IngressPolicy type for use
+// with apply.
+type IngressPolicyApplyConfiguration struct {
+	RateLimit   *IngressRateLimitApplyConfiguration   `json:"rateLimit,omitempty"`
+	IPAllowList *IngressIPAllowListApplyConfiguration `json:"ipAllowList,omitempty"`
+	MaxBodySize *resource.Quantity                    `json:"maxBodySize,omitempty"`
+	Retry       *IngressRetryApplyConfiguration       `json:"retry,omitempty"`
+}
+
+// IngressPolicyApplyConfiguration constructs a declarative configuration of the IngressPolicy type for use with
+// apply.
+func IngressPolicy() *IngressPolicyApplyConfiguration {
+	return &IngressPolicyApplyConfiguration{}
+}
+
+// WithRateLimit sets the RateLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RateLimit field is set to the value of the last call.
+func (b *IngressPolicyApplyConfiguration) WithRateLimit(value *IngressRateLimitApplyConfiguration) *IngressPolicyApplyConfiguration {
+	b.RateLimit = value
+	return b
+}
+
+// WithIPAllowList sets the IPAllowList field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IPAllowList field is set to the value of the last call.
+func (b *IngressPolicyApplyConfiguration) WithIPAllowList(value *IngressIPAllowListApplyConfiguration) *IngressPolicyApplyConfiguration {
+	b.IPAllowList = value
+	return b
+}
+
+// WithMaxBodySize sets the MaxBodySize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxBodySize field is set to the value of the last call.
+func (b *IngressPolicyApplyConfiguration) WithMaxBodySize(value resource.Quantity) *IngressPolicyApplyConfiguration {
+	b.MaxBodySize = &value
+	return b
+}
+
+// WithRetry sets the Retry field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Retry field is set to the value of the last call.
+func (b *IngressPolicyApplyConfiguration) WithRetry(value *IngressRetryApplyConfiguration) *IngressPolicyApplyConfiguration {
+	b.Retry = value
+	return b
+}
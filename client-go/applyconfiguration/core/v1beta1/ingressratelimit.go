@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IngressRateLimitApplyConfiguration represents a declarative configuration of the IngressRateLimit type for use
+// with apply.
+type IngressRateLimitApplyConfiguration struct {
+	Average *int64       `json:"average,omitempty"`
+	Burst   *int64       `json:"burst,omitempty"`
+	Period  *v1.Duration `json:"period,omitempty"`
+}
+
+// IngressRateLimitApplyConfiguration constructs a declarative configuration of the IngressRateLimit type for use with
+// apply.
+func IngressRateLimit() *IngressRateLimitApplyConfiguration {
+	return &IngressRateLimitApplyConfiguration{}
+}
+
+// WithAverage sets the Average field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Average field is set to the value of the last call.
+func (b *IngressRateLimitApplyConfiguration) WithAverage(value int64) *IngressRateLimitApplyConfiguration {
+	b.Average = &value
+	return b
+}
+
+// WithBurst sets the Burst field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Burst field is set to the value of the last call.
+func (b *IngressRateLimitApplyConfiguration) WithBurst(value int64) *IngressRateLimitApplyConfiguration {
+	b.Burst = &value
+	return b
+}
+
+// WithPeriod sets the Period field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Period field is set to the value of the last call.
+func (b *IngressRateLimitApplyConfiguration) WithPeriod(value v1.Duration) *IngressRateLimitApplyConfiguration {
+	b.Period = &value
+	return b
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IngressRetryApplyConfiguration represents a declarative configuration of the IngressRetry type for use
+// with apply.
+type IngressRetryApplyConfiguration struct {
+	Attempts        *int         `json:"attempts,omitempty"`
+	InitialInterval *v1.Duration `json:"initialInterval,omitempty"`
+}
+
+// IngressRetryApplyConfiguration constructs a declarative configuration of the IngressRetry type for use with
+// apply.
+func IngressRetry() *IngressRetryApplyConfiguration {
+	return &IngressRetryApplyConfiguration{}
+}
+
+// WithAttempts sets the Attempts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Attempts field is set to the value of the last call.
+func (b *IngressRetryApplyConfiguration) WithAttempts(value int) *IngressRetryApplyConfiguration {
+	b.Attempts = &value
+	return b
+}
+
+// WithInitialInterval sets the InitialInterval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InitialInterval field is set to the value of the last call.
+func (b *IngressRetryApplyConfiguration) WithInitialInterval(value v1.Duration) *IngressRetryApplyConfiguration {
+	b.InitialInterval = &value
+	return b
+}
@@ -12,12 +12,15 @@ import (
 // InternalChronicleSpecApplyConfiguration represents a declarative configuration of the InternalChronicleSpec type for use
 // with apply.
 type InternalChronicleSpecApplyConfiguration struct {
-	NodeSelector    map[string]string `json:"nodeSelector,omitempty"`
-	Image           *string           `json:"image,omitempty"`
-	AddEnv          map[string]string `json:"addEnv,omitempty"`
-	ImagePullPolicy *v1.PullPolicy    `json:"imagePullPolicy,omitempty"`
-	S3Bucket        *string           `json:"s3Bucket,omitempty"`
-	AgentImage      *string           `json:"agentImage,omitempty"`
+	NodeSelector                          map[string]string `json:"nodeSelector,omitempty"`
+	PodSchedulingConfigApplyConfiguration `json:",inline"`
+	Image                                 *string                  `json:"image,omitempty"`
+	AddEnv                                map[string]string        `json:"addEnv,omitempty"`
+	ImagePullPolicy                       *v1.PullPolicy           `json:"imagePullPolicy,omitempty"`
+	S3Bucket                              *string                  `json:"s3Bucket,omitempty"`
+	AgentImage                            *string                  `json:"agentImage,omitempty"`
+	Resources                             *v1.ResourceRequirements `json:"resources,omitempty"`
+	AgentResources                        *v1.ResourceRequirements `json:"agentResources,omitempty"`
 }
 
 // InternalChronicleSpecApplyConfiguration constructs a declarative configuration of the InternalChronicleSpec type for use with
@@ -40,6 +43,70 @@ func (b *InternalChronicleSpecApplyConfiguration) WithNodeSelector(entries map[s
 	return b
 }
 
+// WithAffinity sets the Affinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Affinity field is set to the value of the last call.
+func (b *InternalChronicleSpecApplyConfiguration) WithAffinity(value v1.Affinity) *InternalChronicleSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.Affinity = &value
+	return b
+}
+
+// WithTolerations adds the given value to the Tolerations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tolerations field.
+func (b *InternalChronicleSpecApplyConfiguration) WithTolerations(values ...v1.Toleration) *InternalChronicleSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.Tolerations = append(b.PodSchedulingConfigApplyConfiguration.Tolerations, values[i])
+	}
+	return b
+}
+
+// WithTopologySpreadConstraints adds the given value to the TopologySpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopologySpreadConstraints field.
+func (b *InternalChronicleSpecApplyConfiguration) WithTopologySpreadConstraints(values ...v1.TopologySpreadConstraint) *InternalChronicleSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints = append(b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints, values[i])
+	}
+	return b
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *InternalChronicleSpecApplyConfiguration) WithPriorityClassName(value string) *InternalChronicleSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.PriorityClassName = &value
+	return b
+}
+
+// WithPodAnnotations puts the entries into the PodAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodAnnotations field,
+// overwriting an existing map entries in PodAnnotations field with the same key.
+func (b *InternalChronicleSpecApplyConfiguration) WithPodAnnotations(entries map[string]string) *InternalChronicleSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodAnnotations == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations[k] = v
+	}
+	return b
+}
+
+// WithPodLabels puts the entries into the PodLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodLabels field,
+// overwriting an existing map entries in PodLabels field with the same key.
+func (b *InternalChronicleSpecApplyConfiguration) WithPodLabels(entries map[string]string) *InternalChronicleSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodLabels == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels[k] = v
+	}
+	return b
+}
+
 // WithImage sets the Image field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Image field is set to the value of the last call.
@@ -85,3 +152,19 @@ func (b *InternalChronicleSpecApplyConfiguration) WithAgentImage(value string) *
 	b.AgentImage = &value
 	return b
 }
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *InternalChronicleSpecApplyConfiguration) WithResources(value v1.ResourceRequirements) *InternalChronicleSpecApplyConfiguration {
+	b.Resources = &value
+	return b
+}
+
+// WithAgentResources sets the AgentResources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AgentResources field is set to the value of the last call.
+func (b *InternalChronicleSpecApplyConfiguration) WithAgentResources(value v1.ResourceRequirements) *InternalChronicleSpecApplyConfiguration {
+	b.AgentResources = &value
+	return b
+}
@@ -13,23 +13,32 @@ import (
 // InternalConnectSpecApplyConfiguration represents a declarative configuration of the InternalConnectSpec type for use
 // with apply.
 type InternalConnectSpecApplyConfiguration struct {
-	License              *product.LicenseSpec                                   `json:"license,omitempty"`
-	Volume               *product.VolumeSpec                                    `json:"volume,omitempty"`
-	NodeSelector         map[string]string                                      `json:"nodeSelector,omitempty"`
-	Auth                 *AuthSpecApplyConfiguration                            `json:"auth,omitempty"`
-	AddEnv               map[string]string                                      `json:"addEnv,omitempty"`
-	Image                *string                                                `json:"image,omitempty"`
-	SessionImage         *string                                                `json:"sessionImage,omitempty"`
-	ImagePullPolicy      *v1.PullPolicy                                         `json:"imagePullPolicy,omitempty"`
-	Databricks           *DatabricksConfigApplyConfiguration                    `json:"databricks,omitempty"`
-	LoggedInWarning      *string                                                `json:"loggedInWarning,omitempty"`
-	PublicWarning        *string                                                `json:"publicWarning,omitempty"`
-	Replicas             *int                                                   `json:"replicas,omitempty"`
-	ExperimentalFeatures *InternalConnectExperimentalFeaturesApplyConfiguration `json:"experimentalFeatures,omitempty"`
-	DomainPrefix         *string                                                `json:"domainPrefix,omitempty"`
-	GPUSettings          *GPUSettingsApplyConfiguration                         `json:"gpuSettings,omitempty"`
-	DatabaseSettings     *DatabaseSettingsApplyConfiguration                    `json:"databaseSettings,omitempty"`
-	ScheduleConcurrency  *int                                                   `json:"scheduleConcurrency,omitempty"`
+	License                               *product.LicenseSpec `json:"license,omitempty"`
+	Volume                                *product.VolumeSpec  `json:"volume,omitempty"`
+	NodeSelector                          map[string]string    `json:"nodeSelector,omitempty"`
+	PodSchedulingConfigApplyConfiguration `json:",inline"`
+	Auth                                  *AuthSpecApplyConfiguration                            `json:"auth,omitempty"`
+	AddEnv                                map[string]string                                      `json:"addEnv,omitempty"`
+	Image                                 *string                                                `json:"image,omitempty"`
+	SessionImage                          *string                                                `json:"sessionImage,omitempty"`
+	ImagePullPolicy                       *v1.PullPolicy                                         `json:"imagePullPolicy,omitempty"`
+	Databricks                            *DatabricksConfigApplyConfiguration                    `json:"databricks,omitempty"`
+	LoggedInWarning                       *string                                                `json:"loggedInWarning,omitempty"`
+	PublicWarning                         *string                                                `json:"publicWarning,omitempty"`
+	Replicas                              *int                                                   `json:"replicas,omitempty"`
+	Autoscaling                           *AutoscalingConfigApplyConfiguration                   `json:"autoscaling,omitempty"`
+	SessionQuota                          *SessionQuotaApplyConfiguration                        `json:"sessionQuota,omitempty"`
+	ExecutionEnvironments                 []ConnectExecutionEnvironmentApplyConfiguration        `json:"executionEnvironments,omitempty"`
+	ReplaceDefaultExecutionEnvironments   *bool                                                  `json:"replaceDefaultExecutionEnvironments,omitempty"`
+	Resources                             *v1.ResourceRequirements                               `json:"resources,omitempty"`
+	ExperimentalFeatures                  *InternalConnectExperimentalFeaturesApplyConfiguration `json:"experimentalFeatures,omitempty"`
+	DomainPrefix                          *string                                                `json:"domainPrefix,omitempty"`
+	Hostnames                             []string                                               `json:"hostnames,omitempty"`
+	TLSSecretName                         *string                                                `json:"tlsSecretName,omitempty"`
+	IngressPolicy                         *IngressPolicyApplyConfiguration                       `json:"ingressPolicy,omitempty"`
+	GPUSettings                           *GPUSettingsApplyConfiguration                         `json:"gpuSettings,omitempty"`
+	DatabaseSettings                      *DatabaseSettingsApplyConfiguration                    `json:"databaseSettings,omitempty"`
+	ScheduleConcurrency                   *int                                                   `json:"scheduleConcurrency,omitempty"`
 }
 
 // InternalConnectSpecApplyConfiguration constructs a declarative configuration of the InternalConnectSpec type for use with
@@ -68,6 +77,70 @@ func (b *InternalConnectSpecApplyConfiguration) WithNodeSelector(entries map[str
 	return b
 }
 
+// WithAffinity sets the Affinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Affinity field is set to the value of the last call.
+func (b *InternalConnectSpecApplyConfiguration) WithAffinity(value v1.Affinity) *InternalConnectSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.Affinity = &value
+	return b
+}
+
+// WithTolerations adds the given value to the Tolerations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tolerations field.
+func (b *InternalConnectSpecApplyConfiguration) WithTolerations(values ...v1.Toleration) *InternalConnectSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.Tolerations = append(b.PodSchedulingConfigApplyConfiguration.Tolerations, values[i])
+	}
+	return b
+}
+
+// WithTopologySpreadConstraints adds the given value to the TopologySpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopologySpreadConstraints field.
+func (b *InternalConnectSpecApplyConfiguration) WithTopologySpreadConstraints(values ...v1.TopologySpreadConstraint) *InternalConnectSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints = append(b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints, values[i])
+	}
+	return b
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *InternalConnectSpecApplyConfiguration) WithPriorityClassName(value string) *InternalConnectSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.PriorityClassName = &value
+	return b
+}
+
+// WithPodAnnotations puts the entries into the PodAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodAnnotations field,
+// overwriting an existing map entries in PodAnnotations field with the same key.
+func (b *InternalConnectSpecApplyConfiguration) WithPodAnnotations(entries map[string]string) *InternalConnectSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodAnnotations == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations[k] = v
+	}
+	return b
+}
+
+// WithPodLabels puts the entries into the PodLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodLabels field,
+// overwriting an existing map entries in PodLabels field with the same key.
+func (b *InternalConnectSpecApplyConfiguration) WithPodLabels(entries map[string]string) *InternalConnectSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodLabels == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels[k] = v
+	}
+	return b
+}
+
 // WithAuth sets the Auth field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Auth field is set to the value of the last call.
@@ -146,6 +219,51 @@ func (b *InternalConnectSpecApplyConfiguration) WithReplicas(value int) *Interna
 	return b
 }
 
+// WithAutoscaling sets the Autoscaling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Autoscaling field is set to the value of the last call.
+func (b *InternalConnectSpecApplyConfiguration) WithAutoscaling(value *AutoscalingConfigApplyConfiguration) *InternalConnectSpecApplyConfiguration {
+	b.Autoscaling = value
+	return b
+}
+
+// WithSessionQuota sets the SessionQuota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionQuota field is set to the value of the last call.
+func (b *InternalConnectSpecApplyConfiguration) WithSessionQuota(value *SessionQuotaApplyConfiguration) *InternalConnectSpecApplyConfiguration {
+	b.SessionQuota = value
+	return b
+}
+
+// WithExecutionEnvironments adds the given value to the ExecutionEnvironments field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ExecutionEnvironments field.
+func (b *InternalConnectSpecApplyConfiguration) WithExecutionEnvironments(values ...*ConnectExecutionEnvironmentApplyConfiguration) *InternalConnectSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithExecutionEnvironments")
+		}
+		b.ExecutionEnvironments = append(b.ExecutionEnvironments, *values[i])
+	}
+	return b
+}
+
+// WithReplaceDefaultExecutionEnvironments sets the ReplaceDefaultExecutionEnvironments field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReplaceDefaultExecutionEnvironments field is set to the value of the last call.
+func (b *InternalConnectSpecApplyConfiguration) WithReplaceDefaultExecutionEnvironments(value bool) *InternalConnectSpecApplyConfiguration {
+	b.ReplaceDefaultExecutionEnvironments = &value
+	return b
+}
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *InternalConnectSpecApplyConfiguration) WithResources(value v1.ResourceRequirements) *InternalConnectSpecApplyConfiguration {
+	b.Resources = &value
+	return b
+}
+
 // WithExperimentalFeatures sets the ExperimentalFeatures field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ExperimentalFeatures field is set to the value of the last call.
@@ -162,6 +280,32 @@ func (b *InternalConnectSpecApplyConfiguration) WithDomainPrefix(value string) *
 	return b
 }
 
+// WithHostnames adds the given value to the Hostnames field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Hostnames field.
+func (b *InternalConnectSpecApplyConfiguration) WithHostnames(values ...string) *InternalConnectSpecApplyConfiguration {
+	for i := range values {
+		b.Hostnames = append(b.Hostnames, values[i])
+	}
+	return b
+}
+
+// WithTLSSecretName sets the TLSSecretName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TLSSecretName field is set to the value of the last call.
+func (b *InternalConnectSpecApplyConfiguration) WithTLSSecretName(value string) *InternalConnectSpecApplyConfiguration {
+	b.TLSSecretName = &value
+	return b
+}
+
+// WithIngressPolicy sets the IngressPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IngressPolicy field is set to the value of the last call.
+func (b *InternalConnectSpecApplyConfiguration) WithIngressPolicy(value *IngressPolicyApplyConfiguration) *InternalConnectSpecApplyConfiguration {
+	b.IngressPolicy = value
+	return b
+}
+
 // WithGPUSettings sets the GPUSettings field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the GPUSettings field is set to the value of the last call.
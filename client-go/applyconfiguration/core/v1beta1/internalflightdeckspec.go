@@ -12,13 +12,19 @@ import (
 // InternalFlightdeckSpecApplyConfiguration represents a declarative configuration of the InternalFlightdeckSpec type for use
 // with apply.
 type InternalFlightdeckSpecApplyConfiguration struct {
-	Enabled         *bool                                   `json:"enabled,omitempty"`
-	Image           *string                                 `json:"image,omitempty"`
-	ImagePullPolicy *v1.PullPolicy                          `json:"imagePullPolicy,omitempty"`
-	Replicas        *int                                    `json:"replicas,omitempty"`
-	FeatureEnabler  *FeatureEnablerConfigApplyConfiguration `json:"featureEnabler,omitempty"`
-	LogLevel        *string                                 `json:"logLevel,omitempty"`
-	LogFormat       *string                                 `json:"logFormat,omitempty"`
+	Enabled                               *bool             `json:"enabled,omitempty"`
+	Image                                 *string           `json:"image,omitempty"`
+	ImagePullPolicy                       *v1.PullPolicy    `json:"imagePullPolicy,omitempty"`
+	NodeSelector                          map[string]string `json:"nodeSelector,omitempty"`
+	PodSchedulingConfigApplyConfiguration `json:",inline"`
+	Replicas                              *int                                    `json:"replicas,omitempty"`
+	Autoscaling                           *AutoscalingConfigApplyConfiguration    `json:"autoscaling,omitempty"`
+	Resources                             *v1.ResourceRequirements                `json:"resources,omitempty"`
+	FeatureEnabler                        *FeatureEnablerConfigApplyConfiguration `json:"featureEnabler,omitempty"`
+	LogLevel                              *string                                 `json:"logLevel,omitempty"`
+	LogFormat                             *string                                 `json:"logFormat,omitempty"`
+	Hostnames                             []string                                `json:"hostnames,omitempty"`
+	TLSSecretName                         *string                                 `json:"tlsSecretName,omitempty"`
 }
 
 // InternalFlightdeckSpecApplyConfiguration constructs a declarative configuration of the InternalFlightdeckSpec type for use with
@@ -51,6 +57,84 @@ func (b *InternalFlightdeckSpecApplyConfiguration) WithImagePullPolicy(value v1.
 	return b
 }
 
+// WithNodeSelector puts the entries into the NodeSelector field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the NodeSelector field,
+// overwriting an existing map entries in NodeSelector field with the same key.
+func (b *InternalFlightdeckSpecApplyConfiguration) WithNodeSelector(entries map[string]string) *InternalFlightdeckSpecApplyConfiguration {
+	if b.NodeSelector == nil && len(entries) > 0 {
+		b.NodeSelector = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.NodeSelector[k] = v
+	}
+	return b
+}
+
+// WithAffinity sets the Affinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Affinity field is set to the value of the last call.
+func (b *InternalFlightdeckSpecApplyConfiguration) WithAffinity(value v1.Affinity) *InternalFlightdeckSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.Affinity = &value
+	return b
+}
+
+// WithTolerations adds the given value to the Tolerations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tolerations field.
+func (b *InternalFlightdeckSpecApplyConfiguration) WithTolerations(values ...v1.Toleration) *InternalFlightdeckSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.Tolerations = append(b.PodSchedulingConfigApplyConfiguration.Tolerations, values[i])
+	}
+	return b
+}
+
+// WithTopologySpreadConstraints adds the given value to the TopologySpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopologySpreadConstraints field.
+func (b *InternalFlightdeckSpecApplyConfiguration) WithTopologySpreadConstraints(values ...v1.TopologySpreadConstraint) *InternalFlightdeckSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints = append(b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints, values[i])
+	}
+	return b
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *InternalFlightdeckSpecApplyConfiguration) WithPriorityClassName(value string) *InternalFlightdeckSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.PriorityClassName = &value
+	return b
+}
+
+// WithPodAnnotations puts the entries into the PodAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodAnnotations field,
+// overwriting an existing map entries in PodAnnotations field with the same key.
+func (b *InternalFlightdeckSpecApplyConfiguration) WithPodAnnotations(entries map[string]string) *InternalFlightdeckSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodAnnotations == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations[k] = v
+	}
+	return b
+}
+
+// WithPodLabels puts the entries into the PodLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodLabels field,
+// overwriting an existing map entries in PodLabels field with the same key.
+func (b *InternalFlightdeckSpecApplyConfiguration) WithPodLabels(entries map[string]string) *InternalFlightdeckSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodLabels == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels[k] = v
+	}
+	return b
+}
+
 // WithReplicas sets the Replicas field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Replicas field is set to the value of the last call.
@@ -59,6 +143,22 @@ func (b *InternalFlightdeckSpecApplyConfiguration) WithReplicas(value int) *Inte
 	return b
 }
 
+// WithAutoscaling sets the Autoscaling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Autoscaling field is set to the value of the last call.
+func (b *InternalFlightdeckSpecApplyConfiguration) WithAutoscaling(value *AutoscalingConfigApplyConfiguration) *InternalFlightdeckSpecApplyConfiguration {
+	b.Autoscaling = value
+	return b
+}
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *InternalFlightdeckSpecApplyConfiguration) WithResources(value v1.ResourceRequirements) *InternalFlightdeckSpecApplyConfiguration {
+	b.Resources = &value
+	return b
+}
+
 // WithFeatureEnabler sets the FeatureEnabler field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the FeatureEnabler field is set to the value of the last call.
@@ -82,3 +182,21 @@ func (b *InternalFlightdeckSpecApplyConfiguration) WithLogFormat(value string) *
 	b.LogFormat = &value
 	return b
 }
+
+// WithHostnames adds the given value to the Hostnames field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Hostnames field.
+func (b *InternalFlightdeckSpecApplyConfiguration) WithHostnames(values ...string) *InternalFlightdeckSpecApplyConfiguration {
+	for i := range values {
+		b.Hostnames = append(b.Hostnames, values[i])
+	}
+	return b
+}
+
+// WithTLSSecretName sets the TLSSecretName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TLSSecretName field is set to the value of the last call.
+func (b *InternalFlightdeckSpecApplyConfiguration) WithTLSSecretName(value string) *InternalFlightdeckSpecApplyConfiguration {
+	b.TLSSecretName = &value
+	return b
+}
@@ -12,10 +12,13 @@ import (
 // InternalKeycloakSpecApplyConfiguration represents a declarative configuration of the InternalKeycloakSpec type for use
 // with apply.
 type InternalKeycloakSpecApplyConfiguration struct {
-	Enabled         *bool             `json:"enabled,omitempty"`
-	Image           *string           `json:"image,omitempty"`
-	ImagePullPolicy *v1.PullPolicy    `json:"imagePullPolicy,omitempty"`
-	NodeSelector    map[string]string `json:"nodeSelector,omitempty"`
+	Enabled                               *bool             `json:"enabled,omitempty"`
+	Image                                 *string           `json:"image,omitempty"`
+	ImagePullPolicy                       *v1.PullPolicy    `json:"imagePullPolicy,omitempty"`
+	NodeSelector                          map[string]string `json:"nodeSelector,omitempty"`
+	Hostnames                             []string          `json:"hostnames,omitempty"`
+	TLSSecretName                         *string           `json:"tlsSecretName,omitempty"`
+	PodSchedulingConfigApplyConfiguration `json:",inline"`
 }
 
 // InternalKeycloakSpecApplyConfiguration constructs a declarative configuration of the InternalKeycloakSpec type for use with
@@ -61,3 +64,85 @@ func (b *InternalKeycloakSpecApplyConfiguration) WithNodeSelector(entries map[st
 	}
 	return b
 }
+
+// WithHostnames adds the given value to the Hostnames field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Hostnames field.
+func (b *InternalKeycloakSpecApplyConfiguration) WithHostnames(values ...string) *InternalKeycloakSpecApplyConfiguration {
+	for i := range values {
+		b.Hostnames = append(b.Hostnames, values[i])
+	}
+	return b
+}
+
+// WithTLSSecretName sets the TLSSecretName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TLSSecretName field is set to the value of the last call.
+func (b *InternalKeycloakSpecApplyConfiguration) WithTLSSecretName(value string) *InternalKeycloakSpecApplyConfiguration {
+	b.TLSSecretName = &value
+	return b
+}
+
+// WithAffinity sets the Affinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Affinity field is set to the value of the last call.
+func (b *InternalKeycloakSpecApplyConfiguration) WithAffinity(value v1.Affinity) *InternalKeycloakSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.Affinity = &value
+	return b
+}
+
+// WithTolerations adds the given value to the Tolerations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tolerations field.
+func (b *InternalKeycloakSpecApplyConfiguration) WithTolerations(values ...v1.Toleration) *InternalKeycloakSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.Tolerations = append(b.PodSchedulingConfigApplyConfiguration.Tolerations, values[i])
+	}
+	return b
+}
+
+// WithTopologySpreadConstraints adds the given value to the TopologySpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopologySpreadConstraints field.
+func (b *InternalKeycloakSpecApplyConfiguration) WithTopologySpreadConstraints(values ...v1.TopologySpreadConstraint) *InternalKeycloakSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints = append(b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints, values[i])
+	}
+	return b
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *InternalKeycloakSpecApplyConfiguration) WithPriorityClassName(value string) *InternalKeycloakSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.PriorityClassName = &value
+	return b
+}
+
+// WithPodAnnotations puts the entries into the PodAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodAnnotations field,
+// overwriting an existing map entries in PodAnnotations field with the same key.
+func (b *InternalKeycloakSpecApplyConfiguration) WithPodAnnotations(entries map[string]string) *InternalKeycloakSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodAnnotations == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations[k] = v
+	}
+	return b
+}
+
+// WithPodLabels puts the entries into the PodLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodLabels field,
+// overwriting an existing map entries in PodLabels field with the same key.
+func (b *InternalKeycloakSpecApplyConfiguration) WithPodLabels(entries map[string]string) *InternalKeycloakSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodLabels == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels[k] = v
+	}
+	return b
+}
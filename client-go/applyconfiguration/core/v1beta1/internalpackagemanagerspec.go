@@ -13,17 +13,23 @@ import (
 // InternalPackageManagerSpecApplyConfiguration represents a declarative configuration of the InternalPackageManagerSpec type for use
 // with apply.
 type InternalPackageManagerSpecApplyConfiguration struct {
-	License         *product.LicenseSpec                `json:"license,omitempty"`
-	Volume          *product.VolumeSpec                 `json:"volume,omitempty"`
-	NodeSelector    map[string]string                   `json:"nodeSelector,omitempty"`
-	AddEnv          map[string]string                   `json:"addEnv,omitempty"`
-	Image           *string                             `json:"image,omitempty"`
-	ImagePullPolicy *v1.PullPolicy                      `json:"imagePullPolicy,omitempty"`
-	S3Bucket        *string                             `json:"s3Bucket,omitempty"`
-	Replicas        *int                                `json:"replicas,omitempty"`
-	DomainPrefix    *string                             `json:"domainPrefix,omitempty"`
-	GitSSHKeys      []SSHKeyConfigApplyConfiguration    `json:"gitSSHKeys,omitempty"`
-	AzureFiles      *AzureFilesConfigApplyConfiguration `json:"azureFiles,omitempty"`
+	License                               *product.LicenseSpec `json:"license,omitempty"`
+	Volume                                *product.VolumeSpec  `json:"volume,omitempty"`
+	NodeSelector                          map[string]string    `json:"nodeSelector,omitempty"`
+	PodSchedulingConfigApplyConfiguration `json:",inline"`
+	AddEnv                                map[string]string                    `json:"addEnv,omitempty"`
+	Image                                 *string                              `json:"image,omitempty"`
+	ImagePullPolicy                       *v1.PullPolicy                       `json:"imagePullPolicy,omitempty"`
+	S3Bucket                              *string                              `json:"s3Bucket,omitempty"`
+	Replicas                              *int                                 `json:"replicas,omitempty"`
+	Autoscaling                           *AutoscalingConfigApplyConfiguration `json:"autoscaling,omitempty"`
+	Resources                             *v1.ResourceRequirements             `json:"resources,omitempty"`
+	DomainPrefix                          *string                              `json:"domainPrefix,omitempty"`
+	Hostnames                             []string                             `json:"hostnames,omitempty"`
+	TLSSecretName                         *string                              `json:"tlsSecretName,omitempty"`
+	IngressPolicy                         *IngressPolicyApplyConfiguration     `json:"ingressPolicy,omitempty"`
+	GitSSHKeys                            []SSHKeyConfigApplyConfiguration     `json:"gitSSHKeys,omitempty"`
+	AzureFiles                            *AzureFilesConfigApplyConfiguration  `json:"azureFiles,omitempty"`
 }
 
 // InternalPackageManagerSpecApplyConfiguration constructs a declarative configuration of the InternalPackageManagerSpec type for use with
@@ -62,6 +68,70 @@ func (b *InternalPackageManagerSpecApplyConfiguration) WithNodeSelector(entries
 	return b
 }
 
+// WithAffinity sets the Affinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Affinity field is set to the value of the last call.
+func (b *InternalPackageManagerSpecApplyConfiguration) WithAffinity(value v1.Affinity) *InternalPackageManagerSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.Affinity = &value
+	return b
+}
+
+// WithTolerations adds the given value to the Tolerations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tolerations field.
+func (b *InternalPackageManagerSpecApplyConfiguration) WithTolerations(values ...v1.Toleration) *InternalPackageManagerSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.Tolerations = append(b.PodSchedulingConfigApplyConfiguration.Tolerations, values[i])
+	}
+	return b
+}
+
+// WithTopologySpreadConstraints adds the given value to the TopologySpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopologySpreadConstraints field.
+func (b *InternalPackageManagerSpecApplyConfiguration) WithTopologySpreadConstraints(values ...v1.TopologySpreadConstraint) *InternalPackageManagerSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints = append(b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints, values[i])
+	}
+	return b
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *InternalPackageManagerSpecApplyConfiguration) WithPriorityClassName(value string) *InternalPackageManagerSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.PriorityClassName = &value
+	return b
+}
+
+// WithPodAnnotations puts the entries into the PodAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodAnnotations field,
+// overwriting an existing map entries in PodAnnotations field with the same key.
+func (b *InternalPackageManagerSpecApplyConfiguration) WithPodAnnotations(entries map[string]string) *InternalPackageManagerSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodAnnotations == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations[k] = v
+	}
+	return b
+}
+
+// WithPodLabels puts the entries into the PodLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodLabels field,
+// overwriting an existing map entries in PodLabels field with the same key.
+func (b *InternalPackageManagerSpecApplyConfiguration) WithPodLabels(entries map[string]string) *InternalPackageManagerSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodLabels == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels[k] = v
+	}
+	return b
+}
+
 // WithAddEnv puts the entries into the AddEnv field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the AddEnv field,
@@ -108,6 +178,22 @@ func (b *InternalPackageManagerSpecApplyConfiguration) WithReplicas(value int) *
 	return b
 }
 
+// WithAutoscaling sets the Autoscaling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Autoscaling field is set to the value of the last call.
+func (b *InternalPackageManagerSpecApplyConfiguration) WithAutoscaling(value *AutoscalingConfigApplyConfiguration) *InternalPackageManagerSpecApplyConfiguration {
+	b.Autoscaling = value
+	return b
+}
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *InternalPackageManagerSpecApplyConfiguration) WithResources(value v1.ResourceRequirements) *InternalPackageManagerSpecApplyConfiguration {
+	b.Resources = &value
+	return b
+}
+
 // WithDomainPrefix sets the DomainPrefix field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the DomainPrefix field is set to the value of the last call.
@@ -116,6 +202,32 @@ func (b *InternalPackageManagerSpecApplyConfiguration) WithDomainPrefix(value st
 	return b
 }
 
+// WithHostnames adds the given value to the Hostnames field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Hostnames field.
+func (b *InternalPackageManagerSpecApplyConfiguration) WithHostnames(values ...string) *InternalPackageManagerSpecApplyConfiguration {
+	for i := range values {
+		b.Hostnames = append(b.Hostnames, values[i])
+	}
+	return b
+}
+
+// WithTLSSecretName sets the TLSSecretName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TLSSecretName field is set to the value of the last call.
+func (b *InternalPackageManagerSpecApplyConfiguration) WithTLSSecretName(value string) *InternalPackageManagerSpecApplyConfiguration {
+	b.TLSSecretName = &value
+	return b
+}
+
+// WithIngressPolicy sets the IngressPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IngressPolicy field is set to the value of the last call.
+func (b *InternalPackageManagerSpecApplyConfiguration) WithIngressPolicy(value *IngressPolicyApplyConfiguration) *InternalPackageManagerSpecApplyConfiguration {
+	b.IngressPolicy = value
+	return b
+}
+
 // WithGitSSHKeys adds the given value to the GitSSHKeys field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the GitSSHKeys field.
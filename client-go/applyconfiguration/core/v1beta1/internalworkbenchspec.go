@@ -9,40 +9,55 @@ import (
 	product "github.com/posit-dev/team-operator/api/product"
 	v1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
 // InternalWorkbenchSpecApplyConfiguration represents a declarative configuration of the InternalWorkbenchSpec type for use
 // with apply.
 type InternalWorkbenchSpecApplyConfiguration struct {
-	Databricks                    map[string]DatabricksConfigApplyConfiguration            `json:"databricks,omitempty"`
-	Snowflake                     *SnowflakeConfigApplyConfiguration                       `json:"snowflake,omitempty"`
-	License                       *product.LicenseSpec                                     `json:"license,omitempty"`
-	Volume                        *product.VolumeSpec                                      `json:"volume,omitempty"`
-	AdditionalVolumes             []product.VolumeSpec                                     `json:"additionalVolumes,omitempty"`
-	NodeSelector                  map[string]string                                        `json:"nodeSelector,omitempty"`
-	Tolerations                   []v1.Toleration                                          `json:"tolerations,omitempty"`
-	SessionTolerations            []v1.Toleration                                          `json:"sessionTolerations,omitempty"`
-	CreateUsersAutomatically      *bool                                                    `json:"createUsersAutomatically,omitempty"`
-	AdminGroups                   []string                                                 `json:"adminGroups,omitempty"`
-	AdminSuperuserGroups          []string                                                 `json:"adminSuperuserGroups,omitempty"`
-	AddEnv                        map[string]string                                        `json:"addEnv,omitempty"`
-	Auth                          *AuthSpecApplyConfiguration                              `json:"auth,omitempty"`
-	Image                         *string                                                  `json:"image,omitempty"`
-	ImagePullPolicy               *v1.PullPolicy                                           `json:"imagePullPolicy,omitempty"`
-	DefaultSessionImage           *string                                                  `json:"defaultSessionImage,omitempty"`
-	ExtraSessionImages            []string                                                 `json:"extraSessionImages,omitempty"`
-	SessionInitContainerImageName *string                                                  `json:"sessionInitContainerImageName,omitempty"`
-	SessionInitContainerImageTag  *string                                                  `json:"sessionInitContainerImageTag,omitempty"`
-	Replicas                      *int                                                     `json:"replicas,omitempty"`
-	ExperimentalFeatures          *InternalWorkbenchExperimentalFeaturesApplyConfiguration `json:"experimentalFeatures,omitempty"`
-	VsCodeExtensions              []string                                                 `json:"vsCodeExtensions,omitempty"`
-	VsCodeUserSettings            map[string]*apiextensionsv1.JSON                         `json:"vsCodeUserSettings,omitempty"`
-	PositronSettings              *PositronConfigApplyConfiguration                        `json:"positronConfig,omitempty"`
-	VSCodeSettings                *VSCodeConfigApplyConfiguration                          `json:"vsCodeConfig,omitempty"`
-	ApiSettings                   *ApiSettingsConfigApplyConfiguration                     `json:"apiSettings,omitempty"`
-	DomainPrefix                  *string                                                  `json:"domainPrefix,omitempty"`
-	AuthLoginPageHtml             *string                                                  `json:"authLoginPageHtml,omitempty"`
-	JupyterConfig                 *WorkbenchJupyterConfigApplyConfiguration                `json:"jupyterConfig,omitempty"`
+	Databricks                            map[string]DatabricksConfigApplyConfiguration `json:"databricks,omitempty"`
+	Snowflake                             *SnowflakeConfigApplyConfiguration            `json:"snowflake,omitempty"`
+	License                               *product.LicenseSpec                          `json:"license,omitempty"`
+	Volume                                *product.VolumeSpec                           `json:"volume,omitempty"`
+	AdditionalVolumes                     []product.VolumeSpec                          `json:"additionalVolumes,omitempty"`
+	NodeSelector                          map[string]string                             `json:"nodeSelector,omitempty"`
+	PodSchedulingConfigApplyConfiguration `json:",inline"`
+	SessionTolerations                    []v1.Toleration                                          `json:"sessionTolerations,omitempty"`
+	CreateUsersAutomatically              *bool                                                    `json:"createUsersAutomatically,omitempty"`
+	AdminGroups                           []string                                                 `json:"adminGroups,omitempty"`
+	AdminSuperuserGroups                  []string                                                 `json:"adminSuperuserGroups,omitempty"`
+	AddEnv                                map[string]string                                        `json:"addEnv,omitempty"`
+	Auth                                  *AuthSpecApplyConfiguration                              `json:"auth,omitempty"`
+	Image                                 *string                                                  `json:"image,omitempty"`
+	ImagePullPolicy                       *v1.PullPolicy                                           `json:"imagePullPolicy,omitempty"`
+	DefaultSessionImage                   *string                                                  `json:"defaultSessionImage,omitempty"`
+	ExtraSessionImages                    []string                                                 `json:"extraSessionImages,omitempty"`
+	Profiles                              []WorkbenchLauncherProfileApplyConfiguration             `json:"profiles,omitempty"`
+	SessionImageSelector                  *metav1.LabelSelectorApplyConfiguration                  `json:"sessionImageSelector,omitempty"`
+	SessionLifecycle                      *WorkbenchSessionLifecycleApplyConfiguration             `json:"sessionLifecycle,omitempty"`
+	UserSelector                          *metav1.LabelSelectorApplyConfiguration                  `json:"userSelector,omitempty"`
+	LauncherTemplates                     *WorkbenchLauncherTemplatesApplyConfiguration            `json:"launcherTemplates,omitempty"`
+	Repositories                          []WorkbenchRepositoryApplyConfiguration                  `json:"repositories,omitempty"`
+	SessionNamespace                      *string                                                  `json:"sessionNamespace,omitempty"`
+	SessionInitContainerImageName         *string                                                  `json:"sessionInitContainerImageName,omitempty"`
+	SessionInitContainerImageTag          *string                                                  `json:"sessionInitContainerImageTag,omitempty"`
+	Replicas                              *int                                                     `json:"replicas,omitempty"`
+	Autoscaling                           *AutoscalingConfigApplyConfiguration                     `json:"autoscaling,omitempty"`
+	SessionQuota                          *SessionQuotaApplyConfiguration                          `json:"sessionQuota,omitempty"`
+	Resources                             *v1.ResourceRequirements                                 `json:"resources,omitempty"`
+	ExperimentalFeatures                  *InternalWorkbenchExperimentalFeaturesApplyConfiguration `json:"experimentalFeatures,omitempty"`
+	VsCodeExtensions                      []string                                                 `json:"vsCodeExtensions,omitempty"`
+	ExtensionCache                        *WorkbenchExtensionCacheConfigApplyConfiguration         `json:"extensionCache,omitempty"`
+	VsCodeUserSettings                    map[string]*apiextensionsv1.JSON                         `json:"vsCodeUserSettings,omitempty"`
+	PositronSettings                      *PositronConfigApplyConfiguration                        `json:"positronConfig,omitempty"`
+	VSCodeSettings                        *VSCodeConfigApplyConfiguration                          `json:"vsCodeConfig,omitempty"`
+	ApiSettings                           *ApiSettingsConfigApplyConfiguration                     `json:"apiSettings,omitempty"`
+	DomainPrefix                          *string                                                  `json:"domainPrefix,omitempty"`
+	Hostnames                             []string                                                 `json:"hostnames,omitempty"`
+	TLSSecretName                         *string                                                  `json:"tlsSecretName,omitempty"`
+	IngressPolicy                         *IngressPolicyApplyConfiguration                         `json:"ingressPolicy,omitempty"`
+	AuthLoginPageHtml                     *string                                                  `json:"authLoginPageHtml,omitempty"`
+	JupyterConfig                         *WorkbenchJupyterConfigApplyConfiguration                `json:"jupyterConfig,omitempty"`
 }
 
 // InternalWorkbenchSpecApplyConfiguration constructs a declarative configuration of the InternalWorkbenchSpec type for use with
@@ -113,12 +128,66 @@ func (b *InternalWorkbenchSpecApplyConfiguration) WithNodeSelector(entries map[s
 	return b
 }
 
+// WithAffinity sets the Affinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Affinity field is set to the value of the last call.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithAffinity(value v1.Affinity) *InternalWorkbenchSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.Affinity = &value
+	return b
+}
+
 // WithTolerations adds the given value to the Tolerations field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Tolerations field.
 func (b *InternalWorkbenchSpecApplyConfiguration) WithTolerations(values ...v1.Toleration) *InternalWorkbenchSpecApplyConfiguration {
 	for i := range values {
-		b.Tolerations = append(b.Tolerations, values[i])
+		b.PodSchedulingConfigApplyConfiguration.Tolerations = append(b.PodSchedulingConfigApplyConfiguration.Tolerations, values[i])
+	}
+	return b
+}
+
+// WithTopologySpreadConstraints adds the given value to the TopologySpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopologySpreadConstraints field.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithTopologySpreadConstraints(values ...v1.TopologySpreadConstraint) *InternalWorkbenchSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints = append(b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints, values[i])
+	}
+	return b
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithPriorityClassName(value string) *InternalWorkbenchSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.PriorityClassName = &value
+	return b
+}
+
+// WithPodAnnotations puts the entries into the PodAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodAnnotations field,
+// overwriting an existing map entries in PodAnnotations field with the same key.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithPodAnnotations(entries map[string]string) *InternalWorkbenchSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodAnnotations == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations[k] = v
+	}
+	return b
+}
+
+// WithPodLabels puts the entries into the PodLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodLabels field,
+// overwriting an existing map entries in PodLabels field with the same key.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithPodLabels(entries map[string]string) *InternalWorkbenchSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodLabels == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels[k] = v
 	}
 	return b
 }
@@ -217,6 +286,72 @@ func (b *InternalWorkbenchSpecApplyConfiguration) WithExtraSessionImages(values
 	return b
 }
 
+// WithProfiles adds the given value to the Profiles field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Profiles field.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithProfiles(values ...*WorkbenchLauncherProfileApplyConfiguration) *InternalWorkbenchSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithProfiles")
+		}
+		b.Profiles = append(b.Profiles, *values[i])
+	}
+	return b
+}
+
+// WithSessionImageSelector sets the SessionImageSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionImageSelector field is set to the value of the last call.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithSessionImageSelector(value *metav1.LabelSelectorApplyConfiguration) *InternalWorkbenchSpecApplyConfiguration {
+	b.SessionImageSelector = value
+	return b
+}
+
+// WithSessionLifecycle sets the SessionLifecycle field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionLifecycle field is set to the value of the last call.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithSessionLifecycle(value *WorkbenchSessionLifecycleApplyConfiguration) *InternalWorkbenchSpecApplyConfiguration {
+	b.SessionLifecycle = value
+	return b
+}
+
+// WithUserSelector sets the UserSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UserSelector field is set to the value of the last call.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithUserSelector(value *metav1.LabelSelectorApplyConfiguration) *InternalWorkbenchSpecApplyConfiguration {
+	b.UserSelector = value
+	return b
+}
+
+// WithLauncherTemplates sets the LauncherTemplates field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LauncherTemplates field is set to the value of the last call.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithLauncherTemplates(value *WorkbenchLauncherTemplatesApplyConfiguration) *InternalWorkbenchSpecApplyConfiguration {
+	b.LauncherTemplates = value
+	return b
+}
+
+// WithRepositories adds the given value to the Repositories field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Repositories field.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithRepositories(values ...*WorkbenchRepositoryApplyConfiguration) *InternalWorkbenchSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithRepositories")
+		}
+		b.Repositories = append(b.Repositories, *values[i])
+	}
+	return b
+}
+
+// WithSessionNamespace sets the SessionNamespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionNamespace field is set to the value of the last call.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithSessionNamespace(value string) *InternalWorkbenchSpecApplyConfiguration {
+	b.SessionNamespace = &value
+	return b
+}
+
 // WithSessionInitContainerImageName sets the SessionInitContainerImageName field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the SessionInitContainerImageName field is set to the value of the last call.
@@ -241,6 +376,30 @@ func (b *InternalWorkbenchSpecApplyConfiguration) WithReplicas(value int) *Inter
 	return b
 }
 
+// WithAutoscaling sets the Autoscaling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Autoscaling field is set to the value of the last call.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithAutoscaling(value *AutoscalingConfigApplyConfiguration) *InternalWorkbenchSpecApplyConfiguration {
+	b.Autoscaling = value
+	return b
+}
+
+// WithSessionQuota sets the SessionQuota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionQuota field is set to the value of the last call.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithSessionQuota(value *SessionQuotaApplyConfiguration) *InternalWorkbenchSpecApplyConfiguration {
+	b.SessionQuota = value
+	return b
+}
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithResources(value v1.ResourceRequirements) *InternalWorkbenchSpecApplyConfiguration {
+	b.Resources = &value
+	return b
+}
+
 // WithExperimentalFeatures sets the ExperimentalFeatures field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ExperimentalFeatures field is set to the value of the last call.
@@ -259,6 +418,14 @@ func (b *InternalWorkbenchSpecApplyConfiguration) WithVsCodeExtensions(values ..
 	return b
 }
 
+// WithExtensionCache sets the ExtensionCache field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExtensionCache field is set to the value of the last call.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithExtensionCache(value *WorkbenchExtensionCacheConfigApplyConfiguration) *InternalWorkbenchSpecApplyConfiguration {
+	b.ExtensionCache = value
+	return b
+}
+
 // WithVsCodeUserSettings puts the entries into the VsCodeUserSettings field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the VsCodeUserSettings field,
@@ -305,6 +472,32 @@ func (b *InternalWorkbenchSpecApplyConfiguration) WithDomainPrefix(value string)
 	return b
 }
 
+// WithHostnames adds the given value to the Hostnames field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Hostnames field.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithHostnames(values ...string) *InternalWorkbenchSpecApplyConfiguration {
+	for i := range values {
+		b.Hostnames = append(b.Hostnames, values[i])
+	}
+	return b
+}
+
+// WithTLSSecretName sets the TLSSecretName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TLSSecretName field is set to the value of the last call.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithTLSSecretName(value string) *InternalWorkbenchSpecApplyConfiguration {
+	b.TLSSecretName = &value
+	return b
+}
+
+// WithIngressPolicy sets the IngressPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IngressPolicy field is set to the value of the last call.
+func (b *InternalWorkbenchSpecApplyConfiguration) WithIngressPolicy(value *IngressPolicyApplyConfiguration) *InternalWorkbenchSpecApplyConfiguration {
+	b.IngressPolicy = value
+	return b
+}
+
 // WithAuthLoginPageHtml sets the AuthLoginPageHtml field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the AuthLoginPageHtml field is set to the value of the last call.
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+)
+
+// NetworkPolicyConfigApplyConfiguration represents a declarative configuration of the NetworkPolicyConfig type for use
+// with apply.
+type NetworkPolicyConfigApplyConfiguration struct {
+	Backend                   *corev1beta1.NetworkPolicyBackend            `json:"backend,omitempty"`
+	ReplaceKubernetesPolicies *bool                                        `json:"replaceKubernetesPolicies,omitempty"`
+	EgressFQDNs               *NetworkPolicyFQDNsApplyConfiguration        `json:"egressFQDNs,omitempty"`
+	PrivateCIDRs              []string                                     `json:"privateCIDRs,omitempty"`
+	PodCIDRs                  []string                                     `json:"podCIDRs,omitempty"`
+	InfrastructureCIDRs       []string                                     `json:"infrastructureCIDRs,omitempty"`
+	ExtraEgress               *NetworkPolicyEgressRulesApplyConfiguration  `json:"extraEgress,omitempty"`
+	ExtraIngress              *NetworkPolicyIngressRulesApplyConfiguration `json:"extraIngress,omitempty"`
+}
+
+// NetworkPolicyConfigApplyConfiguration constructs a declarative configuration of the NetworkPolicyConfig type for use with
+// apply.
+func NetworkPolicyConfig() *NetworkPolicyConfigApplyConfiguration {
+	return &NetworkPolicyConfigApplyConfiguration{}
+}
+
+// WithBackend sets the Backend field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Backend field is set to the value of the last call.
+func (b *NetworkPolicyConfigApplyConfiguration) WithBackend(value corev1beta1.NetworkPolicyBackend) *NetworkPolicyConfigApplyConfiguration {
+	b.Backend = &value
+	return b
+}
+
+// WithReplaceKubernetesPolicies sets the ReplaceKubernetesPolicies field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReplaceKubernetesPolicies field is set to the value of the last call.
+func (b *NetworkPolicyConfigApplyConfiguration) WithReplaceKubernetesPolicies(value bool) *NetworkPolicyConfigApplyConfiguration {
+	b.ReplaceKubernetesPolicies = &value
+	return b
+}
+
+// WithEgressFQDNs sets the EgressFQDNs field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EgressFQDNs field is set to the value of the last call.
+func (b *NetworkPolicyConfigApplyConfiguration) WithEgressFQDNs(value *NetworkPolicyFQDNsApplyConfiguration) *NetworkPolicyConfigApplyConfiguration {
+	b.EgressFQDNs = value
+	return b
+}
+
+// WithPrivateCIDRs adds the given value to the PrivateCIDRs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PrivateCIDRs field.
+func (b *NetworkPolicyConfigApplyConfiguration) WithPrivateCIDRs(values ...string) *NetworkPolicyConfigApplyConfiguration {
+	for i := range values {
+		b.PrivateCIDRs = append(b.PrivateCIDRs, values[i])
+	}
+	return b
+}
+
+// WithPodCIDRs adds the given value to the PodCIDRs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PodCIDRs field.
+func (b *NetworkPolicyConfigApplyConfiguration) WithPodCIDRs(values ...string) *NetworkPolicyConfigApplyConfiguration {
+	for i := range values {
+		b.PodCIDRs = append(b.PodCIDRs, values[i])
+	}
+	return b
+}
+
+// WithInfrastructureCIDRs adds the given value to the InfrastructureCIDRs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the InfrastructureCIDRs field.
+func (b *NetworkPolicyConfigApplyConfiguration) WithInfrastructureCIDRs(values ...string) *NetworkPolicyConfigApplyConfiguration {
+	for i := range values {
+		b.InfrastructureCIDRs = append(b.InfrastructureCIDRs, values[i])
+	}
+	return b
+}
+
+// WithExtraEgress sets the ExtraEgress field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExtraEgress field is set to the value of the last call.
+func (b *NetworkPolicyConfigApplyConfiguration) WithExtraEgress(value *NetworkPolicyEgressRulesApplyConfiguration) *NetworkPolicyConfigApplyConfiguration {
+	b.ExtraEgress = value
+	return b
+}
+
+// WithExtraIngress sets the ExtraIngress field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExtraIngress field is set to the value of the last call.
+func (b *NetworkPolicyConfigApplyConfiguration) WithExtraIngress(value *NetworkPolicyIngressRulesApplyConfiguration) *NetworkPolicyConfigApplyConfiguration {
+	b.ExtraIngress = value
+	return b
+}
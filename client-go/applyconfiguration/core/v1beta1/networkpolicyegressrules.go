@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/networking/v1"
+)
+
+// NetworkPolicyEgressRulesApplyConfiguration represents a declarative configuration of the NetworkPolicyEgressRules type for use
+// with apply.
+type NetworkPolicyEgressRulesApplyConfiguration struct {
+	Connect          []v1.NetworkPolicyEgressRule `json:"connect,omitempty"`
+	ConnectSession   []v1.NetworkPolicyEgressRule `json:"connectSession,omitempty"`
+	Workbench        []v1.NetworkPolicyEgressRule `json:"workbench,omitempty"`
+	WorkbenchSession []v1.NetworkPolicyEgressRule `json:"workbenchSession,omitempty"`
+	PackageManager   []v1.NetworkPolicyEgressRule `json:"packageManager,omitempty"`
+	Chronicle        []v1.NetworkPolicyEgressRule `json:"chronicle,omitempty"`
+	Keycloak         []v1.NetworkPolicyEgressRule `json:"keycloak,omitempty"`
+}
+
+// NetworkPolicyEgressRulesApplyConfiguration constructs a declarative configuration of the NetworkPolicyEgressRules type for use with
+// apply.
+func NetworkPolicyEgressRules() *NetworkPolicyEgressRulesApplyConfiguration {
+	return &NetworkPolicyEgressRulesApplyConfiguration{}
+}
+
+// WithConnect adds the given value to the Connect field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Connect field.
+func (b *NetworkPolicyEgressRulesApplyConfiguration) WithConnect(values ...v1.NetworkPolicyEgressRule) *NetworkPolicyEgressRulesApplyConfiguration {
+	for i := range values {
+		b.Connect = append(b.Connect, values[i])
+	}
+	return b
+}
+
+// WithConnectSession adds the given value to the ConnectSession field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ConnectSession field.
+func (b *NetworkPolicyEgressRulesApplyConfiguration) WithConnectSession(values ...v1.NetworkPolicyEgressRule) *NetworkPolicyEgressRulesApplyConfiguration {
+	for i := range values {
+		b.ConnectSession = append(b.ConnectSession, values[i])
+	}
+	return b
+}
+
+// WithWorkbench adds the given value to the Workbench field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Workbench field.
+func (b *NetworkPolicyEgressRulesApplyConfiguration) WithWorkbench(values ...v1.NetworkPolicyEgressRule) *NetworkPolicyEgressRulesApplyConfiguration {
+	for i := range values {
+		b.Workbench = append(b.Workbench, values[i])
+	}
+	return b
+}
+
+// WithWorkbenchSession adds the given value to the WorkbenchSession field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the WorkbenchSession field.
+func (b *NetworkPolicyEgressRulesApplyConfiguration) WithWorkbenchSession(values ...v1.NetworkPolicyEgressRule) *NetworkPolicyEgressRulesApplyConfiguration {
+	for i := range values {
+		b.WorkbenchSession = append(b.WorkbenchSession, values[i])
+	}
+	return b
+}
+
+// WithPackageManager adds the given value to the PackageManager field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PackageManager field.
+func (b *NetworkPolicyEgressRulesApplyConfiguration) WithPackageManager(values ...v1.NetworkPolicyEgressRule) *NetworkPolicyEgressRulesApplyConfiguration {
+	for i := range values {
+		b.PackageManager = append(b.PackageManager, values[i])
+	}
+	return b
+}
+
+// WithChronicle adds the given value to the Chronicle field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Chronicle field.
+func (b *NetworkPolicyEgressRulesApplyConfiguration) WithChronicle(values ...v1.NetworkPolicyEgressRule) *NetworkPolicyEgressRulesApplyConfiguration {
+	for i := range values {
+		b.Chronicle = append(b.Chronicle, values[i])
+	}
+	return b
+}
+
+// WithKeycloak adds the given value to the Keycloak field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Keycloak field.
+func (b *NetworkPolicyEgressRulesApplyConfiguration) WithKeycloak(values ...v1.NetworkPolicyEgressRule) *NetworkPolicyEgressRulesApplyConfiguration {
+	for i := range values {
+		b.Keycloak = append(b.Keycloak, values[i])
+	}
+	return b
+}
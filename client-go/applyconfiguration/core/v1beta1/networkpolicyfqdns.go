@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// NetworkPolicyFQDNsApplyConfiguration represents a declarative configuration of the NetworkPolicyFQDNs type for use
+// with apply.
+type NetworkPolicyFQDNsApplyConfiguration struct {
+	Connect          []string `json:"connect,omitempty"`
+	ConnectSession   []string `json:"connectSession,omitempty"`
+	Workbench        []string `json:"workbench,omitempty"`
+	WorkbenchSession []string `json:"workbenchSession,omitempty"`
+	PackageManager   []string `json:"packageManager,omitempty"`
+	Chronicle        []string `json:"chronicle,omitempty"`
+	Keycloak         []string `json:"keycloak,omitempty"`
+}
+
+// NetworkPolicyFQDNsApplyConfiguration constructs a declarative configuration of the NetworkPolicyFQDNs type for use with
+// apply.
+func NetworkPolicyFQDNs() *NetworkPolicyFQDNsApplyConfiguration {
+	return &NetworkPolicyFQDNsApplyConfiguration{}
+}
+
+// WithConnect adds the given value to the Connect field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Connect field.
+func (b *NetworkPolicyFQDNsApplyConfiguration) WithConnect(values ...string) *NetworkPolicyFQDNsApplyConfiguration {
+	for i := range values {
+		b.Connect = append(b.Connect, values[i])
+	}
+	return b
+}
+
+// WithConnectSession adds the given value to the ConnectSession field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ConnectSession field.
+func (b *NetworkPolicyFQDNsApplyConfiguration) WithConnectSession(values ...string) *NetworkPolicyFQDNsApplyConfiguration {
+	for i := range values {
+		b.ConnectSession = append(b.ConnectSession, values[i])
+	}
+	return b
+}
+
+// WithWorkbench adds the given value to the Workbench field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Workbench field.
+func (b *NetworkPolicyFQDNsApplyConfiguration) WithWorkbench(values ...string) *NetworkPolicyFQDNsApplyConfiguration {
+	for i := range values {
+		b.Workbench = append(b.Workbench, values[i])
+	}
+	return b
+}
+
+// WithWorkbenchSession adds the given value to the WorkbenchSession field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the WorkbenchSession field.
+func (b *NetworkPolicyFQDNsApplyConfiguration) WithWorkbenchSession(values ...string) *NetworkPolicyFQDNsApplyConfiguration {
+	for i := range values {
+		b.WorkbenchSession = append(b.WorkbenchSession, values[i])
+	}
+	return b
+}
+
+// WithPackageManager adds the given value to the PackageManager field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PackageManager field.
+func (b *NetworkPolicyFQDNsApplyConfiguration) WithPackageManager(values ...string) *NetworkPolicyFQDNsApplyConfiguration {
+	for i := range values {
+		b.PackageManager = append(b.PackageManager, values[i])
+	}
+	return b
+}
+
+// WithChronicle adds the given value to the Chronicle field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Chronicle field.
+func (b *NetworkPolicyFQDNsApplyConfiguration) WithChronicle(values ...string) *NetworkPolicyFQDNsApplyConfiguration {
+	for i := range values {
+		b.Chronicle = append(b.Chronicle, values[i])
+	}
+	return b
+}
+
+// WithKeycloak adds the given value to the Keycloak field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Keycloak field.
+func (b *NetworkPolicyFQDNsApplyConfiguration) WithKeycloak(values ...string) *NetworkPolicyFQDNsApplyConfiguration {
+	for i := range values {
+		b.Keycloak = append(b.Keycloak, values[i])
+	}
+	return b
+}
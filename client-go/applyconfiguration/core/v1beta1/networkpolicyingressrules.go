@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/networking/v1"
+)
+
+// NetworkPolicyIngressRulesApplyConfiguration represents a declarative configuration of the NetworkPolicyIngressRules type for use
+// with apply.
+type NetworkPolicyIngressRulesApplyConfiguration struct {
+	Connect          []v1.NetworkPolicyIngressRule `json:"connect,omitempty"`
+	ConnectSession   []v1.NetworkPolicyIngressRule `json:"connectSession,omitempty"`
+	Workbench        []v1.NetworkPolicyIngressRule `json:"workbench,omitempty"`
+	WorkbenchSession []v1.NetworkPolicyIngressRule `json:"workbenchSession,omitempty"`
+	PackageManager   []v1.NetworkPolicyIngressRule `json:"packageManager,omitempty"`
+	Chronicle        []v1.NetworkPolicyIngressRule `json:"chronicle,omitempty"`
+	Keycloak         []v1.NetworkPolicyIngressRule `json:"keycloak,omitempty"`
+}
+
+// NetworkPolicyIngressRulesApplyConfiguration constructs a declarative configuration of the NetworkPolicyIngressRules type for use with
+// apply.
+func NetworkPolicyIngressRules() *NetworkPolicyIngressRulesApplyConfiguration {
+	return &NetworkPolicyIngressRulesApplyConfiguration{}
+}
+
+// WithConnect adds the given value to the Connect field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Connect field.
+func (b *NetworkPolicyIngressRulesApplyConfiguration) WithConnect(values ...v1.NetworkPolicyIngressRule) *NetworkPolicyIngressRulesApplyConfiguration {
+	for i := range values {
+		b.Connect = append(b.Connect, values[i])
+	}
+	return b
+}
+
+// WithConnectSession adds the given value to the ConnectSession field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ConnectSession field.
+func (b *NetworkPolicyIngressRulesApplyConfiguration) WithConnectSession(values ...v1.NetworkPolicyIngressRule) *NetworkPolicyIngressRulesApplyConfiguration {
+	for i := range values {
+		b.ConnectSession = append(b.ConnectSession, values[i])
+	}
+	return b
+}
+
+// WithWorkbench adds the given value to the Workbench field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Workbench field.
+func (b *NetworkPolicyIngressRulesApplyConfiguration) WithWorkbench(values ...v1.NetworkPolicyIngressRule) *NetworkPolicyIngressRulesApplyConfiguration {
+	for i := range values {
+		b.Workbench = append(b.Workbench, values[i])
+	}
+	return b
+}
+
+// WithWorkbenchSession adds the given value to the WorkbenchSession field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the WorkbenchSession field.
+func (b *NetworkPolicyIngressRulesApplyConfiguration) WithWorkbenchSession(values ...v1.NetworkPolicyIngressRule) *NetworkPolicyIngressRulesApplyConfiguration {
+	for i := range values {
+		b.WorkbenchSession = append(b.WorkbenchSession, values[i])
+	}
+	return b
+}
+
+// WithPackageManager adds the given value to the PackageManager field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PackageManager field.
+func (b *NetworkPolicyIngressRulesApplyConfiguration) WithPackageManager(values ...v1.NetworkPolicyIngressRule) *NetworkPolicyIngressRulesApplyConfiguration {
+	for i := range values {
+		b.PackageManager = append(b.PackageManager, values[i])
+	}
+	return b
+}
+
+// WithChronicle adds the given value to the Chronicle field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Chronicle field.
+func (b *NetworkPolicyIngressRulesApplyConfiguration) WithChronicle(values ...v1.NetworkPolicyIngressRule) *NetworkPolicyIngressRulesApplyConfiguration {
+	for i := range values {
+		b.Chronicle = append(b.Chronicle, values[i])
+	}
+	return b
+}
+
+// WithKeycloak adds the given value to the Keycloak field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Keycloak field.
+func (b *NetworkPolicyIngressRulesApplyConfiguration) WithKeycloak(values ...v1.NetworkPolicyIngressRule) *NetworkPolicyIngressRulesApplyConfiguration {
+	for i := range values {
+		b.Keycloak = append(b.Keycloak, values[i])
+	}
+	return b
+}
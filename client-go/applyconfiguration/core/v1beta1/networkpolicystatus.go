@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// NetworkPolicyStatusApplyConfiguration represents a declarative configuration of the NetworkPolicyStatus type for use
+// with apply.
+type NetworkPolicyStatusApplyConfiguration struct {
+	Level *string  `json:"level,omitempty"`
+	Rules []string `json:"rules,omitempty"`
+}
+
+// NetworkPolicyStatusApplyConfiguration constructs a declarative configuration of the NetworkPolicyStatus type for use with
+// apply.
+func NetworkPolicyStatus() *NetworkPolicyStatusApplyConfiguration {
+	return &NetworkPolicyStatusApplyConfiguration{}
+}
+
+// WithLevel sets the Level field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Level field is set to the value of the last call.
+func (b *NetworkPolicyStatusApplyConfiguration) WithLevel(value string) *NetworkPolicyStatusApplyConfiguration {
+	b.Level = &value
+	return b
+}
+
+// WithRules adds the given value to the Rules field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Rules field.
+func (b *NetworkPolicyStatusApplyConfiguration) WithRules(values ...string) *NetworkPolicyStatusApplyConfiguration {
+	for i := range values {
+		b.Rules = append(b.Rules, values[i])
+	}
+	return b
+}
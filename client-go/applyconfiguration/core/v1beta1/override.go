@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+)
+
+// OverrideApplyConfiguration represents a declarative configuration of the Override type for use
+// with apply.
+type OverrideApplyConfiguration struct {
+	Kind  *string                        `json:"kind,omitempty"`
+	Name  *string                        `json:"name,omitempty"`
+	Type  *corev1beta1.OverridePatchType `json:"type,omitempty"`
+	Patch *string                        `json:"patch,omitempty"`
+}
+
+// OverrideApplyConfiguration constructs a declarative configuration of the Override type for use with
+// apply.
+func Override() *OverrideApplyConfiguration {
+	return &OverrideApplyConfiguration{}
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *OverrideApplyConfiguration) WithKind(value string) *OverrideApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *OverrideApplyConfiguration) WithName(value string) *OverrideApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *OverrideApplyConfiguration) WithType(value corev1beta1.OverridePatchType) *OverrideApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithPatch sets the Patch field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Patch field is set to the value of the last call.
+func (b *OverrideApplyConfiguration) WithPatch(value string) *OverrideApplyConfiguration {
+	b.Patch = &value
+	return b
+}
@@ -6,6 +6,7 @@
 package v1beta1
 
 import (
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
 	product "github.com/posit-dev/team-operator/api/product"
 	v1 "k8s.io/api/core/v1"
 )
@@ -13,30 +14,41 @@ import (
 // PackageManagerSpecApplyConfiguration represents a declarative configuration of the PackageManagerSpec type for use
 // with apply.
 type PackageManagerSpecApplyConfiguration struct {
-	License                      *product.LicenseSpec                      `json:"license,omitempty"`
-	Config                       *PackageManagerConfigApplyConfiguration   `json:"config,omitempty"`
-	Volume                       *product.VolumeSpec                       `json:"volume,omitempty"`
-	SecretType                   *product.SiteSecretType                   `json:"secretType,omitempty"`
-	Url                          *string                                   `json:"url,omitempty"`
-	DatabaseConfig               *PostgresDatabaseConfigApplyConfiguration `json:"databaseConfig,omitempty"`
-	IngressClass                 *string                                   `json:"ingressClass,omitempty"`
-	IngressAnnotations           map[string]string                         `json:"ingressAnnotations,omitempty"`
-	ImagePullSecrets             []string                                  `json:"imagePullSecrets,omitempty"`
-	NodeSelector                 map[string]string                         `json:"nodeSelector,omitempty"`
-	AddEnv                       map[string]string                         `json:"addEnv,omitempty"`
-	Image                        *string                                   `json:"image,omitempty"`
-	ImagePullPolicy              *v1.PullPolicy                            `json:"imagePullPolicy,omitempty"`
-	Sleep                        *bool                                     `json:"sleep,omitempty"`
-	AwsAccountId                 *string                                   `json:"awsAccountId,omitempty"`
-	WorkloadCompoundName         *string                                   `json:"workloadCompoundName,omitempty"`
-	ClusterDate                  *string                                   `json:"clusterDate,omitempty"`
-	ChronicleAgentImage          *string                                   `json:"chronicleImage,omitempty"`
-	Secret                       *SecretConfigApplyConfiguration           `json:"secret,omitempty"`
-	WorkloadSecret               *SecretConfigApplyConfiguration           `json:"workloadSecret,omitempty"`
-	MainDatabaseCredentialSecret *SecretConfigApplyConfiguration           `json:"mainDatabaseCredentialSecret,omitempty"`
-	Replicas                     *int                                      `json:"replicas,omitempty"`
-	GitSSHKeys                   []SSHKeyConfigApplyConfiguration          `json:"gitSSHKeys,omitempty"`
-	AzureFiles                   *AzureFilesConfigApplyConfiguration       `json:"azureFiles,omitempty"`
+	License                               *product.LicenseSpec                      `json:"license,omitempty"`
+	Config                                *PackageManagerConfigApplyConfiguration   `json:"config,omitempty"`
+	Volume                                *product.VolumeSpec                       `json:"volume,omitempty"`
+	SecretType                            *product.SiteSecretType                   `json:"secretType,omitempty"`
+	Url                                   *string                                   `json:"url,omitempty"`
+	RootPath                              *string                                   `json:"rootPath,omitempty"`
+	Aliases                               []string                                  `json:"aliases,omitempty"`
+	TLSSecretName                         *string                                   `json:"tlsSecretName,omitempty"`
+	DatabaseConfig                        *PostgresDatabaseConfigApplyConfiguration `json:"databaseConfig,omitempty"`
+	IngressClass                          *string                                   `json:"ingressClass,omitempty"`
+	IngressAnnotations                    map[string]string                         `json:"ingressAnnotations,omitempty"`
+	Routing                               *RoutingConfigApplyConfiguration          `json:"routing,omitempty"`
+	IngressPolicy                         *IngressPolicyApplyConfiguration          `json:"ingressPolicy,omitempty"`
+	ImagePullSecrets                      []string                                  `json:"imagePullSecrets,omitempty"`
+	NodeSelector                          map[string]string                         `json:"nodeSelector,omitempty"`
+	PodSchedulingConfigApplyConfiguration `json:",inline"`
+	SecurityProfile                       *corev1beta1.SecurityProfile         `json:"securityProfile,omitempty"`
+	Overrides                             []OverrideApplyConfiguration         `json:"overrides,omitempty"`
+	AddEnv                                map[string]string                    `json:"addEnv,omitempty"`
+	Image                                 *string                              `json:"image,omitempty"`
+	ImagePullPolicy                       *v1.PullPolicy                       `json:"imagePullPolicy,omitempty"`
+	Sleep                                 *bool                                `json:"sleep,omitempty"`
+	AwsAccountId                          *string                              `json:"awsAccountId,omitempty"`
+	WorkloadCompoundName                  *string                              `json:"workloadCompoundName,omitempty"`
+	ClusterDate                           *string                              `json:"clusterDate,omitempty"`
+	ChronicleAgentImage                   *string                              `json:"chronicleImage,omitempty"`
+	Secret                                *SecretConfigApplyConfiguration      `json:"secret,omitempty"`
+	WorkloadSecret                        *SecretConfigApplyConfiguration      `json:"workloadSecret,omitempty"`
+	MainDatabaseCredentialSecret          *SecretConfigApplyConfiguration      `json:"mainDatabaseCredentialSecret,omitempty"`
+	Replicas                              *int                                 `json:"replicas,omitempty"`
+	Autoscaling                           *AutoscalingConfigApplyConfiguration `json:"autoscaling,omitempty"`
+	Resources                             *v1.ResourceRequirements             `json:"resources,omitempty"`
+	ChronicleAgentResources               *v1.ResourceRequirements             `json:"chronicleAgentResources,omitempty"`
+	GitSSHKeys                            []SSHKeyConfigApplyConfiguration     `json:"gitSSHKeys,omitempty"`
+	AzureFiles                            *AzureFilesConfigApplyConfiguration  `json:"azureFiles,omitempty"`
 }
 
 // PackageManagerSpecApplyConfiguration constructs a declarative configuration of the PackageManagerSpec type for use with
@@ -85,6 +97,32 @@ func (b *PackageManagerSpecApplyConfiguration) WithUrl(value string) *PackageMan
 	return b
 }
 
+// WithRootPath sets the RootPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RootPath field is set to the value of the last call.
+func (b *PackageManagerSpecApplyConfiguration) WithRootPath(value string) *PackageManagerSpecApplyConfiguration {
+	b.RootPath = &value
+	return b
+}
+
+// WithAliases adds the given value to the Aliases field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Aliases field.
+func (b *PackageManagerSpecApplyConfiguration) WithAliases(values ...string) *PackageManagerSpecApplyConfiguration {
+	for i := range values {
+		b.Aliases = append(b.Aliases, values[i])
+	}
+	return b
+}
+
+// WithTLSSecretName sets the TLSSecretName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TLSSecretName field is set to the value of the last call.
+func (b *PackageManagerSpecApplyConfiguration) WithTLSSecretName(value string) *PackageManagerSpecApplyConfiguration {
+	b.TLSSecretName = &value
+	return b
+}
+
 // WithDatabaseConfig sets the DatabaseConfig field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the DatabaseConfig field is set to the value of the last call.
@@ -115,6 +153,22 @@ func (b *PackageManagerSpecApplyConfiguration) WithIngressAnnotations(entries ma
 	return b
 }
 
+// WithRouting sets the Routing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Routing field is set to the value of the last call.
+func (b *PackageManagerSpecApplyConfiguration) WithRouting(value *RoutingConfigApplyConfiguration) *PackageManagerSpecApplyConfiguration {
+	b.Routing = value
+	return b
+}
+
+// WithIngressPolicy sets the IngressPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IngressPolicy field is set to the value of the last call.
+func (b *PackageManagerSpecApplyConfiguration) WithIngressPolicy(value *IngressPolicyApplyConfiguration) *PackageManagerSpecApplyConfiguration {
+	b.IngressPolicy = value
+	return b
+}
+
 // WithImagePullSecrets adds the given value to the ImagePullSecrets field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the ImagePullSecrets field.
@@ -139,6 +193,91 @@ func (b *PackageManagerSpecApplyConfiguration) WithNodeSelector(entries map[stri
 	return b
 }
 
+// WithAffinity sets the Affinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Affinity field is set to the value of the last call.
+func (b *PackageManagerSpecApplyConfiguration) WithAffinity(value v1.Affinity) *PackageManagerSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.Affinity = &value
+	return b
+}
+
+// WithTolerations adds the given value to the Tolerations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tolerations field.
+func (b *PackageManagerSpecApplyConfiguration) WithTolerations(values ...v1.Toleration) *PackageManagerSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.Tolerations = append(b.PodSchedulingConfigApplyConfiguration.Tolerations, values[i])
+	}
+	return b
+}
+
+// WithTopologySpreadConstraints adds the given value to the TopologySpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopologySpreadConstraints field.
+func (b *PackageManagerSpecApplyConfiguration) WithTopologySpreadConstraints(values ...v1.TopologySpreadConstraint) *PackageManagerSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints = append(b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints, values[i])
+	}
+	return b
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *PackageManagerSpecApplyConfiguration) WithPriorityClassName(value string) *PackageManagerSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.PriorityClassName = &value
+	return b
+}
+
+// WithPodAnnotations puts the entries into the PodAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodAnnotations field,
+// overwriting an existing map entries in PodAnnotations field with the same key.
+func (b *PackageManagerSpecApplyConfiguration) WithPodAnnotations(entries map[string]string) *PackageManagerSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodAnnotations == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations[k] = v
+	}
+	return b
+}
+
+// WithPodLabels puts the entries into the PodLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodLabels field,
+// overwriting an existing map entries in PodLabels field with the same key.
+func (b *PackageManagerSpecApplyConfiguration) WithPodLabels(entries map[string]string) *PackageManagerSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodLabels == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels[k] = v
+	}
+	return b
+}
+
+// WithSecurityProfile sets the SecurityProfile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecurityProfile field is set to the value of the last call.
+func (b *PackageManagerSpecApplyConfiguration) WithSecurityProfile(value corev1beta1.SecurityProfile) *PackageManagerSpecApplyConfiguration {
+	b.SecurityProfile = &value
+	return b
+}
+
+// WithOverrides adds the given value to the Overrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Overrides field.
+func (b *PackageManagerSpecApplyConfiguration) WithOverrides(values ...*OverrideApplyConfiguration) *PackageManagerSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOverrides")
+		}
+		b.Overrides = append(b.Overrides, *values[i])
+	}
+	return b
+}
+
 // WithAddEnv puts the entries into the AddEnv field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the AddEnv field,
@@ -241,6 +380,30 @@ func (b *PackageManagerSpecApplyConfiguration) WithReplicas(value int) *PackageM
 	return b
 }
 
+// WithAutoscaling sets the Autoscaling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Autoscaling field is set to the value of the last call.
+func (b *PackageManagerSpecApplyConfiguration) WithAutoscaling(value *AutoscalingConfigApplyConfiguration) *PackageManagerSpecApplyConfiguration {
+	b.Autoscaling = value
+	return b
+}
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *PackageManagerSpecApplyConfiguration) WithResources(value v1.ResourceRequirements) *PackageManagerSpecApplyConfiguration {
+	b.Resources = &value
+	return b
+}
+
+// WithChronicleAgentResources sets the ChronicleAgentResources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ChronicleAgentResources field is set to the value of the last call.
+func (b *PackageManagerSpecApplyConfiguration) WithChronicleAgentResources(value v1.ResourceRequirements) *PackageManagerSpecApplyConfiguration {
+	b.ChronicleAgentResources = &value
+	return b
+}
+
 // WithGitSSHKeys adds the given value to the GitSSHKeys field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the GitSSHKeys field.
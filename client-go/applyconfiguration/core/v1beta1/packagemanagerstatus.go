@@ -12,8 +12,9 @@ import (
 // PackageManagerStatusApplyConfiguration represents a declarative configuration of the PackageManagerStatus type for use
 // with apply.
 type PackageManagerStatusApplyConfiguration struct {
-	KeySecretRef *v1.SecretReference `json:"keySecretRef,omitempty"`
-	Ready        *bool               `json:"ready,omitempty"`
+	KeySecretRef    *v1.SecretReference `json:"keySecretRef,omitempty"`
+	Ready           *bool               `json:"ready,omitempty"`
+	FailedOverrides []string            `json:"failedOverrides,omitempty"`
 }
 
 // PackageManagerStatusApplyConfiguration constructs a declarative configuration of the PackageManagerStatus type for use with
@@ -37,3 +38,13 @@ func (b *PackageManagerStatusApplyConfiguration) WithReady(value bool) *PackageM
 	b.Ready = &value
 	return b
 }
+
+// WithFailedOverrides adds the given value to the FailedOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FailedOverrides field.
+func (b *PackageManagerStatusApplyConfiguration) WithFailedOverrides(values ...string) *PackageManagerStatusApplyConfiguration {
+	for i := range values {
+		b.FailedOverrides = append(b.FailedOverrides, values[i])
+	}
+	return b
+}
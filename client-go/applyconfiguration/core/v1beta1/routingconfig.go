@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RoutingConfigApplyConfiguration represents a declarative configuration of the RoutingConfig type for use
+// with apply.
+type RoutingConfigApplyConfiguration struct {
+	Mode           *corev1beta1.RoutingMode             `json:"mode,omitempty"`
+	Gateway        *GatewayParentRefApplyConfiguration  `json:"gateway,omitempty"`
+	Provider       *corev1beta1.IngressProvider         `json:"provider,omitempty"`
+	RequestTimeout *v1.Duration                         `json:"requestTimeout,omitempty"`
+	Layout         *corev1beta1.SiteDomainType          `json:"layout,omitempty"`
+	ExternalDNS    *ExternalDNSConfigApplyConfiguration `json:"externalDns,omitempty"`
+}
+
+// RoutingConfigApplyConfiguration constructs a declarative configuration of the RoutingConfig type for use with
+// apply.
+func RoutingConfig() *RoutingConfigApplyConfiguration {
+	return &RoutingConfigApplyConfiguration{}
+}
+
+// WithMode sets the Mode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mode field is set to the value of the last call.
+func (b *RoutingConfigApplyConfiguration) WithMode(value corev1beta1.RoutingMode) *RoutingConfigApplyConfiguration {
+	b.Mode = &value
+	return b
+}
+
+// WithGateway sets the Gateway field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Gateway field is set to the value of the last call.
+func (b *RoutingConfigApplyConfiguration) WithGateway(value *GatewayParentRefApplyConfiguration) *RoutingConfigApplyConfiguration {
+	b.Gateway = value
+	return b
+}
+
+// WithProvider sets the Provider field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Provider field is set to the value of the last call.
+func (b *RoutingConfigApplyConfiguration) WithProvider(value corev1beta1.IngressProvider) *RoutingConfigApplyConfiguration {
+	b.Provider = &value
+	return b
+}
+
+// WithRequestTimeout sets the RequestTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequestTimeout field is set to the value of the last call.
+func (b *RoutingConfigApplyConfiguration) WithRequestTimeout(value v1.Duration) *RoutingConfigApplyConfiguration {
+	b.RequestTimeout = &value
+	return b
+}
+
+// WithLayout sets the Layout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Layout field is set to the value of the last call.
+func (b *RoutingConfigApplyConfiguration) WithLayout(value corev1beta1.SiteDomainType) *RoutingConfigApplyConfiguration {
+	b.Layout = &value
+	return b
+}
+
+// WithExternalDNS sets the ExternalDNS field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExternalDNS field is set to the value of the last call.
+func (b *RoutingConfigApplyConfiguration) WithExternalDNS(value *ExternalDNSConfigApplyConfiguration) *RoutingConfigApplyConfiguration {
+	b.ExternalDNS = value
+	return b
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+)
+
+// SessionImageSpecApplyConfiguration represents a declarative configuration of the SessionImageSpec type for use
+// with apply.
+type SessionImageSpecApplyConfiguration struct {
+	Image           *string                  `json:"image,omitempty"`
+	DisplayName     *string                  `json:"displayName,omitempty"`
+	IDEs            []corev1beta1.SessionIDE `json:"ides,omitempty"`
+	RVersions       []string                 `json:"rVersions,omitempty"`
+	PythonVersions  []string                 `json:"pythonVersions,omitempty"`
+	DeprecationDate *string                  `json:"deprecationDate,omitempty"`
+	Default         *bool                    `json:"default,omitempty"`
+}
+
+// SessionImageSpecApplyConfiguration constructs a declarative configuration of the SessionImageSpec type for use with
+// apply.
+func SessionImageSpec() *SessionImageSpecApplyConfiguration {
+	return &SessionImageSpecApplyConfiguration{}
+}
+
+// WithImage sets the Image field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Image field is set to the value of the last call.
+func (b *SessionImageSpecApplyConfiguration) WithImage(value string) *SessionImageSpecApplyConfiguration {
+	b.Image = &value
+	return b
+}
+
+// WithDisplayName sets the DisplayName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisplayName field is set to the value of the last call.
+func (b *SessionImageSpecApplyConfiguration) WithDisplayName(value string) *SessionImageSpecApplyConfiguration {
+	b.DisplayName = &value
+	return b
+}
+
+// WithIDEs adds the given value to the IDEs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the IDEs field.
+func (b *SessionImageSpecApplyConfiguration) WithIDEs(values ...corev1beta1.SessionIDE) *SessionImageSpecApplyConfiguration {
+	for i := range values {
+		b.IDEs = append(b.IDEs, values[i])
+	}
+	return b
+}
+
+// WithRVersions adds the given value to the RVersions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RVersions field.
+func (b *SessionImageSpecApplyConfiguration) WithRVersions(values ...string) *SessionImageSpecApplyConfiguration {
+	for i := range values {
+		b.RVersions = append(b.RVersions, values[i])
+	}
+	return b
+}
+
+// WithPythonVersions adds the given value to the PythonVersions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PythonVersions field.
+func (b *SessionImageSpecApplyConfiguration) WithPythonVersions(values ...string) *SessionImageSpecApplyConfiguration {
+	for i := range values {
+		b.PythonVersions = append(b.PythonVersions, values[i])
+	}
+	return b
+}
+
+// WithDeprecationDate sets the DeprecationDate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeprecationDate field is set to the value of the last call.
+func (b *SessionImageSpecApplyConfiguration) WithDeprecationDate(value string) *SessionImageSpecApplyConfiguration {
+	b.DeprecationDate = &value
+	return b
+}
+
+// WithDefault sets the Default field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Default field is set to the value of the last call.
+func (b *SessionImageSpecApplyConfiguration) WithDefault(value bool) *SessionImageSpecApplyConfiguration {
+	b.Default = &value
+	return b
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// SessionImageStatusApplyConfiguration represents a declarative configuration of the SessionImageStatus type for use
+// with apply.
+type SessionImageStatusApplyConfiguration struct {
+	Sites                  []string `json:"sites,omitempty"`
+	Digest                 *string  `json:"digest,omitempty"`
+	RVersions              []string `json:"rVersions,omitempty"`
+	PythonVersions         []string `json:"pythonVersions,omitempty"`
+	QuartoVersions         []string `json:"quartoVersions,omitempty"`
+	RuntimeVersionsWarning *string  `json:"runtimeVersionsWarning,omitempty"`
+}
+
+// SessionImageStatusApplyConfiguration constructs a declarative configuration of the SessionImageStatus type for use with
+// apply.
+func SessionImageStatus() *SessionImageStatusApplyConfiguration {
+	return &SessionImageStatusApplyConfiguration{}
+}
+
+// WithSites adds the given value to the Sites field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Sites field.
+func (b *SessionImageStatusApplyConfiguration) WithSites(values ...string) *SessionImageStatusApplyConfiguration {
+	for i := range values {
+		b.Sites = append(b.Sites, values[i])
+	}
+	return b
+}
+
+// WithDigest sets the Digest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Digest field is set to the value of the last call.
+func (b *SessionImageStatusApplyConfiguration) WithDigest(value string) *SessionImageStatusApplyConfiguration {
+	b.Digest = &value
+	return b
+}
+
+// WithRVersions adds the given value to the RVersions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RVersions field.
+func (b *SessionImageStatusApplyConfiguration) WithRVersions(values ...string) *SessionImageStatusApplyConfiguration {
+	for i := range values {
+		b.RVersions = append(b.RVersions, values[i])
+	}
+	return b
+}
+
+// WithPythonVersions adds the given value to the PythonVersions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PythonVersions field.
+func (b *SessionImageStatusApplyConfiguration) WithPythonVersions(values ...string) *SessionImageStatusApplyConfiguration {
+	for i := range values {
+		b.PythonVersions = append(b.PythonVersions, values[i])
+	}
+	return b
+}
+
+// WithQuartoVersions adds the given value to the QuartoVersions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the QuartoVersions field.
+func (b *SessionImageStatusApplyConfiguration) WithQuartoVersions(values ...string) *SessionImageStatusApplyConfiguration {
+	for i := range values {
+		b.QuartoVersions = append(b.QuartoVersions, values[i])
+	}
+	return b
+}
+
+// WithRuntimeVersionsWarning sets the RuntimeVersionsWarning field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RuntimeVersionsWarning field is set to the value of the last call.
+func (b *SessionImageStatusApplyConfiguration) WithRuntimeVersionsWarning(value string) *SessionImageStatusApplyConfiguration {
+	b.RuntimeVersionsWarning = &value
+	return b
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// SessionLimitRangeApplyConfiguration represents a declarative configuration of the SessionLimitRange type for use
+// with apply.
+type SessionLimitRangeApplyConfiguration struct {
+	DefaultRequest *v1.ResourceList `json:"defaultRequest,omitempty"`
+	Default        *v1.ResourceList `json:"default,omitempty"`
+	Max            *v1.ResourceList `json:"max,omitempty"`
+}
+
+// SessionLimitRangeApplyConfiguration constructs a declarative configuration of the SessionLimitRange type for use with
+// apply.
+func SessionLimitRange() *SessionLimitRangeApplyConfiguration {
+	return &SessionLimitRangeApplyConfiguration{}
+}
+
+// WithDefaultRequest sets the DefaultRequest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultRequest field is set to the value of the last call.
+func (b *SessionLimitRangeApplyConfiguration) WithDefaultRequest(value v1.ResourceList) *SessionLimitRangeApplyConfiguration {
+	b.DefaultRequest = &value
+	return b
+}
+
+// WithDefault sets the Default field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Default field is set to the value of the last call.
+func (b *SessionLimitRangeApplyConfiguration) WithDefault(value v1.ResourceList) *SessionLimitRangeApplyConfiguration {
+	b.Default = &value
+	return b
+}
+
+// WithMax sets the Max field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Max field is set to the value of the last call.
+func (b *SessionLimitRangeApplyConfiguration) WithMax(value v1.ResourceList) *SessionLimitRangeApplyConfiguration {
+	b.Max = &value
+	return b
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// SessionQuotaApplyConfiguration represents a declarative configuration of the SessionQuota type for use
+// with apply.
+type SessionQuotaApplyConfiguration struct {
+	PriorityClassName *string                              `json:"priorityClassName,omitempty"`
+	CPU               *resource.Quantity                   `json:"cpu,omitempty"`
+	Memory            *resource.Quantity                   `json:"memory,omitempty"`
+	NvidiaGPUs        *resource.Quantity                   `json:"nvidiaGpus,omitempty"`
+	Pods              *int32                               `json:"pods,omitempty"`
+	LimitRange        *SessionLimitRangeApplyConfiguration `json:"limitRange,omitempty"`
+}
+
+// SessionQuotaApplyConfiguration constructs a declarative configuration of the SessionQuota type for use with
+// apply.
+func SessionQuota() *SessionQuotaApplyConfiguration {
+	return &SessionQuotaApplyConfiguration{}
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *SessionQuotaApplyConfiguration) WithPriorityClassName(value string) *SessionQuotaApplyConfiguration {
+	b.PriorityClassName = &value
+	return b
+}
+
+// WithCPU sets the CPU field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CPU field is set to the value of the last call.
+func (b *SessionQuotaApplyConfiguration) WithCPU(value resource.Quantity) *SessionQuotaApplyConfiguration {
+	b.CPU = &value
+	return b
+}
+
+// WithMemory sets the Memory field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Memory field is set to the value of the last call.
+func (b *SessionQuotaApplyConfiguration) WithMemory(value resource.Quantity) *SessionQuotaApplyConfiguration {
+	b.Memory = &value
+	return b
+}
+
+// WithNvidiaGPUs sets the NvidiaGPUs field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NvidiaGPUs field is set to the value of the last call.
+func (b *SessionQuotaApplyConfiguration) WithNvidiaGPUs(value resource.Quantity) *SessionQuotaApplyConfiguration {
+	b.NvidiaGPUs = &value
+	return b
+}
+
+// WithPods sets the Pods field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Pods field is set to the value of the last call.
+func (b *SessionQuotaApplyConfiguration) WithPods(value int32) *SessionQuotaApplyConfiguration {
+	b.Pods = &value
+	return b
+}
+
+// WithLimitRange sets the LimitRange field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LimitRange field is set to the value of the last call.
+func (b *SessionQuotaApplyConfiguration) WithLimitRange(value *SessionLimitRangeApplyConfiguration) *SessionQuotaApplyConfiguration {
+	b.LimitRange = value
+	return b
+}
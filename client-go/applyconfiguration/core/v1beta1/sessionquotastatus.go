@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// SessionQuotaStatusApplyConfiguration represents a declarative configuration of the SessionQuotaStatus type for use
+// with apply.
+type SessionQuotaStatusApplyConfiguration struct {
+	Hard *v1.ResourceList `json:"hard,omitempty"`
+	Used *v1.ResourceList `json:"used,omitempty"`
+}
+
+// SessionQuotaStatusApplyConfiguration constructs a declarative configuration of the SessionQuotaStatus type for use with
+// apply.
+func SessionQuotaStatus() *SessionQuotaStatusApplyConfiguration {
+	return &SessionQuotaStatusApplyConfiguration{}
+}
+
+// WithHard sets the Hard field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Hard field is set to the value of the last call.
+func (b *SessionQuotaStatusApplyConfiguration) WithHard(value v1.ResourceList) *SessionQuotaStatusApplyConfiguration {
+	b.Hard = &value
+	return b
+}
+
+// WithUsed sets the Used field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Used field is set to the value of the last call.
+func (b *SessionQuotaStatusApplyConfiguration) WithUsed(value v1.ResourceList) *SessionQuotaStatusApplyConfiguration {
+	b.Used = &value
+	return b
+}
@@ -26,6 +26,7 @@ type SiteSpecApplyConfiguration struct {
 	Keycloak                     *InternalKeycloakSpecApplyConfiguration       `json:"keycloak,omitempty"`
 	IngressClass                 *string                                       `json:"ingressClass,omitempty"`
 	IngressAnnotations           map[string]string                             `json:"ingressAnnotations,omitempty"`
+	Routing                      *RoutingConfigApplyConfiguration              `json:"routing,omitempty"`
 	ImagePullSecrets             []string                                      `json:"imagePullSecrets,omitempty"`
 	VolumeSource                 *VolumeSourceApplyConfiguration               `json:"volumeSource,omitempty"`
 	SharedDirectory              *string                                       `json:"sharedDirectory,omitempty"`
@@ -39,9 +40,12 @@ type SiteSpecApplyConfiguration struct {
 	Debug                        *bool                                         `json:"debug,omitempty"`
 	LogFormat                    *product.LogFormat                            `json:"logFormat,omitempty"`
 	NetworkTrust                 *corev1beta1.NetworkTrust                     `json:"networkTrust,omitempty"`
+	SecurityProfile              *corev1beta1.SecurityProfile                  `json:"securityProfile,omitempty"`
+	Overrides                    []OverrideApplyConfiguration                  `json:"overrides,omitempty"`
 	PackageManagerUrl            *string                                       `json:"packageManagerUrl,omitempty"`
 	EFSEnabled                   *bool                                         `json:"efsEnabled,omitempty"`
 	VPCCIDR                      *string                                       `json:"vpcCIDR,omitempty"`
+	NetworkPolicy                *NetworkPolicyConfigApplyConfiguration        `json:"networkPolicy,omitempty"`
 	EnableFQDNHealthChecks       *bool                                         `json:"enableFqdnHealthChecks,omitempty"`
 }
 
@@ -161,6 +165,14 @@ func (b *SiteSpecApplyConfiguration) WithIngressAnnotations(entries map[string]s
 	return b
 }
 
+// WithRouting sets the Routing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Routing field is set to the value of the last call.
+func (b *SiteSpecApplyConfiguration) WithRouting(value *RoutingConfigApplyConfiguration) *SiteSpecApplyConfiguration {
+	b.Routing = value
+	return b
+}
+
 // WithImagePullSecrets adds the given value to the ImagePullSecrets field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the ImagePullSecrets field.
@@ -272,6 +284,27 @@ func (b *SiteSpecApplyConfiguration) WithNetworkTrust(value corev1beta1.NetworkT
 	return b
 }
 
+// WithSecurityProfile sets the SecurityProfile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecurityProfile field is set to the value of the last call.
+func (b *SiteSpecApplyConfiguration) WithSecurityProfile(value corev1beta1.SecurityProfile) *SiteSpecApplyConfiguration {
+	b.SecurityProfile = &value
+	return b
+}
+
+// WithOverrides adds the given value to the Overrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Overrides field.
+func (b *SiteSpecApplyConfiguration) WithOverrides(values ...*OverrideApplyConfiguration) *SiteSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOverrides")
+		}
+		b.Overrides = append(b.Overrides, *values[i])
+	}
+	return b
+}
+
 // WithPackageManagerUrl sets the PackageManagerUrl field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the PackageManagerUrl field is set to the value of the last call.
@@ -296,6 +329,14 @@ func (b *SiteSpecApplyConfiguration) WithVPCCIDR(value string) *SiteSpecApplyCon
 	return b
 }
 
+// WithNetworkPolicy sets the NetworkPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NetworkPolicy field is set to the value of the last call.
+func (b *SiteSpecApplyConfiguration) WithNetworkPolicy(value *NetworkPolicyConfigApplyConfiguration) *SiteSpecApplyConfiguration {
+	b.NetworkPolicy = value
+	return b
+}
+
 // WithEnableFQDNHealthChecks sets the EnableFQDNHealthChecks field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the EnableFQDNHealthChecks field is set to the value of the last call.
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// SiteStatusApplyConfiguration represents a declarative configuration of the SiteStatus type for use
+// with apply.
+type SiteStatusApplyConfiguration struct {
+	NetworkPolicy    *NetworkPolicyStatusApplyConfiguration `json:"networkPolicy,omitempty"`
+	FailedOverrides  []string                               `json:"failedOverrides,omitempty"`
+	SessionNamespace *string                                `json:"sessionNamespace,omitempty"`
+}
+
+// SiteStatusApplyConfiguration constructs a declarative configuration of the SiteStatus type for use with
+// apply.
+func SiteStatus() *SiteStatusApplyConfiguration {
+	return &SiteStatusApplyConfiguration{}
+}
+
+// WithNetworkPolicy sets the NetworkPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NetworkPolicy field is set to the value of the last call.
+func (b *SiteStatusApplyConfiguration) WithNetworkPolicy(value *NetworkPolicyStatusApplyConfiguration) *SiteStatusApplyConfiguration {
+	b.NetworkPolicy = value
+	return b
+}
+
+// WithFailedOverrides adds the given value to the FailedOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FailedOverrides field.
+func (b *SiteStatusApplyConfiguration) WithFailedOverrides(values ...string) *SiteStatusApplyConfiguration {
+	for i := range values {
+		b.FailedOverrides = append(b.FailedOverrides, values[i])
+	}
+	return b
+}
+
+// WithSessionNamespace sets the SessionNamespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionNamespace field is set to the value of the last call.
+func (b *SiteStatusApplyConfiguration) WithSessionNamespace(value string) *SiteStatusApplyConfiguration {
+	b.SessionNamespace = &value
+	return b
+}
@@ -248,6 +248,20 @@ func (b *WorkbenchConfigApplyConfiguration) WithLauncherKubernetesProfiles(entri
 	return b
 }
 
+// WithProfiles adds the given value to the Profiles field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Profiles field.
+func (b *WorkbenchConfigApplyConfiguration) WithProfiles(values ...*WorkbenchLauncherProfileApplyConfiguration) *WorkbenchConfigApplyConfiguration {
+	b.ensureWorkbenchProfilesConfigApplyConfigurationExists()
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithProfiles")
+		}
+		b.WorkbenchProfilesConfigApplyConfiguration.Profiles = append(b.WorkbenchProfilesConfigApplyConfiguration.Profiles, *values[i])
+	}
+	return b
+}
+
 func (b *WorkbenchConfigApplyConfiguration) ensureWorkbenchProfilesConfigApplyConfigurationExists() {
 	if b.WorkbenchProfilesConfigApplyConfiguration == nil {
 		b.WorkbenchProfilesConfigApplyConfiguration = &WorkbenchProfilesConfigApplyConfiguration{}
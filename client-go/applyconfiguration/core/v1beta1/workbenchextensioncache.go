@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// WorkbenchExtensionCacheApplyConfiguration represents a declarative configuration of the WorkbenchExtensionCache type for use
+// with apply.
+type WorkbenchExtensionCacheApplyConfiguration struct {
+	ClaimName *string                                     `json:"claimName,omitempty"`
+	SubPath   *string                                     `json:"subPath,omitempty"`
+	Image     *string                                     `json:"image,omitempty"`
+	Source    *WorkbenchExtensionSourceApplyConfiguration `json:"source,omitempty"`
+}
+
+// WorkbenchExtensionCacheApplyConfiguration constructs a declarative configuration of the WorkbenchExtensionCache type for use with
+// apply.
+func WorkbenchExtensionCache() *WorkbenchExtensionCacheApplyConfiguration {
+	return &WorkbenchExtensionCacheApplyConfiguration{}
+}
+
+// WithClaimName sets the ClaimName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClaimName field is set to the value of the last call.
+func (b *WorkbenchExtensionCacheApplyConfiguration) WithClaimName(value string) *WorkbenchExtensionCacheApplyConfiguration {
+	b.ClaimName = &value
+	return b
+}
+
+// WithSubPath sets the SubPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SubPath field is set to the value of the last call.
+func (b *WorkbenchExtensionCacheApplyConfiguration) WithSubPath(value string) *WorkbenchExtensionCacheApplyConfiguration {
+	b.SubPath = &value
+	return b
+}
+
+// WithImage sets the Image field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Image field is set to the value of the last call.
+func (b *WorkbenchExtensionCacheApplyConfiguration) WithImage(value string) *WorkbenchExtensionCacheApplyConfiguration {
+	b.Image = &value
+	return b
+}
+
+// WithSource sets the Source field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Source field is set to the value of the last call.
+func (b *WorkbenchExtensionCacheApplyConfiguration) WithSource(value *WorkbenchExtensionSourceApplyConfiguration) *WorkbenchExtensionCacheApplyConfiguration {
+	b.Source = value
+	return b
+}
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// WorkbenchExtensionCacheConfigApplyConfiguration represents a declarative configuration of the WorkbenchExtensionCacheConfig type for use
+// with apply.
+type WorkbenchExtensionCacheConfigApplyConfiguration struct {
+	Subdirectory *string                                     `json:"subdirectory,omitempty"`
+	Image        *string                                     `json:"image,omitempty"`
+	Source       *WorkbenchExtensionSourceApplyConfiguration `json:"source,omitempty"`
+}
+
+// WorkbenchExtensionCacheConfigApplyConfiguration constructs a declarative configuration of the WorkbenchExtensionCacheConfig type for use with
+// apply.
+func WorkbenchExtensionCacheConfig() *WorkbenchExtensionCacheConfigApplyConfiguration {
+	return &WorkbenchExtensionCacheConfigApplyConfiguration{}
+}
+
+// WithSubdirectory sets the Subdirectory field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Subdirectory field is set to the value of the last call.
+func (b *WorkbenchExtensionCacheConfigApplyConfiguration) WithSubdirectory(value string) *WorkbenchExtensionCacheConfigApplyConfiguration {
+	b.Subdirectory = &value
+	return b
+}
+
+// WithImage sets the Image field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Image field is set to the value of the last call.
+func (b *WorkbenchExtensionCacheConfigApplyConfiguration) WithImage(value string) *WorkbenchExtensionCacheConfigApplyConfiguration {
+	b.Image = &value
+	return b
+}
+
+// WithSource sets the Source field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Source field is set to the value of the last call.
+func (b *WorkbenchExtensionCacheConfigApplyConfiguration) WithSource(value *WorkbenchExtensionSourceApplyConfiguration) *WorkbenchExtensionCacheConfigApplyConfiguration {
+	b.Source = value
+	return b
+}
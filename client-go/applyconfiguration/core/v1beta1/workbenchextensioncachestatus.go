@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+)
+
+// WorkbenchExtensionCacheStatusApplyConfiguration represents a declarative configuration of the WorkbenchExtensionCacheStatus type for use
+// with apply.
+type WorkbenchExtensionCacheStatusApplyConfiguration struct {
+	Hash  *string                                   `json:"hash,omitempty"`
+	Job   *string                                   `json:"job,omitempty"`
+	Phase *corev1beta1.WorkbenchExtensionCachePhase `json:"phase,omitempty"`
+}
+
+// WorkbenchExtensionCacheStatusApplyConfiguration constructs a declarative configuration of the WorkbenchExtensionCacheStatus type for use with
+// apply.
+func WorkbenchExtensionCacheStatus() *WorkbenchExtensionCacheStatusApplyConfiguration {
+	return &WorkbenchExtensionCacheStatusApplyConfiguration{}
+}
+
+// WithHash sets the Hash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Hash field is set to the value of the last call.
+func (b *WorkbenchExtensionCacheStatusApplyConfiguration) WithHash(value string) *WorkbenchExtensionCacheStatusApplyConfiguration {
+	b.Hash = &value
+	return b
+}
+
+// WithJob sets the Job field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Job field is set to the value of the last call.
+func (b *WorkbenchExtensionCacheStatusApplyConfiguration) WithJob(value string) *WorkbenchExtensionCacheStatusApplyConfiguration {
+	b.Job = &value
+	return b
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *WorkbenchExtensionCacheStatusApplyConfiguration) WithPhase(value corev1beta1.WorkbenchExtensionCachePhase) *WorkbenchExtensionCacheStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
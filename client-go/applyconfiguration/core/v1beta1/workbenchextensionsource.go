@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// WorkbenchExtensionSourceApplyConfiguration represents a declarative configuration of the WorkbenchExtensionSource type for use
+// with apply.
+type WorkbenchExtensionSourceApplyConfiguration struct {
+	Image         *string `json:"image,omitempty"`
+	ConfigMapName *string `json:"configMapName,omitempty"`
+	ClaimName     *string `json:"claimName,omitempty"`
+	SubPath       *string `json:"subPath,omitempty"`
+}
+
+// WorkbenchExtensionSourceApplyConfiguration constructs a declarative configuration of the WorkbenchExtensionSource type for use with
+// apply.
+func WorkbenchExtensionSource() *WorkbenchExtensionSourceApplyConfiguration {
+	return &WorkbenchExtensionSourceApplyConfiguration{}
+}
+
+// WithImage sets the Image field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Image field is set to the value of the last call.
+func (b *WorkbenchExtensionSourceApplyConfiguration) WithImage(value string) *WorkbenchExtensionSourceApplyConfiguration {
+	b.Image = &value
+	return b
+}
+
+// WithConfigMapName sets the ConfigMapName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConfigMapName field is set to the value of the last call.
+func (b *WorkbenchExtensionSourceApplyConfiguration) WithConfigMapName(value string) *WorkbenchExtensionSourceApplyConfiguration {
+	b.ConfigMapName = &value
+	return b
+}
+
+// WithClaimName sets the ClaimName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClaimName field is set to the value of the last call.
+func (b *WorkbenchExtensionSourceApplyConfiguration) WithClaimName(value string) *WorkbenchExtensionSourceApplyConfiguration {
+	b.ClaimName = &value
+	return b
+}
+
+// WithSubPath sets the SubPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SubPath field is set to the value of the last call.
+func (b *WorkbenchExtensionSourceApplyConfiguration) WithSubPath(value string) *WorkbenchExtensionSourceApplyConfiguration {
+	b.SubPath = &value
+	return b
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// WorkbenchGroupSpecApplyConfiguration represents a declarative configuration of the WorkbenchGroupSpec type for use
+// with apply.
+type WorkbenchGroupSpecApplyConfiguration struct {
+	GroupName *string `json:"groupName,omitempty"`
+	GID       *int64  `json:"gid,omitempty"`
+}
+
+// WorkbenchGroupSpecApplyConfiguration constructs a declarative configuration of the WorkbenchGroupSpec type for use with
+// apply.
+func WorkbenchGroupSpec() *WorkbenchGroupSpecApplyConfiguration {
+	return &WorkbenchGroupSpecApplyConfiguration{}
+}
+
+// WithGroupName sets the GroupName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GroupName field is set to the value of the last call.
+func (b *WorkbenchGroupSpecApplyConfiguration) WithGroupName(value string) *WorkbenchGroupSpecApplyConfiguration {
+	b.GroupName = &value
+	return b
+}
+
+// WithGID sets the GID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GID field is set to the value of the last call.
+func (b *WorkbenchGroupSpecApplyConfiguration) WithGID(value int64) *WorkbenchGroupSpecApplyConfiguration {
+	b.GID = &value
+	return b
+}
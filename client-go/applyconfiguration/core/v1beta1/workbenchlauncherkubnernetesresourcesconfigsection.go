@@ -8,14 +8,15 @@ package v1beta1
 // WorkbenchLauncherKubnernetesResourcesConfigSectionApplyConfiguration represents a declarative configuration of the WorkbenchLauncherKubnernetesResourcesConfigSection type for use
 // with apply.
 type WorkbenchLauncherKubnernetesResourcesConfigSectionApplyConfiguration struct {
-	Name                 *string `json:"name,omitempty"`
-	Cpus                 *string `json:"cpus,omitempty"`
-	CpusRequest          *string `json:"cpus-request,omitempty"`
-	MemMb                *string `json:"mem-mb,omitempty"`
-	MemMbRequest         *string `json:"mem-mb-request,omitempty"`
-	NvidiaGpus           *string `json:"nvidia-gpus,omitempty"`
-	AmdGpus              *string `json:"amd-gpus,omitempty"`
-	PlacementConstraints *string `json:"placement-constraints,omitempty"`
+	Name                 *string                                              `json:"name,omitempty"`
+	Cpus                 *string                                              `json:"cpus,omitempty"`
+	CpusRequest          *string                                              `json:"cpus-request,omitempty"`
+	MemMb                *string                                              `json:"mem-mb,omitempty"`
+	MemMbRequest         *string                                              `json:"mem-mb-request,omitempty"`
+	NvidiaGpus           *string                                              `json:"nvidia-gpus,omitempty"`
+	AmdGpus              *string                                              `json:"amd-gpus,omitempty"`
+	PlacementConstraints *string                                              `json:"placement-constraints,omitempty"`
+	Pod                  *WorkbenchResourceProfilePodConfigApplyConfiguration `json:"pod,omitempty"`
 }
 
 // WorkbenchLauncherKubnernetesResourcesConfigSectionApplyConfiguration constructs a declarative configuration of the WorkbenchLauncherKubnernetesResourcesConfigSection type for use with
@@ -87,3 +88,11 @@ func (b *WorkbenchLauncherKubnernetesResourcesConfigSectionApplyConfiguration) W
 	b.PlacementConstraints = &value
 	return b
 }
+
+// WithPod sets the Pod field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Pod field is set to the value of the last call.
+func (b *WorkbenchLauncherKubnernetesResourcesConfigSectionApplyConfiguration) WithPod(value *WorkbenchResourceProfilePodConfigApplyConfiguration) *WorkbenchLauncherKubnernetesResourcesConfigSectionApplyConfiguration {
+	b.Pod = value
+	return b
+}
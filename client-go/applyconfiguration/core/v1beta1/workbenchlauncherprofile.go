@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// WorkbenchLauncherProfileApplyConfiguration represents a declarative configuration of the WorkbenchLauncherProfile type for use
+// with apply.
+type WorkbenchLauncherProfileApplyConfiguration struct {
+	Targets          []string `json:"targets,omitempty"`
+	Images           []string `json:"images,omitempty"`
+	DefaultImage     *string  `json:"defaultImage,omitempty"`
+	ResourceProfiles []string `json:"resourceProfiles,omitempty"`
+	MaxCpus          *string  `json:"maxCpus,omitempty"`
+	MaxMemMb         *int32   `json:"maxMemMb,omitempty"`
+	MaxNvidiaGpus    *int32   `json:"maxNvidiaGpus,omitempty"`
+}
+
+// WorkbenchLauncherProfileApplyConfiguration constructs a declarative configuration of the WorkbenchLauncherProfile type for use with
+// apply.
+func WorkbenchLauncherProfile() *WorkbenchLauncherProfileApplyConfiguration {
+	return &WorkbenchLauncherProfileApplyConfiguration{}
+}
+
+// WithTargets adds the given value to the Targets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Targets field.
+func (b *WorkbenchLauncherProfileApplyConfiguration) WithTargets(values ...string) *WorkbenchLauncherProfileApplyConfiguration {
+	for i := range values {
+		b.Targets = append(b.Targets, values[i])
+	}
+	return b
+}
+
+// WithImages adds the given value to the Images field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Images field.
+func (b *WorkbenchLauncherProfileApplyConfiguration) WithImages(values ...string) *WorkbenchLauncherProfileApplyConfiguration {
+	for i := range values {
+		b.Images = append(b.Images, values[i])
+	}
+	return b
+}
+
+// WithDefaultImage sets the DefaultImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultImage field is set to the value of the last call.
+func (b *WorkbenchLauncherProfileApplyConfiguration) WithDefaultImage(value string) *WorkbenchLauncherProfileApplyConfiguration {
+	b.DefaultImage = &value
+	return b
+}
+
+// WithResourceProfiles adds the given value to the ResourceProfiles field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ResourceProfiles field.
+func (b *WorkbenchLauncherProfileApplyConfiguration) WithResourceProfiles(values ...string) *WorkbenchLauncherProfileApplyConfiguration {
+	for i := range values {
+		b.ResourceProfiles = append(b.ResourceProfiles, values[i])
+	}
+	return b
+}
+
+// WithMaxCpus sets the MaxCpus field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxCpus field is set to the value of the last call.
+func (b *WorkbenchLauncherProfileApplyConfiguration) WithMaxCpus(value string) *WorkbenchLauncherProfileApplyConfiguration {
+	b.MaxCpus = &value
+	return b
+}
+
+// WithMaxMemMb sets the MaxMemMb field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxMemMb field is set to the value of the last call.
+func (b *WorkbenchLauncherProfileApplyConfiguration) WithMaxMemMb(value int32) *WorkbenchLauncherProfileApplyConfiguration {
+	b.MaxMemMb = &value
+	return b
+}
+
+// WithMaxNvidiaGpus sets the MaxNvidiaGpus field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxNvidiaGpus field is set to the value of the last call.
+func (b *WorkbenchLauncherProfileApplyConfiguration) WithMaxNvidiaGpus(value int32) *WorkbenchLauncherProfileApplyConfiguration {
+	b.MaxNvidiaGpus = &value
+	return b
+}
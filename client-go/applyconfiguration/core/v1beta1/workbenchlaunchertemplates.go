@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// WorkbenchLauncherTemplatesApplyConfiguration represents a declarative configuration of the WorkbenchLauncherTemplates type for use
+// with apply.
+type WorkbenchLauncherTemplatesApplyConfiguration struct {
+	Version       *string `json:"version,omitempty"`
+	ConfigMapName *string `json:"configMapName,omitempty"`
+}
+
+// WorkbenchLauncherTemplatesApplyConfiguration constructs a declarative configuration of the WorkbenchLauncherTemplates type for use with
+// apply.
+func WorkbenchLauncherTemplates() *WorkbenchLauncherTemplatesApplyConfiguration {
+	return &WorkbenchLauncherTemplatesApplyConfiguration{}
+}
+
+// WithVersion sets the Version field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Version field is set to the value of the last call.
+func (b *WorkbenchLauncherTemplatesApplyConfiguration) WithVersion(value string) *WorkbenchLauncherTemplatesApplyConfiguration {
+	b.Version = &value
+	return b
+}
+
+// WithConfigMapName sets the ConfigMapName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConfigMapName field is set to the value of the last call.
+func (b *WorkbenchLauncherTemplatesApplyConfiguration) WithConfigMapName(value string) *WorkbenchLauncherTemplatesApplyConfiguration {
+	b.ConfigMapName = &value
+	return b
+}
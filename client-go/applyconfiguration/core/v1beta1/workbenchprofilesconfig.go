@@ -9,6 +9,7 @@ package v1beta1
 // with apply.
 type WorkbenchProfilesConfigApplyConfiguration struct {
 	LauncherKubernetesProfiles map[string]WorkbenchLauncherKubernetesProfilesConfigSectionApplyConfiguration `json:"launcher.kubernetes.profiles.conf,omitempty"`
+	Profiles                   []WorkbenchLauncherProfileApplyConfiguration                                  `json:"profiles,omitempty"`
 }
 
 // WorkbenchProfilesConfigApplyConfiguration constructs a declarative configuration of the WorkbenchProfilesConfig type for use with
@@ -30,3 +31,16 @@ func (b *WorkbenchProfilesConfigApplyConfiguration) WithLauncherKubernetesProfil
 	}
 	return b
 }
+
+// WithProfiles adds the given value to the Profiles field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Profiles field.
+func (b *WorkbenchProfilesConfigApplyConfiguration) WithProfiles(values ...*WorkbenchLauncherProfileApplyConfiguration) *WorkbenchProfilesConfigApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithProfiles")
+		}
+		b.Profiles = append(b.Profiles, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+)
+
+// WorkbenchRepositoryApplyConfiguration represents a declarative configuration of the WorkbenchRepository type for use
+// with apply.
+type WorkbenchRepositoryApplyConfiguration struct {
+	Name *string                              `json:"name,omitempty"`
+	Type *corev1beta1.WorkbenchRepositoryType `json:"type,omitempty"`
+	URL  *string                              `json:"url,omitempty"`
+}
+
+// WorkbenchRepositoryApplyConfiguration constructs a declarative configuration of the WorkbenchRepository type for use with
+// apply.
+func WorkbenchRepository() *WorkbenchRepositoryApplyConfiguration {
+	return &WorkbenchRepositoryApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *WorkbenchRepositoryApplyConfiguration) WithName(value string) *WorkbenchRepositoryApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *WorkbenchRepositoryApplyConfiguration) WithType(value corev1beta1.WorkbenchRepositoryType) *WorkbenchRepositoryApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithURL sets the URL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the URL field is set to the value of the last call.
+func (b *WorkbenchRepositoryApplyConfiguration) WithURL(value string) *WorkbenchRepositoryApplyConfiguration {
+	b.URL = &value
+	return b
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// WorkbenchResourceProfilePodConfigApplyConfiguration represents a declarative configuration of the WorkbenchResourceProfilePodConfig type for use
+// with apply.
+type WorkbenchResourceProfilePodConfigApplyConfiguration struct {
+	Tolerations       []v1.Toleration   `json:"tolerations,omitempty"`
+	NodeSelector      map[string]string `json:"nodeSelector,omitempty"`
+	Affinity          *v1.Affinity      `json:"affinity,omitempty"`
+	PriorityClassName *string           `json:"priorityClassName,omitempty"`
+	RuntimeClassName  *string           `json:"runtimeClassName,omitempty"`
+	Volumes           []v1.Volume       `json:"volumes,omitempty"`
+	VolumeMounts      []v1.VolumeMount  `json:"volumeMounts,omitempty"`
+}
+
+// WorkbenchResourceProfilePodConfigApplyConfiguration constructs a declarative configuration of the WorkbenchResourceProfilePodConfig type for use with
+// apply.
+func WorkbenchResourceProfilePodConfig() *WorkbenchResourceProfilePodConfigApplyConfiguration {
+	return &WorkbenchResourceProfilePodConfigApplyConfiguration{}
+}
+
+// WithTolerations adds the given value to the Tolerations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tolerations field.
+func (b *WorkbenchResourceProfilePodConfigApplyConfiguration) WithTolerations(values ...v1.Toleration) *WorkbenchResourceProfilePodConfigApplyConfiguration {
+	for i := range values {
+		b.Tolerations = append(b.Tolerations, values[i])
+	}
+	return b
+}
+
+// WithNodeSelector puts the entries into the NodeSelector field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the NodeSelector field,
+// overwriting an existing map entries in NodeSelector field with the same key.
+func (b *WorkbenchResourceProfilePodConfigApplyConfiguration) WithNodeSelector(entries map[string]string) *WorkbenchResourceProfilePodConfigApplyConfiguration {
+	if b.NodeSelector == nil && len(entries) > 0 {
+		b.NodeSelector = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.NodeSelector[k] = v
+	}
+	return b
+}
+
+// WithAffinity sets the Affinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Affinity field is set to the value of the last call.
+func (b *WorkbenchResourceProfilePodConfigApplyConfiguration) WithAffinity(value v1.Affinity) *WorkbenchResourceProfilePodConfigApplyConfiguration {
+	b.Affinity = &value
+	return b
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *WorkbenchResourceProfilePodConfigApplyConfiguration) WithPriorityClassName(value string) *WorkbenchResourceProfilePodConfigApplyConfiguration {
+	b.PriorityClassName = &value
+	return b
+}
+
+// WithRuntimeClassName sets the RuntimeClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RuntimeClassName field is set to the value of the last call.
+func (b *WorkbenchResourceProfilePodConfigApplyConfiguration) WithRuntimeClassName(value string) *WorkbenchResourceProfilePodConfigApplyConfiguration {
+	b.RuntimeClassName = &value
+	return b
+}
+
+// WithVolumes adds the given value to the Volumes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Volumes field.
+func (b *WorkbenchResourceProfilePodConfigApplyConfiguration) WithVolumes(values ...v1.Volume) *WorkbenchResourceProfilePodConfigApplyConfiguration {
+	for i := range values {
+		b.Volumes = append(b.Volumes, values[i])
+	}
+	return b
+}
+
+// WithVolumeMounts adds the given value to the VolumeMounts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the VolumeMounts field.
+func (b *WorkbenchResourceProfilePodConfigApplyConfiguration) WithVolumeMounts(values ...v1.VolumeMount) *WorkbenchResourceProfilePodConfigApplyConfiguration {
+	for i := range values {
+		b.VolumeMounts = append(b.VolumeMounts, values[i])
+	}
+	return b
+}
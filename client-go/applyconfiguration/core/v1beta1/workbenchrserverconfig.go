@@ -16,6 +16,7 @@ type WorkbenchRServerConfigApplyConfiguration struct {
 	AdminGroup                             *string  `json:"admin-group,omitempty"`
 	AdminSuperuserGroup                    *string  `json:"admin-superuser-group,omitempty"`
 	WwwPort                                *int     `json:"www-port,omitempty"`
+	WwwRootPath                            *string  `json:"www-root-path,omitempty"`
 	ServerProjectSharing                   *int     `json:"server-project-sharing,omitempty"`
 	LauncherAddress                        *string  `json:"launcher-address,omitempty"`
 	LauncherPort                           *int     `json:"launcher-port,omitempty"`
@@ -38,6 +39,7 @@ type WorkbenchRServerConfigApplyConfiguration struct {
 	WwwThreadPoolSize                      *int     `json:"www-thread-pool-size,omitempty"`
 	LauncherSessionsProxyTimeoutSeconds    *int     `json:"launcher-sessions-proxy-timeout-seconds,omitempty"`
 	LauncherSessionsAutoUpdate             *int     `json:"launcher-sessions-auto-update,omitempty"`
+	LauncherSessionsCreateContainerUser    *int     `json:"launcher-sessions-create-container-user,omitempty"`
 	LauncherSessionsInitContainerImageName *string  `json:"launcher-sessions-init-container-image-name,omitempty"`
 	LauncherSessionsInitContainerImageTag  *string  `json:"launcher-sessions-init-container-image-tag,omitempty"`
 	DatabricksEnabled                      *int     `json:"databricks-enabled,omitempty"`
@@ -117,6 +119,14 @@ func (b *WorkbenchRServerConfigApplyConfiguration) WithWwwPort(value int) *Workb
 	return b
 }
 
+// WithWwwRootPath sets the WwwRootPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WwwRootPath field is set to the value of the last call.
+func (b *WorkbenchRServerConfigApplyConfiguration) WithWwwRootPath(value string) *WorkbenchRServerConfigApplyConfiguration {
+	b.WwwRootPath = &value
+	return b
+}
+
 // WithServerProjectSharing sets the ServerProjectSharing field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ServerProjectSharing field is set to the value of the last call.
@@ -295,6 +305,14 @@ func (b *WorkbenchRServerConfigApplyConfiguration) WithLauncherSessionsAutoUpdat
 	return b
 }
 
+// WithLauncherSessionsCreateContainerUser sets the LauncherSessionsCreateContainerUser field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LauncherSessionsCreateContainerUser field is set to the value of the last call.
+func (b *WorkbenchRServerConfigApplyConfiguration) WithLauncherSessionsCreateContainerUser(value int) *WorkbenchRServerConfigApplyConfiguration {
+	b.LauncherSessionsCreateContainerUser = &value
+	return b
+}
+
 // WithLauncherSessionsInitContainerImageName sets the LauncherSessionsInitContainerImageName field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the LauncherSessionsInitContainerImageName field is set to the value of the last call.
@@ -17,6 +17,8 @@ type WorkbenchRSessionConfigApplyConfiguration struct {
 	ManagedCredentialsInJobsEnabled *int                           `json:"managed-credentials-in-jobs-enabled,omitempty"`
 	SessionFirstProjectTemplatePath *string                        `json:"session-first-project-template-path,omitempty"`
 	SessionSaveActionDefault        *corev1beta1.SessionSaveAction `json:"session-save-action-default,omitempty"`
+	SessionTimeoutMinutes           *int                           `json:"session-timeout-minutes,omitempty"`
+	SessionTimeoutKillHours         *int                           `json:"session-timeout-kill-hours,omitempty"`
 }
 
 // WorkbenchRSessionConfigApplyConfiguration constructs a declarative configuration of the WorkbenchRSessionConfig type for use with
@@ -64,3 +66,19 @@ func (b *WorkbenchRSessionConfigApplyConfiguration) WithSessionSaveActionDefault
 	b.SessionSaveActionDefault = &value
 	return b
 }
+
+// WithSessionTimeoutMinutes sets the SessionTimeoutMinutes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionTimeoutMinutes field is set to the value of the last call.
+func (b *WorkbenchRSessionConfigApplyConfiguration) WithSessionTimeoutMinutes(value int) *WorkbenchRSessionConfigApplyConfiguration {
+	b.SessionTimeoutMinutes = &value
+	return b
+}
+
+// WithSessionTimeoutKillHours sets the SessionTimeoutKillHours field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionTimeoutKillHours field is set to the value of the last call.
+func (b *WorkbenchRSessionConfigApplyConfiguration) WithSessionTimeoutKillHours(value int) *WorkbenchRSessionConfigApplyConfiguration {
+	b.SessionTimeoutKillHours = &value
+	return b
+}
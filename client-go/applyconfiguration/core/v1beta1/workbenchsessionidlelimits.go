@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// WorkbenchSessionIdleLimitsApplyConfiguration represents a declarative configuration of the WorkbenchSessionIdleLimits type for use
+// with apply.
+type WorkbenchSessionIdleLimitsApplyConfiguration struct {
+	IdleSuspendMinutes *int32 `json:"idleSuspendMinutes,omitempty"`
+	IdleKillHours      *int32 `json:"idleKillHours,omitempty"`
+}
+
+// WorkbenchSessionIdleLimitsApplyConfiguration constructs a declarative configuration of the WorkbenchSessionIdleLimits type for use with
+// apply.
+func WorkbenchSessionIdleLimits() *WorkbenchSessionIdleLimitsApplyConfiguration {
+	return &WorkbenchSessionIdleLimitsApplyConfiguration{}
+}
+
+// WithIdleSuspendMinutes sets the IdleSuspendMinutes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IdleSuspendMinutes field is set to the value of the last call.
+func (b *WorkbenchSessionIdleLimitsApplyConfiguration) WithIdleSuspendMinutes(value int32) *WorkbenchSessionIdleLimitsApplyConfiguration {
+	b.IdleSuspendMinutes = &value
+	return b
+}
+
+// WithIdleKillHours sets the IdleKillHours field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IdleKillHours field is set to the value of the last call.
+func (b *WorkbenchSessionIdleLimitsApplyConfiguration) WithIdleKillHours(value int32) *WorkbenchSessionIdleLimitsApplyConfiguration {
+	b.IdleKillHours = &value
+	return b
+}
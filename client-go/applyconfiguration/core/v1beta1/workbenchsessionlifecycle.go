@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// WorkbenchSessionLifecycleApplyConfiguration represents a declarative configuration of the WorkbenchSessionLifecycle type for use
+// with apply.
+type WorkbenchSessionLifecycleApplyConfiguration struct {
+	RStudio        *WorkbenchSessionIdleLimitsApplyConfiguration `json:"rstudio,omitempty"`
+	Jupyter        *WorkbenchSessionIdleLimitsApplyConfiguration `json:"jupyter,omitempty"`
+	VSCode         *WorkbenchSessionIdleLimitsApplyConfiguration `json:"vsCode,omitempty"`
+	Positron       *WorkbenchSessionIdleLimitsApplyConfiguration `json:"positron,omitempty"`
+	JobExpiryHours *int32                                        `json:"jobExpiryHours,omitempty"`
+	Reaper         *WorkbenchSessionReaperApplyConfiguration     `json:"reaper,omitempty"`
+}
+
+// WorkbenchSessionLifecycleApplyConfiguration constructs a declarative configuration of the WorkbenchSessionLifecycle type for use with
+// apply.
+func WorkbenchSessionLifecycle() *WorkbenchSessionLifecycleApplyConfiguration {
+	return &WorkbenchSessionLifecycleApplyConfiguration{}
+}
+
+// WithRStudio sets the RStudio field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RStudio field is set to the value of the last call.
+func (b *WorkbenchSessionLifecycleApplyConfiguration) WithRStudio(value *WorkbenchSessionIdleLimitsApplyConfiguration) *WorkbenchSessionLifecycleApplyConfiguration {
+	b.RStudio = value
+	return b
+}
+
+// WithJupyter sets the Jupyter field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Jupyter field is set to the value of the last call.
+func (b *WorkbenchSessionLifecycleApplyConfiguration) WithJupyter(value *WorkbenchSessionIdleLimitsApplyConfiguration) *WorkbenchSessionLifecycleApplyConfiguration {
+	b.Jupyter = value
+	return b
+}
+
+// WithVSCode sets the VSCode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the VSCode field is set to the value of the last call.
+func (b *WorkbenchSessionLifecycleApplyConfiguration) WithVSCode(value *WorkbenchSessionIdleLimitsApplyConfiguration) *WorkbenchSessionLifecycleApplyConfiguration {
+	b.VSCode = value
+	return b
+}
+
+// WithPositron sets the Positron field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Positron field is set to the value of the last call.
+func (b *WorkbenchSessionLifecycleApplyConfiguration) WithPositron(value *WorkbenchSessionIdleLimitsApplyConfiguration) *WorkbenchSessionLifecycleApplyConfiguration {
+	b.Positron = value
+	return b
+}
+
+// WithJobExpiryHours sets the JobExpiryHours field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the JobExpiryHours field is set to the value of the last call.
+func (b *WorkbenchSessionLifecycleApplyConfiguration) WithJobExpiryHours(value int32) *WorkbenchSessionLifecycleApplyConfiguration {
+	b.JobExpiryHours = &value
+	return b
+}
+
+// WithReaper sets the Reaper field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reaper field is set to the value of the last call.
+func (b *WorkbenchSessionLifecycleApplyConfiguration) WithReaper(value *WorkbenchSessionReaperApplyConfiguration) *WorkbenchSessionLifecycleApplyConfiguration {
+	b.Reaper = value
+	return b
+}
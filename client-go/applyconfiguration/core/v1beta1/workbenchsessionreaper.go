@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// WorkbenchSessionReaperApplyConfiguration represents a declarative configuration of the WorkbenchSessionReaper type for use
+// with apply.
+type WorkbenchSessionReaperApplyConfiguration struct {
+	MaxAgeHours *int32                                            `json:"maxAgeHours,omitempty"`
+	Profiles    []WorkbenchSessionReaperProfileApplyConfiguration `json:"profiles,omitempty"`
+}
+
+// WorkbenchSessionReaperApplyConfiguration constructs a declarative configuration of the WorkbenchSessionReaper type for use with
+// apply.
+func WorkbenchSessionReaper() *WorkbenchSessionReaperApplyConfiguration {
+	return &WorkbenchSessionReaperApplyConfiguration{}
+}
+
+// WithMaxAgeHours sets the MaxAgeHours field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxAgeHours field is set to the value of the last call.
+func (b *WorkbenchSessionReaperApplyConfiguration) WithMaxAgeHours(value int32) *WorkbenchSessionReaperApplyConfiguration {
+	b.MaxAgeHours = &value
+	return b
+}
+
+// WithProfiles adds the given value to the Profiles field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Profiles field.
+func (b *WorkbenchSessionReaperApplyConfiguration) WithProfiles(values ...*WorkbenchSessionReaperProfileApplyConfiguration) *WorkbenchSessionReaperApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithProfiles")
+		}
+		b.Profiles = append(b.Profiles, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+)
+
+// WorkbenchSessionReaperProfileApplyConfiguration represents a declarative configuration of the WorkbenchSessionReaperProfile type for use
+// with apply.
+type WorkbenchSessionReaperProfileApplyConfiguration struct {
+	Users            []string                          `json:"users,omitempty"`
+	IDEs             []corev1beta1.WorkbenchSessionIDE `json:"ides,omitempty"`
+	ResourceProfiles []string                          `json:"resourceProfiles,omitempty"`
+	MaxAgeHours      *int32                            `json:"maxAgeHours,omitempty"`
+}
+
+// WorkbenchSessionReaperProfileApplyConfiguration constructs a declarative configuration of the WorkbenchSessionReaperProfile type for use with
+// apply.
+func WorkbenchSessionReaperProfile() *WorkbenchSessionReaperProfileApplyConfiguration {
+	return &WorkbenchSessionReaperProfileApplyConfiguration{}
+}
+
+// WithUsers adds the given value to the Users field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Users field.
+func (b *WorkbenchSessionReaperProfileApplyConfiguration) WithUsers(values ...string) *WorkbenchSessionReaperProfileApplyConfiguration {
+	for i := range values {
+		b.Users = append(b.Users, values[i])
+	}
+	return b
+}
+
+// WithIDEs adds the given value to the IDEs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the IDEs field.
+func (b *WorkbenchSessionReaperProfileApplyConfiguration) WithIDEs(values ...corev1beta1.WorkbenchSessionIDE) *WorkbenchSessionReaperProfileApplyConfiguration {
+	for i := range values {
+		b.IDEs = append(b.IDEs, values[i])
+	}
+	return b
+}
+
+// WithResourceProfiles adds the given value to the ResourceProfiles field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ResourceProfiles field.
+func (b *WorkbenchSessionReaperProfileApplyConfiguration) WithResourceProfiles(values ...string) *WorkbenchSessionReaperProfileApplyConfiguration {
+	for i := range values {
+		b.ResourceProfiles = append(b.ResourceProfiles, values[i])
+	}
+	return b
+}
+
+// WithMaxAgeHours sets the MaxAgeHours field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxAgeHours field is set to the value of the last call.
+func (b *WorkbenchSessionReaperProfileApplyConfiguration) WithMaxAgeHours(value int32) *WorkbenchSessionReaperProfileApplyConfiguration {
+	b.MaxAgeHours = &value
+	return b
+}
@@ -6,47 +6,67 @@
 package v1beta1
 
 import (
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
 	product "github.com/posit-dev/team-operator/api/product"
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
 // WorkbenchSpecApplyConfiguration represents a declarative configuration of the WorkbenchSpec type for use
 // with apply.
 type WorkbenchSpecApplyConfiguration struct {
-	License                              *product.LicenseSpec                      `json:"license,omitempty"`
-	Config                               *WorkbenchConfigApplyConfiguration        `json:"config,omitempty"`
-	SecretConfig                         *WorkbenchSecretConfigApplyConfiguration  `json:"secretConfig,omitempty"`
-	SessionConfig                        *product.SessionConfig                    `json:"sessionConfig,omitempty"`
-	Volume                               *product.VolumeSpec                       `json:"volume,omitempty"`
-	SecretType                           *product.SiteSecretType                   `json:"secretType,omitempty"`
-	Auth                                 *AuthSpecApplyConfiguration               `json:"auth,omitempty"`
-	Url                                  *string                                   `json:"url,omitempty"`
-	ParentUrl                            *string                                   `json:"parentUrl,omitempty"`
-	NonRoot                              *bool                                     `json:"nonRoot,omitempty"`
-	DatabaseConfig                       *PostgresDatabaseConfigApplyConfiguration `json:"databaseConfig,omitempty"`
-	IngressClass                         *string                                   `json:"ingressClass,omitempty"`
-	IngressAnnotations                   map[string]string                         `json:"ingressAnnotations,omitempty"`
-	ImagePullSecrets                     []string                                  `json:"imagePullSecrets,omitempty"`
-	NodeSelector                         map[string]string                         `json:"nodeSelector,omitempty"`
-	Tolerations                          []v1.Toleration                           `json:"tolerations,omitempty"`
-	AddEnv                               map[string]string                         `json:"addEnv,omitempty"`
-	OffHostExecution                     *bool                                     `json:"offHostExecution,omitempty"`
-	Image                                *string                                   `json:"image,omitempty"`
-	ImagePullPolicy                      *v1.PullPolicy                            `json:"imagePullPolicy,omitempty"`
-	Sleep                                *bool                                     `json:"sleep,omitempty"`
-	Snowflake                            *SnowflakeConfigApplyConfiguration        `json:"snowflake,omitempty"`
-	AwsAccountId                         *string                                   `json:"awsAccountId,omitempty"`
-	ClusterDate                          *string                                   `json:"clusterDate,omitempty"`
-	WorkloadCompoundName                 *string                                   `json:"workloadCompoundName,omitempty"`
-	ChronicleAgentImage                  *string                                   `json:"chronicleImage,omitempty"`
-	AdditionalVolumes                    []product.VolumeSpec                      `json:"additionalVolumes,omitempty"`
-	Secret                               *SecretConfigApplyConfiguration           `json:"secret,omitempty"`
-	WorkloadSecret                       *SecretConfigApplyConfiguration           `json:"workloadSecret,omitempty"`
-	MainDatabaseCredentialSecret         *SecretConfigApplyConfiguration           `json:"mainDatabaseCredentialSecret,omitempty"`
-	Replicas                             *int                                      `json:"replicas,omitempty"`
-	DsnSecret                            *string                                   `json:"dsnSecret,omitempty"`
-	ChronicleSidecarProductApiKeyEnabled *bool                                     `json:"chronicleSidecarProductApiKeyEnabled,omitempty"`
-	AuthLoginPageHtml                    *string                                   `json:"authLoginPageHtml,omitempty"`
+	License                               *product.LicenseSpec                      `json:"license,omitempty"`
+	Config                                *WorkbenchConfigApplyConfiguration        `json:"config,omitempty"`
+	SecretConfig                          *WorkbenchSecretConfigApplyConfiguration  `json:"secretConfig,omitempty"`
+	SessionConfig                         *product.SessionConfig                    `json:"sessionConfig,omitempty"`
+	Volume                                *product.VolumeSpec                       `json:"volume,omitempty"`
+	SecretType                            *product.SiteSecretType                   `json:"secretType,omitempty"`
+	Auth                                  *AuthSpecApplyConfiguration               `json:"auth,omitempty"`
+	Url                                   *string                                   `json:"url,omitempty"`
+	ParentUrl                             *string                                   `json:"parentUrl,omitempty"`
+	RootPath                              *string                                   `json:"rootPath,omitempty"`
+	Aliases                               []string                                  `json:"aliases,omitempty"`
+	TLSSecretName                         *string                                   `json:"tlsSecretName,omitempty"`
+	NonRoot                               *bool                                     `json:"nonRoot,omitempty"`
+	DatabaseConfig                        *PostgresDatabaseConfigApplyConfiguration `json:"databaseConfig,omitempty"`
+	IngressClass                          *string                                   `json:"ingressClass,omitempty"`
+	IngressAnnotations                    map[string]string                         `json:"ingressAnnotations,omitempty"`
+	Routing                               *RoutingConfigApplyConfiguration          `json:"routing,omitempty"`
+	IngressPolicy                         *IngressPolicyApplyConfiguration          `json:"ingressPolicy,omitempty"`
+	ImagePullSecrets                      []string                                  `json:"imagePullSecrets,omitempty"`
+	NodeSelector                          map[string]string                         `json:"nodeSelector,omitempty"`
+	PodSchedulingConfigApplyConfiguration `json:",inline"`
+	SecurityProfile                       *corev1beta1.SecurityProfile                  `json:"securityProfile,omitempty"`
+	Overrides                             []OverrideApplyConfiguration                  `json:"overrides,omitempty"`
+	SessionImageSelector                  *v1.LabelSelectorApplyConfiguration           `json:"sessionImageSelector,omitempty"`
+	SessionReaper                         *WorkbenchSessionReaperApplyConfiguration     `json:"sessionReaper,omitempty"`
+	UserSelector                          *v1.LabelSelectorApplyConfiguration           `json:"userSelector,omitempty"`
+	ExtensionCache                        *WorkbenchExtensionCacheApplyConfiguration    `json:"extensionCache,omitempty"`
+	LauncherTemplates                     *WorkbenchLauncherTemplatesApplyConfiguration `json:"launcherTemplates,omitempty"`
+	SessionQuota                          *SessionQuotaApplyConfiguration               `json:"sessionQuota,omitempty"`
+	Repositories                          []WorkbenchRepositoryApplyConfiguration       `json:"repositories,omitempty"`
+	SessionNamespace                      *string                                       `json:"sessionNamespace,omitempty"`
+	AddEnv                                map[string]string                             `json:"addEnv,omitempty"`
+	OffHostExecution                      *bool                                         `json:"offHostExecution,omitempty"`
+	Image                                 *string                                       `json:"image,omitempty"`
+	ImagePullPolicy                       *corev1.PullPolicy                            `json:"imagePullPolicy,omitempty"`
+	Sleep                                 *bool                                         `json:"sleep,omitempty"`
+	Snowflake                             *SnowflakeConfigApplyConfiguration            `json:"snowflake,omitempty"`
+	AwsAccountId                          *string                                       `json:"awsAccountId,omitempty"`
+	ClusterDate                           *string                                       `json:"clusterDate,omitempty"`
+	WorkloadCompoundName                  *string                                       `json:"workloadCompoundName,omitempty"`
+	ChronicleAgentImage                   *string                                       `json:"chronicleImage,omitempty"`
+	AdditionalVolumes                     []product.VolumeSpec                          `json:"additionalVolumes,omitempty"`
+	Secret                                *SecretConfigApplyConfiguration               `json:"secret,omitempty"`
+	WorkloadSecret                        *SecretConfigApplyConfiguration               `json:"workloadSecret,omitempty"`
+	MainDatabaseCredentialSecret          *SecretConfigApplyConfiguration               `json:"mainDatabaseCredentialSecret,omitempty"`
+	Replicas                              *int                                          `json:"replicas,omitempty"`
+	Autoscaling                           *AutoscalingConfigApplyConfiguration          `json:"autoscaling,omitempty"`
+	Resources                             *corev1.ResourceRequirements                  `json:"resources,omitempty"`
+	ChronicleAgentResources               *corev1.ResourceRequirements                  `json:"chronicleAgentResources,omitempty"`
+	DsnSecret                             *string                                       `json:"dsnSecret,omitempty"`
+	ChronicleSidecarProductApiKeyEnabled  *bool                                         `json:"chronicleSidecarProductApiKeyEnabled,omitempty"`
+	AuthLoginPageHtml                     *string                                       `json:"authLoginPageHtml,omitempty"`
 }
 
 // WorkbenchSpecApplyConfiguration constructs a declarative configuration of the WorkbenchSpec type for use with
@@ -127,6 +147,32 @@ func (b *WorkbenchSpecApplyConfiguration) WithParentUrl(value string) *Workbench
 	return b
 }
 
+// WithRootPath sets the RootPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RootPath field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithRootPath(value string) *WorkbenchSpecApplyConfiguration {
+	b.RootPath = &value
+	return b
+}
+
+// WithAliases adds the given value to the Aliases field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Aliases field.
+func (b *WorkbenchSpecApplyConfiguration) WithAliases(values ...string) *WorkbenchSpecApplyConfiguration {
+	for i := range values {
+		b.Aliases = append(b.Aliases, values[i])
+	}
+	return b
+}
+
+// WithTLSSecretName sets the TLSSecretName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TLSSecretName field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithTLSSecretName(value string) *WorkbenchSpecApplyConfiguration {
+	b.TLSSecretName = &value
+	return b
+}
+
 // WithNonRoot sets the NonRoot field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the NonRoot field is set to the value of the last call.
@@ -165,6 +211,22 @@ func (b *WorkbenchSpecApplyConfiguration) WithIngressAnnotations(entries map[str
 	return b
 }
 
+// WithRouting sets the Routing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Routing field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithRouting(value *RoutingConfigApplyConfiguration) *WorkbenchSpecApplyConfiguration {
+	b.Routing = value
+	return b
+}
+
+// WithIngressPolicy sets the IngressPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IngressPolicy field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithIngressPolicy(value *IngressPolicyApplyConfiguration) *WorkbenchSpecApplyConfiguration {
+	b.IngressPolicy = value
+	return b
+}
+
 // WithImagePullSecrets adds the given value to the ImagePullSecrets field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the ImagePullSecrets field.
@@ -189,16 +251,160 @@ func (b *WorkbenchSpecApplyConfiguration) WithNodeSelector(entries map[string]st
 	return b
 }
 
+// WithAffinity sets the Affinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Affinity field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithAffinity(value corev1.Affinity) *WorkbenchSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.Affinity = &value
+	return b
+}
+
 // WithTolerations adds the given value to the Tolerations field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Tolerations field.
-func (b *WorkbenchSpecApplyConfiguration) WithTolerations(values ...v1.Toleration) *WorkbenchSpecApplyConfiguration {
+func (b *WorkbenchSpecApplyConfiguration) WithTolerations(values ...corev1.Toleration) *WorkbenchSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.Tolerations = append(b.PodSchedulingConfigApplyConfiguration.Tolerations, values[i])
+	}
+	return b
+}
+
+// WithTopologySpreadConstraints adds the given value to the TopologySpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopologySpreadConstraints field.
+func (b *WorkbenchSpecApplyConfiguration) WithTopologySpreadConstraints(values ...corev1.TopologySpreadConstraint) *WorkbenchSpecApplyConfiguration {
+	for i := range values {
+		b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints = append(b.PodSchedulingConfigApplyConfiguration.TopologySpreadConstraints, values[i])
+	}
+	return b
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithPriorityClassName(value string) *WorkbenchSpecApplyConfiguration {
+	b.PodSchedulingConfigApplyConfiguration.PriorityClassName = &value
+	return b
+}
+
+// WithPodAnnotations puts the entries into the PodAnnotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodAnnotations field,
+// overwriting an existing map entries in PodAnnotations field with the same key.
+func (b *WorkbenchSpecApplyConfiguration) WithPodAnnotations(entries map[string]string) *WorkbenchSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodAnnotations == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodAnnotations[k] = v
+	}
+	return b
+}
+
+// WithPodLabels puts the entries into the PodLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PodLabels field,
+// overwriting an existing map entries in PodLabels field with the same key.
+func (b *WorkbenchSpecApplyConfiguration) WithPodLabels(entries map[string]string) *WorkbenchSpecApplyConfiguration {
+	if b.PodSchedulingConfigApplyConfiguration.PodLabels == nil && len(entries) > 0 {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.PodSchedulingConfigApplyConfiguration.PodLabels[k] = v
+	}
+	return b
+}
+
+// WithSecurityProfile sets the SecurityProfile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecurityProfile field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithSecurityProfile(value corev1beta1.SecurityProfile) *WorkbenchSpecApplyConfiguration {
+	b.SecurityProfile = &value
+	return b
+}
+
+// WithOverrides adds the given value to the Overrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Overrides field.
+func (b *WorkbenchSpecApplyConfiguration) WithOverrides(values ...*OverrideApplyConfiguration) *WorkbenchSpecApplyConfiguration {
 	for i := range values {
-		b.Tolerations = append(b.Tolerations, values[i])
+		if values[i] == nil {
+			panic("nil value passed to WithOverrides")
+		}
+		b.Overrides = append(b.Overrides, *values[i])
 	}
 	return b
 }
 
+// WithSessionImageSelector sets the SessionImageSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionImageSelector field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithSessionImageSelector(value *v1.LabelSelectorApplyConfiguration) *WorkbenchSpecApplyConfiguration {
+	b.SessionImageSelector = value
+	return b
+}
+
+// WithSessionReaper sets the SessionReaper field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionReaper field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithSessionReaper(value *WorkbenchSessionReaperApplyConfiguration) *WorkbenchSpecApplyConfiguration {
+	b.SessionReaper = value
+	return b
+}
+
+// WithUserSelector sets the UserSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UserSelector field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithUserSelector(value *v1.LabelSelectorApplyConfiguration) *WorkbenchSpecApplyConfiguration {
+	b.UserSelector = value
+	return b
+}
+
+// WithExtensionCache sets the ExtensionCache field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExtensionCache field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithExtensionCache(value *WorkbenchExtensionCacheApplyConfiguration) *WorkbenchSpecApplyConfiguration {
+	b.ExtensionCache = value
+	return b
+}
+
+// WithLauncherTemplates sets the LauncherTemplates field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LauncherTemplates field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithLauncherTemplates(value *WorkbenchLauncherTemplatesApplyConfiguration) *WorkbenchSpecApplyConfiguration {
+	b.LauncherTemplates = value
+	return b
+}
+
+// WithSessionQuota sets the SessionQuota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionQuota field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithSessionQuota(value *SessionQuotaApplyConfiguration) *WorkbenchSpecApplyConfiguration {
+	b.SessionQuota = value
+	return b
+}
+
+// WithRepositories adds the given value to the Repositories field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Repositories field.
+func (b *WorkbenchSpecApplyConfiguration) WithRepositories(values ...*WorkbenchRepositoryApplyConfiguration) *WorkbenchSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithRepositories")
+		}
+		b.Repositories = append(b.Repositories, *values[i])
+	}
+	return b
+}
+
+// WithSessionNamespace sets the SessionNamespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionNamespace field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithSessionNamespace(value string) *WorkbenchSpecApplyConfiguration {
+	b.SessionNamespace = &value
+	return b
+}
+
 // WithAddEnv puts the entries into the AddEnv field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the AddEnv field,
@@ -232,7 +438,7 @@ func (b *WorkbenchSpecApplyConfiguration) WithImage(value string) *WorkbenchSpec
 // WithImagePullPolicy sets the ImagePullPolicy field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ImagePullPolicy field is set to the value of the last call.
-func (b *WorkbenchSpecApplyConfiguration) WithImagePullPolicy(value v1.PullPolicy) *WorkbenchSpecApplyConfiguration {
+func (b *WorkbenchSpecApplyConfiguration) WithImagePullPolicy(value corev1.PullPolicy) *WorkbenchSpecApplyConfiguration {
 	b.ImagePullPolicy = &value
 	return b
 }
@@ -327,6 +533,30 @@ func (b *WorkbenchSpecApplyConfiguration) WithReplicas(value int) *WorkbenchSpec
 	return b
 }
 
+// WithAutoscaling sets the Autoscaling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Autoscaling field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithAutoscaling(value *AutoscalingConfigApplyConfiguration) *WorkbenchSpecApplyConfiguration {
+	b.Autoscaling = value
+	return b
+}
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithResources(value corev1.ResourceRequirements) *WorkbenchSpecApplyConfiguration {
+	b.Resources = &value
+	return b
+}
+
+// WithChronicleAgentResources sets the ChronicleAgentResources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ChronicleAgentResources field is set to the value of the last call.
+func (b *WorkbenchSpecApplyConfiguration) WithChronicleAgentResources(value corev1.ResourceRequirements) *WorkbenchSpecApplyConfiguration {
+	b.ChronicleAgentResources = &value
+	return b
+}
+
 // WithDsnSecret sets the DsnSecret field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the DsnSecret field is set to the value of the last call.
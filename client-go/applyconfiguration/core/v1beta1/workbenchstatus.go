@@ -7,13 +7,18 @@ package v1beta1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
 // WorkbenchStatusApplyConfiguration represents a declarative configuration of the WorkbenchStatus type for use
 // with apply.
 type WorkbenchStatusApplyConfiguration struct {
-	Ready        *bool               `json:"ready,omitempty"`
-	KeySecretRef *v1.SecretReference `json:"keySecretRef,omitempty"`
+	Ready           *bool                                            `json:"ready,omitempty"`
+	KeySecretRef    *v1.SecretReference                              `json:"keySecretRef,omitempty"`
+	FailedOverrides []string                                         `json:"failedOverrides,omitempty"`
+	ExtensionCache  *WorkbenchExtensionCacheStatusApplyConfiguration `json:"extensionCache,omitempty"`
+	SessionQuota    *SessionQuotaStatusApplyConfiguration            `json:"sessionQuota,omitempty"`
+	Conditions      []metav1.ConditionApplyConfiguration             `json:"conditions,omitempty"`
 }
 
 // WorkbenchStatusApplyConfiguration constructs a declarative configuration of the WorkbenchStatus type for use with
@@ -37,3 +42,42 @@ func (b *WorkbenchStatusApplyConfiguration) WithKeySecretRef(value v1.SecretRefe
 	b.KeySecretRef = &value
 	return b
 }
+
+// WithFailedOverrides adds the given value to the FailedOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FailedOverrides field.
+func (b *WorkbenchStatusApplyConfiguration) WithFailedOverrides(values ...string) *WorkbenchStatusApplyConfiguration {
+	for i := range values {
+		b.FailedOverrides = append(b.FailedOverrides, values[i])
+	}
+	return b
+}
+
+// WithExtensionCache sets the ExtensionCache field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExtensionCache field is set to the value of the last call.
+func (b *WorkbenchStatusApplyConfiguration) WithExtensionCache(value *WorkbenchExtensionCacheStatusApplyConfiguration) *WorkbenchStatusApplyConfiguration {
+	b.ExtensionCache = value
+	return b
+}
+
+// WithSessionQuota sets the SessionQuota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SessionQuota field is set to the value of the last call.
+func (b *WorkbenchStatusApplyConfiguration) WithSessionQuota(value *SessionQuotaStatusApplyConfiguration) *WorkbenchStatusApplyConfiguration {
+	b.SessionQuota = value
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *WorkbenchStatusApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *WorkbenchStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// WorkbenchUserHomeSeedApplyConfiguration represents a declarative configuration of the WorkbenchUserHomeSeed type for use
+// with apply.
+type WorkbenchUserHomeSeedApplyConfiguration struct {
+	ConfigMapName *string `json:"configMapName,omitempty"`
+}
+
+// WorkbenchUserHomeSeedApplyConfiguration constructs a declarative configuration of the WorkbenchUserHomeSeed type for use with
+// apply.
+func WorkbenchUserHomeSeed() *WorkbenchUserHomeSeedApplyConfiguration {
+	return &WorkbenchUserHomeSeedApplyConfiguration{}
+}
+
+// WithConfigMapName sets the ConfigMapName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConfigMapName field is set to the value of the last call.
+func (b *WorkbenchUserHomeSeedApplyConfiguration) WithConfigMapName(value string) *WorkbenchUserHomeSeedApplyConfiguration {
+	b.ConfigMapName = &value
+	return b
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// WorkbenchUserSpecApplyConfiguration represents a declarative configuration of the WorkbenchUserSpec type for use
+// with apply.
+type WorkbenchUserSpecApplyConfiguration struct {
+	Username *string                                  `json:"username,omitempty"`
+	UID      *int64                                   `json:"uid,omitempty"`
+	GID      *int64                                   `json:"gid,omitempty"`
+	Groups   []string                                 `json:"groups,omitempty"`
+	FullName *string                                  `json:"fullName,omitempty"`
+	Shell    *string                                  `json:"shell,omitempty"`
+	HomeSeed *WorkbenchUserHomeSeedApplyConfiguration `json:"homeSeed,omitempty"`
+}
+
+// WorkbenchUserSpecApplyConfiguration constructs a declarative configuration of the WorkbenchUserSpec type for use with
+// apply.
+func WorkbenchUserSpec() *WorkbenchUserSpecApplyConfiguration {
+	return &WorkbenchUserSpecApplyConfiguration{}
+}
+
+// WithUsername sets the Username field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Username field is set to the value of the last call.
+func (b *WorkbenchUserSpecApplyConfiguration) WithUsername(value string) *WorkbenchUserSpecApplyConfiguration {
+	b.Username = &value
+	return b
+}
+
+// WithUID sets the UID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UID field is set to the value of the last call.
+func (b *WorkbenchUserSpecApplyConfiguration) WithUID(value int64) *WorkbenchUserSpecApplyConfiguration {
+	b.UID = &value
+	return b
+}
+
+// WithGID sets the GID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GID field is set to the value of the last call.
+func (b *WorkbenchUserSpecApplyConfiguration) WithGID(value int64) *WorkbenchUserSpecApplyConfiguration {
+	b.GID = &value
+	return b
+}
+
+// WithGroups adds the given value to the Groups field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Groups field.
+func (b *WorkbenchUserSpecApplyConfiguration) WithGroups(values ...string) *WorkbenchUserSpecApplyConfiguration {
+	for i := range values {
+		b.Groups = append(b.Groups, values[i])
+	}
+	return b
+}
+
+// WithFullName sets the FullName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FullName field is set to the value of the last call.
+func (b *WorkbenchUserSpecApplyConfiguration) WithFullName(value string) *WorkbenchUserSpecApplyConfiguration {
+	b.FullName = &value
+	return b
+}
+
+// WithShell sets the Shell field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Shell field is set to the value of the last call.
+func (b *WorkbenchUserSpecApplyConfiguration) WithShell(value string) *WorkbenchUserSpecApplyConfiguration {
+	b.Shell = &value
+	return b
+}
+
+// WithHomeSeed sets the HomeSeed field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HomeSeed field is set to the value of the last call.
+func (b *WorkbenchUserSpecApplyConfiguration) WithHomeSeed(value *WorkbenchUserHomeSeedApplyConfiguration) *WorkbenchUserSpecApplyConfiguration {
+	b.HomeSeed = value
+	return b
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// WorkbenchUserStatusApplyConfiguration represents a declarative configuration of the WorkbenchUserStatus type for use
+// with apply.
+type WorkbenchUserStatusApplyConfiguration struct {
+	Sites     []string `json:"sites,omitempty"`
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
+// WorkbenchUserStatusApplyConfiguration constructs a declarative configuration of the WorkbenchUserStatus type for use with
+// apply.
+func WorkbenchUserStatus() *WorkbenchUserStatusApplyConfiguration {
+	return &WorkbenchUserStatusApplyConfiguration{}
+}
+
+// WithSites adds the given value to the Sites field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Sites field.
+func (b *WorkbenchUserStatusApplyConfiguration) WithSites(values ...string) *WorkbenchUserStatusApplyConfiguration {
+	for i := range values {
+		b.Sites = append(b.Sites, values[i])
+	}
+	return b
+}
+
+// WithConflicts adds the given value to the Conflicts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conflicts field.
+func (b *WorkbenchUserStatusApplyConfiguration) WithConflicts(values ...string) *WorkbenchUserStatusApplyConfiguration {
+	for i := range values {
+		b.Conflicts = append(b.Conflicts, values[i])
+	}
+	return b
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2alpha1
+
+// KeycloakAdditionalOptionApplyConfiguration represents a declarative configuration of the KeycloakAdditionalOption type for use
+// with apply.
+type KeycloakAdditionalOptionApplyConfiguration struct {
+	Name  *string `json:"name,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+// KeycloakAdditionalOptionApplyConfiguration constructs a declarative configuration of the KeycloakAdditionalOption type for use with
+// apply.
+func KeycloakAdditionalOption() *KeycloakAdditionalOptionApplyConfiguration {
+	return &KeycloakAdditionalOptionApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *KeycloakAdditionalOptionApplyConfiguration) WithName(value string) *KeycloakAdditionalOptionApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithValue sets the Value field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Value field is set to the value of the last call.
+func (b *KeycloakAdditionalOptionApplyConfiguration) WithValue(value string) *KeycloakAdditionalOptionApplyConfiguration {
+	b.Value = &value
+	return b
+}
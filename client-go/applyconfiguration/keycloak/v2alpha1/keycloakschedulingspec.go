@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// KeycloakSchedulingSpecApplyConfiguration represents a declarative configuration of the KeycloakSchedulingSpec type for use
+// with apply.
+type KeycloakSchedulingSpecApplyConfiguration struct {
+	Affinity                  *v1.Affinity                  `json:"affinity,omitempty"`
+	Tolerations               []v1.Toleration               `json:"tolerations,omitempty"`
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	PriorityClassName         *string                       `json:"priorityClassName,omitempty"`
+}
+
+// KeycloakSchedulingSpecApplyConfiguration constructs a declarative configuration of the KeycloakSchedulingSpec type for use with
+// apply.
+func KeycloakSchedulingSpec() *KeycloakSchedulingSpecApplyConfiguration {
+	return &KeycloakSchedulingSpecApplyConfiguration{}
+}
+
+// WithAffinity sets the Affinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Affinity field is set to the value of the last call.
+func (b *KeycloakSchedulingSpecApplyConfiguration) WithAffinity(value v1.Affinity) *KeycloakSchedulingSpecApplyConfiguration {
+	b.Affinity = &value
+	return b
+}
+
+// WithTolerations adds the given value to the Tolerations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tolerations field.
+func (b *KeycloakSchedulingSpecApplyConfiguration) WithTolerations(values ...v1.Toleration) *KeycloakSchedulingSpecApplyConfiguration {
+	for i := range values {
+		b.Tolerations = append(b.Tolerations, values[i])
+	}
+	return b
+}
+
+// WithTopologySpreadConstraints adds the given value to the TopologySpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopologySpreadConstraints field.
+func (b *KeycloakSchedulingSpecApplyConfiguration) WithTopologySpreadConstraints(values ...v1.TopologySpreadConstraint) *KeycloakSchedulingSpecApplyConfiguration {
+	for i := range values {
+		b.TopologySpreadConstraints = append(b.TopologySpreadConstraints, values[i])
+	}
+	return b
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *KeycloakSchedulingSpecApplyConfiguration) WithPriorityClassName(value string) *KeycloakSchedulingSpecApplyConfiguration {
+	b.PriorityClassName = &value
+	return b
+}
@@ -8,15 +8,17 @@ package v2alpha1
 // KeycloakSpecApplyConfiguration represents a declarative configuration of the KeycloakSpec type for use
 // with apply.
 type KeycloakSpecApplyConfiguration struct {
-	Db          *KeycloakDbSpecApplyConfiguration          `json:"db,omitempty"`
-	Http        *KeycloakHttpSpecApplyConfiguration        `json:"http,omitempty"`
-	Hostname    *KeycloakHostnameSpecApplyConfiguration    `json:"hostname,omitempty"`
-	Features    *KeycloakFeaturesSpecApplyConfiguration    `json:"features,omitempty"`
-	Transaction *KeycloakTransactionSpecApplyConfiguration `json:"transaction,omitempty"`
-	Unsupported *KeycloakUnsupportedSpecApplyConfiguration `json:"unsupported,omitempty"`
-	Ingress     *KeycloakIngressSpecApplyConfiguration     `json:"ingress,omitempty"`
-	Instances   *int                                       `json:"instances,omitempty"`
-	Image       *string                                    `json:"image,omitempty"`
+	Db                *KeycloakDbSpecApplyConfiguration            `json:"db,omitempty"`
+	Http              *KeycloakHttpSpecApplyConfiguration          `json:"http,omitempty"`
+	Hostname          *KeycloakHostnameSpecApplyConfiguration      `json:"hostname,omitempty"`
+	Features          *KeycloakFeaturesSpecApplyConfiguration      `json:"features,omitempty"`
+	Transaction       *KeycloakTransactionSpecApplyConfiguration   `json:"transaction,omitempty"`
+	Unsupported       *KeycloakUnsupportedSpecApplyConfiguration   `json:"unsupported,omitempty"`
+	Ingress           *KeycloakIngressSpecApplyConfiguration       `json:"ingress,omitempty"`
+	Scheduling        *KeycloakSchedulingSpecApplyConfiguration    `json:"scheduling,omitempty"`
+	Instances         *int                                         `json:"instances,omitempty"`
+	Image             *string                                      `json:"image,omitempty"`
+	AdditionalOptions []KeycloakAdditionalOptionApplyConfiguration `json:"additionalOptions,omitempty"`
 }
 
 // KeycloakSpecApplyConfiguration constructs a declarative configuration of the KeycloakSpec type for use with
@@ -81,6 +83,14 @@ func (b *KeycloakSpecApplyConfiguration) WithIngress(value *KeycloakIngressSpecA
 	return b
 }
 
+// WithScheduling sets the Scheduling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Scheduling field is set to the value of the last call.
+func (b *KeycloakSpecApplyConfiguration) WithScheduling(value *KeycloakSchedulingSpecApplyConfiguration) *KeycloakSpecApplyConfiguration {
+	b.Scheduling = value
+	return b
+}
+
 // WithInstances sets the Instances field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Instances field is set to the value of the last call.
@@ -96,3 +106,16 @@ func (b *KeycloakSpecApplyConfiguration) WithImage(value string) *KeycloakSpecAp
 	b.Image = &value
 	return b
 }
+
+// WithAdditionalOptions adds the given value to the AdditionalOptions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AdditionalOptions field.
+func (b *KeycloakSpecApplyConfiguration) WithAdditionalOptions(values ...*KeycloakAdditionalOptionApplyConfiguration) *KeycloakSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAdditionalOptions")
+		}
+		b.AdditionalOptions = append(b.AdditionalOptions, *values[i])
+	}
+	return b
+}
@@ -25,6 +25,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1beta1.ApiSettingsConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AuthSpec"):
 		return &corev1beta1.AuthSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AutoscalingConfig"):
+		return &corev1beta1.AutoscalingConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AzureFilesConfig"):
 		return &corev1beta1.AzureFilesConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("Chronicle"):
@@ -59,6 +61,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1beta1.ConnectConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ConnectDatabaseConfig"):
 		return &corev1beta1.ConnectDatabaseConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ConnectExecutionEnvironment"):
+		return &corev1beta1.ConnectExecutionEnvironmentApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ConnectHttpConfig"):
 		return &corev1beta1.ConnectHttpConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ConnectLauncherConfig"):
@@ -77,6 +81,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1beta1.ConnectQuartoConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ConnectRConfig"):
 		return &corev1beta1.ConnectRConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ConnectRuntimeInstallation"):
+		return &corev1beta1.ConnectRuntimeInstallationApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ConnectSamlConfig"):
 		return &corev1beta1.ConnectSamlConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ConnectSchedulerConfig"):
@@ -93,6 +99,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1beta1.DatabaseSettingsApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("DatabricksConfig"):
 		return &corev1beta1.DatabricksConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ExternalDNSConfig"):
+		return &corev1beta1.ExternalDNSConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FeatureEnablerConfig"):
 		return &corev1beta1.FeatureEnablerConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("Flightdeck"):
@@ -101,8 +109,18 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1beta1.FlightdeckSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FlightdeckStatus"):
 		return &corev1beta1.FlightdeckStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("GatewayParentRef"):
+		return &corev1beta1.GatewayParentRefApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("GPUSettings"):
 		return &corev1beta1.GPUSettingsApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("IngressIPAllowList"):
+		return &corev1beta1.IngressIPAllowListApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("IngressPolicy"):
+		return &corev1beta1.IngressPolicyApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("IngressRateLimit"):
+		return &corev1beta1.IngressRateLimitApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("IngressRetry"):
+		return &corev1beta1.IngressRetryApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("InternalChronicleSpec"):
 		return &corev1beta1.InternalChronicleSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("InternalConnectExperimentalFeatures"):
@@ -119,6 +137,18 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1beta1.InternalWorkbenchExperimentalFeaturesApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("InternalWorkbenchSpec"):
 		return &corev1beta1.InternalWorkbenchSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NetworkPolicyConfig"):
+		return &corev1beta1.NetworkPolicyConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NetworkPolicyEgressRules"):
+		return &corev1beta1.NetworkPolicyEgressRulesApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NetworkPolicyFQDNs"):
+		return &corev1beta1.NetworkPolicyFQDNsApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NetworkPolicyIngressRules"):
+		return &corev1beta1.NetworkPolicyIngressRulesApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NetworkPolicyStatus"):
+		return &corev1beta1.NetworkPolicyStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("Override"):
+		return &corev1beta1.OverrideApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PackageManager"):
 		return &corev1beta1.PackageManagerApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PackageManagerConfig"):
@@ -149,6 +179,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1beta1.PackageManagerStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PackageManagerStorageConfig"):
 		return &corev1beta1.PackageManagerStorageConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PodSchedulingConfig"):
+		return &corev1beta1.PodSchedulingConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PositronConfig"):
 		return &corev1beta1.PositronConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PostgresDatabase"):
@@ -159,6 +191,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1beta1.PostgresDatabaseSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PostgresDatabaseSpecTeardown"):
 		return &corev1beta1.PostgresDatabaseSpecTeardownApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("RoutingConfig"):
+		return &corev1beta1.RoutingConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("RPackageRepositoryConfig"):
 		return &corev1beta1.RPackageRepositoryConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("SecretConfig"):
@@ -167,10 +201,24 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1beta1.SecretReferenceApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ServiceAccountConfig"):
 		return &corev1beta1.ServiceAccountConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SessionImage"):
+		return &corev1beta1.SessionImageApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SessionImageSpec"):
+		return &corev1beta1.SessionImageSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SessionImageStatus"):
+		return &corev1beta1.SessionImageStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SessionLimitRange"):
+		return &corev1beta1.SessionLimitRangeApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SessionQuota"):
+		return &corev1beta1.SessionQuotaApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SessionQuotaStatus"):
+		return &corev1beta1.SessionQuotaStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("Site"):
 		return &corev1beta1.SiteApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("SiteSpec"):
 		return &corev1beta1.SiteSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SiteStatus"):
+		return &corev1beta1.SiteStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("SnowflakeConfig"):
 		return &corev1beta1.SnowflakeConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("SSHKeyConfig"):
@@ -193,6 +241,20 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1beta1.WorkbenchDatabricksConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchDcfConfig"):
 		return &corev1beta1.WorkbenchDcfConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchExtensionCache"):
+		return &corev1beta1.WorkbenchExtensionCacheApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchExtensionCacheConfig"):
+		return &corev1beta1.WorkbenchExtensionCacheConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchExtensionCacheStatus"):
+		return &corev1beta1.WorkbenchExtensionCacheStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchExtensionSource"):
+		return &corev1beta1.WorkbenchExtensionSourceApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchGroup"):
+		return &corev1beta1.WorkbenchGroupApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchGroupSpec"):
+		return &corev1beta1.WorkbenchGroupSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchGroupStatus"):
+		return &corev1beta1.WorkbenchGroupStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchIniConfig"):
 		return &corev1beta1.WorkbenchIniConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchJupyterConfig"):
@@ -211,8 +273,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1beta1.WorkbenchLauncherKubnernetesResourcesConfigSectionApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchLauncherLocalConfig"):
 		return &corev1beta1.WorkbenchLauncherLocalConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchLauncherProfile"):
+		return &corev1beta1.WorkbenchLauncherProfileApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchLauncherServerConfig"):
 		return &corev1beta1.WorkbenchLauncherServerConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchLauncherTemplates"):
+		return &corev1beta1.WorkbenchLauncherTemplatesApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchLoggingConfig"):
 		return &corev1beta1.WorkbenchLoggingConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchLoggingSection"):
@@ -227,6 +293,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1beta1.WorkbenchProfilesConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchRepoConfig"):
 		return &corev1beta1.WorkbenchRepoConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchRepository"):
+		return &corev1beta1.WorkbenchRepositoryApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchResourceProfilePodConfig"):
+		return &corev1beta1.WorkbenchResourceProfilePodConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchRServerConfig"):
 		return &corev1beta1.WorkbenchRServerConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchRSessionConfig"):
@@ -235,22 +305,40 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1beta1.WorkbenchSecretConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchSecretIniConfig"):
 		return &corev1beta1.WorkbenchSecretIniConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchSessionIdleLimits"):
+		return &corev1beta1.WorkbenchSessionIdleLimitsApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchSessionIniConfig"):
 		return &corev1beta1.WorkbenchSessionIniConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchSessionJsonConfig"):
 		return &corev1beta1.WorkbenchSessionJsonConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchSessionLifecycle"):
+		return &corev1beta1.WorkbenchSessionLifecycleApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchSessionNewlineConfig"):
 		return &corev1beta1.WorkbenchSessionNewlineConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchSessionReaper"):
+		return &corev1beta1.WorkbenchSessionReaperApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchSessionReaperProfile"):
+		return &corev1beta1.WorkbenchSessionReaperProfileApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchSpec"):
 		return &corev1beta1.WorkbenchSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchStatus"):
 		return &corev1beta1.WorkbenchStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchUser"):
+		return &corev1beta1.WorkbenchUserApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchUserHomeSeed"):
+		return &corev1beta1.WorkbenchUserHomeSeedApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchUserSpec"):
+		return &corev1beta1.WorkbenchUserSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchUserStatus"):
+		return &corev1beta1.WorkbenchUserStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkbenchVsCodeConfig"):
 		return &corev1beta1.WorkbenchVsCodeConfigApplyConfiguration{}
 
 		// Group=keycloak, Version=v2alpha1
 	case v2alpha1.SchemeGroupVersion.WithKind("Keycloak"):
 		return &keycloakv2alpha1.KeycloakApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("KeycloakAdditionalOption"):
+		return &keycloakv2alpha1.KeycloakAdditionalOptionApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("KeycloakDbSpec"):
 		return &keycloakv2alpha1.KeycloakDbSpecApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("KeycloakFeaturesSpec"):
@@ -261,6 +349,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &keycloakv2alpha1.KeycloakHttpSpecApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("KeycloakIngressSpec"):
 		return &keycloakv2alpha1.KeycloakIngressSpecApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("KeycloakSchedulingSpec"):
+		return &keycloakv2alpha1.KeycloakSchedulingSpecApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("KeycloakSecretSpec"):
 		return &keycloakv2alpha1.KeycloakSecretSpecApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("KeycloakSpec"):
@@ -20,8 +20,11 @@ type CoreV1beta1Interface interface {
 	FlightdecksGetter
 	PackageManagersGetter
 	PostgresDatabasesGetter
+	SessionImagesGetter
 	SitesGetter
 	WorkbenchesGetter
+	WorkbenchGroupsGetter
+	WorkbenchUsersGetter
 }
 
 // CoreV1beta1Client is used to interact with features provided by the core group.
@@ -49,6 +52,10 @@ func (c *CoreV1beta1Client) PostgresDatabases(namespace string) PostgresDatabase
 	return newPostgresDatabases(c, namespace)
 }
 
+func (c *CoreV1beta1Client) SessionImages(namespace string) SessionImageInterface {
+	return newSessionImages(c, namespace)
+}
+
 func (c *CoreV1beta1Client) Sites(namespace string) SiteInterface {
 	return newSites(c, namespace)
 }
@@ -57,6 +64,14 @@ func (c *CoreV1beta1Client) Workbenches(namespace string) WorkbenchInterface {
 	return newWorkbenches(c, namespace)
 }
 
+func (c *CoreV1beta1Client) WorkbenchGroups(namespace string) WorkbenchGroupInterface {
+	return newWorkbenchGroups(c, namespace)
+}
+
+func (c *CoreV1beta1Client) WorkbenchUsers(namespace string) WorkbenchUserInterface {
+	return newWorkbenchUsers(c, namespace)
+}
+
 // NewForConfig creates a new CoreV1beta1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
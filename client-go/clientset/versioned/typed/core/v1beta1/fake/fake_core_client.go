@@ -35,6 +35,10 @@ func (c *FakeCoreV1beta1) PostgresDatabases(namespace string) v1beta1.PostgresDa
 	return newFakePostgresDatabases(c, namespace)
 }
 
+func (c *FakeCoreV1beta1) SessionImages(namespace string) v1beta1.SessionImageInterface {
+	return newFakeSessionImages(c, namespace)
+}
+
 func (c *FakeCoreV1beta1) Sites(namespace string) v1beta1.SiteInterface {
 	return newFakeSites(c, namespace)
 }
@@ -43,6 +47,14 @@ func (c *FakeCoreV1beta1) Workbenches(namespace string) v1beta1.WorkbenchInterfa
 	return newFakeWorkbenches(c, namespace)
 }
 
+func (c *FakeCoreV1beta1) WorkbenchGroups(namespace string) v1beta1.WorkbenchGroupInterface {
+	return newFakeWorkbenchGroups(c, namespace)
+}
+
+func (c *FakeCoreV1beta1) WorkbenchUsers(namespace string) v1beta1.WorkbenchUserInterface {
+	return newFakeWorkbenchUsers(c, namespace)
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeCoreV1beta1) RESTClient() rest.Interface {
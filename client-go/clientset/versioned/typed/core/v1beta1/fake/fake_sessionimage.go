@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	corev1beta1 "github.com/posit-dev/team-operator/client-go/applyconfiguration/core/v1beta1"
+	typedcorev1beta1 "github.com/posit-dev/team-operator/client-go/clientset/versioned/typed/core/v1beta1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeSessionImages implements SessionImageInterface
+type fakeSessionImages struct {
+	*gentype.FakeClientWithListAndApply[*v1beta1.SessionImage, *v1beta1.SessionImageList, *corev1beta1.SessionImageApplyConfiguration]
+	Fake *FakeCoreV1beta1
+}
+
+func newFakeSessionImages(fake *FakeCoreV1beta1, namespace string) typedcorev1beta1.SessionImageInterface {
+	return &fakeSessionImages{
+		gentype.NewFakeClientWithListAndApply[*v1beta1.SessionImage, *v1beta1.SessionImageList, *corev1beta1.SessionImageApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1beta1.SchemeGroupVersion.WithResource("sessionimages"),
+			v1beta1.SchemeGroupVersion.WithKind("SessionImage"),
+			func() *v1beta1.SessionImage { return &v1beta1.SessionImage{} },
+			func() *v1beta1.SessionImageList { return &v1beta1.SessionImageList{} },
+			func(dst, src *v1beta1.SessionImageList) { dst.ListMeta = src.ListMeta },
+			func(list *v1beta1.SessionImageList) []*v1beta1.SessionImage {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1beta1.SessionImageList, items []*v1beta1.SessionImage) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
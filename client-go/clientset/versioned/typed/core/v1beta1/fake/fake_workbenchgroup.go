@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	corev1beta1 "github.com/posit-dev/team-operator/client-go/applyconfiguration/core/v1beta1"
+	typedcorev1beta1 "github.com/posit-dev/team-operator/client-go/clientset/versioned/typed/core/v1beta1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeWorkbenchGroups implements WorkbenchGroupInterface
+type fakeWorkbenchGroups struct {
+	*gentype.FakeClientWithListAndApply[*v1beta1.WorkbenchGroup, *v1beta1.WorkbenchGroupList, *corev1beta1.WorkbenchGroupApplyConfiguration]
+	Fake *FakeCoreV1beta1
+}
+
+func newFakeWorkbenchGroups(fake *FakeCoreV1beta1, namespace string) typedcorev1beta1.WorkbenchGroupInterface {
+	return &fakeWorkbenchGroups{
+		gentype.NewFakeClientWithListAndApply[*v1beta1.WorkbenchGroup, *v1beta1.WorkbenchGroupList, *corev1beta1.WorkbenchGroupApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1beta1.SchemeGroupVersion.WithResource("workbenchgroups"),
+			v1beta1.SchemeGroupVersion.WithKind("WorkbenchGroup"),
+			func() *v1beta1.WorkbenchGroup { return &v1beta1.WorkbenchGroup{} },
+			func() *v1beta1.WorkbenchGroupList { return &v1beta1.WorkbenchGroupList{} },
+			func(dst, src *v1beta1.WorkbenchGroupList) { dst.ListMeta = src.ListMeta },
+			func(list *v1beta1.WorkbenchGroupList) []*v1beta1.WorkbenchGroup {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1beta1.WorkbenchGroupList, items []*v1beta1.WorkbenchGroup) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
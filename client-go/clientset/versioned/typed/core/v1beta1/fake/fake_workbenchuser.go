@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	corev1beta1 "github.com/posit-dev/team-operator/client-go/applyconfiguration/core/v1beta1"
+	typedcorev1beta1 "github.com/posit-dev/team-operator/client-go/clientset/versioned/typed/core/v1beta1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeWorkbenchUsers implements WorkbenchUserInterface
+type fakeWorkbenchUsers struct {
+	*gentype.FakeClientWithListAndApply[*v1beta1.WorkbenchUser, *v1beta1.WorkbenchUserList, *corev1beta1.WorkbenchUserApplyConfiguration]
+	Fake *FakeCoreV1beta1
+}
+
+func newFakeWorkbenchUsers(fake *FakeCoreV1beta1, namespace string) typedcorev1beta1.WorkbenchUserInterface {
+	return &fakeWorkbenchUsers{
+		gentype.NewFakeClientWithListAndApply[*v1beta1.WorkbenchUser, *v1beta1.WorkbenchUserList, *corev1beta1.WorkbenchUserApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1beta1.SchemeGroupVersion.WithResource("workbenchusers"),
+			v1beta1.SchemeGroupVersion.WithKind("WorkbenchUser"),
+			func() *v1beta1.WorkbenchUser { return &v1beta1.WorkbenchUser{} },
+			func() *v1beta1.WorkbenchUserList { return &v1beta1.WorkbenchUserList{} },
+			func(dst, src *v1beta1.WorkbenchUserList) { dst.ListMeta = src.ListMeta },
+			func(list *v1beta1.WorkbenchUserList) []*v1beta1.WorkbenchUser {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1beta1.WorkbenchUserList, items []*v1beta1.WorkbenchUser) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
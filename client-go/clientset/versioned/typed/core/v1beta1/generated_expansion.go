@@ -15,6 +15,12 @@ type PackageManagerExpansion interface{}
 
 type PostgresDatabaseExpansion interface{}
 
+type SessionImageExpansion interface{}
+
 type SiteExpansion interface{}
 
 type WorkbenchExpansion interface{}
+
+type WorkbenchGroupExpansion interface{}
+
+type WorkbenchUserExpansion interface{}
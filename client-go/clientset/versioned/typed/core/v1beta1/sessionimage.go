@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	context "context"
+
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	applyconfigurationcorev1beta1 "github.com/posit-dev/team-operator/client-go/applyconfiguration/core/v1beta1"
+	scheme "github.com/posit-dev/team-operator/client-go/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// SessionImagesGetter has a method to return a SessionImageInterface.
+// A group's client should implement this interface.
+type SessionImagesGetter interface {
+	SessionImages(namespace string) SessionImageInterface
+}
+
+// SessionImageInterface has methods to work with SessionImage resources.
+type SessionImageInterface interface {
+	Create(ctx context.Context, sessionImage *corev1beta1.SessionImage, opts v1.CreateOptions) (*corev1beta1.SessionImage, error)
+	Update(ctx context.Context, sessionImage *corev1beta1.SessionImage, opts v1.UpdateOptions) (*corev1beta1.SessionImage, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, sessionImage *corev1beta1.SessionImage, opts v1.UpdateOptions) (*corev1beta1.SessionImage, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*corev1beta1.SessionImage, error)
+	List(ctx context.Context, opts v1.ListOptions) (*corev1beta1.SessionImageList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *corev1beta1.SessionImage, err error)
+	Apply(ctx context.Context, sessionImage *applyconfigurationcorev1beta1.SessionImageApplyConfiguration, opts v1.ApplyOptions) (result *corev1beta1.SessionImage, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, sessionImage *applyconfigurationcorev1beta1.SessionImageApplyConfiguration, opts v1.ApplyOptions) (result *corev1beta1.SessionImage, err error)
+	SessionImageExpansion
+}
+
+// sessionImages implements SessionImageInterface
+type sessionImages struct {
+	*gentype.ClientWithListAndApply[*corev1beta1.SessionImage, *corev1beta1.SessionImageList, *applyconfigurationcorev1beta1.SessionImageApplyConfiguration]
+}
+
+// newSessionImages returns a SessionImages
+func newSessionImages(c *CoreV1beta1Client, namespace string) *sessionImages {
+	return &sessionImages{
+		gentype.NewClientWithListAndApply[*corev1beta1.SessionImage, *corev1beta1.SessionImageList, *applyconfigurationcorev1beta1.SessionImageApplyConfiguration](
+			"sessionimages",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *corev1beta1.SessionImage { return &corev1beta1.SessionImage{} },
+			func() *corev1beta1.SessionImageList { return &corev1beta1.SessionImageList{} },
+		),
+	}
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	context "context"
+
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	applyconfigurationcorev1beta1 "github.com/posit-dev/team-operator/client-go/applyconfiguration/core/v1beta1"
+	scheme "github.com/posit-dev/team-operator/client-go/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// WorkbenchGroupsGetter has a method to return a WorkbenchGroupInterface.
+// A group's client should implement this interface.
+type WorkbenchGroupsGetter interface {
+	WorkbenchGroups(namespace string) WorkbenchGroupInterface
+}
+
+// WorkbenchGroupInterface has methods to work with WorkbenchGroup resources.
+type WorkbenchGroupInterface interface {
+	Create(ctx context.Context, workbenchGroup *corev1beta1.WorkbenchGroup, opts v1.CreateOptions) (*corev1beta1.WorkbenchGroup, error)
+	Update(ctx context.Context, workbenchGroup *corev1beta1.WorkbenchGroup, opts v1.UpdateOptions) (*corev1beta1.WorkbenchGroup, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, workbenchGroup *corev1beta1.WorkbenchGroup, opts v1.UpdateOptions) (*corev1beta1.WorkbenchGroup, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*corev1beta1.WorkbenchGroup, error)
+	List(ctx context.Context, opts v1.ListOptions) (*corev1beta1.WorkbenchGroupList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *corev1beta1.WorkbenchGroup, err error)
+	Apply(ctx context.Context, workbenchGroup *applyconfigurationcorev1beta1.WorkbenchGroupApplyConfiguration, opts v1.ApplyOptions) (result *corev1beta1.WorkbenchGroup, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, workbenchGroup *applyconfigurationcorev1beta1.WorkbenchGroupApplyConfiguration, opts v1.ApplyOptions) (result *corev1beta1.WorkbenchGroup, err error)
+	WorkbenchGroupExpansion
+}
+
+// workbenchGroups implements WorkbenchGroupInterface
+type workbenchGroups struct {
+	*gentype.ClientWithListAndApply[*corev1beta1.WorkbenchGroup, *corev1beta1.WorkbenchGroupList, *applyconfigurationcorev1beta1.WorkbenchGroupApplyConfiguration]
+}
+
+// newWorkbenchGroups returns a WorkbenchGroups
+func newWorkbenchGroups(c *CoreV1beta1Client, namespace string) *workbenchGroups {
+	return &workbenchGroups{
+		gentype.NewClientWithListAndApply[*corev1beta1.WorkbenchGroup, *corev1beta1.WorkbenchGroupList, *applyconfigurationcorev1beta1.WorkbenchGroupApplyConfiguration](
+			"workbenchgroups",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *corev1beta1.WorkbenchGroup { return &corev1beta1.WorkbenchGroup{} },
+			func() *corev1beta1.WorkbenchGroupList { return &corev1beta1.WorkbenchGroupList{} },
+		),
+	}
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	context "context"
+
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	applyconfigurationcorev1beta1 "github.com/posit-dev/team-operator/client-go/applyconfiguration/core/v1beta1"
+	scheme "github.com/posit-dev/team-operator/client-go/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// WorkbenchUsersGetter has a method to return a WorkbenchUserInterface.
+// A group's client should implement this interface.
+type WorkbenchUsersGetter interface {
+	WorkbenchUsers(namespace string) WorkbenchUserInterface
+}
+
+// WorkbenchUserInterface has methods to work with WorkbenchUser resources.
+type WorkbenchUserInterface interface {
+	Create(ctx context.Context, workbenchUser *corev1beta1.WorkbenchUser, opts v1.CreateOptions) (*corev1beta1.WorkbenchUser, error)
+	Update(ctx context.Context, workbenchUser *corev1beta1.WorkbenchUser, opts v1.UpdateOptions) (*corev1beta1.WorkbenchUser, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, workbenchUser *corev1beta1.WorkbenchUser, opts v1.UpdateOptions) (*corev1beta1.WorkbenchUser, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*corev1beta1.WorkbenchUser, error)
+	List(ctx context.Context, opts v1.ListOptions) (*corev1beta1.WorkbenchUserList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *corev1beta1.WorkbenchUser, err error)
+	Apply(ctx context.Context, workbenchUser *applyconfigurationcorev1beta1.WorkbenchUserApplyConfiguration, opts v1.ApplyOptions) (result *corev1beta1.WorkbenchUser, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, workbenchUser *applyconfigurationcorev1beta1.WorkbenchUserApplyConfiguration, opts v1.ApplyOptions) (result *corev1beta1.WorkbenchUser, err error)
+	WorkbenchUserExpansion
+}
+
+// workbenchUsers implements WorkbenchUserInterface
+type workbenchUsers struct {
+	*gentype.ClientWithListAndApply[*corev1beta1.WorkbenchUser, *corev1beta1.WorkbenchUserList, *applyconfigurationcorev1beta1.WorkbenchUserApplyConfiguration]
+}
+
+// newWorkbenchUsers returns a WorkbenchUsers
+func newWorkbenchUsers(c *CoreV1beta1Client, namespace string) *workbenchUsers {
+	return &workbenchUsers{
+		gentype.NewClientWithListAndApply[*corev1beta1.WorkbenchUser, *corev1beta1.WorkbenchUserList, *applyconfigurationcorev1beta1.WorkbenchUserApplyConfiguration](
+			"workbenchusers",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *corev1beta1.WorkbenchUser { return &corev1beta1.WorkbenchUser{} },
+			func() *corev1beta1.WorkbenchUserList { return &corev1beta1.WorkbenchUserList{} },
+		),
+	}
+}
@@ -21,10 +21,16 @@ type Interface interface {
 	PackageManagers() PackageManagerInformer
 	// PostgresDatabases returns a PostgresDatabaseInformer.
 	PostgresDatabases() PostgresDatabaseInformer
+	// SessionImages returns a SessionImageInformer.
+	SessionImages() SessionImageInformer
 	// Sites returns a SiteInformer.
 	Sites() SiteInformer
 	// Workbenches returns a WorkbenchInformer.
 	Workbenches() WorkbenchInformer
+	// WorkbenchGroups returns a WorkbenchGroupInformer.
+	WorkbenchGroups() WorkbenchGroupInformer
+	// WorkbenchUsers returns a WorkbenchUserInformer.
+	WorkbenchUsers() WorkbenchUserInformer
 }
 
 type version struct {
@@ -63,6 +69,11 @@ func (v *version) PostgresDatabases() PostgresDatabaseInformer {
 	return &postgresDatabaseInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// SessionImages returns a SessionImageInformer.
+func (v *version) SessionImages() SessionImageInformer {
+	return &sessionImageInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Sites returns a SiteInformer.
 func (v *version) Sites() SiteInformer {
 	return &siteInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -72,3 +83,13 @@ func (v *version) Sites() SiteInformer {
 func (v *version) Workbenches() WorkbenchInformer {
 	return &workbenchInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// WorkbenchGroups returns a WorkbenchGroupInformer.
+func (v *version) WorkbenchGroups() WorkbenchGroupInformer {
+	return &workbenchGroupInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// WorkbenchUsers returns a WorkbenchUserInformer.
+func (v *version) WorkbenchUsers() WorkbenchUserInformer {
+	return &workbenchUserInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
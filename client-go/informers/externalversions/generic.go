@@ -51,10 +51,16 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Core().V1beta1().PackageManagers().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("postgresdatabases"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Core().V1beta1().PostgresDatabases().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("sessionimages"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Core().V1beta1().SessionImages().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("sites"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Core().V1beta1().Sites().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("workbenches"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Core().V1beta1().Workbenches().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("workbenchgroups"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Core().V1beta1().WorkbenchGroups().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("workbenchusers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Core().V1beta1().WorkbenchUsers().Informer()}, nil
 
 		// Group=keycloak, Version=v2alpha1
 	case v2alpha1.SchemeGroupVersion.WithResource("keycloaks"):
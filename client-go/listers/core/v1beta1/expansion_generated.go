@@ -45,6 +45,14 @@ type PostgresDatabaseListerExpansion interface{}
 // PostgresDatabaseNamespaceLister.
 type PostgresDatabaseNamespaceListerExpansion interface{}
 
+// SessionImageListerExpansion allows custom methods to be added to
+// SessionImageLister.
+type SessionImageListerExpansion interface{}
+
+// SessionImageNamespaceListerExpansion allows custom methods to be added to
+// SessionImageNamespaceLister.
+type SessionImageNamespaceListerExpansion interface{}
+
 // SiteListerExpansion allows custom methods to be added to
 // SiteLister.
 type SiteListerExpansion interface{}
@@ -60,3 +68,19 @@ type WorkbenchListerExpansion interface{}
 // WorkbenchNamespaceListerExpansion allows custom methods to be added to
 // WorkbenchNamespaceLister.
 type WorkbenchNamespaceListerExpansion interface{}
+
+// WorkbenchGroupListerExpansion allows custom methods to be added to
+// WorkbenchGroupLister.
+type WorkbenchGroupListerExpansion interface{}
+
+// WorkbenchGroupNamespaceListerExpansion allows custom methods to be added to
+// WorkbenchGroupNamespaceLister.
+type WorkbenchGroupNamespaceListerExpansion interface{}
+
+// WorkbenchUserListerExpansion allows custom methods to be added to
+// WorkbenchUserLister.
+type WorkbenchUserListerExpansion interface{}
+
+// WorkbenchUserNamespaceListerExpansion allows custom methods to be added to
+// WorkbenchUserNamespaceLister.
+type WorkbenchUserNamespaceListerExpansion interface{}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// SessionImageLister helps list SessionImages.
+// All objects returned here must be treated as read-only.
+type SessionImageLister interface {
+	// List lists all SessionImages in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*corev1beta1.SessionImage, err error)
+	// SessionImages returns an object that can list and get SessionImages.
+	SessionImages(namespace string) SessionImageNamespaceLister
+	SessionImageListerExpansion
+}
+
+// sessionImageLister implements the SessionImageLister interface.
+type sessionImageLister struct {
+	listers.ResourceIndexer[*corev1beta1.SessionImage]
+}
+
+// NewSessionImageLister returns a new SessionImageLister.
+func NewSessionImageLister(indexer cache.Indexer) SessionImageLister {
+	return &sessionImageLister{listers.New[*corev1beta1.SessionImage](indexer, corev1beta1.Resource("sessionimage"))}
+}
+
+// SessionImages returns an object that can list and get SessionImages.
+func (s *sessionImageLister) SessionImages(namespace string) SessionImageNamespaceLister {
+	return sessionImageNamespaceLister{listers.NewNamespaced[*corev1beta1.SessionImage](s.ResourceIndexer, namespace)}
+}
+
+// SessionImageNamespaceLister helps list and get SessionImages.
+// All objects returned here must be treated as read-only.
+type SessionImageNamespaceLister interface {
+	// List lists all SessionImages in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*corev1beta1.SessionImage, err error)
+	// Get retrieves the SessionImage from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*corev1beta1.SessionImage, error)
+	SessionImageNamespaceListerExpansion
+}
+
+// sessionImageNamespaceLister implements the SessionImageNamespaceLister
+// interface.
+type sessionImageNamespaceLister struct {
+	listers.ResourceIndexer[*corev1beta1.SessionImage]
+}
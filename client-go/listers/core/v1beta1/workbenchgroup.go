@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// WorkbenchGroupLister helps list WorkbenchGroups.
+// All objects returned here must be treated as read-only.
+type WorkbenchGroupLister interface {
+	// List lists all WorkbenchGroups in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*corev1beta1.WorkbenchGroup, err error)
+	// WorkbenchGroups returns an object that can list and get WorkbenchGroups.
+	WorkbenchGroups(namespace string) WorkbenchGroupNamespaceLister
+	WorkbenchGroupListerExpansion
+}
+
+// workbenchGroupLister implements the WorkbenchGroupLister interface.
+type workbenchGroupLister struct {
+	listers.ResourceIndexer[*corev1beta1.WorkbenchGroup]
+}
+
+// NewWorkbenchGroupLister returns a new WorkbenchGroupLister.
+func NewWorkbenchGroupLister(indexer cache.Indexer) WorkbenchGroupLister {
+	return &workbenchGroupLister{listers.New[*corev1beta1.WorkbenchGroup](indexer, corev1beta1.Resource("workbenchgroup"))}
+}
+
+// WorkbenchGroups returns an object that can list and get WorkbenchGroups.
+func (s *workbenchGroupLister) WorkbenchGroups(namespace string) WorkbenchGroupNamespaceLister {
+	return workbenchGroupNamespaceLister{listers.NewNamespaced[*corev1beta1.WorkbenchGroup](s.ResourceIndexer, namespace)}
+}
+
+// WorkbenchGroupNamespaceLister helps list and get WorkbenchGroups.
+// All objects returned here must be treated as read-only.
+type WorkbenchGroupNamespaceLister interface {
+	// List lists all WorkbenchGroups in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*corev1beta1.WorkbenchGroup, err error)
+	// Get retrieves the WorkbenchGroup from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*corev1beta1.WorkbenchGroup, error)
+	WorkbenchGroupNamespaceListerExpansion
+}
+
+// workbenchGroupNamespaceLister implements the WorkbenchGroupNamespaceLister
+// interface.
+type workbenchGroupNamespaceLister struct {
+	listers.ResourceIndexer[*corev1beta1.WorkbenchGroup]
+}
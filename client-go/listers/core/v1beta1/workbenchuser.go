@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2023-2026 Posit Software, PBC
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	corev1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// WorkbenchUserLister helps list WorkbenchUsers.
+// All objects returned here must be treated as read-only.
+type WorkbenchUserLister interface {
+	// List lists all WorkbenchUsers in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*corev1beta1.WorkbenchUser, err error)
+	// WorkbenchUsers returns an object that can list and get WorkbenchUsers.
+	WorkbenchUsers(namespace string) WorkbenchUserNamespaceLister
+	WorkbenchUserListerExpansion
+}
+
+// workbenchUserLister implements the WorkbenchUserLister interface.
+type workbenchUserLister struct {
+	listers.ResourceIndexer[*corev1beta1.WorkbenchUser]
+}
+
+// NewWorkbenchUserLister returns a new WorkbenchUserLister.
+func NewWorkbenchUserLister(indexer cache.Indexer) WorkbenchUserLister {
+	return &workbenchUserLister{listers.New[*corev1beta1.WorkbenchUser](indexer, corev1beta1.Resource("workbenchuser"))}
+}
+
+// WorkbenchUsers returns an object that can list and get WorkbenchUsers.
+func (s *workbenchUserLister) WorkbenchUsers(namespace string) WorkbenchUserNamespaceLister {
+	return workbenchUserNamespaceLister{listers.NewNamespaced[*corev1beta1.WorkbenchUser](s.ResourceIndexer, namespace)}
+}
+
+// WorkbenchUserNamespaceLister helps list and get WorkbenchUsers.
+// All objects returned here must be treated as read-only.
+type WorkbenchUserNamespaceLister interface {
+	// List lists all WorkbenchUsers in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*corev1beta1.WorkbenchUser, err error)
+	// Get retrieves the WorkbenchUser from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*corev1beta1.WorkbenchUser, error)
+	WorkbenchUserNamespaceListerExpansion
+}
+
+// workbenchUserNamespaceLister implements the WorkbenchUserNamespaceLister
+// interface.
+type workbenchUserNamespaceLister struct {
+	listers.ResourceIndexer[*corev1beta1.WorkbenchUser]
+}
@@ -8,6 +8,8 @@ import (
 	"os"
 	"strconv"
 
+	calicov3 "github.com/posit-dev/team-operator/api/calico/v3"
+	ciliumv2 "github.com/posit-dev/team-operator/api/cilium/v2"
 	"github.com/posit-dev/team-operator/api/keycloak/v2alpha1"
 	"github.com/posit-dev/team-operator/api/product"
 	"github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
@@ -21,6 +23,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -29,11 +32,13 @@ import (
 
 	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
 	"github.com/posit-dev/team-operator/internal"
+	"github.com/posit-dev/team-operator/internal/registry"
 
 	corecontroller "github.com/posit-dev/team-operator/internal/controller/core"
 
 	//+kubebuilder:scaffold:imports
 
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	secretsstorev1 "sigs.k8s.io/secrets-store-csi-driver/apis/v1"
 )
 
@@ -66,6 +71,11 @@ func LoadSchemes(scheme *runtime.Scheme) {
 	utilruntime.Must(v1alpha1.AddToScheme(scheme))
 	// keycloak
 	utilruntime.Must(v2alpha1.AddToScheme(scheme))
+	// gateway api
+	utilruntime.Must(gatewayv1.AddToScheme(scheme))
+	// network policy backends
+	utilruntime.Must(ciliumv2.AddToScheme(scheme))
+	utilruntime.Must(calicov3.AddToScheme(scheme))
 }
 
 func init() {
@@ -78,6 +88,8 @@ func main() {
 		metricsAddr          string
 		enableLeaderElection bool
 		probeAddr            string
+		discoverRuntimes     bool
+		sessionNamespaceRole string
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
@@ -86,6 +98,12 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for team-operator. "+
 			"Enabling this will ensure there is only one active team-operator.")
+	flag.BoolVar(&discoverRuntimes, "discover-runtime-versions", false,
+		"Read the R, Python and Quarto versions of Connect execution environments and Workbench session images "+
+			"from the labels of the images in their registries.")
+	flag.StringVar(&sessionNamespaceRole, "session-namespace-role", "",
+		"The ClusterRole with the permissions that team-operator needs in Workbench session namespaces, which Sites "+
+			"bind to the ServiceAccount of team-operator (OPERATOR_NAMESPACE/OPERATOR_SERVICE_ACCOUNT) there.")
 
 	opts := zap.Options{Development: true}
 
@@ -132,10 +150,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	// the execution environments and session images share the cache of their runtime versions and the registry
+	// connections and tokens of the client
+	var runtimeVersions *registry.Discoverer
+	if discoverRuntimes {
+		runtimeVersions = registry.NewDiscoverer(registry.NewClient(nil))
+	}
+
 	if err = (&corecontroller.SiteReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    setupLog,
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Log:       setupLog,
+		APIReader: mgr.GetAPIReader(),
+
+		SessionNamespaceRole: sessionNamespaceRole,
+		OperatorServiceAccount: types.NamespacedName{
+			Namespace: os.Getenv("OPERATOR_NAMESPACE"),
+			Name:      os.Getenv("OPERATOR_SERVICE_ACCOUNT"),
+		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Site")
 		os.Exit(1)
@@ -151,17 +183,21 @@ func main() {
 	}
 
 	if err = (&corecontroller.ConnectReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    setupLog,
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		Log:             setupLog,
+		RuntimeVersions: runtimeVersions,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ImplConnect")
 		os.Exit(1)
 	}
 
 	if err = (&corecontroller.WorkbenchReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		Recorder:        mgr.GetEventRecorderFor("workbench-controller"),
+		APIReader:       mgr.GetAPIReader(),
+		RuntimeVersions: runtimeVersions,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Workbench")
 		os.Exit(1)
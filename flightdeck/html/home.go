@@ -1,8 +1,6 @@
 package html
 
 import (
-	"fmt"
-
 	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
 	"github.com/posit-dev/team-operator/flightdeck/internal"
 	. "maragu.dev/gomponents"
@@ -10,7 +8,6 @@ import (
 )
 
 func HomePage(site positcov1beta1.Site, config *internal.ServerConfig) Node {
-	baseUrl := site.Spec.Domain
 	return page("Home", config,
 		Main(
 			Class("container mx-auto py-8 px-4"),
@@ -28,17 +25,17 @@ func HomePage(site positcov1beta1.Site, config *internal.ServerConfig) Node {
 			Div(
 				Class("grid grid-cols-1 md:grid-cols-3 gap-6 max-w-6xl mx-auto"),
 				If(!internal.IsEmptyStruct(site.Spec.Workbench),
-					productCard("/static/logo-workbench.svg", "Posit Workbench", site.Spec.Workbench.DomainPrefix, baseUrl,
+					productCard("/static/logo-workbench.svg", "Posit Workbench", site.ProductUrl(site.Spec.Workbench.DomainPrefix, site.Spec.Workbench.Hostnames),
 						"Manage your environments with integrated tools like JupyterLab, RStudio, VS Code and Positron. "+
 							"Self-service workspaces provide a secure solution for both on-premises and cloud deployments"),
 				),
 				If(!internal.IsEmptyStruct(site.Spec.Connect),
-					productCard("/static/logo-connect.svg", "Posit Connect", site.Spec.Connect.DomainPrefix, baseUrl,
+					productCard("/static/logo-connect.svg", "Posit Connect", site.ProductUrl(site.Spec.Connect.DomainPrefix, site.Spec.Connect.Hostnames),
 						"Share your interactive applications, dashboards, and reports built with R and Python. "+
 							"Manage access, and deliver real-time insights to your stakeholders."),
 				),
 				If(!internal.IsEmptyStruct(site.Spec.PackageManager),
-					productCard("/static/logo-packagemanager.svg", "Posit Package Manager", site.Spec.PackageManager.DomainPrefix, baseUrl,
+					productCard("/static/logo-packagemanager.svg", "Posit Package Manager", site.ProductUrl(site.Spec.PackageManager.DomainPrefix, site.Spec.PackageManager.Hostnames),
 						"Securely manage your R and Python packages from public and internal sources, ensuring consistent versions for reproducibility. "+
 							"Strengthen your security with vulnerability reporting and air-gapped deployments."),
 				),
@@ -47,9 +44,9 @@ func HomePage(site positcov1beta1.Site, config *internal.ServerConfig) Node {
 	)
 }
 
-func productCard(logoSrc string, productName string, domainPrefix string, baseUrl string, description string) Node {
+func productCard(logoSrc string, productName string, productUrl string, description string) Node {
 	return A(
-		Href(fmt.Sprintf("https://%s.%s", domainPrefix, baseUrl)),
+		Href(productUrl),
 		Target("_blank"),
 		Rel("noopener noreferrer"),
 		Class("block bg-white border border-gray-200 rounded-md p-6 text-center hover:bg-[#E8F1FB] transition-colors dark:bg-neutral-800 dark:border-neutral-700 dark:hover:bg-neutral-700"),
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const AlbLoadBalancerAttributesKey = "alb.ingress.kubernetes.io/load-balancer-attributes"
+const AlbTargetGroupAttributesKey = "alb.ingress.kubernetes.io/target-group-attributes"
+
+const AlbInboundCidrsKey = "alb.ingress.kubernetes.io/inbound-cidrs"
+
+// AlbHTTPSListenerAttributesKey configures the HTTPS listener on port 443, which is where response headers are set
+const AlbHTTPSListenerAttributesKey = "alb.ingress.kubernetes.io/listener-attributes.HTTPS-443"
+
+// AlbIngressProvider configures Ingresses for the AWS Load Balancer Controller.
+//
+// The ALB always sets X-Forwarded-Proto, X-Forwarded-Port and X-Forwarded-For, but it cannot add arbitrary
+// request headers, so RequestHeaders and ForwardedHost are rejected. The timeout is a load balancer attribute and
+// the CSP header an attribute of the HTTPS listener, so they apply to every Ingress that shares the load balancer
+// (i.e. an ingress group). Paths cannot be rewritten either, so StripPathPrefix is rejected too. Of an IngressPolicy
+// only the IP allow-list is supported, as the inbound CIDRs of the load balancer's security group.
+type AlbIngressProvider struct{}
+
+func (AlbIngressProvider) DeployResources(ctx context.Context, req ctrl.Request, c client.Client, scheme *runtime.Scheme, l logr.Logger, name string, owner RouteOwner, opts IngressOptions) error {
+	if len(opts.RequestHeaders) > 0 || opts.ForwardedHost != "" {
+		return fmt.Errorf("ingress %s needs custom request headers, which the ALB cannot set", name)
+	}
+	if opts.stripsPathPrefix() {
+		return fmt.Errorf("ingress %s needs the path prefix %s stripped, which the ALB cannot do; use another provider or the gateway mode for the %s layout", name, opts.PathPrefix, v1beta1.SitePathDomain)
+	}
+	return CleanupTraefikResources(ctx, c, l, req.Namespace, name)
+}
+
+func (AlbIngressProvider) IngressAnnotations(namespace, name string, opts IngressOptions) map[string]string {
+	annotations := map[string]string{}
+
+	if opts.StickyCookie != "" {
+		// the ALB issues its own AWSALB cookie, so the cookie name cannot be chosen. Stickiness only works when
+		// the ALB targets pods directly
+		annotations["alb.ingress.kubernetes.io/target-type"] = "ip"
+		annotations[AlbTargetGroupAttributesKey] = "stickiness.enabled=true,stickiness.type=lb_cookie,stickiness.lb_cookie.duration_seconds=86400"
+	}
+
+	if opts.Timeout != nil {
+		annotations[AlbLoadBalancerAttributesKey] = fmt.Sprintf("idle_timeout.timeout_seconds=%d", timeoutSeconds(opts.Timeout))
+	}
+	if opts.Policy != nil && opts.Policy.IPAllowList != nil {
+		annotations[AlbInboundCidrsKey] = strings.Join(albCidrs(opts.Policy.IPAllowList.SourceRanges), ",")
+	}
+	if opts.ContentSecurityPolicy != "" {
+		annotations[AlbHTTPSListenerAttributesKey] = fmt.Sprintf("routing.http.response.content_security_policy.header_value=%s", opts.ContentSecurityPolicy)
+	}
+
+	return annotations
+}
+
+// albCidrs turns the single IPs of sourceRanges into CIDRs, which is all the inbound-cidrs annotation accepts
+func albCidrs(sourceRanges []string) []string {
+	cidrs := make([]string, 0, len(sourceRanges))
+	for _, r := range sourceRanges {
+		if addr, err := netip.ParseAddr(r); err == nil {
+			r = netip.PrefixFrom(addr, addr.BitLen()).String()
+		}
+		cidrs = append(cidrs, r)
+	}
+	return cidrs
+}
+
+func (AlbIngressProvider) ServiceAnnotations(namespace, name string, opts IngressOptions) map[string]string {
+	return map[string]string{}
+}
+
+func (AlbIngressProvider) IngressPath(opts IngressOptions) (string, networkingv1.PathType) {
+	return prefixIngressPath(opts)
+}
@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	calicov3 "github.com/posit-dev/team-operator/api/calico/v3"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// CalicoNetworkSetLabelKey labels the NetworkSet holding a component's egress domains, so that its policy can
+// select it
+const CalicoNetworkSetLabelKey = "posit.team/network-set"
+
+// CalicoNetworkPolicySpec renders a component's NetworkPolicy spec as a Calico NetworkPolicy spec. When
+// networkSet is set, egress is also allowed to that NetworkSet (which holds the domain allow-list) and to DNS.
+// When full is false the NetworkPolicy is created alongside, so only the NetworkSet egress is rendered.
+// Returns nil when there is nothing to render.
+func CalicoNetworkPolicySpec(spec networkingv1.NetworkPolicySpec, networkSet string, full bool) *calicov3.NetworkPolicySpec {
+	if !full && networkSet == "" {
+		return nil
+	}
+
+	res := &calicov3.NetworkPolicySpec{
+		Selector: CalicoSelector(spec.PodSelector),
+		Types:    []calicov3.PolicyType{calicov3.PolicyTypeEgress},
+	}
+	if full {
+		res.Types = nil
+		for _, t := range spec.PolicyTypes {
+			res.Types = append(res.Types, calicov3.PolicyType(t))
+		}
+		for _, r := range spec.Ingress {
+			for _, peer := range calicoEntityRules(r.From) {
+				res.Ingress = append(res.Ingress, calicoRules(r.Ports, func(ports []intstr.IntOrString) calicov3.Rule {
+					return calicov3.Rule{Source: peer, Destination: calicov3.EntityRule{Ports: ports}}
+				})...)
+			}
+		}
+		for _, r := range spec.Egress {
+			for _, peer := range calicoEntityRules(r.To) {
+				res.Egress = append(res.Egress, calicoRules(r.Ports, func(ports []intstr.IntOrString) calicov3.Rule {
+					dest := peer
+					dest.Ports = ports
+					return calicov3.Rule{Destination: dest}
+				})...)
+			}
+		}
+	}
+
+	if networkSet != "" {
+		dns := calicov3.EntityRule{
+			Selector:          fmt.Sprintf("%s == '%s'", KubeDNSLabelKey, KubeDNSLabelValue),
+			NamespaceSelector: fmt.Sprintf("kubernetes.io/metadata.name == '%s'", KubeDNSNamespace),
+			Ports:             []intstr.IntOrString{intstr.FromInt32(53)},
+		}
+		res.Egress = append(res.Egress,
+			calicov3.Rule{Action: calicov3.ActionAllow, Protocol: "UDP", Destination: dns},
+			calicov3.Rule{Action: calicov3.ActionAllow, Protocol: "TCP", Destination: dns},
+			calicov3.Rule{
+				Action: calicov3.ActionAllow,
+				Destination: calicov3.EntityRule{
+					Selector: fmt.Sprintf("%s == '%s'", CalicoNetworkSetLabelKey, networkSet),
+				},
+			},
+		)
+	}
+	return res
+}
+
+// calicoEntityRules translates NetworkPolicy peers. No peers means any source or destination
+func calicoEntityRules(peers []networkingv1.NetworkPolicyPeer) []calicov3.EntityRule {
+	if len(peers) == 0 {
+		return []calicov3.EntityRule{{}}
+	}
+	var res []calicov3.EntityRule
+	for _, peer := range peers {
+		entity := calicov3.EntityRule{}
+		if peer.IPBlock != nil {
+			entity.Nets = []string{peer.IPBlock.CIDR}
+			entity.NotNets = peer.IPBlock.Except
+		}
+		if peer.PodSelector != nil {
+			entity.Selector = CalicoSelector(*peer.PodSelector)
+		}
+		if peer.NamespaceSelector != nil {
+			entity.NamespaceSelector = CalicoSelector(*peer.NamespaceSelector)
+		}
+		res = append(res, entity)
+	}
+	return res
+}
+
+// calicoRules builds an allow rule for each protocol in ports, since a Calico rule has a single protocol
+func calicoRules(ports []networkingv1.NetworkPolicyPort, rule func(ports []intstr.IntOrString) calicov3.Rule) []calicov3.Rule {
+	if len(ports) == 0 {
+		r := rule(nil)
+		r.Action = calicov3.ActionAllow
+		return []calicov3.Rule{r}
+	}
+
+	var protocols []string
+	byProtocol := map[string][]intstr.IntOrString{}
+	for _, p := range ports {
+		protocol := "TCP"
+		if p.Protocol != nil {
+			protocol = string(*p.Protocol)
+		}
+		if !slices.Contains(protocols, protocol) {
+			protocols = append(protocols, protocol)
+		}
+		switch {
+		case p.Port == nil:
+			// every port of the protocol
+		case p.EndPort != nil:
+			byProtocol[protocol] = append(byProtocol[protocol], intstr.FromString(fmt.Sprintf("%s:%d", p.Port.String(), *p.EndPort)))
+		default:
+			byProtocol[protocol] = append(byProtocol[protocol], *p.Port)
+		}
+	}
+
+	var res []calicov3.Rule
+	for _, protocol := range protocols {
+		r := rule(byProtocol[protocol])
+		r.Action = calicov3.ActionAllow
+		r.Protocol = protocol
+		res = append(res, r)
+	}
+	return res
+}
+
+// CalicoSelector translates a label selector into a Calico selector expression
+func CalicoSelector(selector metav1.LabelSelector) string {
+	var terms []string
+	keys := make([]string, 0, len(selector.MatchLabels))
+	for k := range selector.MatchLabels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		terms = append(terms, fmt.Sprintf("%s == '%s'", k, selector.MatchLabels[k]))
+	}
+
+	for _, expr := range selector.MatchExpressions {
+		values := make([]string, 0, len(expr.Values))
+		for _, v := range expr.Values {
+			values = append(values, "'"+v+"'")
+		}
+		switch expr.Operator {
+		case metav1.LabelSelectorOpIn:
+			terms = append(terms, fmt.Sprintf("%s in { %s }", expr.Key, strings.Join(values, ", ")))
+		case metav1.LabelSelectorOpNotIn:
+			terms = append(terms, fmt.Sprintf("%s not in { %s }", expr.Key, strings.Join(values, ", ")))
+		case metav1.LabelSelectorOpExists:
+			terms = append(terms, fmt.Sprintf("has(%s)", expr.Key))
+		case metav1.LabelSelectorOpDoesNotExist:
+			terms = append(terms, fmt.Sprintf("!has(%s)", expr.Key))
+		}
+	}
+
+	if len(terms) == 0 {
+		return "all()"
+	}
+	return strings.Join(terms, " && ")
+}
@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"testing"
+
+	calicov3 "github.com/posit-dev/team-operator/api/calico/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestCalicoSelector(t *testing.T) {
+	assert.Equal(t, "all()", CalicoSelector(metav1.LabelSelector{}))
+	assert.Equal(t,
+		"a == 'x' && b == 'y' && c in { '1', '2' } && !has(d)",
+		CalicoSelector(metav1.LabelSelector{
+			MatchLabels: map[string]string{"b": "y", "a": "x"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "c", Operator: metav1.LabelSelectorOpIn, Values: []string{"1", "2"}},
+				{Key: "d", Operator: metav1.LabelSelectorOpDoesNotExist},
+			},
+		}),
+	)
+}
+
+func TestCalicoNetworkPolicySpec(t *testing.T) {
+	spec := sessionLikeNetworkPolicySpec()
+
+	assert.Nil(t, CalicoNetworkPolicySpec(spec, "", false))
+
+	res := CalicoNetworkPolicySpec(spec, "site-workbench-session", false)
+	require.NotNil(t, res)
+	assert.Equal(t, "posit.team/component == 'workbench-session'", res.Selector)
+	assert.Equal(t, []calicov3.PolicyType{calicov3.PolicyTypeEgress}, res.Types)
+	require.Len(t, res.Egress, 3)
+	assert.Equal(t, "UDP", res.Egress[0].Protocol)
+	assert.Equal(t, "posit.team/network-set == 'site-workbench-session'", res.Egress[2].Destination.Selector)
+
+	res = CalicoNetworkPolicySpec(spec, "", true)
+	require.NotNil(t, res)
+	assert.Equal(t, []calicov3.PolicyType{calicov3.PolicyTypeEgress, calicov3.PolicyTypeIngress}, res.Types)
+	require.Len(t, res.Egress, 2)
+	assert.Equal(t, "posit.team/component == 'workbench'", res.Egress[0].Destination.Selector)
+	assert.Equal(t, []string{"10.20.0.0/16"}, res.Egress[1].Destination.Nets)
+	require.Len(t, res.Ingress, 2)
+	assert.Equal(t, calicov3.Rule{
+		Action:   calicov3.ActionAllow,
+		Protocol: "TCP",
+		Source: calicov3.EntityRule{
+			NamespaceSelector: "kubernetes.io/metadata.name == 'alloy'",
+		},
+		Destination: calicov3.EntityRule{
+			Ports: []intstr.IntOrString{intstr.FromInt32(8787)},
+		},
+	}, res.Ingress[0])
+}
+
+func TestCalicoNetworkPolicySpecProtocols(t *testing.T) {
+	tcp := corev1.ProtocolTCP
+	udp := corev1.ProtocolUDP
+	port := intstr.FromInt32(5000)
+	endPort := int32(5010)
+	res := CalicoNetworkPolicySpec(networkingv1.NetworkPolicySpec{
+		Egress: []networkingv1.NetworkPolicyEgressRule{
+			{
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: &tcp, Port: &port, EndPort: &endPort},
+					{Protocol: &udp},
+				},
+			},
+		},
+	}, "", true)
+	require.NotNil(t, res)
+	require.Len(t, res.Egress, 2)
+	assert.Equal(t, "TCP", res.Egress[0].Protocol)
+	assert.Equal(t, []intstr.IntOrString{intstr.FromString("5000:5010")}, res.Egress[0].Destination.Ports)
+	assert.Equal(t, "UDP", res.Egress[1].Protocol)
+	assert.Empty(t, res.Egress[1].Destination.Ports)
+}
@@ -0,0 +1,193 @@
+package internal
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	ciliumv2 "github.com/posit-dev/team-operator/api/cilium/v2"
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const ciliumNamespaceLabelKey = "io.kubernetes.pod.namespace"
+const ciliumNamespaceLabelsPrefix = "io.cilium.k8s.namespace.labels."
+
+// CiliumNetworkPolicyRule renders a component's NetworkPolicy spec and egress domain allow-list as a
+// CiliumNetworkPolicy rule. When full is false the NetworkPolicy is created alongside, so only the domain
+// allow-list (and the DNS access it needs) is rendered. Returns nil when there is nothing to render.
+func CiliumNetworkPolicyRule(spec networkingv1.NetworkPolicySpec, fqdns []string, full bool) *ciliumv2.Rule {
+	if !full && len(fqdns) == 0 {
+		return nil
+	}
+
+	rule := &ciliumv2.Rule{
+		EndpointSelector: *spec.PodSelector.DeepCopy(),
+	}
+	if full {
+		for _, r := range spec.Ingress {
+			rule.Ingress = append(rule.Ingress, ciliumIngressRules(r)...)
+		}
+		for _, r := range spec.Egress {
+			rule.Egress = append(rule.Egress, ciliumEgressRules(r)...)
+		}
+
+		// an empty rule turns on default deny without allowing anything
+		if slices.Contains(spec.PolicyTypes, networkingv1.PolicyTypeIngress) && len(rule.Ingress) == 0 {
+			rule.Ingress = []ciliumv2.IngressRule{{}}
+		}
+		if slices.Contains(spec.PolicyTypes, networkingv1.PolicyTypeEgress) && len(rule.Egress) == 0 && len(fqdns) == 0 {
+			rule.Egress = []ciliumv2.EgressRule{{}}
+		}
+	}
+
+	if len(fqdns) > 0 {
+		// the DNS proxy must see lookups for the toFQDNs rule to learn the addresses of the domains
+		rule.Egress = append(rule.Egress,
+			ciliumv2.EgressRule{
+				ToEndpoints: []metav1.LabelSelector{
+					{
+						MatchLabels: map[string]string{
+							ciliumNamespaceLabelKey: KubeDNSNamespace,
+							KubeDNSLabelKey:         KubeDNSLabelValue,
+						},
+					},
+				},
+				ToPorts: []ciliumv2.PortRule{
+					{
+						Ports: []ciliumv2.PortProtocol{{Port: "53", Protocol: "ANY"}},
+						Rules: &ciliumv2.L7Rules{
+							DNS: []ciliumv2.FQDNSelector{{MatchPattern: "*"}},
+						},
+					},
+				},
+			},
+			ciliumv2.EgressRule{
+				ToFQDNs: ciliumFQDNSelectors(fqdns),
+			},
+		)
+	}
+	return rule
+}
+
+func ciliumFQDNSelectors(fqdns []string) []ciliumv2.FQDNSelector {
+	selectors := make([]ciliumv2.FQDNSelector, 0, len(fqdns))
+	for _, fqdn := range fqdns {
+		if strings.Contains(fqdn, "*") {
+			selectors = append(selectors, ciliumv2.FQDNSelector{MatchPattern: fqdn})
+		} else {
+			selectors = append(selectors, ciliumv2.FQDNSelector{MatchName: fqdn})
+		}
+	}
+	return selectors
+}
+
+// ciliumIngressRules translates a NetworkPolicy ingress rule. Cilium does not allow endpoints and CIDRs in the
+// same rule, so each kind of peer gets its own rule
+func ciliumIngressRules(r networkingv1.NetworkPolicyIngressRule) []ciliumv2.IngressRule {
+	endpoints, cidrs := ciliumPeers(r.From)
+	ports := ciliumPortRules(r.Ports)
+
+	if len(r.From) == 0 {
+		return []ciliumv2.IngressRule{{FromEntities: []ciliumv2.Entity{ciliumv2.EntityAll}, ToPorts: ports}}
+	}
+	var rules []ciliumv2.IngressRule
+	if len(endpoints) > 0 {
+		rules = append(rules, ciliumv2.IngressRule{FromEndpoints: endpoints, ToPorts: ports})
+	}
+	if len(cidrs) > 0 {
+		rules = append(rules, ciliumv2.IngressRule{FromCIDRSet: cidrs, ToPorts: ports})
+	}
+	return rules
+}
+
+// ciliumEgressRules translates a NetworkPolicy egress rule (see ciliumIngressRules)
+func ciliumEgressRules(r networkingv1.NetworkPolicyEgressRule) []ciliumv2.EgressRule {
+	endpoints, cidrs := ciliumPeers(r.To)
+	ports := ciliumPortRules(r.Ports)
+
+	if len(r.To) == 0 {
+		return []ciliumv2.EgressRule{{ToEntities: []ciliumv2.Entity{ciliumv2.EntityAll}, ToPorts: ports}}
+	}
+	var rules []ciliumv2.EgressRule
+	if len(endpoints) > 0 {
+		rules = append(rules, ciliumv2.EgressRule{ToEndpoints: endpoints, ToPorts: ports})
+	}
+	if len(cidrs) > 0 {
+		rules = append(rules, ciliumv2.EgressRule{ToCIDRSet: cidrs, ToPorts: ports})
+	}
+	return rules
+}
+
+func ciliumPeers(peers []networkingv1.NetworkPolicyPeer) ([]metav1.LabelSelector, []ciliumv2.CIDRRule) {
+	var endpoints []metav1.LabelSelector
+	var cidrs []ciliumv2.CIDRRule
+	for _, peer := range peers {
+		if peer.IPBlock != nil {
+			cidrs = append(cidrs, ciliumv2.CIDRRule{Cidr: peer.IPBlock.CIDR, ExceptCIDRs: peer.IPBlock.Except})
+			continue
+		}
+		endpoints = append(endpoints, ciliumEndpointSelector(peer))
+	}
+	return endpoints, cidrs
+}
+
+// ciliumEndpointSelector combines the pod and namespace selectors of a peer. Namespace labels are prefixed the way
+// Cilium exposes them on endpoints; without a namespace selector the peer is in the policy's namespace
+func ciliumEndpointSelector(peer networkingv1.NetworkPolicyPeer) metav1.LabelSelector {
+	selector := metav1.LabelSelector{}
+	if peer.PodSelector != nil {
+		selector.MatchLabels = maps.Clone(peer.PodSelector.MatchLabels)
+		selector.MatchExpressions = append(selector.MatchExpressions, peer.PodSelector.MatchExpressions...)
+	}
+	if peer.NamespaceSelector == nil {
+		return selector
+	}
+
+	namespaceKey := func(key string) string {
+		if key == v1beta1.KubernetesMetadataNameKey {
+			return ciliumNamespaceLabelKey
+		}
+		return ciliumNamespaceLabelsPrefix + key
+	}
+	for k, v := range peer.NamespaceSelector.MatchLabels {
+		if selector.MatchLabels == nil {
+			selector.MatchLabels = map[string]string{}
+		}
+		selector.MatchLabels[namespaceKey(k)] = v
+	}
+	for _, expr := range peer.NamespaceSelector.MatchExpressions {
+		expr.Key = namespaceKey(expr.Key)
+		selector.MatchExpressions = append(selector.MatchExpressions, expr)
+	}
+	if _, ok := selector.MatchLabels[ciliumNamespaceLabelKey]; !ok {
+		// select endpoints in any namespace rather than only the policy's namespace
+		selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      ciliumNamespaceLabelKey,
+			Operator: metav1.LabelSelectorOpExists,
+		})
+	}
+	return selector
+}
+
+func ciliumPortRules(ports []networkingv1.NetworkPolicyPort) []ciliumv2.PortRule {
+	if len(ports) == 0 {
+		return nil
+	}
+	var res []ciliumv2.PortProtocol
+	for _, p := range ports {
+		port := ciliumv2.PortProtocol{Port: "0", Protocol: "TCP"}
+		if p.Protocol != nil {
+			port.Protocol = string(*p.Protocol)
+		}
+		if p.Port != nil {
+			port.Port = p.Port.String()
+		}
+		if p.EndPort != nil {
+			port.EndPort = *p.EndPort
+		}
+		res = append(res, port)
+	}
+	return []ciliumv2.PortRule{{Ports: res}}
+}
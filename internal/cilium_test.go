@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"testing"
+
+	ciliumv2 "github.com/posit-dev/team-operator/api/cilium/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func sessionLikeNetworkPolicySpec() networkingv1.NetworkPolicySpec {
+	tcp := corev1.ProtocolTCP
+	port := intstr.FromInt32(8787)
+	return networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"posit.team/component": "workbench-session"}},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress, networkingv1.PolicyTypeIngress},
+		Egress: []networkingv1.NetworkPolicyEgressRule{
+			{
+				To: []networkingv1.NetworkPolicyPeer{
+					{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"posit.team/component": "workbench"}}},
+					{IPBlock: &networkingv1.IPBlock{CIDR: "10.20.0.0/16"}},
+				},
+			},
+		},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				From: []networkingv1.NetworkPolicyPeer{
+					{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "alloy"}}},
+					{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "monitoring"}}},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &port}},
+			},
+		},
+	}
+}
+
+func TestCiliumNetworkPolicyRule(t *testing.T) {
+	spec := sessionLikeNetworkPolicySpec()
+
+	// nothing to add alongside the NetworkPolicy without domains
+	assert.Nil(t, CiliumNetworkPolicyRule(spec, nil, false))
+
+	rule := CiliumNetworkPolicyRule(spec, []string{"pypi.org", "*.snowflakecomputing.com"}, false)
+	require.NotNil(t, rule)
+	assert.Equal(t, spec.PodSelector, rule.EndpointSelector)
+	assert.Empty(t, rule.Ingress)
+	require.Len(t, rule.Egress, 2)
+	assert.Equal(t, "kube-dns", rule.Egress[0].ToEndpoints[0].MatchLabels["k8s-app"])
+	assert.Equal(t, []ciliumv2.FQDNSelector{
+		{MatchName: "pypi.org"},
+		{MatchPattern: "*.snowflakecomputing.com"},
+	}, rule.Egress[1].ToFQDNs)
+
+	rule = CiliumNetworkPolicyRule(spec, nil, true)
+	require.NotNil(t, rule)
+	// endpoints and CIDRs are split into separate rules
+	require.Len(t, rule.Egress, 2)
+	assert.Equal(t, "workbench", rule.Egress[0].ToEndpoints[0].MatchLabels["posit.team/component"])
+	assert.Equal(t, "10.20.0.0/16", rule.Egress[1].ToCIDRSet[0].Cidr)
+
+	require.Len(t, rule.Ingress, 1)
+	from := rule.Ingress[0].FromEndpoints
+	require.Len(t, from, 2)
+	assert.Equal(t, map[string]string{"io.kubernetes.pod.namespace": "alloy"}, from[0].MatchLabels)
+	assert.Empty(t, from[0].MatchExpressions)
+	assert.Equal(t, map[string]string{"io.cilium.k8s.namespace.labels.team": "monitoring"}, from[1].MatchLabels)
+	assert.Equal(t, metav1.LabelSelectorOpExists, from[1].MatchExpressions[0].Operator)
+	assert.Equal(t, []ciliumv2.PortProtocol{{Port: "8787", Protocol: "TCP"}}, rule.Ingress[0].ToPorts[0].Ports)
+}
+
+func TestCiliumNetworkPolicyRuleAllowAll(t *testing.T) {
+	rule := CiliumNetworkPolicyRule(networkingv1.NetworkPolicySpec{
+		Egress: []networkingv1.NetworkPolicyEgressRule{{}},
+	}, nil, true)
+	require.NotNil(t, rule)
+	assert.Equal(t, []ciliumv2.Entity{ciliumv2.EntityAll}, rule.Egress[0].ToEntities)
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"context"
+
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/posit-dev/team-operator/internal"
+	"github.com/rstudio/goex/ptr"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:namespace=posit-team,groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+
+// CreateOrUpdateHorizontalPodAutoscaler scales the product's Deployment, which shares the HorizontalPodAutoscaler's
+// name, according to config, or removes the HorizontalPodAutoscaler when config is nil
+func CreateOrUpdateHorizontalPodAutoscaler(ctx context.Context, req ctrl.Request, r product.SomeReconciler, c client.Client, scheme *runtime.Scheme, name string, owner internal.RouteOwner, config *positcov1beta1.AutoscalingConfig) error {
+	l := r.GetLogger(ctx).WithValues(
+		"function", "CreateOrUpdateHorizontalPodAutoscaler",
+	)
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: req.Namespace,
+		},
+	}
+	if config == nil {
+		return internal.BasicDelete(ctx, r, l, client.ObjectKeyFromObject(hpa), &autoscalingv2.HorizontalPodAutoscaler{})
+	}
+
+	_, err := internal.CreateOrUpdateResource(ctx, c, scheme, l, hpa, owner, func() error {
+		hpa.Labels = owner.KubernetesLabels()
+		hpa.Spec = autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       name,
+			},
+			MinReplicas: ptr.To(config.GetMinReplicas()),
+			MaxReplicas: max(config.MaxReplicas, config.GetMinReplicas()),
+			Metrics:     config.GetMetrics(),
+			Behavior:    config.Behavior,
+		}
+		return nil
+	})
+	return err
+}
+
+// deploymentReplicas returns the replicas to set on a product's Deployment. While an HPA owns the Deployment, the
+// current replicas are kept so that the operator and the HPA do not fight over them
+func deploymentReplicas(current *int32, replicas int, config *positcov1beta1.AutoscalingConfig) *int32 {
+	if config == nil {
+		return ptr.To(int32(product.PassDefaultReplicas(replicas, 1)))
+	}
+	if current != nil {
+		return current
+	}
+	return ptr.To(config.GetMinReplicas())
+}
+
+// minAvailableReplicas returns the minAvailable of a product's PodDisruptionBudget, which follows the HPA minimum
+// while an HPA owns the Deployment
+func minAvailableReplicas(replicas int, config *positcov1beta1.AutoscalingConfig) *int {
+	if config != nil {
+		replicas = int(config.GetMinReplicas())
+	}
+	return ptr.To(product.DetermineMinAvailableReplicas(replicas))
+}
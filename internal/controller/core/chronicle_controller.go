@@ -6,6 +6,7 @@ package core
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/go-logr/logr"
 	"github.com/posit-dev/team-operator/api/product"
@@ -102,15 +103,17 @@ func (r *ChronicleReconciler) ReconcileChronicle(ctx context.Context, req ctrl.R
 	// default config settings not in the original object
 	// ...
 
-	// then create the service itself
+	// then create the service itself; the overrides that fail to apply are recorded along the way
+	failedOverrides := c.Status.FailedOverrides
+	c.Status.FailedOverrides = nil
 	res, err := r.ensureDeployedService(ctx, req, c)
 	if err != nil {
 		l.Error(err, "error deploying service")
 		return res, err
 	}
 
-	// set to ready if it is not set yet...
-	if !c.Status.Ready {
+	// set to ready if it is not set yet, and keep the failed overrides current
+	if !c.Status.Ready || !slices.Equal(failedOverrides, c.Status.FailedOverrides) {
 		c.Status.Ready = true
 		if err := r.Status().Update(ctx, c); err != nil {
 			l.Error(err, "Error setting ready status")
@@ -229,13 +232,18 @@ func (r *ChronicleReconciler) ensureDeployedService(ctx context.Context, req ctr
 			ServiceName: "chronicle-server",
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: c.KubernetesLabels(),
+					Labels:      c.Spec.GetPodLabels(c.KubernetesLabels()),
+					Annotations: c.Spec.GetPodAnnotations(nil),
 				},
 				Spec: corev1.PodSpec{
-					EnableServiceLinks: ptr.To(false),
-					NodeSelector:       c.Spec.NodeSelector,
-					ImagePullSecrets:   pullSecrets,
-					ServiceAccountName: c.ComponentName(),
+					EnableServiceLinks:        ptr.To(false),
+					NodeSelector:              c.Spec.NodeSelector,
+					Affinity:                  c.Spec.Affinity,
+					Tolerations:               c.Spec.Tolerations,
+					TopologySpreadConstraints: c.Spec.GetTopologySpreadConstraints(c.SelectorLabels()),
+					PriorityClassName:         c.Spec.PriorityClassName,
+					ImagePullSecrets:          pullSecrets,
+					ServiceAccountName:        c.ComponentName(),
 					Containers: []corev1.Container{
 						{
 							Name:    "chronicle-server",
@@ -247,10 +255,7 @@ func (r *ChronicleReconciler) ensureDeployedService(ctx context.Context, req ctr
 								internal.DefaultPortChronicleHTTP.ContainerPort("http"),
 								internal.DefaultPortChronicleMetrics.ContainerPort("profile"),
 							},
-							Resources: corev1.ResourceRequirements{
-								Requests: corev1.ResourceList{},
-								Limits:   corev1.ResourceList{},
-							},
+							Resources: c.GetResources(),
 							VolumeMounts: product.ConcatLists(
 								[]corev1.VolumeMount{{
 									Name:      "config",
@@ -288,6 +293,7 @@ func (r *ChronicleReconciler) ensureDeployedService(ctx context.Context, req ctr
 			PodManagementPolicy: "",
 			UpdateStrategy:      v1.StatefulSetUpdateStrategy{},
 		}
+		c.Spec.SecurityProfile.HardenPod(&statefulset.Spec.Template.Spec)
 		return nil
 	}); err != nil {
 		return ctrl.Result{}, err
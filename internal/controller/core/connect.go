@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/go-logr/logr"
 	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
@@ -14,6 +15,7 @@ import (
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -27,6 +29,7 @@ import (
 //+kubebuilder:rbac:namespace=posit-team,groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:namespace=posit-team,groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=secrets-store.csi.x-k8s.io,resources=secretproviderclasses,verbs=get;list;watch;create;update;patch;delete
@@ -37,6 +40,16 @@ func (r *ConnectReconciler) ReconcileConnect(ctx context.Context, req ctrl.Reque
 		"product", "connect",
 	)
 
+	if err := c.Spec.ValidateSecurityProfile(); err != nil {
+		l.Error(err, "invalid connect specification")
+		return ctrl.Result{}, err
+	}
+
+	if err := c.Spec.ValidateExecutionEnvironments(); err != nil {
+		l.Error(err, "invalid connect execution environments")
+		return ctrl.Result{}, err
+	}
+
 	// create database
 	secretKey := "pub-db-password"
 
@@ -103,7 +116,13 @@ func (r *ConnectReconciler) ReconcileConnect(ctx context.Context, req ctrl.Reque
 		}
 	}
 
-	// then create the service itself
+	// then create the service itself; the overrides that fail to apply are recorded along the way
+	failedOverrides := c.Status.FailedOverrides
+	sessionQuota := c.Status.SessionQuota
+	executionEnvironmentWarnings := c.Status.ExecutionEnvironmentWarnings
+	discoveredExecutionEnvironments := c.Status.DiscoveredExecutionEnvironments
+	c.Status.FailedOverrides = nil
+	nextDiscovery := r.discoverExecutionEnvironments(ctx, l, c)
 	res, err := r.ensureDeployedService(ctx, req, c)
 	if err != nil {
 		l.Error(err, "error deploying service")
@@ -112,8 +131,11 @@ func (r *ConnectReconciler) ReconcileConnect(ctx context.Context, req ctrl.Reque
 
 	// TODO: should we watch for happy pods?
 
-	// set to ready if it is not set yet...
-	if !c.Status.Ready {
+	// set to ready if it is not set yet, and keep the failed overrides, session quota and execution environments
+	// current
+	if !c.Status.Ready || !slices.Equal(failedOverrides, c.Status.FailedOverrides) || !sessionQuotaStatusEqual(sessionQuota, c.Status.SessionQuota) ||
+		!slices.Equal(executionEnvironmentWarnings, c.Status.ExecutionEnvironmentWarnings) ||
+		!equality.Semantic.DeepEqual(discoveredExecutionEnvironments, c.Status.DiscoveredExecutionEnvironments) {
 		c.Status.Ready = true
 		if err := r.Status().Update(ctx, c); err != nil {
 			l.Error(err, "Error setting ready status")
@@ -121,23 +143,20 @@ func (r *ConnectReconciler) ReconcileConnect(ctx context.Context, req ctrl.Reque
 		}
 	}
 
-	return ctrl.Result{}, nil
-}
-
-func (r *ConnectReconciler) forwardMiddleware(c *positcov1beta1.Connect) string {
-	return fmt.Sprintf("%s-forward", c.ComponentName())
+	// the registries that could not be read are read again
+	return ctrl.Result{RequeueAfter: nextDiscovery}, nil
 }
 
-func (r *ConnectReconciler) deployTraefikMiddlewares(ctx context.Context, req ctrl.Request, c *positcov1beta1.Connect) error {
-	l := r.GetLogger(ctx).WithValues(
-		"function", "deployTraefikMiddlewares",
-	)
-
-	if err := internal.DeployTraefikForwardMiddleware(ctx, req, r.Client, r.Scheme, l, r.forwardMiddleware(c), c); err != nil {
-		return err
+// ingressOptions describes what Connect needs from the ingress controller (or Gateway) in front of it
+func (r *ConnectReconciler) ingressOptions(c *positcov1beta1.Connect) internal.IngressOptions {
+	return internal.IngressOptions{
+		ForwardHeaders:  true,
+		StickyCookie:    c.ComponentName(),
+		Timeout:         c.Spec.Routing.GetRequestTimeout(),
+		PathPrefix:      c.Spec.RootPath,
+		StripPathPrefix: true,
+		Policy:          c.Spec.IngressPolicy,
 	}
-
-	return nil
 }
 
 var defaultConnectVolumeSize = resource.MustParse("2Gi")
@@ -234,7 +253,7 @@ func (r *ConnectReconciler) ensureDeployedService(ctx context.Context, req ctrl.
 	if c.Spec.Url != "" {
 		// TODO: server address protocol should perhaps be configurable...?
 		//       or we should figure out a way to do TLS in development...
-		address := "https://" + c.Spec.Url
+		address := "https://" + c.Spec.Url + c.Spec.RootPath
 		if configCopy.Server != nil {
 			configCopy.Server.Address = address
 		} else {
@@ -596,23 +615,25 @@ func (r *ConnectReconciler) ensureDeployedService(ctx context.Context, req ctrl.
 	if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, deployment, c, func() error {
 		deployment.Labels = c.KubernetesLabels()
 		deployment.Spec = v1.DeploymentSpec{
-			Replicas: ptr.To(int32(product.PassDefaultReplicas(c.Spec.Replicas, 1))),
+			Replicas: deploymentReplicas(deployment.Spec.Replicas, c.Spec.Replicas, c.Spec.Autoscaling),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: c.SelectorLabels(),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: c.KubernetesLabels(),
-					Annotations: map[string]string{
+					Labels: c.Spec.GetPodLabels(c.KubernetesLabels()),
+					Annotations: c.Spec.GetPodAnnotations(map[string]string{
 						// TODO: this is a hack to get config changes to trigger a new deployment (for now)
 						//   In the future, we could use our own mechanism and decide whether to restart or SIGHUP the service...
 						connectConfigShaKey:   cmSha,
 						connectTemplateShaKey: templateCmSha,
-					},
+					}),
 				},
 				Spec: corev1.PodSpec{
 					EnableServiceLinks: ptr.To(false),
 					NodeSelector:       c.Spec.NodeSelector,
+					Tolerations:        c.Spec.Tolerations,
+					PriorityClassName:  c.Spec.PriorityClassName,
 					ImagePullSecrets:   pullSecrets,
 					ServiceAccountName: maybeServiceAccountName,
 					// TODO: go back to automounting service token...
@@ -656,20 +677,7 @@ func (r *ConnectReconciler) ensureDeployedService(ctx context.Context, req ctrl.
 								secretVolumeFactory.VolumeMounts(),
 								c.TokenVolumeMounts(),
 							),
-							Resources: corev1.ResourceRequirements{
-								Requests: corev1.ResourceList{
-									// TODO: resources
-									//"cpu":               resource.Quantity{Format: "2000m"},
-									//"memory":            resource.Quantity{Format: "3Gi"},
-									//"ephemeral-storage": resource.Quantity{Format: "100Mi"},
-								},
-								Limits: corev1.ResourceList{
-									// TODO: resources
-									//"cpu":               resource.Quantity{Format: "6000m"},
-									//"memory":            resource.Quantity{Format: "8Gi"},
-									//"ephemeral-storage": resource.Quantity{Format: "200Mi"},
-								},
-							},
+							Resources: c.GetResources(),
 							ReadinessProbe: &corev1.Probe{
 								ProbeHandler: corev1.ProbeHandler{
 									HTTPGet: &corev1.HTTPGetAction{
@@ -688,10 +696,9 @@ func (r *ConnectReconciler) ensureDeployedService(ctx context.Context, req ctrl.
 					},
 						product.ChronicleSidecar(c, chronicleSeededEnv),
 					),
-					Affinity: &corev1.Affinity{
-						PodAntiAffinity: positcov1beta1.ComponentSpecPodAntiAffinity(c, req.Namespace),
-					},
-					SecurityContext: &corev1.PodSecurityContext{
+					Affinity:                  c.Spec.GetAffinity(c, req.Namespace),
+					TopologySpreadConstraints: c.Spec.GetTopologySpreadConstraints(c.SelectorLabels()),
+					SecurityContext:           &corev1.PodSecurityContext{
 						//FSGroup: ptr.To(int64(999)),
 					},
 					Volumes: product.ConcatLists(
@@ -706,6 +713,7 @@ func (r *ConnectReconciler) ensureDeployedService(ctx context.Context, req ctrl.
 			deployment.Spec.Template.Spec.Containers[0].Command = []string{"sleep"}
 			deployment.Spec.Template.Spec.Containers[0].Args = []string{"infinity"}
 		}
+		c.Spec.SecurityProfile.HardenPod(&deployment.Spec.Template.Spec)
 		return nil
 	}); err != nil {
 		return ctrl.Result{}, err
@@ -721,7 +729,7 @@ func (r *ConnectReconciler) ensureDeployedService(ctx context.Context, req ctrl.
 	}
 	if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, service, c, func() error {
 		service.Labels = c.KubernetesLabels()
-		service.Annotations = internal.TraefikStickyServiceAnnotations(c)
+		service.Annotations = internal.NewIngressProvider(c.Spec.Routing).ServiceAnnotations(req.Namespace, c.ComponentName(), r.ingressOptions(c))
 		service.Spec = corev1.ServiceSpec{
 			Ports: []corev1.ServicePort{
 				{
@@ -752,27 +760,79 @@ func (r *ConnectReconciler) ensureDeployedService(ctx context.Context, req ctrl.
 		return ctrl.Result{}, err
 	}
 
-	// TRAEFIK MIDDLEWARES
+	// ROUTING
+
+	if err := c.Spec.Routing.ValidateIngressPolicy(c.Spec.IngressPolicy); err != nil {
+		l.Error(err, "Routing cannot enforce the ingress policy")
+		return ctrl.Result{}, err
+	}
+	if c.Spec.Routing.UseGateway() {
+		if err := r.ensureHTTPRoute(ctx, req, c); err != nil {
+			l.Error(err, "Error deploying HTTPRoute")
+			return ctrl.Result{}, err
+		}
+	} else if err := r.ensureIngress(ctx, req, c); err != nil {
+		l.Error(err, "Error deploying ingress")
+		return ctrl.Result{}, err
+	}
 
-	if err := r.deployTraefikMiddlewares(ctx, req, c); err != nil {
-		l.Error(err, "Error deploying traefik middlewares")
+	// AUTOSCALING
+	if err := CreateOrUpdateHorizontalPodAutoscaler(ctx, req, r, r.Client, r.Scheme, c.ComponentName(), c, c.Spec.Autoscaling); err != nil {
+		l.Error(err, "Error deploying HorizontalPodAutoscaler")
 		return ctrl.Result{}, err
 	}
 
-	// INGRESS
+	// POD DISRUPTION BUDGET
+	if err := CreateOrUpdateDisruptionBudget(
+		ctx, req, r.Client, r.Scheme, c, c, minAvailableReplicas(c.Spec.Replicas, c.Spec.Autoscaling), nil,
+	); err != nil {
+		return ctrl.Result{}, err
+	}
 
-	annotations := map[string]string{}
-	traefikMiddlewares := internal.BuildTraefikMiddlewareAnnotation(req.Namespace, r.forwardMiddleware(c))
-	annotations[internal.TraefikMiddlewaresKey] = traefikMiddlewares
+	// SESSION QUOTA
 
-	// add spec annotations and append traefik middlewares
-	for k, v := range c.Spec.IngressAnnotations {
-		if k == internal.TraefikMiddlewaresKey {
-			traefikMiddlewares = fmt.Sprintf("%s,%s", v, traefikMiddlewares)
-			v = traefikMiddlewares
-		}
-		annotations[k] = v
+	// only the launcher creates the pods that the quota counts
+	var sessionQuota *positcov1beta1.SessionQuota
+	if c.Spec.OffHostExecution {
+		sessionQuota = c.Spec.SessionQuota
+	}
+	quotaStatus, err := CreateOrUpdateSessionQuota(ctx, req, r, r.Client, r.Scheme, c.ComponentName(), c, sessionQuota)
+	if err != nil {
+		l.Error(err, "Error deploying session quota")
+		return ctrl.Result{}, err
 	}
+	c.Status.SessionQuota = quotaStatus
+
+	return ctrl.Result{}, nil
+}
+
+func (r *ConnectReconciler) ensureIngress(ctx context.Context, req ctrl.Request, c *positcov1beta1.Connect) error {
+	l := r.GetLogger(ctx).WithValues(
+		"function", "ensureIngress",
+	)
+
+	// INGRESS PROVIDER RESOURCES
+
+	provider := internal.NewIngressProvider(c.Spec.Routing)
+	opts := r.ingressOptions(c)
+	if err := provider.DeployResources(ctx, req, r.Client, r.Scheme, l, c.ComponentName(), c, opts); err != nil {
+		l.Error(err, "Error deploying ingress provider resources")
+		return err
+	}
+
+	// INGRESS
+
+	// add spec annotations and append provider generated values (i.e. traefik middlewares)
+	hosts := internal.IngressHosts(c.Spec.Url, c.Spec.Aliases)
+	annotations := internal.MergeIngressAnnotations(
+		product.LabelMerge(
+			provider.IngressAnnotations(req.Namespace, c.ComponentName(), opts),
+			internal.ExternalDNSAnnotations(c.Spec.Routing.GetExternalDNS(), hosts),
+		),
+		c.Spec.IngressAnnotations,
+	)
+
+	ingressPath, ingressPathType := provider.IngressPath(opts)
 
 	ingress := &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
@@ -785,32 +845,15 @@ func (r *ConnectReconciler) ensureDeployedService(ctx context.Context, req ctrl.
 		ingress.Annotations = annotations
 		ingress.Spec = networkingv1.IngressSpec{
 			// IngressClass set below
-			// TODO: TLS configuration, perhaps
-			TLS: nil,
-			Rules: []networkingv1.IngressRule{
-				{
-					Host: c.Spec.Url,
-					IngressRuleValue: networkingv1.IngressRuleValue{
-						HTTP: &networkingv1.HTTPIngressRuleValue{
-							Paths: []networkingv1.HTTPIngressPath{
-								{
-									Path:     "/",
-									PathType: ptr.To(networkingv1.PathTypePrefix),
-									Backend: networkingv1.IngressBackend{
-										Service: &networkingv1.IngressServiceBackend{
-											Name: c.ComponentName(),
-											Port: networkingv1.ServiceBackendPort{
-												Name: "http",
-											},
-										},
-										Resource: nil,
-									},
-								},
-							},
-						},
+			TLS: internal.IngressTLS(hosts, c.Spec.TLSSecretName),
+			Rules: internal.IngressRules(hosts, ingressPath, ingressPathType, networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: c.ComponentName(),
+					Port: networkingv1.ServiceBackendPort{
+						Name: "http",
 					},
 				},
-			},
+			}),
 		}
 		// only define the ingressClassName if it is specified on the site
 		if c.Spec.IngressClass != "" {
@@ -818,17 +861,34 @@ func (r *ConnectReconciler) ensureDeployedService(ctx context.Context, req ctrl.
 		}
 		return nil
 	}); err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 
-	// POD DISRUPTION BUDGET
-	if err := CreateOrUpdateDisruptionBudget(
-		ctx, req, r.Client, r.Scheme, c, c, ptr.To(product.DetermineMinAvailableReplicas(c.Spec.Replicas)), nil,
-	); err != nil {
-		return ctrl.Result{}, err
+	// remove any HTTPRoute left over from gateway routing mode
+	return internal.CleanupHTTPRoute(ctx, r, l, client.ObjectKeyFromObject(ingress))
+}
+
+func (r *ConnectReconciler) ensureHTTPRoute(ctx context.Context, req ctrl.Request, c *positcov1beta1.Connect) error {
+	l := r.GetLogger(ctx).WithValues(
+		"function", "ensureHTTPRoute",
+	)
+
+	opts := r.ingressOptions(c).HTTPRouteOptions(internal.IngressHosts(c.Spec.Url, c.Spec.Aliases), c.ComponentName(), 80)
+	if err := internal.DeployHTTPRoute(ctx, req, r.Client, r.Scheme, l, c.ComponentName(), c, c.Spec.Routing, opts); err != nil {
+		return err
 	}
 
-	return ctrl.Result{}, nil
+	// remove any Traefik middlewares left over from ingress routing mode
+	if err := internal.CleanupTraefikResources(ctx, r.Client, l, req.Namespace, c.ComponentName()); err != nil {
+		return err
+	}
+
+	// remove any Ingress left over from ingress routing mode
+	key := client.ObjectKey{
+		Name:      c.ComponentName(),
+		Namespace: req.Namespace,
+	}
+	return internal.BasicDelete(ctx, r, l, key, &networkingv1.Ingress{})
 }
 
 func (r *ConnectReconciler) CleanupConnect(ctx context.Context, req ctrl.Request, c *positcov1beta1.Connect) (ctrl.Result, error) {
@@ -867,6 +927,12 @@ func (r *ConnectReconciler) cleanupDeployedService(ctx context.Context, req ctrl
 		return err
 	}
 
+	// HTTPROUTE
+
+	if err := internal.CleanupHTTPRoute(ctx, r, l, key); err != nil {
+		return err
+	}
+
 	// SERVICE
 
 	existingService := &corev1.Service{}
@@ -905,5 +971,11 @@ func (r *ConnectReconciler) cleanupDeployedService(ctx context.Context, req ctrl
 		return err
 	}
 
+	// SESSION QUOTA
+
+	if _, err := CreateOrUpdateSessionQuota(ctx, req, r, r.Client, r.Scheme, c.ComponentName(), c, nil); err != nil {
+		return err
+	}
+
 	return nil
 }
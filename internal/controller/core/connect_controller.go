@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -15,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/internal/registry"
 )
 
 // ConnectReconciler reconciles a ImplConnect object
@@ -22,6 +24,10 @@ type ConnectReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	Log    logr.Logger
+
+	// RuntimeVersions discovers the installations of execution environments from the labels of their image. Nil
+	// disables discovery
+	RuntimeVersions *registry.Discoverer
 }
 
 //+kubebuilder:rbac:namespace=posit-team,groups=core.posit.team,resources=connects,verbs=get;list;watch;create;update;patch;delete
@@ -74,12 +80,13 @@ func (r *ConnectReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 	l.Info("Connect found; updating resources")
 
-	if res, err := r.ReconcileConnect(ctx, req, &c); err != nil {
+	res, err := r.ReconcileConnect(ctx, req, &c)
+	if err != nil {
 		l.Error(err, "error reconciling product state")
 		return res, err
 	}
-	// reconcile successful
-	return ctrl.Result{}, nil
+	// reconcile successful; the result requeues the execution environments whose registry could not be read
+	return res, nil
 }
 
 func (r *ConnectReconciler) GetLogger(ctx context.Context) logr.Logger {
@@ -93,5 +100,6 @@ func (r *ConnectReconciler) GetLogger(ctx context.Context) logr.Logger {
 func (r *ConnectReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&positcov1beta1.Connect{}).
+		Owns(&corev1.ResourceQuota{}).
 		Complete(r)
 }
@@ -2,6 +2,9 @@ package core
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -9,13 +12,17 @@ import (
 	localtest "github.com/posit-dev/team-operator/api/localtest"
 	"github.com/posit-dev/team-operator/api/product"
 	"github.com/posit-dev/team-operator/internal"
+	"github.com/posit-dev/team-operator/internal/registry"
+	"github.com/posit-dev/team-operator/internal/registry/registrytest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrl "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func initConnectReconciler(t *testing.T, ctx context.Context, namespace, name string) (context.Context, *ConnectReconciler, ctrl.Request, client.Client) {
@@ -378,3 +385,111 @@ func TestConnectReconciler_OIDC_DisableGroupsClaim(t *testing.T) {
 	// Ensure it's not set to a non-empty value
 	assert.NotContains(t, config, "GroupsClaim = groups", "GroupsClaim should not have the default 'groups' value")
 }
+
+func TestConnectExecutionEnvironmentDiscovery(t *testing.T) {
+	ctx := context.Background()
+	reg := registrytest.New()
+	var down atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		reg.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	host := registrytest.Host(srv)
+	registrytest.PushImage(t, host, "content", "labeled", registrytest.Image(t, map[string]string{
+		registry.RVersionsLabel:      "4.4.2,4.3.3",
+		registry.RPathLabel:          "/opt/R/{version}/bin/R",
+		registry.PythonVersionsLabel: "3.12.8",
+		registry.PythonPathLabel:     "/usr/local/bin/python3",
+	}))
+	registrytest.PushImage(t, host, "content", "unlabeled", registrytest.Image(t, nil))
+	registrytest.PushImage(t, host, "content", "pathless", registrytest.Image(t, map[string]string{
+		registry.RVersionsLabel: "4.4.2",
+	}))
+
+	scheme := runtime.NewScheme()
+	loadSchemes(scheme)
+	cli := fakectrl.NewClientBuilder().WithScheme(scheme).Build()
+	r := &ConnectReconciler{Client: cli, Scheme: scheme, Log: product.NewSimpleLogger()}
+
+	c := defineDefaultConnect(t, "posit-team", "connect-discovery")
+	c.Spec.OffHostExecution = true
+	c.Spec.ImagePullSecrets = []string{"missing-pull-secret"}
+	hardened := positcov1beta1.ConnectExecutionEnvironment{
+		Image: "registry.example.com/hardened/content:2025.01",
+		R:     []positcov1beta1.ConnectRuntimeInstallation{{Version: "4.4.2", Path: "/usr/lib/R/bin/R"}},
+	}
+	c.Spec.ExecutionEnvironments = []positcov1beta1.ConnectExecutionEnvironment{
+		hardened,
+		{Image: host + "/content", Tag: "labeled"},
+		{Image: host + "/content:unlabeled"},
+		{Image: host + "/content:pathless"},
+		{Image: host + "/content:missing"},
+	}
+	spec := c.Spec.DeepCopy()
+	c.Status.ExecutionEnvironmentWarnings = []string{"stale"}
+
+	// without discovery, the environments without installations are left out
+	disabled := c.DeepCopy()
+	assert.Zero(t, r.discoverExecutionEnvironments(ctx, r.Log, disabled))
+	assert.Equal(t, []positcov1beta1.ConnectExecutionEnvironment{hardened}, disabled.Spec.ExecutionEnvironments)
+	assert.Equal(t, []string{
+		"runtime version discovery is disabled: " + host + "/content:labeled",
+		"runtime version discovery is disabled: " + host + "/content:unlabeled",
+		"runtime version discovery is disabled: " + host + "/content:pathless",
+		"runtime version discovery is disabled: " + host + "/content:missing",
+	}, disabled.Status.ExecutionEnvironmentWarnings)
+	assert.Empty(t, disabled.Status.DiscoveredExecutionEnvironments)
+
+	// with discovery, the labels of the image list its installations and their paths
+	r.RuntimeVersions = registry.NewDiscoverer(registry.NewClient(srv.Client().Transport))
+	retryAfter := r.discoverExecutionEnvironments(ctx, r.Log, c)
+	labeled := positcov1beta1.ConnectExecutionEnvironment{
+		Image: host + "/content:labeled",
+		R: []positcov1beta1.ConnectRuntimeInstallation{
+			{Version: "4.4.2", Path: "/opt/R/4.4.2/bin/R"},
+			{Version: "4.3.3", Path: "/opt/R/4.3.3/bin/R"},
+		},
+		Python: []positcov1beta1.ConnectRuntimeInstallation{{Version: "3.12.8", Path: "/usr/local/bin/python3"}},
+	}
+	require.Len(t, c.Spec.ExecutionEnvironments, 2)
+	assert.Equal(t, hardened, c.Spec.ExecutionEnvironments[0])
+	assert.Equal(t, positcov1beta1.ConnectExecutionEnvironment{
+		Image:  host + "/content",
+		Tag:    "labeled",
+		R:      labeled.R,
+		Python: labeled.Python,
+	}, c.Spec.ExecutionEnvironments[1])
+	assert.Equal(t, []positcov1beta1.ConnectExecutionEnvironment{labeled}, c.Status.DiscoveredExecutionEnvironments)
+
+	// and the images that are unlabeled, have no paths or cannot be read are reported, the last to be read again
+	require.Len(t, c.Status.ExecutionEnvironmentWarnings, 3)
+	assert.Equal(t, "image has no runtime version labels: "+host+"/content:unlabeled", c.Status.ExecutionEnvironmentWarnings[0])
+	assert.Equal(t, "the co.posit.r.path label of image "+host+"/content:pathless does not list the path of each version", c.Status.ExecutionEnvironmentWarnings[1])
+	assert.Contains(t, c.Status.ExecutionEnvironmentWarnings[2], host+"/content:missing")
+	assert.Positive(t, retryAfter)
+
+	runtimeYaml, err := c.DefaultRuntimeYAML()
+	require.NoError(t, err)
+	assert.Contains(t, runtimeYaml, "path: /opt/R/4.3.3/bin/R")
+	assert.NotContains(t, runtimeYaml, "unlabeled")
+
+	// an environment whose registry cannot be read keeps the installations that were last discovered
+	down.Store(true)
+	c.Spec = *spec.DeepCopy()
+	r.RuntimeVersions = registry.NewDiscoverer(registry.NewClient(srv.Client().Transport))
+	assert.Positive(t, r.discoverExecutionEnvironments(ctx, r.Log, c))
+	require.Len(t, c.Spec.ExecutionEnvironments, 2)
+	assert.Equal(t, labeled.R, c.Spec.ExecutionEnvironments[1].R)
+	assert.Equal(t, []positcov1beta1.ConnectExecutionEnvironment{labeled}, c.Status.DiscoveredExecutionEnvironments)
+	assert.Len(t, c.Status.ExecutionEnvironmentWarnings, 4)
+
+	// without off-host execution, there is no runtime.yaml to discover installations for
+	c.Spec.OffHostExecution = false
+	r.discoverExecutionEnvironments(ctx, r.Log, c)
+	assert.Empty(t, c.Status.ExecutionEnvironmentWarnings)
+	assert.Empty(t, c.Status.DiscoveredExecutionEnvironments)
+}
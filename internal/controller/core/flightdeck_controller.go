@@ -5,9 +5,11 @@ package core
 
 import (
 	"context"
+	"slices"
 
 	"github.com/go-logr/logr"
 	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
 	"github.com/posit-dev/team-operator/internal"
 	"github.com/rstudio/goex/ptr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -15,7 +17,6 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -37,6 +38,7 @@ type FlightdeckReconciler struct {
 //+kubebuilder:rbac:namespace=posit-team,groups="",resources=services;serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:namespace=posit-team,groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=core.posit.team,resources=sites,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -67,11 +69,21 @@ func (r *FlightdeckReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		"domain", fd.Spec.Domain,
 	)
 
+	// the overrides that fail to apply are recorded along the way
+	failedOverrides := fd.Status.FailedOverrides
+	fd.Status.FailedOverrides = nil
 	if res, err := r.reconcileFlightdeckResources(ctx, req, fd, l); err != nil {
 		l.Error(err, "failed to reconcile flightdeck resources")
 		return res, err
 	}
 
+	if !slices.Equal(failedOverrides, fd.Status.FailedOverrides) {
+		if err := r.Status().Update(ctx, fd); err != nil {
+			l.Error(err, "failed to update status")
+			return ctrl.Result{}, err
+		}
+	}
+
 	l.Info("reconciliation completed successfully",
 		"component", fd.ComponentName(),
 		"domain", fd.Spec.Domain,
@@ -215,10 +227,7 @@ func (r *FlightdeckReconciler) reconcileFlightdeckResources(
 	}
 
 	// Determine replicas
-	replicas := int32(1)
-	if fd.Spec.Replicas > 0 {
-		replicas = int32(fd.Spec.Replicas)
-	}
+	replicas := int32(product.PassDefaultReplicas(fd.Spec.Replicas, 1))
 
 	// Determine port
 	port := fd.Spec.Port
@@ -246,18 +255,24 @@ func (r *FlightdeckReconciler) reconcileFlightdeckResources(
 	if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, deployment, fd, func() error {
 		deployment.Labels = fd.KubernetesLabels()
 		deployment.Spec = appsv1.DeploymentSpec{
-			Replicas: ptr.To(replicas),
+			Replicas: deploymentReplicas(deployment.Spec.Replicas, fd.Spec.Replicas, fd.Spec.Autoscaling),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: fd.SelectorLabels(),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: fd.KubernetesLabels(),
+					Labels:      fd.Spec.GetPodLabels(fd.KubernetesLabels()),
+					Annotations: fd.Spec.GetPodAnnotations(nil),
 				},
 				Spec: corev1.PodSpec{
-					EnableServiceLinks: ptr.To(false),
-					ImagePullSecrets:   pullSecrets,
-					ServiceAccountName: fd.ComponentName(),
+					EnableServiceLinks:        ptr.To(false),
+					NodeSelector:              fd.Spec.NodeSelector,
+					Affinity:                  fd.Spec.Affinity,
+					Tolerations:               fd.Spec.Tolerations,
+					TopologySpreadConstraints: fd.Spec.GetTopologySpreadConstraints(fd.SelectorLabels()),
+					PriorityClassName:         fd.Spec.PriorityClassName,
+					ImagePullSecrets:          pullSecrets,
+					ServiceAccountName:        fd.ComponentName(),
 					Containers: []corev1.Container{
 						{
 							Name:            "flightdeck",
@@ -282,16 +297,7 @@ func (r *FlightdeckReconciler) reconcileFlightdeckResources(
 									Type: corev1.SeccompProfileTypeRuntimeDefault,
 								},
 							},
-							Resources: corev1.ResourceRequirements{
-								Limits: corev1.ResourceList{
-									corev1.ResourceCPU:    resource.MustParse("200m"),
-									corev1.ResourceMemory: resource.MustParse("256Mi"),
-								},
-								Requests: corev1.ResourceList{
-									corev1.ResourceCPU:    resource.MustParse("50m"),
-									corev1.ResourceMemory: resource.MustParse("64Mi"),
-								},
-							},
+							Resources: fd.GetResources(),
 							LivenessProbe: &corev1.Probe{
 								ProbeHandler: corev1.ProbeHandler{
 									HTTPGet: &corev1.HTTPGetAction{
@@ -323,6 +329,11 @@ func (r *FlightdeckReconciler) reconcileFlightdeckResources(
 				},
 			},
 		}
+		fd.Spec.SecurityProfile.HardenPod(&deployment.Spec.Template.Spec)
+		if fd.Spec.SecurityProfile == positcov1beta1.SecurityProfileRestricted {
+			// flightdeck writes nothing to disk, so its root filesystem can be read-only as well
+			deployment.Spec.Template.Spec.Containers[0].SecurityContext.ReadOnlyRootFilesystem = ptr.To(true)
+		}
 		return nil
 	}); err != nil {
 		l.Error(err, "failed to reconcile deployment", "deployment", componentName)
@@ -343,6 +354,7 @@ func (r *FlightdeckReconciler) reconcileFlightdeckResources(
 	}
 	if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, service, fd, func() error {
 		service.Labels = fd.KubernetesLabels()
+		service.Annotations = internal.NewIngressProvider(fd.Spec.Routing).ServiceAnnotations(req.Namespace, componentName, flightdeckIngressOptions(fd))
 		service.Spec = corev1.ServiceSpec{
 			Ports: []corev1.ServicePort{
 				{
@@ -362,6 +374,42 @@ func (r *FlightdeckReconciler) reconcileFlightdeckResources(
 	}
 	l.V(1).Info("reconciled service", "service", componentName)
 
+	// AUTOSCALING
+	if err := CreateOrUpdateHorizontalPodAutoscaler(ctx, req, r, r.Client, r.Scheme, fd.ComponentName(), fd, fd.Spec.Autoscaling); err != nil {
+		l.Error(err, "failed to reconcile horizontal pod autoscaler", "hpa", componentName)
+		return ctrl.Result{}, err
+	}
+
+	// ROUTING
+	if fd.Spec.Routing.UseGateway() {
+		if err := r.reconcileHTTPRoute(ctx, req, fd, l); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if err := r.reconcileIngress(ctx, req, fd, l); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	l.V(1).Info("all flightdeck resources reconciled successfully", "component", componentName)
+
+	return ctrl.Result{}, nil
+}
+
+func (r *FlightdeckReconciler) reconcileIngress(
+	ctx context.Context,
+	req ctrl.Request,
+	fd *positcov1beta1.Flightdeck,
+	l logr.Logger,
+) error {
+	componentName := fd.ComponentName()
+
+	// INGRESS PROVIDER RESOURCES
+	provider := internal.NewIngressProvider(fd.Spec.Routing)
+	opts := flightdeckIngressOptions(fd)
+	if err := provider.DeployResources(ctx, req, r.Client, r.Scheme, l, componentName, fd, opts); err != nil {
+		l.Error(err, "failed to reconcile ingress provider resources", "ingress", componentName)
+		return err
+	}
+
 	// INGRESS
 	ingress := &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
@@ -371,37 +419,27 @@ func (r *FlightdeckReconciler) reconcileFlightdeckResources(
 	}
 	if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, ingress, fd, func() error {
 		// Build annotations
-		annotations := map[string]string{}
-		for k, v := range fd.Spec.IngressAnnotations {
-			annotations[k] = v
-		}
+		hosts := internal.IngressHosts(fd.Spec.Domain, fd.Spec.Aliases)
+		ingressPath, ingressPathType := provider.IngressPath(opts)
+		annotations := internal.MergeIngressAnnotations(
+			product.LabelMerge(
+				provider.IngressAnnotations(req.Namespace, componentName, opts),
+				internal.ExternalDNSAnnotations(fd.Spec.Routing.GetExternalDNS(), hosts),
+			),
+			fd.Spec.IngressAnnotations,
+		)
 		ingress.Labels = fd.KubernetesLabels()
 		ingress.Annotations = annotations
 		ingress.Spec = networkingv1.IngressSpec{
-			TLS: nil,
-			Rules: []networkingv1.IngressRule{
-				{
-					Host: fd.Spec.Domain,
-					IngressRuleValue: networkingv1.IngressRuleValue{
-						HTTP: &networkingv1.HTTPIngressRuleValue{
-							Paths: []networkingv1.HTTPIngressPath{
-								{
-									Path:     "/",
-									PathType: ptr.To(networkingv1.PathTypePrefix),
-									Backend: networkingv1.IngressBackend{
-										Service: &networkingv1.IngressServiceBackend{
-											Name: fd.ComponentName(),
-											Port: networkingv1.ServiceBackendPort{
-												Name: "http",
-											},
-										},
-									},
-								},
-							},
-						},
+			TLS: internal.IngressTLS(hosts, fd.Spec.TLSSecretName),
+			Rules: internal.IngressRules(hosts, ingressPath, ingressPathType, networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: fd.ComponentName(),
+					Port: networkingv1.ServiceBackendPort{
+						Name: "http",
 					},
 				},
-			},
+			}),
 		}
 		// Only define the ingressClassName if it is specified
 		if fd.Spec.IngressClass != "" {
@@ -410,7 +448,7 @@ func (r *FlightdeckReconciler) reconcileFlightdeckResources(
 		return nil
 	}); err != nil {
 		l.Error(err, "failed to reconcile ingress", "ingress", componentName)
-		return ctrl.Result{}, err
+		return err
 	}
 	l.V(1).Info("reconciled ingress",
 		"ingress", componentName,
@@ -418,9 +456,41 @@ func (r *FlightdeckReconciler) reconcileFlightdeckResources(
 		"ingressClass", fd.Spec.IngressClass,
 	)
 
-	l.V(1).Info("all flightdeck resources reconciled successfully", "component", componentName)
+	// remove any HTTPRoute left over from gateway routing mode
+	return internal.CleanupHTTPRoute(ctx, r, l, client.ObjectKeyFromObject(ingress))
+}
 
-	return ctrl.Result{}, nil
+func (r *FlightdeckReconciler) reconcileHTTPRoute(
+	ctx context.Context,
+	req ctrl.Request,
+	fd *positcov1beta1.Flightdeck,
+	l logr.Logger,
+) error {
+	componentName := fd.ComponentName()
+
+	opts := flightdeckIngressOptions(fd).HTTPRouteOptions(internal.IngressHosts(fd.Spec.Domain, fd.Spec.Aliases), componentName, 80)
+	if err := internal.DeployHTTPRoute(ctx, req, r.Client, r.Scheme, l, componentName, fd, fd.Spec.Routing, opts); err != nil {
+		l.Error(err, "failed to reconcile httproute", "httproute", componentName)
+		return err
+	}
+	l.V(1).Info("reconciled httproute",
+		"httproute", componentName,
+		"domain", fd.Spec.Domain,
+	)
+
+	// remove any Ingress left over from ingress routing mode
+	key := client.ObjectKey{
+		Name:      componentName,
+		Namespace: req.Namespace,
+	}
+	return internal.BasicDelete(ctx, r, l, key, &networkingv1.Ingress{})
+}
+
+// flightdeckIngressOptions describes what Flightdeck needs from the ingress controller (or Gateway) in front of it
+func flightdeckIngressOptions(fd *positcov1beta1.Flightdeck) internal.IngressOptions {
+	return internal.IngressOptions{
+		Timeout: fd.Spec.Routing.GetRequestTimeout(),
+	}
 }
 
 // GetLogger returns a logger with the controller name
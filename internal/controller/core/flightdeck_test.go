@@ -3,20 +3,28 @@ package core
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/posit-dev/team-operator/api/core/v1beta1"
 	"github.com/posit-dev/team-operator/api/localtest"
+	"github.com/posit-dev/team-operator/internal"
+	"github.com/rstudio/goex/ptr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 func defaultFlightdeck(name, namespace string) *v1beta1.Flightdeck {
@@ -278,6 +286,99 @@ func TestFlightdeckReconciler_IngressAnnotations(t *testing.T) {
 	assert.Equal(t, "letsencrypt", ing.Annotations["cert-manager.io/cluster-issuer"])
 }
 
+func TestFlightdeckReconciler_GatewayRouting(t *testing.T) {
+	fdName := "gateway-flightdeck"
+	fdNamespace := "posit-team"
+	fd := defaultFlightdeck(fdName, fdNamespace)
+	fd.Spec.Routing = &v1beta1.RoutingConfig{
+		Mode: v1beta1.RoutingModeGateway,
+		Gateway: &v1beta1.GatewayParentRef{
+			Name:      "shared-gateway",
+			Namespace: "gateway-system",
+		},
+	}
+
+	cli, _, err := runFakeFlightdeckReconciler(t, fdNamespace, fdName, fd)
+	assert.NoError(t, err)
+
+	route := &gatewayv1.HTTPRoute{}
+	err = cli.Get(context.TODO(), client.ObjectKey{Name: fd.ComponentName(), Namespace: fdNamespace}, route)
+	require.NoError(t, err)
+
+	assert.Equal(t, []gatewayv1.Hostname{"test.posit.team"}, route.Spec.Hostnames)
+	require.Len(t, route.Spec.ParentRefs, 1)
+	assert.Equal(t, gatewayv1.ObjectName("shared-gateway"), route.Spec.ParentRefs[0].Name)
+	assert.Equal(t, gatewayv1.Namespace("gateway-system"), *route.Spec.ParentRefs[0].Namespace)
+	require.Len(t, route.Spec.Rules, 1)
+	assert.Equal(t, gatewayv1.ObjectName(fd.ComponentName()), route.Spec.Rules[0].BackendRefs[0].Name)
+
+	// no ingress should be created in gateway mode
+	ing := &networkingv1.Ingress{}
+	err = cli.Get(context.TODO(), client.ObjectKey{Name: fd.ComponentName(), Namespace: fdNamespace}, ing)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestFlightdeckReconciler_GatewayRoutingRequiresGateway(t *testing.T) {
+	fdName := "no-gateway-flightdeck"
+	fdNamespace := "posit-team"
+	fd := defaultFlightdeck(fdName, fdNamespace)
+	fd.Spec.Routing = &v1beta1.RoutingConfig{
+		Mode: v1beta1.RoutingModeGateway,
+	}
+
+	_, _, err := runFakeFlightdeckReconciler(t, fdNamespace, fdName, fd)
+	assert.Error(t, err)
+}
+
+func TestFlightdeckReconciler_NginxProviderTimeout(t *testing.T) {
+	fdName := "nginx-flightdeck"
+	fdNamespace := "posit-team"
+	fd := defaultFlightdeck(fdName, fdNamespace)
+	fd.Spec.IngressAnnotations = map[string]string{"custom": "value"}
+	fd.Spec.Routing = &v1beta1.RoutingConfig{
+		Provider:       v1beta1.IngressProviderNginx,
+		RequestTimeout: &metav1.Duration{Duration: 2 * time.Minute},
+	}
+
+	cli, _, err := runFakeFlightdeckReconciler(t, fdNamespace, fdName, fd)
+	require.NoError(t, err)
+
+	ing := &networkingv1.Ingress{}
+	err = cli.Get(context.TODO(), client.ObjectKey{Name: fd.ComponentName(), Namespace: fdNamespace}, ing)
+	require.NoError(t, err)
+
+	assert.Equal(t, "120", ing.Annotations["nginx.ingress.kubernetes.io/proxy-read-timeout"])
+	assert.Equal(t, "value", ing.Annotations["custom"])
+	assert.NotContains(t, ing.Annotations, internal.TraefikMiddlewaresKey)
+}
+
+func TestFlightdeckReconciler_AliasesAndExternalDNS(t *testing.T) {
+	fdName := "alias-flightdeck"
+	fdNamespace := "posit-team"
+	fd := defaultFlightdeck(fdName, fdNamespace)
+	fd.Spec.Aliases = []string{"old.posit.team"}
+	fd.Spec.TLSSecretName = "flightdeck-tls"
+	fd.Spec.Routing = &v1beta1.RoutingConfig{
+		ExternalDNS: &v1beta1.ExternalDNSConfig{
+			Target: "lb.posit.team",
+		},
+	}
+
+	cli, _, err := runFakeFlightdeckReconciler(t, fdNamespace, fdName, fd)
+	require.NoError(t, err)
+
+	ing := &networkingv1.Ingress{}
+	err = cli.Get(context.TODO(), client.ObjectKey{Name: fd.ComponentName(), Namespace: fdNamespace}, ing)
+	require.NoError(t, err)
+
+	require.Len(t, ing.Spec.Rules, 2)
+	assert.Equal(t, "test.posit.team", ing.Spec.Rules[0].Host)
+	assert.Equal(t, "old.posit.team", ing.Spec.Rules[1].Host)
+	assert.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"test.posit.team", "old.posit.team"}, SecretName: "flightdeck-tls"}}, ing.Spec.TLS)
+	assert.Equal(t, "test.posit.team,old.posit.team", ing.Annotations[internal.ExternalDNSHostnameKey])
+	assert.Equal(t, "lb.posit.team", ing.Annotations[internal.ExternalDNSTargetKey])
+}
+
 func TestFlightdeckReconciler_ServiceUsesCorrectSelector(t *testing.T) {
 	fdName := "selector-flightdeck"
 	fdNamespace := "posit-team"
@@ -437,3 +538,130 @@ func TestResolveFlightdeckImage(t *testing.T) {
 		})
 	}
 }
+
+func TestFlightdeckReconciler_Autoscaling(t *testing.T) {
+	fdName := "test-flightdeck"
+	fdNamespace := "posit-team"
+	fd := defaultFlightdeck(fdName, fdNamespace)
+	fd.Spec.Autoscaling = &v1beta1.AutoscalingConfig{
+		MinReplicas:                       ptr.To(int32(2)),
+		MaxReplicas:                       5,
+		TargetMemoryUtilizationPercentage: ptr.To(int32(70)),
+	}
+
+	cli, _, err := runFakeFlightdeckReconciler(t, fdNamespace, fdName, fd)
+	require.NoError(t, err)
+
+	key := client.ObjectKey{Name: fd.ComponentName(), Namespace: fdNamespace}
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	require.NoError(t, cli.Get(context.TODO(), key, hpa))
+	assert.Equal(t, fd.ComponentName(), hpa.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, "Deployment", hpa.Spec.ScaleTargetRef.Kind)
+	assert.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+	require.Len(t, hpa.Spec.Metrics, 1)
+	assert.Equal(t, corev1.ResourceMemory, hpa.Spec.Metrics[0].Resource.Name)
+
+	// the deployment starts at the HPA minimum
+	dep := &appsv1.Deployment{}
+	require.NoError(t, cli.Get(context.TODO(), key, dep))
+	assert.Equal(t, int32(2), *dep.Spec.Replicas)
+
+	// the replicas set by the HPA are kept
+	dep.Spec.Replicas = ptr.To(int32(4))
+	require.NoError(t, cli.Update(context.TODO(), dep))
+	rec := FlightdeckReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard()}
+	_, err = rec.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: fdNamespace, Name: fdName}})
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(context.TODO(), key, dep))
+	assert.Equal(t, int32(4), *dep.Spec.Replicas)
+
+	// removing autoscaling removes the HPA and restores the replicas
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKeyFromObject(fd), fd))
+	fd.Spec.Autoscaling = nil
+	require.NoError(t, cli.Update(context.TODO(), fd))
+	_, err = rec.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: fdNamespace, Name: fdName}})
+	require.NoError(t, err)
+	assert.True(t, apierrors.IsNotFound(cli.Get(context.TODO(), key, &autoscalingv2.HorizontalPodAutoscaler{})))
+	require.NoError(t, cli.Get(context.TODO(), key, dep))
+	assert.Equal(t, int32(1), *dep.Spec.Replicas)
+}
+
+func TestFlightdeckReconciler_Resources(t *testing.T) {
+	fdName := "test-flightdeck"
+	fdNamespace := "posit-team"
+	fd := defaultFlightdeck(fdName, fdNamespace)
+
+	cli, _, err := runFakeFlightdeckReconciler(t, fdNamespace, fdName, fd)
+	require.NoError(t, err)
+
+	key := client.ObjectKey{Name: fd.ComponentName(), Namespace: fdNamespace}
+	dep := &appsv1.Deployment{}
+	require.NoError(t, cli.Get(context.TODO(), key, dep))
+	require.Len(t, dep.Spec.Template.Spec.Containers, 1)
+	assert.Equal(t, v1beta1.DefaultFlightdeckResources(), dep.Spec.Template.Spec.Containers[0].Resources)
+
+	// configured resources replace the defaults
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKeyFromObject(fd), fd))
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+	}
+	fd.Spec.Resources = &resources
+	require.NoError(t, cli.Update(context.TODO(), fd))
+	rec := FlightdeckReconciler{Client: cli, Scheme: cli.Scheme(), Log: logr.Discard()}
+	_, err = rec.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: fdNamespace, Name: fdName}})
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(context.TODO(), key, dep))
+	assert.True(t, equality.Semantic.DeepEqual(resources, dep.Spec.Template.Spec.Containers[0].Resources))
+}
+
+func TestFlightdeckReconciler_PodScheduling(t *testing.T) {
+	fdName := "test-flightdeck"
+	fdNamespace := "posit-team"
+	fd := defaultFlightdeck(fdName, fdNamespace)
+	fd.Spec.NodeSelector = map[string]string{"pool": "system"}
+	fd.Spec.Tolerations = []corev1.Toleration{{Key: "system", Operator: corev1.TolerationOpExists}}
+	fd.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+	}}
+	fd.Spec.PriorityClassName = "posit-products"
+	fd.Spec.PodLabels = map[string]string{"team": "platform"}
+	fd.Spec.PodAnnotations = map[string]string{"karpenter.sh/do-not-disrupt": "true"}
+
+	cli, _, err := runFakeFlightdeckReconciler(t, fdNamespace, fdName, fd)
+	require.NoError(t, err)
+
+	dep := &appsv1.Deployment{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: fd.ComponentName(), Namespace: fdNamespace}, dep))
+	template := dep.Spec.Template
+	assert.Equal(t, "platform", template.Labels["team"])
+	assert.Equal(t, fd.ComponentName(), template.Labels[v1beta1.KubernetesInstanceLabelKey])
+	assert.Equal(t, fd.Spec.PodAnnotations, template.Annotations)
+	assert.Equal(t, fd.Spec.NodeSelector, template.Spec.NodeSelector)
+	assert.Equal(t, fd.Spec.Tolerations, template.Spec.Tolerations)
+	assert.Equal(t, "posit-products", template.Spec.PriorityClassName)
+	require.Len(t, template.Spec.TopologySpreadConstraints, 1)
+	assert.Equal(t, fd.SelectorLabels(), template.Spec.TopologySpreadConstraints[0].LabelSelector.MatchLabels)
+}
+
+func TestFlightdeckReconciler_SecurityProfile(t *testing.T) {
+	fdName := "test-flightdeck"
+	fdNamespace := "posit-team"
+	fd := defaultFlightdeck(fdName, fdNamespace)
+	fd.Spec.SecurityProfile = v1beta1.SecurityProfileRestricted
+
+	cli, _, err := runFakeFlightdeckReconciler(t, fdNamespace, fdName, fd)
+	require.NoError(t, err)
+
+	dep := &appsv1.Deployment{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: fd.ComponentName(), Namespace: fdNamespace}, dep))
+	podSpec := dep.Spec.Template.Spec
+	assert.True(t, *podSpec.SecurityContext.RunAsNonRoot)
+	assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, podSpec.SecurityContext.SeccompProfile.Type)
+	sc := podSpec.Containers[0].SecurityContext
+	assert.Equal(t, int64(999), *sc.RunAsUser)
+	assert.False(t, *sc.Privileged)
+	assert.True(t, *sc.ReadOnlyRootFilesystem)
+}
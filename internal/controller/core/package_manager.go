@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
 	"github.com/posit-dev/team-operator/api/product"
@@ -27,6 +28,7 @@ import (
 //+kubebuilder:rbac:namespace=posit-team,groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:namespace=posit-team,groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=secrets-store.csi.x-k8s.io,resources=secretproviderclasses,verbs=get;list;watch;create;update;patch;delete
 
 func (r *PackageManagerReconciler) CleanupPackageManager(ctx context.Context, req ctrl.Request, pm *positcov1beta1.PackageManager) (ctrl.Result, error) {
@@ -64,6 +66,12 @@ func (r *PackageManagerReconciler) cleanupDeployedService(ctx context.Context, r
 		return err
 	}
 
+	// HTTPROUTE
+
+	if err := internal.CleanupHTTPRoute(ctx, r, l, key); err != nil {
+		return err
+	}
+
 	// SERVICE
 
 	existingService := &corev1.Service{}
@@ -105,6 +113,16 @@ func (r *PackageManagerReconciler) cleanupDeployedService(ctx context.Context, r
 	return nil
 }
 
+// ingressOptions describes what Package Manager needs from the ingress controller (or Gateway) in front of it
+func (r *PackageManagerReconciler) ingressOptions(pm *positcov1beta1.PackageManager) internal.IngressOptions {
+	return internal.IngressOptions{
+		Timeout:         pm.Spec.Routing.GetRequestTimeout(),
+		PathPrefix:      pm.Spec.RootPath,
+		StripPathPrefix: true,
+		Policy:          pm.Spec.IngressPolicy,
+	}
+}
+
 const packageManagerConfigShaKey = "package-manager.posit.team/configmap-sha"
 
 func (r *PackageManagerReconciler) ReconcilePackageManager(ctx context.Context, req ctrl.Request, pm *positcov1beta1.PackageManager) (ctrl.Result, error) {
@@ -173,7 +191,9 @@ func (r *PackageManagerReconciler) ReconcilePackageManager(ctx context.Context,
 		}
 	}
 
-	// then create the service itself
+	// then create the service itself; the overrides that fail to apply are recorded along the way
+	failedOverrides := pm.Status.FailedOverrides
+	pm.Status.FailedOverrides = nil
 	res, err := r.ensureDeployedService(ctx, req, pm)
 	if err != nil {
 		l.Error(err, "error deploying service")
@@ -182,8 +202,8 @@ func (r *PackageManagerReconciler) ReconcilePackageManager(ctx context.Context,
 
 	// TODO: should we watch for happy pods?
 
-	// set to ready if it is not set yet...
-	if !pm.Status.Ready {
+	// set to ready if it is not set yet, and keep the failed overrides current
+	if !pm.Status.Ready || !slices.Equal(failedOverrides, pm.Status.FailedOverrides) {
 		pm.Status.Ready = true
 		if err := r.Status().Update(ctx, pm); err != nil {
 			l.Error(err, "Error setting ready status")
@@ -356,7 +376,7 @@ func (r *PackageManagerReconciler) ensureDeployedService(ctx context.Context, re
 	if pm.Spec.Url != "" {
 		// TODO: server address protocol should perhaps be configurable...?
 		//       or we should figure out a way to do TLS in development...
-		configCopy.Server.Address = "https://" + pm.Spec.Url
+		configCopy.Server.Address = "https://" + pm.Spec.Url + pm.Spec.RootPath
 	}
 
 	// CONFIGMAP
@@ -456,22 +476,24 @@ func (r *PackageManagerReconciler) ensureDeployedService(ctx context.Context, re
 	if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, deployment, pm, func() error {
 		deployment.Labels = pm.KubernetesLabels()
 		deployment.Spec = v1.DeploymentSpec{
-			Replicas: ptr.To(int32(product.PassDefaultReplicas(pm.Spec.Replicas, 1))),
+			Replicas: deploymentReplicas(deployment.Spec.Replicas, pm.Spec.Replicas, pm.Spec.Autoscaling),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: pm.SelectorLabels(),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: pm.KubernetesLabels(),
-					Annotations: map[string]string{
+					Labels: pm.Spec.GetPodLabels(pm.KubernetesLabels()),
+					Annotations: pm.Spec.GetPodAnnotations(map[string]string{
 						// TODO: this is a hack to get config changes to trigger a new deployment (for now)
 						//   In the future, we could use our own mechanism and decide whether to restart or SIGHUP the service...
 						packageManagerConfigShaKey: cmSha,
-					},
+					}),
 				},
 				Spec: corev1.PodSpec{
 					EnableServiceLinks: ptr.To(false),
 					NodeSelector:       pm.Spec.NodeSelector,
+					Tolerations:        pm.Spec.Tolerations,
+					PriorityClassName:  pm.Spec.PriorityClassName,
 					ImagePullSecrets:   pullSecrets,
 					ServiceAccountName: pm.ComponentName(),
 					Containers: product.ConcatLists([]corev1.Container{
@@ -523,18 +545,7 @@ func (r *PackageManagerReconciler) ensureDeployedService(ctx context.Context, re
 								}(),
 								secretVolumeFactory.VolumeMounts(),
 							),
-							Resources: corev1.ResourceRequirements{
-								Requests: corev1.ResourceList{
-									corev1.ResourceCPU:              resource.MustParse("100m"),
-									corev1.ResourceMemory:           resource.MustParse("2Gi"),
-									corev1.ResourceEphemeralStorage: resource.MustParse("500Mi"),
-								},
-								Limits: corev1.ResourceList{
-									corev1.ResourceCPU:              resource.MustParse("2000m"),
-									corev1.ResourceMemory:           resource.MustParse("4Gi"),
-									corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
-								},
-							},
+							Resources: pm.GetResources(),
 							ReadinessProbe: &corev1.Probe{
 								ProbeHandler: corev1.ProbeHandler{
 									HTTPGet: &corev1.HTTPGetAction{
@@ -552,9 +563,8 @@ func (r *PackageManagerReconciler) ensureDeployedService(ctx context.Context, re
 						},
 					},
 					),
-					Affinity: &corev1.Affinity{
-						PodAntiAffinity: positcov1beta1.ComponentSpecPodAntiAffinity(pm, req.Namespace),
-					},
+					Affinity:                  pm.Spec.GetAffinity(pm, req.Namespace),
+					TopologySpreadConstraints: pm.Spec.GetTopologySpreadConstraints(pm.SelectorLabels()),
 					Volumes: product.ConcatLists([]corev1.Volume{
 						{
 							Name: "config-volume",
@@ -596,6 +606,7 @@ func (r *PackageManagerReconciler) ensureDeployedService(ctx context.Context, re
 			deployment.Spec.Template.Spec.Containers[0].Command = []string{"sleep"}
 			deployment.Spec.Template.Spec.Containers[0].Args = []string{"infinity"}
 		}
+		pm.Spec.SecurityProfile.HardenPod(&deployment.Spec.Template.Spec)
 		return nil
 	}); err != nil {
 		return ctrl.Result{}, err
@@ -610,6 +621,7 @@ func (r *PackageManagerReconciler) ensureDeployedService(ctx context.Context, re
 	}
 	if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, service, pm, func() error {
 		service.Labels = pm.KubernetesLabels()
+		service.Annotations = internal.NewIngressProvider(pm.Spec.Routing).ServiceAnnotations(req.Namespace, pm.ComponentName(), r.ingressOptions(pm))
 		service.Spec = corev1.ServiceSpec{
 			Ports: []corev1.ServicePort{
 				{
@@ -631,13 +643,65 @@ func (r *PackageManagerReconciler) ensureDeployedService(ctx context.Context, re
 		return ctrl.Result{}, err
 	}
 
-	// INGRESS
+	// ROUTING
+
+	if err := pm.Spec.Routing.ValidateIngressPolicy(pm.Spec.IngressPolicy); err != nil {
+		l.Error(err, "Routing cannot enforce the ingress policy")
+		return ctrl.Result{}, err
+	}
+	if pm.Spec.Routing.UseGateway() {
+		if err := r.ensureHTTPRoute(ctx, req, pm); err != nil {
+			l.Error(err, "Error deploying HTTPRoute")
+			return ctrl.Result{}, err
+		}
+	} else if err := r.ensureIngress(ctx, req, pm); err != nil {
+		l.Error(err, "Error deploying ingress")
+		return ctrl.Result{}, err
+	}
+
+	// AUTOSCALING
+	if err := CreateOrUpdateHorizontalPodAutoscaler(ctx, req, r, r.Client, r.Scheme, pm.ComponentName(), pm, pm.Spec.Autoscaling); err != nil {
+		l.Error(err, "Error deploying HorizontalPodAutoscaler")
+		return ctrl.Result{}, err
+	}
 
-	ing_annotations := map[string]string{}
-	for k, v := range pm.Spec.IngressAnnotations {
-		ing_annotations[k] = v
+	// POD DISRUPTION BUDGET
+	if err := CreateOrUpdateDisruptionBudget(
+		ctx, req, r.Client, r.Scheme, pm, pm, minAvailableReplicas(pm.Spec.Replicas, pm.Spec.Autoscaling), nil,
+	); err != nil {
+		return ctrl.Result{}, err
 	}
 
+	return ctrl.Result{}, nil
+}
+
+func (r *PackageManagerReconciler) ensureIngress(ctx context.Context, req ctrl.Request, pm *positcov1beta1.PackageManager) error {
+	l := r.GetLogger(ctx).WithValues(
+		"function", "ensureIngress",
+	)
+
+	// INGRESS PROVIDER RESOURCES
+
+	provider := internal.NewIngressProvider(pm.Spec.Routing)
+	opts := r.ingressOptions(pm)
+	if err := provider.DeployResources(ctx, req, r.Client, r.Scheme, l, pm.ComponentName(), pm, opts); err != nil {
+		l.Error(err, "Error deploying ingress provider resources")
+		return err
+	}
+
+	// INGRESS
+
+	hosts := internal.IngressHosts(pm.Spec.Url, pm.Spec.Aliases)
+	ing_annotations := internal.MergeIngressAnnotations(
+		product.LabelMerge(
+			provider.IngressAnnotations(req.Namespace, pm.ComponentName(), opts),
+			internal.ExternalDNSAnnotations(pm.Spec.Routing.GetExternalDNS(), hosts),
+		),
+		pm.Spec.IngressAnnotations,
+	)
+
+	ingressPath, ingressPathType := provider.IngressPath(opts)
+
 	ingress := &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      pm.ComponentName(),
@@ -649,32 +713,15 @@ func (r *PackageManagerReconciler) ensureDeployedService(ctx context.Context, re
 		ingress.Annotations = ing_annotations
 		ingress.Spec = networkingv1.IngressSpec{
 			// IngressClass set below
-			// TODO: TLS configuration, perhaps
-			TLS: nil,
-			Rules: []networkingv1.IngressRule{
-				{
-					Host: pm.Spec.Url,
-					IngressRuleValue: networkingv1.IngressRuleValue{
-						HTTP: &networkingv1.HTTPIngressRuleValue{
-							Paths: []networkingv1.HTTPIngressPath{
-								{
-									Path:     "/",
-									PathType: ptr.To(networkingv1.PathTypePrefix),
-									Backend: networkingv1.IngressBackend{
-										Service: &networkingv1.IngressServiceBackend{
-											Name: pm.ComponentName(),
-											Port: networkingv1.ServiceBackendPort{
-												Name: "http",
-											},
-										},
-										Resource: nil,
-									},
-								},
-							},
-						},
+			TLS: internal.IngressTLS(hosts, pm.Spec.TLSSecretName),
+			Rules: internal.IngressRules(hosts, ingressPath, ingressPathType, networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: pm.ComponentName(),
+					Port: networkingv1.ServiceBackendPort{
+						Name: "http",
 					},
 				},
-			},
+			}),
 		}
 		// only define the ingressClassName if it is specified on the site
 		if pm.Spec.IngressClass != "" {
@@ -682,15 +729,32 @@ func (r *PackageManagerReconciler) ensureDeployedService(ctx context.Context, re
 		}
 		return nil
 	}); err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 
-	// POD DISRUPTION BUDGET
-	if err := CreateOrUpdateDisruptionBudget(
-		ctx, req, r.Client, r.Scheme, pm, pm, ptr.To(product.DetermineMinAvailableReplicas(pm.Spec.Replicas)), nil,
-	); err != nil {
-		return ctrl.Result{}, err
+	// remove any HTTPRoute left over from gateway routing mode
+	return internal.CleanupHTTPRoute(ctx, r, l, client.ObjectKeyFromObject(ingress))
+}
+
+func (r *PackageManagerReconciler) ensureHTTPRoute(ctx context.Context, req ctrl.Request, pm *positcov1beta1.PackageManager) error {
+	l := r.GetLogger(ctx).WithValues(
+		"function", "ensureHTTPRoute",
+	)
+
+	opts := r.ingressOptions(pm).HTTPRouteOptions(internal.IngressHosts(pm.Spec.Url, pm.Spec.Aliases), pm.ComponentName(), 80)
+	if err := internal.DeployHTTPRoute(ctx, req, r.Client, r.Scheme, l, pm.ComponentName(), pm, pm.Spec.Routing, opts); err != nil {
+		return err
 	}
 
-	return ctrl.Result{}, nil
+	// remove any Traefik middlewares left over from ingress routing mode
+	if err := internal.CleanupTraefikResources(ctx, r.Client, l, req.Namespace, pm.ComponentName()); err != nil {
+		return err
+	}
+
+	// remove any Ingress left over from ingress routing mode
+	key := client.ObjectKey{
+		Name:      pm.ComponentName(),
+		Namespace: req.Namespace,
+	}
+	return internal.BasicDelete(ctx, r, l, key, &networkingv1.Ingress{})
 }
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/internal/registry"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// imagePullKeychain returns the registry credentials of the image pull secrets in namespace. Secrets that cannot be
+// read are left out, so that their registries are read anonymously
+func imagePullKeychain(ctx context.Context, l logr.Logger, c client.Reader, namespace string, names []string) registry.SecretKeychain {
+	var secrets []corev1.Secret
+	for _, name := range names {
+		s := corev1.Secret{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &s); err != nil {
+			l.Error(err, "error reading image pull secret", "secret", name)
+			continue
+		}
+		if _, err := registry.NewSecretKeychain(s); err != nil {
+			l.Error(err, "error parsing image pull secret", "secret", name)
+			continue
+		}
+		secrets = append(secrets, s)
+	}
+	// every secret parses on its own, so the keychain does too
+	keychain, _ := registry.NewSecretKeychain(secrets...)
+	return keychain
+}
+
+// shorterRetry returns the shorter of two delays before a registry is read again, where 0 is no retry
+func shorterRetry(a, b time.Duration) time.Duration {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// discoverExecutionEnvironments sets the installations of the execution environments without any to the runtime
+// versions that the labels of their image list, and records them in the status. Environments whose image has no
+// labels are left out, and environments whose registry cannot be read keep the installations that the status
+// recorded, if any; both are listed in the status. It returns the time until a registry that could not be read is
+// read again, or 0
+func (r *ConnectReconciler) discoverExecutionEnvironments(ctx context.Context, l logr.Logger, c *positcov1beta1.Connect) time.Duration {
+	previous := c.Status.DiscoveredExecutionEnvironments
+	c.Status.ExecutionEnvironmentWarnings = nil
+	c.Status.DiscoveredExecutionEnvironments = nil
+	discovers := slices.ContainsFunc(c.Spec.ExecutionEnvironments, func(env positcov1beta1.ConnectExecutionEnvironment) bool {
+		return env.DiscoversInstallations()
+	})
+	if !c.Spec.OffHostExecution || !discovers {
+		return 0
+	}
+
+	keychain := imagePullKeychain(ctx, l, r, c.Namespace, c.Spec.ImagePullSecrets)
+	var environments []positcov1beta1.ConnectExecutionEnvironment
+	var retryAfter time.Duration
+	for _, env := range c.Spec.ExecutionEnvironments {
+		if !env.DiscoversInstallations() {
+			environments = append(environments, env)
+			continue
+		}
+
+		image := env.ImageName()
+		if r.RuntimeVersions == nil {
+			c.Status.ExecutionEnvironmentWarnings = append(c.Status.ExecutionEnvironmentWarnings, fmt.Sprintf("runtime version discovery is disabled: %s", image))
+			continue
+		}
+		discovered, err := r.discoverInstallations(ctx, image, keychain)
+		if err != nil {
+			l.Error(err, "error discovering the runtime versions of execution environment", "image", image)
+			c.Status.ExecutionEnvironmentWarnings = append(c.Status.ExecutionEnvironmentWarnings, err.Error())
+
+			var lookupErr *registry.LookupError
+			if !errors.As(err, &lookupErr) {
+				continue
+			}
+			retryAfter = shorterRetry(retryAfter, lookupErr.RetryAfter)
+			i := slices.IndexFunc(previous, func(p positcov1beta1.ConnectExecutionEnvironment) bool {
+				return p.Image == image
+			})
+			if i < 0 {
+				continue
+			}
+			// the registry could not be read, so the environment keeps the installations that were last discovered
+			discovered = previous[i]
+		}
+		c.Status.DiscoveredExecutionEnvironments = append(c.Status.DiscoveredExecutionEnvironments, discovered)
+		env.R, env.Python, env.Quarto = discovered.R, discovered.Python, discovered.Quarto
+		environments = append(environments, env)
+	}
+	c.Spec.ExecutionEnvironments = environments
+	return retryAfter
+}
+
+// discoverInstallations returns the execution environment of image with the installations that its labels list
+func (r *ConnectReconciler) discoverInstallations(ctx context.Context, image string, keychain registry.SecretKeychain) (positcov1beta1.ConnectExecutionEnvironment, error) {
+	versions, err := r.RuntimeVersions.Discover(ctx, image, keychain)
+	if err != nil {
+		return positcov1beta1.ConnectExecutionEnvironment{}, err
+	}
+
+	env := positcov1beta1.ConnectExecutionEnvironment{Image: image}
+	var errs []error
+	for _, runtime := range []struct {
+		versions      []string
+		path          string
+		pathLabel     string
+		installations *[]positcov1beta1.ConnectRuntimeInstallation
+	}{
+		{versions.R, versions.RPath, registry.RPathLabel, &env.R},
+		{versions.Python, versions.PythonPath, registry.PythonPathLabel, &env.Python},
+		{versions.Quarto, versions.QuartoPath, registry.QuartoPathLabel, &env.Quarto},
+	} {
+		if len(runtime.versions) == 0 {
+			continue
+		}
+		// the versions of a runtime are installed at different paths
+		if runtime.path == "" || (len(runtime.versions) > 1 && !strings.Contains(runtime.path, registry.VersionPlaceholder)) {
+			errs = append(errs, fmt.Errorf("the %s label of image %s does not list the path of each version", runtime.pathLabel, image))
+			continue
+		}
+		for _, v := range runtime.versions {
+			*runtime.installations = append(*runtime.installations, positcov1beta1.ConnectRuntimeInstallation{
+				Version: v,
+				Path:    registry.ExecutablePath(runtime.path, v),
+			})
+		}
+	}
+	return env, errors.Join(errs...)
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/posit-dev/team-operator/internal"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:namespace=posit-team,groups="",resources=resourcequotas,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:namespace=posit-team,groups="",resources=limitranges,verbs=get;list;watch;create;update;patch;delete
+
+// sessionQuotaName is the name of the ResourceQuota and LimitRange of a product's launched pods
+func sessionQuotaName(componentName string) string {
+	return fmt.Sprintf("%s-sessions", componentName)
+}
+
+// CreateOrUpdateSessionQuota caps the pods that a product launches into req.Namespace according to config, and
+// returns the usage of the quota. A LimitRange cannot be scoped to the launched pods, so it is only created in a
+// session namespace, away from the product's own pods. The ResourceQuota and LimitRange are removed when config is
+// nil
+func CreateOrUpdateSessionQuota(ctx context.Context, req ctrl.Request, r product.SomeReconciler, c client.Client, scheme *runtime.Scheme, componentName string, owner internal.RouteOwner, config *positcov1beta1.SessionQuota) (*positcov1beta1.SessionQuotaStatus, error) {
+	l := r.GetLogger(ctx).WithValues(
+		"function", "CreateOrUpdateSessionQuota",
+	)
+
+	key := client.ObjectKey{Name: sessionQuotaName(componentName), Namespace: req.Namespace}
+	if config == nil {
+		if err := internal.BasicDelete(ctx, r, l, key, &corev1.ResourceQuota{}); err != nil {
+			return nil, err
+		}
+		return nil, internal.BasicDelete(ctx, r, l, key, &corev1.LimitRange{})
+	}
+
+	// owner references cannot cross namespaces, so a quota in another namespace is labeled with the owner's
+	var controller client.Object
+	labels := owner.KubernetesLabels()
+	if owner.GetNamespace() == key.Namespace {
+		controller = owner
+	} else {
+		labels = product.LabelMerge(labels, map[string]string{
+			positcov1beta1.OwnerNamespaceLabelKey: owner.GetNamespace(),
+		})
+	}
+
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+	}
+	if _, err := internal.CreateOrUpdateResource(ctx, c, scheme, l, quota, controller, func() error {
+		quota.Labels = labels
+		quota.Spec = corev1.ResourceQuotaSpec{
+			Hard: config.Hard(),
+			// only the launched pods have the PriorityClass
+			ScopeSelector: &corev1.ScopeSelector{
+				MatchExpressions: []corev1.ScopedResourceSelectorRequirement{
+					{
+						ScopeName: corev1.ResourceQuotaScopePriorityClass,
+						Operator:  corev1.ScopeSelectorOpIn,
+						Values:    []string{config.PriorityClassName},
+					},
+				},
+			},
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	sessionNamespace := owner.GetNamespace() != key.Namespace
+	if config.LimitRange != nil && !sessionNamespace {
+		l.Info("ignoring the session limit range, because it would apply to the product's own pods; it needs a session namespace")
+	}
+	if config.LimitRange == nil || !sessionNamespace {
+		if err := internal.BasicDelete(ctx, r, l, key, &corev1.LimitRange{}); err != nil {
+			return nil, err
+		}
+	} else {
+		limitRange := &corev1.LimitRange{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+		if _, err := internal.CreateOrUpdateResource(ctx, c, scheme, l, limitRange, controller, func() error {
+			limitRange.Labels = labels
+			limitRange.Spec = corev1.LimitRangeSpec{
+				Limits: []corev1.LimitRangeItem{
+					{
+						Type:           corev1.LimitTypeContainer,
+						DefaultRequest: config.LimitRange.DefaultRequest,
+						Default:        config.LimitRange.Default,
+						Max:            config.LimitRange.Max,
+					},
+				},
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &positcov1beta1.SessionQuotaStatus{
+		Hard: quota.Status.Hard,
+		Used: quota.Status.Used,
+	}, nil
+}
+
+// sessionQuotaStatusEqual reports whether two usages of a session quota are the same
+func sessionQuotaStatusEqual(a, b *positcov1beta1.SessionQuotaStatus) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return equality.Semantic.DeepEqual(a.Hard, b.Hard) && equality.Semantic.DeepEqual(a.Used, b.Used)
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/posit-dev/team-operator/internal"
+	"github.com/rstudio/goex/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func testSessionQuota() *positcov1beta1.SessionQuota {
+	return &positcov1beta1.SessionQuota{
+		PriorityClassName: "sessions",
+		CPU:               ptr.To(resource.MustParse("64")),
+		Memory:            ptr.To(resource.MustParse("256Gi")),
+		NvidiaGPUs:        ptr.To(resource.MustParse("4")),
+		Pods:              ptr.To(int32(50)),
+		LimitRange: &positcov1beta1.SessionLimitRange{
+			DefaultRequest: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			Max:            corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("32Gi")},
+		},
+	}
+}
+
+func TestWorkbenchReconciler_BasicSessionQuota(t *testing.T) {
+	ctx := context.Background()
+	ns := "posit-team"
+	name := "workbench-basic-quota"
+
+	ctx, r, req, cli := initWorkbenchReconciler(t, ctx, ns, name)
+	wb := defineDefaultWorkbench(t, ns, name)
+	key := client.ObjectKey{Namespace: ns, Name: wb.ComponentName() + "-sessions"}
+
+	_, err := CreateOrUpdateSessionQuota(ctx, req, r, cli, r.Scheme, wb.ComponentName(), wb, testSessionQuota())
+	require.NoError(t, err)
+
+	// the quota only counts the pods of the PriorityClass
+	quota := &corev1.ResourceQuota{}
+	require.NoError(t, cli.Get(ctx, key, quota))
+	assert.True(t, equality.Semantic.DeepEqual(corev1.ResourceList{
+		corev1.ResourceRequestsCPU:    resource.MustParse("64"),
+		corev1.ResourceRequestsMemory: resource.MustParse("256Gi"),
+		"requests.nvidia.com/gpu":     resource.MustParse("4"),
+		corev1.ResourcePods:           resource.MustParse("50"),
+	}, quota.Spec.Hard), quota.Spec.Hard)
+	require.Len(t, quota.Spec.ScopeSelector.MatchExpressions, 1)
+	assert.Equal(t, corev1.ScopedResourceSelectorRequirement{
+		ScopeName: corev1.ResourceQuotaScopePriorityClass,
+		Operator:  corev1.ScopeSelectorOpIn,
+		Values:    []string{"sessions"},
+	}, quota.Spec.ScopeSelector.MatchExpressions[0])
+
+	// the LimitRange would cap the product's own pods too
+	assert.True(t, kerrors.IsNotFound(cli.Get(ctx, key, &corev1.LimitRange{})))
+
+	// the usage is copied from the ResourceQuota
+	quota.Status = corev1.ResourceQuotaStatus{
+		Hard: quota.Spec.Hard,
+		Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("3"), corev1.ResourcePods: resource.MustParse("2")},
+	}
+	require.NoError(t, cli.Status().Update(ctx, quota))
+	status, err := CreateOrUpdateSessionQuota(ctx, req, r, cli, r.Scheme, wb.ComponentName(), wb, testSessionQuota())
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.True(t, equality.Semantic.DeepEqual(quota.Status.Used, status.Used), status.Used)
+	assert.True(t, equality.Semantic.DeepEqual(quota.Status.Hard, status.Hard), status.Hard)
+
+	// ... and neither without a quota
+	status, err = CreateOrUpdateSessionQuota(ctx, req, r, cli, r.Scheme, wb.ComponentName(), wb, nil)
+	require.NoError(t, err)
+	assert.Nil(t, status)
+	assert.True(t, kerrors.IsNotFound(cli.Get(ctx, key, &corev1.ResourceQuota{})))
+
+	// in a session namespace, the LimitRange caps the session containers
+	sessionNs := "posit-team-basic-quota-sessions"
+	require.NoError(t, cli.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sessionNs}}))
+	sessionReq := req
+	sessionReq.Namespace = sessionNs
+	sessionKey := client.ObjectKey{Namespace: sessionNs, Name: key.Name}
+	_, err = CreateOrUpdateSessionQuota(ctx, sessionReq, r, cli, r.Scheme, wb.ComponentName(), wb, testSessionQuota())
+	require.NoError(t, err)
+
+	limitRange := &corev1.LimitRange{}
+	require.NoError(t, cli.Get(ctx, sessionKey, limitRange))
+	require.Len(t, limitRange.Spec.Limits, 1)
+	assert.Equal(t, corev1.LimitTypeContainer, limitRange.Spec.Limits[0].Type)
+	assert.True(t, resource.MustParse("32Gi").Equal(limitRange.Spec.Limits[0].Max[corev1.ResourceMemory]))
+	// owner references cannot cross namespaces
+	assert.Empty(t, limitRange.OwnerReferences)
+	assert.Equal(t, ns, limitRange.Labels[positcov1beta1.OwnerNamespaceLabelKey])
+	require.NoError(t, cli.Get(ctx, sessionKey, &corev1.ResourceQuota{}))
+
+	// without a LimitRange, only the ResourceQuota is kept
+	config := testSessionQuota()
+	config.LimitRange = nil
+	_, err = CreateOrUpdateSessionQuota(ctx, sessionReq, r, cli, r.Scheme, wb.ComponentName(), wb, config)
+	require.NoError(t, err)
+	assert.True(t, kerrors.IsNotFound(cli.Get(ctx, sessionKey, &corev1.LimitRange{})))
+	require.NoError(t, cli.Get(ctx, sessionKey, &corev1.ResourceQuota{}))
+}
+
+func TestConnectReconciler_SessionQuota(t *testing.T) {
+	ctx := context.Background()
+	ns := "posit-team"
+	name := "connect-quota"
+
+	ctx, r, req, cli := initConnectReconciler(t, ctx, ns, name)
+
+	c := defineDefaultConnect(t, ns, name)
+	c.Spec.OffHostExecution = true
+	c.Spec.Volume = &product.VolumeSpec{Create: true}
+	c.Spec.SessionQuota = testSessionQuota()
+
+	err := internal.BasicCreateOrUpdate(ctx, r, r.GetLogger(ctx), req.NamespacedName, &positcov1beta1.Connect{}, c)
+	require.NoError(t, err)
+	c = getConnect(t, cli, ns, name)
+
+	res, err := r.ReconcileConnect(ctx, req, c)
+	require.NoError(t, err)
+	require.True(t, res.IsZero())
+
+	key := client.ObjectKey{Namespace: ns, Name: c.ComponentName() + "-sessions"}
+	quota := &corev1.ResourceQuota{}
+	require.NoError(t, cli.Get(ctx, key, quota))
+	assert.Equal(t, resource.MustParse("64"), quota.Spec.Hard[corev1.ResourceRequestsCPU])
+	// the content pods share the namespace with Connect, so there is no LimitRange
+	assert.Error(t, cli.Get(ctx, key, &corev1.LimitRange{}))
+
+	// the content pods get the PriorityClass of the quota, unless they have their own
+	assert.Contains(t, c.SessionConfigTemplateData(logr.NewContext(ctx, r.Log)), `"priorityClassName":"sessions"`)
+	c.Spec.SessionConfig = &product.SessionConfig{Pod: &product.PodConfig{PriorityClassName: "content"}}
+	assert.Contains(t, c.SessionConfigTemplateData(logr.NewContext(ctx, r.Log)), `"priorityClassName":"content"`)
+
+	_, err = r.CleanupConnect(ctx, req, c)
+	require.NoError(t, err)
+	assert.Error(t, cli.Get(ctx, key, &corev1.ResourceQuota{}))
+	assert.Error(t, cli.Get(ctx, key, &corev1.LimitRange{}))
+}
@@ -15,10 +15,12 @@ import (
 	"github.com/posit-dev/team-operator/internal"
 	"github.com/rstudio/goex/ptr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -29,6 +31,17 @@ type SiteReconciler struct {
 	client.Client
 	Log    logr.Logger
 	Scheme *runtime.Scheme
+
+	// APIReader reads the Workbench session namespace, which the cache of the manager does not hold. Defaults to
+	// the Client
+	APIReader client.Reader
+
+	// SessionNamespaceRole is the ClusterRole with the permissions that the operator needs in a Workbench session
+	// namespace, which a Site binds to OperatorServiceAccount there. Empty leaves the binding to the cluster admin
+	SessionNamespaceRole string
+
+	// OperatorServiceAccount is the ServiceAccount that the operator runs as
+	OperatorServiceAccount types.NamespacedName
 }
 
 //+kubebuilder:rbac:namespace=posit-team,groups=core.posit.team,resources=sites,verbs=get;list;watch;create;update;patch;delete
@@ -39,11 +52,14 @@ type SiteReconciler struct {
 //+kubebuilder:rbac:namespace=posit-team,groups="apps",resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
 
 //+kubebuilder:rbac:namespace=posit-team,groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:namespace=posit-team,groups=cilium.io,resources=ciliumnetworkpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:namespace=posit-team,groups=projectcalico.org,resources=networkpolicies;networksets,verbs=get;list;watch;create;update;patch;delete
 
 //+kubebuilder:rbac:namespace=posit-team,groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 
 //+kubebuilder:rbac:namespace=posit-team,groups="k8s.keycloak.org",resources=keycloaks,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups="k8s.keycloak.org",resources=keycloakrealmimports,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:namespace=posit-team,groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -73,28 +89,63 @@ func (r *SiteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, err
 	}
 
+	if !s.DeletionTimestamp.IsZero() {
+		l.Info("Site deleted; cleaning up session namespaces")
+		return ctrl.Result{}, r.finalizeSessionNamespaces(ctx, req, s)
+	}
+
 	l.Info("Site found; updating resources")
 
-	return r.reconcileResources(ctx, req, s)
+	status := s.Status.DeepCopy()
+	res, err := r.reconcileResources(ctx, req, s)
+	if err != nil {
+		return res, err
+	}
+
+	// the network policy rules are recorded while reconciling
+	if !equality.Semantic.DeepEqual(*status, s.Status) {
+		if err := r.Status().Update(ctx, s); err != nil {
+			l.Error(err, "Error updating status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return res, nil
 }
 
 var rootVolumeSize = resource.MustParse("1Gi")
 var connectVolumeSize = resource.MustParse("10Gi")
 var workbenchSharedStorageVolumeSize = resource.MustParse("10Gi")
 
-func prefixDomain(prefix, domain string, domainType positcov1beta1.SiteDomainType) string {
-	if domainType == positcov1beta1.SiteDashDomain {
-		return fmt.Sprintf("%s-%s", prefix, domain)
-	}
-	return fmt.Sprintf("%s.%s", prefix, domain)
-}
-
 func (r *SiteReconciler) reconcileResources(ctx context.Context, req ctrl.Request, site *positcov1beta1.Site) (ctrl.Result, error) {
 
 	l := r.GetLogger(ctx).WithValues(
 		"event", "reconcile-resources",
 	)
 
+	// the overrides that fail to apply are recorded along the way
+	site.Status.FailedOverrides = nil
+
+	if err := site.Spec.ValidateSecurityProfile(); err != nil {
+		l.Error(err, "site conflicts with its security profile")
+		return ctrl.Result{}, err
+	}
+
+	if err := site.Spec.ValidateIngressPolicies(); err != nil {
+		l.Error(err, "site routing cannot enforce its ingress policies")
+		return ctrl.Result{}, err
+	}
+
+	if err := site.Spec.ValidateSessionQuotas(); err != nil {
+		l.Error(err, "invalid session quota")
+		return ctrl.Result{}, err
+	}
+
+	if err := site.Spec.ValidateWorkbenchUsers(); err != nil {
+		l.Error(err, "site cannot sync its workbench users")
+		return ctrl.Result{}, err
+	}
+
 	var dbUrl *url.URL
 	var err error
 	// NOTE: this dbUrl can have the password in it!
@@ -111,6 +162,19 @@ func (r *SiteReconciler) reconcileResources(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	// POD SECURITY ADMISSION
+
+	if err := r.reconcilePodSecurityLabels(ctx, req, site); err != nil {
+		l.Error(err, "error reconciling pod security labels")
+		return ctrl.Result{}, err
+	}
+
+	// WORKBENCH SESSION NAMESPACE
+	if err := r.reconcileSessionNamespace(ctx, req, site); err != nil {
+		l.Error(err, "error reconciling workbench session namespace")
+		return ctrl.Result{}, err
+	}
+
 	// IMAGE PREPULL DAEMONSET
 	if !site.Spec.DisablePrePullImages {
 		if err := deployPrePullDaemonset(ctx, r, req, site); err != nil {
@@ -123,13 +187,12 @@ func (r *SiteReconciler) reconcileResources(ctx context.Context, req ctrl.Reques
 	// Building these here instead of in the product reconciler because packageManagerUrl is needed to
 	// create the packageManagerRepoUrl which must be passed to more than one product and this keeps them all together.
 
-	// Default to subdomain type since SiteHome is removed
-	domainType := positcov1beta1.SiteSubDomain
-	packageManagerUrl := prefixDomain(site.Spec.PackageManager.DomainPrefix, site.Spec.Domain, domainType)
-	connectUrl := prefixDomain(site.Spec.Connect.DomainPrefix, site.Spec.Domain, domainType)
-	workbenchUrl := prefixDomain(site.Spec.Workbench.DomainPrefix, site.Spec.Domain, domainType)
+	// these are primary hosts; in the path layout every product shares the site domain and is told its root path instead
+	packageManagerUrl := site.ProductHost(site.Spec.PackageManager.DomainPrefix, site.Spec.PackageManager.Hostnames)
+	connectUrl := site.ProductHost(site.Spec.Connect.DomainPrefix, site.Spec.Connect.Hostnames)
+	workbenchUrl := site.ProductHost(site.Spec.Workbench.DomainPrefix, site.Spec.Workbench.Hostnames)
 
-	packageManagerRepoUrl := fmt.Sprintf("https://%s/cran/__linux__/jammy/latest", packageManagerUrl) // TODO: don't hardcode OS
+	packageManagerRepoUrl := fmt.Sprintf("%s/cran/__linux__/jammy/latest", site.ProductUrl(site.Spec.PackageManager.DomainPrefix, site.Spec.PackageManager.Hostnames)) // TODO: don't hardcode OS
 	if site.Spec.PackageManagerUrl != "" {
 		packageManagerRepoUrl = site.Spec.PackageManagerUrl
 	}
@@ -420,6 +483,7 @@ func (r *SiteReconciler) cleanupResources(ctx context.Context, req ctrl.Request)
 		l.Error(err, "error cleaning up network policies")
 	}
 
+	// the session namespaces are not owned by the Site; its finalizer deleted them
 	return ctrl.Result{}, nil
 }
 
@@ -65,10 +65,15 @@ func (r *SiteReconciler) reconcileChronicle(ctx context.Context, req controllerr
 				LocalStorage: nil,
 				// ^^ NOTE: the storage settings are set below
 			},
-			ImagePullSecrets: site.Spec.ImagePullSecrets,
-			NodeSelector:     site.Spec.Chronicle.NodeSelector,
-			AddEnv:           site.Spec.Chronicle.AddEnv,
-			Image:            chronicleServerImage,
+			ImagePullSecrets:    site.Spec.ImagePullSecrets,
+			NodeSelector:        site.Spec.Chronicle.NodeSelector,
+			PodSchedulingConfig: site.Spec.Chronicle.PodSchedulingConfig,
+			AddEnv:              site.Spec.Chronicle.AddEnv,
+			Image:               chronicleServerImage,
+			Resources:           site.Spec.Chronicle.Resources,
+			AgentResources:      site.Spec.Chronicle.AgentResources,
+			SecurityProfile:     site.Spec.SecurityProfile,
+			Overrides:           site.Spec.Overrides,
 		}
 
 		// configure storage mechanism based on whether s3 bucket is set...
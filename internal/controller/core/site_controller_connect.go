@@ -122,9 +122,13 @@ func (r *SiteReconciler) reconcileConnect(
 					},
 				},
 			},
-			Volume:     site.Spec.Connect.Volume,
-			SecretType: site.Spec.Secret.Type,
-			Url:        connectUrl,
+			Volume:        site.Spec.Connect.Volume,
+			SecretType:    site.Spec.Secret.Type,
+			Url:           connectUrl,
+			Aliases:       site.ProductAliases(site.Spec.Connect.Hostnames),
+			TLSSecretName: site.Spec.Connect.TLSSecretName,
+			IngressPolicy: site.Spec.Connect.IngressPolicy,
+			RootPath:      site.ProductPath(site.Spec.Connect.DomainPrefix),
 			DatabaseConfig: v1beta1.PostgresDatabaseConfig{
 				Host:                  dbHost,
 				DropOnTeardown:        site.Spec.DropDatabaseOnTeardown,
@@ -135,13 +139,16 @@ func (r *SiteReconciler) reconcileConnect(
 			MainDatabaseCredentialSecret: site.Spec.MainDatabaseCredentialSecret,
 			IngressClass:                 site.Spec.IngressClass,
 			IngressAnnotations:           site.Spec.IngressAnnotations,
+			Routing:                      site.Spec.Routing,
 			Image:                        site.Spec.Connect.Image,
 			SessionImage:                 site.Spec.Connect.SessionImage,
 			ImagePullPolicy:              site.Spec.Connect.ImagePullPolicy,
 			ImagePullSecrets:             site.Spec.ImagePullSecrets,
 			ChronicleAgentImage:          site.Spec.Chronicle.AgentImage,
+			ChronicleAgentResources:      site.Spec.Chronicle.AgentResources,
 			AdditionalVolumes:            additionalVolumes,
 			NodeSelector:                 site.Spec.Connect.NodeSelector,
+			PodSchedulingConfig:          site.Spec.Connect.PodSchedulingConfig,
 			AddEnv:                       site.Spec.Connect.AddEnv,
 			// default to true...
 			OffHostExecution: true,
@@ -150,9 +157,21 @@ func (r *SiteReconciler) reconcileConnect(
 			WorkloadSecret:   site.Spec.WorkloadSecret,
 			Debug:            connectDebugLog,
 			Replicas:         product.PassDefaultReplicas(site.Spec.Connect.Replicas, 1),
+			Autoscaling:      site.Spec.Connect.Autoscaling,
+			SessionQuota:     site.Spec.Connect.SessionQuota,
+			Resources:        site.Spec.Connect.Resources,
+			SecurityProfile:  site.Spec.SecurityProfile,
+			Overrides:        site.Spec.Overrides,
 		},
 	}
 
+	targetConnect.Spec.ExecutionEnvironments = site.Spec.Connect.ExecutionEnvironments
+	targetConnect.Spec.ReplaceDefaultExecutionEnvironments = site.Spec.Connect.ReplaceDefaultExecutionEnvironments
+	if err := targetConnect.Spec.ValidateExecutionEnvironments(); err != nil {
+		l.Error(err, "invalid connect execution environments")
+		return err
+	}
+
 	if site.Spec.Connect.GPUSettings != nil {
 		if site.Spec.Connect.GPUSettings.NvidiaGPULimit > 0 {
 			targetConnect.Spec.Config.Scheduler.NvidiaGPULimit = site.Spec.Connect.GPUSettings.NvidiaGPULimit
@@ -97,12 +97,21 @@ func (r *SiteReconciler) reconcileFlightdeck(
 			SiteName:             site.Name,
 			Image:                flightdeckImage,
 			ImagePullPolicy:      imagePullPolicy,
+			NodeSelector:         site.Spec.Flightdeck.NodeSelector,
+			PodSchedulingConfig:  site.Spec.Flightdeck.PodSchedulingConfig,
 			Port:                 8080,
 			Replicas:             replicas,
+			Autoscaling:          site.Spec.Flightdeck.Autoscaling,
+			Resources:            site.Spec.Flightdeck.Resources,
+			SecurityProfile:      site.Spec.SecurityProfile,
+			Overrides:            site.Spec.Overrides,
 			FeatureEnabler:       site.Spec.Flightdeck.FeatureEnabler,
-			Domain:               site.Spec.Domain,
+			Domain:               site.FlightdeckHost(),
+			Aliases:              site.ProductAliases(site.Spec.Flightdeck.Hostnames),
+			TLSSecretName:        site.Spec.Flightdeck.TLSSecretName,
 			IngressClass:         site.Spec.IngressClass,
 			IngressAnnotations:   site.Spec.IngressAnnotations,
+			Routing:              site.Spec.Routing,
 			ImagePullSecrets:     site.Spec.ImagePullSecrets,
 			AwsAccountId:         site.Spec.AwsAccountId,
 			ClusterDate:          site.Spec.ClusterDate,
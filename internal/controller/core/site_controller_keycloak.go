@@ -13,6 +13,7 @@ import (
 	"github.com/rstudio/goex/ptr"
 	v14 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	controllerruntime "sigs.k8s.io/controller-runtime"
@@ -20,6 +21,9 @@ import (
 	v13 "sigs.k8s.io/secrets-store-csi-driver/apis/v1"
 )
 
+// keycloakDomainPrefix is the domain prefix (or path, in the path layout) that keycloak is served under
+const keycloakDomainPrefix = "key"
+
 func (r *SiteReconciler) reconcileKeycloak(ctx context.Context, req controllerruntime.Request, site *v1beta1.Site, dbUrl *url.URL, sslMode string) error {
 	l := r.GetLogger(ctx).WithValues(
 		"event", "reconcile-keycloak",
@@ -31,7 +35,9 @@ func (r *SiteReconciler) reconcileKeycloak(ctx context.Context, req controllerru
 	}
 	keycloakKey := client.ObjectKey{Name: localKeycloak.ComponentName(), Namespace: req.Namespace}
 	if site.Spec.Keycloak.Enabled {
-		keycloakDomain := prefixDomain("key", site.Spec.Domain, v1beta1.SiteSubDomain)
+		keycloakDomain := site.ProductHost(keycloakDomainPrefix, site.Spec.Keycloak.Hostnames)
+		keycloakPath := site.ProductPath(keycloakDomainPrefix)
+		keycloakHosts := internal.IngressHosts(keycloakDomain, site.ProductAliases(site.Spec.Keycloak.Hostnames))
 
 		// ensure database...
 		secretKey := "keycloak-db-password"
@@ -99,6 +105,7 @@ func (r *SiteReconciler) reconcileKeycloak(ctx context.Context, req controllerru
 				l.Error(err, "Error preparing keycloak secret provider class consumer deployment")
 				return err
 			} else {
+				site.Spec.SecurityProfile.HardenPod(&targetKeycloakSpcConsumer.Spec.Template.Spec)
 				keycloakSpcConsumer := &v14.Deployment{
 					ObjectMeta: v12.ObjectMeta{
 						Name:      targetKeycloakSpcConsumer.Name,
@@ -117,15 +124,30 @@ func (r *SiteReconciler) reconcileKeycloak(ctx context.Context, req controllerru
 			}
 		}
 
-		// deploy keycloak middleware
-		if err := internal.DeployTraefikForwardMiddlewareWithHost(
-			ctx, req, r.Client, r.Scheme, l,
-			localKeycloak.MiddlewareForwardName(),
-			site,
-			keycloakDomain,
-		); err != nil {
-			l.Error(err, "error deploying keycloak middlewares")
-			return err
+		// deploy keycloak middleware (HTTPRoutes set the forwarded headers themselves)
+		useGateway := site.Spec.Routing.UseGateway()
+		// the keycloak operator's ingress serves "/" (which belongs to flightdeck in the path layout) on a single
+		// host without TLS
+		useOperatorIngress := !useGateway && keycloakPath == "" && len(keycloakHosts) == 1 && site.Spec.Keycloak.TLSSecretName == ""
+		ingressProvider := internal.NewIngressProvider(site.Spec.Routing)
+		ingressOptions := internal.IngressOptions{
+			// keycloak serves its relative path itself, so the prefix is kept
+			PathPrefix: keycloakPath,
+		}
+		// the ALB cannot set X-Forwarded-Host, but it keeps the Host header, which keycloak falls back to
+		if site.Spec.Routing.SetsRequestHeaders() {
+			ingressOptions.ForwardedHost = keycloakDomain
+		}
+		if !useGateway {
+			if err := ingressProvider.DeployResources(
+				ctx, req, r.Client, r.Scheme, l,
+				localKeycloak.ComponentName(),
+				site,
+				ingressOptions,
+			); err != nil {
+				l.Error(err, "error deploying keycloak middlewares")
+				return err
+			}
 		}
 
 		// deploy keycloak instance by using operator
@@ -159,20 +181,17 @@ func (r *SiteReconciler) reconcileKeycloak(ctx context.Context, req controllerru
 				HttpsPort:   8443,
 			},
 			Hostname: &v2alpha1.KeycloakHostnameSpec{
-				Hostname: keycloakDomain,
-				//Admin:             prefixDomain("key", site.Spec.Domain, v1beta1.SiteSubDomain),
+				Hostname:          keycloakDomain,
 				Strict:            true,
 				StrictBackchannel: true,
 			},
 			Instances: 1,
 			Ingress: &v2alpha1.KeycloakIngressSpec{
-				Enabled: true,
-				Annotations: map[string]string{
-					internal.TraefikMiddlewaresKey: internal.BuildTraefikMiddlewareAnnotation(
-						req.Namespace,
-						localKeycloak.MiddlewareForwardName(),
-					),
-				},
+				Enabled: useOperatorIngress,
+				Annotations: product.LabelMerge(
+					ingressProvider.IngressAnnotations(req.Namespace, localKeycloak.ComponentName(), ingressOptions),
+					internal.ExternalDNSAnnotations(site.Spec.Routing.GetExternalDNS(), keycloakHosts),
+				),
 			},
 			Features: &v2alpha1.KeycloakFeaturesSpec{
 				Enabled: []string{
@@ -216,10 +235,25 @@ func (r *SiteReconciler) reconcileKeycloak(ctx context.Context, req controllerru
 			Transaction: &v2alpha1.KeycloakTransactionSpec{
 				XaEnabled: true,
 			},
+			Scheduling: &v2alpha1.KeycloakSchedulingSpec{
+				Affinity:    site.Spec.Keycloak.Affinity,
+				Tolerations: site.Spec.Keycloak.Tolerations,
+				// the keycloak operator labels its pods with the name of the Keycloak
+				TopologySpreadConstraints: site.Spec.Keycloak.GetTopologySpreadConstraints(map[string]string{
+					"app":                              "keycloak",
+					v1beta1.KubernetesInstanceLabelKey: localKeycloak.ComponentName(),
+				}),
+				PriorityClassName: site.Spec.Keycloak.PriorityClassName,
+			},
 			Unsupported: &v2alpha1.KeycloakUnsupportedSpec{
 				PodTemplate: &v1.PodTemplateSpec{
+					ObjectMeta: v12.ObjectMeta{
+						Labels:      site.Spec.Keycloak.GetPodLabels(nil),
+						Annotations: site.Spec.Keycloak.GetPodAnnotations(nil),
+					},
 					Spec: v1.PodSpec{
 						ServiceAccountName: localKeycloak.ComponentName(),
+						NodeSelector:       site.Spec.Keycloak.NodeSelector,
 						Containers: []v1.Container{
 							{
 								Env: []v1.EnvVar{
@@ -233,6 +267,22 @@ func (r *SiteReconciler) reconcileKeycloak(ctx context.Context, req controllerru
 			},
 		}
 
+		if keycloakPath != "" {
+			// a full URL lets keycloak generate links that include the path
+			keycloakSpec.Hostname.Hostname = site.ProductUrl(keycloakDomainPrefix, site.Spec.Keycloak.Hostnames)
+			keycloakSpec.AdditionalOptions = []v2alpha1.KeycloakAdditionalOption{
+				{Name: "http-relative-path", Value: keycloakPath},
+			}
+		}
+
+		if site.Spec.SecurityProfile.Enabled() {
+			// the keycloak image runs as this user; the keycloak operator merges the hardened
+			// security contexts into the pods that it creates
+			podSpec := &keycloakSpec.Unsupported.PodTemplate.Spec
+			podSpec.SecurityContext = &v1.PodSecurityContext{RunAsUser: ptr.To(int64(1000))}
+			site.Spec.SecurityProfile.HardenPod(podSpec)
+		}
+
 		// Set custom image if specified
 		if site.Spec.Keycloak.Image != "" {
 			keycloakSpec.Image = site.Spec.Keycloak.Image
@@ -254,6 +304,32 @@ func (r *SiteReconciler) reconcileKeycloak(ctx context.Context, req controllerru
 			l.Error(err, "error converging keycloak")
 			return err
 		}
+
+		// when the keycloak operator's ingress is disabled, we route to its service instead
+		if useGateway {
+			routeOptions := ingressOptions.HTTPRouteOptions(keycloakHosts, localKeycloak.ServiceName(), 8080)
+			if err := internal.DeployHTTPRoute(ctx, req, r.Client, r.Scheme, l, localKeycloak.ComponentName(), site, site.Spec.Routing, routeOptions); err != nil {
+				l.Error(err, "error deploying keycloak HTTPRoute")
+				return err
+			}
+			if err := internal.CleanupTraefikResources(ctx, r.Client, l, req.Namespace, localKeycloak.ComponentName()); err != nil {
+				l.Error(err, "error cleaning up keycloak middlewares")
+				return err
+			}
+		} else if err := internal.CleanupHTTPRoute(ctx, r, l, keycloakKey); err != nil {
+			l.Error(err, "error cleaning up keycloak HTTPRoute")
+			return err
+		}
+
+		if !useGateway && !useOperatorIngress {
+			if err := r.reconcileKeycloakIngress(ctx, req, site, localKeycloak, keycloakHosts, ingressProvider, ingressOptions); err != nil {
+				l.Error(err, "error deploying keycloak ingress")
+				return err
+			}
+		} else if err := internal.BasicDelete(ctx, r, l, keycloakKey, &networkingv1.Ingress{}); err != nil {
+			l.Error(err, "error cleaning up keycloak ingress")
+			return err
+		}
 	} else {
 
 		// delete the object if it exists
@@ -263,6 +339,58 @@ func (r *SiteReconciler) reconcileKeycloak(ctx context.Context, req controllerru
 			l.Error(err, "error deleting keycloak instance")
 			// do not exit... because we will try again later...
 		}
+
+		if err := internal.CleanupHTTPRoute(ctx, r, l, keycloakKey); err != nil {
+			l.Error(err, "error cleaning up keycloak HTTPRoute")
+		}
+
+		if err := internal.BasicDelete(ctx, r, l, keycloakKey, &networkingv1.Ingress{}); err != nil {
+			l.Error(err, "error cleaning up keycloak ingress")
+		}
 	}
 	return nil
 }
+
+// reconcileKeycloakIngress exposes the keycloak operator's Service when the operator's own Ingress cannot be
+// used (i.e. when keycloak is served under a path or on several hosts)
+func (r *SiteReconciler) reconcileKeycloakIngress(ctx context.Context, req controllerruntime.Request, site *v1beta1.Site, localKeycloak *v1beta1.Keycloak, hosts []string, provider internal.IngressProvider, opts internal.IngressOptions) error {
+	l := r.GetLogger(ctx).WithValues(
+		"event", "reconcile-keycloak-ingress",
+	)
+
+	annotations := internal.MergeIngressAnnotations(
+		product.LabelMerge(
+			provider.IngressAnnotations(req.Namespace, localKeycloak.ComponentName(), opts),
+			internal.ExternalDNSAnnotations(site.Spec.Routing.GetExternalDNS(), hosts),
+		),
+		site.Spec.IngressAnnotations,
+	)
+	ingressPath, ingressPathType := provider.IngressPath(opts)
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: v12.ObjectMeta{
+			Name:      localKeycloak.ComponentName(),
+			Namespace: req.Namespace,
+		},
+	}
+	_, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, ingress, site, func() error {
+		ingress.Labels = site.KubernetesLabels()
+		ingress.Annotations = annotations
+		ingress.Spec = networkingv1.IngressSpec{
+			TLS: internal.IngressTLS(hosts, site.Spec.Keycloak.TLSSecretName),
+			Rules: internal.IngressRules(hosts, ingressPath, ingressPathType, networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: localKeycloak.ServiceName(),
+					Port: networkingv1.ServiceBackendPort{
+						Number: 8080,
+					},
+				},
+			}),
+		}
+		if site.Spec.IngressClass != "" {
+			ingress.Spec.IngressClassName = &site.Spec.IngressClass
+		}
+		return nil
+	})
+	return err
+}
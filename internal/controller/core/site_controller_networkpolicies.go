@@ -2,13 +2,18 @@ package core
 
 import (
 	"context"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	calicov3 "github.com/posit-dev/team-operator/api/calico/v3"
+	ciliumv2 "github.com/posit-dev/team-operator/api/cilium/v2"
 	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
 	"github.com/posit-dev/team-operator/internal"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -23,21 +28,56 @@ const (
 	grafanaAlloyNamespace = "alloy"
 )
 
+// networkPolicyComponents are the suffixes of the NetworkPolicy names of a Site
+var networkPolicyComponents = []string{
+	"chronicle",
+	"connect",
+	"connect-session",
+	"default-deny",
+	"home",
+	"keycloak",
+	"packagemanager",
+	"workbench",
+	"workbench-session",
+}
+
 func (r *SiteReconciler) reconcileNetworkPolicies(ctx context.Context, req ctrl.Request, site *v1beta1.Site) error {
 	l := r.GetLogger(ctx).WithValues("event", "reconcile-networkpolicies")
 
-	l = l.WithValues("network_trust", site.Spec.NetworkTrust)
+	l = l.WithValues("network_trust", site.Spec.GetNetworkTrust())
+
+	level := site.Spec.GetNetworkTrust().Level()
+	site.Status.NetworkPolicy = &v1beta1.NetworkPolicyStatus{
+		Level: site.Spec.GetNetworkTrust().LevelName(),
+	}
 
-	if site.Spec.NetworkTrust > v1beta1.NetworkTrustSameSite {
+	if level == v1beta1.NetworkTrustFull {
 		l.Info("network trust does not require policies")
 
 		if err := r.cleanupNetworkPolicies(ctx, req); err != nil {
 			l.Error(err, "failed to clean up policies")
 		}
+		if sessionNamespace := site.Spec.Workbench.SessionNamespace; sessionNamespace != "" {
+			sessionReq := req
+			sessionReq.Namespace = sessionNamespace
+			if err := r.sessionNamespaceReconciler(l).cleanupNetworkPolicies(ctx, sessionReq); err != nil {
+				l.Error(err, "failed to clean up policies of the session namespace")
+			}
+		}
 
 		return nil
 	}
 
+	if level == v1beta1.NetworkTrustZero {
+		if err := r.reconcileDefaultDenyNetworkPolicy(ctx, req.Namespace, l, site); err != nil {
+			l.Error(err, "error ensuring default deny network policy")
+			return err
+		}
+	} else if err := r.deleteComponentNetworkPolicy(ctx, req.Namespace, l, site, site.Name+"-default-deny"); err != nil {
+		l.Error(err, "error cleaning up default deny network policy")
+		return err
+	}
+
 	if err := r.reconcileChronicleNetworkPolicy(ctx, req.Namespace, l, site); err != nil {
 		l.Error(err, "error ensuring chronicle network policy")
 		return err
@@ -73,11 +113,21 @@ func (r *SiteReconciler) reconcileNetworkPolicies(ctx context.Context, req ctrl.
 		return err
 	}
 
-	if err := r.reconcileWorkbenchSessionNetworkPolicy(ctx, req.Namespace, l, site); err != nil {
+	if site.Spec.Workbench.SessionNamespace != "" {
+		if err := r.reconcileSessionNamespaceNetworkPolicies(ctx, req, l, site); err != nil {
+			l.Error(err, "error ensuring workbench session network policies in the session namespace")
+			return err
+		}
+	} else if err := r.reconcileWorkbenchSessionNetworkPolicy(ctx, req.Namespace, l, site); err != nil {
 		l.Error(err, "error ensuring workbench session network policy")
 		return err
 	}
 
+	if err := r.cleanupNetworkPolicyBackends(ctx, req, site.Spec.NetworkPolicy.GetBackend()); err != nil {
+		l.Error(err, "error cleaning up network policies of other backends")
+		return err
+	}
+
 	return nil
 }
 
@@ -86,16 +136,7 @@ func (r *SiteReconciler) cleanupNetworkPolicies(ctx context.Context, req ctrl.Re
 
 	var cleanupErr error
 
-	for _, component := range []string{
-		"chronicle",
-		"connect",
-		"connect-session",
-		"home",
-		"keycloak",
-		"packagemanager",
-		"workbench",
-		"workbench-session",
-	} {
+	for _, component := range networkPolicyComponents {
 		key := client.ObjectKey{
 			Name:      req.Name + "-" + component,
 			Namespace: req.Namespace,
@@ -112,538 +153,707 @@ func (r *SiteReconciler) cleanupNetworkPolicies(ctx context.Context, req ctrl.Re
 		}
 	}
 
+	if err := r.cleanupNetworkPolicyBackends(ctx, req, v1beta1.NetworkPolicyBackendKubernetes); err != nil {
+		l.Error(err, "error cleaning up network policies of other backends")
+
+		if cleanupErr == nil {
+			cleanupErr = errors.Wrapf(errNetworkPolicyCleanup, "%v", err)
+		} else {
+			cleanupErr = errors.Wrapf(cleanupErr, "%v", err)
+		}
+	}
+
 	return cleanupErr
 }
 
-func (r *SiteReconciler) reconcileChronicleNetworkPolicy(ctx context.Context, namespace string, l logr.Logger, site *v1beta1.Site) error {
-	policyName := site.Name + "-chronicle"
+// cleanupNetworkPolicyBackends removes the policies rendered for every backend other than the given one. Clusters
+// without a backend's CRDs installed have nothing to clean up, so a missing kind is not an error.
+func (r *SiteReconciler) cleanupNetworkPolicyBackends(ctx context.Context, req ctrl.Request, backend v1beta1.NetworkPolicyBackend) error {
+	l := r.GetLogger(ctx).WithValues("event", "cleanup-networkpolicy-backends")
+
+	backendObjects := map[v1beta1.NetworkPolicyBackend][]func() client.Object{
+		v1beta1.NetworkPolicyBackendCilium: {
+			func() client.Object { return &ciliumv2.CiliumNetworkPolicy{} },
+		},
+		v1beta1.NetworkPolicyBackendCalico: {
+			func() client.Object { return &calicov3.NetworkPolicy{} },
+			func() client.Object { return &calicov3.NetworkSet{} },
+		},
+	}
 
-	policy := &networkingv1.NetworkPolicy{
+	for b, objects := range backendObjects {
+		if b == backend {
+			continue
+		}
+		for _, newObj := range objects {
+			for _, component := range networkPolicyComponents {
+				key := client.ObjectKey{
+					Name:      req.Name + "-" + component,
+					Namespace: req.Namespace,
+				}
+				if err := internal.BasicDelete(ctx, r, l, key, newObj()); meta.IsNoMatchError(err) {
+					// the CRD is not installed, so there is nothing left for the other components either
+					break
+				} else if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// reconcileComponentNetworkPolicy creates a component's NetworkPolicy and the policies of the configured backend.
+// When the backend supports domains and the component has an allow-list, its internet egress is limited to those
+// domains.
+func (r *SiteReconciler) reconcileComponentNetworkPolicy(ctx context.Context, namespace string, l logr.Logger, site *v1beta1.Site, policyName string, spec networkingv1.NetworkPolicySpec, fqdns []string) error {
+	config := site.Spec.NetworkPolicy
+	backend := config.GetBackend()
+	if backend == v1beta1.NetworkPolicyBackendKubernetes {
+		fqdns = nil
+	}
+	spec = sessionNamespacePeers(site, spec)
+	if len(fqdns) > 0 {
+		spec.Egress = internal.WithoutInternetEgress(spec.Egress, config.GetPrivateCIDRs())
+	}
+
+	key := client.ObjectKey{
+		Name:      policyName,
+		Namespace: namespace,
+	}
+
+	if config.UseKubernetesPolicies() {
+		policy := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      policyName,
+				Namespace: namespace,
+			},
+		}
+		if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, policy, networkPolicyOwner(site, namespace), func() error {
+			policy.Labels = site.KubernetesLabels()
+			policy.Spec = spec
+			return nil
+		}); err != nil {
+			return err
+		}
+	} else if err := internal.BasicDelete(ctx, r, l, key, &networkingv1.NetworkPolicy{}); err != nil {
+		return err
+	}
+
+	if site.Status.NetworkPolicy != nil {
+		site.Status.NetworkPolicy.Rules = append(site.Status.NetworkPolicy.Rules, internal.DescribeNetworkPolicy(policyName, spec)...)
+		if len(fqdns) > 0 {
+			site.Status.NetworkPolicy.Rules = append(site.Status.NetworkPolicy.Rules, policyName+": allow egress to domains "+strings.Join(fqdns, ", "))
+		}
+	}
+
+	full := !config.UseKubernetesPolicies()
+	switch backend {
+	case v1beta1.NetworkPolicyBackendCilium:
+		return r.reconcileCiliumNetworkPolicy(ctx, key, l, site, internal.CiliumNetworkPolicyRule(spec, fqdns, full))
+	case v1beta1.NetworkPolicyBackendCalico:
+		networkSet := ""
+		if len(fqdns) > 0 {
+			networkSet = policyName
+		}
+		return r.reconcileCalicoNetworkPolicy(ctx, key, l, site, internal.CalicoNetworkPolicySpec(spec, networkSet, full), fqdns)
+	}
+	return nil
+}
+
+// deleteComponentNetworkPolicy removes a NetworkPolicy and the policies of the configured backend
+func (r *SiteReconciler) deleteComponentNetworkPolicy(ctx context.Context, namespace string, l logr.Logger, site *v1beta1.Site, policyName string) error {
+	key := client.ObjectKey{
+		Name:      policyName,
+		Namespace: namespace,
+	}
+
+	if err := internal.BasicDelete(ctx, r, l, key, &networkingv1.NetworkPolicy{}); err != nil {
+		return err
+	}
+	switch site.Spec.NetworkPolicy.GetBackend() {
+	case v1beta1.NetworkPolicyBackendCilium:
+		return r.reconcileCiliumNetworkPolicy(ctx, key, l, site, nil)
+	case v1beta1.NetworkPolicyBackendCalico:
+		return r.reconcileCalicoNetworkPolicy(ctx, key, l, site, nil, nil)
+	}
+	return nil
+}
+
+func (r *SiteReconciler) reconcileCiliumNetworkPolicy(ctx context.Context, key client.ObjectKey, l logr.Logger, site *v1beta1.Site, rule *ciliumv2.Rule) error {
+	if rule == nil {
+		return internal.BasicDelete(ctx, r, l, key, &ciliumv2.CiliumNetworkPolicy{})
+	}
+
+	policy := &ciliumv2.CiliumNetworkPolicy{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      policyName,
-			Namespace: namespace,
+			Name:      key.Name,
+			Namespace: key.Namespace,
 		},
 	}
-	_, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, policy, site, func() error {
+	_, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, policy, networkPolicyOwner(site, key.Namespace), func() error {
 		policy.Labels = site.KubernetesLabels()
-		policy.Spec = networkingv1.NetworkPolicySpec{
-			PodSelector: metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					v1beta1.SiteLabelKey:               site.Name,
-					v1beta1.KubernetesInstanceLabelKey: policyName,
-				},
-			},
-			PolicyTypes: []networkingv1.PolicyType{
-				networkingv1.PolicyTypeEgress,
-				networkingv1.PolicyTypeIngress,
+		policy.Spec = rule
+		return nil
+	})
+	return err
+}
+
+func (r *SiteReconciler) reconcileCalicoNetworkPolicy(ctx context.Context, key client.ObjectKey, l logr.Logger, site *v1beta1.Site, spec *calicov3.NetworkPolicySpec, fqdns []string) error {
+	if spec == nil {
+		if err := internal.BasicDelete(ctx, r, l, key, &calicov3.NetworkPolicy{}); err != nil {
+			return err
+		}
+	} else {
+		policy := &calicov3.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
 			},
-			Egress: []networkingv1.NetworkPolicyEgressRule{
-				{},
+		}
+		if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, policy, networkPolicyOwner(site, key.Namespace), func() error {
+			policy.Labels = site.KubernetesLabels()
+			policy.Spec = *spec
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	if len(fqdns) == 0 {
+		return internal.BasicDelete(ctx, r, l, key, &calicov3.NetworkSet{})
+	}
+
+	networkSet := &calicov3.NetworkSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+	}
+	_, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, networkSet, networkPolicyOwner(site, key.Namespace), func() error {
+		networkSet.Labels = product.LabelMerge(site.KubernetesLabels(), map[string]string{
+			internal.CalicoNetworkSetLabelKey: key.Name,
+		})
+		networkSet.Spec = calicov3.NetworkSetSpec{
+			AllowedEgressDomains: fqdns,
+		}
+		return nil
+	})
+	return err
+}
+
+func (r *SiteReconciler) reconcileChronicleNetworkPolicy(ctx context.Context, namespace string, l logr.Logger, site *v1beta1.Site) error {
+	policyName := site.Name + "-chronicle"
+
+	spec := networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				v1beta1.SiteLabelKey:               site.Name,
+				v1beta1.KubernetesInstanceLabelKey: policyName,
 			},
-			Ingress: []networkingv1.NetworkPolicyIngressRule{
-				{
-					From: []networkingv1.NetworkPolicyPeer{
-						{
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.SiteLabelKey: site.Name,
-								},
+		},
+		PolicyTypes: []networkingv1.PolicyType{
+			networkingv1.PolicyTypeEgress,
+			networkingv1.PolicyTypeIngress,
+		},
+		Egress: []networkingv1.NetworkPolicyEgressRule{
+			{},
+		},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				From: []networkingv1.NetworkPolicyPeer{
+					{
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.SiteLabelKey: site.Name,
 							},
 						},
-						{
-							NamespaceSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
-								},
+					},
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
 							},
 						},
 					},
-					Ports: []networkingv1.NetworkPolicyPort{
-						internal.DefaultPortChronicleHTTP.NetworkPolicyPort(),
-						internal.DefaultPortChronicleMetrics.NetworkPolicyPort(),
-					},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					internal.DefaultPortChronicleHTTP.NetworkPolicyPort(),
+					internal.DefaultPortChronicleMetrics.NetworkPolicyPort(),
 				},
 			},
-		}
-		return nil
-	})
-	return err
+		},
+	}
+	if site.Spec.GetNetworkTrust().Level() == v1beta1.NetworkTrustZero {
+		spec.Egress, spec.Ingress = zeroTrustChronicleRules(site)
+	}
+	spec.Egress = append(spec.Egress, site.Spec.NetworkPolicy.GetExtraEgress().Chronicle...)
+	spec.Ingress = append(spec.Ingress, site.Spec.NetworkPolicy.GetExtraIngress().Chronicle...)
+
+	return r.reconcileComponentNetworkPolicy(ctx, namespace, l, site, policyName, spec, site.Spec.NetworkPolicy.GetEgressFQDNs().Chronicle)
 }
 
 func (r *SiteReconciler) reconcileConnectNetworkPolicy(ctx context.Context, namespace string, l logr.Logger, site *v1beta1.Site) error {
 	policyName := site.Name + "-connect"
 
-	policy := &networkingv1.NetworkPolicy{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      policyName,
-			Namespace: namespace,
+	spec := networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				v1beta1.SiteLabelKey:               site.Name,
+				v1beta1.KubernetesInstanceLabelKey: policyName,
+			},
 		},
-	}
-	_, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, policy, site, func() error {
-		policy.Labels = site.KubernetesLabels()
-		policy.Spec = networkingv1.NetworkPolicySpec{
-			PodSelector: metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					v1beta1.SiteLabelKey:               site.Name,
-					v1beta1.KubernetesInstanceLabelKey: policyName,
+		PolicyTypes: []networkingv1.PolicyType{
+			networkingv1.PolicyTypeEgress,
+			networkingv1.PolicyTypeIngress,
+		},
+		Egress: []networkingv1.NetworkPolicyEgressRule{
+			{
+				To: internal.IPBlockNetworkPolicyPeers(site.Spec.NetworkPolicy.GetInfrastructureCIDRs()),
+				Ports: []networkingv1.NetworkPolicyPort{
+					internal.DefaultPortHTTPS.NetworkPolicyPort(),
+					internal.DefaultPortPostgres.NetworkPolicyPort(),
 				},
 			},
-			PolicyTypes: []networkingv1.PolicyType{
-				networkingv1.PolicyTypeEgress,
-				networkingv1.PolicyTypeIngress,
-			},
-			Egress: []networkingv1.NetworkPolicyEgressRule{
-				{
-					To: []networkingv1.NetworkPolicyPeer{
-						{
-							IPBlock: &networkingv1.IPBlock{
-								// TODO: look up CIDR block that contains all private addresses
-								CIDR: "10.0.0.0/8",
+			internal.PublicInternetNetworkPolicyEgressRule(site.Spec.NetworkPolicy.GetPrivateCIDRs(), site.Spec.NetworkPolicy.GetPodCIDRs()),
+		},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				From: []networkingv1.NetworkPolicyPeer{
+					{
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.SiteLabelKey:      site.Name,
+								v1beta1.ComponentLabelKey: "workbench",
 							},
 						},
 					},
-					Ports: []networkingv1.NetworkPolicyPort{
-						internal.DefaultPortHTTPS.NetworkPolicyPort(),
-						internal.DefaultPortPostgres.NetworkPolicyPort(),
-					},
-				},
-				internal.PublicInternetNetworkPolicyEgressRule(),
-			},
-			Ingress: []networkingv1.NetworkPolicyIngressRule{
-				{
-					From: []networkingv1.NetworkPolicyPeer{
-						{
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.SiteLabelKey:      site.Name,
-									v1beta1.ComponentLabelKey: "workbench",
-								},
-							},
-						},
-						{
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.SiteLabelKey:      site.Name,
-									v1beta1.ComponentLabelKey: "connect",
-								},
+					{
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.SiteLabelKey:      site.Name,
+								v1beta1.ComponentLabelKey: "connect",
 							},
 						},
-						{
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.SiteLabelKey:          site.Name,
-									v1beta1.LauncherInstanceIDKey: site.Name + "-workbench",
-								},
+					},
+					{
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.SiteLabelKey:          site.Name,
+								v1beta1.LauncherInstanceIDKey: site.Name + "-workbench",
 							},
 						},
-						{
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.SiteLabelKey:          site.Name,
-									v1beta1.LauncherInstanceIDKey: site.Name + "-connect",
-								},
+					},
+					{
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.SiteLabelKey:          site.Name,
+								v1beta1.LauncherInstanceIDKey: site.Name + "-connect",
 							},
 						},
-						{
-							NamespaceSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.KubernetesMetadataNameKey: "traefik",
-								},
+					},
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.KubernetesMetadataNameKey: "traefik",
 							},
 						},
-						{
-							NamespaceSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
-								},
+					},
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
 							},
 						},
 					},
-					Ports: []networkingv1.NetworkPolicyPort{
-						internal.DefaultPortConnectHTTP.NetworkPolicyPort(),
-						internal.DefaultPortConnectMetrics.NetworkPolicyPort(),
-						internal.DefaultPortLauncher.NetworkPolicyPort(),
-					},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					internal.DefaultPortConnectHTTP.NetworkPolicyPort(),
+					internal.DefaultPortConnectMetrics.NetworkPolicyPort(),
+					internal.DefaultPortLauncher.NetworkPolicyPort(),
 				},
 			},
-		}
-		return nil
-	})
-	return err
+		},
+	}
+	if site.Spec.GetNetworkTrust().Level() == v1beta1.NetworkTrustZero {
+		spec.Egress, spec.Ingress = zeroTrustConnectRules(site)
+	}
+	spec.Egress = append(spec.Egress, site.Spec.NetworkPolicy.GetExtraEgress().Connect...)
+	spec.Ingress = append(spec.Ingress, site.Spec.NetworkPolicy.GetExtraIngress().Connect...)
+
+	return r.reconcileComponentNetworkPolicy(ctx, namespace, l, site, policyName, spec, site.Spec.NetworkPolicy.GetEgressFQDNs().Connect)
 }
 
 func (r *SiteReconciler) reconcileConnectSessionNetworkPolicy(ctx context.Context, namespace string, l logr.Logger, site *v1beta1.Site) error {
 	policyName := site.Name + "-connect-session"
 
-	policy := &networkingv1.NetworkPolicy{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      policyName,
-			Namespace: namespace,
-		},
-	}
-	_, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, policy, site, func() error {
-		policy.Labels = site.KubernetesLabels()
-		policy.Spec = networkingv1.NetworkPolicySpec{
-			PodSelector: metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					v1beta1.SiteLabelKey:      site.Name,
-					v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueConnectSession,
-				},
-			},
-			PolicyTypes: []networkingv1.PolicyType{
-				networkingv1.PolicyTypeEgress,
-				networkingv1.PolicyTypeIngress,
+	spec := networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				v1beta1.SiteLabelKey:      site.Name,
+				v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueConnectSession,
 			},
-			Egress: []networkingv1.NetworkPolicyEgressRule{
-				// allow only outbound internet access
-				internal.PublicInternetOnlyNetworkPolicyEgressRule(),
-				{
-					To: []networkingv1.NetworkPolicyPeer{
-						{
-							// egress to parent Connect
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.SiteLabelKey:      site.Name,
-									v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueConnect,
-								},
+		},
+		PolicyTypes: []networkingv1.PolicyType{
+			networkingv1.PolicyTypeEgress,
+			networkingv1.PolicyTypeIngress,
+		},
+		Egress: []networkingv1.NetworkPolicyEgressRule{
+			// allow only outbound internet access
+			internal.PublicInternetOnlyNetworkPolicyEgressRule(site.Spec.NetworkPolicy.GetPrivateCIDRs()),
+			{
+				To: []networkingv1.NetworkPolicyPeer{
+					{
+						// egress to parent Connect
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.SiteLabelKey:      site.Name,
+								v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueConnect,
 							},
 						},
 					},
 				},
 			},
-			Ingress: []networkingv1.NetworkPolicyIngressRule{
-				{
-					From: []networkingv1.NetworkPolicyPeer{
-						{
-							// ingress from parent Connect
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.SiteLabelKey:      site.Name,
-									v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueConnect,
-								},
+		},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				From: []networkingv1.NetworkPolicyPeer{
+					{
+						// ingress from parent Connect
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.SiteLabelKey:      site.Name,
+								v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueConnect,
 							},
 						},
-						{
-							// ingress from grafana agent
-							NamespaceSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
-								},
+					},
+					{
+						// ingress from grafana agent
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
 							},
 						},
 					},
-					Ports: []networkingv1.NetworkPolicyPort{
-						internal.DefaultPortConnectHTTP.NetworkPolicyPort(),
-						internal.DefaultPortConnectSession.NetworkPolicyPort(),
-					},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					internal.DefaultPortConnectHTTP.NetworkPolicyPort(),
+					internal.DefaultPortConnectSession.NetworkPolicyPort(),
 				},
 			},
-		}
-		return nil
-	})
-	return err
+		},
+	}
+	if site.Spec.GetNetworkTrust().Level() == v1beta1.NetworkTrustZero {
+		spec.Egress, spec.Ingress = zeroTrustConnectSessionRules(site)
+	}
+	spec.Egress = append(spec.Egress, site.Spec.NetworkPolicy.GetExtraEgress().ConnectSession...)
+	spec.Ingress = append(spec.Ingress, site.Spec.NetworkPolicy.GetExtraIngress().ConnectSession...)
+
+	return r.reconcileComponentNetworkPolicy(ctx, namespace, l, site, policyName, spec, site.Spec.NetworkPolicy.GetEgressFQDNs().ConnectSession)
 }
 
 func (r *SiteReconciler) reconcileHomeNetworkPolicy(ctx context.Context, namespace string, l logr.Logger, site *v1beta1.Site) error {
 	policyName := site.Name + "-home"
 
-	policy := &networkingv1.NetworkPolicy{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      policyName,
-			Namespace: namespace,
-		},
-	}
-	_, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, policy, site, func() error {
-		policy.Labels = site.KubernetesLabels()
-		policy.Spec = networkingv1.NetworkPolicySpec{
-			PodSelector: metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					v1beta1.SiteLabelKey:               site.Name,
-					v1beta1.KubernetesInstanceLabelKey: policyName,
-				},
-			},
-			PolicyTypes: []networkingv1.PolicyType{
-				networkingv1.PolicyTypeEgress,
-				networkingv1.PolicyTypeIngress,
-			},
-			Egress: []networkingv1.NetworkPolicyEgressRule{
-				{},
+	spec := networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				v1beta1.SiteLabelKey:               site.Name,
+				v1beta1.KubernetesInstanceLabelKey: policyName,
 			},
-			Ingress: []networkingv1.NetworkPolicyIngressRule{
-				{
-					From: []networkingv1.NetworkPolicyPeer{
-						{
-							NamespaceSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.KubernetesMetadataNameKey: "traefik",
-								},
+		},
+		PolicyTypes: []networkingv1.PolicyType{
+			networkingv1.PolicyTypeEgress,
+			networkingv1.PolicyTypeIngress,
+		},
+		Egress: []networkingv1.NetworkPolicyEgressRule{
+			{},
+		},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				From: []networkingv1.NetworkPolicyPeer{
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.KubernetesMetadataNameKey: "traefik",
 							},
 						},
-						{
-							NamespaceSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
-								},
+					},
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
 							},
 						},
 					},
-					Ports: []networkingv1.NetworkPolicyPort{
-						internal.DefaultPortHomeHTTP.NetworkPolicyPort(),
-					},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					internal.DefaultPortHomeHTTP.NetworkPolicyPort(),
 				},
 			},
-		}
-		return nil
-	})
-	return err
+		},
+	}
+	if site.Spec.GetNetworkTrust().Level() == v1beta1.NetworkTrustZero {
+		spec.Egress, spec.Ingress = zeroTrustHomeRules(site)
+	}
+
+	return r.reconcileComponentNetworkPolicy(ctx, namespace, l, site, policyName, spec, nil)
 }
 
 func (r *SiteReconciler) reconcileKeycloakNetworkPolicy(ctx context.Context, namespace string, l logr.Logger, site *v1beta1.Site) error {
 	policyName := site.Name + "-keycloak"
 
-	policy := &networkingv1.NetworkPolicy{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      policyName,
-			Namespace: namespace,
+	spec := networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app":                              "keycloak",
+				v1beta1.KubernetesInstanceLabelKey: site.Name,
+			},
 		},
-	}
-	_, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, policy, site, func() error {
-		policy.Labels = site.KubernetesLabels()
-		policy.Spec = networkingv1.NetworkPolicySpec{
-			PodSelector: metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"app":                              "keycloak",
-					v1beta1.KubernetesInstanceLabelKey: site.Name,
+		PolicyTypes: []networkingv1.PolicyType{
+			networkingv1.PolicyTypeEgress,
+			networkingv1.PolicyTypeIngress,
+		},
+		Egress: []networkingv1.NetworkPolicyEgressRule{
+			{
+				To: internal.IPBlockNetworkPolicyPeers(site.Spec.NetworkPolicy.GetInfrastructureCIDRs()),
+				Ports: []networkingv1.NetworkPolicyPort{
+					internal.DefaultPortPostgres.NetworkPolicyPort(),
 				},
 			},
-			PolicyTypes: []networkingv1.PolicyType{
-				networkingv1.PolicyTypeEgress,
-				networkingv1.PolicyTypeIngress,
-			},
-			Egress: []networkingv1.NetworkPolicyEgressRule{
-				{
-					To: []networkingv1.NetworkPolicyPeer{
-						{
-							IPBlock: &networkingv1.IPBlock{
-								// TODO: look up CIDR block that contains all private addresses
-								CIDR: "10.0.0.0/8",
+		},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				From: []networkingv1.NetworkPolicyPeer{
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.KubernetesMetadataNameKey: "traefik",
 							},
 						},
 					},
-					Ports: []networkingv1.NetworkPolicyPort{
-						internal.DefaultPortPostgres.NetworkPolicyPort(),
-					},
-				},
-			},
-			Ingress: []networkingv1.NetworkPolicyIngressRule{
-				{
-					From: []networkingv1.NetworkPolicyPeer{
-						{
-							NamespaceSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.KubernetesMetadataNameKey: "traefik",
-								},
-							},
-						},
-						{
-							NamespaceSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
-								},
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
 							},
 						},
 					},
-					Ports: []networkingv1.NetworkPolicyPort{
-						internal.DefaultPortKeycloakHTTP.NetworkPolicyPort(),
-						internal.DefaultPortKeycloakHTTPS.NetworkPolicyPort(),
-					},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					internal.DefaultPortKeycloakHTTP.NetworkPolicyPort(),
+					internal.DefaultPortKeycloakHTTPS.NetworkPolicyPort(),
 				},
 			},
-		}
-		return nil
-	})
-	return err
+		},
+	}
+	if site.Spec.GetNetworkTrust().Level() == v1beta1.NetworkTrustZero {
+		spec.Egress, spec.Ingress = zeroTrustKeycloakRules(site)
+	}
+	spec.Egress = append(spec.Egress, site.Spec.NetworkPolicy.GetExtraEgress().Keycloak...)
+	spec.Ingress = append(spec.Ingress, site.Spec.NetworkPolicy.GetExtraIngress().Keycloak...)
+
+	return r.reconcileComponentNetworkPolicy(ctx, namespace, l, site, policyName, spec, site.Spec.NetworkPolicy.GetEgressFQDNs().Keycloak)
 }
 
 func (r *SiteReconciler) reconcilePackageManagerNetworkPolicy(ctx context.Context, namespace string, l logr.Logger, site *v1beta1.Site) error {
 	policyName := site.Name + "-packagemanager"
 
-	policy := &networkingv1.NetworkPolicy{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      policyName,
-			Namespace: namespace,
-		},
-	}
-	_, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, policy, site, func() error {
-		policy.Labels = site.KubernetesLabels()
-		policy.Spec = networkingv1.NetworkPolicySpec{
-			PodSelector: metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					v1beta1.SiteLabelKey:               site.Name,
-					v1beta1.KubernetesInstanceLabelKey: policyName,
-				},
-			},
-			PolicyTypes: []networkingv1.PolicyType{
-				networkingv1.PolicyTypeEgress,
-				networkingv1.PolicyTypeIngress,
-			},
-			Egress: []networkingv1.NetworkPolicyEgressRule{
-				{},
+	spec := networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				v1beta1.SiteLabelKey:               site.Name,
+				v1beta1.KubernetesInstanceLabelKey: policyName,
 			},
-			Ingress: []networkingv1.NetworkPolicyIngressRule{
-				{
-					From: []networkingv1.NetworkPolicyPeer{
-						{
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.SiteLabelKey:               site.Name,
-									v1beta1.KubernetesInstanceLabelKey: site.Name + "-workbench",
-								},
+		},
+		PolicyTypes: []networkingv1.PolicyType{
+			networkingv1.PolicyTypeEgress,
+			networkingv1.PolicyTypeIngress,
+		},
+		Egress: []networkingv1.NetworkPolicyEgressRule{
+			{},
+		},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				From: []networkingv1.NetworkPolicyPeer{
+					{
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.SiteLabelKey:               site.Name,
+								v1beta1.KubernetesInstanceLabelKey: site.Name + "-workbench",
 							},
 						},
-						{
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.SiteLabelKey:          site.Name,
-									v1beta1.LauncherInstanceIDKey: site.Name + "-workbench",
-								},
+					},
+					{
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.SiteLabelKey:          site.Name,
+								v1beta1.LauncherInstanceIDKey: site.Name + "-workbench",
 							},
 						},
-						{
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.SiteLabelKey:               site.Name,
-									v1beta1.KubernetesInstanceLabelKey: site.Name + "-connect",
-								},
+					},
+					{
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.SiteLabelKey:               site.Name,
+								v1beta1.KubernetesInstanceLabelKey: site.Name + "-connect",
 							},
 						},
-						{
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.SiteLabelKey:               site.Name,
-									v1beta1.KubernetesInstanceLabelKey: site.Name + "-connect",
-								},
+					},
+					{
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.SiteLabelKey:               site.Name,
+								v1beta1.KubernetesInstanceLabelKey: site.Name + "-connect",
 							},
 						},
-						{
-							NamespaceSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.KubernetesMetadataNameKey: "traefik",
-								},
+					},
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.KubernetesMetadataNameKey: "traefik",
 							},
 						},
-						{
-							NamespaceSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
-								},
+					},
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
 							},
 						},
 					},
-					Ports: []networkingv1.NetworkPolicyPort{
-						internal.DefaultPortPackageManagerHTTP.NetworkPolicyPort(),
-						internal.DefaultPortPackageManagerMetrics.NetworkPolicyPort(),
-					},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					internal.DefaultPortPackageManagerHTTP.NetworkPolicyPort(),
+					internal.DefaultPortPackageManagerMetrics.NetworkPolicyPort(),
 				},
 			},
-		}
-		return nil
-	})
-	return err
+		},
+	}
+	if site.Spec.GetNetworkTrust().Level() == v1beta1.NetworkTrustZero {
+		spec.Egress, spec.Ingress = zeroTrustPackageManagerRules(site)
+	}
+	spec.Egress = append(spec.Egress, site.Spec.NetworkPolicy.GetExtraEgress().PackageManager...)
+	spec.Ingress = append(spec.Ingress, site.Spec.NetworkPolicy.GetExtraIngress().PackageManager...)
+
+	return r.reconcileComponentNetworkPolicy(ctx, namespace, l, site, policyName, spec, site.Spec.NetworkPolicy.GetEgressFQDNs().PackageManager)
 }
 
 func (r *SiteReconciler) reconcileWorkbenchNetworkPolicy(ctx context.Context, namespace string, l logr.Logger, site *v1beta1.Site) error {
 	policyName := site.Name + "-workbench"
 
-	policy := &networkingv1.NetworkPolicy{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      policyName,
-			Namespace: namespace,
-		},
-	}
-	_, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, policy, site, func() error {
-		policy.Labels = site.KubernetesLabels()
-		policy.Spec = networkingv1.NetworkPolicySpec{
-			PodSelector: metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					v1beta1.SiteLabelKey:      site.Name,
-					v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueWorkbench,
-				},
+	spec := networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				v1beta1.SiteLabelKey:      site.Name,
+				v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueWorkbench,
 			},
-			PolicyTypes: []networkingv1.PolicyType{
-				networkingv1.PolicyTypeEgress,
-				networkingv1.PolicyTypeIngress,
-			},
-			Egress: []networkingv1.NetworkPolicyEgressRule{
-				{},
-			},
-			Ingress: []networkingv1.NetworkPolicyIngressRule{
-				{
-					From: []networkingv1.NetworkPolicyPeer{
-						{
-							// from other workbenches
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.SiteLabelKey:      site.Name,
-									v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueWorkbench,
-								},
+		},
+		PolicyTypes: []networkingv1.PolicyType{
+			networkingv1.PolicyTypeEgress,
+			networkingv1.PolicyTypeIngress,
+		},
+		Egress: []networkingv1.NetworkPolicyEgressRule{
+			{},
+		},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				From: []networkingv1.NetworkPolicyPeer{
+					{
+						// from other workbenches
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.SiteLabelKey:      site.Name,
+								v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueWorkbench,
 							},
 						},
-						{
-							// from workbench sessions
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.SiteLabelKey:      site.Name,
-									v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueWorkbenchSession,
-								},
+					},
+					{
+						// from workbench sessions
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.SiteLabelKey:      site.Name,
+								v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueWorkbenchSession,
 							},
 						},
-						{
-							// from traefik
-							NamespaceSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.KubernetesMetadataNameKey: "traefik",
-								},
+					},
+					{
+						// from traefik
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.KubernetesMetadataNameKey: "traefik",
 							},
 						},
-						{
-							// from grafana agent
-							NamespaceSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
-								},
+					},
+					{
+						// from grafana agent
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
 							},
 						},
 					},
-					Ports: []networkingv1.NetworkPolicyPort{
-						internal.DefaultPortLauncher.NetworkPolicyPort(),
-						internal.DefaultPortWorkbenchHTTP.NetworkPolicyPort(),
-						internal.DefaultPortWorkbenchMetrics.NetworkPolicyPort(),
-					},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					internal.DefaultPortLauncher.NetworkPolicyPort(),
+					internal.DefaultPortWorkbenchHTTP.NetworkPolicyPort(),
+					internal.DefaultPortWorkbenchMetrics.NetworkPolicyPort(),
 				},
 			},
-		}
-		return nil
-	})
-	return err
+		},
+	}
+	if site.Spec.GetNetworkTrust().Level() == v1beta1.NetworkTrustZero {
+		spec.Egress, spec.Ingress = zeroTrustWorkbenchRules(site)
+	}
+	spec.Egress = append(spec.Egress, site.Spec.NetworkPolicy.GetExtraEgress().Workbench...)
+	spec.Ingress = append(spec.Ingress, site.Spec.NetworkPolicy.GetExtraIngress().Workbench...)
+
+	return r.reconcileComponentNetworkPolicy(ctx, namespace, l, site, policyName, spec, site.Spec.NetworkPolicy.GetEgressFQDNs().Workbench)
 }
 
 func (r *SiteReconciler) reconcileWorkbenchSessionNetworkPolicy(ctx context.Context, namespace string, l logr.Logger, site *v1beta1.Site) error {
 	policyName := site.Name + "-workbench-session"
 
-	policy := &networkingv1.NetworkPolicy{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      policyName,
-			Namespace: namespace,
+	// Build egress rules
+	egressRules := []networkingv1.NetworkPolicyEgressRule{
+		{
+			To: []networkingv1.NetworkPolicyPeer{
+				{
+					// to parent workbench host
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							v1beta1.SiteLabelKey:      site.Name,
+							v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueWorkbench,
+						},
+					},
+				},
+			},
 		},
+		// access to the internet with no private networks
+		internal.PublicInternetOnlyNetworkPolicyEgressRule(site.Spec.NetworkPolicy.GetPrivateCIDRs()),
 	}
-	_, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, policy, site, func() error {
-		// Build egress rules
-		egressRules := []networkingv1.NetworkPolicyEgressRule{
+
+	// Add EFS egress rule if enabled
+	egressRules = append(egressRules, efsNetworkPolicyEgressRules(site)...)
+
+	spec := networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				v1beta1.SiteLabelKey:      site.Name,
+				v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueWorkbenchSession,
+			},
+		},
+		PolicyTypes: []networkingv1.PolicyType{
+			networkingv1.PolicyTypeEgress,
+			networkingv1.PolicyTypeIngress,
+		},
+		Egress: egressRules,
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
 			{
-				To: []networkingv1.NetworkPolicyPeer{
+				From: []networkingv1.NetworkPolicyPeer{
 					{
-						// to parent workbench host
+						// from workbench host
 						PodSelector: &metav1.LabelSelector{
 							MatchLabels: map[string]string{
 								v1beta1.SiteLabelKey:      site.Name,
@@ -651,75 +861,54 @@ func (r *SiteReconciler) reconcileWorkbenchSessionNetworkPolicy(ctx context.Cont
 							},
 						},
 					},
-				},
-			},
-			// access to the internet with no private networks
-			internal.PublicInternetOnlyNetworkPolicyEgressRule(),
-		}
-
-		// Add EFS egress rule if enabled
-		if site.Spec.EFSEnabled && site.Spec.VPCCIDR != "" {
-			tcp := v1.ProtocolTCP
-			port2049 := intstr.FromInt(2049)
-			egressRules = append(egressRules, networkingv1.NetworkPolicyEgressRule{
-				To: []networkingv1.NetworkPolicyPeer{
 					{
-						IPBlock: &networkingv1.IPBlock{
-							CIDR: site.Spec.VPCCIDR,
+						// from grafana agent
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
+							},
 						},
 					},
 				},
 				Ports: []networkingv1.NetworkPolicyPort{
-					{
-						Protocol: &tcp,
-						Port:     &port2049,
-					},
+					internal.DefaultPortWorkbenchSessionHTTP.NetworkPolicyPort(),
+					internal.DefaultPortWorkbenchSessionProxy.NetworkPolicyPort(),
 				},
-			})
-		}
+			},
+		},
+	}
+	if site.Spec.GetNetworkTrust().Level() == v1beta1.NetworkTrustZero {
+		spec.Egress, spec.Ingress = zeroTrustWorkbenchSessionRules(site)
+	}
+	spec.Egress = append(spec.Egress, site.Spec.NetworkPolicy.GetExtraEgress().WorkbenchSession...)
+	spec.Ingress = append(spec.Ingress, site.Spec.NetworkPolicy.GetExtraIngress().WorkbenchSession...)
 
-		policy.Labels = site.KubernetesLabels()
-		policy.Spec = networkingv1.NetworkPolicySpec{
-			PodSelector: metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					v1beta1.SiteLabelKey:      site.Name,
-					v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueWorkbenchSession,
+	return r.reconcileComponentNetworkPolicy(ctx, namespace, l, site, policyName, spec, site.Spec.NetworkPolicy.GetEgressFQDNs().WorkbenchSession)
+}
+
+// efsNetworkPolicyEgressRules allows workbench sessions to reach the EFS mount targets when EFS is enabled
+func efsNetworkPolicyEgressRules(site *v1beta1.Site) []networkingv1.NetworkPolicyEgressRule {
+	if !site.Spec.EFSEnabled || site.Spec.VPCCIDR == "" {
+		return nil
+	}
+
+	tcp := v1.ProtocolTCP
+	port2049 := intstr.FromInt(2049)
+	return []networkingv1.NetworkPolicyEgressRule{
+		{
+			To: []networkingv1.NetworkPolicyPeer{
+				{
+					IPBlock: &networkingv1.IPBlock{
+						CIDR: site.Spec.VPCCIDR,
+					},
 				},
 			},
-			PolicyTypes: []networkingv1.PolicyType{
-				networkingv1.PolicyTypeEgress,
-				networkingv1.PolicyTypeIngress,
-			},
-			Egress: egressRules,
-			Ingress: []networkingv1.NetworkPolicyIngressRule{
+			Ports: []networkingv1.NetworkPolicyPort{
 				{
-					From: []networkingv1.NetworkPolicyPeer{
-						{
-							// from workbench host
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.SiteLabelKey:      site.Name,
-									v1beta1.ComponentLabelKey: v1beta1.ComponentLabelValueWorkbench,
-								},
-							},
-						},
-						{
-							// from grafana agent
-							NamespaceSelector: &metav1.LabelSelector{
-								MatchLabels: map[string]string{
-									v1beta1.KubernetesMetadataNameKey: grafanaAlloyNamespace,
-								},
-							},
-						},
-					},
-					Ports: []networkingv1.NetworkPolicyPort{
-						internal.DefaultPortWorkbenchSessionHTTP.NetworkPolicyPort(),
-						internal.DefaultPortWorkbenchSessionProxy.NetworkPolicyPort(),
-					},
+					Protocol: &tcp,
+					Port:     &port2049,
 				},
 			},
-		}
-		return nil
-	})
-	return err
+		},
+	}
 }
@@ -0,0 +1,211 @@
+package core
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/internal"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The zero trust rules replace the same-site rules of each component: only the flows that a component needs are
+// allowed, on the ports that they use, and Grafana Alloy may only scrape metrics ports.
+
+const (
+	traefikNamespace = "traefik"
+)
+
+// reconcileDefaultDenyNetworkPolicy denies all traffic to and from the pods of the namespace that no other policy
+// allows, except for DNS lookups
+func (r *SiteReconciler) reconcileDefaultDenyNetworkPolicy(ctx context.Context, namespace string, l logr.Logger, site *v1beta1.Site) error {
+	policyName := site.Name + "-default-deny"
+
+	spec := networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{},
+		PolicyTypes: []networkingv1.PolicyType{
+			networkingv1.PolicyTypeEgress,
+			networkingv1.PolicyTypeIngress,
+		},
+		Egress: []networkingv1.NetworkPolicyEgressRule{
+			internal.DNSNetworkPolicyEgressRule(),
+		},
+	}
+
+	return r.reconcileComponentNetworkPolicy(ctx, namespace, l, site, policyName, spec, nil)
+}
+
+func zeroTrustNetworkPolicyPorts(ports ...internal.TCPPort) []networkingv1.NetworkPolicyPort {
+	res := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, p := range ports {
+		res = append(res, p.NetworkPolicyPort())
+	}
+	return res
+}
+
+func sitePodNetworkPolicyPeer(site *v1beta1.Site, key, value string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				v1beta1.SiteLabelKey: site.Name,
+				key:                  value,
+			},
+		},
+	}
+}
+
+func namespaceNetworkPolicyPeer(namespace string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				v1beta1.KubernetesMetadataNameKey: namespace,
+			},
+		},
+	}
+}
+
+// zeroTrustPeers are the components of a Site, as NetworkPolicy peers
+type zeroTrustPeers struct {
+	connect          networkingv1.NetworkPolicyPeer
+	connectSession   networkingv1.NetworkPolicyPeer
+	workbench        networkingv1.NetworkPolicyPeer
+	workbenchSession networkingv1.NetworkPolicyPeer
+	packageManager   networkingv1.NetworkPolicyPeer
+	chronicle        networkingv1.NetworkPolicyPeer
+	traefik          networkingv1.NetworkPolicyPeer
+	alloy            networkingv1.NetworkPolicyPeer
+}
+
+func newZeroTrustPeers(site *v1beta1.Site) zeroTrustPeers {
+	return zeroTrustPeers{
+		connect:          sitePodNetworkPolicyPeer(site, v1beta1.ComponentLabelKey, v1beta1.ComponentLabelValueConnect),
+		connectSession:   sitePodNetworkPolicyPeer(site, v1beta1.ComponentLabelKey, v1beta1.ComponentLabelValueConnectSession),
+		workbench:        sitePodNetworkPolicyPeer(site, v1beta1.ComponentLabelKey, v1beta1.ComponentLabelValueWorkbench),
+		workbenchSession: sitePodNetworkPolicyPeer(site, v1beta1.ComponentLabelKey, v1beta1.ComponentLabelValueWorkbenchSession),
+		packageManager:   sitePodNetworkPolicyPeer(site, v1beta1.KubernetesInstanceLabelKey, site.Name+"-packagemanager"),
+		chronicle:        sitePodNetworkPolicyPeer(site, v1beta1.KubernetesInstanceLabelKey, site.Name+"-chronicle"),
+		traefik:          namespaceNetworkPolicyPeer(traefikNamespace),
+		alloy:            namespaceNetworkPolicyPeer(grafanaAlloyNamespace),
+	}
+}
+
+func allowIngressFrom(ports []networkingv1.NetworkPolicyPort, peers ...networkingv1.NetworkPolicyPeer) networkingv1.NetworkPolicyIngressRule {
+	return networkingv1.NetworkPolicyIngressRule{From: peers, Ports: ports}
+}
+
+func allowEgressTo(ports []networkingv1.NetworkPolicyPort, peers ...networkingv1.NetworkPolicyPeer) networkingv1.NetworkPolicyEgressRule {
+	return networkingv1.NetworkPolicyEgressRule{To: peers, Ports: ports}
+}
+
+// infrastructureEgressRule allows a component to reach the private infrastructure (databases, the Kubernetes API
+// and cloud services) on the given ports
+func infrastructureEgressRule(site *v1beta1.Site, ports ...internal.TCPPort) networkingv1.NetworkPolicyEgressRule {
+	return allowEgressTo(
+		zeroTrustNetworkPolicyPorts(ports...),
+		internal.IPBlockNetworkPolicyPeers(site.Spec.NetworkPolicy.GetInfrastructureCIDRs())...,
+	)
+}
+
+func zeroTrustChronicleRules(site *v1beta1.Site) ([]networkingv1.NetworkPolicyEgressRule, []networkingv1.NetworkPolicyIngressRule) {
+	p := newZeroTrustPeers(site)
+	return []networkingv1.NetworkPolicyEgressRule{
+		internal.PublicInternetOnlyNetworkPolicyEgressRule(site.Spec.NetworkPolicy.GetPrivateCIDRs()),
+		infrastructureEgressRule(site, internal.DefaultPortHTTPS),
+	}, []networkingv1.NetworkPolicyIngressRule{
+		// the Chronicle agents run alongside Connect and Workbench
+		allowIngressFrom(zeroTrustNetworkPolicyPorts(internal.DefaultPortChronicleHTTP), p.connect, p.workbench),
+		allowIngressFrom(zeroTrustNetworkPolicyPorts(internal.DefaultPortChronicleMetrics), p.alloy),
+	}
+}
+
+func zeroTrustConnectRules(site *v1beta1.Site) ([]networkingv1.NetworkPolicyEgressRule, []networkingv1.NetworkPolicyIngressRule) {
+	p := newZeroTrustPeers(site)
+	return []networkingv1.NetworkPolicyEgressRule{
+		infrastructureEgressRule(site, internal.DefaultPortHTTPS, internal.DefaultPortPostgres),
+		internal.PublicInternetOnlyNetworkPolicyEgressRule(site.Spec.NetworkPolicy.GetPrivateCIDRs()),
+		allowEgressTo(zeroTrustNetworkPolicyPorts(internal.DefaultPortConnectHTTP, internal.DefaultPortLauncher), p.connect),
+		allowEgressTo(zeroTrustNetworkPolicyPorts(internal.DefaultPortConnectHTTP, internal.DefaultPortConnectSession), p.connectSession),
+		allowEgressTo(zeroTrustNetworkPolicyPorts(internal.DefaultPortPackageManagerHTTP), p.packageManager),
+		allowEgressTo(zeroTrustNetworkPolicyPorts(internal.DefaultPortChronicleHTTP), p.chronicle),
+	}, []networkingv1.NetworkPolicyIngressRule{
+		allowIngressFrom(zeroTrustNetworkPolicyPorts(internal.DefaultPortConnectHTTP), p.traefik, p.workbench, p.workbenchSession, p.connectSession),
+		allowIngressFrom(zeroTrustNetworkPolicyPorts(internal.DefaultPortConnectHTTP, internal.DefaultPortLauncher), p.connect),
+		allowIngressFrom(zeroTrustNetworkPolicyPorts(internal.DefaultPortConnectMetrics), p.alloy),
+	}
+}
+
+func zeroTrustConnectSessionRules(site *v1beta1.Site) ([]networkingv1.NetworkPolicyEgressRule, []networkingv1.NetworkPolicyIngressRule) {
+	p := newZeroTrustPeers(site)
+	return []networkingv1.NetworkPolicyEgressRule{
+		internal.PublicInternetOnlyNetworkPolicyEgressRule(site.Spec.NetworkPolicy.GetPrivateCIDRs()),
+		allowEgressTo(zeroTrustNetworkPolicyPorts(internal.DefaultPortConnectHTTP), p.connect),
+		allowEgressTo(zeroTrustNetworkPolicyPorts(internal.DefaultPortPackageManagerHTTP), p.packageManager),
+	}, []networkingv1.NetworkPolicyIngressRule{
+		allowIngressFrom(zeroTrustNetworkPolicyPorts(internal.DefaultPortConnectHTTP, internal.DefaultPortConnectSession), p.connect),
+	}
+}
+
+func zeroTrustHomeRules(site *v1beta1.Site) ([]networkingv1.NetworkPolicyEgressRule, []networkingv1.NetworkPolicyIngressRule) {
+	p := newZeroTrustPeers(site)
+	return []networkingv1.NetworkPolicyEgressRule{
+		// the Kubernetes API
+		infrastructureEgressRule(site, internal.DefaultPortHTTPS),
+	}, []networkingv1.NetworkPolicyIngressRule{
+		allowIngressFrom(zeroTrustNetworkPolicyPorts(internal.DefaultPortHomeHTTP), p.traefik),
+	}
+}
+
+func zeroTrustKeycloakRules(site *v1beta1.Site) ([]networkingv1.NetworkPolicyEgressRule, []networkingv1.NetworkPolicyIngressRule) {
+	p := newZeroTrustPeers(site)
+	return []networkingv1.NetworkPolicyEgressRule{
+		infrastructureEgressRule(site, internal.DefaultPortPostgres),
+	}, []networkingv1.NetworkPolicyIngressRule{
+		allowIngressFrom(zeroTrustNetworkPolicyPorts(internal.DefaultPortKeycloakHTTP, internal.DefaultPortKeycloakHTTPS), p.traefik),
+	}
+}
+
+func zeroTrustPackageManagerRules(site *v1beta1.Site) ([]networkingv1.NetworkPolicyEgressRule, []networkingv1.NetworkPolicyIngressRule) {
+	p := newZeroTrustPeers(site)
+	return []networkingv1.NetworkPolicyEgressRule{
+		internal.PublicInternetOnlyNetworkPolicyEgressRule(site.Spec.NetworkPolicy.GetPrivateCIDRs()),
+		infrastructureEgressRule(site, internal.DefaultPortHTTPS, internal.DefaultPortPostgres),
+	}, []networkingv1.NetworkPolicyIngressRule{
+		allowIngressFrom(
+			zeroTrustNetworkPolicyPorts(internal.DefaultPortPackageManagerHTTP),
+			p.traefik, p.workbench, p.workbenchSession, p.connect, p.connectSession,
+		),
+		allowIngressFrom(zeroTrustNetworkPolicyPorts(internal.DefaultPortPackageManagerMetrics), p.alloy),
+	}
+}
+
+func zeroTrustWorkbenchRules(site *v1beta1.Site) ([]networkingv1.NetworkPolicyEgressRule, []networkingv1.NetworkPolicyIngressRule) {
+	p := newZeroTrustPeers(site)
+	return []networkingv1.NetworkPolicyEgressRule{
+		internal.PublicInternetOnlyNetworkPolicyEgressRule(site.Spec.NetworkPolicy.GetPrivateCIDRs()),
+		infrastructureEgressRule(site, internal.DefaultPortHTTPS, internal.DefaultPortPostgres),
+		allowEgressTo(zeroTrustNetworkPolicyPorts(internal.DefaultPortLauncher, internal.DefaultPortWorkbenchHTTP), p.workbench),
+		allowEgressTo(zeroTrustNetworkPolicyPorts(internal.DefaultPortWorkbenchSessionHTTP, internal.DefaultPortWorkbenchSessionProxy), p.workbenchSession),
+		allowEgressTo(zeroTrustNetworkPolicyPorts(internal.DefaultPortConnectHTTP), p.connect),
+		allowEgressTo(zeroTrustNetworkPolicyPorts(internal.DefaultPortPackageManagerHTTP), p.packageManager),
+		allowEgressTo(zeroTrustNetworkPolicyPorts(internal.DefaultPortChronicleHTTP), p.chronicle),
+	}, []networkingv1.NetworkPolicyIngressRule{
+		allowIngressFrom(zeroTrustNetworkPolicyPorts(internal.DefaultPortWorkbenchHTTP), p.traefik),
+		allowIngressFrom(zeroTrustNetworkPolicyPorts(internal.DefaultPortLauncher, internal.DefaultPortWorkbenchHTTP), p.workbench, p.workbenchSession),
+		allowIngressFrom(zeroTrustNetworkPolicyPorts(internal.DefaultPortWorkbenchMetrics), p.alloy),
+	}
+}
+
+func zeroTrustWorkbenchSessionRules(site *v1beta1.Site) ([]networkingv1.NetworkPolicyEgressRule, []networkingv1.NetworkPolicyIngressRule) {
+	p := newZeroTrustPeers(site)
+	egress := []networkingv1.NetworkPolicyEgressRule{
+		internal.PublicInternetOnlyNetworkPolicyEgressRule(site.Spec.NetworkPolicy.GetPrivateCIDRs()),
+		allowEgressTo(zeroTrustNetworkPolicyPorts(internal.DefaultPortLauncher, internal.DefaultPortWorkbenchHTTP), p.workbench),
+		// publishing to Connect
+		allowEgressTo(zeroTrustNetworkPolicyPorts(internal.DefaultPortConnectHTTP), p.connect),
+		allowEgressTo(zeroTrustNetworkPolicyPorts(internal.DefaultPortPackageManagerHTTP), p.packageManager),
+	}
+	return append(egress, efsNetworkPolicyEgressRules(site)...), []networkingv1.NetworkPolicyIngressRule{
+		allowIngressFrom(zeroTrustNetworkPolicyPorts(internal.DefaultPortWorkbenchSessionHTTP, internal.DefaultPortWorkbenchSessionProxy), p.workbench),
+	}
+}
@@ -76,9 +76,13 @@ func (r *SiteReconciler) reconcilePackageManager(
 					Bioconductor: "bioconductor",
 				},
 			},
-			Volume:     site.Spec.PackageManager.Volume,
-			SecretType: site.Spec.Secret.Type,
-			Url:        packageManagerUrl,
+			Volume:        site.Spec.PackageManager.Volume,
+			SecretType:    site.Spec.Secret.Type,
+			Url:           packageManagerUrl,
+			Aliases:       site.ProductAliases(site.Spec.PackageManager.Hostnames),
+			TLSSecretName: site.Spec.PackageManager.TLSSecretName,
+			IngressPolicy: site.Spec.PackageManager.IngressPolicy,
+			RootPath:      site.ProductPath(site.Spec.PackageManager.DomainPrefix),
 			DatabaseConfig: v1beta1.PostgresDatabaseConfig{
 				Host:           dbHost,
 				DropOnTeardown: site.Spec.DropDatabaseOnTeardown,
@@ -87,15 +91,22 @@ func (r *SiteReconciler) reconcilePackageManager(
 			MainDatabaseCredentialSecret: site.Spec.MainDatabaseCredentialSecret,
 			IngressClass:                 site.Spec.IngressClass,
 			IngressAnnotations:           site.Spec.IngressAnnotations,
+			Routing:                      site.Spec.Routing,
 			Image:                        site.Spec.PackageManager.Image,
 			ImagePullPolicy:              site.Spec.PackageManager.ImagePullPolicy,
 			ImagePullSecrets:             site.Spec.ImagePullSecrets,
 			ChronicleAgentImage:          site.Spec.Chronicle.AgentImage,
+			ChronicleAgentResources:      site.Spec.Chronicle.AgentResources,
 			NodeSelector:                 site.Spec.PackageManager.NodeSelector,
+			PodSchedulingConfig:          site.Spec.PackageManager.PodSchedulingConfig,
 			AddEnv:                       site.Spec.PackageManager.AddEnv,
 			Secret:                       site.Spec.Secret,
 			WorkloadSecret:               site.Spec.WorkloadSecret,
 			Replicas:                     product.PassDefaultReplicas(site.Spec.PackageManager.Replicas, 1),
+			Autoscaling:                  site.Spec.PackageManager.Autoscaling,
+			Resources:                    site.Spec.PackageManager.Resources,
+			SecurityProfile:              site.Spec.SecurityProfile,
+			Overrides:                    site.Spec.Overrides,
 			GitSSHKeys:                   site.Spec.PackageManager.GitSSHKeys,
 			AzureFiles:                   site.Spec.PackageManager.AzureFiles,
 		}
@@ -112,6 +112,7 @@ func deployPrePullDaemonset(ctx context.Context, r *SiteReconciler, req controll
 
 			// TODO: should also use the workbench node selectors...? But could differ from Connect...
 		}
+		site.Spec.SecurityProfile.HardenPod(&prePullDaemonset.Spec.Template.Spec)
 		return nil
 	}); err != nil {
 		l.Error(err, "error creating or updating pre-pull daemonset")
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"context"
+
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;update;patch
+
+// podSecurityLabelKeys are the Pod Security Admission labels that the operator manages on the Site namespace
+var podSecurityLabelKeys = []string{
+	v1beta1.PodSecurityEnforceLabelKey,
+	v1beta1.PodSecurityEnforceVersionLabelKey,
+	v1beta1.PodSecurityWarnLabelKey,
+	v1beta1.PodSecurityAuditLabelKey,
+}
+
+// reconcilePodSecurityLabels labels the Site namespace so that Pod Security Admission enforces the Site's
+// SecurityProfile. Labels are only removed again when the operator set them
+func (r *SiteReconciler) reconcilePodSecurityLabels(ctx context.Context, req ctrl.Request, site *v1beta1.Site) error {
+	l := r.GetLogger(ctx).WithValues("event", "reconcile-pod-security-labels", "security_profile", site.Spec.SecurityProfile)
+
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: req.Namespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			l.Info("namespace not found; skipping pod security labels")
+			return nil
+		}
+		return err
+	}
+
+	profile := site.Spec.SecurityProfile
+	_, managed := ns.Annotations[v1beta1.PodSecurityProfileAnnotationKey]
+	if !profile.Enabled() && !managed {
+		return nil
+	}
+
+	patch := client.MergeFrom(ns.DeepCopy())
+	if profile.Enabled() {
+		if ns.Labels == nil {
+			ns.Labels = map[string]string{}
+		}
+		for k, v := range profile.PodSecurityLabels() {
+			ns.Labels[k] = v
+		}
+		if ns.Annotations == nil {
+			ns.Annotations = map[string]string{}
+		}
+		ns.Annotations[v1beta1.PodSecurityProfileAnnotationKey] = string(profile)
+	} else {
+		for _, k := range podSecurityLabelKeys {
+			delete(ns.Labels, k)
+		}
+		delete(ns.Annotations, v1beta1.PodSecurityProfileAnnotationKey)
+	}
+
+	if err := r.Patch(ctx, ns, patch); err != nil {
+		l.Error(err, "error labeling namespace")
+		return err
+	}
+	return nil
+}
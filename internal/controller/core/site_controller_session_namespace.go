@@ -0,0 +1,301 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/posit-dev/team-operator/internal"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Creating and deleting session namespaces needs the opt-in session-namespace-role. It grants no permissions in the
+// namespaces themselves: the Site binds the session-namespace-manager-role to the operator in its session namespace
+// for the objects there (see workbench_session_namespace.go)
+
+// sessionNamespaceRoleBindingName is the RoleBinding of the operator in a session namespace. The session-namespace-role
+// only lets the operator change the RoleBindings of this name
+const sessionNamespaceRoleBindingName = "team-operator-session-namespace"
+
+// siteSessionNamespaceSelector selects the session namespaces of a Site, and the twins of the volumes that were
+// copied there
+func siteSessionNamespaceSelector(siteName, siteNamespace string) client.MatchingLabels {
+	return client.MatchingLabels{
+		v1beta1.ManagedByLabelKey:      v1beta1.ManagedByLabelValue,
+		v1beta1.SiteLabelKey:           siteName,
+		v1beta1.OwnerNamespaceLabelKey: siteNamespace,
+	}
+}
+
+// sessionNamespaceReconciler is a copy of the reconciler that reads the session namespace from the API server
+func (r *SiteReconciler) sessionNamespaceReconciler(l logr.Logger) *SiteReconciler {
+	return &SiteReconciler{
+		Client:                 newSessionNamespaceClient(r.Client, r.APIReader, l),
+		Log:                    r.Log,
+		Scheme:                 r.Scheme,
+		APIReader:              r.APIReader,
+		SessionNamespaceRole:   r.SessionNamespaceRole,
+		OperatorServiceAccount: r.OperatorServiceAccount,
+	}
+}
+
+// siteSessionNamespaceFinalizer holds the deletion of a Site that has had a session namespace until its session
+// namespace is deleted. Sites without one never read namespaces or volumes
+const siteSessionNamespaceFinalizer = "core.posit.team/session-namespaces"
+
+// reconcileSessionNamespace creates and labels the namespace that the Workbench sessions of the Site run in, binds the
+// operator's permissions there, and deletes the session namespace that the Site no longer uses. The namespace cannot
+// be owned by the Site, so it is labeled with the namespace of the Site instead, recorded in the status and deleted
+// by a finalizer of the Site
+func (r *SiteReconciler) reconcileSessionNamespace(ctx context.Context, req ctrl.Request, site *v1beta1.Site) error {
+	l := r.GetLogger(ctx).WithValues("event", "reconcile-session-namespace")
+	sr := r.sessionNamespaceReconciler(l)
+
+	sessionNamespace := site.Spec.Workbench.SessionNamespace
+	previous := site.Status.SessionNamespace
+	if sessionNamespace == req.Namespace {
+		return fmt.Errorf("the workbench sessionNamespace %q must differ from the namespace of the Site", sessionNamespace)
+	}
+	if sessionNamespace == "" && previous == "" && !controllerutil.ContainsFinalizer(site, siteSessionNamespaceFinalizer) {
+		// the Site never had a session namespace to delete
+		return nil
+	}
+
+	if sessionNamespace != "" {
+		if err := r.setSessionNamespaceFinalizer(ctx, site, true); err != nil {
+			l.Error(err, "error adding session namespace finalizer")
+			return err
+		}
+
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: sessionNamespace,
+			},
+		}
+		if _, err := internal.CreateOrUpdateResource(ctx, sr.Client, r.Scheme, l, ns, nil, func() error {
+			for _, k := range podSecurityLabelKeys {
+				delete(ns.Labels, k)
+			}
+			ns.Labels = product.LabelMerge(ns.Labels, site.KubernetesLabels())
+			ns.Labels = product.LabelMerge(ns.Labels, map[string]string{
+				v1beta1.ComponentLabelKey:      v1beta1.ComponentLabelValueWorkbenchSession,
+				v1beta1.OwnerNamespaceLabelKey: req.Namespace,
+			})
+			// the sessions are held to the SecurityProfile of the Site
+			ns.Labels = product.LabelMerge(ns.Labels, site.Spec.SecurityProfile.PodSecurityLabels())
+			return nil
+		}); err != nil {
+			l.Error(err, "error creating session namespace", "namespace", sessionNamespace)
+			return sessionNamespaceForbidden(err, sessionNamespace)
+		}
+
+		if err := sr.reconcileSessionNamespaceRoleBinding(ctx, l, req, site, sessionNamespace); err != nil {
+			l.Error(err, "error binding the operator in session namespace", "namespace", sessionNamespace)
+			return sessionNamespaceForbidden(err, sessionNamespace)
+		}
+	}
+
+	if previous != "" && previous != sessionNamespace {
+		if err := sr.deleteSessionNamespace(ctx, l, req, previous); err != nil {
+			return err
+		}
+	}
+	site.Status.SessionNamespace = sessionNamespace
+	if sessionNamespace == "" {
+		return r.setSessionNamespaceFinalizer(ctx, site, false)
+	}
+	return nil
+}
+
+// reconcileSessionNamespaceRoleBinding binds the SessionNamespaceRole to the operator in the session namespace, so
+// that the operator can manage the objects there without permissions in every namespace
+func (r *SiteReconciler) reconcileSessionNamespaceRoleBinding(ctx context.Context, l logr.Logger, req ctrl.Request, site *v1beta1.Site, sessionNamespace string) error {
+	if r.SessionNamespaceRole == "" || r.OperatorServiceAccount.Name == "" {
+		// the cluster admin grants the permissions in the session namespace
+		return nil
+	}
+
+	rb := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sessionNamespaceRoleBindingName,
+			Namespace: sessionNamespace,
+		},
+	}
+	_, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, rb, nil, func() error {
+		rb.Labels = product.LabelMerge(rb.Labels, site.KubernetesLabels())
+		rb.Labels = product.LabelMerge(rb.Labels, map[string]string{
+			v1beta1.OwnerNamespaceLabelKey: req.Namespace,
+		})
+		rb.RoleRef = rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     r.SessionNamespaceRole,
+		}
+		rb.Subjects = []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      r.OperatorServiceAccount.Name,
+			Namespace: r.OperatorServiceAccount.Namespace,
+		}}
+		return nil
+	})
+	return err
+}
+
+// finalizeSessionNamespaces deletes the session namespace of a deleted Site and then releases it
+func (r *SiteReconciler) finalizeSessionNamespaces(ctx context.Context, req ctrl.Request, site *v1beta1.Site) error {
+	if !controllerutil.ContainsFinalizer(site, siteSessionNamespaceFinalizer) {
+		return nil
+	}
+	l := r.GetLogger(ctx).WithValues("event", "finalize-session-namespace")
+	sr := r.sessionNamespaceReconciler(l)
+	// the status may not have recorded a namespace that was created by a reconcile that failed later on
+	for _, namespace := range []string{site.Status.SessionNamespace, site.Spec.Workbench.SessionNamespace} {
+		if namespace == "" || namespace == req.Namespace {
+			continue
+		}
+		if err := sr.deleteSessionNamespace(ctx, l, req, namespace); err != nil {
+			l.Error(err, "error cleaning up workbench session namespace", "namespace", namespace)
+			return err
+		}
+	}
+	return r.setSessionNamespaceFinalizer(ctx, site, false)
+}
+
+// setSessionNamespaceFinalizer adds or removes the session namespace finalizer of the Site. Only the metadata of site
+// is updated, so that the status that the reconcile recorded so far is kept
+func (r *SiteReconciler) setSessionNamespaceFinalizer(ctx context.Context, site *v1beta1.Site, present bool) error {
+	obj := site.DeepCopy()
+	var changed bool
+	if present {
+		changed = controllerutil.AddFinalizer(obj, siteSessionNamespaceFinalizer)
+	} else {
+		changed = controllerutil.RemoveFinalizer(obj, siteSessionNamespaceFinalizer)
+	}
+	if !changed {
+		return nil
+	}
+	if err := r.Update(ctx, obj); err != nil {
+		return err
+	}
+	site.Finalizers = obj.Finalizers
+	site.ResourceVersion = obj.ResourceVersion
+	return nil
+}
+
+// deleteSessionNamespace deletes a session namespace of the Site. The twin volumes of the claims there retain their
+// data, so they are deleted as well. A namespace that the Site did not create is left alone
+func (r *SiteReconciler) deleteSessionNamespace(ctx context.Context, l logr.Logger, req ctrl.Request, namespace string) error {
+	selector := siteSessionNamespaceSelector(req.Name, req.Namespace)
+
+	ns := &corev1.Namespace{}
+	err := r.Get(ctx, client.ObjectKey{Name: namespace}, ns)
+	switch {
+	case kerrors.IsNotFound(err):
+	case err != nil:
+		l.Error(err, "error reading session namespace", "namespace", namespace)
+		return sessionNamespaceForbidden(err, namespace)
+	case !labels.SelectorFromSet(labels.Set(selector)).Matches(labels.Set(ns.Labels)):
+		l.Info("not deleting a session namespace that the Site did not create", "namespace", namespace)
+		return nil
+	case ns.DeletionTimestamp == nil:
+		if err := r.Delete(ctx, ns); err != nil && !kerrors.IsNotFound(err) {
+			l.Error(err, "error deleting session namespace", "namespace", namespace)
+			return sessionNamespaceForbidden(err, namespace)
+		}
+		l.Info("deleted session namespace", "namespace", namespace)
+	}
+
+	volumes := &corev1.PersistentVolumeList{}
+	if err := r.List(ctx, volumes, selector); err != nil {
+		l.Error(err, "error listing session volumes")
+		return sessionNamespaceForbidden(err, namespace)
+	}
+	for i := range volumes.Items {
+		pv := &volumes.Items[i]
+		if pv.Spec.ClaimRef == nil || pv.Spec.ClaimRef.Namespace != namespace {
+			continue
+		}
+		if err := r.Delete(ctx, pv); err != nil && !kerrors.IsNotFound(err) {
+			l.Error(err, "error deleting session volume", "pv", pv.Name)
+			return err
+		}
+		l.Info("deleted session volume", "pv", pv.Name)
+	}
+	return nil
+}
+
+// reconcileSessionNamespaceNetworkPolicies moves the workbench session policies into the session namespace
+func (r *SiteReconciler) reconcileSessionNamespaceNetworkPolicies(ctx context.Context, req ctrl.Request, l logr.Logger, site *v1beta1.Site) error {
+	sessionNamespace := site.Spec.Workbench.SessionNamespace
+	if err := r.deleteComponentNetworkPolicy(ctx, req.Namespace, l, site, site.Name+"-workbench-session"); err != nil {
+		return err
+	}
+
+	sr := r.sessionNamespaceReconciler(l)
+	if site.Spec.GetNetworkTrust().Level() == v1beta1.NetworkTrustZero {
+		if err := sr.reconcileDefaultDenyNetworkPolicy(ctx, sessionNamespace, l, site); err != nil {
+			return err
+		}
+	} else if err := sr.deleteComponentNetworkPolicy(ctx, sessionNamespace, l, site, site.Name+"-default-deny"); err != nil {
+		return err
+	}
+	if err := sr.reconcileWorkbenchSessionNetworkPolicy(ctx, sessionNamespace, l, site); err != nil {
+		return err
+	}
+
+	sessionReq := req
+	sessionReq.Namespace = sessionNamespace
+	return sr.cleanupNetworkPolicyBackends(ctx, sessionReq, site.Spec.NetworkPolicy.GetBackend())
+}
+
+// networkPolicyOwner owns the policies in the namespace of the Site. Owner references cannot cross namespaces, so the
+// policies of the session namespace are removed with that namespace
+func networkPolicyOwner(site *v1beta1.Site, namespace string) client.Object {
+	if site.Spec.Workbench.SessionNamespace != "" && namespace == site.Spec.Workbench.SessionNamespace {
+		return nil
+	}
+	return site
+}
+
+// sessionNamespacePeers limits the pod peers of a policy to their namespace when the Workbench sessions run in a
+// namespace of their own: session pods to the session namespace, and the other pods of the Site to its namespace
+func sessionNamespacePeers(site *v1beta1.Site, spec networkingv1.NetworkPolicySpec) networkingv1.NetworkPolicySpec {
+	sessionNamespace := site.Spec.Workbench.SessionNamespace
+	if sessionNamespace == "" {
+		return spec
+	}
+
+	spec = *spec.DeepCopy()
+	scope := func(peers []networkingv1.NetworkPolicyPeer) {
+		for i := range peers {
+			peer := &peers[i]
+			if peer.PodSelector == nil || peer.NamespaceSelector != nil {
+				continue
+			}
+			namespace := site.Namespace
+			labels := peer.PodSelector.MatchLabels
+			if labels[v1beta1.ComponentLabelKey] == v1beta1.ComponentLabelValueWorkbenchSession ||
+				labels[v1beta1.LauncherInstanceIDKey] == site.Name+"-workbench" {
+				namespace = sessionNamespace
+			}
+			peer.NamespaceSelector = namespaceNetworkPolicyPeer(namespace).NamespaceSelector
+		}
+	}
+	for i := range spec.Ingress {
+		scope(spec.Ingress[i].From)
+	}
+	for i := range spec.Egress {
+		scope(spec.Egress[i].To)
+	}
+	return spec
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -80,6 +81,17 @@ func (r *SiteReconciler) reconcileWorkbench(
 		}
 	}
 
+	if err := validateWorkbenchLauncherProfiles(site.Spec.Workbench.Profiles, resourceProfiles); err != nil {
+		l.Error(err, "invalid workbench profiles")
+		return err
+	}
+
+	extensionCache, err := workbenchExtensionCache(site)
+	if err != nil {
+		l.Error(err, "invalid workbench extension cache")
+		return err
+	}
+
 	targetWorkbench := &v1beta1.Workbench{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      req.Name,
@@ -140,7 +152,7 @@ func (r *SiteReconciler) reconcileWorkbench(
 						LauncherAddress:                        "127.0.0.1",
 						LauncherPort:                           5559,
 						LauncherSessionsEnabled:                1,
-						WwwFrameOrigin:                         fmt.Sprintf("https://%s", site.Spec.Domain),
+						WwwFrameOrigin:                         fmt.Sprintf("https://%s", site.FlightdeckHost()),
 						UserProvisioningEnabled:                1,
 						UserHomedirPath:                        "/home",
 						WwwThreadPoolSize:                      threadPoolSize,
@@ -161,15 +173,9 @@ func (r *SiteReconciler) reconcileWorkbench(
 				WorkbenchSessionIniConfig: v1beta1.WorkbenchSessionIniConfig{
 					RSession: &v1beta1.WorkbenchRSessionConfig{
 						// TODO: need TLS to be configurable... for plaintext sites...
-						DefaultRSConnectServer: "https://" + prefixDomain(site.Spec.Connect.DomainPrefix, site.Spec.Domain, v1beta1.SiteSubDomain),
+						DefaultRSConnectServer: site.ProductUrl(site.Spec.Connect.DomainPrefix, site.Spec.Connect.Hostnames),
 						CopilotEnabled:         1,
 					},
-					// TODO: configure the expected package manager repositories...?
-					Repos: &v1beta1.WorkbenchRepoConfig{
-						// don't want two definitions here... they make things slow!
-						// TODO: also worth noting that OS is hard-coded here
-						CRAN: packageManagerRepoUrl,
-					},
 					Positron: &v1beta1.WorkbenchPositronConfig{
 						Enabled:                      site.Spec.Workbench.PositronSettings.Enabled,
 						Exe:                          site.Spec.Workbench.PositronSettings.Exe,
@@ -192,19 +198,17 @@ func (r *SiteReconciler) reconcileWorkbench(
 					VSCodeUserSettingsJson:   site.Spec.Workbench.VsCodeUserSettings,
 					PositronUserSettingsJson: site.Spec.Workbench.PositronSettings.UserSettings,
 				},
-				WorkbenchProfilesConfig: v1beta1.WorkbenchProfilesConfig{
-					LauncherKubernetesProfiles: map[string]v1beta1.WorkbenchLauncherKubernetesProfilesConfigSection{
-						"*": {
-							// TODO: allow configuring...
-							ContainerImages:       product.ConcatLists([]string{defaultSessionImage}, site.Spec.Workbench.ExtraSessionImages),
-							DefaultContainerImage: defaultSessionImage,
-							AllowUnknownImages:    1,
-							MemoryRequestRatio:    getMemoryRequestRatio(site.Spec.Workbench.ExperimentalFeatures),
-							CpuRequestRatio:       getCpuRequestRatio(site.Spec.Workbench.ExperimentalFeatures),
-							ResourceProfiles:      getResourceProfileKeys(resourceProfiles),
-						},
+				WorkbenchProfilesConfig: workbenchLauncherProfiles(
+					v1beta1.WorkbenchLauncherKubernetesProfilesConfigSection{
+						ContainerImages:       product.ConcatLists([]string{defaultSessionImage}, site.Spec.Workbench.ExtraSessionImages),
+						DefaultContainerImage: defaultSessionImage,
+						AllowUnknownImages:    1,
+						MemoryRequestRatio:    getMemoryRequestRatio(site.Spec.Workbench.ExperimentalFeatures),
+						CpuRequestRatio:       getCpuRequestRatio(site.Spec.Workbench.ExperimentalFeatures),
+						ResourceProfiles:      getResourceProfileKeys(resourceProfiles),
 					},
-				},
+					site.Spec.Workbench.Profiles,
+				),
 			},
 			SecretConfig: v1beta1.WorkbenchSecretConfig{
 				WorkbenchSecretIniConfig: v1beta1.WorkbenchSecretIniConfig{
@@ -220,11 +224,15 @@ func (r *SiteReconciler) reconcileWorkbench(
 					ServiceAccountName: fmt.Sprintf("%s-workbench-session", req.Name),
 				},
 			},
-			License:    site.Spec.Workbench.License,
-			Volume:     site.Spec.Workbench.Volume,
-			SecretType: site.Spec.Secret.Type,
-			Url:        workbenchUrl,
-			ParentUrl:  site.Spec.Domain,
+			License:       site.Spec.Workbench.License,
+			Volume:        site.Spec.Workbench.Volume,
+			SecretType:    site.Spec.Secret.Type,
+			Url:           workbenchUrl,
+			Aliases:       site.ProductAliases(site.Spec.Workbench.Hostnames),
+			TLSSecretName: site.Spec.Workbench.TLSSecretName,
+			IngressPolicy: site.Spec.Workbench.IngressPolicy,
+			RootPath:      site.ProductPath(site.Spec.Workbench.DomainPrefix),
+			ParentUrl:     site.FlightdeckHost(),
 			DatabaseConfig: v1beta1.PostgresDatabaseConfig{
 				Host:           dbHost,
 				DropOnTeardown: site.Spec.DropDatabaseOnTeardown,
@@ -233,18 +241,32 @@ func (r *SiteReconciler) reconcileWorkbench(
 			MainDatabaseCredentialSecret: site.Spec.MainDatabaseCredentialSecret,
 			IngressClass:                 site.Spec.IngressClass,
 			IngressAnnotations:           site.Spec.IngressAnnotations,
+			Routing:                      site.Spec.Routing,
 			Image:                        workbenchServerImage,
 			ImagePullPolicy:              site.Spec.Workbench.ImagePullPolicy,
 			ChronicleAgentImage:          site.Spec.Chronicle.AgentImage,
+			ChronicleAgentResources:      site.Spec.Chronicle.AgentResources,
 			AdditionalVolumes:            additionalVolumes,
 			ImagePullSecrets:             site.Spec.ImagePullSecrets,
 			NodeSelector:                 site.Spec.Workbench.NodeSelector,
-			Tolerations:                  site.Spec.Workbench.Tolerations,
+			PodSchedulingConfig:          site.Spec.Workbench.PodSchedulingConfig,
 			AddEnv:                       site.Spec.Workbench.AddEnv,
 			Auth:                         site.Spec.Workbench.Auth,
 			Secret:                       site.Spec.Secret,
 			WorkloadSecret:               site.Spec.WorkloadSecret,
 			Replicas:                     product.PassDefaultReplicas(site.Spec.Workbench.Replicas, 1),
+			Autoscaling:                  site.Spec.Workbench.Autoscaling,
+			SessionQuota:                 site.Spec.Workbench.SessionQuota,
+			Resources:                    site.Spec.Workbench.Resources,
+			SecurityProfile:              site.Spec.SecurityProfile,
+			Overrides:                    site.Spec.Overrides,
+			SessionImageSelector:         site.Spec.Workbench.SessionImageSelector,
+			UserSelector:                 site.Spec.Workbench.UserSelector,
+			LauncherTemplates:            site.Spec.Workbench.LauncherTemplates,
+			Repositories:                 workbenchRepositories(site, packageManagerRepoUrl),
+			SessionNamespace:             site.Spec.Workbench.SessionNamespace,
+			// the restricted profile does not allow the server to run as root
+			NonRoot: site.Spec.SecurityProfile == v1beta1.SecurityProfileRestricted,
 		},
 	}
 	// potentially enable experimental features
@@ -368,6 +390,11 @@ func (r *SiteReconciler) reconcileWorkbench(
 		targetWorkbench.Spec.Config.RServer.UserProvisioningRegisterOnFirstLogin = 1
 	}
 
+	// sessions get the projected users from the server, which creates them in the session containers
+	if site.Spec.Workbench.UserSelector != nil {
+		targetWorkbench.Spec.Config.RServer.LauncherSessionsCreateContainerUser = 1
+	}
+
 	// set databricks config if it exists
 	databricksEnabled := false
 	for k, v := range site.Spec.Workbench.Databricks {
@@ -393,6 +420,15 @@ func (r *SiteReconciler) reconcileWorkbench(
 		targetWorkbench.Spec.Config.WorkbenchIniConfig.Jupyter = site.Spec.Workbench.JupyterConfig
 	}
 
+	// limit the lifetime of sessions
+	if lifecycle := site.Spec.Workbench.SessionLifecycle; lifecycle != nil {
+		applyWorkbenchSessionLifecycle(&targetWorkbench.Spec.Config, lifecycle)
+		targetWorkbench.Spec.SessionReaper = lifecycle.Reaper
+	}
+
+	// install the session extensions once, into the shared directory
+	targetWorkbench.Spec.ExtensionCache = extensionCache
+
 	// if landing/auth page is customized
 	if site.Spec.Workbench.AuthLoginPageHtml != "" {
 		targetWorkbench.Spec.AuthLoginPageHtml = site.Spec.Workbench.AuthLoginPageHtml
@@ -431,6 +467,54 @@ func (r *SiteReconciler) reconcileWorkbench(
 	return nil
 }
 
+// workbenchExtensionCache places the extension cache of the Site in a subdirectory of its shared directory
+func workbenchExtensionCache(site *v1beta1.Site) (*v1beta1.WorkbenchExtensionCache, error) {
+	config := site.Spec.Workbench.ExtensionCache
+	if config == nil {
+		return nil, nil
+	}
+	if site.Spec.SharedDirectory == "" {
+		return nil, errors.New("workbench extensionCache requires a sharedDirectory")
+	}
+	if site.Spec.Workbench.ExperimentalFeatures != nil && site.Spec.Workbench.ExperimentalFeatures.VsCodeExtensionsDir != "" {
+		return nil, errors.New("workbench extensionCache cannot be used with experimentalFeatures.vsCodeExtensionsDir")
+	}
+
+	subPath := config.Subdirectory
+	if subPath == "" {
+		subPath = "workbench-extensions"
+	}
+	return &v1beta1.WorkbenchExtensionCache{
+		ClaimName: fmt.Sprintf("%s-shared", site.Name),
+		SubPath:   subPath,
+		Image:     config.Image,
+		Source:    config.Source,
+	}, nil
+}
+
+// workbenchRepositories are the repositories of the Site, or else those of its Package Manager. Only the CRAN
+// repository is known when packageManagerUrl points to another Package Manager
+func workbenchRepositories(site *v1beta1.Site, packageManagerRepoUrl string) []v1beta1.WorkbenchRepository {
+	if len(site.Spec.Workbench.Repositories) > 0 {
+		return site.Spec.Workbench.Repositories
+	}
+
+	// don't want two CRAN definitions here... they make things slow!
+	repos := []v1beta1.WorkbenchRepository{
+		{Name: "CRAN", Type: v1beta1.WorkbenchRepositoryTypeCRAN, URL: packageManagerRepoUrl},
+	}
+	if site.Spec.PackageManagerUrl != "" {
+		return repos
+	}
+
+	// the repositories that the Site configures its Package Manager with
+	packageManagerUrl := site.ProductUrl(site.Spec.PackageManager.DomainPrefix, site.Spec.PackageManager.Hostnames)
+	return append(repos,
+		v1beta1.WorkbenchRepository{Name: "Bioconductor", Type: v1beta1.WorkbenchRepositoryTypeBioc, URL: packageManagerUrl + "/bioconductor"},
+		v1beta1.WorkbenchRepository{Name: "PyPI", Type: v1beta1.WorkbenchRepositoryTypePyPI, URL: packageManagerUrl + "/pypi/latest/simple"},
+	)
+}
+
 func defaultWorkbenchResourceProfiles() map[string]*v1beta1.WorkbenchLauncherKubnernetesResourcesConfigSection {
 	return map[string]*v1beta1.WorkbenchLauncherKubnernetesResourcesConfigSection{
 		"default": {
@@ -460,6 +544,87 @@ func getResourceProfileKeys(resourceProfiles map[string]*v1beta1.WorkbenchLaunch
 	return keys
 }
 
+// validateWorkbenchLauncherProfiles checks that every target has one profile and that every referenced resource
+// profile exists
+func validateWorkbenchLauncherProfiles(
+	profiles []v1beta1.WorkbenchLauncherProfile,
+	resourceProfiles map[string]*v1beta1.WorkbenchLauncherKubnernetesResourcesConfigSection,
+) error {
+	targets := map[string]bool{}
+	for i, p := range profiles {
+		for _, target := range p.Targets {
+			if targets[target] {
+				return fmt.Errorf("workbench profiles[%d]: target %q is in more than one profile", i, target)
+			}
+			targets[target] = true
+		}
+		for _, name := range p.ResourceProfiles {
+			if _, ok := resourceProfiles[name]; !ok {
+				return fmt.Errorf("workbench profiles[%d]: resource profile %q does not exist", i, name)
+			}
+		}
+	}
+	return nil
+}
+
+// workbenchLauncherProfiles builds launcher.kubernetes.profiles.conf from the profile for all users and the
+// Site's per-user and per-group profiles, which are written in order after it
+func workbenchLauncherProfiles(
+	all v1beta1.WorkbenchLauncherKubernetesProfilesConfigSection,
+	profiles []v1beta1.WorkbenchLauncherProfile,
+) v1beta1.WorkbenchProfilesConfig {
+	return v1beta1.WorkbenchProfilesConfig{
+		LauncherKubernetesProfiles: map[string]v1beta1.WorkbenchLauncherKubernetesProfilesConfigSection{"*": all},
+		Profiles:                   profiles,
+	}
+}
+
+// applyWorkbenchSessionLifecycle writes the idle limits of each IDE and the job expiry to the Workbench config
+func applyWorkbenchSessionLifecycle(config *v1beta1.WorkbenchConfig, lifecycle *v1beta1.WorkbenchSessionLifecycle) {
+	if limits := lifecycle.RStudio; limits != nil {
+		if config.RSession == nil {
+			config.RSession = &v1beta1.WorkbenchRSessionConfig{}
+		}
+		if limits.IdleSuspendMinutes != nil {
+			config.RSession.SessionTimeoutMinutes = ptr.To(int(*limits.IdleSuspendMinutes))
+		}
+		if limits.IdleKillHours != nil {
+			config.RSession.SessionTimeoutKillHours = ptr.To(int(*limits.IdleKillHours))
+		}
+	}
+
+	if limits := lifecycle.Jupyter; limits != nil && limits.IdleSuspendMinutes != nil {
+		// the Jupyter config can be the Site's own, so it is copied before it is changed
+		jupyter := v1beta1.WorkbenchJupyterConfig{}
+		if config.Jupyter != nil {
+			jupyter = *config.Jupyter
+		}
+		jupyter.SessionCullMinutes = int(*limits.IdleSuspendMinutes)
+		config.Jupyter = &jupyter
+	}
+
+	if limits := lifecycle.VSCode; limits != nil && limits.IdleKillHours != nil {
+		if config.VsCode == nil {
+			config.VsCode = &v1beta1.WorkbenchVsCodeConfig{}
+		}
+		config.VsCode.SessionTimeoutKillHours = int(*limits.IdleKillHours)
+	}
+
+	if limits := lifecycle.Positron; limits != nil && limits.IdleKillHours != nil {
+		if config.Positron == nil {
+			config.Positron = &v1beta1.WorkbenchPositronConfig{}
+		}
+		config.Positron.SessionTimeoutKillHours = int(*limits.IdleKillHours)
+	}
+
+	if lifecycle.JobExpiryHours != nil {
+		if config.LauncherKubernetes == nil {
+			config.LauncherKubernetes = &v1beta1.WorkbenchLauncherKubernetesConfig{}
+		}
+		config.LauncherKubernetes.JobExpiryHours = int(*lifecycle.JobExpiryHours)
+	}
+}
+
 // getCpuRequestRatio returns the configured CPU request ratio, with kubebuilder default fallback
 func getCpuRequestRatio(experimentalFeatures *v1beta1.InternalWorkbenchExperimentalFeatures) string {
 	if experimentalFeatures != nil && experimentalFeatures.CpuRequestRatio != "" {
@@ -3,17 +3,25 @@ package core
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
+	calicov3 "github.com/posit-dev/team-operator/api/calico/v3"
+	ciliumv2 "github.com/posit-dev/team-operator/api/cilium/v2"
 	"github.com/posit-dev/team-operator/api/core/v1beta1"
 	"github.com/posit-dev/team-operator/api/keycloak/v2alpha1"
 	"github.com/posit-dev/team-operator/api/localtest"
 	"github.com/posit-dev/team-operator/api/product"
+	"github.com/posit-dev/team-operator/internal"
+	"github.com/rstudio/goex/ptr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -22,6 +30,7 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	secretsstorev1 "sigs.k8s.io/secrets-store-csi-driver/apis/v1"
 )
 
@@ -37,6 +46,11 @@ func loadSchemes(scheme *runtime.Scheme) {
 	utilruntime.Must(v1alpha1.AddToScheme(scheme))
 	// keycloak
 	utilruntime.Must(v2alpha1.AddToScheme(scheme))
+	// gateway api
+	utilruntime.Must(gatewayv1.AddToScheme(scheme))
+	// network policy backends
+	utilruntime.Must(ciliumv2.AddToScheme(scheme))
+	utilruntime.Must(calicov3.AddToScheme(scheme))
 }
 
 // runFakeSiteReconciler uses a FakeClient to run the SiteReconciler in a "fake" capacity. (i.e. no actual server API)
@@ -125,7 +139,7 @@ func defaultSite(name string) *v1beta1.Site {
 			DropDatabaseOnTeardown: false,
 			Debug:                  false,
 			LogFormat:              "",
-			NetworkTrust:           v1beta1.NetworkTrustSameSite,
+			NetworkTrust:           ptr.To(v1beta1.NetworkTrustSameSite),
 		},
 	}
 }
@@ -699,6 +713,687 @@ func TestSiteKeycloak(t *testing.T) {
 	assert.Equal(t, site.Namespace, testTraefik.Namespace)
 }
 
+func TestSiteGatewayRouting(t *testing.T) {
+	siteName := "gateway-routing"
+	siteNamespace := "posit-team"
+
+	err := product.GlobalTestSecretProvider.SetSecret("main-database-url", "postgres://my-url:5432/my-db")
+	assert.Nil(t, err)
+
+	site := defaultSite(siteName)
+	site.Spec.Routing = &v1beta1.RoutingConfig{
+		Mode: v1beta1.RoutingModeGateway,
+		Gateway: &v1beta1.GatewayParentRef{
+			Name:        "shared-gateway",
+			SectionName: "https",
+		},
+	}
+	site.Spec.Keycloak = v1beta1.InternalKeycloakSpec{
+		Enabled: true,
+	}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	assert.Nil(t, err)
+
+	// routing is passed through to every product
+	assert.Equal(t, site.Spec.Routing, getConnect(t, cli, siteNamespace, siteName).Spec.Routing)
+	assert.Equal(t, site.Spec.Routing, getWorkbench(t, cli, siteNamespace, siteName).Spec.Routing)
+	assert.Equal(t, site.Spec.Routing, getPackageManager(t, cli, siteNamespace, siteName).Spec.Routing)
+	assert.Equal(t, site.Spec.Routing, getFlightdeck(t, cli, siteNamespace, siteName).Spec.Routing)
+
+	// keycloak is routed through the gateway instead of its own ingress
+	keycloakName := fmt.Sprintf("%s-keycloak", site.Name)
+	keycloakKey := client.ObjectKey{Name: keycloakName, Namespace: siteNamespace}
+	testKeycloak := &v2alpha1.Keycloak{}
+	err = cli.Get(context.TODO(), keycloakKey, testKeycloak, &client.GetOptions{})
+	require.Nil(t, err)
+	assert.False(t, testKeycloak.Spec.Ingress.Enabled)
+
+	testRoute := &gatewayv1.HTTPRoute{}
+	err = cli.Get(context.TODO(), keycloakKey, testRoute, &client.GetOptions{})
+	require.Nil(t, err)
+	assert.Equal(t, []gatewayv1.Hostname{gatewayv1.Hostname("key." + site.Spec.Domain)}, testRoute.Spec.Hostnames)
+	assert.Equal(t, gatewayv1.SectionName("https"), *testRoute.Spec.ParentRefs[0].SectionName)
+	assert.Equal(t, gatewayv1.ObjectName(keycloakName+"-service"), testRoute.Spec.Rules[0].BackendRefs[0].Name)
+
+	// no traefik middleware is needed
+	testTraefik := &v1alpha1.Middleware{}
+	err = cli.Get(context.TODO(), client.ObjectKey{Name: keycloakName + "-forward", Namespace: siteNamespace}, testTraefik, &client.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestSitePathLayout(t *testing.T) {
+	siteName := "path-layout"
+	siteNamespace := "posit-team"
+
+	err := product.GlobalTestSecretProvider.SetSecret("main-database-url", "postgres://my-url:5432/my-db")
+	assert.Nil(t, err)
+
+	site := defaultSite(siteName)
+	site.Spec.Domain = "posit.example.com"
+	site.Spec.Connect.DomainPrefix = "connect"
+	site.Spec.Workbench.DomainPrefix = "workbench"
+	site.Spec.PackageManager.DomainPrefix = "packagemanager"
+	site.Spec.Routing = &v1beta1.RoutingConfig{
+		Layout: v1beta1.SitePathDomain,
+	}
+	site.Spec.Keycloak = v1beta1.InternalKeycloakSpec{
+		Enabled: true,
+	}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	assert.Nil(t, err)
+
+	// every product shares the site domain and is told its root path
+	testConnect := getConnect(t, cli, siteNamespace, siteName)
+	assert.Equal(t, "posit.example.com", testConnect.Spec.Url)
+	assert.Equal(t, "/connect", testConnect.Spec.RootPath)
+	assert.Equal(t, "https://posit.example.com/packagemanager/cran/__linux__/jammy/latest", testConnect.Spec.Config.RPackageRepository["CRAN"].Url)
+
+	testWorkbench := getWorkbench(t, cli, siteNamespace, siteName)
+	assert.Equal(t, "posit.example.com", testWorkbench.Spec.Url)
+	assert.Equal(t, "/workbench", testWorkbench.Spec.RootPath)
+	assert.Equal(t, "https://posit.example.com/connect", testWorkbench.Spec.Config.WorkbenchSessionIniConfig.RSession.DefaultRSConnectServer)
+
+	testPackageManager := getPackageManager(t, cli, siteNamespace, siteName)
+	assert.Equal(t, "posit.example.com", testPackageManager.Spec.Url)
+	assert.Equal(t, "/packagemanager", testPackageManager.Spec.RootPath)
+
+	// keycloak serves its relative path behind an ingress of our own, since "/" belongs to flightdeck
+	keycloakName := fmt.Sprintf("%s-keycloak", site.Name)
+	keycloakKey := client.ObjectKey{Name: keycloakName, Namespace: siteNamespace}
+	testKeycloak := &v2alpha1.Keycloak{}
+	err = cli.Get(context.TODO(), keycloakKey, testKeycloak, &client.GetOptions{})
+	require.Nil(t, err)
+	assert.False(t, testKeycloak.Spec.Ingress.Enabled)
+	assert.Equal(t, "https://posit.example.com/key", testKeycloak.Spec.Hostname.Hostname)
+	assert.Equal(t, []v2alpha1.KeycloakAdditionalOption{{Name: "http-relative-path", Value: "/key"}}, testKeycloak.Spec.AdditionalOptions)
+
+	testIngress := &networkingv1.Ingress{}
+	err = cli.Get(context.TODO(), keycloakKey, testIngress, &client.GetOptions{})
+	require.Nil(t, err)
+	assert.Equal(t, "posit.example.com", testIngress.Spec.Rules[0].Host)
+	assert.Equal(t, "/key", testIngress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, keycloakName+"-service", testIngress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+}
+
+func TestSiteIngressPolicy(t *testing.T) {
+	siteName := "ingress-policy"
+	siteNamespace := "posit-team"
+	site := defaultSite(siteName)
+	site.Spec.Connect.IngressPolicy = &v1beta1.IngressPolicy{
+		RateLimit: &v1beta1.IngressRateLimit{Average: 50, Burst: 100},
+	}
+	site.Spec.PackageManager.IngressPolicy = &v1beta1.IngressPolicy{
+		IPAllowList: &v1beta1.IngressIPAllowList{SourceRanges: []string{"10.0.0.0/8"}},
+	}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	testConnect := getConnect(t, cli, siteNamespace, siteName)
+	assert.Equal(t, site.Spec.Connect.IngressPolicy, testConnect.Spec.IngressPolicy)
+	testPackageManager := getPackageManager(t, cli, siteNamespace, siteName)
+	assert.Equal(t, site.Spec.PackageManager.IngressPolicy, testPackageManager.Spec.IngressPolicy)
+	testWorkbench := getWorkbench(t, cli, siteNamespace, siteName)
+	assert.Nil(t, testWorkbench.Spec.IngressPolicy)
+}
+
+func TestSiteAutoscaling(t *testing.T) {
+	siteName := "autoscaling"
+	siteNamespace := "posit-team"
+	site := defaultSite(siteName)
+	site.Spec.PackageManager.Autoscaling = &v1beta1.AutoscalingConfig{MaxReplicas: 6}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	testPackageManager := getPackageManager(t, cli, siteNamespace, siteName)
+	assert.Equal(t, site.Spec.PackageManager.Autoscaling, testPackageManager.Spec.Autoscaling)
+	testConnect := getConnect(t, cli, siteNamespace, siteName)
+	assert.Nil(t, testConnect.Spec.Autoscaling)
+}
+
+func TestSiteResources(t *testing.T) {
+	siteName := "resources"
+	siteNamespace := "posit-team"
+	site := defaultSite(siteName)
+	site.Spec.Connect.Resources = &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")},
+	}
+	site.Spec.Chronicle.AgentResources = &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")},
+	}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	testConnect := getConnect(t, cli, siteNamespace, siteName)
+	assert.Equal(t, site.Spec.Connect.Resources, testConnect.Spec.Resources)
+	assert.Equal(t, site.Spec.Chronicle.AgentResources, testConnect.Spec.ChronicleAgentResources)
+	testWorkbench := getWorkbench(t, cli, siteNamespace, siteName)
+	assert.Nil(t, testWorkbench.Spec.Resources)
+	assert.Equal(t, v1beta1.DefaultWorkbenchResources(), testWorkbench.GetResources())
+	assert.Equal(t, site.Spec.Chronicle.AgentResources, testWorkbench.Spec.ChronicleAgentResources)
+}
+
+func TestSitePodScheduling(t *testing.T) {
+	siteName := "pod-scheduling"
+	siteNamespace := "posit-team"
+
+	err := product.GlobalTestSecretProvider.SetSecret("main-database-url", "postgres://my-url:5432/my-db")
+	require.NoError(t, err)
+	zoneSpread := corev1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+	}
+	site := defaultSite(siteName)
+	site.Spec.Connect.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{zoneSpread}
+	site.Spec.Connect.PriorityClassName = "posit-products"
+	site.Spec.Connect.PodLabels = map[string]string{"team": "data"}
+	site.Spec.Workbench.Tolerations = []corev1.Toleration{{Key: "workbench", Operator: corev1.TolerationOpExists}}
+	site.Spec.Keycloak = v1beta1.InternalKeycloakSpec{
+		Enabled:      true,
+		NodeSelector: map[string]string{"pool": "system"},
+		PodSchedulingConfig: v1beta1.PodSchedulingConfig{
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{zoneSpread},
+			PriorityClassName:         "posit-products",
+			PodAnnotations:            map[string]string{"karpenter.sh/do-not-disrupt": "true"},
+		},
+	}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	testConnect := getConnect(t, cli, siteNamespace, siteName)
+	assert.Equal(t, site.Spec.Connect.PodSchedulingConfig, testConnect.Spec.PodSchedulingConfig)
+	testWorkbench := getWorkbench(t, cli, siteNamespace, siteName)
+	assert.Equal(t, site.Spec.Workbench.Tolerations, testWorkbench.Spec.Tolerations)
+
+	testKeycloak := &v2alpha1.Keycloak{}
+	keycloakKey := client.ObjectKey{Name: fmt.Sprintf("%s-keycloak", siteName), Namespace: siteNamespace}
+	require.NoError(t, cli.Get(context.TODO(), keycloakKey, testKeycloak))
+	require.NotNil(t, testKeycloak.Spec.Scheduling)
+	assert.Equal(t, "posit-products", testKeycloak.Spec.Scheduling.PriorityClassName)
+	require.Len(t, testKeycloak.Spec.Scheduling.TopologySpreadConstraints, 1)
+	assert.Equal(t, map[string]string{
+		"app":                              "keycloak",
+		v1beta1.KubernetesInstanceLabelKey: keycloakKey.Name,
+	}, testKeycloak.Spec.Scheduling.TopologySpreadConstraints[0].LabelSelector.MatchLabels)
+	podTemplate := testKeycloak.Spec.Unsupported.PodTemplate
+	assert.Equal(t, site.Spec.Keycloak.PodAnnotations, podTemplate.Annotations)
+	assert.Equal(t, site.Spec.Keycloak.NodeSelector, podTemplate.Spec.NodeSelector)
+}
+
+func TestSiteSecurityProfile(t *testing.T) {
+	siteName := "security-profile"
+	siteNamespace := "posit-team"
+
+	err := product.GlobalTestSecretProvider.SetSecret("main-database-url", "postgres://my-url:5432/my-db")
+	require.NoError(t, err)
+	site := defaultSite(siteName)
+	site.Spec.SecurityProfile = v1beta1.SecurityProfileRestricted
+	site.Spec.Workbench.Image = "workbench-image"
+	site.Spec.Keycloak = v1beta1.InternalKeycloakSpec{Enabled: true}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	testConnect := getConnect(t, cli, siteNamespace, siteName)
+	assert.Equal(t, v1beta1.SecurityProfileRestricted, testConnect.Spec.SecurityProfile)
+	testWorkbench := getWorkbench(t, cli, siteNamespace, siteName)
+	assert.Equal(t, v1beta1.SecurityProfileRestricted, testWorkbench.Spec.SecurityProfile)
+	assert.True(t, testWorkbench.Spec.NonRoot)
+	testPackageManager := getPackageManager(t, cli, siteNamespace, siteName)
+	assert.Equal(t, v1beta1.SecurityProfileRestricted, testPackageManager.Spec.SecurityProfile)
+
+	prePull := &appsv1.DaemonSet{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-prepull", Namespace: siteNamespace}, prePull))
+	for _, c := range append(prePull.Spec.Template.Spec.InitContainers, prePull.Spec.Template.Spec.Containers...) {
+		require.NotNil(t, c.SecurityContext, c.Name)
+		assert.True(t, *c.SecurityContext.RunAsNonRoot, c.Name)
+		assert.False(t, *c.SecurityContext.AllowPrivilegeEscalation, c.Name)
+	}
+
+	testKeycloak := &v2alpha1.Keycloak{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-keycloak", Namespace: siteNamespace}, testKeycloak))
+	podSpec := testKeycloak.Spec.Unsupported.PodTemplate.Spec
+	assert.Equal(t, int64(1000), *podSpec.SecurityContext.RunAsUser)
+	assert.True(t, *podSpec.SecurityContext.RunAsNonRoot)
+	assert.Equal(t, []corev1.Capability{"ALL"}, podSpec.Containers[0].SecurityContext.Capabilities.Drop)
+
+	// privileged sessions conflict with every profile
+	site = defaultSite(siteName)
+	site.Spec.SecurityProfile = v1beta1.SecurityProfileBaseline
+	site.Spec.Workbench.ExperimentalFeatures = &v1beta1.InternalWorkbenchExperimentalFeatures{PrivilegedSessions: true}
+	_, _, err = runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	assert.ErrorContains(t, err, "privilegedSessions")
+}
+
+func TestSitePodSecurityLabels(t *testing.T) {
+	siteName := "pod-security-labels"
+	siteNamespace := "posit-team"
+
+	fakeClient := localtest.FakeTestEnv{}
+	cli, scheme, log := fakeClient.Start(loadSchemes)
+	rec := SiteReconciler{Client: cli, Scheme: scheme, Log: log}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: siteNamespace, Name: siteName}}
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   siteNamespace,
+		Labels: map[string]string{v1beta1.PodSecurityEnforceLabelKey: "privileged", "team": "data"},
+	}}
+	require.NoError(t, cli.Create(context.TODO(), ns))
+	getNamespace := func() *corev1.Namespace {
+		out := &corev1.Namespace{}
+		require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: siteNamespace}, out))
+		return out
+	}
+
+	// labels that the operator did not set are left alone
+	site := defaultSite(siteName)
+	require.NoError(t, rec.reconcilePodSecurityLabels(context.TODO(), req, site))
+	assert.Equal(t, "privileged", getNamespace().Labels[v1beta1.PodSecurityEnforceLabelKey])
+
+	site.Spec.SecurityProfile = v1beta1.SecurityProfileRestricted
+	require.NoError(t, rec.reconcilePodSecurityLabels(context.TODO(), req, site))
+	labeled := getNamespace()
+	assert.Equal(t, "restricted", labeled.Labels[v1beta1.PodSecurityEnforceLabelKey])
+	assert.Equal(t, "restricted", labeled.Labels[v1beta1.PodSecurityWarnLabelKey])
+	assert.Equal(t, "restricted", labeled.Annotations[v1beta1.PodSecurityProfileAnnotationKey])
+
+	// unsetting the profile removes the labels again
+	site.Spec.SecurityProfile = v1beta1.SecurityProfileNone
+	require.NoError(t, rec.reconcilePodSecurityLabels(context.TODO(), req, site))
+	unlabeled := getNamespace()
+	assert.Equal(t, map[string]string{"team": "data"}, unlabeled.Labels)
+	assert.NotContains(t, unlabeled.Annotations, v1beta1.PodSecurityProfileAnnotationKey)
+}
+
+func TestSiteOverrides(t *testing.T) {
+	siteName := "overrides"
+	siteNamespace := "posit-team"
+
+	err := product.GlobalTestSecretProvider.SetSecret("main-database-url", "postgres://my-url:5432/my-db")
+	require.NoError(t, err)
+	site := defaultSite(siteName)
+	site.Spec.Workbench.Image = "workbench-image"
+	site.Spec.Overrides = []v1beta1.Override{
+		{
+			Kind:  "DaemonSet",
+			Name:  siteName + "-prepull",
+			Patch: `{"spec": {"template": {"spec": {"priorityClassName": "low"}}}}`,
+		},
+		{
+			Kind:  "Connect",
+			Type:  v1beta1.OverridePatchTypeJSON6902,
+			Patch: `[{"op": "replace", "path": "/spec/missing", "value": true}]`,
+		},
+	}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	prePull := &appsv1.DaemonSet{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-prepull", Namespace: siteNamespace}, prePull))
+	assert.Equal(t, "low", prePull.Spec.Template.Spec.PriorityClassName)
+
+	// the products receive the overrides of the site
+	testConnect := getConnect(t, cli, siteNamespace, siteName)
+	assert.Equal(t, site.Spec.Overrides, testConnect.Spec.Overrides)
+
+	require.Len(t, site.Status.FailedOverrides, 1)
+	assert.Contains(t, site.Status.FailedOverrides[0], "Connect/"+siteName)
+}
+
+func TestSiteWorkbenchProfiles(t *testing.T) {
+	siteName := "workbench-profiles"
+	siteNamespace := "posit-team"
+
+	err := product.GlobalTestSecretProvider.SetSecret("main-database-url", "postgres://my-url:5432/my-db")
+	require.NoError(t, err)
+	site := defaultSite(siteName)
+	site.Spec.Workbench.Image = "workbench-image"
+	site.Spec.Workbench.ExperimentalFeatures = &v1beta1.InternalWorkbenchExperimentalFeatures{
+		ResourceProfiles: map[string]*v1beta1.WorkbenchLauncherKubnernetesResourcesConfigSection{
+			"default": {Name: "Small", Cpus: "1", MemMb: "2000"},
+			"gpu":     {Name: "GPU", Cpus: "4", MemMb: "16000", NvidiaGpus: "1"},
+		},
+	}
+	site.Spec.Workbench.Profiles = []v1beta1.WorkbenchLauncherProfile{
+		{
+			Targets:          []string{"*"},
+			ResourceProfiles: []string{"default"},
+			MaxNvidiaGpus:    ptr.To(int32(0)),
+		},
+		{
+			Targets:          []string{"@data-science", "alice"},
+			Images:           []string{"gpu-image", "workbench-image"},
+			ResourceProfiles: []string{"default", "gpu"},
+			MaxCpus:          "4",
+			MaxMemMb:         ptr.To(int32(16000)),
+			MaxNvidiaGpus:    ptr.To(int32(1)),
+		},
+	}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	testWorkbench := getWorkbench(t, cli, siteNamespace, siteName)
+	profiles := testWorkbench.Spec.Config.WorkbenchProfilesConfig
+	assert.Equal(t, site.Spec.Workbench.Profiles, profiles.Profiles)
+	assert.Equal(t, "workbench-image", profiles.LauncherKubernetesProfiles["*"].DefaultContainerImage)
+	assert.Equal(t, 1, profiles.LauncherKubernetesProfiles["*"].AllowUnknownImages)
+
+	// the "*" profile changes the profile for all users, and the others follow it in order
+	config, err := testWorkbench.Spec.Config.GenerateConfigmap()
+	require.NoError(t, err)
+	conf := config["launcher.kubernetes.profiles.conf"]
+	assert.Contains(t, conf, "allow-unknown-images=1\nmax-nvidia-gpus=0\n")
+	assert.Contains(t, conf, "resource-profiles=default\nallow-custom-resources=0\n\n[@data-science]\n")
+	for _, target := range []string{"@data-science", "alice"} {
+		assert.Contains(t, conf, "["+target+"]\ncontainer-images=gpu-image,workbench-image\ndefault-container-image=gpu-image\n"+
+			"max-cpus=4\nmax-mem-mb=16000\nmax-nvidia-gpus=1\nresource-profiles=default,gpu\n", target)
+	}
+	assert.Less(t, strings.Index(conf, "[@data-science]"), strings.Index(conf, "[alice]"))
+
+	// resource profiles must exist
+	site.Spec.Workbench.Profiles[1].ResourceProfiles = []string{"zz-large"}
+	_, _, err = runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	assert.ErrorContains(t, err, `resource profile "zz-large" does not exist`)
+
+	// a target can only be in one profile
+	site.Spec.Workbench.Profiles[1].ResourceProfiles = nil
+	site.Spec.Workbench.Profiles[1].Targets = []string{"*"}
+	_, _, err = runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	assert.ErrorContains(t, err, `target "*" is in more than one profile`)
+}
+
+func TestSiteWorkbenchSessionImageSelector(t *testing.T) {
+	siteName := "session-image-selector"
+	siteNamespace := "posit-team"
+
+	err := product.GlobalTestSecretProvider.SetSecret("main-database-url", "postgres://my-url:5432/my-db")
+	require.NoError(t, err)
+	site := defaultSite(siteName)
+	site.Spec.Workbench.SessionImageSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"channel": "stable"}}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	testWorkbench := getWorkbench(t, cli, siteNamespace, siteName)
+	assert.Equal(t, site.Spec.Workbench.SessionImageSelector, testWorkbench.Spec.SessionImageSelector)
+}
+
+func TestSiteWorkbenchUserSelector(t *testing.T) {
+	siteName := "user-selector"
+	siteNamespace := "posit-team"
+
+	err := product.GlobalTestSecretProvider.SetSecret("main-database-url", "postgres://my-url:5432/my-db")
+	require.NoError(t, err)
+	site := defaultSite(siteName)
+	site.Spec.Workbench.UserSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"site": siteName}}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	testWorkbench := getWorkbench(t, cli, siteNamespace, siteName)
+	assert.Equal(t, site.Spec.Workbench.UserSelector, testWorkbench.Spec.UserSelector)
+	assert.Equal(t, 1, testWorkbench.Spec.Config.RServer.LauncherSessionsCreateContainerUser)
+}
+
+func TestSiteWorkbenchLauncherTemplates(t *testing.T) {
+	siteName := "launcher-templates"
+	siteNamespace := "posit-team"
+
+	err := product.GlobalTestSecretProvider.SetSecret("main-database-url", "postgres://my-url:5432/my-db")
+	require.NoError(t, err)
+	site := defaultSite(siteName)
+	site.Spec.Workbench.LauncherTemplates = &v1beta1.WorkbenchLauncherTemplates{
+		Version:       v1beta1.LauncherTemplateVersionAuto,
+		ConfigMapName: "custom-templates",
+	}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	testWorkbench := getWorkbench(t, cli, siteNamespace, siteName)
+	assert.Equal(t, site.Spec.Workbench.LauncherTemplates, testWorkbench.Spec.LauncherTemplates)
+}
+
+func TestSiteSessionQuota(t *testing.T) {
+	siteName := "session-quota"
+	siteNamespace := "posit-team"
+
+	err := product.GlobalTestSecretProvider.SetSecret("main-database-url", "postgres://my-url:5432/my-db")
+	require.NoError(t, err)
+	site := defaultSite(siteName)
+	site.Spec.Workbench.SessionQuota = &v1beta1.SessionQuota{
+		PriorityClassName: "workbench-sessions",
+		CPU:               ptr.To(resource.MustParse("32")),
+	}
+	site.Spec.Connect.SessionQuota = &v1beta1.SessionQuota{
+		PriorityClassName: "connect-content",
+		Pods:              ptr.To(int32(20)),
+	}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	testWorkbench := getWorkbench(t, cli, siteNamespace, siteName)
+	assert.Equal(t, site.Spec.Workbench.SessionQuota, testWorkbench.Spec.SessionQuota)
+	testConnect := getConnect(t, cli, siteNamespace, siteName)
+	assert.Equal(t, site.Spec.Connect.SessionQuota, testConnect.Spec.SessionQuota)
+
+	// a LimitRange would apply to the product's own pods without a session namespace
+	limitRange := &v1beta1.SessionLimitRange{Max: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("32Gi")}}
+	site.Spec.Workbench.SessionQuota.LimitRange = limitRange
+	_, _, err = runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	assert.ErrorContains(t, err, "workbench: sessionQuota.limitRange needs a sessionNamespace")
+
+	site.Spec.Workbench.SessionNamespace = siteNamespace + "-sessions"
+	site.Spec.Connect.SessionQuota.LimitRange = limitRange
+	_, _, err = runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	assert.ErrorContains(t, err, "connect: sessionQuota.limitRange")
+	assert.NotContains(t, err.Error(), "workbench")
+}
+
+func TestSiteConnectExecutionEnvironments(t *testing.T) {
+	siteName := "execution-environments"
+	siteNamespace := "posit-team"
+
+	err := product.GlobalTestSecretProvider.SetSecret("main-database-url", "postgres://my-url:5432/my-db")
+	require.NoError(t, err)
+	site := defaultSite(siteName)
+	site.Spec.Connect.ReplaceDefaultExecutionEnvironments = true
+	site.Spec.Connect.ExecutionEnvironments = []v1beta1.ConnectExecutionEnvironment{{
+		Image: "registry.example.com/hardened/content:2025.01",
+		R:     []v1beta1.ConnectRuntimeInstallation{{Version: "4.4.2", Path: "/usr/lib/R/bin/R"}},
+	}}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	testConnect := getConnect(t, cli, siteNamespace, siteName)
+	assert.Equal(t, site.Spec.Connect.ExecutionEnvironments, testConnect.Spec.ExecutionEnvironments)
+	assert.True(t, testConnect.Spec.ReplaceDefaultExecutionEnvironments)
+
+	// an execution environment with a relative path is not usable
+	site = defaultSite(siteName + "-invalid")
+	site.Spec.Connect.ExecutionEnvironments = []v1beta1.ConnectExecutionEnvironment{{
+		Image: "content:1",
+		R:     []v1beta1.ConnectRuntimeInstallation{{Version: "4.4.2", Path: "bin/R"}},
+	}}
+	_, _, err = runFakeSiteReconciler(t, siteNamespace, siteName+"-invalid", site)
+	assert.ErrorIs(t, err, product.InvalidConnectRuntimeImageDefinitionError)
+}
+
+func TestSiteWorkbenchRepositories(t *testing.T) {
+	siteName := "repositories"
+	siteNamespace := "posit-team"
+
+	err := product.GlobalTestSecretProvider.SetSecret("main-database-url", "postgres://my-url:5432/my-db")
+	require.NoError(t, err)
+	site := defaultSite(siteName)
+	site.Spec.PackageManager.Hostnames = []string{"packages.example.com"}
+
+	// the repositories of the Site's Package Manager by default
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	testWorkbench := getWorkbench(t, cli, siteNamespace, siteName)
+	assert.Nil(t, testWorkbench.Spec.Config.Repos)
+	assert.Equal(t, []v1beta1.WorkbenchRepository{
+		{Name: "CRAN", Type: v1beta1.WorkbenchRepositoryTypeCRAN, URL: "https://packages.example.com/cran/__linux__/jammy/latest"},
+		{Name: "Bioconductor", Type: v1beta1.WorkbenchRepositoryTypeBioc, URL: "https://packages.example.com/bioconductor"},
+		{Name: "PyPI", Type: v1beta1.WorkbenchRepositoryTypePyPI, URL: "https://packages.example.com/pypi/latest/simple"},
+	}, testWorkbench.Spec.Repositories)
+
+	// only the CRAN repository of another Package Manager is known
+	site.Spec.PackageManagerUrl = "https://ppm.example.com/cran/latest"
+	cli, _, err = runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	testWorkbench = getWorkbench(t, cli, siteNamespace, siteName)
+	assert.Equal(t, []v1beta1.WorkbenchRepository{
+		{Name: "CRAN", Type: v1beta1.WorkbenchRepositoryTypeCRAN, URL: "https://ppm.example.com/cran/latest"},
+	}, testWorkbench.Spec.Repositories)
+
+	// the Site's repositories replace the defaults
+	site.Spec.Workbench.Repositories = []v1beta1.WorkbenchRepository{
+		{Name: "CRAN", Type: v1beta1.WorkbenchRepositoryTypeCRAN, URL: "https://ppm.example.com/cran/latest"},
+		{Name: "Internal", Type: v1beta1.WorkbenchRepositoryTypeCRAN, URL: "https://ppm.example.com/internal/latest"},
+		{Name: "PyPI", Type: v1beta1.WorkbenchRepositoryTypePyPI, URL: "https://ppm.example.com/pypi/latest/simple"},
+	}
+	cli, _, err = runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	testWorkbench = getWorkbench(t, cli, siteNamespace, siteName)
+	assert.Equal(t, site.Spec.Workbench.Repositories, testWorkbench.Spec.Repositories)
+}
+
+func TestSiteWorkbenchSessionLifecycle(t *testing.T) {
+	siteName := "session-lifecycle"
+	siteNamespace := "posit-team"
+
+	err := product.GlobalTestSecretProvider.SetSecret("main-database-url", "postgres://my-url:5432/my-db")
+	require.NoError(t, err)
+	site := defaultSite(siteName)
+	site.Spec.Workbench.SessionLifecycle = &v1beta1.WorkbenchSessionLifecycle{
+		RStudio:        &v1beta1.WorkbenchSessionIdleLimits{IdleSuspendMinutes: ptr.To(int32(120)), IdleKillHours: ptr.To(int32(0))},
+		Jupyter:        &v1beta1.WorkbenchSessionIdleLimits{IdleSuspendMinutes: ptr.To(int32(60))},
+		VSCode:         &v1beta1.WorkbenchSessionIdleLimits{IdleKillHours: ptr.To(int32(8))},
+		Positron:       &v1beta1.WorkbenchSessionIdleLimits{IdleKillHours: ptr.To(int32(12))},
+		JobExpiryHours: ptr.To(int32(6)),
+		Reaper:         &v1beta1.WorkbenchSessionReaper{MaxAgeHours: 168},
+	}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	testWorkbench := getWorkbench(t, cli, siteNamespace, siteName)
+	config := testWorkbench.Spec.Config
+	assert.Equal(t, ptr.To(120), config.RSession.SessionTimeoutMinutes)
+	assert.Equal(t, ptr.To(0), config.RSession.SessionTimeoutKillHours)
+	assert.Equal(t, 60, config.Jupyter.SessionCullMinutes)
+	assert.Equal(t, 8, config.VsCode.SessionTimeoutKillHours)
+	assert.Equal(t, 12, config.Positron.SessionTimeoutKillHours)
+	assert.Equal(t, 6, config.LauncherKubernetes.JobExpiryHours)
+	assert.Equal(t, site.Spec.Workbench.SessionLifecycle.Reaper, testWorkbench.Spec.SessionReaper)
+}
+
+func TestSiteWorkbenchExtensionCache(t *testing.T) {
+	siteName := "extension-cache"
+	siteNamespace := "posit-team"
+
+	err := product.GlobalTestSecretProvider.SetSecret("main-database-url", "postgres://my-url:5432/my-db")
+	require.NoError(t, err)
+	site := defaultSite(siteName)
+	site.Spec.SharedDirectory = "shared"
+	site.Spec.Workbench.ExtensionCache = &v1beta1.WorkbenchExtensionCacheConfig{
+		Source: &v1beta1.WorkbenchExtensionSource{ConfigMapName: "vsix"},
+	}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	// the cache is a subdirectory of the shared directory
+	testWorkbench := getWorkbench(t, cli, siteNamespace, siteName)
+	assert.Equal(t, &v1beta1.WorkbenchExtensionCache{
+		ClaimName: siteName + "-shared",
+		SubPath:   "workbench-extensions",
+		Source:    site.Spec.Workbench.ExtensionCache.Source,
+	}, testWorkbench.Spec.ExtensionCache)
+
+	// it cannot be used with a separate extensions directory
+	site.Spec.Workbench.ExperimentalFeatures = &v1beta1.InternalWorkbenchExperimentalFeatures{VsCodeExtensionsDir: "/mnt/extensions"}
+	_, _, err = runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	assert.ErrorContains(t, err, "vsCodeExtensionsDir")
+
+	// ... and needs the shared directory
+	site.Spec.Workbench.ExperimentalFeatures = nil
+	site.Spec.SharedDirectory = ""
+	_, _, err = runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	assert.ErrorContains(t, err, "requires a sharedDirectory")
+}
+
+func TestSiteHostnames(t *testing.T) {
+	siteName := "hostnames"
+	siteNamespace := "posit-team"
+
+	err := product.GlobalTestSecretProvider.SetSecret("main-database-url", "postgres://my-url:5432/my-db")
+	assert.Nil(t, err)
+
+	site := defaultSite(siteName)
+	site.Spec.Domain = "posit.example.com"
+	site.Spec.Connect.DomainPrefix = "connect"
+	site.Spec.Connect.Hostnames = []string{"rsc.example.com", "connect.old.example.com"}
+	site.Spec.Connect.TLSSecretName = "connect-tls"
+	site.Spec.PackageManager.DomainPrefix = "packagemanager"
+	site.Spec.PackageManager.Hostnames = []string{"packages.example.com"}
+	site.Spec.Flightdeck.Hostnames = []string{"home.example.com", "posit.example.com"}
+	site.Spec.Keycloak = v1beta1.InternalKeycloakSpec{
+		Enabled:   true,
+		Hostnames: []string{"login.example.com", "key.posit.example.com"},
+	}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	assert.Nil(t, err)
+
+	testConnect := getConnect(t, cli, siteNamespace, siteName)
+	assert.Equal(t, "rsc.example.com", testConnect.Spec.Url)
+	assert.Equal(t, []string{"connect.old.example.com"}, testConnect.Spec.Aliases)
+	assert.Equal(t, "connect-tls", testConnect.Spec.TLSSecretName)
+
+	// the primary hostname is used everywhere else
+	assert.Equal(t, "https://packages.example.com/cran/__linux__/jammy/latest", testConnect.Spec.Config.RPackageRepository["CRAN"].Url)
+	testWorkbench := getWorkbench(t, cli, siteNamespace, siteName)
+	assert.Equal(t, "https://rsc.example.com", testWorkbench.Spec.Config.WorkbenchSessionIniConfig.RSession.DefaultRSConnectServer)
+	assert.Equal(t, "home.example.com", testWorkbench.Spec.ParentUrl)
+
+	testFlightdeck := getFlightdeck(t, cli, siteNamespace, siteName)
+	assert.Equal(t, "home.example.com", testFlightdeck.Spec.Domain)
+	assert.Equal(t, []string{"posit.example.com"}, testFlightdeck.Spec.Aliases)
+
+	// keycloak aliases need an ingress of our own
+	keycloakName := fmt.Sprintf("%s-keycloak", site.Name)
+	keycloakKey := client.ObjectKey{Name: keycloakName, Namespace: siteNamespace}
+	testKeycloak := &v2alpha1.Keycloak{}
+	err = cli.Get(context.TODO(), keycloakKey, testKeycloak, &client.GetOptions{})
+	require.Nil(t, err)
+	assert.False(t, testKeycloak.Spec.Ingress.Enabled)
+	assert.Equal(t, "login.example.com", testKeycloak.Spec.Hostname.Hostname)
+
+	testIngress := &networkingv1.Ingress{}
+	err = cli.Get(context.TODO(), keycloakKey, testIngress, &client.GetOptions{})
+	require.Nil(t, err)
+	require.Len(t, testIngress.Spec.Rules, 2)
+	assert.Equal(t, "login.example.com", testIngress.Spec.Rules[0].Host)
+	assert.Equal(t, "key.posit.example.com", testIngress.Spec.Rules[1].Host)
+}
+
 func TestSiteKeycloakCustomImage(t *testing.T) {
 	siteName := "keycloak-custom-image"
 	siteNamespace := "posit-team"
@@ -939,3 +1634,291 @@ func TestSiteReconciler_WorkbenchSessionImagePullPolicyNever(t *testing.T) {
 	testWorkbench := getWorkbench(t, cli, siteNamespace, siteName)
 	assert.Equal(t, corev1.PullNever, testWorkbench.Spec.SessionConfig.Pod.ImagePullPolicy)
 }
+
+func TestSiteReconciler_NetworkPolicyExtraRules(t *testing.T) {
+	siteName := "network-policy-extra-rules"
+	siteNamespace := "posit-team"
+	site := defaultSite(siteName)
+	warehouse := networkingv1.NetworkPolicyEgressRule{
+		To: []networkingv1.NetworkPolicyPeer{
+			{
+				IPBlock: &networkingv1.IPBlock{CIDR: "10.20.0.0/16"},
+			},
+		},
+	}
+	monitoring := networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{
+			{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{v1beta1.KubernetesMetadataNameKey: "monitoring"},
+				},
+			},
+		},
+	}
+	site.Spec.NetworkPolicy = &v1beta1.NetworkPolicyConfig{
+		PodCIDRs:            []string{"10.244.0.0/16"},
+		InfrastructureCIDRs: []string{"10.0.0.0/16"},
+		ExtraEgress: v1beta1.NetworkPolicyEgressRules{
+			WorkbenchSession: []networkingv1.NetworkPolicyEgressRule{warehouse},
+		},
+		ExtraIngress: v1beta1.NetworkPolicyIngressRules{
+			Connect: []networkingv1.NetworkPolicyIngressRule{monitoring},
+		},
+	}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	session := &networkingv1.NetworkPolicy{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-workbench-session", Namespace: siteNamespace}, session))
+	require.Len(t, session.Spec.Egress, 3)
+	assert.Equal(t, []string{"192.168.0.0/16", "10.0.0.0/8", "172.16.0.0/12"}, session.Spec.Egress[1].To[0].IPBlock.Except)
+	assert.Equal(t, warehouse, session.Spec.Egress[2])
+
+	connect := &networkingv1.NetworkPolicy{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-connect", Namespace: siteNamespace}, connect))
+	require.Len(t, connect.Spec.Ingress, 2)
+	assert.Equal(t, monitoring, connect.Spec.Ingress[1])
+	assert.Equal(t, "10.0.0.0/16", connect.Spec.Egress[0].To[0].IPBlock.CIDR)
+	// the pod network is inside a blocked range, so it is allowed explicitly
+	require.Len(t, connect.Spec.Egress[1].To, 2)
+	assert.Equal(t, "10.244.0.0/16", connect.Spec.Egress[1].To[1].IPBlock.CIDR)
+}
+
+func TestSiteReconciler_NetworkPolicyCiliumFQDNs(t *testing.T) {
+	siteName := "network-policy-cilium"
+	siteNamespace := "posit-team"
+	site := defaultSite(siteName)
+	site.Spec.NetworkPolicy = &v1beta1.NetworkPolicyConfig{
+		Backend: v1beta1.NetworkPolicyBackendCilium,
+		EgressFQDNs: v1beta1.NetworkPolicyFQDNs{
+			WorkbenchSession: []string{"pypi.org", "*.snowflakecomputing.com"},
+		},
+	}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	// the session policy no longer allows the internet
+	session := &networkingv1.NetworkPolicy{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-workbench-session", Namespace: siteNamespace}, session))
+	require.Len(t, session.Spec.Egress, 1)
+	assert.NotNil(t, session.Spec.Egress[0].To[0].PodSelector)
+
+	cnp := &ciliumv2.CiliumNetworkPolicy{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-workbench-session", Namespace: siteNamespace}, cnp))
+	require.NotNil(t, cnp.Spec)
+	assert.Equal(t, session.Spec.PodSelector, cnp.Spec.EndpointSelector)
+	require.Len(t, cnp.Spec.Egress, 2)
+	assert.Equal(t, "pypi.org", cnp.Spec.Egress[1].ToFQDNs[0].MatchName)
+
+	// components without domains are left to the NetworkPolicy
+	err = cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-connect", Namespace: siteNamespace}, &ciliumv2.CiliumNetworkPolicy{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestSiteReconciler_NetworkPolicyCalicoReplace(t *testing.T) {
+	siteName := "network-policy-calico"
+	siteNamespace := "posit-team"
+	site := defaultSite(siteName)
+	site.Spec.NetworkPolicy = &v1beta1.NetworkPolicyConfig{
+		Backend:                   v1beta1.NetworkPolicyBackendCalico,
+		ReplaceKubernetesPolicies: true,
+		EgressFQDNs: v1beta1.NetworkPolicyFQDNs{
+			ConnectSession: []string{"pypi.org"},
+		},
+	}
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	key := client.ObjectKey{Name: siteName + "-connect-session", Namespace: siteNamespace}
+	err = cli.Get(context.TODO(), key, &networkingv1.NetworkPolicy{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	policy := &calicov3.NetworkPolicy{}
+	require.NoError(t, cli.Get(context.TODO(), key, policy))
+	assert.Equal(t, "posit.team/component == 'connect-session' && posit.team/site == 'network-policy-calico'", policy.Spec.Selector)
+	assert.NotEmpty(t, policy.Spec.Ingress)
+
+	networkSet := &calicov3.NetworkSet{}
+	require.NoError(t, cli.Get(context.TODO(), key, networkSet))
+	assert.Equal(t, []string{"pypi.org"}, networkSet.Spec.AllowedEgressDomains)
+	assert.Equal(t, key.Name, networkSet.Labels[internal.CalicoNetworkSetLabelKey])
+
+	// every component gets a Calico policy when it replaces the NetworkPolicies
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-home", Namespace: siteNamespace}, &calicov3.NetworkPolicy{}))
+}
+
+func TestSiteReconciler_NetworkPolicyZeroTrust(t *testing.T) {
+	siteName := "network-policy-zero-trust"
+	siteNamespace := "posit-team"
+	site := defaultSite(siteName)
+	site.Spec.NetworkTrust = ptr.To(v1beta1.NetworkTrustZero)
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	deny := &networkingv1.NetworkPolicy{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-default-deny", Namespace: siteNamespace}, deny))
+	assert.Empty(t, deny.Spec.PodSelector.MatchLabels)
+	assert.Empty(t, deny.Spec.Ingress)
+	require.Len(t, deny.Spec.Egress, 1)
+	assert.Equal(t, "53", deny.Spec.Egress[0].Ports[0].Port.String())
+
+	pm := &networkingv1.NetworkPolicy{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-packagemanager", Namespace: siteNamespace}, pm))
+	require.Len(t, pm.Spec.Ingress, 2)
+	// workbench only reaches package manager on its HTTP port
+	assert.Contains(t, pm.Spec.Ingress[0].From, sitePodNetworkPolicyPeer(site, v1beta1.ComponentLabelKey, v1beta1.ComponentLabelValueWorkbench))
+	require.Len(t, pm.Spec.Ingress[0].Ports, 1)
+	assert.Equal(t, "4242", pm.Spec.Ingress[0].Ports[0].Port.String())
+	// alloy only scrapes metrics
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{namespaceNetworkPolicyPeer(grafanaAlloyNamespace)}, pm.Spec.Ingress[1].From)
+	require.Len(t, pm.Spec.Ingress[1].Ports, 1)
+	assert.Equal(t, "2112", pm.Spec.Ingress[1].Ports[0].Port.String())
+
+	connect := &networkingv1.NetworkPolicy{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-connect", Namespace: siteNamespace}, connect))
+	assert.Contains(t, connect.Spec.Egress, allowEgressTo(
+		zeroTrustNetworkPolicyPorts(internal.DefaultPortChronicleHTTP),
+		sitePodNetworkPolicyPeer(site, v1beta1.KubernetesInstanceLabelKey, siteName+"-chronicle"),
+	))
+
+	require.NotNil(t, site.Status.NetworkPolicy)
+	assert.Equal(t, "zero", site.Status.NetworkPolicy.Level)
+	assert.Contains(t, site.Status.NetworkPolicy.Rules, siteName+"-default-deny: deny all ingress")
+}
+
+func TestSiteReconciler_NetworkPolicySameSiteStatus(t *testing.T) {
+	siteName := "network-policy-same-site"
+	siteNamespace := "posit-team"
+	site := defaultSite(siteName)
+
+	cli, _, err := runFakeSiteReconciler(t, siteNamespace, siteName, site)
+	require.NoError(t, err)
+
+	deny := &networkingv1.NetworkPolicy{}
+	err = cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-default-deny", Namespace: siteNamespace}, deny)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	require.NotNil(t, site.Status.NetworkPolicy)
+	assert.Equal(t, "same-site", site.Status.NetworkPolicy.Level)
+	assert.NotEmpty(t, site.Status.NetworkPolicy.Rules)
+}
+
+func TestSiteWorkbenchSessionNamespace(t *testing.T) {
+	siteName := "session-namespace"
+	siteNamespace := "posit-team"
+	sessionNamespace := "posit-team-sessions"
+
+	fakeClient := localtest.FakeTestEnv{}
+	cli, scheme, log := fakeClient.Start(loadSchemes)
+	rec := SiteReconciler{
+		Client:                 cli,
+		Scheme:                 scheme,
+		Log:                    log,
+		SessionNamespaceRole:   "team-operator-session-namespace-manager-role",
+		OperatorServiceAccount: types.NamespacedName{Namespace: "posit-team-system", Name: "team-operator"},
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: siteNamespace, Name: siteName}}
+
+	// a Site without a session namespace neither records nor finalizes one
+	site := defaultSite(siteName)
+	site.Spec.SecurityProfile = v1beta1.SecurityProfileBaseline
+	require.NoError(t, cli.Create(context.TODO(), site))
+	_, err := rec.reconcileResources(context.TODO(), req, site)
+	require.NoError(t, err)
+	assert.Empty(t, site.Status.SessionNamespace)
+	assert.Empty(t, site.Finalizers)
+
+	site.Spec.Workbench.SessionNamespace = sessionNamespace
+	_, err = rec.reconcileResources(context.TODO(), req, site)
+	require.NoError(t, err)
+	assert.Equal(t, sessionNamespace, site.Status.SessionNamespace)
+	assert.Equal(t, []string{siteSessionNamespaceFinalizer}, site.Finalizers)
+
+	ns := &corev1.Namespace{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: sessionNamespace}, ns))
+	assert.Equal(t, v1beta1.ManagedByLabelValue, ns.Labels[v1beta1.ManagedByLabelKey])
+	assert.Equal(t, siteName, ns.Labels[v1beta1.SiteLabelKey])
+	assert.Equal(t, siteNamespace, ns.Labels[v1beta1.OwnerNamespaceLabelKey])
+	assert.Equal(t, "baseline", ns.Labels[v1beta1.PodSecurityEnforceLabelKey])
+	assert.Empty(t, ns.OwnerReferences)
+
+	// the operator gets its permissions in the session namespace only
+	rb := &rbacv1.RoleBinding{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: sessionNamespaceRoleBindingName, Namespace: sessionNamespace}, rb))
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "team-operator-session-namespace-manager-role"}, rb.RoleRef)
+	assert.Equal(t, []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "team-operator", Namespace: "posit-team-system"}}, rb.Subjects)
+	assert.Equal(t, siteNamespace, rb.Labels[v1beta1.OwnerNamespaceLabelKey])
+
+	wb := getWorkbench(t, cli, siteNamespace, siteName)
+	assert.Equal(t, sessionNamespace, wb.Spec.SessionNamespace)
+
+	// the session policy moves to the session namespace, and peers across the namespaces select the namespace
+	err = cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-workbench-session", Namespace: siteNamespace}, &networkingv1.NetworkPolicy{})
+	assert.True(t, apierrors.IsNotFound(err))
+	session := &networkingv1.NetworkPolicy{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-workbench-session", Namespace: sessionNamespace}, session))
+	assert.Empty(t, session.OwnerReferences)
+	assert.Equal(t, namespaceNetworkPolicyPeer(siteNamespace).NamespaceSelector, session.Spec.Ingress[0].From[0].NamespaceSelector)
+
+	server := &networkingv1.NetworkPolicy{}
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: siteName + "-workbench", Namespace: siteNamespace}, server))
+	assert.Equal(t, namespaceNetworkPolicyPeer(sessionNamespace).NamespaceSelector, server.Spec.Ingress[0].From[1].NamespaceSelector)
+
+	// deleting the Site deletes the namespace and the twin volumes of the claims there
+	twin := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: sessionNamespace + "-home",
+			Labels: map[string]string{
+				v1beta1.ManagedByLabelKey:      v1beta1.ManagedByLabelValue,
+				v1beta1.SiteLabelKey:           siteName,
+				v1beta1.OwnerNamespaceLabelKey: siteNamespace,
+			},
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			ClaimRef: &corev1.ObjectReference{Name: "home", Namespace: sessionNamespace},
+		},
+	}
+	require.NoError(t, cli.Create(context.TODO(), twin))
+	require.NoError(t, cli.Delete(context.TODO(), site))
+	_, err = rec.Reconcile(context.TODO(), req)
+	require.NoError(t, err)
+	err = cli.Get(context.TODO(), client.ObjectKey{Name: sessionNamespace}, &corev1.Namespace{})
+	assert.True(t, apierrors.IsNotFound(err))
+	err = cli.Get(context.TODO(), client.ObjectKey{Name: twin.Name}, &corev1.PersistentVolume{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// and then releases the Site
+	err = cli.Get(context.TODO(), req.NamespacedName, &v1beta1.Site{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestSiteWorkbenchSessionNamespace_Removed(t *testing.T) {
+	siteName := "session-namespace-removed"
+	siteNamespace := "posit-team"
+	sessionNamespace := "posit-team-removed-sessions"
+
+	fakeClient := localtest.FakeTestEnv{}
+	cli, scheme, log := fakeClient.Start(loadSchemes)
+	rec := SiteReconciler{Client: cli, Scheme: scheme, Log: log}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: siteNamespace, Name: siteName}}
+
+	site := defaultSite(siteName)
+	site.Spec.Workbench.SessionNamespace = sessionNamespace
+	require.NoError(t, cli.Create(context.TODO(), site))
+	_, err := rec.reconcileResources(context.TODO(), req, site)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: sessionNamespace}, &corev1.Namespace{}))
+
+	// a Site that stops using its session namespace deletes it, and forgets it
+	site.Spec.Workbench.SessionNamespace = ""
+	_, err = rec.reconcileResources(context.TODO(), req, site)
+	require.NoError(t, err)
+	err = cli.Get(context.TODO(), client.ObjectKey{Name: sessionNamespace}, &corev1.Namespace{})
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.Empty(t, site.Status.SessionNamespace)
+	assert.Empty(t, site.Finalizers)
+}
@@ -179,6 +179,8 @@ func (r *SiteReconciler) provisionSubDirectoryCreator(ctx context.Context, req c
 					},
 				},
 			}
+			// the job runs as root, so the site only passes validation with it under the baseline profile
+			site.Spec.SecurityProfile.HardenPod(&provisionerJob.Spec.Template.Spec)
 			return nil
 		}); err != nil {
 			l.Error(err, "Error creating provisioner job")
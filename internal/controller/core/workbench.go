@@ -3,22 +3,24 @@ package core
 import (
 	"context"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
 	"github.com/posit-dev/team-operator/api/product"
-	"github.com/posit-dev/team-operator/api/templates"
 	"github.com/posit-dev/team-operator/internal"
 	"github.com/posit-dev/team-operator/internal/db"
 	"github.com/rstudio/goex/ptr"
-	"github.com/traefik/traefik/v3/pkg/config/dynamic"
-	"github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -34,6 +36,7 @@ import (
 //+kubebuilder:rbac:namespace=posit-team,groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:namespace=posit-team,groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=secrets-store.csi.x-k8s.io,resources=secretproviderclasses,verbs=get;list;watch;create;update;patch;delete
@@ -86,6 +89,16 @@ func (r *WorkbenchReconciler) ReconcileWorkbench(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, err
 	}
 
+	if err := w.Spec.ValidateSecurityProfile(); err != nil {
+		l.Error(err, "invalid workbench specification")
+		return ctrl.Result{}, err
+	}
+
+	if err := w.Spec.ValidateUserSelector(); err != nil {
+		l.Error(err, "invalid workbench specification")
+		return ctrl.Result{}, err
+	}
+
 	// create database
 	secretKey := "dev-db-password"
 	if err := db.EnsureDatabaseExists(ctx, r, req, w, w.Spec.DatabaseConfig, w.ComponentName(), "", []string{}, w.Spec.Secret, w.Spec.WorkloadSecret, w.Spec.MainDatabaseCredentialSecret, secretKey); err != nil {
@@ -112,6 +125,13 @@ func (r *WorkbenchReconciler) ReconcileWorkbench(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, err
 	}
 
+	// install the session extensions into the extension cache, before the config that depends on it
+	nextExtensionCache, err := r.reconcileExtensionCache(ctx, req, w, time.Now())
+	if err != nil {
+		l.Error(err, "error reconciling extension cache")
+		return ctrl.Result{}, err
+	}
+
 	// define database stuff
 	matches := dbHostRegexp.FindStringSubmatch(w.Spec.DatabaseConfig.Host)
 	hostIndex := dbHostRegexp.SubexpIndex("host")
@@ -134,17 +154,38 @@ func (r *WorkbenchReconciler) ReconcileWorkbench(ctx context.Context, req ctrl.R
 		l.Error(err, "error fetching client secret for databricks azure. Not fatal")
 	}
 
-	// now create the service itself
+	// add the session images of the catalog
+	nextSessionImages, err := r.reconcileSessionImages(ctx, req, w, time.Now())
+	if err != nil {
+		l.Error(err, "error reconciling session images")
+		return ctrl.Result{}, err
+	}
+
+	// now create the service itself; the overrides that fail to apply are recorded along the way
+	failedOverrides := w.Status.FailedOverrides
+	sessionQuota := w.Status.SessionQuota
+	conditions := slices.Clone(w.Status.Conditions)
+	w.Status.FailedOverrides = nil
 	res, err := r.ensureDeployedService(ctx, req, w)
 	if err != nil {
 		l.Error(err, "error deploying service")
 		return res, err
 	}
 
+	// delete the sessions that are past their maximum age
+	nextReap, err := r.reapSessions(ctx, req, w, time.Now())
+	if err != nil {
+		l.Error(err, "error reaping sessions")
+		return ctrl.Result{}, err
+	}
+
 	// TODO: should we watch for happy pods?
 
-	// set to ready if it is not set yet...
-	if !w.Status.Ready {
+	// set to ready if it is not set yet, and keep the failed overrides, session quota and conditions current
+	statusChanged := !slices.Equal(failedOverrides, w.Status.FailedOverrides) ||
+		!sessionQuotaStatusEqual(sessionQuota, w.Status.SessionQuota) ||
+		!equality.Semantic.DeepEqual(conditions, w.Status.Conditions)
+	if !w.Status.Ready || statusChanged {
 		w.Status.Ready = true
 		if err := r.Status().Update(ctx, w); err != nil {
 			l.Error(err, "Error updating status")
@@ -152,88 +193,60 @@ func (r *WorkbenchReconciler) ReconcileWorkbench(ctx context.Context, req ctrl.R
 		}
 	}
 
-	return ctrl.Result{}, nil
+	// come back for the next retired session image or session image registry retry, reaper run or extension cache
+	// retry, whichever is first
+	requeueAfter := nextSessionImages
+	for _, next := range []time.Duration{nextReap, nextExtensionCache} {
+		if next > 0 && (requeueAfter == 0 || next < requeueAfter) {
+			requeueAfter = next
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 var defaultWorkbenchVolumeSize = resource.MustParse("2Gi")
 
 func (r *WorkbenchReconciler) CspMiddleware(w *positcov1beta1.Workbench) string {
-	return fmt.Sprintf("%s-csp", w.ComponentName())
+	return internal.TraefikCspMiddlewareName(w.ComponentName())
 }
 
 func (r *WorkbenchReconciler) ForwardMiddleware(w *positcov1beta1.Workbench) string {
-	return fmt.Sprintf("%s-forward", w.ComponentName())
+	return internal.TraefikForwardMiddlewareName(w.ComponentName())
 }
 
 func (r *WorkbenchReconciler) HeadersMiddleware(w *positcov1beta1.Workbench) string {
-	return fmt.Sprintf("%s-headers", w.ComponentName())
+	return internal.TraefikHeadersMiddlewareName(w.ComponentName())
 }
 
-func (r *WorkbenchReconciler) deployTraefikMiddlewares(ctx context.Context, req ctrl.Request, w *positcov1beta1.Workbench) error {
-	l := r.GetLogger(ctx).WithValues(
-		"function", "deployTraefikMiddlewares",
-	)
-
-	if err := internal.DeployTraefikForwardMiddleware(ctx, req, r.Client, r.Scheme, l, r.ForwardMiddleware(w), w); err != nil {
-		return err
-	}
-
-	cspMiddleware := &v1alpha1.Middleware{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      r.CspMiddleware(w),
-			Namespace: req.Namespace,
-		},
-	}
-
-	l.Info("CREATING CSP traefik middleware...")
-	if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, cspMiddleware, w, func() error {
-		cspMiddleware.Labels = w.KubernetesLabels()
-		cspMiddleware.Spec = v1alpha1.MiddlewareSpec{
-			Headers: &dynamic.Headers{
-				// allow the product to be iframed within the parent
-				// TODO: there is a risk of overriding other CSPs here...
-				//  this also gets simpler if we use the same domain...
-				ContentSecurityPolicy: fmt.Sprintf("frame-ancestors %s 'self';", w.Spec.ParentUrl),
-			},
+// ingressOptions describes what Workbench needs from the ingress controller (or Gateway) in front of it
+func (r *WorkbenchReconciler) ingressOptions(w *positcov1beta1.Workbench) internal.IngressOptions {
+	var requestHeaders map[string]string
+	// the ALB cannot set it, so Workbench builds its redirect URI from the X-Forwarded-* headers there
+	if w.Spec.Routing.SetsRequestHeaders() {
+		requestHeaders = map[string]string{
+			"X-Rstudio-Request": fmt.Sprintf("https://%s%s", w.Spec.Url, w.Spec.RootPath), // setting this prevents Workbench from including port :443 in its redirect URI in OIDC flows
 		}
-		return nil
-	}); err != nil {
-		l.Error(err, "Error creating or updating CSP Middleware")
-		return err
 	}
-	l.Info("DONE creating CSP traefik middleware...")
-
-	headersMiddleware := &v1alpha1.Middleware{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      r.HeadersMiddleware(w),
-			Namespace: req.Namespace,
-		},
+	return internal.IngressOptions{
+		ForwardHeaders: true,
+		RequestHeaders: requestHeaders,
+		// allow the product to be iframed within the parent
+		// TODO: there is a risk of overriding other CSPs here...
+		//  this also gets simpler if we use the same domain...
+		ContentSecurityPolicy: fmt.Sprintf("frame-ancestors %s 'self';", w.Spec.ParentUrl),
+		StickyCookie:          w.ComponentName(),
+		Timeout:               w.Spec.Routing.GetRequestTimeout(),
+		PathPrefix:            w.Spec.RootPath,
+		StripPathPrefix:       true,
+		Policy:                w.Spec.IngressPolicy,
 	}
-
-	l.Info("CREATING HEADERS traefik middleware...")
-	if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, headersMiddleware, w, func() error {
-		headersMiddleware.Labels = w.KubernetesLabels()
-		headersMiddleware.Spec = v1alpha1.MiddlewareSpec{
-			Headers: &dynamic.Headers{
-				CustomRequestHeaders: map[string]string{
-					"X-Rstudio-Request": fmt.Sprintf("https://%s", w.Spec.Url), // setting this prevents Workbench from including port :443 in its redirect URI in OIDC flows
-				},
-			},
-		}
-		return nil
-	}); err != nil {
-		l.Error(err, "Error creating or updating HEADERS Middleware")
-		return err
-	}
-	l.Info("DONE creating Headers traefik middleware...")
-
-	return nil
 }
 
 const workbenchConfigShaKey = "workbench.posit.team/configmap-sha"
 const workbenchSessionShaKey = "workbench.posit.team/session-sha"
 const workbenchSecretShaKey = "workbench.posit.team/secret-sha"
 const workbenchTemplateShaKey = "workbench.posit.team/template-sha"
+const workbenchUsersSeedShaKey = "workbench.posit.team/users-seed-sha"
 
 func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctrl.Request, w *positcov1beta1.Workbench) (ctrl.Result, error) {
 	l := r.GetLogger(ctx).WithValues(
@@ -320,14 +333,28 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 	}
 	configCopy.RServer.MetricsEnabled = 1
 	configCopy.RServer.MetricsPort = int(internal.DefaultPortWorkbenchMetrics)
+	if w.Spec.RootPath != "" {
+		// the ingress strips the root path, so workbench needs to know it to build its URLs
+		configCopy.RServer.WwwRootPath = w.Spec.RootPath
+	}
+
+	// the repositories replace the repos.conf of the config
+	repoFiles := positcov1beta1.GenerateRepositoryConfig(w.Spec.Repositories)
+	if w.Spec.Repositories != nil {
+		configCopy.Repos = nil
+	}
 
 	if w.Spec.OffHostExecution {
 		// config changes for off-host execution...
 		// TODO: this overwrites whatever is set in the spec... should we converge conflicts?
+		jobExpiryHours := 1
+		if configCopy.WorkbenchIniConfig.LauncherKubernetes != nil && configCopy.WorkbenchIniConfig.LauncherKubernetes.JobExpiryHours > 0 {
+			jobExpiryHours = configCopy.WorkbenchIniConfig.LauncherKubernetes.JobExpiryHours
+		}
 		configCopy.WorkbenchIniConfig.LauncherKubernetes = &positcov1beta1.WorkbenchLauncherKubernetesConfig{
-			KubernetesNamespace: req.Namespace,
+			KubernetesNamespace: w.SessionNamespace(),
 			UseTemplating:       1,
-			JobExpiryHours:      1,
+			JobExpiryHours:      jobExpiryHours,
 		}
 
 		// set the server address to a backend path... TODO: use TLS at some point (or make it configurable)
@@ -351,6 +378,9 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 			configCopy.WorkbenchIniConfig.LauncherLocal.Unprivileged = 1
 		}
 	}
+	if w.Spec.OffHostExecution && w.Spec.ExtensionCache != nil {
+		applyExtensionCache(configCopy, w.Status.ExtensionCache)
+	}
 
 	loginData, err := configCopy.GenerateLoginConfigmapData(ctx)
 	if err != nil {
@@ -514,6 +544,7 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 		l.Error(err, "Error generating configmap contents")
 		return ctrl.Result{}, err
 	} else {
+		maps.Copy(cmData, repoFiles)
 		if tmpCmSha, err := product.ComputeSha256(cmData); err != nil {
 			l.Error(err, "Error computing sha256 for configmap")
 			return ctrl.Result{}, err
@@ -544,6 +575,7 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 		l.Error(err, "Error generating session configmap contents")
 		return ctrl.Result{}, err
 	} else {
+		maps.Copy(sessionCmData, repoFiles)
 		if tmpSessionCmSha, err := product.ComputeSha256(sessionCmData); err != nil {
 			l.Error(err, "Error computing sha256 for session configmap")
 			return ctrl.Result{}, err
@@ -567,17 +599,41 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 		}
 	}
 
+	// USERS CONFIGMAP
+
+	projectedUsers, err := r.reconcileUsers(ctx, req, w)
+	if err != nil {
+		l.Error(err, "Error reconciling users")
+		return ctrl.Result{}, err
+	}
+	usersSeedSha, err := projectedUsers.seedsSha()
+	if err != nil {
+		l.Error(err, "Error computing sha256 for users seeds")
+		return ctrl.Result{}, err
+	}
+	var seedHomesContainers []corev1.Container
+	var seedHomesVolumes []corev1.Volume
+	if projectedUsers != nil {
+		seedHomesContainers, seedHomesVolumes = projectedUsers.seedHomesInitContainer(w)
+	}
+
 	// SESSION SERVICE ACCOUNT
 	saAnnotations := internal.AddIamAnnotation(fmt.Sprintf("%s-ses", w.ShortName()), req.Namespace, w.SiteName(), map[string]string{}, w)
 	saName := w.SessionServiceAccountName()
 	sessionServiceAccount := &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      saName,
-			Namespace: req.Namespace,
+			Namespace: w.SessionNamespace(),
 		},
 	}
-	if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, sessionServiceAccount, w, func() error {
-		sessionServiceAccount.Labels = w.KubernetesLabels()
+	var saOwner client.Object = w
+	saLabels := w.KubernetesLabels()
+	if w.Spec.SessionNamespace != "" {
+		saOwner = nil
+		saLabels = sessionNamespaceLabels(w)
+	}
+	if _, err := internal.CreateOrUpdateResource(ctx, r.sessionClient(ctx, w), r.Scheme, l, sessionServiceAccount, saOwner, func() error {
+		sessionServiceAccount.Labels = saLabels
 		sessionServiceAccount.Annotations = saAnnotations
 		return nil
 	}); err != nil {
@@ -621,10 +677,16 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 
 	templateSha := ""
 	if w.Spec.OffHostExecution {
+		sessionTemplateData := w.SessionConfigTemplateData(l, configCopy)
+		launcherTemplates, err := r.launcherTemplates(ctx, req, w, sessionTemplateData)
+		if err != nil {
+			l.Error(err, "Error choosing launcher templates")
+			return ctrl.Result{}, err
+		}
 		templateData := map[string]string{
-			"job.tpl":                            templates.DumpJobTpl(),
-			"service.tpl":                        templates.DumpServiceTpl(),
-			"rstudio-library-templates-data.tpl": w.SessionConfigTemplateData(l, configCopy),
+			"job.tpl":                            launcherTemplates["job.tpl"],
+			"service.tpl":                        launcherTemplates["service.tpl"],
+			"rstudio-library-templates-data.tpl": sessionTemplateData,
 		}
 
 		if tmpTemplateSha, err := product.ComputeSha256(templateData); err != nil {
@@ -647,12 +709,21 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 		}); err != nil {
 			return ctrl.Result{}, err
 		}
+	} else {
+		// only the Kubernetes launcher renders the templates
+		meta.RemoveStatusCondition(&w.Status.Conditions, positcov1beta1.WorkbenchConditionLauncherTemplatesValid)
 	}
 
 	// SERVICE ACCOUNT & RBAC ...
 
 	// TODO: there may be a time that you want to just generate a service account, even if Workbench is on-host execution...
-	if w.Spec.OffHostExecution {
+	if w.Spec.OffHostExecution && w.Spec.SessionNamespace != "" {
+		// the launcher Role is created in the session namespace, with the copies of what sessions mount
+		if err := internal.GenerateServiceAccount(ctx, r.Client, r.Scheme, req, w); err != nil {
+			l.Error(err, "Error generating service account")
+			return ctrl.Result{}, err
+		}
+	} else if w.Spec.OffHostExecution {
 		if err := internal.GenerateRbac(ctx, r.Client, r.Scheme, req, w); err != nil {
 			l.Error(err, "Error generating service account and rbac")
 			return ctrl.Result{}, err
@@ -760,6 +831,19 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 
 	chronicleFactory := product.CreateChronicleWorkbenchVolumeFactory(w, chronicleSeededEnv)
 
+	// TODO: this is a hack to get config changes to trigger a new deployment (for now)
+	//   In the future, we could use our own mechanism and decide whether to restart or SIGHUP the service...
+	podAnnotations := map[string]string{
+		workbenchConfigShaKey:   cmSha,
+		workbenchSessionShaKey:  sessionCmSha,
+		workbenchSecretShaKey:   secretSha,
+		workbenchTemplateShaKey: templateSha,
+	}
+	// new home directories are seeded when the server starts
+	if len(seedHomesContainers) > 0 {
+		podAnnotations[workbenchUsersSeedShaKey] = usersSeedSha
+	}
+
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      w.ComponentName(),
@@ -772,28 +856,23 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 	if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, deployment, w, func() error {
 		deployment.Labels = w.KubernetesLabels()
 		deployment.Spec = appsv1.DeploymentSpec{
-			Replicas: ptr.To(int32(product.PassDefaultReplicas(w.Spec.Replicas, 1))),
+			Replicas: deploymentReplicas(deployment.Spec.Replicas, w.Spec.Replicas, w.Spec.Autoscaling),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: w.SelectorLabels(),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: w.KubernetesLabels(),
-					Annotations: map[string]string{
-						// TODO: this is a hack to get config changes to trigger a new deployment (for now)
-						//   In the future, we could use our own mechanism and decide whether to restart or SIGHUP the service...
-						workbenchConfigShaKey:   cmSha,
-						workbenchSessionShaKey:  sessionCmSha,
-						workbenchSecretShaKey:   secretSha,
-						workbenchTemplateShaKey: templateSha,
-					},
+					Labels:      w.Spec.GetPodLabels(w.KubernetesLabels()),
+					Annotations: w.Spec.GetPodAnnotations(podAnnotations),
 				},
 				Spec: corev1.PodSpec{
 					EnableServiceLinks:           ptr.To(false),
 					NodeSelector:                 w.Spec.NodeSelector,
+					PriorityClassName:            w.Spec.PriorityClassName,
 					ImagePullSecrets:             pullSecrets,
 					ServiceAccountName:           maybeServiceAccountName,
 					AutomountServiceAccountToken: ptr.To(true),
+					InitContainers:               seedHomesContainers,
 					Containers: product.ConcatLists(
 						[]corev1.Container{
 							{
@@ -834,19 +913,7 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 									workbenchSecretVolumeFactory.VolumeMounts(),
 									chronicleFactory.VolumeMounts(),
 								),
-								Resources: corev1.ResourceRequirements{
-									Requests: corev1.ResourceList{
-										// TODO: resources for Workbench
-										//"cpu":               resource.Quantity{Format: "2000m"},
-										//"memory":            resource.Quantity{Format: "3Gi"},
-										//"ephemeral-storage": resource.Quantity{Format: "100Mi"},
-									},
-									Limits: corev1.ResourceList{
-										//"cpu":               resource.Quantity{Format: "6000m"},
-										//"memory":            resource.Quantity{Format: "8Gi"},
-										//"ephemeral-storage": resource.Quantity{Format: "200Mi"},
-									},
-								},
+								Resources: w.GetResources(),
 								ReadinessProbe: &corev1.Probe{
 									ProbeHandler: corev1.ProbeHandler{
 										HTTPGet: &corev1.HTTPGetAction{
@@ -865,10 +932,9 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 						},
 						chronicleFactory.Sidecars(),
 					),
-					Affinity: &corev1.Affinity{
-						PodAntiAffinity: positcov1beta1.ComponentSpecPodAntiAffinity(w, req.Namespace),
-					},
-					SecurityContext: &corev1.PodSecurityContext{
+					Affinity:                  w.Spec.GetAffinity(w, req.Namespace),
+					TopologySpreadConstraints: w.Spec.GetTopologySpreadConstraints(w.SelectorLabels()),
+					SecurityContext:           &corev1.PodSecurityContext{
 						//FSGroup: ptr.To(int64(999)),
 					},
 					Tolerations: w.Spec.Tolerations,
@@ -876,6 +942,7 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 						workbenchVolumeFactory.Volumes(),
 						workbenchSecretVolumeFactory.Volumes(),
 						chronicleFactory.Volumes(),
+						seedHomesVolumes,
 					),
 				},
 			},
@@ -884,6 +951,7 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 			deployment.Spec.Template.Spec.Containers[0].Command = []string{"sleep"}
 			deployment.Spec.Template.Spec.Containers[0].Args = []string{"infinity"}
 		}
+		w.Spec.SecurityProfile.HardenPod(&deployment.Spec.Template.Spec)
 		return nil
 	}); err != nil {
 		return ctrl.Result{}, err
@@ -899,7 +967,7 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 	}
 	if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, service, w, func() error {
 		service.Labels = w.KubernetesLabels()
-		service.Annotations = internal.TraefikStickyServiceAnnotations(w)
+		service.Annotations = internal.NewIngressProvider(w.Spec.Routing).ServiceAnnotations(req.Namespace, w.ComponentName(), r.ingressOptions(w))
 		service.Spec = corev1.ServiceSpec{
 			Ports: []corev1.ServicePort{
 				{
@@ -921,32 +989,93 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 		return ctrl.Result{}, err
 	}
 
-	// TRAEFIK MIDDLEWARES
+	// ROUTING
+
+	if err := w.Spec.Routing.ValidateIngressPolicy(w.Spec.IngressPolicy); err != nil {
+		l.Error(err, "Routing cannot enforce the ingress policy")
+		return ctrl.Result{}, err
+	}
+	if w.Spec.Routing.UseGateway() {
+		if err := r.ensureHTTPRoute(ctx, req, w); err != nil {
+			l.Error(err, "Error deploying HTTPRoute")
+			return ctrl.Result{}, err
+		}
+	} else if err := r.ensureIngress(ctx, req, w); err != nil {
+		l.Error(err, "Error deploying ingress")
+		return ctrl.Result{}, err
+	}
+
+	// AUTOSCALING
+	if err := CreateOrUpdateHorizontalPodAutoscaler(ctx, req, r, r.Client, r.Scheme, w.ComponentName(), w, w.Spec.Autoscaling); err != nil {
+		l.Error(err, "Error deploying HorizontalPodAutoscaler")
+		return ctrl.Result{}, err
+	}
 
-	if err := r.deployTraefikMiddlewares(ctx, req, w); err != nil {
-		l.Error(err, "Error deploying traefik middlewares")
+	// POD DISRUPTION BUDGET
+	if err := CreateOrUpdateDisruptionBudget(
+		ctx, req, r.Client, r.Scheme, w, w, minAvailableReplicas(w.Spec.Replicas, w.Spec.Autoscaling), nil,
+	); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	// INGRESS
-	annotations := map[string]string{}
-	traefikMiddlewares := internal.BuildTraefikMiddlewareAnnotation(
-		req.Namespace,
-		r.ForwardMiddleware(w),
-		r.CspMiddleware(w),
-		r.HeadersMiddleware(w),
-	)
-	annotations[internal.TraefikMiddlewaresKey] = traefikMiddlewares
+	// SESSION NAMESPACE
 
-	// add spec annotations and append traefik middlewares
-	for k, v := range w.Spec.IngressAnnotations {
-		if k == internal.TraefikMiddlewaresKey {
-			traefikMiddlewares = fmt.Sprintf("%s,%s", v, traefikMiddlewares)
-			v = traefikMiddlewares
+	// after the Deployment, since claims that wait for their first consumer are only bound then
+	if w.Spec.OffHostExecution && w.Spec.SessionNamespace != "" {
+		if err := r.reconcileSessionNamespace(ctx, req, w, configCopy); err != nil {
+			l.Error(err, "Error reconciling session namespace")
+			return ctrl.Result{}, err
 		}
-		annotations[k] = v
+	} else {
+		meta.RemoveStatusCondition(&w.Status.Conditions, positcov1beta1.WorkbenchConditionSessionNamespaceReady)
 	}
 
+	// SESSION QUOTA
+
+	// only the launcher creates the pods that the quota counts
+	var sessionQuota *positcov1beta1.SessionQuota
+	if w.Spec.OffHostExecution {
+		sessionQuota = w.Spec.SessionQuota
+	}
+	sessionClient := r.sessionClient(ctx, w)
+	quotaStatus, err := CreateOrUpdateSessionQuota(ctx, sessionRequest(req, w), sessionClient, sessionClient, r.Scheme, w.ComponentName(), w, sessionQuota)
+	if err != nil {
+		l.Error(err, "Error deploying session quota")
+		return ctrl.Result{}, err
+	}
+	w.Status.SessionQuota = quotaStatus
+
+	return ctrl.Result{}, nil
+}
+
+func (r *WorkbenchReconciler) ensureIngress(ctx context.Context, req ctrl.Request, w *positcov1beta1.Workbench) error {
+	l := r.GetLogger(ctx).WithValues(
+		"function", "ensureIngress",
+	)
+
+	// INGRESS PROVIDER RESOURCES
+
+	provider := internal.NewIngressProvider(w.Spec.Routing)
+	opts := r.ingressOptions(w)
+	if err := provider.DeployResources(ctx, req, r.Client, r.Scheme, l, w.ComponentName(), w, opts); err != nil {
+		l.Error(err, "Error deploying ingress provider resources")
+		return err
+	}
+
+	// INGRESS
+
+	// add spec annotations and append provider generated values (i.e. traefik middlewares)
+	hosts := internal.IngressHosts(w.Spec.Url, w.Spec.Aliases)
+	annotations := internal.MergeIngressAnnotations(
+		product.LabelMerge(
+			provider.IngressAnnotations(req.Namespace, w.ComponentName(), opts),
+			internal.ExternalDNSAnnotations(w.Spec.Routing.GetExternalDNS(), hosts),
+		),
+		w.Spec.IngressAnnotations,
+	)
+
+	ingressPath, ingressPathType := provider.IngressPath(opts)
+
 	ingress := &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      w.ComponentName(),
@@ -958,32 +1087,15 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 		ingress.Annotations = annotations
 		ingress.Spec = networkingv1.IngressSpec{
 			// IngressClass set below
-			// TODO: TLS configuration, perhaps
-			TLS: nil,
-			Rules: []networkingv1.IngressRule{
-				{
-					Host: w.Spec.Url,
-					IngressRuleValue: networkingv1.IngressRuleValue{
-						HTTP: &networkingv1.HTTPIngressRuleValue{
-							Paths: []networkingv1.HTTPIngressPath{
-								{
-									Path:     "/",
-									PathType: ptr.To(networkingv1.PathTypePrefix),
-									Backend: networkingv1.IngressBackend{
-										Service: &networkingv1.IngressServiceBackend{
-											Name: w.ComponentName(),
-											Port: networkingv1.ServiceBackendPort{
-												Name: "http",
-											},
-										},
-										Resource: nil,
-									},
-								},
-							},
-						},
+			TLS: internal.IngressTLS(hosts, w.Spec.TLSSecretName),
+			Rules: internal.IngressRules(hosts, ingressPath, ingressPathType, networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: w.ComponentName(),
+					Port: networkingv1.ServiceBackendPort{
+						Name: "http",
 					},
 				},
-			},
+			}),
 		}
 		// only define the ingressClassName if it is specified on the site
 		if w.Spec.IngressClass != "" {
@@ -991,17 +1103,34 @@ func (r *WorkbenchReconciler) ensureDeployedService(ctx context.Context, req ctr
 		}
 		return nil
 	}); err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 
-	// POD DISRUPTION BUDGET
-	if err := CreateOrUpdateDisruptionBudget(
-		ctx, req, r.Client, r.Scheme, w, w, ptr.To(product.DetermineMinAvailableReplicas(w.Spec.Replicas)), nil,
-	); err != nil {
-		return ctrl.Result{}, err
+	// remove any HTTPRoute left over from gateway routing mode
+	return internal.CleanupHTTPRoute(ctx, r, l, client.ObjectKeyFromObject(ingress))
+}
+
+func (r *WorkbenchReconciler) ensureHTTPRoute(ctx context.Context, req ctrl.Request, w *positcov1beta1.Workbench) error {
+	l := r.GetLogger(ctx).WithValues(
+		"function", "ensureHTTPRoute",
+	)
+
+	opts := r.ingressOptions(w).HTTPRouteOptions(internal.IngressHosts(w.Spec.Url, w.Spec.Aliases), w.ComponentName(), 80)
+	if err := internal.DeployHTTPRoute(ctx, req, r.Client, r.Scheme, l, w.ComponentName(), w, w.Spec.Routing, opts); err != nil {
+		return err
 	}
 
-	return ctrl.Result{}, nil
+	// remove any Traefik middlewares left over from ingress routing mode
+	if err := internal.CleanupTraefikResources(ctx, r.Client, l, req.Namespace, w.ComponentName()); err != nil {
+		return err
+	}
+
+	// remove any Ingress left over from ingress routing mode
+	key := client.ObjectKey{
+		Name:      w.ComponentName(),
+		Namespace: req.Namespace,
+	}
+	return internal.BasicDelete(ctx, r, l, key, &networkingv1.Ingress{})
 }
 
 func (r *WorkbenchReconciler) CleanupWorkbench(ctx context.Context, req ctrl.Request, w *positcov1beta1.Workbench) (ctrl.Result, error) {
@@ -1014,6 +1143,22 @@ func (r *WorkbenchReconciler) CleanupWorkbench(ctx context.Context, req ctrl.Req
 	if err := db.CleanupDatabase(ctx, r, req, w.ComponentName()); err != nil {
 		return ctrl.Result{}, err
 	}
+	// the site no longer offers any session image
+	sessionImages, err := r.listSessionImages(ctx, req.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.updateSessionImageStatus(ctx, w, sessionImages, nil, nil); err != nil {
+		return ctrl.Result{}, err
+	}
+	// ... nor any user
+	users, groups, err := r.listWorkbenchUsers(ctx, req.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.updateWorkbenchUserStatuses(ctx, w, users, groups, nil); err != nil {
+		return ctrl.Result{}, err
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -1025,5 +1170,27 @@ func (r *WorkbenchReconciler) cleanupDeployedService(ctx context.Context, req ct
 
 	l.Info("starting")
 
+	// HTTPROUTE
+
+	key := client.ObjectKey{
+		Name:      w.ComponentName(),
+		Namespace: req.Namespace,
+	}
+	if err := internal.CleanupHTTPRoute(ctx, r, l, key); err != nil {
+		return err
+	}
+
+	// SESSION QUOTA
+
+	if _, err := CreateOrUpdateSessionQuota(ctx, req, r, r.Client, r.Scheme, w.ComponentName(), w, nil); err != nil {
+		return err
+	}
+
+	// SESSION NAMESPACE
+
+	if err := r.cleanupSessionNamespace(ctx, req, w.ComponentName()); err != nil {
+		return err
+	}
+
 	return nil
 }
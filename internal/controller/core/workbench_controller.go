@@ -7,21 +7,34 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/internal/registry"
 )
 
 // WorkbenchReconciler reconciles a Workbench object
 type WorkbenchReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Log    logr.Logger
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
+
+	// APIReader reads the session namespace, which the cache of the manager does not hold. Defaults to the Client
+	APIReader client.Reader
+
+	// RuntimeVersions discovers the runtime versions of session images from their labels. Nil disables discovery
+	RuntimeVersions *registry.Discoverer
 }
 
 //+kubebuilder:rbac:namespace=posit-team,groups=core.posit.team,resources=workbenches,verbs=get;list;watch;create;update;patch;delete
@@ -37,6 +50,7 @@ type WorkbenchReconciler struct {
 //+kubebuilder:rbac:namespace=posit-team,groups=metrics.k8s.io,resources=pods,verbs=get
 //+kubebuilder:rbac:namespace=posit-team,groups=secrets-store.csi.x-k8s.io,resources=secretsproviderclass,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:namespace=posit-team,groups=traefik.io,resources=middlewares,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:namespace=posit-team,groups=traefik.io,resources=serverstransports,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -88,9 +102,30 @@ func (r *WorkbenchReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 func (r *WorkbenchReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&positcov1beta1.Workbench{}).
+		Owns(&corev1.ResourceQuota{}).
+		Owns(&batchv1.Job{}).
+		Watches(&positcov1beta1.SessionImage{}, handler.EnqueueRequestsFromMapFunc(r.workbenchesInNamespace)).
+		Watches(&positcov1beta1.WorkbenchUser{}, handler.EnqueueRequestsFromMapFunc(r.workbenchesInNamespace)).
+		Watches(&positcov1beta1.WorkbenchGroup{}, handler.EnqueueRequestsFromMapFunc(r.workbenchesInNamespace)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.workbenchesForLauncherTemplates)).
 		Complete(r)
 }
 
+// workbenchesInNamespace enqueues the Workbenches in the namespace of a SessionImage, WorkbenchUser or
+// WorkbenchGroup
+func (r *WorkbenchReconciler) workbenchesInNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &positcov1beta1.WorkbenchList{}
+	if err := r.List(ctx, list, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.GetLogger(ctx).Error(err, "error listing workbenches", "object", obj.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, w := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&w)})
+	}
+	return requests
+}
+
 func (r *WorkbenchReconciler) GetLogger(ctx context.Context) logr.Logger {
 	if v, err := logr.FromContext(ctx); err == nil {
 		return v
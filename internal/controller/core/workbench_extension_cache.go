@@ -0,0 +1,388 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/posit-dev/team-operator/internal"
+	"github.com/rstudio/goex/ptr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// extensionCacheLabelKey labels the extension cache Jobs with the Workbench component
+	extensionCacheLabelKey = "workbench.posit.team/extension-cache"
+
+	// extensionCacheSourceMountPath is where the extension cache Job mounts the .vsix files
+	extensionCacheSourceMountPath = "/mnt/extension-source"
+
+	// the VS Code and Positron servers of the Workbench image
+	extensionCacheVsCodeBin   = "/usr/lib/rstudio-server/bin/pwb-code-server/bin/code-server"
+	extensionCachePositronBin = "/usr/lib/rstudio-server/bin/positron-server/bin/positron-server"
+
+	// extensionCacheRetryInterval is how long a failed extension cache Job is kept before it runs again
+	extensionCacheRetryInterval = 5 * time.Minute
+)
+
+// installExtensionsSh installs the extensions of each IDE into <ide>-<hash> and then points <ide> at it, so that
+// sessions never see a partial install. The previous install is kept for the sessions that still use it
+var installExtensionsSh = `#!/bin/bash
+set -euo pipefail
+shopt -s nullglob
+
+install_extensions() {
+  local ide="$1" bin="$2" extensions="$3"
+  if [[ -z "$extensions" ]]; then
+    return
+  fi
+  if [[ ! -x "$bin" ]]; then
+    echo "extension-cache: $bin is not in the image, cannot install the $ide extensions" >&2
+    exit 1
+  fi
+
+  local dir="` + positcov1beta1.WorkbenchExtensionCacheMountPath + `/$ide-$HASH"
+  rm -rf "$dir.tmp"
+  mkdir -p "$dir.tmp"
+  while read -r ext; do
+    if [[ -z "$ext" ]]; then
+      continue
+    fi
+    local id="${ext%@*}" version="" target="$ext" vsix=""
+    if [[ "$ext" == *@* ]]; then
+      version="${ext#*@}"
+      vsix="$(find -L ` + extensionCacheSourceMountPath + ` -maxdepth 1 -iname "$id-$version.vsix" | head -n 1)"
+    else
+      vsix="$(find -L ` + extensionCacheSourceMountPath + ` -maxdepth 1 -iname "$id-*.vsix" | sort -V | tail -n 1)"
+    fi
+    if [[ -n "$vsix" ]]; then
+      target="$vsix"
+    fi
+    echo "extension-cache: installing $ext for $ide from $target"
+    "$bin" --extensions-dir "$dir.tmp" --install-extension "$target"
+  done <<< "$extensions"
+
+  rm -rf "$dir"
+  mv "$dir.tmp" "$dir"
+  chmod -R a+rX "$dir"
+
+  local previous
+  previous="$(readlink "` + positcov1beta1.WorkbenchExtensionCacheMountPath + `/$ide" || true)"
+  ln -sfn "$ide-$HASH" "` + positcov1beta1.WorkbenchExtensionCacheMountPath + `/$ide"
+  for old in "` + positcov1beta1.WorkbenchExtensionCacheMountPath + `/$ide"-*; do
+    local name
+    name="$(basename "$old")"
+    if [[ "$name" != "$ide-$HASH" && "$name" != "$previous" ]]; then
+      rm -rf "$old"
+    fi
+  done
+}
+
+install_extensions vscode ` + extensionCacheVsCodeBin + ` "$VSCODE_EXTENSIONS"
+install_extensions positron ` + extensionCachePositronBin + ` "$POSITRON_EXTENSIONS"
+`
+
+// applyExtensionCache points the VS Code and Positron sessions at the extension cache, and stops them from
+// installing the extensions themselves. Until the Job has installed the extensions, the sessions keep installing
+// them
+func applyExtensionCache(config *positcov1beta1.WorkbenchConfig, status *positcov1beta1.WorkbenchExtensionCacheStatus) {
+	if status == nil || status.Phase != positcov1beta1.WorkbenchExtensionCacheSucceeded {
+		return
+	}
+	if config.VsCode != nil {
+		config.VsCode.Args = strings.TrimSpace(config.VsCode.Args + " --extensions-dir=" + positcov1beta1.WorkbenchExtensionCacheMountPath + "/vscode")
+		config.VsCodeExtensionsConf = nil
+	}
+	if config.Positron != nil {
+		config.Positron.Args = strings.TrimSpace(config.Positron.Args + " --extensions-dir=" + positcov1beta1.WorkbenchExtensionCacheMountPath + "/positron")
+		config.PositronExtensionsConf = nil
+	}
+}
+
+// reconcileExtensionCache runs the Job that installs the extensions of the Workbench into its extension cache,
+// and records the state of the Job in the status. A new Job replaces the previous one when the extension lists or
+// their source change. It returns the time until a failed Job runs again, or 0 when there is none
+func (r *WorkbenchReconciler) reconcileExtensionCache(ctx context.Context, req ctrl.Request, w *positcov1beta1.Workbench, now time.Time) (time.Duration, error) {
+	l := r.GetLogger(ctx).WithValues(
+		"event", "reconcile-extension-cache",
+		"product", "workbench",
+	)
+
+	cache := w.Spec.ExtensionCache
+	if !w.Spec.OffHostExecution {
+		// only the sessions of the Kubernetes launcher mount the cache
+		cache = nil
+	}
+
+	var status *positcov1beta1.WorkbenchExtensionCacheStatus
+	var retry time.Duration
+	if cache != nil {
+		hash, err := extensionCacheHash(w, cache)
+		if err != nil {
+			l.Error(err, "error computing extension cache hash")
+			return 0, err
+		}
+		var job *batchv1.Job
+		job, retry, err = r.ensureExtensionCacheJob(ctx, req, w, cache, hash, now)
+		if err != nil {
+			l.Error(err, "error ensuring extension cache job")
+			return 0, err
+		}
+		status = &positcov1beta1.WorkbenchExtensionCacheStatus{
+			Hash:  hash,
+			Job:   job.Name,
+			Phase: extensionCachePhase(job),
+		}
+	}
+
+	// the Jobs of previous extension lists, or of a cache that is gone
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs, client.InNamespace(req.Namespace), client.MatchingLabels{extensionCacheLabelKey: w.ComponentName()}); err != nil {
+		l.Error(err, "error listing extension cache jobs")
+		return 0, err
+	}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if status != nil && job.Name == status.Job {
+			continue
+		}
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !kerrors.IsNotFound(err) {
+			l.Error(err, "error deleting extension cache job", "job", job.Name)
+			return 0, err
+		}
+	}
+	if cache == nil {
+		key := client.ObjectKey{Name: extensionCacheConfigmapName(w), Namespace: req.Namespace}
+		if err := internal.BasicDelete(ctx, r, l, key, &corev1.ConfigMap{}); err != nil && !kerrors.IsNotFound(err) {
+			l.Error(err, "error cleaning up extension cache configmap")
+			return 0, err
+		}
+	}
+
+	if !extensionCacheStatusEqual(w.Status.ExtensionCache, status) {
+		w.Status.ExtensionCache = status
+		if err := r.Status().Update(ctx, w); err != nil {
+			l.Error(err, "error updating extension cache status")
+			return 0, err
+		}
+	}
+	return retry, nil
+}
+
+// ensureExtensionCacheJob creates the Job for hash, unless it exists. Jobs cannot change, so an existing Job is
+// left as it is, unless it failed at least extensionCacheRetryInterval ago and is replaced. It returns the time
+// until a failed Job is replaced, or 0
+func (r *WorkbenchReconciler) ensureExtensionCacheJob(ctx context.Context, req ctrl.Request, w *positcov1beta1.Workbench, cache *positcov1beta1.WorkbenchExtensionCache, hash string, now time.Time) (*batchv1.Job, time.Duration, error) {
+	l := r.GetLogger(ctx).WithValues(
+		"event", "ensure-extension-cache-job",
+		"product", "workbench",
+	)
+
+	scriptCm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      extensionCacheConfigmapName(w),
+			Namespace: req.Namespace,
+		},
+	}
+	if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, scriptCm, w, func() error {
+		scriptCm.Labels = w.KubernetesLabels()
+		scriptCm.Data = map[string]string{
+			"install-extensions.sh": installExtensionsSh,
+		}
+		return nil
+	}); err != nil {
+		return nil, 0, err
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-extensions-%s", w.ComponentName(), hash[:10]),
+			Namespace: req.Namespace,
+		},
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(job), job); err == nil {
+		failedAt, failed := extensionCacheJobFailedAt(job)
+		if !failed {
+			return job, 0, nil
+		}
+		if wait := failedAt.Add(extensionCacheRetryInterval).Sub(now); wait > 0 {
+			return job, wait, nil
+		}
+		l.Info("replacing failed extension cache job", "job", job.Name)
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !kerrors.IsNotFound(err) {
+			return nil, 0, err
+		}
+		// the Job can take a moment to go away
+		if err := r.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{}); err == nil {
+			return job, 5 * time.Second, nil
+		} else if !kerrors.IsNotFound(err) {
+			return nil, 0, err
+		}
+		job = &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      job.Name,
+				Namespace: job.Namespace,
+			},
+		}
+	} else if !kerrors.IsNotFound(err) {
+		return nil, 0, err
+	}
+
+	image := cache.Image
+	if image == "" {
+		image = w.Spec.Image
+	}
+	var pullSecrets []corev1.LocalObjectReference
+	for _, s := range w.Spec.ImagePullSecrets {
+		pullSecrets = append(pullSecrets, corev1.LocalObjectReference{Name: s})
+	}
+	sourceVolume, sourceSubPath := extensionCacheSourceVolume(cache.Source)
+
+	if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, job, w, func() error {
+		job.Labels = w.KubernetesLabels()
+		job.Labels[extensionCacheLabelKey] = w.ComponentName()
+		job.Spec = batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(3)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{extensionCacheLabelKey: w.ComponentName()},
+				},
+				Spec: corev1.PodSpec{
+					EnableServiceLinks: ptr.To(false),
+					RestartPolicy:      corev1.RestartPolicyOnFailure,
+					ImagePullSecrets:   pullSecrets,
+					NodeSelector:       w.Spec.NodeSelector,
+					Containers: []corev1.Container{
+						{
+							Name:            "install-extensions",
+							Image:           image,
+							ImagePullPolicy: w.Spec.ImagePullPolicy,
+							Command:         []string{"/bin/bash", "/scripts/install-extensions.sh"},
+							Env: []corev1.EnvVar{
+								{Name: "HASH", Value: hash[:10]},
+								{Name: "VSCODE_EXTENSIONS", Value: strings.Join(w.Spec.Config.VsCodeExtensionsConf, "\n")},
+								{Name: "POSITRON_EXTENSIONS", Value: strings.Join(w.Spec.Config.PositronExtensionsConf, "\n")},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "scripts", MountPath: "/scripts", ReadOnly: true},
+								{Name: "extension-cache", MountPath: positcov1beta1.WorkbenchExtensionCacheMountPath, SubPath: cache.SubPath},
+								{Name: "extension-source", MountPath: extensionCacheSourceMountPath, SubPath: sourceSubPath, ReadOnly: true},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "scripts",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: scriptCm.Name},
+									DefaultMode:          ptr.To(product.MustParseOctal("0644")),
+								},
+							},
+						},
+						{
+							Name: "extension-cache",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: cache.ClaimName},
+							},
+						},
+						{
+							Name:         "extension-source",
+							VolumeSource: sourceVolume,
+						},
+					},
+				},
+			},
+		}
+		w.Spec.SecurityProfile.HardenPod(&job.Spec.Template.Spec)
+		return nil
+	}); err != nil {
+		return nil, 0, err
+	}
+	return job, 0, nil
+}
+
+// extensionCacheSourceVolume returns the volume with the .vsix files, and the directory of it to mount
+func extensionCacheSourceVolume(source *positcov1beta1.WorkbenchExtensionSource) (corev1.VolumeSource, string) {
+	switch {
+	case source == nil:
+		return corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}, ""
+	case source.Image != "":
+		return corev1.VolumeSource{
+			Image: &corev1.ImageVolumeSource{Reference: source.Image, PullPolicy: corev1.PullIfNotPresent},
+		}, ""
+	case source.ConfigMapName != "":
+		return corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: source.ConfigMapName},
+			},
+		}, ""
+	default:
+		return corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: source.ClaimName, ReadOnly: true},
+		}, source.SubPath
+	}
+}
+
+// extensionCacheHash returns the hash of what the extension cache Job installs
+func extensionCacheHash(w *positcov1beta1.Workbench, cache *positcov1beta1.WorkbenchExtensionCache) (string, error) {
+	data := map[string]string{
+		"vscode":   strings.Join(w.Spec.Config.VsCodeExtensionsConf, "\n"),
+		"positron": strings.Join(w.Spec.Config.PositronExtensionsConf, "\n"),
+		"image":    cache.Image,
+		"subPath":  cache.SubPath,
+	}
+	if s := cache.Source; s != nil {
+		data["source"] = strings.Join([]string{s.Image, s.ConfigMapName, s.ClaimName, s.SubPath}, "\n")
+	}
+	return product.ComputeSha256(data)
+}
+
+// extensionCachePhase returns the phase of an extension cache Job
+func extensionCachePhase(job *batchv1.Job) positcov1beta1.WorkbenchExtensionCachePhase {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return positcov1beta1.WorkbenchExtensionCacheSucceeded
+		case batchv1.JobFailed:
+			return positcov1beta1.WorkbenchExtensionCacheFailed
+		}
+	}
+	if job.Status.Active > 0 {
+		return positcov1beta1.WorkbenchExtensionCacheRunning
+	}
+	return positcov1beta1.WorkbenchExtensionCachePending
+}
+
+// extensionCacheJobFailedAt returns when an extension cache Job failed, and false if it did not
+func extensionCacheJobFailedAt(job *batchv1.Job) (time.Time, bool) {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			return c.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func extensionCacheStatusEqual(a, b *positcov1beta1.WorkbenchExtensionCacheStatus) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// extensionCacheConfigmapName is the ConfigMap with the script of the extension cache Job
+func extensionCacheConfigmapName(w *positcov1beta1.Workbench) string {
+	return fmt.Sprintf("%s-extensions", w.ComponentName())
+}
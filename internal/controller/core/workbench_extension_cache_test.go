@@ -0,0 +1,150 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrl "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestApplyExtensionCache(t *testing.T) {
+	config := &positcov1beta1.WorkbenchConfig{
+		WorkbenchIniConfig: positcov1beta1.WorkbenchIniConfig{
+			VsCode: &positcov1beta1.WorkbenchVsCodeConfig{Args: "--host=0.0.0.0"},
+		},
+		WorkbenchSessionIniConfig: positcov1beta1.WorkbenchSessionIniConfig{
+			Positron: &positcov1beta1.WorkbenchPositronConfig{},
+		},
+		WorkbenchSessionNewlineConfig: positcov1beta1.WorkbenchSessionNewlineConfig{
+			VsCodeExtensionsConf:   []string{"quarto.quarto"},
+			PositronExtensionsConf: []string{"posit.shiny"},
+		},
+	}
+
+	// the sessions install the extensions until the Job has
+	for _, status := range []*positcov1beta1.WorkbenchExtensionCacheStatus{
+		nil,
+		{Phase: positcov1beta1.WorkbenchExtensionCacheRunning},
+		{Phase: positcov1beta1.WorkbenchExtensionCacheFailed},
+	} {
+		applyExtensionCache(config, status)
+		assert.Equal(t, "--host=0.0.0.0", config.VsCode.Args)
+		assert.Equal(t, []string{"quarto.quarto"}, config.VsCodeExtensionsConf)
+		assert.Equal(t, []string{"posit.shiny"}, config.PositronExtensionsConf)
+	}
+
+	succeeded := &positcov1beta1.WorkbenchExtensionCacheStatus{Phase: positcov1beta1.WorkbenchExtensionCacheSucceeded}
+	applyExtensionCache(config, succeeded)
+	assert.Equal(t, "--host=0.0.0.0 --extensions-dir=/mnt/extension-cache/vscode", config.VsCode.Args)
+	assert.Equal(t, "--extensions-dir=/mnt/extension-cache/positron", config.Positron.Args)
+	// sessions no longer install the extensions themselves
+	assert.Nil(t, config.VsCodeExtensionsConf)
+	assert.Nil(t, config.PositronExtensionsConf)
+
+	// an IDE without a config is left alone
+	config.Positron = nil
+	config.PositronExtensionsConf = []string{"posit.shiny"}
+	applyExtensionCache(config, succeeded)
+	assert.Nil(t, config.Positron)
+	assert.Equal(t, []string{"posit.shiny"}, config.PositronExtensionsConf)
+}
+
+func TestWorkbenchReconciler_ExtensionCache(t *testing.T) {
+	ctx := context.Background()
+	ns := "posit-team"
+	name := "workbench-extensions"
+
+	scheme := runtime.NewScheme()
+	loadSchemes(scheme)
+	cli := fakectrl.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&positcov1beta1.Workbench{}).
+		Build()
+	r := &WorkbenchReconciler{Client: cli, Scheme: scheme, Log: product.NewSimpleLogger()}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: name}}
+
+	wb := defineDefaultWorkbench(t, ns, name)
+	wb.Spec.OffHostExecution = true
+	wb.Spec.Config.VsCodeExtensionsConf = []string{"quarto.quarto", "ms-python.python@2024.2.1"}
+	wb.Spec.ExtensionCache = &positcov1beta1.WorkbenchExtensionCache{
+		ClaimName: "main-shared",
+		SubPath:   "workbench-extensions",
+		Source:    &positcov1beta1.WorkbenchExtensionSource{ClaimName: "vsix", SubPath: "bundle"},
+	}
+	require.NoError(t, cli.Create(ctx, wb))
+
+	_, err := r.reconcileExtensionCache(ctx, req, wb, time.Now())
+	require.NoError(t, err)
+	require.NotNil(t, wb.Status.ExtensionCache)
+	assert.Equal(t, positcov1beta1.WorkbenchExtensionCachePending, wb.Status.ExtensionCache.Phase)
+
+	job := &batchv1.Job{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: ns, Name: wb.Status.ExtensionCache.Job}, job))
+	container := job.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, wb.Spec.Image, container.Image)
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "VSCODE_EXTENSIONS", Value: "quarto.quarto\nms-python.python@2024.2.1"})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name: "extension-cache", MountPath: positcov1beta1.WorkbenchExtensionCacheMountPath, SubPath: "workbench-extensions",
+	})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name: "extension-source", MountPath: extensionCacheSourceMountPath, SubPath: "bundle", ReadOnly: true,
+	})
+
+	// the status follows the Job
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	require.NoError(t, cli.Status().Update(ctx, job))
+	_, err = r.reconcileExtensionCache(ctx, req, wb, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, positcov1beta1.WorkbenchExtensionCacheSucceeded, wb.Status.ExtensionCache.Phase)
+
+	// a failed Job is replaced after the retry interval
+	failedAt := time.Now().Truncate(time.Second)
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(failedAt)}}
+	require.NoError(t, cli.Status().Update(ctx, job))
+	retry, err := r.reconcileExtensionCache(ctx, req, wb, failedAt.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, extensionCacheRetryInterval-time.Minute, retry)
+	assert.Equal(t, positcov1beta1.WorkbenchExtensionCacheFailed, wb.Status.ExtensionCache.Phase)
+
+	retry, err = r.reconcileExtensionCache(ctx, req, wb, failedAt.Add(extensionCacheRetryInterval))
+	require.NoError(t, err)
+	assert.Zero(t, retry)
+	assert.Equal(t, positcov1beta1.WorkbenchExtensionCachePending, wb.Status.ExtensionCache.Phase)
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: ns, Name: wb.Status.ExtensionCache.Job}, job))
+	assert.Empty(t, job.Status.Conditions)
+
+	// a new extension list replaces the Job
+	previous := wb.Status.ExtensionCache
+	wb.Spec.Config.VsCodeExtensionsConf = append(wb.Spec.Config.VsCodeExtensionsConf, "posit.shiny")
+	_, err = r.reconcileExtensionCache(ctx, req, wb, time.Now())
+	require.NoError(t, err)
+	assert.NotEqual(t, previous.Hash, wb.Status.ExtensionCache.Hash)
+	assert.NotEqual(t, previous.Job, wb.Status.ExtensionCache.Job)
+	assert.Equal(t, positcov1beta1.WorkbenchExtensionCachePending, wb.Status.ExtensionCache.Phase)
+	jobs := &batchv1.JobList{}
+	require.NoError(t, cli.List(ctx, jobs, client.InNamespace(ns)))
+	require.Len(t, jobs.Items, 1)
+	assert.Equal(t, wb.Status.ExtensionCache.Job, jobs.Items[0].Name)
+
+	// without the cache, the Job and its script are removed
+	wb.Spec.ExtensionCache = nil
+	_, err = r.reconcileExtensionCache(ctx, req, wb, time.Now())
+	require.NoError(t, err)
+	assert.Nil(t, wb.Status.ExtensionCache)
+	require.NoError(t, cli.List(ctx, jobs, client.InNamespace(ns)))
+	assert.Empty(t, jobs.Items)
+	err = cli.Get(ctx, client.ObjectKey{Namespace: ns, Name: extensionCacheConfigmapName(wb)}, &corev1.ConfigMap{})
+	assert.Error(t, err)
+}
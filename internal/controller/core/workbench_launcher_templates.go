@@ -0,0 +1,127 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/templates"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// launcherTemplateKinds are the launcher templates, and the kind of object that each renders
+var launcherTemplateKinds = []struct {
+	name string
+	kind string
+}{
+	{"job.tpl", "Job"},
+	{"service.tpl", "Service"},
+}
+
+// launcherTemplates returns the job.tpl and service.tpl of the Workbench. Templates of the ConfigMap replace the
+// bundled ones if they render a sample session with templateData; the LauncherTemplatesValid condition records
+// the outcome
+func (r *WorkbenchReconciler) launcherTemplates(ctx context.Context, req ctrl.Request, w *positcov1beta1.Workbench, templateData string) (map[string]string, error) {
+	l := r.GetLogger(ctx).WithValues(
+		"event", "launcher-templates",
+		"product", "workbench",
+	)
+
+	lt := w.Spec.LauncherTemplates
+	if lt == nil {
+		lt = &positcov1beta1.WorkbenchLauncherTemplates{}
+	}
+	version := lt.Version
+	switch version {
+	case "":
+		version = templates.LatestLauncherTemplateVersion
+	case positcov1beta1.LauncherTemplateVersionAuto:
+		version = templates.LauncherTemplateVersionForImage(w.Spec.Image)
+	}
+
+	tpls := map[string]string{}
+	for _, t := range launcherTemplateKinds {
+		tpl, err := templates.LauncherTemplate(version, t.name)
+		if err != nil {
+			l.Error(err, "error loading bundled launcher template", "version", version)
+			return nil, err
+		}
+		tpls[t.name] = tpl
+	}
+
+	if lt.ConfigMapName == "" {
+		meta.RemoveStatusCondition(&w.Status.Conditions, positcov1beta1.WorkbenchConditionLauncherTemplatesValid)
+		return tpls, nil
+	}
+
+	condition := metav1.Condition{
+		Type:               positcov1beta1.WorkbenchConditionLauncherTemplatesValid,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: w.Generation,
+		Reason:             "Rendered",
+	}
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: lt.ConfigMapName}, cm); kerrors.IsNotFound(err) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ConfigMapNotFound"
+		condition.Message = fmt.Sprintf("ConfigMap %s does not exist; using the bundled %s templates", lt.ConfigMapName, version)
+		meta.SetStatusCondition(&w.Status.Conditions, condition)
+		return tpls, nil
+	} else if err != nil {
+		l.Error(err, "error getting launcher templates configmap", "configmap", lt.ConfigMapName)
+		return nil, err
+	}
+
+	var rendered, failed []string
+	for _, t := range launcherTemplateKinds {
+		tpl, ok := cm.Data[t.name]
+		if !ok {
+			continue
+		}
+		if err := templates.ValidateLauncherTemplate(tpl, templateData, t.kind); err != nil {
+			l.Info("launcher template does not render; using the bundled one", "configmap", lt.ConfigMapName, "template", t.name, "error", err.Error())
+			failed = append(failed, fmt.Sprintf("%s: %s", t.name, err))
+			continue
+		}
+		tpls[t.name] = tpl
+		rendered = append(rendered, t.name)
+	}
+
+	switch {
+	case len(failed) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "RenderFailed"
+		condition.Message = fmt.Sprintf("using the bundled %s templates instead of ConfigMap %s: %s", version, lt.ConfigMapName, strings.Join(failed, "; "))
+	case len(rendered) == 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "NoTemplates"
+		condition.Message = fmt.Sprintf("ConfigMap %s has no job.tpl or service.tpl; using the bundled %s templates", lt.ConfigMapName, version)
+	default:
+		condition.Message = fmt.Sprintf("%s of ConfigMap %s render a sample session", strings.Join(rendered, " and "), lt.ConfigMapName)
+	}
+	meta.SetStatusCondition(&w.Status.Conditions, condition)
+	return tpls, nil
+}
+
+// workbenchesForLauncherTemplates enqueues the Workbenches whose launcher templates are in a ConfigMap
+func (r *WorkbenchReconciler) workbenchesForLauncherTemplates(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &positcov1beta1.WorkbenchList{}
+	if err := r.List(ctx, list, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.GetLogger(ctx).Error(err, "error listing workbenches for launcher templates")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, w := range list.Items {
+		if lt := w.Spec.LauncherTemplates; lt != nil && lt.ConfigMapName == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&w)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/posit-dev/team-operator/api/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	fakectrl "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWorkbenchReconciler_LauncherTemplates(t *testing.T) {
+	ctx := context.Background()
+	ns := "posit-team"
+	name := "workbench-templates"
+
+	scheme := runtime.NewScheme()
+	loadSchemes(scheme)
+	cli := fakectrl.NewClientBuilder().WithScheme(scheme).Build()
+	r := &WorkbenchReconciler{Client: cli, Scheme: scheme, Log: product.NewSimpleLogger()}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: name}}
+
+	wb := defineDefaultWorkbench(t, ns, name)
+	wb.Spec.OffHostExecution = true
+	wb.Spec.Image = "rstudio/rstudio-workbench:ubuntu2204-2024.04.2"
+	templateData := wb.SessionConfigTemplateData(r.Log, &wb.Spec.Config)
+
+	// the latest bundled templates by default
+	tpls, err := r.launcherTemplates(ctx, req, wb, templateData)
+	require.NoError(t, err)
+	assert.Equal(t, templates.DumpJobTpl(), tpls["job.tpl"])
+	assert.Empty(t, wb.Status.Conditions)
+
+	// ... or those of the release in the image tag
+	wb.Spec.LauncherTemplates = &positcov1beta1.WorkbenchLauncherTemplates{Version: positcov1beta1.LauncherTemplateVersionAuto}
+	tpls, err = r.launcherTemplates(ctx, req, wb, templateData)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(tpls["job.tpl"], "# Version: 2.4.0"))
+	assert.True(t, strings.HasPrefix(tpls["service.tpl"], "# Version: 2.4.0"))
+
+	// a missing ConfigMap keeps the bundled templates
+	wb.Spec.LauncherTemplates.ConfigMapName = "custom-templates"
+	_, err = r.launcherTemplates(ctx, req, wb, templateData)
+	require.NoError(t, err)
+	condition := meta.FindStatusCondition(wb.Status.Conditions, positcov1beta1.WorkbenchConditionLauncherTemplatesValid)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "ConfigMapNotFound", condition.Reason)
+
+	// a template that renders replaces the bundled one
+	job, err := templates.LauncherTemplate("2.4.0", "job.tpl")
+	require.NoError(t, err)
+	customJob := strings.Replace(job, "      containers:\n", "      containers:\n        - name: sidecar\n          image: busybox\n", 1)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom-templates", Namespace: ns},
+		Data:       map[string]string{"job.tpl": customJob},
+	}
+	require.NoError(t, cli.Create(ctx, cm))
+	tpls, err = r.launcherTemplates(ctx, req, wb, templateData)
+	require.NoError(t, err)
+	assert.Equal(t, customJob, tpls["job.tpl"])
+	assert.True(t, strings.HasPrefix(tpls["service.tpl"], "# Version: 2.4.0"))
+	condition = meta.FindStatusCondition(wb.Status.Conditions, positcov1beta1.WorkbenchConditionLauncherTemplatesValid)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+
+	// ... and one that does not is reported
+	cm.Data["service.tpl"] = "kind: Service\nspec: {{ .Job.ports"
+	require.NoError(t, cli.Update(ctx, cm))
+	tpls, err = r.launcherTemplates(ctx, req, wb, templateData)
+	require.NoError(t, err)
+	assert.Equal(t, customJob, tpls["job.tpl"])
+	assert.True(t, strings.HasPrefix(tpls["service.tpl"], "# Version: 2.4.0"))
+	condition = meta.FindStatusCondition(wb.Status.Conditions, positcov1beta1.WorkbenchConditionLauncherTemplatesValid)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "RenderFailed", condition.Reason)
+	assert.Contains(t, condition.Message, "service.tpl: parsing template")
+
+	// the condition goes away with the ConfigMap setting
+	wb.Spec.LauncherTemplates.ConfigMapName = ""
+	_, err = r.launcherTemplates(ctx, req, wb, templateData)
+	require.NoError(t, err)
+	assert.Empty(t, wb.Status.Conditions)
+}
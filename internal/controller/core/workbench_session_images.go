@@ -0,0 +1,212 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/internal/registry"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:namespace=posit-team,groups=core.posit.team,resources=sessionimages,verbs=get;list;watch
+//+kubebuilder:rbac:namespace=posit-team,groups=core.posit.team,resources=sessionimages/status,verbs=get;update;patch
+
+// reconcileSessionImages adds the SessionImages that the Workbench selects to its config and lists its site in the
+// status of the images that it offers, along with the runtime versions that the labels of the images list. It returns
+// the time until the next selected image is retired or a registry that could not be read is read again, or 0
+func (r *WorkbenchReconciler) reconcileSessionImages(ctx context.Context, req ctrl.Request, w *positcov1beta1.Workbench, now time.Time) (time.Duration, error) {
+	l := r.GetLogger(ctx).WithValues(
+		"event", "reconcile-session-images",
+		"product", "workbench",
+	)
+
+	all, err := r.listSessionImages(ctx, req.Namespace)
+	if err != nil {
+		l.Error(err, "error listing session images")
+		return 0, err
+	}
+
+	var selected []positcov1beta1.SessionImage
+	if w.Spec.SessionImageSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(w.Spec.SessionImageSelector)
+		if err != nil {
+			l.Error(err, "invalid session image selector")
+			return 0, err
+		}
+		for _, si := range all {
+			if selector.Matches(labels.Set(si.Labels)) {
+				selected = append(selected, si)
+			}
+		}
+	}
+
+	offered := applySessionImages(&w.Spec.Config, selected, now)
+	discovered, nextDiscovery := r.discoverSessionImageVersions(ctx, l, w, offered)
+	if err := r.updateSessionImageStatus(ctx, w, all, offered, discovered); err != nil {
+		l.Error(err, "error updating session image status")
+		return 0, err
+	}
+	return shorterRetry(nextSessionImageRetirement(selected, now), nextDiscovery), nil
+}
+
+// applySessionImages adds the images that are not retired to the profile for all users and to the Positron
+// images. The first default image, by name, replaces the default of the IDEs it supports. It returns the images
+// that were added
+func applySessionImages(config *positcov1beta1.WorkbenchConfig, images []positcov1beta1.SessionImage, now time.Time) []positcov1beta1.SessionImage {
+	images = slices.Clone(images)
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Name < images[j].Name
+	})
+
+	var offered []positcov1beta1.SessionImage
+	defaultSet, positronDefaultSet := false, false
+	for _, si := range images {
+		if si.Retired(now) {
+			continue
+		}
+		offered = append(offered, si)
+
+		if si.Supports(positcov1beta1.SessionIDERStudio) || si.Supports(positcov1beta1.SessionIDEJupyter) || si.Supports(positcov1beta1.SessionIDEVSCode) {
+			if config.LauncherKubernetesProfiles == nil {
+				config.LauncherKubernetesProfiles = map[string]positcov1beta1.WorkbenchLauncherKubernetesProfilesConfigSection{}
+			}
+			all := config.LauncherKubernetesProfiles["*"]
+			if !slices.Contains(all.ContainerImages, si.Spec.Image) {
+				all.ContainerImages = append(slices.Clone(all.ContainerImages), si.Spec.Image)
+			}
+			if si.Spec.Default && !defaultSet {
+				all.DefaultContainerImage = si.Spec.Image
+				defaultSet = true
+			}
+			config.LauncherKubernetesProfiles["*"] = all
+		}
+
+		if si.Supports(positcov1beta1.SessionIDEPositron) {
+			if config.Positron == nil {
+				config.Positron = &positcov1beta1.WorkbenchPositronConfig{}
+			}
+			if !slices.Contains(config.Positron.SessionContainerImages, si.Spec.Image) {
+				config.Positron.SessionContainerImages = append(slices.Clone(config.Positron.SessionContainerImages), si.Spec.Image)
+			}
+			if si.Spec.Default && !positronDefaultSet {
+				config.Positron.DefaultSessionContainerImage = si.Spec.Image
+				positronDefaultSet = true
+			}
+		}
+	}
+	return offered
+}
+
+// nextSessionImageRetirement returns the time until the next of images is retired, or 0 when none will be
+func nextSessionImageRetirement(images []positcov1beta1.SessionImage, now time.Time) time.Duration {
+	var next time.Duration
+	for _, si := range images {
+		t, ok := si.Deprecation()
+		if !ok || !t.After(now) {
+			continue
+		}
+		if d := t.Sub(now); next == 0 || d < next {
+			next = d
+		}
+	}
+	return next
+}
+
+// discoverSessionImageVersions returns the status of the runtime versions that the labels of the images list, by
+// image name, for the images whose spec lists no versions. An image whose registry cannot be read keeps the versions
+// that its status lists. It also returns the time until a registry that could not be read is read again, or 0
+func (r *WorkbenchReconciler) discoverSessionImageVersions(ctx context.Context, l logr.Logger, w *positcov1beta1.Workbench, images []positcov1beta1.SessionImage) (map[string]positcov1beta1.SessionImageStatus, time.Duration) {
+	discovers := slices.ContainsFunc(images, func(si positcov1beta1.SessionImage) bool {
+		return si.DiscoversRuntimeVersions()
+	})
+	if r.RuntimeVersions == nil || !discovers {
+		return nil, 0
+	}
+
+	keychain := imagePullKeychain(ctx, l, r, w.Namespace, w.Spec.ImagePullSecrets)
+	discovered := map[string]positcov1beta1.SessionImageStatus{}
+	var retryAfter time.Duration
+	for _, si := range images {
+		if !si.DiscoversRuntimeVersions() {
+			continue
+		}
+		versions, err := r.RuntimeVersions.Discover(ctx, si.Spec.Image, keychain)
+		status := positcov1beta1.SessionImageStatus{
+			Digest:         versions.Digest,
+			RVersions:      versions.R,
+			PythonVersions: versions.Python,
+			QuartoVersions: versions.Quarto,
+		}
+		if err != nil {
+			l.Error(err, "error discovering the runtime versions of session image", "image", si.Spec.Image)
+			var lookupErr *registry.LookupError
+			if errors.As(err, &lookupErr) {
+				// the registry could not be read, so the image keeps the versions that were last discovered
+				status = *si.Status.DeepCopy()
+				retryAfter = shorterRetry(retryAfter, lookupErr.RetryAfter)
+			}
+			status.RuntimeVersionsWarning = err.Error()
+		}
+		discovered[si.Name] = status
+	}
+	return discovered, retryAfter
+}
+
+// updateSessionImageStatus lists the Workbench's site in the status of the offered images and removes it from the
+// others. The images in discovered get their discovered runtime versions, and the images whose spec lists versions
+// lose theirs
+func (r *WorkbenchReconciler) updateSessionImageStatus(ctx context.Context, w *positcov1beta1.Workbench, all, offered []positcov1beta1.SessionImage, discovered map[string]positcov1beta1.SessionImageStatus) error {
+	for _, si := range all {
+		status := si.Status.DeepCopy()
+
+		used := slices.ContainsFunc(offered, func(o positcov1beta1.SessionImage) bool {
+			return o.Name == si.Name
+		})
+		if used && !slices.Contains(si.Status.Sites, w.SiteName()) {
+			si.Status.Sites = append(si.Status.Sites, w.SiteName())
+			sort.Strings(si.Status.Sites)
+		} else if !used {
+			si.Status.Sites = slices.DeleteFunc(si.Status.Sites, func(s string) bool {
+				return s == w.SiteName()
+			})
+		}
+
+		if d, ok := discovered[si.Name]; ok {
+			d.Sites = si.Status.Sites
+			si.Status = d
+		} else if !si.DiscoversRuntimeVersions() {
+			// the spec lists the versions of the image
+			si.Status = positcov1beta1.SessionImageStatus{Sites: si.Status.Sites}
+		}
+
+		if equality.Semantic.DeepEqual(status, &si.Status) {
+			continue
+		}
+		if err := r.Status().Update(ctx, &si); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listSessionImages lists the SessionImages in the namespace. A cluster without the SessionImage CRD has none
+func (r *WorkbenchReconciler) listSessionImages(ctx context.Context, namespace string) ([]positcov1beta1.SessionImage, error) {
+	list := &positcov1beta1.SessionImageList{}
+	if err := r.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,208 @@
+package core
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/posit-dev/team-operator/internal/registry"
+	"github.com/posit-dev/team-operator/internal/registry/registrytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrl "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func sessionImage(name, image string, ides ...positcov1beta1.SessionIDE) positcov1beta1.SessionImage {
+	return positcov1beta1.SessionImage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "posit-team",
+			Labels:    map[string]string{"channel": "stable"},
+		},
+		Spec: positcov1beta1.SessionImageSpec{Image: image, IDEs: ides},
+	}
+}
+
+func TestApplySessionImages(t *testing.T) {
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	r := sessionImage("r", "r-image", positcov1beta1.SessionIDERStudio)
+	r.Spec.Default = true
+	positron := sessionImage("positron", "positron-image", positcov1beta1.SessionIDEPositron)
+	positron.Spec.Default = true
+	all := sessionImage("all", "all-image")
+	all.Spec.Default = true
+	retired := sessionImage("retired", "retired-image")
+	retired.Spec.DeprecationDate = "2026-07-01"
+	upcoming := sessionImage("upcoming", "upcoming-image", positcov1beta1.SessionIDEJupyter)
+	upcoming.Spec.DeprecationDate = "2026-07-03"
+
+	config := &positcov1beta1.WorkbenchConfig{
+		WorkbenchProfilesConfig: positcov1beta1.WorkbenchProfilesConfig{
+			LauncherKubernetesProfiles: map[string]positcov1beta1.WorkbenchLauncherKubernetesProfilesConfigSection{
+				"*": {ContainerImages: []string{"site-image", "r-image"}, DefaultContainerImage: "site-image"},
+			},
+		},
+	}
+	images := []positcov1beta1.SessionImage{upcoming, r, retired, positron, all}
+	offered := applySessionImages(config, images, now)
+
+	var offeredNames []string
+	for _, si := range offered {
+		offeredNames = append(offeredNames, si.Name)
+	}
+	assert.Equal(t, []string{"all", "positron", "r", "upcoming"}, offeredNames)
+
+	// images are added by name, once, and "all" is the first default
+	profile := config.LauncherKubernetesProfiles["*"]
+	assert.Equal(t, []string{"site-image", "r-image", "all-image", "upcoming-image"}, profile.ContainerImages)
+	assert.Equal(t, "all-image", profile.DefaultContainerImage)
+	assert.Equal(t, []string{"all-image", "positron-image"}, config.Positron.SessionContainerImages)
+	assert.Equal(t, "all-image", config.Positron.DefaultSessionContainerImage)
+
+	assert.Equal(t, 36*time.Hour, nextSessionImageRetirement(images, now))
+	assert.Zero(t, nextSessionImageRetirement([]positcov1beta1.SessionImage{r, retired}, now))
+}
+
+func TestWorkbenchReconciler_SessionImages(t *testing.T) {
+	ctx := context.Background()
+	ns := "posit-team"
+	name := "workbench-session-images"
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	scheme := runtime.NewScheme()
+	loadSchemes(scheme)
+	cli := fakectrl.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&positcov1beta1.SessionImage{}).
+		Build()
+	r := &WorkbenchReconciler{Client: cli, Scheme: scheme, Log: product.NewSimpleLogger()}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: name}}
+
+	stable := sessionImage("stable", "stable-image")
+	stable.Spec.Default = true
+	stable.Spec.DeprecationDate = "2026-07-02"
+	require.NoError(t, cli.Create(ctx, &stable))
+	beta := sessionImage("beta", "beta-image", positcov1beta1.SessionIDEPositron)
+	beta.Labels["channel"] = "beta"
+	require.NoError(t, cli.Create(ctx, &beta))
+	beta.Status.Sites = []string{"other", name}
+	require.NoError(t, cli.Status().Update(ctx, &beta))
+
+	wb := defineDefaultWorkbench(t, ns, name)
+	wb.Spec.SessionImageSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"channel": "stable"}}
+
+	nextRetirement, err := r.reconcileSessionImages(ctx, req, wb, now)
+	require.NoError(t, err)
+	assert.Equal(t, 12*time.Hour, nextRetirement)
+
+	profile := wb.Spec.Config.LauncherKubernetesProfiles["*"]
+	assert.Equal(t, []string{"stable-image"}, profile.ContainerImages)
+	assert.Equal(t, "stable-image", profile.DefaultContainerImage)
+	assert.Equal(t, []string{"stable-image"}, wb.Spec.Config.Positron.SessionContainerImages)
+
+	// the status lists the sites that offer the image
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(&stable), &stable))
+	assert.Equal(t, []string{name}, stable.Status.Sites)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(&beta), &beta))
+	assert.Equal(t, []string{"other"}, beta.Status.Sites)
+
+	// a retired image is no longer offered
+	wb = defineDefaultWorkbench(t, ns, name)
+	wb.Spec.SessionImageSelector = &metav1.LabelSelector{}
+	nextRetirement, err = r.reconcileSessionImages(ctx, req, wb, now.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, nextRetirement)
+	assert.Empty(t, wb.Spec.Config.LauncherKubernetesProfiles)
+	assert.Equal(t, []string{"beta-image"}, wb.Spec.Config.Positron.SessionContainerImages)
+
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(&stable), &stable))
+	assert.Empty(t, stable.Status.Sites)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(&beta), &beta))
+	assert.Equal(t, []string{"other", name}, beta.Status.Sites)
+}
+
+func TestWorkbenchReconciler_SessionImageRuntimeVersions(t *testing.T) {
+	ctx := context.Background()
+	ns := "posit-team"
+	name := "workbench-session-image-runtimes"
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	srv := httptest.NewServer(registrytest.New())
+	defer srv.Close()
+	host := registrytest.Host(srv)
+	digest := registrytest.PushImage(t, host, "session", "labeled", registrytest.Image(t, map[string]string{
+		registry.RVersionsLabel:      "4.4.2",
+		registry.PythonVersionsLabel: "3.12.8,3.11.11",
+		registry.QuartoVersionsLabel: "1.6.39",
+	}))
+	registrytest.PushImage(t, host, "session", "unlabeled", registrytest.Image(t, nil))
+
+	scheme := runtime.NewScheme()
+	loadSchemes(scheme)
+	cli := fakectrl.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&positcov1beta1.SessionImage{}).
+		Build()
+	r := &WorkbenchReconciler{
+		Client:          cli,
+		Scheme:          scheme,
+		Log:             product.NewSimpleLogger(),
+		RuntimeVersions: registry.NewDiscoverer(registry.NewClient(srv.Client().Transport)),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: name}}
+
+	labeled := sessionImage("labeled", host+"/session:labeled")
+	require.NoError(t, cli.Create(ctx, &labeled))
+	unlabeled := sessionImage("unlabeled", host+"/session:unlabeled")
+	require.NoError(t, cli.Create(ctx, &unlabeled))
+	listed := sessionImage("listed", host+"/session:missing")
+	listed.Spec.RVersions = []string{"4.3.3"}
+	require.NoError(t, cli.Create(ctx, &listed))
+	listed.Status.RVersions = []string{"stale"}
+	require.NoError(t, cli.Status().Update(ctx, &listed))
+
+	wb := defineDefaultWorkbench(t, ns, name)
+	wb.Spec.SessionImageSelector = &metav1.LabelSelector{}
+	_, err := r.reconcileSessionImages(ctx, req, wb, now)
+	require.NoError(t, err)
+
+	// the labels of the image list its runtime versions
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(&labeled), &labeled))
+	assert.Equal(t, positcov1beta1.SessionImageStatus{
+		Sites:          []string{name},
+		Digest:         digest.String(),
+		RVersions:      []string{"4.4.2"},
+		PythonVersions: []string{"3.12.8", "3.11.11"},
+		QuartoVersions: []string{"1.6.39"},
+	}, labeled.Status)
+
+	// an image without labels is reported
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(&unlabeled), &unlabeled))
+	assert.NotEmpty(t, unlabeled.Status.Digest)
+	assert.Empty(t, unlabeled.Status.RVersions)
+	assert.Equal(t, "image has no runtime version labels: "+host+"/session:unlabeled", unlabeled.Status.RuntimeVersionsWarning)
+
+	// and an image whose spec lists versions is not read
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(&listed), &listed))
+	assert.Equal(t, positcov1beta1.SessionImageStatus{Sites: []string{name}}, listed.Status)
+
+	// an image whose registry cannot be read keeps the versions that were last discovered, and is read again
+	srv.Close()
+	r.RuntimeVersions = registry.NewDiscoverer(registry.NewClient(srv.Client().Transport))
+	retryAfter, err := r.reconcileSessionImages(ctx, req, wb, now)
+	require.NoError(t, err)
+	assert.Positive(t, retryAfter)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(&labeled), &labeled))
+	assert.Equal(t, digest.String(), labeled.Status.Digest)
+	assert.Equal(t, []string{"4.4.2"}, labeled.Status.RVersions)
+	assert.Contains(t, labeled.Status.RuntimeVersionsWarning, host+"/session:labeled")
+}
@@ -0,0 +1,472 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/posit-dev/team-operator/internal"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	secretstorev1 "sigs.k8s.io/secrets-store-csi-driver/apis/v1"
+)
+
+// The session namespace is only known at runtime, so the objects that the operator creates there need cluster-wide
+// permissions, which the default ClusterRole does not grant. They are in the opt-in session-namespace-role of
+// config/rbac/session_namespace_role.yaml and of the chart's rbac.sessionNamespaces value.
+
+// sessionNamespaceClient reads from the API server rather than from the cache of the manager, which only holds the
+// namespaces that the operator watches
+type sessionNamespaceClient struct {
+	client.Client
+	reader client.Reader
+	log    logr.Logger
+}
+
+// newSessionNamespaceClient reads through reader, or through c when reader is nil (i.e. without a manager)
+func newSessionNamespaceClient(c client.Client, reader client.Reader, l logr.Logger) *sessionNamespaceClient {
+	if reader == nil {
+		reader = c
+	}
+	return &sessionNamespaceClient{Client: c, reader: reader, log: l}
+}
+
+func (c *sessionNamespaceClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.reader.Get(ctx, key, obj, opts...)
+}
+
+func (c *sessionNamespaceClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
+func (c *sessionNamespaceClient) GetLogger(ctx context.Context) logr.Logger {
+	return c.log
+}
+
+// sessionClient is the client for the objects in the session namespace of the Workbench
+func (r *WorkbenchReconciler) sessionClient(ctx context.Context, w *positcov1beta1.Workbench) *sessionNamespaceClient {
+	var reader client.Reader
+	if w.Spec.SessionNamespace != "" {
+		reader = r.APIReader
+	}
+	return newSessionNamespaceClient(r.Client, reader, r.GetLogger(ctx))
+}
+
+// sessionRequest is the request of the Workbench in its session namespace
+func sessionRequest(req ctrl.Request, w *positcov1beta1.Workbench) ctrl.Request {
+	sessionReq := req
+	sessionReq.Namespace = w.SessionNamespace()
+	return sessionReq
+}
+
+// sessionNamespaceLabels are the labels of the objects that the Workbench creates in its session namespace, which
+// cannot be owned by the Workbench
+func sessionNamespaceLabels(w *positcov1beta1.Workbench) map[string]string {
+	return product.LabelMerge(w.KubernetesLabels(), map[string]string{
+		positcov1beta1.OwnerNamespaceLabelKey: w.Namespace,
+	})
+}
+
+// sessionNamespaceSelector selects the objects that a Workbench created outside of its namespace
+func sessionNamespaceSelector(namespace, componentName string) client.MatchingLabels {
+	return client.MatchingLabels{
+		positcov1beta1.ManagedByLabelKey:          positcov1beta1.ManagedByLabelValue,
+		positcov1beta1.KubernetesInstanceLabelKey: componentName,
+		positcov1beta1.OwnerNamespaceLabelKey:     namespace,
+	}
+}
+
+// sessionPersistentVolumeName is the name of the PersistentVolume that gives a claim of the Workbench a twin in the
+// session namespace
+func sessionPersistentVolumeName(w *positcov1beta1.Workbench, claimName string) string {
+	return fmt.Sprintf("%s-%s", w.SessionNamespace(), claimName)
+}
+
+// sessionObjectReferences are the ConfigMaps, Secrets, claims and SecretProviderClasses that sessions refer to
+type sessionObjectReferences struct {
+	configMaps            map[string]bool
+	secrets               map[string]bool
+	claims                map[string]bool
+	secretProviderClasses map[string]bool
+	persistentVolumeNames map[string]bool
+}
+
+// referencedSessionObjects collects the objects that the volumes, image pull secrets and environment of the
+// sessions refer to
+func referencedSessionObjects(w *positcov1beta1.Workbench, cfg *positcov1beta1.WorkbenchConfig) sessionObjectReferences {
+	refs := sessionObjectReferences{
+		configMaps:            map[string]bool{},
+		secrets:               map[string]bool{},
+		claims:                map[string]bool{},
+		secretProviderClasses: map[string]bool{},
+		persistentVolumeNames: map[string]bool{},
+	}
+
+	volumes := w.CreateSessionVolumeFactory(cfg).Volumes()
+	var env []corev1.EnvVar
+	if s := w.Spec.SessionConfig; s != nil && s.Pod != nil {
+		volumes = append(volumes, s.Pod.Volumes...)
+		env = s.Pod.Env
+		for _, s := range s.Pod.ImagePullSecrets {
+			refs.secrets[s.Name] = true
+		}
+	}
+	pods, _ := cfg.ResourceProfilePods()
+	for _, pod := range pods {
+		volumes = append(volumes, pod.Volumes...)
+	}
+
+	for _, v := range volumes {
+		switch {
+		case v.ConfigMap != nil:
+			refs.configMaps[v.ConfigMap.Name] = true
+		case v.Secret != nil:
+			refs.secrets[v.Secret.SecretName] = true
+		case v.PersistentVolumeClaim != nil:
+			refs.claims[v.PersistentVolumeClaim.ClaimName] = true
+			refs.persistentVolumeNames[sessionPersistentVolumeName(w, v.PersistentVolumeClaim.ClaimName)] = true
+		case v.CSI != nil && v.CSI.VolumeAttributes["secretProviderClass"] != "":
+			refs.secretProviderClasses[v.CSI.VolumeAttributes["secretProviderClass"]] = true
+		case v.Projected != nil:
+			for _, s := range v.Projected.Sources {
+				if s.ConfigMap != nil {
+					refs.configMaps[s.ConfigMap.Name] = true
+				}
+				if s.Secret != nil {
+					refs.secrets[s.Secret.Name] = true
+				}
+			}
+		}
+	}
+
+	for _, e := range env {
+		if e.ValueFrom == nil {
+			continue
+		}
+		if e.ValueFrom.ConfigMapKeyRef != nil {
+			refs.configMaps[e.ValueFrom.ConfigMapKeyRef.Name] = true
+		}
+		if e.ValueFrom.SecretKeyRef != nil {
+			refs.secrets[e.ValueFrom.SecretKeyRef.Name] = true
+		}
+	}
+	return refs
+}
+
+// sessionNamespaceForbidden explains an error of the operator lacking the opt-in permissions of session namespaces
+func sessionNamespaceForbidden(err error, namespace string) error {
+	if kerrors.IsForbidden(err) {
+		return fmt.Errorf("the operator needs the session-namespace-role, and the session-namespace-manager-role in session namespace %q (see --session-namespace-role): %w", namespace, err)
+	}
+	return err
+}
+
+// sharedClaim is true when the volume of claim can be mounted by the server and the sessions at once, i.e. it is
+// ReadWriteMany or NFS
+func sharedClaim(claim *corev1.PersistentVolumeClaim, volume *corev1.PersistentVolume) bool {
+	return slices.Contains(claim.Spec.AccessModes, corev1.ReadWriteMany) || volume.Spec.NFS != nil
+}
+
+// reconcileSessionNamespace creates the launcher Role in the session namespace of the Workbench, and copies what
+// sessions refer to there. Claims are copied as a claim on a twin of their PersistentVolume, so they must be bound
+// and shared; the SessionNamespaceReady condition lists the claims that are not. Copies that sessions no longer
+// refer to are removed
+func (r *WorkbenchReconciler) reconcileSessionNamespace(ctx context.Context, req ctrl.Request, w *positcov1beta1.Workbench, cfg *positcov1beta1.WorkbenchConfig) error {
+	l := r.GetLogger(ctx).WithValues(
+		"event", "reconcile-session-namespace",
+		"session_namespace", w.SessionNamespace(),
+	)
+	c := r.sessionClient(ctx, w)
+
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: w.SessionNamespace()}, ns); err != nil {
+		l.Error(err, "error getting session namespace")
+		return err
+	}
+
+	// the launcher of the Workbench starts the sessions there; it no longer needs a Role in its own namespace
+	if err := internal.GenerateLauncherRbac(ctx, c, r.Scheme, w.SessionNamespace(), w); err != nil {
+		l.Error(err, "error generating launcher rbac")
+		return sessionNamespaceForbidden(err, w.SessionNamespace())
+	}
+	localKey := client.ObjectKey{Name: w.ComponentName(), Namespace: req.Namespace}
+	if err := internal.BasicDelete(ctx, r, l, localKey, &rbacv1.RoleBinding{}); err != nil {
+		return err
+	}
+	if err := internal.BasicDelete(ctx, r, l, localKey, &rbacv1.Role{}); err != nil {
+		return err
+	}
+
+	refs := referencedSessionObjects(w, cfg)
+	for name := range refs.configMaps {
+		if err := r.copyConfigMap(ctx, c, l, w, name); err != nil {
+			return err
+		}
+	}
+	for name := range refs.secrets {
+		if err := r.copySecret(ctx, c, l, w, name); err != nil {
+			return err
+		}
+	}
+	for name := range refs.secretProviderClasses {
+		if err := r.copySecretProviderClass(ctx, c, l, w, name); err != nil {
+			return err
+		}
+	}
+	var unshared []string
+	for name := range refs.claims {
+		shared, err := r.copyClaim(ctx, c, l, w, name)
+		if err != nil {
+			return err
+		}
+		if !shared {
+			unshared = append(unshared, name)
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               positcov1beta1.WorkbenchConditionSessionNamespaceReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: w.Generation,
+		Reason:             "Copied",
+		Message:            fmt.Sprintf("sessions can mount what they refer to in namespace %s", w.SessionNamespace()),
+	}
+	if len(unshared) > 0 {
+		slices.Sort(unshared)
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ClaimNotShared"
+		condition.Message = fmt.Sprintf("claims that are neither ReadWriteMany nor NFS are not copied to namespace %s: %s", w.SessionNamespace(), strings.Join(unshared, ", "))
+	}
+	meta.SetStatusCondition(&w.Status.Conditions, condition)
+
+	selector := sessionNamespaceSelector(w.Namespace, w.ComponentName())
+	inNamespace := client.InNamespace(w.SessionNamespace())
+	if err := pruneSessionObjects(ctx, c, l, &corev1.ConfigMapList{}, refs.configMaps, inNamespace, selector); err != nil {
+		return err
+	}
+	if err := pruneSessionObjects(ctx, c, l, &corev1.SecretList{}, refs.secrets, inNamespace, selector); err != nil {
+		return err
+	}
+	if err := pruneSessionObjects(ctx, c, l, &secretstorev1.SecretProviderClassList{}, refs.secretProviderClasses, inNamespace, selector); err != nil && !meta.IsNoMatchError(err) {
+		return err
+	}
+	if err := pruneSessionObjects(ctx, c, l, &corev1.PersistentVolumeClaimList{}, refs.claims, inNamespace, selector); err != nil {
+		return err
+	}
+	return pruneSessionObjects(ctx, c, l, &corev1.PersistentVolumeList{}, refs.persistentVolumeNames, selector)
+}
+
+func (r *WorkbenchReconciler) copyConfigMap(ctx context.Context, c client.Client, l logr.Logger, w *positcov1beta1.Workbench, name string) error {
+	source := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: w.Namespace}, source); kerrors.IsNotFound(err) {
+		// sessions cannot mount it in the namespace of the Workbench either
+		l.Info("configmap for sessions not found; not copying it", "configmap", name)
+		return nil
+	} else if err != nil {
+		l.Error(err, "error getting configmap for sessions", "configmap", name)
+		return err
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: w.SessionNamespace(),
+		},
+	}
+	_, err := internal.CreateOrUpdateResource(ctx, c, r.Scheme, l, configMap, nil, func() error {
+		configMap.Labels = sessionNamespaceLabels(w)
+		configMap.Data = source.Data
+		configMap.BinaryData = source.BinaryData
+		return nil
+	})
+	return err
+}
+
+func (r *WorkbenchReconciler) copySecret(ctx context.Context, c client.Client, l logr.Logger, w *positcov1beta1.Workbench, name string) error {
+	source := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: w.Namespace}, source); kerrors.IsNotFound(err) {
+		// sessions cannot mount it in the namespace of the Workbench either
+		l.Info("secret for sessions not found; not copying it", "secret", name)
+		return nil
+	} else if err != nil {
+		l.Error(err, "error getting secret for sessions", "secret", name)
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: w.SessionNamespace(),
+		},
+	}
+	_, err := internal.CreateOrUpdateResource(ctx, c, r.Scheme, l, secret, nil, func() error {
+		secret.Labels = sessionNamespaceLabels(w)
+		secret.Type = source.Type
+		secret.Data = source.Data
+		return nil
+	})
+	return err
+}
+
+func (r *WorkbenchReconciler) copySecretProviderClass(ctx context.Context, c client.Client, l logr.Logger, w *positcov1beta1.Workbench, name string) error {
+	source := &secretstorev1.SecretProviderClass{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: w.Namespace}, source); kerrors.IsNotFound(err) {
+		// sessions cannot mount it in the namespace of the Workbench either
+		l.Info("secretproviderclass for sessions not found; not copying it", "secretproviderclass", name)
+		return nil
+	} else if err != nil {
+		l.Error(err, "error getting secretproviderclass for sessions", "secretproviderclass", name)
+		return err
+	}
+	spc := &secretstorev1.SecretProviderClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: w.SessionNamespace(),
+		},
+	}
+	_, err := internal.CreateOrUpdateResource(ctx, c, r.Scheme, l, spc, nil, func() error {
+		spc.Labels = sessionNamespaceLabels(w)
+		spc.Spec = source.Spec
+		return nil
+	})
+	return err
+}
+
+// copyClaim binds a claim in the session namespace to a twin of the PersistentVolume of the claim in the namespace
+// of the Workbench. The twin retains the data when it is deleted. shared is false for a claim that is neither
+// ReadWriteMany nor NFS, which is not copied
+func (r *WorkbenchReconciler) copyClaim(ctx context.Context, c client.Client, l logr.Logger, w *positcov1beta1.Workbench, name string) (shared bool, err error) {
+	source := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: w.Namespace}, source); kerrors.IsNotFound(err) {
+		// sessions cannot mount it in the namespace of the Workbench either
+		l.Info("claim for sessions not found; not copying it", "pvc", name)
+		return true, nil
+	} else if err != nil {
+		l.Error(err, "error getting claim for sessions", "pvc", name)
+		return false, err
+	}
+	if source.Spec.VolumeName == "" {
+		return false, fmt.Errorf("claim %s/%s is not bound, so it cannot be copied to the session namespace", w.Namespace, name)
+	}
+	sourceVolume := &corev1.PersistentVolume{}
+	if err := r.Get(ctx, client.ObjectKey{Name: source.Spec.VolumeName}, sourceVolume); err != nil {
+		l.Error(err, "error getting volume for sessions", "pv", source.Spec.VolumeName)
+		return false, err
+	}
+	// the server keeps the claim mounted, so a claim that cannot be mounted twice would keep the sessions pending
+	if !sharedClaim(source, sourceVolume) {
+		l.Info("claim for sessions is neither ReadWriteMany nor NFS; not copying it", "pvc", name)
+		return false, nil
+	}
+
+	volume := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: sessionPersistentVolumeName(w, name),
+		},
+	}
+	if _, err := internal.CreateOrUpdateResource(ctx, c, r.Scheme, l, volume, nil, func() error {
+		volume.Labels = sessionNamespaceLabels(w)
+		// the source and the claim of a bound volume cannot change
+		if volume.ResourceVersion == "" {
+			volume.Spec = corev1.PersistentVolumeSpec{
+				Capacity:               sourceVolume.Spec.Capacity,
+				PersistentVolumeSource: sourceVolume.Spec.PersistentVolumeSource,
+				AccessModes:            sourceVolume.Spec.AccessModes,
+				StorageClassName:       sourceVolume.Spec.StorageClassName,
+				MountOptions:           sourceVolume.Spec.MountOptions,
+				VolumeMode:             sourceVolume.Spec.VolumeMode,
+				NodeAffinity:           sourceVolume.Spec.NodeAffinity,
+				ClaimRef: &corev1.ObjectReference{
+					Kind:       "PersistentVolumeClaim",
+					APIVersion: "v1",
+					Name:       name,
+					Namespace:  w.SessionNamespace(),
+				},
+			}
+		}
+		volume.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: w.SessionNamespace(),
+		},
+	}
+	_, err = internal.CreateOrUpdateResource(ctx, c, r.Scheme, l, claim, nil, func() error {
+		claim.Labels = sessionNamespaceLabels(w)
+		if claim.ResourceVersion == "" {
+			claim.Spec = corev1.PersistentVolumeClaimSpec{
+				AccessModes:      source.Spec.AccessModes,
+				Resources:        source.Spec.Resources,
+				StorageClassName: &volume.Spec.StorageClassName,
+				VolumeMode:       source.Spec.VolumeMode,
+				VolumeName:       volume.Name,
+			}
+		}
+		return nil
+	})
+	return err == nil, err
+}
+
+// pruneSessionObjects deletes the listed objects that are not kept
+func pruneSessionObjects(ctx context.Context, c client.Client, l logr.Logger, list client.ObjectList, keep map[string]bool, opts ...client.ListOption) error {
+	if err := c.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok || keep[obj.GetName()] {
+			continue
+		}
+		if err := c.Delete(ctx, obj); err != nil && !kerrors.IsNotFound(err) {
+			l.Error(err, "error deleting object in session namespace", "name", obj.GetName())
+			return err
+		}
+		l.Info("deleted object in session namespace", "name", obj.GetName(), "namespace", obj.GetNamespace())
+	}
+	return nil
+}
+
+// cleanupSessionNamespace deletes what the Workbench created in any session namespace, which its owner reference
+// does not cover
+func (r *WorkbenchReconciler) cleanupSessionNamespace(ctx context.Context, req ctrl.Request, componentName string) error {
+	l := r.GetLogger(ctx).WithValues(
+		"event", "cleanup-session-namespace",
+	)
+	c := newSessionNamespaceClient(r.Client, r.APIReader, l)
+
+	selector := sessionNamespaceSelector(req.Namespace, componentName)
+	lists := []client.ObjectList{
+		&corev1.ConfigMapList{},
+		&corev1.SecretList{},
+		&corev1.ServiceAccountList{},
+		&corev1.ResourceQuotaList{},
+		&corev1.LimitRangeList{},
+		&rbacv1.RoleBindingList{},
+		&rbacv1.RoleList{},
+		&secretstorev1.SecretProviderClassList{},
+		&corev1.PersistentVolumeClaimList{},
+		&corev1.PersistentVolumeList{},
+	}
+	for _, list := range lists {
+		if err := pruneSessionObjects(ctx, c, l, list, nil, selector); err != nil && !meta.IsNoMatchError(err) {
+			return err
+		}
+	}
+	return nil
+}
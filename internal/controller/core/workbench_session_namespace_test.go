@@ -0,0 +1,163 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// sessionClaim creates a claim of the Workbench namespace that is bound to a volume of source
+func sessionClaim(t *testing.T, ctx context.Context, cli client.Client, ns, name string, mode corev1.PersistentVolumeAccessMode, source corev1.PersistentVolumeSource) {
+	storage := corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}
+	require.NoError(t, cli.Create(ctx, &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-" + name},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity:                      storage,
+			AccessModes:                   []corev1.PersistentVolumeAccessMode{mode},
+			PersistentVolumeSource:        source,
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete,
+			StorageClassName:              "shared",
+		},
+	}))
+	require.NoError(t, cli.Create(ctx, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{mode},
+			Resources:   corev1.VolumeResourceRequirements{Requests: storage},
+			VolumeName:  "pv-" + name,
+		},
+	}))
+}
+
+func TestWorkbenchReconciler_BasicSessionNamespace(t *testing.T) {
+	ctx := context.Background()
+	ns := "posit-team"
+	sessionNs := "posit-team-basic-sessions"
+	name := "workbench-basic-session-namespace"
+
+	ctx, r, req, cli := initWorkbenchReconciler(t, ctx, ns, name)
+
+	// the API server protects claims and volumes with finalizers, which no controller removes here
+	deleted := func(key client.ObjectKey, obj client.Object) bool {
+		err := cli.Get(ctx, key, obj)
+		return apierrors.IsNotFound(err) || (err == nil && obj.GetDeletionTimestamp() != nil)
+	}
+
+	wb := defineDefaultWorkbench(t, ns, name)
+	wb.Spec.OffHostExecution = true
+	wb.Spec.SessionNamespace = sessionNs
+	wb.Spec.AdditionalVolumes = []product.VolumeSpec{{PvcName: "projects", MountPath: "/mnt/projects"}}
+	wb.Spec.SessionConfig = &product.SessionConfig{
+		Pod: &product.PodConfig{ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}}},
+	}
+	cfg := &wb.Spec.Config
+
+	require.NoError(t, cli.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: sessionNs}}))
+	require.NoError(t, cli.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: ns},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+	}))
+	refs := referencedSessionObjects(wb, cfg)
+	for name := range refs.configMaps {
+		require.NoError(t, cli.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Data:       map[string]string{"file": name},
+		}))
+	}
+	for name := range refs.claims {
+		sessionClaim(t, ctx, cli, ns, name, corev1.ReadWriteMany, corev1.PersistentVolumeSource{
+			NFS: &corev1.NFSVolumeSource{Server: "nfs", Path: "/" + name},
+		})
+	}
+	assert.Contains(t, refs.claims, "projects")
+	assert.Contains(t, refs.configMaps, wb.SessionConfigMapName())
+	assert.Contains(t, refs.secrets, "registry")
+
+	require.NoError(t, r.reconcileSessionNamespace(ctx, req, wb, cfg))
+
+	// the launcher can start sessions in the session namespace
+	role := &rbacv1.Role{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Name: wb.ComponentName(), Namespace: sessionNs}, role))
+	assert.Empty(t, role.OwnerReferences)
+	assert.Equal(t, ns, role.Labels[positcov1beta1.OwnerNamespaceLabelKey])
+	binding := &rbacv1.RoleBinding{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Name: wb.ComponentName(), Namespace: sessionNs}, binding))
+	assert.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Name: wb.ComponentName(), Namespace: ns}}, binding.Subjects)
+
+	// what sessions mount is copied
+	configMap := &corev1.ConfigMap{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Name: wb.SessionConfigMapName(), Namespace: sessionNs}, configMap))
+	assert.Equal(t, wb.SessionConfigMapName(), configMap.Data["file"])
+	secret := &corev1.Secret{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Name: "registry", Namespace: sessionNs}, secret))
+	assert.Equal(t, corev1.SecretTypeDockerConfigJson, secret.Type)
+
+	// claims bind to a twin of their volume, which keeps the data
+	claim := &corev1.PersistentVolumeClaim{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Name: "projects", Namespace: sessionNs}, claim))
+	assert.Equal(t, sessionNs+"-projects", claim.Spec.VolumeName)
+	twin := &corev1.PersistentVolume{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Name: sessionNs + "-projects"}, twin))
+	assert.Equal(t, "/projects", twin.Spec.NFS.Path)
+	assert.Equal(t, corev1.PersistentVolumeReclaimRetain, twin.Spec.PersistentVolumeReclaimPolicy)
+	assert.Equal(t, sessionNs, twin.Spec.ClaimRef.Namespace)
+	assert.True(t, meta.IsStatusConditionTrue(wb.Status.Conditions, positcov1beta1.WorkbenchConditionSessionNamespaceReady))
+
+	// claims that the server and the sessions cannot mount at once are not copied
+	sessionClaim(t, ctx, cli, ns, "scratch", corev1.ReadWriteOnce, corev1.PersistentVolumeSource{
+		HostPath: &corev1.HostPathVolumeSource{Path: "/scratch"},
+	})
+	sessionClaim(t, ctx, cli, ns, "nfs-scratch", corev1.ReadWriteOnce, corev1.PersistentVolumeSource{
+		NFS: &corev1.NFSVolumeSource{Server: "nfs", Path: "/scratch"},
+	})
+	wb.Spec.AdditionalVolumes = append(wb.Spec.AdditionalVolumes,
+		product.VolumeSpec{PvcName: "scratch", MountPath: "/mnt/scratch"},
+		product.VolumeSpec{PvcName: "nfs-scratch", MountPath: "/mnt/nfs-scratch"},
+	)
+	require.NoError(t, r.reconcileSessionNamespace(ctx, req, wb, cfg))
+	assert.True(t, apierrors.IsNotFound(cli.Get(ctx, client.ObjectKey{Name: "scratch", Namespace: sessionNs}, &corev1.PersistentVolumeClaim{})))
+	assert.True(t, apierrors.IsNotFound(cli.Get(ctx, client.ObjectKey{Name: sessionNs + "-scratch"}, &corev1.PersistentVolume{})))
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Name: "nfs-scratch", Namespace: sessionNs}, &corev1.PersistentVolumeClaim{}))
+	condition := meta.FindStatusCondition(wb.Status.Conditions, positcov1beta1.WorkbenchConditionSessionNamespaceReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "ClaimNotShared", condition.Reason)
+	assert.Contains(t, condition.Message, "scratch")
+	assert.NotContains(t, condition.Message, "nfs-scratch")
+
+	// copies that sessions no longer mount are removed
+	wb.Spec.AdditionalVolumes = nil
+	require.NoError(t, r.reconcileSessionNamespace(ctx, req, wb, cfg))
+	assert.True(t, deleted(client.ObjectKey{Name: "projects", Namespace: sessionNs}, &corev1.PersistentVolumeClaim{}))
+	assert.True(t, deleted(client.ObjectKey{Name: sessionNs + "-projects"}, &corev1.PersistentVolume{}))
+	assert.True(t, meta.IsStatusConditionTrue(wb.Status.Conditions, positcov1beta1.WorkbenchConditionSessionNamespaceReady))
+
+	// an unbound claim cannot be copied yet
+	wb.Spec.AdditionalVolumes = []product.VolumeSpec{{PvcName: "pending", MountPath: "/mnt/pending"}}
+	require.NoError(t, cli.Create(ctx, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: ns},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			Resources:   corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")}},
+		},
+	}))
+	assert.ErrorContains(t, r.reconcileSessionNamespace(ctx, req, wb, cfg), "is not bound")
+
+	// deleting the Workbench deletes what it created in the session namespace
+	require.NoError(t, r.cleanupSessionNamespace(ctx, req, wb.ComponentName()))
+	assert.True(t, deleted(client.ObjectKey{Name: wb.ComponentName(), Namespace: sessionNs}, &rbacv1.Role{}))
+	assert.True(t, deleted(client.ObjectKey{Name: "registry", Namespace: sessionNs}, &corev1.Secret{}))
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Name: "registry", Namespace: ns}, &corev1.Secret{}))
+}
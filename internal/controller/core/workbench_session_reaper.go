@@ -0,0 +1,112 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:namespace=posit-team,groups="",resources=events,verbs=create;patch
+
+const (
+	// sessionReaperInterval is the longest time between two runs of the session reaper
+	sessionReaperInterval = 10 * time.Minute
+
+	// launcherManagedByLabelValue is the managed-by label of the Jobs that the launcher creates
+	launcherManagedByLabelValue = "launcher"
+
+	// launcherJobUserAnnotationKey is the pod annotation with the user of a launcher Job
+	launcherJobUserAnnotationKey = "user"
+
+	// launcherJobTagAnnotationPrefix is the prefix of the pod annotations with the tags of a launcher Job
+	launcherJobTagAnnotationPrefix = "USER_TAG_"
+)
+
+// launcherSessionTags are the tags that Workbench submits the sessions of each IDE with
+var launcherSessionTags = map[string]positcov1beta1.WorkbenchSessionIDE{
+	"rstudio-r-session":                positcov1beta1.WorkbenchSessionIDERStudio,
+	"rstudio-jupyter-lab-session":      positcov1beta1.WorkbenchSessionIDEJupyter,
+	"rstudio-jupyter-notebook-session": positcov1beta1.WorkbenchSessionIDEJupyter,
+	"rstudio-vscode-session":           positcov1beta1.WorkbenchSessionIDEVSCode,
+	"rstudio-positron-session":         positcov1beta1.WorkbenchSessionIDEPositron,
+}
+
+// launcherSession returns the user, IDE and resource profile of a launcher session Job from the annotations of its
+// pod template
+func launcherSession(job *batchv1.Job) positcov1beta1.WorkbenchSession {
+	annotations := job.Spec.Template.Annotations
+	session := positcov1beta1.WorkbenchSession{
+		User:            annotations[launcherJobUserAnnotationKey],
+		ResourceProfile: annotations[positcov1beta1.ResourceProfileAnnotationKey],
+	}
+	for key, value := range annotations {
+		if ide, ok := launcherSessionTags[value]; ok && strings.HasPrefix(key, launcherJobTagAnnotationPrefix) {
+			session.IDE = ide
+			break
+		}
+	}
+	return session
+}
+
+// reapSessions deletes the session Jobs of the Workbench that are older than their maximum age, and records an
+// Event for each. It returns the time until the reaper should run again, or 0 when it is off
+func (r *WorkbenchReconciler) reapSessions(ctx context.Context, req ctrl.Request, w *positcov1beta1.Workbench, now time.Time) (time.Duration, error) {
+	reaper := w.Spec.SessionReaper
+	if reaper == nil || !w.Spec.OffHostExecution {
+		return 0, nil
+	}
+
+	l := r.GetLogger(ctx).WithValues(
+		"event", "reap-sessions",
+		"product", "workbench",
+	)
+
+	// the launcher labels its Jobs with the instance id, which is the name of the Workbench component
+	jobs := &batchv1.JobList{}
+	if err := r.sessionClient(ctx, w).List(ctx, jobs, client.InNamespace(w.SessionNamespace()), client.MatchingLabels{
+		positcov1beta1.ManagedByLabelKey:     launcherManagedByLabelValue,
+		positcov1beta1.LauncherInstanceIDKey: w.ComponentName(),
+	}); err != nil {
+		l.Error(err, "error listing session jobs")
+		return 0, err
+	}
+
+	next := sessionReaperInterval
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if job.DeletionTimestamp != nil {
+			continue
+		}
+
+		session := launcherSession(job)
+		maxAge := reaper.MaxAge(session)
+		age := now.Sub(job.CreationTimestamp.Time)
+		if age < maxAge {
+			next = min(next, maxAge-age)
+			continue
+		}
+
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			l.Error(err, "error deleting session job", "job", job.Name)
+			return 0, err
+		}
+		l.Info("deleted session job past its maximum age", "job", job.Name, "user", session.User, "ide", session.IDE, "resourceProfile", session.ResourceProfile, "age", age.String())
+		if r.Recorder != nil {
+			r.Recorder.Eventf(w, corev1.EventTypeNormal, "SessionReaped",
+				"Deleted session Job %s of user %q: %s old, the maximum age is %s",
+				job.Name, session.User, age.Round(time.Minute), maxAge)
+		}
+	}
+	return next, nil
+}
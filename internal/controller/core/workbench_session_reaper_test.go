@@ -0,0 +1,216 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/posit-dev/team-operator/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrl "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// sessionJob is a launcher session Job of user that was created at created, with the tag of the session's IDE and
+// its resource profile
+func sessionJob(name, instanceID, user, tag, resourceProfile string, created time.Time) *batchv1.Job {
+	annotations := map[string]string{launcherJobUserAnnotationKey: user}
+	if tag != "" {
+		annotations[launcherJobTagAnnotationPrefix+"0"] = tag
+	}
+	if resourceProfile != "" {
+		annotations[positcov1beta1.ResourceProfileAnnotationKey] = resourceProfile
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "posit-team",
+			CreationTimestamp: metav1.NewTime(created),
+			Labels: map[string]string{
+				positcov1beta1.ManagedByLabelKey:     launcherManagedByLabelValue,
+				positcov1beta1.LauncherInstanceIDKey: instanceID,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers:    []corev1.Container{{Name: "session", Image: "workbench-session"}},
+				},
+			},
+		},
+	}
+}
+
+// sessionReaper has a default maximum age of a day, and profiles by user, IDE and resource profile
+func sessionReaper() *positcov1beta1.WorkbenchSessionReaper {
+	return &positcov1beta1.WorkbenchSessionReaper{
+		MaxAgeHours: 24,
+		Profiles: []positcov1beta1.WorkbenchSessionReaperProfile{
+			{Users: []string{"long"}, MaxAgeHours: 72},
+			{IDEs: []positcov1beta1.WorkbenchSessionIDE{positcov1beta1.WorkbenchSessionIDEJupyter}, ResourceProfiles: []string{"gpu"}, MaxAgeHours: 4},
+			{ResourceProfiles: []string{"gpu"}, MaxAgeHours: 12},
+		},
+	}
+}
+
+func TestLauncherSession(t *testing.T) {
+	job := sessionJob("session", "instance", "alice", "rstudio-vscode-session", "gpu", time.Now())
+	assert.Equal(t, positcov1beta1.WorkbenchSession{
+		User:            "alice",
+		IDE:             positcov1beta1.WorkbenchSessionIDEVSCode,
+		ResourceProfile: "gpu",
+	}, launcherSession(job))
+
+	// the IDE and resource profile are unknown without their annotations
+	job = sessionJob("session", "instance", "alice", "", "", time.Now())
+	assert.Equal(t, positcov1beta1.WorkbenchSession{User: "alice"}, launcherSession(job))
+}
+
+func TestWorkbenchSessionReaper_MaxAge(t *testing.T) {
+	reaper := sessionReaper()
+	for _, tc := range []struct {
+		session positcov1beta1.WorkbenchSession
+		hours   int
+	}{
+		{positcov1beta1.WorkbenchSession{User: "alice", IDE: positcov1beta1.WorkbenchSessionIDERStudio}, 24},
+		{positcov1beta1.WorkbenchSession{User: "long", IDE: positcov1beta1.WorkbenchSessionIDEJupyter, ResourceProfile: "gpu"}, 72},
+		{positcov1beta1.WorkbenchSession{User: "alice", IDE: positcov1beta1.WorkbenchSessionIDEJupyter, ResourceProfile: "gpu"}, 4},
+		{positcov1beta1.WorkbenchSession{User: "alice", IDE: positcov1beta1.WorkbenchSessionIDEJupyter}, 24},
+		{positcov1beta1.WorkbenchSession{User: "alice", IDE: positcov1beta1.WorkbenchSessionIDEPositron, ResourceProfile: "gpu"}, 12},
+		{positcov1beta1.WorkbenchSession{User: "alice", ResourceProfile: "gpu"}, 12},
+	} {
+		assert.Equal(t, time.Duration(tc.hours)*time.Hour, reaper.MaxAge(tc.session), tc.session)
+	}
+}
+
+func TestWorkbenchReconciler_ReapSessions(t *testing.T) {
+	ctx := context.Background()
+	ns := "posit-team"
+	name := "workbench-reaper"
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	wb := defineDefaultWorkbench(t, ns, name)
+	wb.Spec.OffHostExecution = true
+	wb.Spec.SessionReaper = sessionReaper()
+
+	// fake Jobs with back-dated creation timestamps
+	jobs := []*batchv1.Job{
+		sessionJob("expired", wb.ComponentName(), "alice", "rstudio-r-session", "", now.Add(-25*time.Hour)),
+		sessionJob("young", wb.ComponentName(), "alice", "rstudio-r-session", "", now.Add(-20*time.Hour)),
+		sessionJob("long", wb.ComponentName(), "long", "rstudio-r-session", "", now.Add(-48*time.Hour)),
+		sessionJob("long-expired", wb.ComponentName(), "long", "rstudio-r-session", "", now.Add(-73*time.Hour)),
+		sessionJob("jupyter-gpu", wb.ComponentName(), "alice", "rstudio-jupyter-lab-session", "gpu", now.Add(-5*time.Hour)),
+		sessionJob("vscode-gpu", wb.ComponentName(), "alice", "rstudio-vscode-session", "gpu", now.Add(-5*time.Hour)),
+		sessionJob("other-site", "other-workbench", "alice", "rstudio-r-session", "", now.Add(-100*time.Hour)),
+	}
+
+	scheme := runtime.NewScheme()
+	loadSchemes(scheme)
+	builder := fakectrl.NewClientBuilder().WithScheme(scheme)
+	for _, j := range jobs {
+		builder = builder.WithObjects(j)
+	}
+	cli := builder.Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &WorkbenchReconciler{Client: cli, Scheme: scheme, Log: product.NewSimpleLogger(), Recorder: recorder}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: name}}
+
+	next, err := r.reapSessions(ctx, req, wb, now)
+	require.NoError(t, err)
+	// the young job expires first, but the reaper runs at least every interval
+	assert.Equal(t, sessionReaperInterval, next)
+
+	for _, j := range jobs {
+		err := cli.Get(ctx, client.ObjectKeyFromObject(j), &batchv1.Job{})
+		switch j.Name {
+		case "expired", "long-expired", "jupyter-gpu":
+			assert.True(t, kerrors.IsNotFound(err), j.Name)
+		default:
+			assert.NoError(t, err, j.Name)
+		}
+	}
+
+	require.Len(t, recorder.Events, 3)
+	for range 3 {
+		assert.Contains(t, <-recorder.Events, "SessionReaped")
+	}
+
+	// the next run comes back when the young job expires
+	next, err = r.reapSessions(ctx, req, wb, now.Add(3*time.Hour+55*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, next)
+
+	// the reaper is off without a policy
+	wb.Spec.SessionReaper = nil
+	next, err = r.reapSessions(ctx, req, wb, now.Add(100*time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, next)
+	assert.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(jobs[1]), &batchv1.Job{}))
+}
+
+func TestWorkbenchReconciler_BasicReapSessions(t *testing.T) {
+	ctx := context.Background()
+	ns := "posit-team"
+	name := "workbench-basic-reaper"
+
+	ctx, r, req, cli := initWorkbenchReconciler(t, ctx, ns, name)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	wb := defineDefaultWorkbench(t, ns, name)
+	wb.Spec.OffHostExecution = true
+	wb.Spec.SessionReaper = sessionReaper()
+	err := internal.BasicCreateOrUpdate(ctx, r, r.GetLogger(ctx), req.NamespacedName, &positcov1beta1.Workbench{}, wb)
+	require.NoError(t, err)
+	wb = getWorkbench(t, cli, ns, name)
+	require.NotNil(t, wb.Spec.SessionReaper)
+
+	// the API server sets the creation timestamps, so the reaper runs in the future instead
+	jobs := []*batchv1.Job{
+		sessionJob("basic-rstudio", wb.ComponentName(), "alice", "rstudio-r-session", "", time.Time{}),
+		sessionJob("basic-long", wb.ComponentName(), "long", "rstudio-r-session", "", time.Time{}),
+		sessionJob("basic-jupyter-gpu", wb.ComponentName(), "alice", "rstudio-jupyter-lab-session", "gpu", time.Time{}),
+		sessionJob("basic-positron-gpu", wb.ComponentName(), "alice", "rstudio-positron-session", "gpu", time.Time{}),
+		sessionJob("basic-other-site", "other-workbench", "alice", "rstudio-r-session", "", time.Time{}),
+	}
+	for _, j := range jobs {
+		require.NoError(t, cli.Create(ctx, j))
+	}
+
+	reaped := func(now time.Time) []string {
+		_, err := r.reapSessions(ctx, req, wb, now)
+		require.NoError(t, err)
+		var names []string
+		for _, j := range jobs {
+			job := &batchv1.Job{}
+			err := cli.Get(ctx, client.ObjectKeyFromObject(j), job)
+			if kerrors.IsNotFound(err) || (err == nil && job.DeletionTimestamp != nil) {
+				names = append(names, j.Name)
+			} else {
+				require.NoError(t, err, j.Name)
+			}
+		}
+		return names
+	}
+
+	assert.Empty(t, reaped(time.Now().Add(time.Hour)))
+	assert.Equal(t, []string{"basic-jupyter-gpu"}, reaped(time.Now().Add(5*time.Hour)))
+	assert.Equal(t, []string{"basic-jupyter-gpu", "basic-positron-gpu"}, reaped(time.Now().Add(13*time.Hour)))
+	assert.Equal(t, []string{"basic-rstudio", "basic-jupyter-gpu", "basic-positron-gpu"}, reaped(time.Now().Add(25*time.Hour)))
+	assert.Len(t, recorder.Events, 3)
+}
@@ -12,10 +12,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrl "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 func TestAzureDatabricks(t *testing.T) {
@@ -202,6 +206,39 @@ func TestWorkbenchConfigReload(t *testing.T) {
 	require.NotEqual(t, preSha, postSha)
 }
 
+func TestWorkbenchRepositories(t *testing.T) {
+	ctx := context.Background()
+	ns := "posit-team"
+	name := "workbench-repositories"
+
+	ctx, r, req, cli := initWorkbenchReconciler(t, ctx, ns, name)
+
+	wb := defineDefaultWorkbench(t, ns, name)
+	wb.Spec.Config.Repos = &positcov1beta1.WorkbenchRepoConfig{CRAN: "https://cran.example.com"}
+	wb.Spec.Repositories = []positcov1beta1.WorkbenchRepository{
+		{Name: "CRAN", Type: positcov1beta1.WorkbenchRepositoryTypeCRAN, URL: "https://ppm.example.com/cran/latest"},
+		{Name: "Internal", Type: positcov1beta1.WorkbenchRepositoryTypeCRAN, URL: "https://ppm.example.com/internal/latest"},
+		{Name: "PyPI", Type: positcov1beta1.WorkbenchRepositoryTypePyPI, URL: "https://ppm.example.com/pypi/latest/simple"},
+	}
+
+	err := internal.BasicCreateOrUpdate(ctx, r, r.GetLogger(ctx), req.NamespacedName, &positcov1beta1.Workbench{}, wb)
+	require.NoError(t, err)
+
+	wb = getWorkbench(t, cli, ns, name)
+
+	res, err := r.ReconcileWorkbench(ctx, req, wb)
+	require.NoError(t, err)
+	require.True(t, res.IsZero())
+
+	// the repositories replace the repos.conf of the config
+	sessionConfigmap := &corev1.ConfigMap{}
+	err = cli.Get(ctx, client.ObjectKey{Name: wb.SessionConfigMapName(), Namespace: ns}, sessionConfigmap)
+	require.NoError(t, err)
+	assert.Equal(t, "CRAN=https://ppm.example.com/cran/latest\nInternal=https://ppm.example.com/internal/latest\n", sessionConfigmap.Data["repos.conf"])
+	assert.Equal(t, "[global]\nindex-url = https://ppm.example.com/pypi/latest/simple\n", sessionConfigmap.Data["pip.conf"])
+	assert.NotContains(t, sessionConfigmap.Data, "Rprofile.site")
+}
+
 func TestWorkbenchAuthSaml(t *testing.T) {
 	ctx := context.Background()
 	ns := "posit-team"
@@ -262,3 +299,28 @@ func TestWorkbenchAuthSamlMissingMetadata(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "SAML authentication requires a metadata URL")
 }
+
+func TestWorkbenchCleanupHTTPRoute(t *testing.T) {
+	ctx := context.Background()
+	ns := "posit-team"
+	name := "workbench-cleanup"
+
+	scheme := runtime.NewScheme()
+	loadSchemes(scheme)
+	cli := fakectrl.NewClientBuilder().WithScheme(scheme).Build()
+	r := &WorkbenchReconciler{Client: cli, Scheme: scheme, Log: product.NewSimpleLogger()}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: name}}
+	wb := defineDefaultWorkbench(t, ns, name)
+
+	key := client.ObjectKey{Namespace: ns, Name: wb.ComponentName()}
+	require.NoError(t, cli.Create(ctx, &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{
+		Namespace: ns,
+		Name:      key.Name,
+		Labels:    map[string]string{positcov1beta1.ManagedByLabelKey: positcov1beta1.ManagedByLabelValue},
+	}}))
+
+	require.NoError(t, r.cleanupDeployedService(ctx, req, wb))
+
+	err := cli.Get(ctx, key, &gatewayv1.HTTPRoute{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
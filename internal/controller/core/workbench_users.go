@@ -0,0 +1,434 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/posit-dev/team-operator/internal"
+	"github.com/rstudio/goex/ptr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:namespace=posit-team,groups=core.posit.team,resources=workbenchusers;workbenchgroups,verbs=get;list;watch
+//+kubebuilder:rbac:namespace=posit-team,groups=core.posit.team,resources=workbenchusers/status;workbenchgroups/status,verbs=get;update;patch
+
+// workbenchUsersSupervisorConf runs the sync-users script in the server. supervisord in the Workbench images
+// starts the programs in /startup/user-provisioning
+var workbenchUsersSupervisorConf = `# NOTE: This file is injected by rstudio/ptd team-operator
+[program:workbench-users]
+command=/bin/bash ` + positcov1beta1.WorkbenchUsersMountPath + `/sync-users.sh
+autorestart=true
+numprocs=1
+stdout_logfile=/dev/stdout
+stdout_logfile_maxbytes=0
+stderr_logfile=/dev/stderr
+stderr_logfile_maxbytes=0
+`
+
+// workbenchUsersSyncSh adds the projected groups and users to /etc/group and /etc/passwd, which every image
+// resolves users from, and keeps the members of the projected groups up to date. A name or id that already belongs
+// to someone else, i.e. a user of the image or of a directory that sssd serves, is skipped. Users and groups that
+// are no longer projected are left in place
+var workbenchUsersSyncSh = `#!/bin/bash
+set -uo pipefail
+
+dir=` + positcov1beta1.WorkbenchUsersMountPath + `
+
+for cmd in getent groupadd useradd gpasswd; do
+  if ! command -v "$cmd" > /dev/null; then
+    echo "workbench-users: $cmd is missing from the image, users are not synced"
+    exec sleep infinity
+  fi
+done
+
+sync_groups() {
+  while IFS=: read -r name _ gid _; do
+    [[ -z "$name" ]] && continue
+    owner="$(getent group "$gid" | cut -d: -f1)"
+    if [[ -z "$owner" ]]; then
+      groupadd -g "$gid" "$name" || echo "workbench-users: cannot add group '$name' ($gid)"
+    elif [[ "$owner" != "$name" ]]; then
+      echo "workbench-users: skipping group '$name', gid $gid belongs to '$owner'"
+    fi
+  done < "$dir/group"
+}
+
+sync_users() {
+  while IFS=: read -r name _ uid gid gecos home shell; do
+    [[ -z "$name" ]] && continue
+    owner="$(getent passwd "$uid" | cut -d: -f1)"
+    if [[ -z "$owner" ]]; then
+      useradd -M -N -u "$uid" -g "$gid" -c "$gecos" -d "$home" -s "$shell" "$name" || echo "workbench-users: cannot add user '$name' ($uid)"
+    elif [[ "$owner" != "$name" ]]; then
+      echo "workbench-users: skipping user '$name', uid $uid belongs to '$owner'"
+    fi
+  done < "$dir/passwd"
+}
+
+sync_members() {
+  while IFS=: read -r name _ gid members; do
+    [[ -z "$name" ]] && continue
+    entry="$(getent group "$gid")"
+    if [[ "$(cut -d: -f1 <<< "$entry")" == "$name" && "$(cut -d: -f4 <<< "$entry")" != "$members" ]]; then
+      gpasswd -M "$members" "$name" > /dev/null || echo "workbench-users: cannot set the members of group '$name'"
+    fi
+  done < "$dir/group"
+}
+
+while true; do
+  sync_groups
+  sync_users
+  sync_members
+  sleep 30
+done
+`
+
+// workbenchUsersSeedHomesSh seeds the home directories that do not exist yet. The seeds file lists
+// username:uid:gid:home, and the seed of each user is mounted in its own directory
+var workbenchUsersSeedHomesSh = `#!/bin/bash
+set -euo pipefail
+shopt -s dotglob nullglob
+
+while IFS=: read -r username uid gid home; do
+  if [[ -z "$username" || -e "$home" ]]; then
+    continue
+  fi
+  echo "workbench-users: seeding '$home'"
+  mkdir -p "$home"
+  for f in ` + workbenchUsersSeedMountPath + `/"$username"/*; do
+    # skip the bookkeeping entries of the ConfigMap volume
+    if [[ "$(basename "$f")" == ..* ]]; then
+      continue
+    fi
+    cp -rL "$f" "$home"/
+  done
+  chown -R "$uid:$gid" "$home"
+  chmod 700 "$home"
+done < ` + positcov1beta1.WorkbenchUsersMountPath + `/seeds
+`
+
+// workbenchUsersSeedMountPath is where the seed-homes init container mounts the home directory seeds
+const workbenchUsersSeedMountPath = "/mnt/workbench-users/seeds"
+
+// projectedWorkbenchUsers are the WorkbenchUsers and WorkbenchGroups that a Workbench projects
+type projectedWorkbenchUsers struct {
+	Users  []positcov1beta1.WorkbenchUser
+	Groups []positcov1beta1.WorkbenchGroup
+}
+
+// reconcileUsers projects the WorkbenchUsers and WorkbenchGroups that the Workbench selects into its users
+// ConfigMap, and records the sites and conflicts in their status. It returns the projected users and groups, or
+// nil when the Workbench does not select any
+func (r *WorkbenchReconciler) reconcileUsers(ctx context.Context, req ctrl.Request, w *positcov1beta1.Workbench) (*projectedWorkbenchUsers, error) {
+	l := r.GetLogger(ctx).WithValues(
+		"event", "reconcile-users",
+		"product", "workbench",
+	)
+
+	users, groups, err := r.listWorkbenchUsers(ctx, req.Namespace)
+	if err != nil {
+		l.Error(err, "error listing workbench users and groups")
+		return nil, err
+	}
+
+	var projected *projectedWorkbenchUsers
+	if w.Spec.UserSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(w.Spec.UserSelector)
+		if err != nil {
+			l.Error(err, "invalid user selector")
+			return nil, err
+		}
+		projected = selectWorkbenchUsers(selector, users, groups)
+	}
+
+	if err := r.updateWorkbenchUserStatuses(ctx, w, users, groups, projected); err != nil {
+		l.Error(err, "error updating workbench user status")
+		return nil, err
+	}
+
+	key := client.ObjectKey{Name: w.UsersConfigmapName(), Namespace: req.Namespace}
+	if projected == nil {
+		if err := internal.BasicDelete(ctx, r, l, key, &corev1.ConfigMap{}); err != nil && !kerrors.IsNotFound(err) {
+			l.Error(err, "error cleaning up users configmap")
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+	}
+	if _, err := internal.CreateOrUpdateResource(ctx, r.Client, r.Scheme, l, cm, w, func() error {
+		cm.Labels = w.KubernetesLabels()
+		cm.Data = projected.configMapData()
+		return nil
+	}); err != nil {
+		l.Error(err, "error creating or updating users configmap")
+		return nil, err
+	}
+	return projected, nil
+}
+
+// workbenchUserConflicts returns the conflicts of each WorkbenchUser and WorkbenchGroup, by name. The oldest
+// object keeps a username, UID, group name or GID, and the others conflict with it
+func workbenchUserConflicts(users []positcov1beta1.WorkbenchUser, groups []positcov1beta1.WorkbenchGroup) (map[string][]string, map[string][]string) {
+	users = slices.Clone(users)
+	sort.SliceStable(users, func(i, j int) bool {
+		return olderObject(&users[i].ObjectMeta, &users[j].ObjectMeta)
+	})
+	userConflicts := map[string][]string{}
+	usernames, uids := map[string]string{}, map[int64]string{}
+	for _, u := range users {
+		if owner, ok := usernames[u.Spec.Username]; ok {
+			userConflicts[u.Name] = append(userConflicts[u.Name], fmt.Sprintf("username %s is also used by WorkbenchUser %s", u.Spec.Username, owner))
+		}
+		if owner, ok := uids[u.Spec.UID]; ok {
+			userConflicts[u.Name] = append(userConflicts[u.Name], fmt.Sprintf("uid %d is also used by WorkbenchUser %s", u.Spec.UID, owner))
+		}
+		if len(userConflicts[u.Name]) == 0 {
+			usernames[u.Spec.Username] = u.Name
+			uids[u.Spec.UID] = u.Name
+		}
+	}
+
+	groups = slices.Clone(groups)
+	sort.SliceStable(groups, func(i, j int) bool {
+		return olderObject(&groups[i].ObjectMeta, &groups[j].ObjectMeta)
+	})
+	groupConflicts := map[string][]string{}
+	groupNames, gids := map[string]string{}, map[int64]string{}
+	for _, g := range groups {
+		if owner, ok := groupNames[g.Spec.GroupName]; ok {
+			groupConflicts[g.Name] = append(groupConflicts[g.Name], fmt.Sprintf("group name %s is also used by WorkbenchGroup %s", g.Spec.GroupName, owner))
+		}
+		if owner, ok := gids[g.Spec.GID]; ok {
+			groupConflicts[g.Name] = append(groupConflicts[g.Name], fmt.Sprintf("gid %d is also used by WorkbenchGroup %s", g.Spec.GID, owner))
+		}
+		if len(groupConflicts[g.Name]) == 0 {
+			groupNames[g.Spec.GroupName] = g.Name
+			gids[g.Spec.GID] = g.Name
+		}
+	}
+	return userConflicts, groupConflicts
+}
+
+// olderObject orders objects by creation, then by name
+func olderObject(a, b *metav1.ObjectMeta) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
+// selectWorkbenchUsers returns the users and groups that selector matches and that do not conflict, ordered by id
+func selectWorkbenchUsers(selector labels.Selector, users []positcov1beta1.WorkbenchUser, groups []positcov1beta1.WorkbenchGroup) *projectedWorkbenchUsers {
+	userConflicts, groupConflicts := workbenchUserConflicts(users, groups)
+
+	projected := &projectedWorkbenchUsers{}
+	for _, u := range users {
+		if len(userConflicts[u.Name]) == 0 && selector.Matches(labels.Set(u.Labels)) {
+			projected.Users = append(projected.Users, u)
+		}
+	}
+	for _, g := range groups {
+		if len(groupConflicts[g.Name]) == 0 && selector.Matches(labels.Set(g.Labels)) {
+			projected.Groups = append(projected.Groups, g)
+		}
+	}
+	sort.Slice(projected.Users, func(i, j int) bool {
+		return projected.Users[i].Spec.UID < projected.Users[j].Spec.UID
+	})
+	sort.Slice(projected.Groups, func(i, j int) bool {
+		return projected.Groups[i].Spec.GID < projected.Groups[j].Spec.GID
+	})
+	return projected
+}
+
+// configMapData returns the passwd and group files with the script that syncs them and its supervisord program,
+// and the home directory seeds with the script that applies them
+func (p *projectedWorkbenchUsers) configMapData() map[string]string {
+	var passwd, group, seeds strings.Builder
+
+	for _, u := range p.Users {
+		passwd.WriteString(u.PasswdEntry() + "\n")
+	}
+
+	gids := map[int64]bool{}
+	for _, g := range p.Groups {
+		var members []string
+		for _, u := range p.Users {
+			if slices.Contains(u.Spec.Groups, g.Spec.GroupName) {
+				members = append(members, u.Spec.Username)
+			}
+		}
+		sort.Strings(members)
+		group.WriteString(g.GroupEntry(members) + "\n")
+		gids[g.Spec.GID] = true
+	}
+	// a user whose primary group is not a WorkbenchGroup gets a group of its own
+	for _, u := range p.Users {
+		if gid := u.PrimaryGID(); !gids[gid] {
+			group.WriteString(fmt.Sprintf("%s:x:%d:\n", u.Spec.Username, gid))
+			gids[gid] = true
+		}
+	}
+
+	for _, u := range p.seededUsers() {
+		seeds.WriteString(fmt.Sprintf("%s:%d:%d:%s\n", u.Spec.Username, u.Spec.UID, u.PrimaryGID(), u.HomeDirectory()))
+	}
+
+	return map[string]string{
+		"passwd":               passwd.String(),
+		"group":                group.String(),
+		"seeds":                seeds.String(),
+		"seed-homes.sh":        workbenchUsersSeedHomesSh,
+		"sync-users.sh":        workbenchUsersSyncSh,
+		"workbench-users.conf": workbenchUsersSupervisorConf,
+	}
+}
+
+// seededUsers returns the projected users that have a home directory seed
+func (p *projectedWorkbenchUsers) seededUsers() []positcov1beta1.WorkbenchUser {
+	var seeded []positcov1beta1.WorkbenchUser
+	for _, u := range p.Users {
+		if u.Spec.HomeSeed != nil && u.Spec.HomeSeed.ConfigMapName != "" {
+			seeded = append(seeded, u)
+		}
+	}
+	return seeded
+}
+
+// seedHomesInitContainer returns the init container that seeds the home directories of the projected users,
+// with its volumes. Seeding needs the home directory volume, and root to hand the directories to their users, so
+// there is nothing to do without a volume or under the restricted security profile
+func (p *projectedWorkbenchUsers) seedHomesInitContainer(w *positcov1beta1.Workbench) ([]corev1.Container, []corev1.Volume) {
+	seeded := p.seededUsers()
+	if len(seeded) == 0 || w.Spec.Volume == nil || w.Spec.SecurityProfile == positcov1beta1.SecurityProfileRestricted {
+		return nil, nil
+	}
+
+	mounts := []corev1.VolumeMount{
+		{Name: "users-volume", MountPath: positcov1beta1.WorkbenchUsersMountPath, ReadOnly: true},
+		{Name: "home-dir-volume", MountPath: "/home"},
+	}
+	var volumes []corev1.Volume
+	for i, u := range seeded {
+		name := fmt.Sprintf("users-seed-%d", i)
+		volumes = append(volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: u.Spec.HomeSeed.ConfigMapName},
+					// a missing seed must not keep the server from starting
+					Optional: ptr.To(true),
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: workbenchUsersSeedMountPath + "/" + u.Spec.Username,
+			ReadOnly:  true,
+		})
+	}
+
+	return []corev1.Container{
+		{
+			Name:            "seed-homes",
+			Image:           w.Spec.Image,
+			ImagePullPolicy: w.Spec.ImagePullPolicy,
+			Command:         []string{"/bin/bash", positcov1beta1.WorkbenchUsersMountPath + "/seed-homes.sh"},
+			VolumeMounts:    mounts,
+		},
+	}, volumes
+}
+
+// seedsSha returns the sha of the home directory seeds, so that the server restarts to seed new home directories
+func (p *projectedWorkbenchUsers) seedsSha() (string, error) {
+	if p == nil {
+		return "", nil
+	}
+	return product.ComputeSha256(map[string]string{"seeds": p.configMapData()["seeds"]})
+}
+
+// updateWorkbenchUserStatuses records the conflicts of the WorkbenchUsers and WorkbenchGroups, and lists the
+// Workbench's site in the status of the projected ones
+func (r *WorkbenchReconciler) updateWorkbenchUserStatuses(ctx context.Context, w *positcov1beta1.Workbench, users []positcov1beta1.WorkbenchUser, groups []positcov1beta1.WorkbenchGroup, projected *projectedWorkbenchUsers) error {
+	userConflicts, groupConflicts := workbenchUserConflicts(users, groups)
+	if projected == nil {
+		projected = &projectedWorkbenchUsers{}
+	}
+
+	for _, u := range users {
+		used := slices.ContainsFunc(projected.Users, func(p positcov1beta1.WorkbenchUser) bool {
+			return p.Name == u.Name
+		})
+		sites := updateSites(u.Status.Sites, w.SiteName(), used)
+		if slices.Equal(sites, u.Status.Sites) && slices.Equal(userConflicts[u.Name], u.Status.Conflicts) {
+			continue
+		}
+		u.Status.Sites = sites
+		u.Status.Conflicts = userConflicts[u.Name]
+		if err := r.Status().Update(ctx, &u); err != nil {
+			return err
+		}
+	}
+
+	for _, g := range groups {
+		used := slices.ContainsFunc(projected.Groups, func(p positcov1beta1.WorkbenchGroup) bool {
+			return p.Name == g.Name
+		})
+		sites := updateSites(g.Status.Sites, w.SiteName(), used)
+		if slices.Equal(sites, g.Status.Sites) && slices.Equal(groupConflicts[g.Name], g.Status.Conflicts) {
+			continue
+		}
+		g.Status.Sites = sites
+		g.Status.Conflicts = groupConflicts[g.Name]
+		if err := r.Status().Update(ctx, &g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateSites returns sites with site when used, and without it otherwise
+func updateSites(sites []string, site string, used bool) []string {
+	if used == slices.Contains(sites, site) {
+		return sites
+	}
+	if used {
+		sites = append(slices.Clone(sites), site)
+		sort.Strings(sites)
+		return sites
+	}
+	return slices.DeleteFunc(slices.Clone(sites), func(s string) bool {
+		return s == site
+	})
+}
+
+// listWorkbenchUsers lists the WorkbenchUsers and WorkbenchGroups in the namespace. A cluster without the CRDs has
+// none
+func (r *WorkbenchReconciler) listWorkbenchUsers(ctx context.Context, namespace string) ([]positcov1beta1.WorkbenchUser, []positcov1beta1.WorkbenchGroup, error) {
+	users := &positcov1beta1.WorkbenchUserList{}
+	if err := r.List(ctx, users, client.InNamespace(namespace)); err != nil && !meta.IsNoMatchError(err) {
+		return nil, nil, err
+	}
+	groups := &positcov1beta1.WorkbenchGroupList{}
+	if err := r.List(ctx, groups, client.InNamespace(namespace)); err != nil && !meta.IsNoMatchError(err) {
+		return nil, nil, err
+	}
+	return users.Items, groups.Items, nil
+}
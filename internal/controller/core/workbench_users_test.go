@@ -0,0 +1,173 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	positcov1beta1 "github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/rstudio/goex/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrl "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func workbenchUser(name string, uid int64, created time.Time, groups ...string) positcov1beta1.WorkbenchUser {
+	return positcov1beta1.WorkbenchUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "posit-team",
+			Labels:            map[string]string{"site": "main"},
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: positcov1beta1.WorkbenchUserSpec{Username: name, UID: uid, Groups: groups},
+	}
+}
+
+func workbenchGroup(name string, gid int64, created time.Time) positcov1beta1.WorkbenchGroup {
+	return positcov1beta1.WorkbenchGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "posit-team",
+			Labels:            map[string]string{"site": "main"},
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: positcov1beta1.WorkbenchGroupSpec{GroupName: name, GID: gid},
+	}
+}
+
+func TestWorkbenchUserConflicts(t *testing.T) {
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	alice := workbenchUser("alice", 2001, now)
+	// a newer user cannot take the uid of alice
+	bob := workbenchUser("bob", 2001, now.Add(time.Hour))
+	// nor her username
+	alice2 := workbenchUser("alice-2", 2002, now.Add(time.Hour))
+	alice2.Spec.Username = "alice"
+	carol := workbenchUser("carol", 2003, now.Add(time.Hour))
+
+	data := workbenchGroup("data", 3001, now)
+	other := workbenchGroup("other", 3001, now)
+
+	userConflicts, groupConflicts := workbenchUserConflicts(
+		[]positcov1beta1.WorkbenchUser{bob, carol, alice2, alice},
+		[]positcov1beta1.WorkbenchGroup{other, data},
+	)
+	assert.Equal(t, map[string][]string{
+		"bob":     {"uid 2001 is also used by WorkbenchUser alice"},
+		"alice-2": {"username alice is also used by WorkbenchUser alice"},
+	}, userConflicts)
+	// objects of the same age are ordered by name
+	assert.Equal(t, map[string][]string{
+		"other": {"gid 3001 is also used by WorkbenchGroup data"},
+	}, groupConflicts)
+}
+
+func TestProjectedWorkbenchUsers_ConfigMapData(t *testing.T) {
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	alice := workbenchUser("alice", 2001, now, "data", "unknown")
+	alice.Spec.GID = ptr.To(int64(3001))
+	alice.Spec.HomeSeed = &positcov1beta1.WorkbenchUserHomeSeed{ConfigMapName: "seed"}
+	bob := workbenchUser("bob", 2002, now, "data")
+	p := &projectedWorkbenchUsers{
+		Users:  []positcov1beta1.WorkbenchUser{alice, bob},
+		Groups: []positcov1beta1.WorkbenchGroup{workbenchGroup("data", 3001, now)},
+	}
+
+	data := p.configMapData()
+	assert.Equal(t, "alice:x:2001:3001::/home/alice:/bin/bash\nbob:x:2002:2002::/home/bob:/bin/bash\n", data["passwd"])
+	// bob gets a group of his own, alice's primary group is data
+	assert.Equal(t, "data:x:3001:alice,bob\nbob:x:2002:\n", data["group"])
+	assert.Equal(t, "alice:2001:3001:/home/alice\n", data["seeds"])
+	assert.Contains(t, data["workbench-users.conf"], "command=/bin/bash /etc/workbench-users/sync-users.sh\n")
+	assert.Contains(t, data["sync-users.sh"], `done < "$dir/passwd"`)
+
+	// seeding needs the home directory volume
+	w := &positcov1beta1.Workbench{ObjectMeta: metav1.ObjectMeta{Name: "main"}}
+	containers, volumes := p.seedHomesInitContainer(w)
+	assert.Empty(t, containers)
+	assert.Empty(t, volumes)
+
+	w.Spec.Volume = &product.VolumeSpec{Create: true}
+	containers, volumes = p.seedHomesInitContainer(w)
+	require.Len(t, containers, 1)
+	require.Len(t, volumes, 1)
+	assert.Equal(t, "seed", volumes[0].ConfigMap.Name)
+	assert.Contains(t, containers[0].VolumeMounts, corev1.VolumeMount{
+		Name: volumes[0].Name, MountPath: "/mnt/workbench-users/seeds/alice", ReadOnly: true,
+	})
+
+	// ... and root
+	w.Spec.SecurityProfile = positcov1beta1.SecurityProfileRestricted
+	containers, _ = p.seedHomesInitContainer(w)
+	assert.Empty(t, containers)
+}
+
+func TestWorkbenchReconciler_Users(t *testing.T) {
+	ctx := context.Background()
+	ns := "posit-team"
+	name := "workbench-users"
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	scheme := runtime.NewScheme()
+	loadSchemes(scheme)
+	cli := fakectrl.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&positcov1beta1.WorkbenchUser{}, &positcov1beta1.WorkbenchGroup{}).
+		Build()
+	r := &WorkbenchReconciler{Client: cli, Scheme: scheme, Log: product.NewSimpleLogger()}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: name}}
+
+	alice := workbenchUser("alice", 2001, now, "data")
+	require.NoError(t, cli.Create(ctx, &alice))
+	bob := workbenchUser("bob", 2001, now.Add(time.Hour))
+	require.NoError(t, cli.Create(ctx, &bob))
+	carol := workbenchUser("carol", 2003, now)
+	carol.Labels["site"] = "other"
+	require.NoError(t, cli.Create(ctx, &carol))
+	data := workbenchGroup("data", 3001, now)
+	require.NoError(t, cli.Create(ctx, &data))
+
+	wb := defineDefaultWorkbench(t, ns, name)
+	wb.Spec.UserSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"site": "main"}}
+
+	projected, err := r.reconcileUsers(ctx, req, wb)
+	require.NoError(t, err)
+	require.NotNil(t, projected)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: ns, Name: wb.UsersConfigmapName()}, cm))
+	assert.Equal(t, "alice:x:2001:2001::/home/alice:/bin/bash\n", cm.Data["passwd"])
+	assert.Equal(t, "data:x:3001:alice\nalice:x:2001:\n", cm.Data["group"])
+
+	// the status lists the sites that project the user, and the conflicts
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(&alice), &alice))
+	assert.Equal(t, []string{name}, alice.Status.Sites)
+	assert.Empty(t, alice.Status.Conflicts)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(&bob), &bob))
+	assert.Empty(t, bob.Status.Sites)
+	assert.Equal(t, []string{"uid 2001 is also used by WorkbenchUser alice"}, bob.Status.Conflicts)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(&carol), &carol))
+	assert.Empty(t, carol.Status.Sites)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(&data), &data))
+	assert.Equal(t, []string{name}, data.Status.Sites)
+
+	// without a selector, the users are no longer projected
+	wb.Spec.UserSelector = nil
+	projected, err = r.reconcileUsers(ctx, req, wb)
+	require.NoError(t, err)
+	assert.Nil(t, projected)
+	err = cli.Get(ctx, client.ObjectKey{Namespace: ns, Name: wb.UsersConfigmapName()}, cm)
+	assert.Error(t, err)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(&alice), &alice))
+	assert.Empty(t, alice.Status.Sites)
+}
@@ -0,0 +1,239 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/product"
+	"github.com/rstudio/goex/ptr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// RouteOwner is any object that can own (and label) routing objects like HTTPRoutes and Traefik Middlewares
+type RouteOwner interface {
+	product.KubernetesLabelser
+	client.Object
+}
+
+// HTTPRouteOptions describes the HTTPRoute that exposes a single product Service
+type HTTPRouteOptions struct {
+	Hostnames   []string
+	ServiceName string
+	ServicePort int32
+
+	// RequestHeaders are set on every request before it is forwarded to the Service
+	RequestHeaders map[string]string
+
+	// ResponseHeaders are set on every response returned to the client
+	ResponseHeaders map[string]string
+
+	// StickyCookie enables cookie-based session persistence using a cookie with this name
+	StickyCookie string
+
+	// Timeout is the request timeout for the route
+	Timeout *metav1.Duration
+
+	// PathPrefix limits the route to requests under this path
+	PathPrefix string
+
+	// StripPathPrefix rewrites PathPrefix to "/" (and sets X-Forwarded-Prefix) before requests are forwarded
+	StripPathPrefix bool
+}
+
+// ForwardedRequestHeaders mirrors the headers set by the Traefik forward middleware
+func ForwardedRequestHeaders() map[string]string {
+	return map[string]string{
+		"X-Forwarded-Port":  "443",
+		"X-Forwarded-Proto": "https",
+	}
+}
+
+// ForwardedRequestHeadersWithHost mirrors the headers set by DeployTraefikForwardMiddlewareWithHost
+func ForwardedRequestHeadersWithHost(host string) map[string]string {
+	headers := ForwardedRequestHeaders()
+	headers["X-Forwarded-Host"] = host
+	headers["X-Forwarded-For"] = fmt.Sprintf("https://%s", host)
+	return headers
+}
+
+func httpHeaders(headers map[string]string) []gatewayv1.HTTPHeader {
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	// sort so that the generated spec is stable across reconciles
+	sort.Strings(keys)
+
+	output := make([]gatewayv1.HTTPHeader, 0, len(keys))
+	for _, k := range keys {
+		output = append(output, gatewayv1.HTTPHeader{
+			Name:  gatewayv1.HTTPHeaderName(k),
+			Value: headers[k],
+		})
+	}
+	return output
+}
+
+// GatewayParentReference translates the configured Gateway into an HTTPRoute parent reference
+func GatewayParentReference(routing *v1beta1.RoutingConfig) (gatewayv1.ParentReference, error) {
+	if routing == nil || routing.Gateway == nil || routing.Gateway.Name == "" {
+		return gatewayv1.ParentReference{}, fmt.Errorf("routing mode %q requires a gateway name", v1beta1.RoutingModeGateway)
+	}
+	ref := gatewayv1.ParentReference{
+		Name: gatewayv1.ObjectName(routing.Gateway.Name),
+	}
+	if routing.Gateway.Namespace != "" {
+		ref.Namespace = ptr.To(gatewayv1.Namespace(routing.Gateway.Namespace))
+	}
+	if routing.Gateway.SectionName != "" {
+		ref.SectionName = ptr.To(gatewayv1.SectionName(routing.Gateway.SectionName))
+	}
+	return ref, nil
+}
+
+// BuildHTTPRouteSpec builds the spec for an HTTPRoute that sends all traffic for the given hostnames (and
+// path prefix) to a single Service
+func BuildHTTPRouteSpec(routing *v1beta1.RoutingConfig, opts HTTPRouteOptions) (gatewayv1.HTTPRouteSpec, error) {
+	parentRef, err := GatewayParentReference(routing)
+	if err != nil {
+		return gatewayv1.HTTPRouteSpec{}, err
+	}
+
+	hostnames := make([]gatewayv1.Hostname, 0, len(opts.Hostnames))
+	for _, h := range opts.Hostnames {
+		if h != "" {
+			hostnames = append(hostnames, gatewayv1.Hostname(h))
+		}
+	}
+
+	pathPrefix := "/"
+	if opts.PathPrefix != "" {
+		pathPrefix = opts.PathPrefix
+	}
+	stripPathPrefix := opts.StripPathPrefix && pathPrefix != "/"
+
+	requestHeaders := map[string]string{}
+	for k, v := range opts.RequestHeaders {
+		requestHeaders[k] = v
+	}
+	if stripPathPrefix {
+		requestHeaders["X-Forwarded-Prefix"] = pathPrefix
+	}
+
+	var filters []gatewayv1.HTTPRouteFilter
+	if stripPathPrefix {
+		filters = append(filters, gatewayv1.HTTPRouteFilter{
+			Type: gatewayv1.HTTPRouteFilterURLRewrite,
+			URLRewrite: &gatewayv1.HTTPURLRewriteFilter{
+				Path: &gatewayv1.HTTPPathModifier{
+					Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+					ReplacePrefixMatch: ptr.To("/"),
+				},
+			},
+		})
+	}
+	if len(requestHeaders) > 0 {
+		filters = append(filters, gatewayv1.HTTPRouteFilter{
+			Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+				Set: httpHeaders(requestHeaders),
+			},
+		})
+	}
+	if len(opts.ResponseHeaders) > 0 {
+		filters = append(filters, gatewayv1.HTTPRouteFilter{
+			Type: gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+			ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+				Set: httpHeaders(opts.ResponseHeaders),
+			},
+		})
+	}
+
+	rule := gatewayv1.HTTPRouteRule{
+		Matches: []gatewayv1.HTTPRouteMatch{
+			{
+				Path: &gatewayv1.HTTPPathMatch{
+					Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+					Value: ptr.To(pathPrefix),
+				},
+			},
+		},
+		Filters: filters,
+		BackendRefs: []gatewayv1.HTTPBackendRef{
+			{
+				BackendRef: gatewayv1.BackendRef{
+					BackendObjectReference: gatewayv1.BackendObjectReference{
+						Name: gatewayv1.ObjectName(opts.ServiceName),
+						Port: ptr.To(gatewayv1.PortNumber(opts.ServicePort)),
+					},
+				},
+			},
+		},
+	}
+	if opts.Timeout != nil {
+		rule.Timeouts = &gatewayv1.HTTPRouteTimeouts{
+			Request: ptr.To(gatewayv1.Duration(fmt.Sprintf("%ds", timeoutSeconds(opts.Timeout)))),
+		}
+	}
+	if opts.StickyCookie != "" {
+		rule.SessionPersistence = &gatewayv1.SessionPersistence{
+			SessionName: ptr.To(opts.StickyCookie),
+			Type:        ptr.To(gatewayv1.CookieBasedSessionPersistence),
+		}
+	}
+
+	return gatewayv1.HTTPRouteSpec{
+		CommonRouteSpec: gatewayv1.CommonRouteSpec{
+			ParentRefs: []gatewayv1.ParentReference{parentRef},
+		},
+		Hostnames: hostnames,
+		Rules:     []gatewayv1.HTTPRouteRule{rule},
+	}, nil
+}
+
+func DeployHTTPRoute(ctx context.Context, req ctrl.Request, c client.Client, scheme *runtime.Scheme, l logr.Logger, name string, owner RouteOwner, routing *v1beta1.RoutingConfig, opts HTTPRouteOptions) error {
+	l = l.WithValues(
+		"function", "DeployHTTPRoute",
+	)
+
+	spec, err := BuildHTTPRouteSpec(routing, opts)
+	if err != nil {
+		l.Error(err, "Error building HTTPRoute spec")
+		return err
+	}
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: req.Namespace,
+		},
+	}
+	if _, err := CreateOrUpdateResource(ctx, c, scheme, l, route, owner, func() error {
+		route.Labels = owner.KubernetesLabels()
+		route.Annotations = ExternalDNSAnnotations(routing.GetExternalDNS(), opts.Hostnames)
+		route.Spec = spec
+		return nil
+	}); err != nil {
+		l.Error(err, "Error creating or updating HTTPRoute")
+		return err
+	}
+
+	return nil
+}
+
+// CleanupHTTPRoute removes an HTTPRoute left behind by a previous routing mode. Clusters without the
+// Gateway API CRDs installed have nothing to clean up, so a missing kind is not an error.
+func CleanupHTTPRoute(ctx context.Context, r product.SomeReconciler, l logr.Logger, key client.ObjectKey) error {
+	if err := BasicDelete(ctx, r, l, key, &gatewayv1.HTTPRoute{}); err != nil && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
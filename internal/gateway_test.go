@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestRoutingConfigUseGateway(t *testing.T) {
+	var nilRouting *v1beta1.RoutingConfig
+	assert.False(t, nilRouting.UseGateway())
+	assert.False(t, (&v1beta1.RoutingConfig{}).UseGateway())
+	assert.False(t, (&v1beta1.RoutingConfig{Mode: v1beta1.RoutingModeIngress}).UseGateway())
+	assert.True(t, (&v1beta1.RoutingConfig{Mode: v1beta1.RoutingModeGateway}).UseGateway())
+}
+
+func TestBuildHTTPRouteSpec(t *testing.T) {
+	routing := &v1beta1.RoutingConfig{
+		Mode: v1beta1.RoutingModeGateway,
+		Gateway: &v1beta1.GatewayParentRef{
+			Name:        "shared",
+			Namespace:   "gateway-system",
+			SectionName: "https",
+		},
+	}
+
+	spec, err := BuildHTTPRouteSpec(routing, HTTPRouteOptions{
+		Hostnames:   []string{"workbench.example.com"},
+		ServiceName: "site-workbench",
+		ServicePort: 80,
+		RequestHeaders: map[string]string{
+			"X-Forwarded-Proto": "https",
+			"X-Forwarded-Port":  "443",
+		},
+		ResponseHeaders: map[string]string{
+			"Content-Security-Policy": "frame-ancestors example.com 'self';",
+		},
+		StickyCookie: "site-workbench",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, spec.ParentRefs, 1)
+	assert.Equal(t, gatewayv1.ObjectName("shared"), spec.ParentRefs[0].Name)
+	assert.Equal(t, gatewayv1.Namespace("gateway-system"), *spec.ParentRefs[0].Namespace)
+	assert.Equal(t, gatewayv1.SectionName("https"), *spec.ParentRefs[0].SectionName)
+	assert.Equal(t, []gatewayv1.Hostname{"workbench.example.com"}, spec.Hostnames)
+
+	require.Len(t, spec.Rules, 1)
+	rule := spec.Rules[0]
+	require.Len(t, rule.Filters, 2)
+	assert.Equal(t, gatewayv1.HTTPRouteFilterRequestHeaderModifier, rule.Filters[0].Type)
+	// headers are sorted so that the spec is stable
+	assert.Equal(t, []gatewayv1.HTTPHeader{
+		{Name: "X-Forwarded-Port", Value: "443"},
+		{Name: "X-Forwarded-Proto", Value: "https"},
+	}, rule.Filters[0].RequestHeaderModifier.Set)
+	assert.Equal(t, gatewayv1.HTTPRouteFilterResponseHeaderModifier, rule.Filters[1].Type)
+
+	require.Len(t, rule.BackendRefs, 1)
+	assert.Equal(t, gatewayv1.ObjectName("site-workbench"), rule.BackendRefs[0].Name)
+	assert.Equal(t, gatewayv1.PortNumber(80), *rule.BackendRefs[0].Port)
+
+	require.NotNil(t, rule.SessionPersistence)
+	assert.Equal(t, "site-workbench", *rule.SessionPersistence.SessionName)
+	assert.Equal(t, gatewayv1.CookieBasedSessionPersistence, *rule.SessionPersistence.Type)
+}
+
+func TestBuildHTTPRouteSpecWithoutGateway(t *testing.T) {
+	_, err := BuildHTTPRouteSpec(&v1beta1.RoutingConfig{Mode: v1beta1.RoutingModeGateway}, HTTPRouteOptions{
+		Hostnames:   []string{"connect.example.com"},
+		ServiceName: "site-connect",
+		ServicePort: 80,
+	})
+	assert.Error(t, err)
+}
+
+func TestBuildHTTPRouteSpecWithoutOptionalFields(t *testing.T) {
+	spec, err := BuildHTTPRouteSpec(&v1beta1.RoutingConfig{
+		Mode:    v1beta1.RoutingModeGateway,
+		Gateway: &v1beta1.GatewayParentRef{Name: "shared"},
+	}, HTTPRouteOptions{
+		Hostnames:   []string{"packagemanager.example.com"},
+		ServiceName: "site-packagemanager",
+		ServicePort: 80,
+	})
+	require.NoError(t, err)
+
+	assert.Nil(t, spec.ParentRefs[0].Namespace)
+	assert.Nil(t, spec.ParentRefs[0].SectionName)
+	assert.Empty(t, spec.Rules[0].Filters)
+	assert.Nil(t, spec.Rules[0].SessionPersistence)
+}
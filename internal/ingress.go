@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const ExternalDNSHostnameKey = "external-dns.alpha.kubernetes.io/hostname"
+const ExternalDNSTargetKey = "external-dns.alpha.kubernetes.io/target"
+const ExternalDNSTTLKey = "external-dns.alpha.kubernetes.io/ttl"
+
+// IngressHosts returns the primary host followed by any aliases, skipping empty and duplicate hosts
+func IngressHosts(primary string, aliases []string) []string {
+	var hosts []string
+	seen := map[string]bool{}
+	for _, h := range append([]string{primary}, aliases...) {
+		if h == "" || seen[h] {
+			continue
+		}
+		seen[h] = true
+		hosts = append(hosts, h)
+	}
+	return hosts
+}
+
+// IngressRules returns a rule for each host that sends path to the backend
+func IngressRules(hosts []string, path string, pathType networkingv1.PathType, backend networkingv1.IngressBackend) []networkingv1.IngressRule {
+	rules := make([]networkingv1.IngressRule, 0, len(hosts))
+	for _, h := range hosts {
+		rules = append(rules, networkingv1.IngressRule{
+			Host: h,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{
+							Path:     path,
+							PathType: &pathType,
+							Backend:  backend,
+						},
+					},
+				},
+			},
+		})
+	}
+	return rules
+}
+
+// IngressTLS lists every host under the TLS Secret, or returns nil (TLS terminated elsewhere) when there is no Secret
+func IngressTLS(hosts []string, secretName string) []networkingv1.IngressTLS {
+	if secretName == "" || len(hosts) == 0 {
+		return nil
+	}
+	return []networkingv1.IngressTLS{
+		{
+			Hosts:      hosts,
+			SecretName: secretName,
+		},
+	}
+}
+
+// ExternalDNSAnnotations returns the external-dns annotations for the hosts, or no annotations when external-dns
+// is not configured
+func ExternalDNSAnnotations(config *v1beta1.ExternalDNSConfig, hosts []string) map[string]string {
+	annotations := map[string]string{}
+	if config == nil || len(hosts) == 0 {
+		return annotations
+	}
+
+	annotations[ExternalDNSHostnameKey] = strings.Join(hosts, ",")
+	if config.Target != "" {
+		annotations[ExternalDNSTargetKey] = config.Target
+	}
+	if config.TTL != nil {
+		annotations[ExternalDNSTTLKey] = strconv.FormatInt(*config.TTL, 10)
+	}
+	return annotations
+}
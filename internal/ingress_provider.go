@@ -0,0 +1,156 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/go-logr/logr"
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IngressOptions describes the proxy behaviour that a product needs, independent of the ingress controller
+// (or Gateway) that ends up providing it
+type IngressOptions struct {
+	// ForwardHeaders sets X-Forwarded-Port and X-Forwarded-Proto so that products behind a TLS terminating
+	// proxy generate https URLs
+	ForwardHeaders bool
+
+	// ForwardedHost additionally sets X-Forwarded-Host and X-Forwarded-For for this host
+	ForwardedHost string
+
+	// RequestHeaders are additional headers set on every request
+	RequestHeaders map[string]string
+
+	// ContentSecurityPolicy is returned as the Content-Security-Policy response header
+	ContentSecurityPolicy string
+
+	// StickyCookie enables cookie-based session affinity using a cookie with this name
+	StickyCookie string
+
+	// Timeout is how long the proxy waits for the product to respond
+	Timeout *metav1.Duration
+
+	// PathPrefix is the path the product is served under. Empty serves the product at the root of its host
+	PathPrefix string
+
+	// StripPathPrefix removes PathPrefix before requests are forwarded (and sets X-Forwarded-Prefix), for
+	// products that expect to be served at the root and generate URLs from their configured address
+	StripPathPrefix bool
+
+	// Policy adds rate limiting, an IP allow-list, a maximum body size and retries. Only Traefik supports it
+	Policy *v1beta1.IngressPolicy
+}
+
+func (o IngressOptions) stripsPathPrefix() bool {
+	return o.StripPathPrefix && o.PathPrefix != ""
+}
+
+func (o IngressOptions) forwardedHeaders() map[string]string {
+	if o.ForwardedHost != "" {
+		return ForwardedRequestHeadersWithHost(o.ForwardedHost)
+	}
+	if o.ForwardHeaders {
+		return ForwardedRequestHeaders()
+	}
+	return map[string]string{}
+}
+
+// HTTPRouteOptions translates the options into those for a Gateway API HTTPRoute
+func (o IngressOptions) HTTPRouteOptions(hostnames []string, serviceName string, servicePort int32) HTTPRouteOptions {
+	requestHeaders := o.forwardedHeaders()
+	for k, v := range o.RequestHeaders {
+		requestHeaders[k] = v
+	}
+
+	var responseHeaders map[string]string
+	if o.ContentSecurityPolicy != "" {
+		responseHeaders = map[string]string{
+			"Content-Security-Policy": o.ContentSecurityPolicy,
+		}
+	}
+
+	return HTTPRouteOptions{
+		Hostnames:       hostnames,
+		ServiceName:     serviceName,
+		ServicePort:     servicePort,
+		RequestHeaders:  requestHeaders,
+		ResponseHeaders: responseHeaders,
+		StickyCookie:    o.StickyCookie,
+		Timeout:         o.Timeout,
+		PathPrefix:      o.PathPrefix,
+		StripPathPrefix: o.StripPathPrefix,
+	}
+}
+
+// timeoutSeconds rounds a timeout up to whole seconds, which is the resolution most ingress controllers accept
+func timeoutSeconds(d *metav1.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
+
+// IngressProvider translates IngressOptions into the annotations (and any supporting objects) understood by
+// a particular ingress controller
+type IngressProvider interface {
+	// DeployResources creates any objects (i.e. Traefik Middlewares) that the annotations refer to
+	DeployResources(ctx context.Context, req ctrl.Request, c client.Client, scheme *runtime.Scheme, l logr.Logger, name string, owner RouteOwner, opts IngressOptions) error
+
+	// IngressAnnotations returns the annotations for the Ingress named name
+	IngressAnnotations(namespace, name string, opts IngressOptions) map[string]string
+
+	// ServiceAnnotations returns the annotations for the Service behind the Ingress named name
+	ServiceAnnotations(namespace, name string, opts IngressOptions) map[string]string
+
+	// IngressPath returns the path (and path type) of the Ingress rule that serves the product
+	IngressPath(opts IngressOptions) (string, networkingv1.PathType)
+}
+
+// prefixIngressPath is the Ingress path for controllers that strip prefixes without special path syntax
+func prefixIngressPath(opts IngressOptions) (string, networkingv1.PathType) {
+	if opts.PathPrefix == "" {
+		return "/", networkingv1.PathTypePrefix
+	}
+	return opts.PathPrefix, networkingv1.PathTypePrefix
+}
+
+// NewIngressProvider returns the IngressProvider selected by the routing configuration
+func NewIngressProvider(routing *v1beta1.RoutingConfig) IngressProvider {
+	switch routing.GetProvider() {
+	case v1beta1.IngressProviderNginx:
+		return NginxIngressProvider{}
+	case v1beta1.IngressProviderAlb:
+		return AlbIngressProvider{}
+	default:
+		return TraefikIngressProvider{}
+	}
+}
+
+// listAnnotationSeparators are the annotations whose values are lists, so user supplied values are combined
+// with (rather than replaced by) the values generated by the operator
+var listAnnotationSeparators = map[string]string{
+	TraefikMiddlewaresKey:         ",",
+	NginxConfigurationSnippetKey:  "\n",
+	AlbLoadBalancerAttributesKey:  ",",
+	AlbTargetGroupAttributesKey:   ",",
+	AlbHTTPSListenerAttributesKey: ",",
+}
+
+// MergeIngressAnnotations overlays user supplied annotations onto those generated by an IngressProvider.
+// List valued annotations are combined, with the user supplied values first.
+func MergeIngressAnnotations(generated, user map[string]string) map[string]string {
+	annotations := map[string]string{}
+	for k, v := range generated {
+		annotations[k] = v
+	}
+	for k, v := range user {
+		if sep, ok := listAnnotationSeparators[k]; ok && annotations[k] != "" {
+			v = fmt.Sprintf("%s%s%s", v, sep, annotations[k])
+		}
+		annotations[k] = v
+	}
+	return annotations
+}
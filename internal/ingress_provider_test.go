@@ -0,0 +1,254 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func workbenchLikeIngressOptions() IngressOptions {
+	return IngressOptions{
+		ForwardHeaders: true,
+		RequestHeaders: map[string]string{
+			"X-Rstudio-Request": "https://workbench.example.com",
+		},
+		ContentSecurityPolicy: "frame-ancestors example.com 'self';",
+		StickyCookie:          "site-workbench",
+		Timeout:               &metav1.Duration{Duration: 90 * time.Second},
+	}
+}
+
+func TestNewIngressProvider(t *testing.T) {
+	assert.IsType(t, TraefikIngressProvider{}, NewIngressProvider(nil))
+	assert.IsType(t, TraefikIngressProvider{}, NewIngressProvider(&v1beta1.RoutingConfig{}))
+	assert.IsType(t, NginxIngressProvider{}, NewIngressProvider(&v1beta1.RoutingConfig{Provider: v1beta1.IngressProviderNginx}))
+	assert.IsType(t, AlbIngressProvider{}, NewIngressProvider(&v1beta1.RoutingConfig{Provider: v1beta1.IngressProviderAlb}))
+}
+
+func TestTraefikIngressProvider(t *testing.T) {
+	p := TraefikIngressProvider{}
+	opts := workbenchLikeIngressOptions()
+
+	ingress := p.IngressAnnotations("ns", "site-workbench", opts)
+	assert.Equal(t, map[string]string{
+		TraefikMiddlewaresKey: "ns-site-workbench-forward@kubernetescrd,ns-site-workbench-csp@kubernetescrd,ns-site-workbench-headers@kubernetescrd",
+	}, ingress)
+
+	service := p.ServiceAnnotations("ns", "site-workbench", opts)
+	assert.Equal(t, "true", service["traefik.ingress.kubernetes.io/service.sticky.cookie"])
+	assert.Equal(t, "site-workbench", service["traefik.ingress.kubernetes.io/service.sticky.cookie.name"])
+	assert.Equal(t, "ns-site-workbench-transport@kubernetescrd", service[TraefikServersTransportKey])
+
+	// nothing requested, nothing generated
+	assert.Empty(t, p.IngressAnnotations("ns", "site-packagemanager", IngressOptions{}))
+	assert.Empty(t, p.ServiceAnnotations("ns", "site-packagemanager", IngressOptions{}))
+}
+
+func TestNginxIngressProvider(t *testing.T) {
+	p := NginxIngressProvider{}
+	opts := workbenchLikeIngressOptions()
+
+	ingress := p.IngressAnnotations("ns", "site-workbench", opts)
+	assert.Equal(t, "cookie", ingress["nginx.ingress.kubernetes.io/affinity"])
+	assert.Equal(t, "site-workbench", ingress["nginx.ingress.kubernetes.io/session-cookie-name"])
+	assert.Equal(t, "90", ingress["nginx.ingress.kubernetes.io/proxy-read-timeout"])
+	assert.Equal(t, "90", ingress["nginx.ingress.kubernetes.io/proxy-send-timeout"])
+	assert.Equal(t,
+		"proxy_set_header X-Rstudio-Request \"https://workbench.example.com\";\n"+
+			"more_set_headers \"Content-Security-Policy: frame-ancestors example.com 'self';\";",
+		ingress[NginxConfigurationSnippetKey],
+	)
+
+	assert.Empty(t, p.ServiceAnnotations("ns", "site-workbench", opts))
+}
+
+func TestAlbIngressProvider(t *testing.T) {
+	p := AlbIngressProvider{}
+	opts := workbenchLikeIngressOptions()
+
+	ingress := p.IngressAnnotations("ns", "site-workbench", opts)
+	assert.Equal(t, "ip", ingress["alb.ingress.kubernetes.io/target-type"])
+	assert.Contains(t, ingress[AlbTargetGroupAttributesKey], "stickiness.enabled=true")
+	assert.Equal(t, "idle_timeout.timeout_seconds=90", ingress[AlbLoadBalancerAttributesKey])
+	// response headers are set by the listener
+	assert.Equal(t,
+		"routing.http.response.content_security_policy.header_value=frame-ancestors example.com 'self';",
+		ingress[AlbHTTPSListenerAttributesKey],
+	)
+
+	assert.Empty(t, p.ServiceAnnotations("ns", "site-workbench", opts))
+
+	// request headers cannot be set
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "site"}}
+	err := p.DeployResources(context.TODO(), req, nil, nil, logr.Discard(), "site-workbench", nil, opts)
+	assert.ErrorContains(t, err, "request headers")
+	err = p.DeployResources(context.TODO(), req, nil, nil, logr.Discard(), "site-keycloak", nil, IngressOptions{ForwardedHost: "key.example.com"})
+	assert.ErrorContains(t, err, "request headers")
+
+	// nor can path prefixes be stripped
+	err = p.DeployResources(context.TODO(), req, nil, nil, logr.Discard(), "site-connect", nil, IngressOptions{PathPrefix: "/connect", StripPathPrefix: true})
+	assert.ErrorContains(t, err, "/connect")
+}
+
+func TestMergeIngressAnnotations(t *testing.T) {
+	res := MergeIngressAnnotations(
+		map[string]string{
+			TraefikMiddlewaresKey: "ns-ours@kubernetescrd",
+			"generated":           "value",
+		},
+		map[string]string{
+			TraefikMiddlewaresKey: "ns-theirs@kubernetescrd",
+			"generated":           "overridden",
+			"user":                "value",
+		},
+	)
+	assert.Equal(t, map[string]string{
+		TraefikMiddlewaresKey: "ns-theirs@kubernetescrd,ns-ours@kubernetescrd",
+		"generated":           "overridden",
+		"user":                "value",
+	}, res)
+
+	// list valued annotations are passed through when the provider does not generate them
+	res = MergeIngressAnnotations(map[string]string{}, map[string]string{AlbLoadBalancerAttributesKey: "a=b"})
+	assert.Equal(t, "a=b", res[AlbLoadBalancerAttributesKey])
+}
+
+func TestIngressOptionsHTTPRouteOptions(t *testing.T) {
+	opts := workbenchLikeIngressOptions().HTTPRouteOptions([]string{"workbench.example.com"}, "site-workbench", 80)
+
+	assert.Equal(t, map[string]string{
+		"X-Forwarded-Port":  "443",
+		"X-Forwarded-Proto": "https",
+		"X-Rstudio-Request": "https://workbench.example.com",
+	}, opts.RequestHeaders)
+	assert.Equal(t, "frame-ancestors example.com 'self';", opts.ResponseHeaders["Content-Security-Policy"])
+	assert.Equal(t, "site-workbench", opts.StickyCookie)
+
+	spec, err := BuildHTTPRouteSpec(&v1beta1.RoutingConfig{
+		Mode:    v1beta1.RoutingModeGateway,
+		Gateway: &v1beta1.GatewayParentRef{Name: "shared"},
+	}, opts)
+	require.NoError(t, err)
+	require.NotNil(t, spec.Rules[0].Timeouts)
+	assert.EqualValues(t, "90s", *spec.Rules[0].Timeouts.Request)
+}
+
+func TestIngressProviderPathPrefix(t *testing.T) {
+	opts := IngressOptions{
+		ForwardHeaders:  true,
+		PathPrefix:      "/connect",
+		StripPathPrefix: true,
+	}
+
+	path, pathType := TraefikIngressProvider{}.IngressPath(opts)
+	assert.Equal(t, "/connect", path)
+	assert.Equal(t, networkingv1.PathTypePrefix, pathType)
+	// the prefix must be stripped before the forwarded headers are set
+	assert.Equal(t,
+		"ns-site-connect-prefix@kubernetescrd,ns-site-connect-forward@kubernetescrd",
+		TraefikIngressProvider{}.IngressAnnotations("ns", "site-connect", opts)[TraefikMiddlewaresKey],
+	)
+
+	path, pathType = NginxIngressProvider{}.IngressPath(opts)
+	assert.Equal(t, "/connect(/|$)(.*)", path)
+	assert.Equal(t, networkingv1.PathTypeImplementationSpecific, pathType)
+	nginx := NginxIngressProvider{}.IngressAnnotations("ns", "site-connect", opts)
+	assert.Equal(t, "/$2", nginx["nginx.ingress.kubernetes.io/rewrite-target"])
+	assert.Equal(t, "/connect", nginx["nginx.ingress.kubernetes.io/x-forwarded-prefix"])
+
+	// products that serve their own path keep the prefix
+	opts.StripPathPrefix = false
+	assert.Empty(t, TraefikIngressProvider{}.middlewareNames("site-keycloak", IngressOptions{PathPrefix: "/key"}))
+	path, pathType = NginxIngressProvider{}.IngressPath(opts)
+	assert.Equal(t, "/connect", path)
+	assert.Equal(t, networkingv1.PathTypePrefix, pathType)
+
+	path, _ = AlbIngressProvider{}.IngressPath(IngressOptions{})
+	assert.Equal(t, "/", path)
+}
+
+func TestBuildHTTPRouteSpecPathPrefix(t *testing.T) {
+	routing := &v1beta1.RoutingConfig{
+		Mode:    v1beta1.RoutingModeGateway,
+		Gateway: &v1beta1.GatewayParentRef{Name: "shared"},
+	}
+	opts := IngressOptions{PathPrefix: "/workbench", StripPathPrefix: true}.HTTPRouteOptions([]string{"posit.example.com"}, "site-workbench", 80)
+
+	spec, err := BuildHTTPRouteSpec(routing, opts)
+	require.NoError(t, err)
+	rule := spec.Rules[0]
+	assert.Equal(t, "/workbench", *rule.Matches[0].Path.Value)
+	require.Len(t, rule.Filters, 2)
+	assert.Equal(t, "/", *rule.Filters[0].URLRewrite.Path.ReplacePrefixMatch)
+	assert.EqualValues(t, "X-Forwarded-Prefix", rule.Filters[1].RequestHeaderModifier.Set[0].Name)
+	assert.Equal(t, "/workbench", rule.Filters[1].RequestHeaderModifier.Set[0].Value)
+}
+
+func TestTraefikIngressProviderPolicy(t *testing.T) {
+	maxBodySize := resource.MustParse("100Mi")
+	opts := workbenchLikeIngressOptions()
+	opts.Policy = &v1beta1.IngressPolicy{
+		RateLimit:   &v1beta1.IngressRateLimit{Average: 100, Burst: 50},
+		IPAllowList: &v1beta1.IngressIPAllowList{SourceRanges: []string{"10.0.0.0/8"}},
+		MaxBodySize: &maxBodySize,
+		Retry:       &v1beta1.IngressRetry{Attempts: 3},
+	}
+
+	// unwanted requests are rejected first, and retries wrap the request as it reaches the product
+	assert.Equal(t, []string{
+		"site-connect-ipallowlist",
+		"site-connect-ratelimit",
+		"site-connect-buffering",
+		"site-connect-forward",
+		"site-connect-csp",
+		"site-connect-headers",
+		"site-connect-retry",
+	}, TraefikIngressProvider{}.middlewareNames("site-connect", opts))
+
+	opts = IngressOptions{Policy: &v1beta1.IngressPolicy{RateLimit: &v1beta1.IngressRateLimit{Average: 10}}}
+	assert.Equal(t,
+		"ns-site-packagemanager-ratelimit@kubernetescrd",
+		TraefikIngressProvider{}.IngressAnnotations("ns", "site-packagemanager", opts)[TraefikMiddlewaresKey],
+	)
+	assert.Empty(t, TraefikIngressProvider{}.middlewareNames("site-packagemanager", IngressOptions{Policy: &v1beta1.IngressPolicy{}}))
+}
+
+func TestIngressPolicyAnnotations(t *testing.T) {
+	maxBodySize := resource.MustParse("10Mi")
+	opts := IngressOptions{
+		Policy: &v1beta1.IngressPolicy{
+			RateLimit:   &v1beta1.IngressRateLimit{Average: 30, Burst: 100, Period: &metav1.Duration{Duration: time.Minute}},
+			IPAllowList: &v1beta1.IngressIPAllowList{SourceRanges: []string{"203.0.113.0/24", "198.51.100.7"}},
+			MaxBodySize: &maxBodySize,
+			Retry:       &v1beta1.IngressRetry{Attempts: 3},
+		},
+	}
+
+	nginx := NginxIngressProvider{}.IngressAnnotations("ns", "site-connect", opts)
+	assert.Equal(t, "203.0.113.0/24,198.51.100.7", nginx["nginx.ingress.kubernetes.io/whitelist-source-range"])
+	assert.Equal(t, "30", nginx["nginx.ingress.kubernetes.io/limit-rpm"])
+	assert.Equal(t, "4", nginx["nginx.ingress.kubernetes.io/limit-burst-multiplier"])
+	assert.Equal(t, "10485760", nginx["nginx.ingress.kubernetes.io/proxy-body-size"])
+	assert.Equal(t, "3", nginx["nginx.ingress.kubernetes.io/proxy-next-upstream-tries"])
+
+	// whole requests per second are preferred
+	opts.Policy.RateLimit = &v1beta1.IngressRateLimit{Average: 120, Period: &metav1.Duration{Duration: time.Minute}}
+	nginx = NginxIngressProvider{}.IngressAnnotations("ns", "site-connect", opts)
+	assert.Equal(t, "2", nginx["nginx.ingress.kubernetes.io/limit-rps"])
+	assert.NotContains(t, nginx, "nginx.ingress.kubernetes.io/limit-rpm")
+	assert.NotContains(t, nginx, "nginx.ingress.kubernetes.io/limit-burst-multiplier")
+
+	// the ALB only enforces the allow-list, which takes CIDRs
+	alb := AlbIngressProvider{}.IngressAnnotations("ns", "site-connect", opts)
+	assert.Equal(t, "203.0.113.0/24,198.51.100.7/32", alb[AlbInboundCidrsKey])
+}
@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/rstudio/goex/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+func TestIngressHosts(t *testing.T) {
+	assert.Equal(t, []string{"a.example.com"}, IngressHosts("a.example.com", nil))
+	assert.Equal(t,
+		[]string{"a.example.com", "b.example.com"},
+		IngressHosts("a.example.com", []string{"", "b.example.com", "a.example.com"}),
+	)
+	assert.Nil(t, IngressHosts("", nil))
+}
+
+func TestIngressRulesAndTLS(t *testing.T) {
+	hosts := []string{"a.example.com", "b.example.com"}
+	backend := networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{Name: "svc"},
+	}
+
+	rules := IngressRules(hosts, "/", networkingv1.PathTypePrefix, backend)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "b.example.com", rules[1].Host)
+	assert.Equal(t, "svc", rules[1].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, networkingv1.PathTypePrefix, *rules[1].HTTP.Paths[0].PathType)
+
+	assert.Nil(t, IngressTLS(hosts, ""))
+	assert.Equal(t, []networkingv1.IngressTLS{{Hosts: hosts, SecretName: "tls"}}, IngressTLS(hosts, "tls"))
+}
+
+func TestExternalDNSAnnotations(t *testing.T) {
+	hosts := []string{"a.example.com", "b.example.com"}
+
+	assert.Empty(t, ExternalDNSAnnotations(nil, hosts))
+	assert.Equal(t, map[string]string{
+		ExternalDNSHostnameKey: "a.example.com,b.example.com",
+	}, ExternalDNSAnnotations(&v1beta1.ExternalDNSConfig{}, hosts))
+	assert.Equal(t, map[string]string{
+		ExternalDNSHostnameKey: "a.example.com,b.example.com",
+		ExternalDNSTargetKey:   "lb.example.com",
+		ExternalDNSTTLKey:      "60",
+	}, ExternalDNSAnnotations(&v1beta1.ExternalDNSConfig{
+		Target: "lb.example.com",
+		TTL:    ptr.To(int64(60)),
+	}, hosts))
+}
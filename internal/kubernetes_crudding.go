@@ -255,6 +255,7 @@ func BasicCreateOrUpdate(ctx context.Context, r product.SomeReconciler, l logr.L
 // The mutateFn should set all desired fields on obj. It will be called after the object is fetched
 // (for updates) or initialized (for creates). The function automatically:
 //   - Sets the controller reference if owner is provided
+//   - Applies the overrides of the owner that target obj
 //   - Validates the managed-by label for existing objects
 //   - Logs the operation result (created, updated, or unchanged)
 func CreateOrUpdateResource(
@@ -298,6 +299,9 @@ func CreateOrUpdateResource(
 			return err
 		}
 
+		// Then the owner's overrides, which cannot change the managed-by label or the selectors
+		applyOverrides(obj, kind, owner)
+
 		// Validate that mutateFn set the managed-by label (required for all managed objects)
 		if obj.GetLabels()[v1beta1.ManagedByLabelKey] != v1beta1.ManagedByLabelValue {
 			return errors.NewBadRequest("mutateFn must set managed-by label via KubernetesLabels()")
@@ -1,48 +1,215 @@
 package internal
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+const KubeDNSNamespace = "kube-system"
+const KubeDNSLabelKey = "k8s-app"
+const KubeDNSLabelValue = "kube-dns"
+
 // PublicInternetNetworkPolicyEgressRule generates a NetworkPolicyEgressRule that is
 // suitable for untrusted workloads that needs to access public internet resources and other
-// componenents within the Calico private network.
-func PublicInternetNetworkPolicyEgressRule() networkingv1.NetworkPolicyEgressRule {
+// componenents within the pod network. Private networks other than the pod network are blocked.
+func PublicInternetNetworkPolicyEgressRule(privateCIDRs, podCIDRs []string) networkingv1.NetworkPolicyEgressRule {
+	var except []string
+	for _, cidr := range privateCIDRs {
+		// the pod network stays reachable through 0.0.0.0/0 when it is one of the blocked ranges
+		if !slices.Contains(podCIDRs, cidr) {
+			except = append(except, cidr)
+		}
+	}
+
+	peers := []networkingv1.NetworkPolicyPeer{
+		{
+			IPBlock: &networkingv1.IPBlock{
+				CIDR:   "0.0.0.0/0",
+				Except: except,
+			},
+		},
+	}
+	for _, cidr := range podCIDRs {
+		// a pod network inside one of the blocked ranges needs to be allowed explicitly
+		if !slices.Contains(privateCIDRs, cidr) {
+			peers = append(peers, networkingv1.NetworkPolicyPeer{
+				IPBlock: &networkingv1.IPBlock{
+					CIDR: cidr,
+				},
+			})
+		}
+	}
+
+	return networkingv1.NetworkPolicyEgressRule{
+		To: peers,
+	}
+}
+
+// PublicInternetOnlyNetworkPolicyEgressRule generates a NetworkPolicyEgressRule that is
+// suitable for untrusted workloads that needs to access public internet resources only and
+// NO other private resources.
+func PublicInternetOnlyNetworkPolicyEgressRule(privateCIDRs []string) networkingv1.NetworkPolicyEgressRule {
 	return networkingv1.NetworkPolicyEgressRule{
 		To: []networkingv1.NetworkPolicyPeer{
 			{
 				IPBlock: &networkingv1.IPBlock{
 					CIDR: "0.0.0.0/0",
-					Except: []string{
-						// NOTE: explicitly blocking these CIDRs means that we are also
-						// depending on Calico using 172.16.0.0/16 so that those addresses
-						// are still reachable.
-						"192.168.0.0/16",
-						"10.0.0.0/8",
-					},
+					// this blocks ALL private network communication
+					Except: privateCIDRs,
 				},
 			},
 		},
 	}
 }
 
-// PublicInternetOnlyNetworkPolicyEgressRule generates a NetworkPolicyEgressRule that is
-// suitable for untrusted workloads that needs to access public internet resources only and
-// NO other private resources.
-func PublicInternetOnlyNetworkPolicyEgressRule() networkingv1.NetworkPolicyEgressRule {
+// DNSNetworkPolicyEgressRule generates a NetworkPolicyEgressRule that allows DNS lookups against the cluster DNS
+func DNSNetworkPolicyEgressRule() networkingv1.NetworkPolicyEgressRule {
+	udp := corev1.ProtocolUDP
+	tcp := corev1.ProtocolTCP
+	port := intstr.FromInt32(53)
 	return networkingv1.NetworkPolicyEgressRule{
 		To: []networkingv1.NetworkPolicyPeer{
 			{
-				IPBlock: &networkingv1.IPBlock{
-					CIDR: "0.0.0.0/0",
-					Except: []string{
-						// this blocks ALL private network communication
-						"192.168.0.0/16",
-						"10.0.0.0/8",
-						"172.16.0.0/12",
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						v1beta1.KubernetesMetadataNameKey: KubeDNSNamespace,
+					},
+				},
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						KubeDNSLabelKey: KubeDNSLabelValue,
 					},
 				},
 			},
 		},
+		Ports: []networkingv1.NetworkPolicyPort{
+			{Protocol: &udp, Port: &port},
+			{Protocol: &tcp, Port: &port},
+		},
+	}
+}
+
+// IPBlockNetworkPolicyPeers generates a NetworkPolicyPeer for each CIDR
+func IPBlockNetworkPolicyPeers(cidrs []string) []networkingv1.NetworkPolicyPeer {
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{
+				CIDR: cidr,
+			},
+		})
+	}
+	return peers
+}
+
+// WithoutInternetEgress removes internet access from egress rules, for components whose internet egress is
+// limited to a domain allow-list by another policy. Rules that allow all egress keep access to the cluster and
+// the private networks, and 0.0.0.0/0 blocks keep access to the private networks that they did not except.
+func WithoutInternetEgress(rules []networkingv1.NetworkPolicyEgressRule, privateCIDRs []string) []networkingv1.NetworkPolicyEgressRule {
+	var res []networkingv1.NetworkPolicyEgressRule
+	for _, rule := range rules {
+		var to []networkingv1.NetworkPolicyPeer
+		if len(rule.To) == 0 {
+			to = append(to, networkingv1.NetworkPolicyPeer{NamespaceSelector: &metav1.LabelSelector{}})
+			to = append(to, IPBlockNetworkPolicyPeers(privateCIDRs)...)
+		}
+		for _, peer := range rule.To {
+			if peer.IPBlock == nil || peer.IPBlock.CIDR != "0.0.0.0/0" {
+				to = append(to, peer)
+				continue
+			}
+			for _, cidr := range privateCIDRs {
+				if !slices.Contains(peer.IPBlock.Except, cidr) {
+					to = append(to, IPBlockNetworkPolicyPeers([]string{cidr})...)
+				}
+			}
+		}
+
+		// nothing but the internet was allowed
+		if len(to) == 0 {
+			continue
+		}
+		rule.To = to
+		res = append(res, rule)
+	}
+	return res
+}
+
+// DescribeNetworkPolicy describes the traffic that a NetworkPolicy allows, one line per rule, i.e.
+// "site-packagemanager: allow ingress from pods posit.team/component=workbench on 4242/TCP"
+func DescribeNetworkPolicy(name string, spec networkingv1.NetworkPolicySpec) []string {
+	var res []string
+	if slices.Contains(spec.PolicyTypes, networkingv1.PolicyTypeIngress) && len(spec.Ingress) == 0 {
+		res = append(res, name+": deny all ingress")
+	}
+	for _, rule := range spec.Ingress {
+		res = append(res, fmt.Sprintf("%s: allow ingress from %s on %s", name, describeNetworkPolicyPeers(rule.From), describeNetworkPolicyPorts(rule.Ports)))
+	}
+	if slices.Contains(spec.PolicyTypes, networkingv1.PolicyTypeEgress) && len(spec.Egress) == 0 {
+		res = append(res, name+": deny all egress")
+	}
+	for _, rule := range spec.Egress {
+		res = append(res, fmt.Sprintf("%s: allow egress to %s on %s", name, describeNetworkPolicyPeers(rule.To), describeNetworkPolicyPorts(rule.Ports)))
+	}
+	return res
+}
+
+func describeNetworkPolicyPeers(peers []networkingv1.NetworkPolicyPeer) string {
+	if len(peers) == 0 {
+		return "anywhere"
+	}
+
+	describeSelector := func(kind string, selector *metav1.LabelSelector) string {
+		if len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 {
+			return "all " + kind
+		}
+		return kind + " " + metav1.FormatLabelSelector(selector)
+	}
+
+	res := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		switch {
+		case peer.IPBlock != nil && len(peer.IPBlock.Except) > 0:
+			res = append(res, peer.IPBlock.CIDR+" except "+strings.Join(peer.IPBlock.Except, ", "))
+		case peer.IPBlock != nil:
+			res = append(res, peer.IPBlock.CIDR)
+		case peer.PodSelector != nil && peer.NamespaceSelector != nil:
+			res = append(res, describeSelector("pods", peer.PodSelector)+" in "+describeSelector("namespaces", peer.NamespaceSelector))
+		case peer.NamespaceSelector != nil:
+			res = append(res, describeSelector("namespaces", peer.NamespaceSelector))
+		case peer.PodSelector != nil:
+			res = append(res, describeSelector("pods", peer.PodSelector))
+		}
+	}
+	return strings.Join(res, " or ")
+}
+
+func describeNetworkPolicyPorts(ports []networkingv1.NetworkPolicyPort) string {
+	if len(ports) == 0 {
+		return "any port"
+	}
+
+	res := make([]string, 0, len(ports))
+	for _, p := range ports {
+		protocol := "TCP"
+		if p.Protocol != nil {
+			protocol = string(*p.Protocol)
+		}
+		switch {
+		case p.Port == nil:
+			res = append(res, "any "+protocol+" port")
+		case p.EndPort != nil:
+			res = append(res, fmt.Sprintf("%s-%d/%s", p.Port.String(), *p.EndPort, protocol))
+		default:
+			res = append(res, p.Port.String()+"/"+protocol)
+		}
 	}
+	return strings.Join(res, ", ")
 }
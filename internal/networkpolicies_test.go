@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestPublicInternetNetworkPolicyEgressRule(t *testing.T) {
+	private := []string{"192.168.0.0/16", "10.0.0.0/8", "172.16.0.0/12"}
+
+	// a pod network that is one of the private ranges is reachable through 0.0.0.0/0
+	rule := PublicInternetNetworkPolicyEgressRule(private, []string{"172.16.0.0/12"})
+	require.Len(t, rule.To, 1)
+	assert.Equal(t, "0.0.0.0/0", rule.To[0].IPBlock.CIDR)
+	assert.Equal(t, []string{"192.168.0.0/16", "10.0.0.0/8"}, rule.To[0].IPBlock.Except)
+
+	// a pod network inside a private range is allowed by its own peer
+	rule = PublicInternetNetworkPolicyEgressRule(private, []string{"10.244.0.0/16"})
+	require.Len(t, rule.To, 2)
+	assert.Equal(t, private, rule.To[0].IPBlock.Except)
+	assert.Equal(t, "10.244.0.0/16", rule.To[1].IPBlock.CIDR)
+}
+
+func TestPublicInternetOnlyNetworkPolicyEgressRule(t *testing.T) {
+	rule := PublicInternetOnlyNetworkPolicyEgressRule([]string{"10.0.0.0/8"})
+	require.Len(t, rule.To, 1)
+	assert.Equal(t, []string{"10.0.0.0/8"}, rule.To[0].IPBlock.Except)
+
+	assert.Empty(t, IPBlockNetworkPolicyPeers(nil))
+	assert.Equal(t, "10.0.0.0/8", IPBlockNetworkPolicyPeers([]string{"10.0.0.0/8"})[0].IPBlock.CIDR)
+}
+
+func TestWithoutInternetEgress(t *testing.T) {
+	private := []string{"192.168.0.0/16", "10.0.0.0/8", "172.16.0.0/12"}
+	workbench := networkingv1.NetworkPolicyEgressRule{
+		To: []networkingv1.NetworkPolicyPeer{
+			{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "workbench"}}},
+		},
+	}
+
+	// sessions only keep the non-internet rules
+	res := WithoutInternetEgress([]networkingv1.NetworkPolicyEgressRule{
+		workbench,
+		PublicInternetOnlyNetworkPolicyEgressRule(private),
+	}, private)
+	assert.Equal(t, []networkingv1.NetworkPolicyEgressRule{workbench}, res)
+
+	// products keep the private ranges that were not excepted
+	res = WithoutInternetEgress([]networkingv1.NetworkPolicyEgressRule{
+		PublicInternetNetworkPolicyEgressRule(private, []string{"172.16.0.0/12"}),
+	}, private)
+	require.Len(t, res, 1)
+	assert.Equal(t, IPBlockNetworkPolicyPeers([]string{"172.16.0.0/12"}), res[0].To)
+
+	// allow-all keeps the cluster and the private ranges
+	res = WithoutInternetEgress([]networkingv1.NetworkPolicyEgressRule{{}}, private)
+	require.Len(t, res, 1)
+	require.Len(t, res[0].To, 4)
+	assert.Equal(t, &metav1.LabelSelector{}, res[0].To[0].NamespaceSelector)
+	assert.Equal(t, "10.0.0.0/8", res[0].To[2].IPBlock.CIDR)
+}
+
+func TestDNSNetworkPolicyEgressRule(t *testing.T) {
+	rule := DNSNetworkPolicyEgressRule()
+	require.Len(t, rule.To, 1)
+	assert.Equal(t, KubeDNSNamespace, rule.To[0].NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"])
+	assert.Equal(t, KubeDNSLabelValue, rule.To[0].PodSelector.MatchLabels[KubeDNSLabelKey])
+	require.Len(t, rule.Ports, 2)
+	assert.Equal(t, "53", rule.Ports[0].Port.String())
+}
+
+func TestDescribeNetworkPolicy(t *testing.T) {
+	tcp := corev1.ProtocolTCP
+	port := intstr.FromInt32(4242)
+	spec := networkingv1.NetworkPolicySpec{
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				From: []networkingv1.NetworkPolicyPeer{
+					{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"posit.team/component": "workbench"}}},
+					{NamespaceSelector: &metav1.LabelSelector{}},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &port}},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"pm: allow ingress from pods posit.team/component=workbench or all namespaces on 4242/TCP",
+		"pm: deny all egress",
+	}, DescribeNetworkPolicy("pm", spec))
+
+	spec = networkingv1.NetworkPolicySpec{
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+		Egress:      []networkingv1.NetworkPolicyEgressRule{PublicInternetOnlyNetworkPolicyEgressRule([]string{"10.0.0.0/8"}), {}},
+	}
+	assert.Equal(t, []string{
+		"session: allow egress to 0.0.0.0/0 except 10.0.0.0/8 on any port",
+		"session: allow egress to anywhere on any port",
+	}, DescribeNetworkPolicy("session", spec))
+}
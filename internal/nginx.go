@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const NginxConfigurationSnippetKey = "nginx.ingress.kubernetes.io/configuration-snippet"
+
+// NginxIngressProvider configures Ingresses for ingress-nginx using annotations only.
+//
+// ingress-nginx sets the X-Forwarded-* headers itself (from the client connection, or from a trusted upstream
+// proxy with use-forwarded-headers), so ForwardHeaders needs no configuration. Additional request headers and
+// the CSP header are set with a configuration snippet, which requires allow-snippet-annotations on the
+// controller. An IngressPolicy maps to the allow-list, rate limit, body size and retry annotations.
+type NginxIngressProvider struct{}
+
+func (NginxIngressProvider) DeployResources(ctx context.Context, req ctrl.Request, c client.Client, scheme *runtime.Scheme, l logr.Logger, name string, owner RouteOwner, opts IngressOptions) error {
+	// everything is expressed as annotations
+	return CleanupTraefikResources(ctx, c, l, req.Namespace, name)
+}
+
+func (NginxIngressProvider) IngressAnnotations(namespace, name string, opts IngressOptions) map[string]string {
+	annotations := map[string]string{}
+
+	if opts.StickyCookie != "" {
+		annotations["nginx.ingress.kubernetes.io/affinity"] = "cookie"
+		annotations["nginx.ingress.kubernetes.io/affinity-mode"] = "persistent"
+		annotations["nginx.ingress.kubernetes.io/session-cookie-name"] = opts.StickyCookie
+		annotations["nginx.ingress.kubernetes.io/session-cookie-samesite"] = "None"
+		annotations["nginx.ingress.kubernetes.io/session-cookie-secure"] = "true"
+	}
+
+	var snippet []string
+	if opts.ForwardedHost != "" {
+		snippet = append(snippet, fmt.Sprintf("proxy_set_header X-Forwarded-Host %q;", opts.ForwardedHost))
+	}
+	keys := make([]string, 0, len(opts.RequestHeaders))
+	for k := range opts.RequestHeaders {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		snippet = append(snippet, fmt.Sprintf("proxy_set_header %s %q;", k, opts.RequestHeaders[k]))
+	}
+	if opts.ContentSecurityPolicy != "" {
+		snippet = append(snippet, fmt.Sprintf("more_set_headers %q;", "Content-Security-Policy: "+opts.ContentSecurityPolicy))
+	}
+	if len(snippet) > 0 {
+		annotations[NginxConfigurationSnippetKey] = strings.Join(snippet, "\n")
+	}
+
+	if opts.stripsPathPrefix() {
+		// IngressPath captures everything after the prefix so that it can be rewritten away
+		annotations["nginx.ingress.kubernetes.io/use-regex"] = "true"
+		annotations["nginx.ingress.kubernetes.io/rewrite-target"] = "/$2"
+		annotations["nginx.ingress.kubernetes.io/x-forwarded-prefix"] = opts.PathPrefix
+	}
+
+	if opts.Timeout != nil {
+		seconds := fmt.Sprintf("%d", timeoutSeconds(opts.Timeout))
+		annotations["nginx.ingress.kubernetes.io/proxy-read-timeout"] = seconds
+		annotations["nginx.ingress.kubernetes.io/proxy-send-timeout"] = seconds
+	}
+
+	if opts.Policy != nil {
+		for k, v := range nginxPolicyAnnotations(opts.Policy) {
+			annotations[k] = v
+		}
+	}
+
+	return annotations
+}
+
+// nginxPolicyAnnotations returns the annotations that enforce an IngressPolicy, which
+// RoutingConfig.ValidateIngressPolicy has accepted for ingress-nginx
+func nginxPolicyAnnotations(policy *v1beta1.IngressPolicy) map[string]string {
+	annotations := map[string]string{}
+	if policy.IPAllowList != nil {
+		annotations["nginx.ingress.kubernetes.io/whitelist-source-range"] = strings.Join(policy.IPAllowList.SourceRanges, ",")
+	}
+	if rl := policy.RateLimit; rl != nil {
+		key, limit := "nginx.ingress.kubernetes.io/limit-rps", int64(0)
+		if rps, ok := rl.RequestsPer(time.Second); ok {
+			limit = rps
+		} else if rpm, ok := rl.RequestsPer(time.Minute); ok {
+			key, limit = "nginx.ingress.kubernetes.io/limit-rpm", rpm
+		}
+		if limit > 0 {
+			annotations[key] = strconv.FormatInt(limit, 10)
+			// ingress-nginx allows a burst of a multiple of the limit
+			if rl.Burst > 0 {
+				annotations["nginx.ingress.kubernetes.io/limit-burst-multiplier"] = strconv.FormatInt(max(1, (rl.Burst+limit-1)/limit), 10)
+			}
+		}
+	}
+	if policy.MaxBodySize != nil {
+		annotations["nginx.ingress.kubernetes.io/proxy-body-size"] = strconv.FormatInt(policy.MaxBodySize.Value(), 10)
+	}
+	if policy.Retry != nil {
+		annotations["nginx.ingress.kubernetes.io/proxy-next-upstream-tries"] = strconv.Itoa(policy.Retry.Attempts)
+	}
+	return annotations
+}
+
+func (NginxIngressProvider) ServiceAnnotations(namespace, name string, opts IngressOptions) map[string]string {
+	return map[string]string{}
+}
+
+func (NginxIngressProvider) IngressPath(opts IngressOptions) (string, networkingv1.PathType) {
+	if opts.stripsPathPrefix() {
+		return opts.PathPrefix + "(/|$)(.*)", networkingv1.PathTypeImplementationSpecific
+	}
+	return prefixIngressPath(opts)
+}
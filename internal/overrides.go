@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// OverrideOwner is an owner whose generated objects can be patched with overrides
+type OverrideOwner interface {
+	GetOverrides() []v1beta1.Override
+	// RecordOverrideFailure records an override that failed to apply in the owner's status
+	RecordOverrideFailure(failure string)
+}
+
+// applyOverrides patches obj with the overrides of owner that target it. An override that fails to apply is
+// recorded on the owner and skipped, so that the object still converges to its generated state
+func applyOverrides(obj client.Object, kind string, owner client.Object) {
+	o, ok := owner.(OverrideOwner)
+	if !ok {
+		return
+	}
+	for _, override := range o.GetOverrides() {
+		if !override.Targets(kind, obj.GetName()) {
+			continue
+		}
+		if err := ApplyOverride(obj, override); err != nil {
+			o.RecordOverrideFailure(fmt.Sprintf("%s/%s: %s", kind, obj.GetName(), err))
+		}
+	}
+}
+
+// ApplyOverride patches obj in place. obj is left unchanged if the patch fails, renames the object or changes the
+// fields that the operator and the cluster find objects by (see checkProtectedFields)
+func ApplyOverride(obj client.Object, override v1beta1.Override) error {
+	patch, err := yaml.YAMLToJSON([]byte(override.Patch))
+	if err != nil {
+		return fmt.Errorf("invalid patch: %w", err)
+	}
+	original, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	var patched []byte
+	switch override.Type {
+	case v1beta1.OverridePatchTypeJSON6902:
+		ops, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return fmt.Errorf("invalid json6902 patch: %w", err)
+		}
+		if patched, err = ops.Apply(original); err != nil {
+			return err
+		}
+	case v1beta1.OverridePatchTypeStrategicMerge, "":
+		if patched, err = strategicpatch.StrategicMergePatch(original, patch, obj); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown patch type %q", override.Type)
+	}
+
+	out := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+	if err := json.Unmarshal(patched, out); err != nil {
+		return fmt.Errorf("patched object is invalid: %w", err)
+	}
+	if out.GetName() != obj.GetName() || out.GetNamespace() != obj.GetNamespace() {
+		return errors.New("overrides cannot rename an object")
+	}
+	if err := checkProtectedFields(original, patched); err != nil {
+		return err
+	}
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(out).Elem())
+	return nil
+}
+
+// checkProtectedFields returns an error when patched changes the managed-by label of original, the selector of a
+// Service or workload, or the pod template labels that the selector matches. The operator finds the objects it manages
+// by their managed-by label, and a changed selector orphans the pods or endpoints of the object
+func checkProtectedFields(original, patched []byte) error {
+	var before, after map[string]interface{}
+	if err := json.Unmarshal(original, &before); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(patched, &after); err != nil {
+		return err
+	}
+
+	changed := func(fields ...string) bool {
+		b, _, _ := unstructured.NestedFieldNoCopy(before, fields...)
+		a, _, _ := unstructured.NestedFieldNoCopy(after, fields...)
+		return !reflect.DeepEqual(b, a)
+	}
+	if changed("metadata", "labels", v1beta1.ManagedByLabelKey) {
+		return fmt.Errorf("overrides cannot change the %s label", v1beta1.ManagedByLabelKey)
+	}
+	if changed("spec", "selector") {
+		return errors.New("overrides cannot change the selector")
+	}
+	matchLabels, _, _ := unstructured.NestedStringMap(before, "spec", "selector", "matchLabels")
+	for key := range matchLabels {
+		if changed("spec", "template", "metadata", "labels", key) {
+			return fmt.Errorf("overrides cannot change the selector label %s of the pod template", key)
+		}
+	}
+	return nil
+}
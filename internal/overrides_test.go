@@ -0,0 +1,200 @@
+package internal_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/localtest"
+	"github.com/posit-dev/team-operator/internal"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func overrideDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "site-connect",
+			Namespace: "posit-team",
+			Labels:    map[string]string{v1beta1.ManagedByLabelKey: v1beta1.ManagedByLabelValue},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "connect"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "connect"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "connect", Image: "connect"}},
+				},
+			},
+		},
+	}
+}
+
+func TestApplyOverrideStrategicMerge(t *testing.T) {
+	r := require.New(t)
+
+	dep := overrideDeployment()
+	r.NoError(internal.ApplyOverride(dep, v1beta1.Override{
+		Kind: "Deployment",
+		Patch: `
+spec:
+  template:
+    spec:
+      hostAliases:
+        - ip: 10.0.0.1
+          hostnames: [git.example.com]
+      containers:
+        - name: connect
+          env:
+            - name: EXTRA
+              value: "1"
+`,
+	}))
+	podSpec := dep.Spec.Template.Spec
+	r.Equal([]corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"git.example.com"}}}, podSpec.HostAliases)
+	// containers are merged by name
+	r.Len(podSpec.Containers, 1)
+	r.Equal("connect", podSpec.Containers[0].Image)
+	r.Equal([]corev1.EnvVar{{Name: "EXTRA", Value: "1"}}, podSpec.Containers[0].Env)
+}
+
+func TestApplyOverrideJSON6902(t *testing.T) {
+	r := require.New(t)
+
+	dep := overrideDeployment()
+	r.NoError(internal.ApplyOverride(dep, v1beta1.Override{
+		Kind:  "Deployment",
+		Type:  v1beta1.OverridePatchTypeJSON6902,
+		Patch: `[{"op": "add", "path": "/spec/template/spec/dnsPolicy", "value": "None"}, {"op": "remove", "path": "/spec/template/spec/containers/0/image"}]`,
+	}))
+	r.Equal(corev1.DNSNone, dep.Spec.Template.Spec.DNSPolicy)
+	r.Empty(dep.Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestApplyOverrideFailures(t *testing.T) {
+	r := require.New(t)
+
+	dep := overrideDeployment()
+	r.Error(internal.ApplyOverride(dep, v1beta1.Override{
+		Kind:  "Deployment",
+		Type:  v1beta1.OverridePatchTypeJSON6902,
+		Patch: `[{"op": "replace", "path": "/spec/missing/field", "value": 1}]`,
+	}))
+	r.ErrorContains(internal.ApplyOverride(dep, v1beta1.Override{
+		Kind:  "Deployment",
+		Patch: `{"metadata": {"name": "other"}}`,
+	}), "rename")
+	r.Equal(overrideDeployment(), dep)
+}
+
+func TestApplyOverrideProtectedFields(t *testing.T) {
+	for name, tc := range map[string]struct {
+		obj      func() client.Object
+		override v1beta1.Override
+		err      string
+	}{
+		"managed-by label": {
+			obj: func() client.Object { return overrideDeployment() },
+			override: v1beta1.Override{
+				Kind:  "Deployment",
+				Type:  v1beta1.OverridePatchTypeJSON6902,
+				Patch: `[{"op": "remove", "path": "/metadata/labels/app.kubernetes.io~1managed-by"}]`,
+			},
+			err: "managed-by",
+		},
+		"deployment selector": {
+			obj:      func() client.Object { return overrideDeployment() },
+			override: v1beta1.Override{Kind: "Deployment", Patch: `{"spec": {"selector": {"matchLabels": {"tier": "web"}}}}`},
+			err:      "selector",
+		},
+		"pod template selector label": {
+			obj:      func() client.Object { return overrideDeployment() },
+			override: v1beta1.Override{Kind: "Deployment", Patch: `{"spec": {"template": {"metadata": {"labels": {"app": "other"}}}}}`},
+			err:      "selector label app",
+		},
+		"service selector": {
+			obj: func() client.Object {
+				return &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "site-connect", Namespace: "posit-team"},
+					Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "connect"}},
+				}
+			},
+			override: v1beta1.Override{Kind: "Service", Patch: `{"spec": {"selector": {"app": "other"}}}`},
+			err:      "selector",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := require.New(t)
+
+			obj := tc.obj()
+			r.ErrorContains(internal.ApplyOverride(obj, tc.override), tc.err)
+			r.Equal(tc.obj(), obj)
+		})
+	}
+
+	// other pod template labels can be added
+	dep := overrideDeployment()
+	require.NoError(t, internal.ApplyOverride(dep, v1beta1.Override{
+		Kind:  "Deployment",
+		Patch: `{"spec": {"template": {"metadata": {"labels": {"team": "data"}}}}}`,
+	}))
+	require.Equal(t, map[string]string{"app": "connect", "team": "data"}, dep.Spec.Template.Labels)
+}
+
+func TestCreateOrUpdateResourceOverrides(t *testing.T) {
+	r := require.New(t)
+
+	fakeClient := localtest.FakeTestEnv{}
+	cli, scheme, log := fakeClient.Start(func(scheme *runtime.Scheme) {
+		utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+		utilruntime.Must(v1beta1.AddToScheme(scheme))
+	})
+
+	owner := &v1beta1.Connect{
+		ObjectMeta: metav1.ObjectMeta{Name: "site", Namespace: "posit-team", UID: "connect-uid"},
+		Spec: v1beta1.ConnectSpec{
+			Overrides: []v1beta1.Override{
+				{Kind: "Deployment", Name: "site-connect", Patch: `{"metadata": {"labels": {"team": "data"}}}`},
+				{Kind: "Deployment", Patch: `{"metadata": {"name": "other"}}`},
+				{Kind: "Service", Patch: `{"metadata": {"labels": {"ignored": "true"}}}`},
+			},
+		},
+	}
+
+	dep := overrideDeployment()
+	_, err := internal.CreateOrUpdateResource(context.TODO(), cli, scheme, log, dep, owner, func() error {
+		dep.Labels = owner.KubernetesLabels()
+		return nil
+	})
+	r.NoError(err)
+
+	out := &appsv1.Deployment{}
+	r.NoError(cli.Get(context.TODO(), client.ObjectKeyFromObject(dep), out))
+	r.Equal("data", out.Labels["team"])
+	r.NotContains(out.Labels, "ignored")
+	r.Len(owner.Status.FailedOverrides, 1)
+	r.Contains(owner.Status.FailedOverrides[0], "Deployment/site-connect: ")
+
+	// overrides cannot remove the managed-by label
+	owner.Status.FailedOverrides = nil
+	owner.Spec.Overrides = []v1beta1.Override{{
+		Kind:  "Deployment",
+		Type:  v1beta1.OverridePatchTypeJSON6902,
+		Patch: `[{"op": "remove", "path": "/metadata/labels/app.kubernetes.io~1managed-by"}]`,
+	}}
+	_, err = internal.CreateOrUpdateResource(context.TODO(), cli, scheme, log, dep, owner, func() error {
+		dep.Labels = owner.KubernetesLabels()
+		return nil
+	})
+	r.NoError(err)
+	r.NoError(cli.Get(context.TODO(), client.ObjectKeyFromObject(dep), out))
+	r.Equal(v1beta1.ManagedByLabelValue, out.Labels[v1beta1.ManagedByLabelKey])
+	r.Len(owner.Status.FailedOverrides, 1)
+	r.Contains(owner.Status.FailedOverrides[0], "managed-by")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
 	"github.com/posit-dev/team-operator/api/product"
 	"github.com/rstudio/goex/ptr"
 	v1 "k8s.io/api/core/v1"
@@ -42,6 +43,14 @@ type ProductOwner interface {
 
 // GenerateRbac generates the SA, Role, and Rolebinding necessary for Launcher to function
 func GenerateRbac(ctx context.Context, c client.Client, scheme *runtime.Scheme, req controllerruntime.Request, p ProductOwner) error {
+	if err := GenerateServiceAccount(ctx, c, scheme, req, p); err != nil {
+		return err
+	}
+	return GenerateLauncherRbac(ctx, c, scheme, req.Namespace, p)
+}
+
+// GenerateServiceAccount generates the SA that Launcher runs as
+func GenerateServiceAccount(ctx context.Context, c client.Client, scheme *runtime.Scheme, req controllerruntime.Request, p ProductOwner) error {
 	l := logr.FromContextOrDiscard(ctx).WithValues(
 		"event", "generate-rbac",
 	)
@@ -65,16 +74,36 @@ func GenerateRbac(ctx context.Context, c client.Client, scheme *runtime.Scheme,
 	}); err != nil {
 		return err
 	}
+	return nil
+}
+
+// GenerateLauncherRbac generates the Role and Rolebinding that let the Launcher SA in the namespace of p start
+// sessions in namespace. Objects in another namespace than p cannot be owned by it, so they are labeled with the
+// namespace of p instead
+func GenerateLauncherRbac(ctx context.Context, c client.Client, scheme *runtime.Scheme, namespace string, p ProductOwner) error {
+	l := logr.FromContextOrDiscard(ctx).WithValues(
+		"event", "generate-rbac",
+	)
+
+	var owner client.Object
+	labels := p.KubernetesLabels()
+	if namespace == p.GetNamespace() {
+		owner = p
+	} else {
+		labels = product.LabelMerge(labels, map[string]string{
+			v1beta1.OwnerNamespaceLabelKey: p.GetNamespace(),
+		})
+	}
 
 	// ROLE
 	role := &rbacv1.Role{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      p.ComponentName(),
-			Namespace: req.Namespace,
+			Namespace: namespace,
 		},
 	}
-	if _, err := CreateOrUpdateResource(ctx, c, scheme, l, role, p, func() error {
-		role.Labels = p.KubernetesLabels()
+	if _, err := CreateOrUpdateResource(ctx, c, scheme, l, role, owner, func() error {
+		role.Labels = labels
 		role.Rules = []rbacv1.PolicyRule{
 			{
 				APIGroups: []string{""},
@@ -121,16 +150,16 @@ func GenerateRbac(ctx context.Context, c client.Client, scheme *runtime.Scheme,
 	roleBinding := &rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      p.ComponentName(),
-			Namespace: req.Namespace,
+			Namespace: namespace,
 		},
 	}
-	if _, err := CreateOrUpdateResource(ctx, c, scheme, l, roleBinding, p, func() error {
-		roleBinding.Labels = p.KubernetesLabels()
+	if _, err := CreateOrUpdateResource(ctx, c, scheme, l, roleBinding, owner, func() error {
+		roleBinding.Labels = labels
 		roleBinding.Subjects = []rbacv1.Subject{
 			{
 				Kind:      "ServiceAccount",
-				Name:      serviceAccount.Name,
-				Namespace: serviceAccount.Namespace,
+				Name:      p.ComponentName(),
+				Namespace: p.GetNamespace(),
 			},
 		}
 		roleBinding.RoleRef = rbacv1.RoleRef{
@@ -0,0 +1,220 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// The image config labels that list the runtime versions of an image, separated by commas, i.e. "4.4.1,4.3.3"
+const (
+	RVersionsLabel      = "co.posit.r.versions"
+	PythonVersionsLabel = "co.posit.python.versions"
+	QuartoVersionsLabel = "co.posit.quarto.versions"
+)
+
+// The image config labels that list the path of the executable of each runtime version, with VersionPlaceholder in
+// place of the version, i.e. "/opt/R/{version}/bin/R"
+const (
+	RPathLabel      = "co.posit.r.path"
+	PythonPathLabel = "co.posit.python.path"
+	QuartoPathLabel = "co.posit.quarto.path"
+)
+
+// VersionPlaceholder is replaced by the version in the path labels
+const VersionPlaceholder = "{version}"
+
+const (
+	// lookupTimeout bounds a lookup, so that a registry that does not answer does not hold up the reconcile
+	lookupTimeout = 10 * time.Second
+
+	// refreshInterval is how long the versions of a tag are used before the registry is asked whether it moved.
+	// The versions of a digest do not change
+	refreshInterval = 10 * time.Minute
+
+	// The delay before an image that could not be read is read again doubles with each failure, between these
+	minRetryDelay = 10 * time.Second
+	maxRetryDelay = 10 * time.Minute
+)
+
+// ErrUnlabeled is the error of an image without runtime version labels
+var ErrUnlabeled = errors.New("image has no runtime version labels")
+
+// LookupError is the error of an image whose registry could not be read. The image is not read again until
+// RetryAfter has passed
+type LookupError struct {
+	Image      string
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LookupError) Error() string {
+	return fmt.Sprintf("error reading image %s: %v", e.Image, e.Err)
+}
+
+func (e *LookupError) Unwrap() error {
+	return e.Err
+}
+
+// RuntimeVersions are the runtime versions that the labels of an image list
+type RuntimeVersions struct {
+	// Digest is the digest that the image resolved to
+	Digest string
+
+	R      []string
+	Python []string
+	Quarto []string
+
+	// RPath, PythonPath and QuartoPath are the paths of the executables that the path labels list, with
+	// VersionPlaceholder in place of the version. They are empty when the image has no path label
+	RPath      string
+	PythonPath string
+	QuartoPath string
+}
+
+// ExecutablePath returns the path of the executable of version, from the path label of its runtime
+func ExecutablePath(path, version string) string {
+	return strings.ReplaceAll(path, VersionPlaceholder, version)
+}
+
+// Discoverer reads the runtime versions of images from their labels. Results are cached by image reference: a tag
+// is resolved again after a while and its labels are read again when it moved, and an image that could not be read
+// is read again after a delay that grows with each failure
+type Discoverer struct {
+	client *Client
+	now    func() time.Time
+
+	mu     sync.Mutex
+	images map[imageKey]cachedImage
+}
+
+// imageKey identifies an image reference, as the credentials of a keychain read it
+type imageKey struct {
+	keychain string
+	image    string
+}
+
+type cachedImage struct {
+	// versions were read when the image was last resolved, at checked
+	versions RuntimeVersions
+	checked  time.Time
+
+	// err is the error of the failures since then, and the image is not read again before retryAt
+	err      error
+	failures int
+	retryAt  time.Time
+}
+
+// NewDiscoverer returns a Discoverer that reads images with client
+func NewDiscoverer(client *Client) *Discoverer {
+	return &Discoverer{client: client, now: time.Now, images: map[imageKey]cachedImage{}}
+}
+
+// Discover returns the runtime versions of image, authenticating with keychain. It returns an error wrapping
+// ErrUnlabeled when the image has no runtime version labels, with the Digest of the RuntimeVersions set, and a
+// *LookupError when the registry could not be read
+func (d *Discoverer) Discover(ctx context.Context, image string, keychain SecretKeychain) (RuntimeVersions, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return RuntimeVersions{}, fmt.Errorf("image %q is not an image reference: %w", image, err)
+	}
+
+	key := imageKey{keychain: keychain.key, image: ref.Name()}
+	now := d.now()
+	d.mu.Lock()
+	cached, ok := d.images[key]
+	d.mu.Unlock()
+
+	if ok && cached.err != nil && now.Before(cached.retryAt) {
+		return RuntimeVersions{}, &LookupError{Image: image, Err: cached.err, RetryAfter: cached.retryAt.Sub(now)}
+	}
+	_, pinned := ref.(name.Digest)
+	if ok && cached.err == nil && (pinned || now.Sub(cached.checked) < refreshInterval) {
+		return cached.versions, unlabeled(image, cached.versions)
+	}
+
+	versions, err := d.lookup(ctx, ref, keychain, cached.versions)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err != nil {
+		// the versions that were read before are kept, so that they are not read again if the tag did not move
+		cached.err = err
+		cached.failures++
+		delay := retryDelay(cached.failures)
+		cached.retryAt = now.Add(delay)
+		d.images[key] = cached
+		return RuntimeVersions{}, &LookupError{Image: image, Err: err, RetryAfter: delay}
+	}
+	d.images[key] = cachedImage{versions: versions, checked: now}
+	return versions, unlabeled(image, versions)
+}
+
+// lookup resolves ref and reads the labels of its config, unless it resolves to the digest of known
+func (d *Discoverer) lookup(ctx context.Context, ref name.Reference, keychain SecretKeychain, known RuntimeVersions) (RuntimeVersions, error) {
+	ctx, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+
+	digest, err := d.client.Digest(ctx, ref, keychain)
+	if err != nil {
+		return RuntimeVersions{}, err
+	}
+	if digest.String() == known.Digest {
+		return known, nil
+	}
+
+	cfg, err := d.client.ConfigFile(ctx, ref.Context().Digest(digest.String()), keychain)
+	if err != nil {
+		return RuntimeVersions{}, err
+	}
+	versions := runtimeVersionsFromLabels(cfg.Config.Labels)
+	versions.Digest = digest.String()
+	return versions, nil
+}
+
+// retryDelay is the delay before an image that failed to be read failures times in a row is read again
+func retryDelay(failures int) time.Duration {
+	delay := minRetryDelay
+	for i := 1; i < failures && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
+
+// unlabeled returns an error wrapping ErrUnlabeled when versions lists no runtime versions
+func unlabeled(image string, versions RuntimeVersions) error {
+	if len(versions.R) == 0 && len(versions.Python) == 0 && len(versions.Quarto) == 0 {
+		return fmt.Errorf("%w: %s", ErrUnlabeled, image)
+	}
+	return nil
+}
+
+// runtimeVersionsFromLabels parses the runtime version and path labels of an image config
+func runtimeVersionsFromLabels(labels map[string]string) RuntimeVersions {
+	return RuntimeVersions{
+		R:          parseVersions(labels[RVersionsLabel]),
+		Python:     parseVersions(labels[PythonVersionsLabel]),
+		Quarto:     parseVersions(labels[QuartoVersionsLabel]),
+		RPath:      strings.TrimSpace(labels[RPathLabel]),
+		PythonPath: strings.TrimSpace(labels[PythonPathLabel]),
+		QuartoPath: strings.TrimSpace(labels[QuartoPathLabel]),
+	}
+}
+
+// parseVersions splits a version label at its commas, dropping blank and repeated versions
+func parseVersions(label string) []string {
+	var versions []string
+	for _, v := range strings.Split(label, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" && !slices.Contains(versions, v) {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
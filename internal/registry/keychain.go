@@ -0,0 +1,110 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// dockerConfig is the content of a kubernetes.io/dockerconfigjson secret
+type dockerConfig struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+// SecretKeychain is the authn.Keychain of image pull secrets. The zero SecretKeychain is anonymous
+type SecretKeychain struct {
+	auths map[string]authn.AuthConfig
+
+	// key identifies the credentials of the keychain
+	key string
+}
+
+// Anonymous is the SecretKeychain without credentials
+var Anonymous = SecretKeychain{}
+
+// NewSecretKeychain reads the credentials of kubernetes.io/dockerconfigjson and kubernetes.io/dockercfg secrets. The
+// first secret with credentials for a registry wins, as for the image pull secrets of a pod
+func NewSecretKeychain(secrets ...corev1.Secret) (SecretKeychain, error) {
+	keychain := SecretKeychain{auths: map[string]authn.AuthConfig{}}
+	for _, s := range secrets {
+		var auths map[string]dockerConfigEntry
+		switch s.Type {
+		case corev1.SecretTypeDockerConfigJson:
+			var cfg dockerConfig
+			if err := json.Unmarshal(s.Data[corev1.DockerConfigJsonKey], &cfg); err != nil {
+				return SecretKeychain{}, fmt.Errorf("error parsing secret %s: %w", s.Name, err)
+			}
+			auths = cfg.Auths
+		case corev1.SecretTypeDockercfg:
+			if err := json.Unmarshal(s.Data[corev1.DockerConfigKey], &auths); err != nil {
+				return SecretKeychain{}, fmt.Errorf("error parsing secret %s: %w", s.Name, err)
+			}
+		default:
+			continue
+		}
+
+		for server, entry := range auths {
+			cfg := authn.AuthConfig{Username: entry.Username, Password: entry.Password}
+			if entry.Auth != "" {
+				decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+				if err != nil {
+					return SecretKeychain{}, fmt.Errorf("error decoding the credentials of %s in secret %s: %w", server, s.Name, err)
+				}
+				cfg.Username, cfg.Password, _ = strings.Cut(string(decoded), ":")
+			}
+			host := registryHost(server)
+			if _, ok := keychain.auths[host]; !ok {
+				keychain.auths[host] = cfg
+			}
+		}
+	}
+
+	if len(keychain.auths) == 0 {
+		return Anonymous, nil
+	}
+	// the keys of the map are marshalled in order, so equal credentials have the same key
+	raw, err := json.Marshal(keychain.auths)
+	if err != nil {
+		return SecretKeychain{}, err
+	}
+	sum := sha256.Sum256(raw)
+	keychain.key = hex.EncodeToString(sum[:])
+	return keychain, nil
+}
+
+// Resolve returns the credentials of the registry of target, or authn.Anonymous
+func (k SecretKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	cfg, ok := k.auths[registryHost(target.RegistryStr())]
+	if !ok {
+		return authn.Anonymous, nil
+	}
+	return authn.FromConfig(cfg), nil
+}
+
+// registryHost normalizes the server of a docker config, i.e. https://index.docker.io/v1/, to the registry of an
+// image reference, i.e. index.docker.io
+func registryHost(server string) string {
+	host := server
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+	host, _, _ = strings.Cut(host, "/")
+	switch host {
+	case "docker.io", "registry-1.docker.io":
+		return name.DefaultRegistry
+	}
+	return host
+}
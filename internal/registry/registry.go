@@ -0,0 +1,101 @@
+// Package registry reads image metadata from container registries over the OCI distribution API
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// maxPullers bounds the pullers that a Client keeps. Rotated image pull secrets leave their pullers behind, so the
+// pullers are dropped all at once when there are more
+const maxPullers = 64
+
+// Client reads images from their registries. It keeps a puller per set of credentials, so that lookups with the
+// same credentials share their connections and registry tokens
+type Client struct {
+	transport http.RoundTripper
+
+	mu      sync.Mutex
+	pullers map[string]*remote.Puller
+}
+
+// NewClient returns a Client that reads images over transport. A nil transport is remote.DefaultTransport
+func NewClient(transport http.RoundTripper) *Client {
+	if transport == nil {
+		transport = remote.DefaultTransport
+	}
+	return &Client{transport: transport, pullers: map[string]*remote.Puller{}}
+}
+
+// Digest resolves ref to the digest of its manifest, authenticating with keychain
+func (c *Client) Digest(ctx context.Context, ref name.Reference, keychain SecretKeychain) (v1.Hash, error) {
+	p, err := c.puller(keychain)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+	desc, err := p.Head(ctx, ref)
+	if err != nil {
+		c.drop(keychain, p)
+		return v1.Hash{}, err
+	}
+	return desc.Digest, nil
+}
+
+// ConfigFile returns the config of the image at digest, authenticating with keychain. An index resolves to its
+// linux/amd64 image
+func (c *Client) ConfigFile(ctx context.Context, digest name.Digest, keychain SecretKeychain) (*v1.ConfigFile, error) {
+	p, err := c.puller(keychain)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := func() (*v1.ConfigFile, error) {
+		desc, err := p.Get(ctx, digest)
+		if err != nil {
+			return nil, err
+		}
+		img, err := desc.Image()
+		if err != nil {
+			return nil, err
+		}
+		return img.ConfigFile()
+	}()
+	if err != nil {
+		c.drop(keychain, p)
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// puller returns the puller of the credentials of keychain
+func (c *Client) puller(keychain SecretKeychain) (*remote.Puller, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if p, ok := c.pullers[keychain.key]; ok {
+		return p, nil
+	}
+	p, err := remote.NewPuller(remote.WithTransport(c.transport), remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		return nil, fmt.Errorf("error creating registry client: %w", err)
+	}
+	if len(c.pullers) >= maxPullers {
+		clear(c.pullers)
+	}
+	c.pullers[keychain.key] = p
+	return p, nil
+}
+
+// drop forgets the puller p of the credentials of keychain after a failed request. A puller keeps the error of
+// authenticating to a repository, so a registry that failed once would otherwise never be read again
+func (c *Client) drop(keychain SecretKeychain, p *remote.Puller) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pullers[keychain.key] == p {
+		delete(c.pullers, keychain.key)
+	}
+}
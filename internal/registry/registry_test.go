@@ -0,0 +1,264 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/posit-dev/team-operator/internal/registry/registrytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDiscoverer(t *testing.T) {
+	ctx := context.Background()
+	var manifestRequests atomic.Int32
+	reg := registrytest.New()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/manifests/") {
+			manifestRequests.Add(1)
+		}
+		reg.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	host := registrytest.Host(srv)
+
+	labeled := registrytest.Image(t, map[string]string{
+		RVersionsLabel:      "4.4.1, 4.3.3,4.4.1",
+		RPathLabel:          " /opt/R/{version}/bin/R ",
+		PythonVersionsLabel: "3.12.4",
+		QuartoVersionsLabel: "",
+		"other":             "label",
+	})
+	digest := registrytest.PushImage(t, host, "content", "labeled", labeled)
+	registrytest.PushImage(t, host, "content", "unlabeled", registrytest.Image(t, nil))
+
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	d := NewDiscoverer(NewClient(srv.Client().Transport))
+	d.now = func() time.Time { return now }
+
+	versions, err := d.Discover(ctx, host+"/content:labeled", Anonymous)
+	require.NoError(t, err)
+	assert.Equal(t, RuntimeVersions{
+		Digest: digest.String(),
+		R:      []string{"4.4.1", "4.3.3"},
+		Python: []string{"3.12.4"},
+		RPath:  "/opt/R/{version}/bin/R",
+	}, versions)
+	assert.Equal(t, "/opt/R/4.4.1/bin/R", ExecutablePath(versions.RPath, "4.4.1"))
+
+	// the versions of a reference are cached
+	requests := manifestRequests.Load()
+	again, err := d.Discover(ctx, host+"/content:labeled", Anonymous)
+	require.NoError(t, err)
+	assert.Equal(t, versions, again)
+	assert.Equal(t, requests, manifestRequests.Load())
+
+	// a moved tag is read again once the cached versions are refreshed
+	moved := registrytest.PushImage(t, host, "content", "labeled", registrytest.Image(t, map[string]string{QuartoVersionsLabel: "1.5.57"}))
+	again, err = d.Discover(ctx, host+"/content:labeled", Anonymous)
+	require.NoError(t, err)
+	assert.Equal(t, versions, again)
+	now = now.Add(refreshInterval)
+	versions, err = d.Discover(ctx, host+"/content:labeled", Anonymous)
+	require.NoError(t, err)
+	assert.Equal(t, RuntimeVersions{Digest: moved.String(), Quarto: []string{"1.5.57"}}, versions)
+
+	// a digest is never refreshed
+	_, err = d.Discover(ctx, host+"/content@"+moved.String(), Anonymous)
+	require.NoError(t, err)
+	requests = manifestRequests.Load()
+	now = now.Add(refreshInterval)
+	_, err = d.Discover(ctx, host+"/content@"+moved.String(), Anonymous)
+	require.NoError(t, err)
+	assert.Equal(t, requests, manifestRequests.Load())
+
+	versions, err = d.Discover(ctx, host+"/content:unlabeled", Anonymous)
+	assert.ErrorIs(t, err, ErrUnlabeled)
+	assert.NotEmpty(t, versions.Digest)
+
+	_, err = d.Discover(ctx, "not a reference", Anonymous)
+	assert.Error(t, err)
+}
+
+func TestDiscoverer_Backoff(t *testing.T) {
+	ctx := context.Background()
+	var requests atomic.Int32
+	var down atomic.Bool
+	down.Store(true)
+	reg := registrytest.New()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			requests.Add(1)
+			if down.Load() {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+		}
+		reg.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	host := registrytest.Host(srv)
+	registrytest.PushImage(t, host, "content", "labeled", registrytest.Image(t, map[string]string{RVersionsLabel: "4.4.1"}))
+
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	d := NewDiscoverer(NewClient(srv.Client().Transport))
+	d.now = func() time.Time { return now }
+
+	// a registry that cannot be read is read again after a delay
+	_, err := d.Discover(ctx, host+"/content:labeled", Anonymous)
+	var lookupErr *LookupError
+	require.ErrorAs(t, err, &lookupErr)
+	assert.Equal(t, minRetryDelay, lookupErr.RetryAfter)
+
+	// which the lookups before it do not wait for
+	failed := requests.Load()
+	now = now.Add(time.Second)
+	_, err = d.Discover(ctx, host+"/content:labeled", Anonymous)
+	require.ErrorAs(t, err, &lookupErr)
+	assert.Equal(t, minRetryDelay-time.Second, lookupErr.RetryAfter)
+	assert.Equal(t, failed, requests.Load())
+
+	// and that grows with each failure
+	now = now.Add(minRetryDelay)
+	_, err = d.Discover(ctx, host+"/content:labeled", Anonymous)
+	require.ErrorAs(t, err, &lookupErr)
+	assert.Equal(t, 2*minRetryDelay, lookupErr.RetryAfter)
+	assert.Equal(t, maxRetryDelay, retryDelay(100))
+
+	down.Store(false)
+	now = now.Add(2 * minRetryDelay)
+	versions, err := d.Discover(ctx, host+"/content:labeled", Anonymous)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"4.4.1"}, versions.R)
+}
+
+func TestDiscoverer_Index(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(registrytest.New())
+	defer srv.Close()
+	host := registrytest.Host(srv)
+
+	amd64 := registrytest.Image(t, map[string]string{RVersionsLabel: "4.4.1"})
+	arm64 := registrytest.Image(t, map[string]string{RVersionsLabel: "4.3.3"})
+	registrytest.PushImage(t, host, "session", "amd64", amd64)
+	registrytest.PushImage(t, host, "session", "arm64", arm64)
+	index := mutate.AppendManifests(empty.Index,
+		mutate.IndexAddendum{Add: arm64, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64"}}},
+		mutate.IndexAddendum{Add: amd64, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}}},
+	)
+	indexDigest := registrytest.PushIndex(t, host, "session", "multi", index)
+
+	// an index resolves to its linux/amd64 image
+	versions, err := NewDiscoverer(NewClient(srv.Client().Transport)).Discover(ctx, host+"/session:multi", Anonymous)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"4.4.1"}, versions.R)
+	assert.Equal(t, indexDigest.String(), versions.Digest)
+}
+
+func TestDiscoverer_Authentication(t *testing.T) {
+	ctx := context.Background()
+	reg := registrytest.New()
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	var tokenRequests atomic.Int32
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests.Add(1)
+		if u, p, ok := r.BasicAuth(); !ok || u != "robot" || p != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "repository:private:pull", r.URL.Query().Get("scope"))
+		assert.Equal(t, "test-registry", r.URL.Query().Get("service"))
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "pull-token"})
+	})
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost && r.Method != http.MethodPut && r.Header.Get("Authorization") != "Bearer pull-token" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test-registry"`, srv.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		reg.ServeHTTP(w, r)
+	})
+	srv = httptest.NewServer(mux)
+	defer srv.Close()
+	host := registrytest.Host(srv)
+
+	registrytest.PushImage(t, host, "private", "latest", registrytest.Image(t, map[string]string{PythonVersionsLabel: "3.11.9"}))
+	registrytest.PushImage(t, host, "private", "next", registrytest.Image(t, map[string]string{PythonVersionsLabel: "3.12.4"}))
+
+	client := NewClient(srv.Client().Transport)
+	_, err := NewDiscoverer(client).Discover(ctx, host+"/private:latest", Anonymous)
+	assert.ErrorContains(t, err, "401")
+
+	auth, err := json.Marshal(map[string]any{"auths": map[string]any{
+		"http://" + host: map[string]string{"auth": "cm9ib3Q6c2VjcmV0"}, // robot:secret
+	}})
+	require.NoError(t, err)
+	secret := corev1.Secret{Type: corev1.SecretTypeDockerConfigJson, Data: map[string][]byte{corev1.DockerConfigJsonKey: auth}}
+	keychain, err := NewSecretKeychain(corev1.Secret{Type: corev1.SecretTypeOpaque}, secret)
+	require.NoError(t, err)
+
+	tokenRequests.Store(0)
+	d := NewDiscoverer(client)
+	versions, err := d.Discover(ctx, host+"/private:latest", keychain)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3.11.9"}, versions.Python)
+
+	// the lookups with the same credentials share their token
+	same, err := NewSecretKeychain(secret)
+	require.NoError(t, err)
+	versions, err = d.Discover(ctx, host+"/private:next", same)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3.12.4"}, versions.Python)
+	assert.EqualValues(t, 1, tokenRequests.Load())
+}
+
+func TestSecretKeychain(t *testing.T) {
+	keychain, err := NewSecretKeychain(
+		corev1.Secret{Type: corev1.SecretTypeDockerConfigJson, Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths":{"https://index.docker.io/v1/":{"username":"first","password":"one"},"ghcr.io":{"username":"gh","password":"two"}}}`),
+		}},
+		corev1.Secret{Type: corev1.SecretTypeDockercfg, Data: map[string][]byte{
+			corev1.DockerConfigKey: []byte(`{"docker.io":{"username":"second","password":"three"},"quay.io":{"username":"quay","password":"four"}}`),
+		}},
+	)
+	require.NoError(t, err)
+
+	resolve := func(registry string) *authn.AuthConfig {
+		reg, err := name.NewRegistry(registry)
+		require.NoError(t, err)
+		auth, err := keychain.Resolve(reg)
+		require.NoError(t, err)
+		cfg, err := auth.Authorization()
+		require.NoError(t, err)
+		return cfg
+	}
+
+	// the first secret with credentials for a registry wins
+	assert.Equal(t, &authn.AuthConfig{Username: "first", Password: "one"}, resolve("docker.io"))
+	assert.Equal(t, &authn.AuthConfig{Username: "gh", Password: "two"}, resolve("ghcr.io"))
+	assert.Equal(t, &authn.AuthConfig{Username: "quay", Password: "four"}, resolve("quay.io"))
+	assert.Equal(t, &authn.AuthConfig{}, resolve("example.com"))
+	assert.NotEmpty(t, keychain.key)
+
+	// a keychain without credentials is anonymous
+	anonymous, err := NewSecretKeychain(corev1.Secret{Type: corev1.SecretTypeOpaque})
+	require.NoError(t, err)
+	assert.Equal(t, Anonymous, anonymous)
+
+	_, err = NewSecretKeychain(corev1.Secret{Type: corev1.SecretTypeDockerConfigJson, Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte("{")}})
+	assert.Error(t, err)
+}
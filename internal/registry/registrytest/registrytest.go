@@ -0,0 +1,90 @@
+// Package registrytest serves an in-process registry and pushes images to it for tests
+package registrytest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/require"
+)
+
+// New returns an in-process registry that does not log its requests
+func New() http.Handler {
+	return registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+}
+
+// Host is the host of srv, as image references name it
+func Host(srv *httptest.Server) string {
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+// Image returns a linux/amd64 image without layers whose config has labels
+func Image(t *testing.T, labels map[string]string) v1.Image {
+	cfg, err := empty.Image.ConfigFile()
+	require.NoError(t, err)
+	cfg = cfg.DeepCopy()
+	cfg.OS, cfg.Architecture = "linux", "amd64"
+	cfg.Config.Labels = labels
+	img, err := mutate.ConfigFile(empty.Image, cfg)
+	require.NoError(t, err)
+	return img
+}
+
+// PushImage uploads the config and the manifest of img to repo:ref of the registry at host and returns the digest
+// of the manifest
+func PushImage(t *testing.T, host, repo, ref string, img v1.Image) v1.Hash {
+	cfg, err := img.RawConfigFile()
+	require.NoError(t, err)
+	digest, _, err := v1.SHA256(bytes.NewReader(cfg))
+	require.NoError(t, err)
+	do(t, http.MethodPost, fmt.Sprintf("http://%s/v2/%s/blobs/uploads/?digest=%s", host, repo, digest), "", cfg)
+
+	return pushManifest(t, host, repo, ref, img)
+}
+
+// PushIndex uploads the manifest of index to repo:ref of the registry at host and returns its digest. The images of
+// the index must have been pushed
+func PushIndex(t *testing.T, host, repo, ref string, index v1.ImageIndex) v1.Hash {
+	return pushManifest(t, host, repo, ref, index)
+}
+
+type manifest interface {
+	RawManifest() ([]byte, error)
+	MediaType() (types.MediaType, error)
+	Digest() (v1.Hash, error)
+}
+
+func pushManifest(t *testing.T, host, repo, ref string, m manifest) v1.Hash {
+	raw, err := m.RawManifest()
+	require.NoError(t, err)
+	mediaType, err := m.MediaType()
+	require.NoError(t, err)
+	do(t, http.MethodPut, fmt.Sprintf("http://%s/v2/%s/manifests/%s", host, repo, ref), string(mediaType), raw)
+
+	digest, err := m.Digest()
+	require.NoError(t, err)
+	return digest
+}
+
+func do(t *testing.T, method, url, contentType string, body []byte) {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "%s %s", method, url)
+}
@@ -3,25 +3,26 @@ package internal
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"slices"
 
 	"github.com/go-logr/logr"
-	"github.com/posit-dev/team-operator/api/product"
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/rstudio/goex/ptr"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const TraefikMiddlewaresKey = "traefik.ingress.kubernetes.io/router.middlewares"
-
-// TraefikOwner combines KubernetesOwnerProvider (for labels) with client.Object (for owner references).
-// This allows passing a single object that satisfies both requirements.
-type TraefikOwner interface {
-	product.KubernetesOwnerProvider
-	client.Object
-}
+const TraefikServersTransportKey = "traefik.ingress.kubernetes.io/service.serverstransport"
 
 func BuildTraefikMiddlewareAnnotation(ns string, middlewareNames ...string) string {
 	output := ""
@@ -34,31 +35,17 @@ func BuildTraefikMiddlewareAnnotation(ns string, middlewareNames ...string) stri
 	return output
 }
 
-func DeployTraefikForwardMiddleware(ctx context.Context, req ctrl.Request, c client.Client, scheme *runtime.Scheme, l logr.Logger, name string, owner TraefikOwner) error {
+func DeployTraefikForwardMiddleware(ctx context.Context, req ctrl.Request, c client.Client, scheme *runtime.Scheme, l logr.Logger, name string, owner RouteOwner) error {
 	l = l.WithValues(
 		"function", "DeployTraefikForwardMiddleware",
 	)
 
-	middleware := &v1alpha1.Middleware{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: req.Namespace,
-		},
-	}
 	l.Info("CREATING Forward traefik middleware...")
-	_, err := CreateOrUpdateResource(ctx, c, scheme, l, middleware, owner, func() error {
-		middleware.Labels = owner.KubernetesLabels()
-		middleware.Spec = v1alpha1.MiddlewareSpec{
-			Headers: &dynamic.Headers{
-				CustomRequestHeaders: map[string]string{
-					"X-Forwarded-Port":  "443",
-					"X-Forwarded-Proto": "https",
-				},
-			},
-		}
-		return nil
-	})
-	if err != nil {
+	if err := deployTraefikMiddleware(ctx, req, c, scheme, l, name, owner, v1alpha1.MiddlewareSpec{
+		Headers: &dynamic.Headers{
+			CustomRequestHeaders: ForwardedRequestHeaders(),
+		},
+	}); err != nil {
 		l.Error(err, "Error creating or updating Forward Middleware")
 		return err
 	}
@@ -67,47 +54,332 @@ func DeployTraefikForwardMiddleware(ctx context.Context, req ctrl.Request, c cli
 	return nil
 }
 
-func DeployTraefikForwardMiddlewareWithHost(ctx context.Context, req ctrl.Request, c client.Client, scheme *runtime.Scheme, l logr.Logger, name string, owner TraefikOwner, host string) error {
+func DeployTraefikForwardMiddlewareWithHost(ctx context.Context, req ctrl.Request, c client.Client, scheme *runtime.Scheme, l logr.Logger, name string, owner RouteOwner, host string) error {
 	l = l.WithValues(
 		"function", "DeployTraefikForwardMiddlewareWithHost",
 	)
 
+	l.Info("CREATING Forward traefik middleware...")
+	if err := deployTraefikMiddleware(ctx, req, c, scheme, l, name, owner, v1alpha1.MiddlewareSpec{
+		Headers: &dynamic.Headers{
+			CustomRequestHeaders: ForwardedRequestHeadersWithHost(host),
+		},
+	}); err != nil {
+		l.Error(err, "Error creating or updating Forward Middleware")
+		return err
+	}
+	l.Info("DONE creating Forward traefik middleware...?")
+
+	return nil
+}
+
+func deployTraefikMiddleware(ctx context.Context, req ctrl.Request, c client.Client, scheme *runtime.Scheme, l logr.Logger, name string, owner RouteOwner, spec v1alpha1.MiddlewareSpec) error {
 	middleware := &v1alpha1.Middleware{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: req.Namespace,
 		},
 	}
-	l.Info("CREATING Forward traefik middleware...")
 	_, err := CreateOrUpdateResource(ctx, c, scheme, l, middleware, owner, func() error {
 		middleware.Labels = owner.KubernetesLabels()
-		middleware.Spec = v1alpha1.MiddlewareSpec{
+		middleware.Spec = spec
+		return nil
+	})
+	return err
+}
+
+// TraefikIngressProvider configures Ingresses for Traefik using Middlewares, ServersTransports and sticky
+// cookie annotations on the Service
+type TraefikIngressProvider struct{}
+
+func TraefikForwardMiddlewareName(name string) string {
+	return fmt.Sprintf("%s-forward", name)
+}
+
+func TraefikCspMiddlewareName(name string) string {
+	return fmt.Sprintf("%s-csp", name)
+}
+
+func TraefikHeadersMiddlewareName(name string) string {
+	return fmt.Sprintf("%s-headers", name)
+}
+
+func TraefikPrefixMiddlewareName(name string) string {
+	return fmt.Sprintf("%s-prefix", name)
+}
+
+func TraefikRateLimitMiddlewareName(name string) string {
+	return fmt.Sprintf("%s-ratelimit", name)
+}
+
+func TraefikIPAllowListMiddlewareName(name string) string {
+	return fmt.Sprintf("%s-ipallowlist", name)
+}
+
+func TraefikBufferingMiddlewareName(name string) string {
+	return fmt.Sprintf("%s-buffering", name)
+}
+
+func TraefikRetryMiddlewareName(name string) string {
+	return fmt.Sprintf("%s-retry", name)
+}
+
+func TraefikServersTransportName(name string) string {
+	return fmt.Sprintf("%s-transport", name)
+}
+
+// traefikObjects returns every Middleware and the ServersTransport that TraefikIngressProvider may create for the
+// Ingress named name
+func traefikObjects(namespace, name string) []client.Object {
+	var objects []client.Object
+	for _, n := range []string{
+		TraefikForwardMiddlewareName(name),
+		TraefikCspMiddlewareName(name),
+		TraefikHeadersMiddlewareName(name),
+		TraefikPrefixMiddlewareName(name),
+		TraefikRateLimitMiddlewareName(name),
+		TraefikIPAllowListMiddlewareName(name),
+		TraefikBufferingMiddlewareName(name),
+		TraefikRetryMiddlewareName(name),
+	} {
+		objects = append(objects, &v1alpha1.Middleware{ObjectMeta: metav1.ObjectMeta{Name: n, Namespace: namespace}})
+	}
+	return append(objects, &v1alpha1.ServersTransport{ObjectMeta: metav1.ObjectMeta{Name: TraefikServersTransportName(name), Namespace: namespace}})
+}
+
+// CleanupTraefikResources deletes the Middlewares and ServersTransport of the Ingress named name, except those named
+// in keep, i.e. after switching to another provider. Clusters without the Traefik CRDs installed have nothing to
+// clean up, so a missing kind is not an error.
+func CleanupTraefikResources(ctx context.Context, c client.Client, l logr.Logger, namespace, name string, keep ...string) error {
+	for _, obj := range traefikObjects(namespace, name) {
+		if slices.Contains(keep, obj.GetName()) {
+			continue
+		}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if meta.IsNoMatchError(err) {
+				return nil
+			}
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if obj.GetLabels()[v1beta1.ManagedByLabelKey] != v1beta1.ManagedByLabelValue {
+			continue
+		}
+		l.Info("deleting unused traefik object", "kind", reflect.TypeOf(obj).Elem().Name(), "name", obj.GetName())
+		if err := c.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			l.Error(err, "error deleting unused traefik object", "name", obj.GetName())
+			return err
+		}
+	}
+	return nil
+}
+
+func (TraefikIngressProvider) middlewareNames(name string, opts IngressOptions) []string {
+	var names []string
+	// reject unwanted requests before doing any other work
+	if p := opts.Policy; p != nil {
+		if p.IPAllowList != nil {
+			names = append(names, TraefikIPAllowListMiddlewareName(name))
+		}
+		if p.RateLimit != nil {
+			names = append(names, TraefikRateLimitMiddlewareName(name))
+		}
+		if p.MaxBodySize != nil {
+			names = append(names, TraefikBufferingMiddlewareName(name))
+		}
+	}
+	if opts.stripsPathPrefix() {
+		names = append(names, TraefikPrefixMiddlewareName(name))
+	}
+	if opts.ForwardHeaders || opts.ForwardedHost != "" {
+		names = append(names, TraefikForwardMiddlewareName(name))
+	}
+	if opts.ContentSecurityPolicy != "" {
+		names = append(names, TraefikCspMiddlewareName(name))
+	}
+	if len(opts.RequestHeaders) > 0 {
+		names = append(names, TraefikHeadersMiddlewareName(name))
+	}
+	if opts.Policy != nil && opts.Policy.Retry != nil {
+		names = append(names, TraefikRetryMiddlewareName(name))
+	}
+	return names
+}
+
+func (p TraefikIngressProvider) DeployResources(ctx context.Context, req ctrl.Request, c client.Client, scheme *runtime.Scheme, l logr.Logger, name string, owner RouteOwner, opts IngressOptions) error {
+	if opts.ForwardedHost != "" {
+		if err := DeployTraefikForwardMiddlewareWithHost(ctx, req, c, scheme, l, TraefikForwardMiddlewareName(name), owner, opts.ForwardedHost); err != nil {
+			return err
+		}
+	} else if opts.ForwardHeaders {
+		if err := DeployTraefikForwardMiddleware(ctx, req, c, scheme, l, TraefikForwardMiddlewareName(name), owner); err != nil {
+			return err
+		}
+	}
+
+	if opts.stripsPathPrefix() {
+		// StripPrefix also sets X-Forwarded-Prefix, so the product can still build its external URLs
+		l.Info("CREATING PREFIX traefik middleware...")
+		if err := deployTraefikMiddleware(ctx, req, c, scheme, l, TraefikPrefixMiddlewareName(name), owner, v1alpha1.MiddlewareSpec{
+			StripPrefix: &dynamic.StripPrefix{
+				Prefixes: []string{opts.PathPrefix},
+			},
+		}); err != nil {
+			l.Error(err, "Error creating or updating PREFIX Middleware")
+			return err
+		}
+	}
+
+	if opts.ContentSecurityPolicy != "" {
+		l.Info("CREATING CSP traefik middleware...")
+		if err := deployTraefikMiddleware(ctx, req, c, scheme, l, TraefikCspMiddlewareName(name), owner, v1alpha1.MiddlewareSpec{
+			Headers: &dynamic.Headers{
+				ContentSecurityPolicy: opts.ContentSecurityPolicy,
+			},
+		}); err != nil {
+			l.Error(err, "Error creating or updating CSP Middleware")
+			return err
+		}
+	}
+
+	if len(opts.RequestHeaders) > 0 {
+		l.Info("CREATING HEADERS traefik middleware...")
+		if err := deployTraefikMiddleware(ctx, req, c, scheme, l, TraefikHeadersMiddlewareName(name), owner, v1alpha1.MiddlewareSpec{
 			Headers: &dynamic.Headers{
-				CustomRequestHeaders: map[string]string{
-					"X-Forwarded-Port":  "443",
-					"X-Forwarded-Proto": "https",
-					"X-Forwarded-Host":  host,
-					"X-Forwarded-For":   fmt.Sprintf("https://%s", host),
+				CustomRequestHeaders: opts.RequestHeaders,
+			},
+		}); err != nil {
+			l.Error(err, "Error creating or updating HEADERS Middleware")
+			return err
+		}
+	}
+
+	if opts.Policy != nil {
+		if err := deployTraefikPolicyMiddlewares(ctx, req, c, scheme, l, name, owner, opts.Policy); err != nil {
+			return err
+		}
+	}
+
+	keep := p.middlewareNames(name, opts)
+	if opts.Timeout != nil {
+		keep = append(keep, TraefikServersTransportName(name))
+		transport := &v1alpha1.ServersTransport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      TraefikServersTransportName(name),
+				Namespace: req.Namespace,
+			},
+		}
+		if _, err := CreateOrUpdateResource(ctx, c, scheme, l, transport, owner, func() error {
+			transport.Labels = owner.KubernetesLabels()
+			transport.Spec = v1alpha1.ServersTransportSpec{
+				ForwardingTimeouts: &v1alpha1.ForwardingTimeouts{
+					ResponseHeaderTimeout: ptr.To(intstr.FromString(fmt.Sprintf("%ds", timeoutSeconds(opts.Timeout)))),
 				},
+			}
+			return nil
+		}); err != nil {
+			l.Error(err, "Error creating or updating ServersTransport")
+			return err
+		}
+	}
+
+	// remove the middlewares of settings (i.e. an IngressPolicy) that were turned off
+	return CleanupTraefikResources(ctx, c, l, req.Namespace, name, keep...)
+}
+
+// deployTraefikPolicyMiddlewares creates the middlewares that enforce an IngressPolicy
+func deployTraefikPolicyMiddlewares(ctx context.Context, req ctrl.Request, c client.Client, scheme *runtime.Scheme, l logr.Logger, name string, owner RouteOwner, policy *v1beta1.IngressPolicy) error {
+	if policy.IPAllowList != nil {
+		l.Info("CREATING IP ALLOW LIST traefik middleware...")
+		spec := &dynamic.IPAllowList{
+			SourceRange: policy.IPAllowList.SourceRanges,
+		}
+		if policy.IPAllowList.Depth > 0 {
+			spec.IPStrategy = &dynamic.IPStrategy{Depth: policy.IPAllowList.Depth}
+		}
+		if err := deployTraefikMiddleware(ctx, req, c, scheme, l, TraefikIPAllowListMiddlewareName(name), owner, v1alpha1.MiddlewareSpec{
+			IPAllowList: spec,
+		}); err != nil {
+			l.Error(err, "Error creating or updating IP ALLOW LIST Middleware")
+			return err
+		}
+	}
+
+	if policy.RateLimit != nil {
+		l.Info("CREATING RATE LIMIT traefik middleware...")
+		spec := &v1alpha1.RateLimit{
+			Average: ptr.To(policy.RateLimit.Average),
+		}
+		if policy.RateLimit.Burst > 0 {
+			spec.Burst = ptr.To(policy.RateLimit.Burst)
+		}
+		if policy.RateLimit.Period != nil {
+			spec.Period = ptr.To(intstr.FromString(policy.RateLimit.Period.Duration.String()))
+		}
+		if err := deployTraefikMiddleware(ctx, req, c, scheme, l, TraefikRateLimitMiddlewareName(name), owner, v1alpha1.MiddlewareSpec{
+			RateLimit: spec,
+		}); err != nil {
+			l.Error(err, "Error creating or updating RATE LIMIT Middleware")
+			return err
+		}
+	}
+
+	if policy.MaxBodySize != nil {
+		l.Info("CREATING BUFFERING traefik middleware...")
+		if err := deployTraefikMiddleware(ctx, req, c, scheme, l, TraefikBufferingMiddlewareName(name), owner, v1alpha1.MiddlewareSpec{
+			Buffering: &dynamic.Buffering{
+				MaxRequestBodyBytes: policy.MaxBodySize.Value(),
 			},
+		}); err != nil {
+			l.Error(err, "Error creating or updating BUFFERING Middleware")
+			return err
+		}
+	}
+
+	if policy.Retry != nil {
+		l.Info("CREATING RETRY traefik middleware...")
+		spec := &v1alpha1.Retry{
+			Attempts: policy.Retry.Attempts,
+		}
+		if policy.Retry.InitialInterval != nil {
+			spec.InitialInterval = intstr.FromString(policy.Retry.InitialInterval.Duration.String())
+		}
+		if err := deployTraefikMiddleware(ctx, req, c, scheme, l, TraefikRetryMiddlewareName(name), owner, v1alpha1.MiddlewareSpec{
+			Retry: spec,
+		}); err != nil {
+			l.Error(err, "Error creating or updating RETRY Middleware")
+			return err
 		}
-		return nil
-	})
-	if err != nil {
-		l.Error(err, "Error creating or updating Forward Middleware")
-		return err
 	}
-	l.Info("DONE creating Forward traefik middleware...?")
 
 	return nil
 }
 
-func TraefikStickyServiceAnnotations(p product.Product) map[string]string {
-	return map[string]string{
-		"traefik.ingress.kubernetes.io/service.sticky.cookie":          "true",
-		"traefik.ingress.kubernetes.io/service.sticky.cookie.httponly": "true",
-		"traefik.ingress.kubernetes.io/service.sticky.cookie.name":     p.ComponentName(),
-		"traefik.ingress.kubernetes.io/service.sticky.cookie.samesite": "none",
-		"traefik.ingress.kubernetes.io/service.sticky.cookie.secure":   "true",
+func (p TraefikIngressProvider) IngressAnnotations(namespace, name string, opts IngressOptions) map[string]string {
+	annotations := map[string]string{}
+	if middlewares := p.middlewareNames(name, opts); len(middlewares) > 0 {
+		annotations[TraefikMiddlewaresKey] = BuildTraefikMiddlewareAnnotation(namespace, middlewares...)
+	}
+	return annotations
+}
+
+func (TraefikIngressProvider) IngressPath(opts IngressOptions) (string, networkingv1.PathType) {
+	return prefixIngressPath(opts)
+}
+
+func (TraefikIngressProvider) ServiceAnnotations(namespace, name string, opts IngressOptions) map[string]string {
+	annotations := map[string]string{}
+	if opts.StickyCookie != "" {
+		annotations["traefik.ingress.kubernetes.io/service.sticky.cookie"] = "true"
+		annotations["traefik.ingress.kubernetes.io/service.sticky.cookie.httponly"] = "true"
+		annotations["traefik.ingress.kubernetes.io/service.sticky.cookie.name"] = opts.StickyCookie
+		annotations["traefik.ingress.kubernetes.io/service.sticky.cookie.samesite"] = "none"
+		annotations["traefik.ingress.kubernetes.io/service.sticky.cookie.secure"] = "true"
+	}
+	if opts.Timeout != nil {
+		annotations[TraefikServersTransportKey] = fmt.Sprintf("%s-%s@kubernetescrd", namespace, TraefikServersTransportName(name))
 	}
+	return annotations
 }
@@ -1,9 +1,23 @@
 package internal
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/posit-dev/team-operator/api/core/v1beta1"
+	"github.com/posit-dev/team-operator/api/localtest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestTraefikConstant(t *testing.T) {
@@ -16,3 +30,115 @@ func TestBuildTraefikMiddlewareAnnotation(t *testing.T) {
 	res := BuildTraefikMiddlewareAnnotation("ns", "middleware1", "middleware2")
 	assert.Equal(t, "ns-middleware1@kubernetescrd,ns-middleware2@kubernetescrd", res)
 }
+
+func TestTraefikIngressProviderDeployPolicy(t *testing.T) {
+	fakeEnv := localtest.FakeTestEnv{}
+	cli, scheme, log := fakeEnv.Start(func(scheme *runtime.Scheme) {
+		utilruntime.Must(v1beta1.AddToScheme(scheme))
+		utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "posit-team", Name: "site"}}
+	owner := &v1beta1.Connect{ObjectMeta: metav1.ObjectMeta{Name: "site", Namespace: "posit-team", UID: "connect-uid"}}
+
+	maxBodySize := resource.MustParse("10Mi")
+	opts := IngressOptions{
+		Policy: &v1beta1.IngressPolicy{
+			RateLimit:   &v1beta1.IngressRateLimit{Average: 100, Burst: 200, Period: &metav1.Duration{Duration: time.Minute}},
+			IPAllowList: &v1beta1.IngressIPAllowList{SourceRanges: []string{"203.0.113.0/24"}, Depth: 1},
+			MaxBodySize: &maxBodySize,
+			Retry:       &v1beta1.IngressRetry{Attempts: 3, InitialInterval: &metav1.Duration{Duration: 100 * time.Millisecond}},
+		},
+	}
+	require.NoError(t, TraefikIngressProvider{}.DeployResources(context.TODO(), req, cli, scheme, log, "site-connect", owner, opts))
+
+	get := func(name string) *v1alpha1.Middleware {
+		middleware := &v1alpha1.Middleware{}
+		require.NoError(t, cli.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: "posit-team"}, middleware))
+		return middleware
+	}
+
+	rateLimit := get(TraefikRateLimitMiddlewareName("site-connect")).Spec.RateLimit
+	require.NotNil(t, rateLimit)
+	assert.Equal(t, int64(100), *rateLimit.Average)
+	assert.Equal(t, int64(200), *rateLimit.Burst)
+	assert.Equal(t, "1m0s", rateLimit.Period.String())
+
+	allowList := get(TraefikIPAllowListMiddlewareName("site-connect")).Spec.IPAllowList
+	require.NotNil(t, allowList)
+	assert.Equal(t, []string{"203.0.113.0/24"}, allowList.SourceRange)
+	assert.Equal(t, 1, allowList.IPStrategy.Depth)
+
+	buffering := get(TraefikBufferingMiddlewareName("site-connect")).Spec.Buffering
+	require.NotNil(t, buffering)
+	assert.Equal(t, int64(10*1024*1024), buffering.MaxRequestBodyBytes)
+
+	retry := get(TraefikRetryMiddlewareName("site-connect")).Spec.Retry
+	require.NotNil(t, retry)
+	assert.Equal(t, 3, retry.Attempts)
+	assert.Equal(t, "100ms", retry.InitialInterval.String())
+}
+
+func TestCleanupTraefikResources(t *testing.T) {
+	fakeEnv := localtest.FakeTestEnv{}
+	cli, scheme, log := fakeEnv.Start(func(scheme *runtime.Scheme) {
+		utilruntime.Must(v1beta1.AddToScheme(scheme))
+		utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "posit-team", Name: "site"}}
+	owner := &v1beta1.Connect{ObjectMeta: metav1.ObjectMeta{Name: "site", Namespace: "posit-team", UID: "connect-uid"}}
+	key := func(name string) client.ObjectKey {
+		return client.ObjectKey{Name: name, Namespace: "posit-team"}
+	}
+
+	opts := IngressOptions{
+		ForwardHeaders: true,
+		Timeout:        &metav1.Duration{Duration: time.Minute},
+	}
+	require.NoError(t, TraefikIngressProvider{}.DeployResources(context.TODO(), req, cli, scheme, log, "site-connect", owner, opts))
+	require.NoError(t, cli.Get(context.TODO(), key(TraefikForwardMiddlewareName("site-connect")), &v1alpha1.Middleware{}))
+	require.NoError(t, cli.Get(context.TODO(), key(TraefikServersTransportName("site-connect")), &v1alpha1.ServersTransport{}))
+
+	// a middleware that the operator does not manage is left alone
+	require.NoError(t, cli.Create(context.TODO(), &v1alpha1.Middleware{ObjectMeta: metav1.ObjectMeta{Name: TraefikCspMiddlewareName("site-connect"), Namespace: "posit-team"}}))
+
+	// switching to another provider removes the traefik objects
+	require.NoError(t, NginxIngressProvider{}.DeployResources(context.TODO(), req, cli, scheme, log, "site-connect", owner, opts))
+	assert.True(t, apierrors.IsNotFound(cli.Get(context.TODO(), key(TraefikForwardMiddlewareName("site-connect")), &v1alpha1.Middleware{})))
+	assert.True(t, apierrors.IsNotFound(cli.Get(context.TODO(), key(TraefikServersTransportName("site-connect")), &v1alpha1.ServersTransport{})))
+	assert.NoError(t, cli.Get(context.TODO(), key(TraefikCspMiddlewareName("site-connect")), &v1alpha1.Middleware{}))
+}
+
+func TestTraefikIngressProviderRemovesPolicy(t *testing.T) {
+	fakeEnv := localtest.FakeTestEnv{}
+	cli, scheme, log := fakeEnv.Start(func(scheme *runtime.Scheme) {
+		utilruntime.Must(v1beta1.AddToScheme(scheme))
+		utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	})
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "posit-team", Name: "site"}}
+	owner := &v1beta1.Connect{ObjectMeta: metav1.ObjectMeta{Name: "site", Namespace: "posit-team", UID: "connect-uid"}}
+	key := func(name string) client.ObjectKey {
+		return client.ObjectKey{Name: name, Namespace: "posit-team"}
+	}
+
+	opts := IngressOptions{
+		ForwardHeaders: true,
+		Policy: &v1beta1.IngressPolicy{
+			RateLimit:   &v1beta1.IngressRateLimit{Average: 100},
+			IPAllowList: &v1beta1.IngressIPAllowList{SourceRanges: []string{"203.0.113.0/24"}},
+		},
+	}
+	require.NoError(t, TraefikIngressProvider{}.DeployResources(context.TODO(), req, cli, scheme, log, "site-connect", owner, opts))
+	require.NoError(t, cli.Get(context.TODO(), key(TraefikRateLimitMiddlewareName("site-connect")), &v1alpha1.Middleware{}))
+	require.NoError(t, cli.Get(context.TODO(), key(TraefikIPAllowListMiddlewareName("site-connect")), &v1alpha1.Middleware{}))
+
+	// dropping a setting removes its middleware, and removing the policy removes the rest
+	opts.Policy.RateLimit = nil
+	require.NoError(t, TraefikIngressProvider{}.DeployResources(context.TODO(), req, cli, scheme, log, "site-connect", owner, opts))
+	assert.True(t, apierrors.IsNotFound(cli.Get(context.TODO(), key(TraefikRateLimitMiddlewareName("site-connect")), &v1alpha1.Middleware{})))
+	require.NoError(t, cli.Get(context.TODO(), key(TraefikIPAllowListMiddlewareName("site-connect")), &v1alpha1.Middleware{}))
+
+	opts.Policy = nil
+	require.NoError(t, TraefikIngressProvider{}.DeployResources(context.TODO(), req, cli, scheme, log, "site-connect", owner, opts))
+	assert.True(t, apierrors.IsNotFound(cli.Get(context.TODO(), key(TraefikIPAllowListMiddlewareName("site-connect")), &v1alpha1.Middleware{})))
+	assert.NoError(t, cli.Get(context.TODO(), key(TraefikForwardMiddlewareName("site-connect")), &v1alpha1.Middleware{}))
+}